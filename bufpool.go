@@ -0,0 +1,72 @@
+package gopresentation
+
+import (
+	"image"
+	"math/bits"
+	"sync"
+)
+
+// Temporary images (rotated shapes, group and fade layers, shadows) are
+// drawn into pixel buffers recycled through pools, one per power-of-two
+// size class, so that decks with many such shapes do not allocate a fresh
+// buffer for each one.
+const (
+	minPooledBufShift = 12 // 4 KiB
+	maxPooledBufShift = 26 // 64 MiB; larger buffers are not pooled
+)
+
+var rgbaBufPools [maxPooledBufShift - minPooledBufShift + 1]sync.Pool
+
+// rgbaBufClass returns the pool index for a buffer of n bytes, or -1 if
+// buffers of that size are not pooled.
+func rgbaBufClass(n int) int {
+	shift := bits.Len(uint(n - 1))
+	if shift < minPooledBufShift {
+		shift = minPooledBufShift
+	}
+	if shift > maxPooledBufShift {
+		return -1
+	}
+	return shift - minPooledBufShift
+}
+
+// getRGBA returns a transparent image with bounds rect, reusing a pooled
+// buffer when one is available. Pass it to putRGBA once it is no longer
+// referenced.
+func getRGBA(rect image.Rectangle) *image.RGBA {
+	w, h := rect.Dx(), rect.Dy()
+	if w <= 0 || h <= 0 {
+		return image.NewRGBA(rect)
+	}
+	n := w * h * 4
+	class := rgbaBufClass(n)
+	if class < 0 {
+		return image.NewRGBA(rect)
+	}
+	var pix []byte
+	if v, ok := rgbaBufPools[class].Get().(*[]byte); ok {
+		pix = (*v)[:n]
+		clear(pix)
+	} else {
+		pix = make([]byte, n, 1<<(class+minPooledBufShift))
+	}
+	return &image.RGBA{Pix: pix, Stride: w * 4, Rect: rect}
+}
+
+// putRGBA returns the buffer of an image from getRGBA to its pool.
+func putRGBA(img *image.RGBA) {
+	if img == nil {
+		return
+	}
+	c := cap(img.Pix)
+	if c == 0 || c&(c-1) != 0 {
+		return // not from getRGBA
+	}
+	class := rgbaBufClass(c)
+	if class < 0 || 1<<(class+minPooledBufShift) != c {
+		return
+	}
+	pix := img.Pix[:0]
+	img.Pix = nil
+	rgbaBufPools[class].Put(&pix)
+}
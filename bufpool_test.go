@@ -0,0 +1,36 @@
+package gopresentation
+
+import (
+	"image"
+	"testing"
+)
+
+// BenchmarkGetPutRGBA measures a pooled buffer round trip, which should not
+// allocate pixels once the pool is warm.
+func BenchmarkGetPutRGBA(b *testing.B) {
+	rect := image.Rect(0, 0, 400, 300)
+	b.ReportAllocs()
+	for b.Loop() {
+		putRGBA(getRGBA(rect))
+	}
+}
+
+// BenchmarkRenderRotatedShapes renders a slide of rotated shapes, each of
+// which is drawn into a pooled temporary buffer.
+func BenchmarkRenderRotatedShapes(b *testing.B) {
+	p := New()
+	slide := p.GetActiveSlide()
+	for i := 0; i < 20; i++ {
+		s := slide.CreateAutoShape()
+		s.SetOffsetX(int64(i) * 400000).SetOffsetY(int64(i) * 300000).
+			SetWidth(1500000).SetHeight(1000000).SetRotation(15 * i)
+		s.SetFill(NewFill().SetSolid(NewColor("FF4472C4")))
+	}
+	opts := DefaultRenderOptions()
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := p.SlideToImage(0, opts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package gopresentation
 
-import "sort"
+import (
+	"fmt"
+	"image/color"
+	"sort"
+)
 
 // ChartShape represents a chart embedded in a slide.
 type ChartShape struct {
@@ -10,6 +14,12 @@ type ChartShape struct {
 	legend      *ChartLegend
 	view3D      *View3D
 	displayBlankAs string
+
+	// preview is the picture PowerPoint cached of a chart read from a
+	// file, drawn instead of the chart while previewOf, the digest of the
+	// chart when read, still matches.
+	preview   *DrawingShape
+	previewOf string
 }
 
 // Chart display blank constants.
@@ -32,6 +42,23 @@ func NewChartShape() *ChartShape {
 	}
 }
 
+// setPreview keeps p as the picture of the chart as it is now.
+func (c *ChartShape) setPreview(p *DrawingShape) {
+	c.preview = p
+	if p != nil {
+		c.previewOf = chartDigest(c)
+	}
+}
+
+// currentPreview returns the cached preview picture when the chart still
+// shows what it did when read, or nil.
+func (c *ChartShape) currentPreview() *DrawingShape {
+	if c.preview == nil || chartDigest(c) != c.previewOf {
+		return nil
+	}
+	return c.preview
+}
+
 // GetTitle returns the chart title.
 func (c *ChartShape) GetTitle() *ChartTitle { return c.title }
 
@@ -50,11 +77,87 @@ func (c *ChartShape) SetDisplayBlankAs(mode string) { c.displayBlankAs = mode }
 // GetDisplayBlankAs returns how blank values are displayed.
 func (c *ChartShape) GetDisplayBlankAs() string { return c.displayBlankAs }
 
+// Type returns the chart type name, such as "bar", "line" or "pie", or ""
+// if no chart type has been set.
+func (c *ChartShape) Type() string {
+	if c.plotArea == nil || c.plotArea.chartType == nil {
+		return ""
+	}
+	return c.plotArea.chartType.GetChartTypeName()
+}
+
+// Title returns the chart title text, or "" if the title is hidden.
+func (c *ChartShape) Title() string {
+	if c.title == nil || !c.title.Visible {
+		return ""
+	}
+	return c.title.Text
+}
+
+// ChartSeriesData is a read-only snapshot of one chart series.
+type ChartSeriesData struct {
+	Name       string
+	Categories []string
+	Values     []float64 // parallel to Categories; missing values are 0
+	// Color is the series color as rendered: the series fill color, or the
+	// default palette color for the series index.
+	Color Color
+	// PointColors holds one color per category for charts that vary colors
+	// by point (pie, pie3D and doughnut); nil otherwise.
+	PointColors []Color
+}
+
+// Series returns the chart's series data in series order, so applications
+// can extract the numbers without rendering the chart.
+func (c *ChartShape) Series() []ChartSeriesData {
+	if c.plotArea == nil {
+		return nil
+	}
+	series := getChartSeries(c.plotArea.chartType)
+	if len(series) == 0 {
+		return nil
+	}
+	varyColors := false
+	switch c.plotArea.chartType.(type) {
+	case *PieChart, *Pie3DChart, *DoughnutChart:
+		varyColors = true
+	}
+	out := make([]ChartSeriesData, 0, len(series))
+	for i, s := range series {
+		if s == nil {
+			continue
+		}
+		d := ChartSeriesData{
+			Name:       s.Title,
+			Categories: append([]string(nil), s.Categories...),
+			Values:     make([]float64, len(s.Categories)),
+			Color:      paletteColor(getSeriesColor(s, i, defaultChartPalette)),
+		}
+		for j, cat := range s.Categories {
+			d.Values[j] = s.Values[cat]
+		}
+		if varyColors {
+			d.PointColors = make([]Color, len(s.Categories))
+			for j := range s.Categories {
+				d.PointColors[j] = paletteColor(defaultChartPalette[j%len(defaultChartPalette)])
+			}
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+// paletteColor converts a rendered RGBA color to a Color.
+func paletteColor(c color.RGBA) Color {
+	return Color{ARGB: fmt.Sprintf("%02X%02X%02X%02X", c.A, c.R, c.G, c.B)}
+}
+
 // ChartTitle represents a chart title.
 type ChartTitle struct {
 	Text    string
-	Visible bool
+	Visible bool // false writes c:autoTitleDeleted
 	Font    *Font
+	Overlay bool // drawn over the plot area instead of above it (c:overlay)
 }
 
 // NewChartTitle creates a new chart title.
@@ -77,6 +180,13 @@ func (ct *ChartTitle) SetVisible(v bool) *ChartTitle {
 	return ct
 }
 
+// SetOverlay sets whether the title overlays the plot area rather than
+// taking space above it.
+func (ct *ChartTitle) SetOverlay(v bool) *ChartTitle {
+	ct.Overlay = v
+	return ct
+}
+
 // PlotArea represents the chart plot area.
 type PlotArea struct {
 	chartType ChartType
@@ -123,6 +233,9 @@ type ChartAxis struct {
 	TickLabelPos   string
 	OutlineWidth   int
 	OutlineColor   Color
+	NumberFormat   string // c:numFmt format code for tick labels, e.g. "0%"
+	LabelRotation  int    // tick label rotation in degrees (c:txPr bodyPr rot), -90 to 90
+	TickLabelSkip  int    // label every nth category (c:tickLblSkip); 0 chooses automatically
 }
 
 // Axis crossing constants.
@@ -255,6 +368,26 @@ func (a *ChartAxis) SetTickLabelPosition(v string) *ChartAxis {
 	return a
 }
 
+// SetNumberFormat sets the number format code used for tick labels.
+func (a *ChartAxis) SetNumberFormat(code string) *ChartAxis {
+	a.NumberFormat = code
+	return a
+}
+
+// SetLabelRotation sets the tick label rotation in degrees. Negative
+// angles turn the labels counter-clockwise so that they rise to the right.
+func (a *ChartAxis) SetLabelRotation(deg int) *ChartAxis {
+	a.LabelRotation = deg
+	return a
+}
+
+// SetTickLabelSkip sets how many categories each label covers: n labels
+// every nth category. 0 lets the renderer skip or stagger labels as needed.
+func (a *ChartAxis) SetTickLabelSkip(n int) *ChartAxis {
+	a.TickLabelSkip = n
+	return a
+}
+
 // Gridlines represents chart gridlines.
 type Gridlines struct {
 	Width int
@@ -340,6 +473,7 @@ type ChartSeries struct {
 	ShowValue         bool
 	Separator         string
 	LabelPosition     string
+	NumberFormat      string // c:numFmt format code for data labels
 	Font              *Font
 	Outline           *SeriesOutline
 	Marker            *SeriesMarker
@@ -406,6 +540,12 @@ func (s *ChartSeries) SetLabelPosition(pos string) *ChartSeries {
 	return s
 }
 
+// SetNumberFormat sets the number format code used for data labels.
+func (s *ChartSeries) SetNumberFormat(code string) *ChartSeries {
+	s.NumberFormat = code
+	return s
+}
+
 // SeriesOutline represents a series outline.
 type SeriesOutline struct {
 	Width int
@@ -0,0 +1,257 @@
+// Command gopptrender renders the slides of one or more PPTX files to images.
+//
+// Usage:
+//
+//	gopptrender [flags] input.pptx [more.pptx | "decks/*.pptx" ...]
+//
+// Each input may be a file path or a glob pattern. Output file names are built
+// from the -name pattern, where {deck} is the input file name without its
+// extension, {n} is the 1-based slide number (zero-padded to the slide count)
+// and {ext} is the image format extension. Inputs that would write the same
+// output file, such as a/deck.pptx and b/deck.pptx, are rejected before
+// anything is rendered.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	gopresentation "github.com/VantageDataChat/GoPPT"
+)
+
+type job struct {
+	pres  *gopresentation.Presentation
+	input string
+	index int
+	path  string
+}
+
+func main() {
+	outDir := flag.String("o", ".", "output directory")
+	name := flag.String("name", "{deck}_{n}.{ext}", "output file name pattern ({deck}, {n}, {ext})")
+	width := flag.Int("width", 1920, "output image width in pixels")
+	format := flag.String("format", "png", "output image format: png, jpeg or webp")
+	quality := flag.Int("quality", 90, "JPEG or WebP quality (1-100; WebP is lossless at 100)")
+	chroma := flag.String("chroma", "420", "JPEG chroma subsampling: 420 or 444")
+	colors := flag.Int("colors", 0, "quantize PNG output to at most this many colors (2-256)")
+	slides := flag.String("slides", "", "slide range, e.g. \"1-3,5\" (default: all slides)")
+	jobs := flag.Int("j", runtime.NumCPU(), "number of slides rendered concurrently")
+	colorMode := flag.String("color", "rgba", "output color mode: rgba, gray or bilevel")
+	textOnly := flag.Bool("text-only", false, "render black text on white only, e.g. for OCR")
+	hinting := flag.String("hinting", "full", "glyph hinting: full or vertical")
+	gamma := flag.Float64("text-gamma", 0, "darken anti-aliased text edges (e.g. 1.5; 0 or 1 = off)")
+	stem := flag.Float64("stem-darkening", 0, "widen glyph stems by up to this many pixels (0-1)")
+	gradient := flag.String("gradient", "srgb", "gradient interpolation: srgb, linear or oklab")
+	debug := flag.Bool("debug", false, "overlay shape boxes, names and text line boxes")
+	guides := flag.Bool("guides", false, "overlay the slide view guides")
+	safeAreas := flag.Bool("safe-areas", false, "overlay the action-safe and title-safe areas")
+	fontDirs := flag.String("fonts", "", "additional font directories, separated by "+string(os.PathListSeparator))
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] input.pptx [...]\n", filepath.Base(os.Args[0]))
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	opts := gopresentation.DefaultRenderOptions()
+	opts.Width = *width
+	opts.JPEGQuality = *quality
+	opts.TextOnly = *textOnly
+	opts.PNGPaletteSize = *colors
+	opts.TextGamma = *gamma
+	opts.StemDarkening = *stem
+	opts.DebugOverlay = *debug
+	opts.ShowGuides = *guides
+	opts.ShowSafeAreas = *safeAreas
+	opts.OnWarning = func(w gopresentation.RenderWarning) {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", w)
+	}
+	switch *hinting {
+	case "full":
+	case "vertical":
+		opts.TextHinting = gopresentation.TextHintingVertical
+	default:
+		fatalf("unsupported hinting %q", *hinting)
+	}
+	switch strings.ToLower(*gradient) {
+	case "srgb":
+	case "linear":
+		opts.GradientInterpolation = gopresentation.GradientSpaceLinear
+	case "oklab":
+		opts.GradientInterpolation = gopresentation.GradientSpaceOkLab
+	default:
+		fatalf("unsupported gradient interpolation %q", *gradient)
+	}
+	switch *chroma {
+	case "420":
+	case "444":
+		opts.JPEGSubsampling = gopresentation.JPEGSubsampling444
+	default:
+		fatalf("unsupported chroma subsampling %q", *chroma)
+	}
+	ext := "png"
+	switch strings.ToLower(*format) {
+	case "png":
+	case "jpg", "jpeg":
+		opts.Format = gopresentation.ImageFormatJPEG
+		ext = "jpg"
+	case "webp":
+		opts.Format = gopresentation.ImageFormatWebP
+		opts.WebPQuality = *quality
+		ext = "webp"
+	default:
+		fatalf("unsupported format %q", *format)
+	}
+	switch strings.ToLower(*colorMode) {
+	case "rgba", "color":
+	case "gray", "grey":
+		opts.ColorMode = gopresentation.ColorModeGray
+	case "bilevel", "bw":
+		opts.ColorMode = gopresentation.ColorModeBilevel
+	default:
+		fatalf("unsupported color mode %q", *colorMode)
+	}
+	var dirs []string
+	if *fontDirs != "" {
+		dirs = filepath.SplitList(*fontDirs)
+	}
+	opts.FontCache = gopresentation.NewFontCache(dirs...)
+
+	inputs, err := expandInputs(flag.Args())
+	if err != nil {
+		fatalf("%v", err)
+	}
+
+	var work []job
+	writers := make(map[string]string) // output path → input writing it
+	for _, in := range inputs {
+		pres, err := gopresentation.Open(in)
+		if err != nil {
+			fatalf("open %s: %v", in, err)
+		}
+		n := pres.GetSlideCount()
+		indices, err := parseSlideRange(*slides, n)
+		if err != nil {
+			fatalf("%s: %v", in, err)
+		}
+		deck := strings.TrimSuffix(filepath.Base(in), filepath.Ext(in))
+		pad := len(strconv.Itoa(n))
+		for _, i := range indices {
+			file := strings.NewReplacer(
+				"{deck}", deck,
+				"{n}", fmt.Sprintf("%0*d", pad, i+1),
+				"{ext}", ext,
+			).Replace(*name)
+			out := filepath.Join(*outDir, file)
+			if prev, ok := writers[out]; ok {
+				fatalf("%s and %s both write %s; render them separately or change -o or -name", prev, in, out)
+			}
+			writers[out] = in
+			work = append(work, job{pres: pres, input: in, index: i, path: out})
+		}
+	}
+
+	if *jobs < 1 {
+		*jobs = 1
+	}
+	ch := make(chan job)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	failed := 0
+	for w := 0; w < *jobs; w++ {
+		wopts := *opts
+		wopts.FontCache = opts.FontCache.Worker()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range ch {
+				err := j.pres.SaveSlideAsImage(j.index, j.path, &wopts)
+				mu.Lock()
+				if err != nil {
+					failed++
+					fmt.Fprintf(os.Stderr, "%s: slide %d: %v\n", j.input, j.index+1, err)
+				} else {
+					fmt.Println(j.path)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, j := range work {
+		ch <- j
+	}
+	close(ch)
+	wg.Wait()
+
+	if failed > 0 {
+		fatalf("%d of %d slides failed", failed, len(work))
+	}
+}
+
+// expandInputs resolves glob patterns into a list of file paths.
+func expandInputs(args []string) ([]string, error) {
+	var out []string
+	for _, arg := range args {
+		matches, err := filepath.Glob(arg)
+		if err != nil {
+			return nil, fmt.Errorf("bad pattern %q: %w", arg, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files match %q", arg)
+		}
+		out = append(out, matches...)
+	}
+	return out, nil
+}
+
+// parseSlideRange parses a 1-based range list such as "1-3,5" into 0-based
+// slide indices. An empty spec selects all n slides.
+func parseSlideRange(spec string, n int) ([]int, error) {
+	if strings.TrimSpace(spec) == "" {
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices, nil
+	}
+	var indices []int
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		lo, hi := part, part
+		if i := strings.Index(part, "-"); i >= 0 {
+			lo, hi = part[:i], part[i+1:]
+		}
+		from, err := strconv.Atoi(strings.TrimSpace(lo))
+		if err != nil {
+			return nil, fmt.Errorf("bad slide range %q", part)
+		}
+		to := n
+		if strings.TrimSpace(hi) != "" {
+			if to, err = strconv.Atoi(strings.TrimSpace(hi)); err != nil {
+				return nil, fmt.Errorf("bad slide range %q", part)
+			}
+		}
+		if from < 1 || to > n || from > to {
+			return nil, fmt.Errorf("slide range %q out of bounds (1-%d)", part, n)
+		}
+		for i := from; i <= to; i++ {
+			indices = append(indices, i-1)
+		}
+	}
+	return indices, nil
+}
+
+func fatalf(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "gopptrender: "+format+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,33 @@
+// Command gopptworker is a render worker for renderworker.Supervisor. It
+// reads gob-encoded render jobs from stdin and writes the results to stdout
+// until stdin is closed.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/VantageDataChat/GoPPT/renderworker"
+)
+
+func main() {
+	out := bufio.NewWriter(os.Stdout)
+	err := renderworker.Serve(os.Stdin, flushWriter{out})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gopptworker: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// flushWriter flushes after every write so that each result reaches the
+// supervisor as soon as it is encoded.
+type flushWriter struct{ w *bufio.Writer }
+
+func (f flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if err == nil {
+		err = f.w.Flush()
+	}
+	return n, err
+}
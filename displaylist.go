@@ -0,0 +1,684 @@
+package gopresentation
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"strings"
+)
+
+// DisplayList is a slide interpreted into drawing operations with resolved
+// coordinates and colors, for drawing it with another graphics backend.
+// Coordinates are in output pixels, as in the image SlideToImage renders.
+type DisplayList struct {
+	Width  int
+	Height int
+	// Ops are in painting order, from the background up.
+	Ops []DisplayOp
+}
+
+// DisplayOpKind is what a DisplayOp draws.
+type DisplayOpKind int
+
+const (
+	// DisplayFill fills Path with Color, or with Gradient if set.
+	DisplayFill DisplayOpKind = iota
+	// DisplayStroke strokes Path with Color, LineWidth, Dashes and LineJoin.
+	DisplayStroke
+	// DisplayText draws a run of text on its baseline from Origin.
+	DisplayText
+	// DisplayImage draws Image stretched over Rect.
+	DisplayImage
+)
+
+// DisplayOp is one drawing operation of a DisplayList. Which fields are
+// used depends on Kind.
+type DisplayOp struct {
+	Kind DisplayOpKind
+	// Path outlines a fill or stroke, already in output pixels.
+	Path DisplayPath
+	// EvenOdd fills Path with the even-odd rule instead of nonzero.
+	EvenOdd bool
+	// Color paints a fill, stroke or text. Its alpha includes the
+	// shape's transparency; alpha 0 paints nothing.
+	Color color.RGBA
+	// Gradient paints a fill instead of Color when set.
+	Gradient *DisplayGradient
+
+	// LineWidth is the stroke width in pixels. Dashes holds alternating
+	// dash and gap lengths in pixels; nil strokes a solid line.
+	LineWidth float64
+	Dashes    []float64
+	// LineJoin is how the stroke turns corners; "" leaves it to the
+	// backend. MiterLimit is in line widths; 0 means the default.
+	LineJoin   LineJoin
+	MiterLimit float64
+
+	// Text is a run laid out by the renderer in Font at FontSize pixels.
+	// It starts on its baseline at Origin and spans TextWidth pixels.
+	Text      string
+	Font      *Font
+	FontSize  float64
+	Origin    DisplayPoint
+	TextWidth float64
+	// OutlineWidth strokes the glyphs of the text with OutlineColor when
+	// above 0.
+	OutlineColor color.RGBA
+	OutlineWidth float64
+
+	// Image is drawn stretched over Rect at Opacity, from 0 to 1.
+	// Pixelated asks for it to be enlarged by repeating pixels rather
+	// than smoothly, as for QR codes (DrawingShape.SetPixelated).
+	Image     image.Image
+	Rect      DisplayRect
+	Opacity   float64
+	Pixelated bool
+
+	// Transform maps the coordinates of text and images to output pixels,
+	// rotating and flipping them with their shape and groups.
+	Transform DisplayTransform
+}
+
+// DisplayPoint is a point in pixels.
+type DisplayPoint struct {
+	X, Y float64
+}
+
+// DisplayRect is a box in pixels.
+type DisplayRect struct {
+	X, Y, Width, Height float64
+}
+
+// DisplayGradient is a linear gradient from FromColor at From to ToColor at
+// To, extended past both ends. LinearRGB interpolates in linear light
+// rather than in sRGB.
+type DisplayGradient struct {
+	From, To           DisplayPoint
+	FromColor, ToColor color.RGBA
+	LinearRGB          bool
+}
+
+// DisplayPathVerb is the kind of a DisplayPathSegment.
+type DisplayPathVerb int
+
+const (
+	// DisplayMoveTo starts a subpath at Points[0].
+	DisplayMoveTo DisplayPathVerb = iota
+	// DisplayLineTo draws a line to Points[0].
+	DisplayLineTo
+	// DisplayCubicTo draws a cubic Bézier curve through the control points
+	// Points[0] and Points[1] to Points[2].
+	DisplayCubicTo
+	// DisplayClose closes the subpath back to its start.
+	DisplayClose
+)
+
+// DisplayPathSegment is one step of a DisplayPath.
+type DisplayPathSegment struct {
+	Verb   DisplayPathVerb
+	Points []DisplayPoint
+}
+
+// DisplayPath is an outline made of lines and cubic curves.
+type DisplayPath []DisplayPathSegment
+
+// DisplayTransform is the affine transform [a b c d e f] that maps (x, y) to
+// (a*x + c*y + e, b*x + d*y + f), as in SVG and PDF.
+type DisplayTransform [6]float64
+
+// identityTransform leaves points where they are.
+var identityTransform = DisplayTransform{1, 0, 0, 1, 0, 0}
+
+// Apply returns the point (x, y) transformed by t.
+func (t DisplayTransform) Apply(x, y float64) (float64, float64) {
+	return t[0]*x + t[2]*y + t[4], t[1]*x + t[3]*y + t[5]
+}
+
+// Multiply returns the transform that applies u and then t.
+func (t DisplayTransform) Multiply(u DisplayTransform) DisplayTransform {
+	return DisplayTransform{
+		t[0]*u[0] + t[2]*u[1],
+		t[1]*u[0] + t[3]*u[1],
+		t[0]*u[2] + t[2]*u[3],
+		t[1]*u[2] + t[3]*u[3],
+		t[0]*u[4] + t[2]*u[5] + t[4],
+		t[1]*u[4] + t[3]*u[5] + t[5],
+	}
+}
+
+// rotateFlipTransform returns the transform that rotates and flips a box
+// about (scx, scy) and moves that point to (dcx, dcy), the matrix form of
+// svgTransformList.
+func rotateFlipTransform(dcx, dcy, scx, scy float64, rotation int, flipH, flipV bool) DisplayTransform {
+	rad := float64(rotation) * math.Pi / 180
+	cos, sin := math.Cos(rad), math.Sin(rad)
+	fx, fy := 1.0, 1.0
+	if flipH {
+		fx = -1
+	}
+	if flipV {
+		fy = -1
+	}
+	t := DisplayTransform{cos * fx, sin * fx, -sin * fy, cos * fy, 0, 0}
+	t[4] = dcx - t[0]*scx - t[2]*scy
+	t[5] = dcy - t[1]*scx - t[3]*scy
+	return t
+}
+
+// SlideToDisplayList interprets a slide into the drawing operations the
+// renderer would perform for an image of opts.Width pixels: fills and
+// strokes of outlines, text runs with their fonts, and pictures. Shapes
+// with no such form, such as charts, shadows and recolored pictures, are
+// rendered as images at twice the output resolution, as SlideToSVG does.
+// The options that apply are those SlideToSVG uses.
+func (p *Presentation) SlideToDisplayList(slideIndex int, opts *RenderOptions) (*DisplayList, error) {
+	if slideIndex < 0 || slideIndex >= len(p.slides) {
+		return nil, fmt.Errorf("slide index %d out of range (0-%d)", slideIndex, len(p.slides)-1)
+	}
+	if opts == nil {
+		opts = DefaultRenderOptions()
+	}
+	width := opts.Width
+	if width <= 0 {
+		width = 960
+	}
+	slide := p.slides[slideIndex]
+	slideW := float64(p.layout.CX)
+	slideH := float64(p.layout.CY)
+	height := int(float64(width) * slideH / slideW)
+
+	scratch := image.NewRGBA(image.Rect(0, 0, width, height))
+	b := &displayListBuilder{
+		r:     p.newRenderer(slideIndex, opts, scratch, float64(width)/slideW, float64(height)/slideH),
+		list:  &DisplayList{Width: width, Height: height},
+		t:     identityTransform,
+		alpha: 1,
+	}
+	b.r.fillSlideBackground(slide, opts, scratch.Bounds())
+	if bg := b.r.background; bg.Type == FillSolid {
+		b.add(DisplayOp{Kind: DisplayFill, Path: rectPath(0, 0, float64(width), float64(height)), Color: b.r.rgba(bg.Color)})
+	} else {
+		// Text layout draws into the scratch image, so the background is
+		// kept as a copy.
+		bgImg := image.NewRGBA(scratch.Bounds())
+		copy(bgImg.Pix, scratch.Pix)
+		b.add(DisplayOp{Kind: DisplayImage, Image: bgImg, Rect: DisplayRect{Width: float64(width), Height: float64(height)},
+			Opacity: 1, Transform: identityTransform})
+	}
+	for _, shape := range slide.shapes {
+		b.shape(shape)
+	}
+	return b.list, nil
+}
+
+// displayListBuilder adds the shapes of a slide to a display list.
+type displayListBuilder struct {
+	r     *renderer // renderer at the output scale, drawing into a scratch image
+	list  *DisplayList
+	t     DisplayTransform // transform of the groups the shape is in
+	alpha float64          // opacity of the groups the shape is in
+}
+
+// add appends op with the transform of the current groups.
+func (b *displayListBuilder) add(op DisplayOp) {
+	if op.Kind == DisplayText || op.Kind == DisplayImage {
+		op.Transform = b.t.Multiply(op.Transform)
+	} else {
+		op.Path = op.Path.transformed(b.t)
+		if g := op.Gradient; g != nil {
+			g.From.X, g.From.Y = b.t.Apply(g.From.X, g.From.Y)
+			g.To.X, g.To.Y = b.t.Apply(g.To.X, g.To.Y)
+		}
+		op.Transform = identityTransform
+	}
+	b.list.Ops = append(b.list.Ops, op)
+}
+
+// color returns c faded with the groups and shape being added.
+func (b *displayListBuilder) color(c color.RGBA) color.RGBA {
+	if b.alpha < 1 {
+		c.A = uint8(float64(c.A)*b.alpha + 0.5)
+	}
+	return c
+}
+
+// shape adds one shape, and the children of a group. The fade of a group
+// is applied to each of its shapes.
+func (b *displayListBuilder) shape(shape Shape) {
+	if shape == nil {
+		return
+	}
+	bs := shape.base()
+	if bs.fade >= 1 {
+		return
+	}
+	alpha := b.alpha
+	b.alpha *= 1 - bs.fade
+	defer func() { b.alpha = alpha }()
+
+	if g, ok := shape.(*GroupShape); ok {
+		t := b.t
+		b.t = b.t.Multiply(b.shapeTransform(&g.BaseShape))
+		for _, child := range g.slideSpaceChildren() {
+			b.shape(child)
+		}
+		b.t = t
+		return
+	}
+	if !b.r.textOnly && !b.vector(shape) {
+		b.raster(shape)
+	}
+	b.text(shape)
+}
+
+// shapeTransform returns the transform that rotates and flips a shape about
+// its centre.
+func (b *displayListBuilder) shapeTransform(bs *BaseShape) DisplayTransform {
+	if bs.rotation == 0 && !bs.flipHorizontal && !bs.flipVertical {
+		return identityTransform
+	}
+	x, y, wd, ht := b.r.shapeBox(bs)
+	cx, cy := x+wd/2, y+ht/2
+	return rotateFlipTransform(cx, cy, cx, cy, bs.rotation, bs.flipHorizontal, bs.flipVertical)
+}
+
+// vector adds the geometry of shape as fills and strokes and reports
+// whether it could, on the same terms as svgWriter.vector. Text is added
+// separately.
+func (b *displayListBuilder) vector(shape Shape) bool {
+	switch s := shape.(type) {
+	case *AutoShape:
+		if (s.shadow != nil && s.shadow.Visible) || (s.text != "" && len(s.paragraphs) == 0) {
+			return false
+		}
+		if s.shapeType == AutoShapeRtTriangle && (s.rotation == 90 || s.rotation == 270) {
+			return false
+		}
+		return b.geometry(&s.BaseShape, s.shapeType, s.adjustValues)
+	case *RichTextShape:
+		return b.textShapeGeometry(s)
+	case *PlaceholderShape:
+		return b.textShapeGeometry(&s.RichTextShape)
+	case *DrawingShape:
+		return b.picture(s)
+	case *LineShape:
+		return b.line(s)
+	}
+	return false
+}
+
+// textShapeGeometry adds the box of a text shape: a preset geometry, a
+// freeform path or a rectangle.
+func (b *displayListBuilder) textShapeGeometry(s *RichTextShape) bool {
+	if (s.shadow != nil && s.shadow.Visible) || svgHasArrow(s.headEnd) || svgHasArrow(s.tailEnd) {
+		return false
+	}
+	if g := s.geometryShape(); g != nil {
+		return b.geometry(&s.BaseShape, g.shapeType, g.adjustValues)
+	}
+	if s.customPath == nil {
+		return b.geometry(&s.BaseShape, AutoShapeRectangle, nil)
+	}
+	x, y, wd, ht := b.r.shapeBox(&s.BaseShape)
+	path := customDisplayPath(s.customPath, x, y, wd, ht)
+	if len(path) == 0 {
+		return true
+	}
+	return b.element(&s.BaseShape, path, true)
+}
+
+// geometry adds a shape with preset geometry kind, if display lists
+// support it.
+func (b *displayListBuilder) geometry(bs *BaseShape, kind AutoShapeType, adj map[string]int) bool {
+	x, y, wd, ht := b.r.shapeBox(bs)
+	path, ok := presetDisplayPath(kind, adj, x, y, wd, ht)
+	if !ok {
+		return false
+	}
+	return b.element(bs, path, true)
+}
+
+// element adds the fill and outline of bs drawn along path. It reports
+// false, adding nothing, for fills that have no display list form.
+func (b *displayListBuilder) element(bs *BaseShape, path DisplayPath, evenOdd bool) bool {
+	m := b.shapeTransform(bs)
+	path = path.transformed(m)
+	var ops []DisplayOp
+	if fill := b.r.resolveFill(bs.fill); fill != nil && fill.Type != FillNone {
+		op := DisplayOp{Kind: DisplayFill, Path: path, EvenOdd: evenOdd}
+		switch fill.Type {
+		case FillSolid:
+			op.Color = b.color(b.r.scaleAlpha(b.r.rgba(fill.Color)))
+		case FillGradientLinear:
+			if b.r.gradientSpace != GradientSpaceSRGB && b.r.gradientSpace != GradientSpaceLinear {
+				return false
+			}
+			// The gradient turns with the shape.
+			x, y, wd, ht := b.r.shapeBox(bs)
+			x1, y1, x2, y2 := gradientLine(x, y, wd, ht, fill.Rotation)
+			g := &DisplayGradient{
+				FromColor: b.color(b.r.rgba(fill.Color)),
+				ToColor:   b.color(b.r.rgba(fill.EndColor)),
+				LinearRGB: b.r.gradientSpace == GradientSpaceLinear,
+			}
+			g.From.X, g.From.Y = m.Apply(x1, y1)
+			g.To.X, g.To.Y = m.Apply(x2, y2)
+			op.Gradient = g
+		default:
+			return false
+		}
+		ops = append(ops, op)
+	}
+	if op, ok := b.stroke(bs.border); ok {
+		op.Path = path
+		ops = append(ops, op)
+	}
+	for _, op := range ops {
+		b.add(op)
+	}
+	return true
+}
+
+// stroke returns the stroke of an outline, or false for none.
+func (b *displayListBuilder) stroke(border *Border) (DisplayOp, bool) {
+	if border == nil || border.Style == BorderNone {
+		return DisplayOp{}, false
+	}
+	pw := math.Max(float64(maxInt(border.Width, 1))*12700*b.r.scaleX, 1)
+	return DisplayOp{
+		Kind:       DisplayStroke,
+		Color:      b.color(b.r.rgba(border.Color)),
+		LineWidth:  pw,
+		Dashes:     dashArray(border.Style, border.DashPattern, int(pw)),
+		LineJoin:   border.Join,
+		MiterLimit: float64(border.MiterLimit) / 100000,
+	}, true
+}
+
+// line adds a straight line without arrowheads.
+func (b *displayListBuilder) line(s *LineShape) bool {
+	switch s.connectorType {
+	case "", "line", "straightConnector1":
+	default:
+		return false
+	}
+	if s.customPath != nil || svgHasArrow(s.headEnd) || svgHasArrow(s.tailEnd) {
+		return false
+	}
+	x1, y1, x2, y2 := b.r.lineEnds(s)
+	var path DisplayPath
+	path.moveTo(x1, y1)
+	path.lineTo(x2, y2)
+	if s.rotation != 0 {
+		cx, cy := (x1+x2)/2, (y1+y2)/2
+		path = path.transformed(rotateFlipTransform(cx, cy, cx, cy, s.rotation, false, false))
+	}
+	pw := float64(s.GetLineWidthEMU()) * b.r.scaleX
+	if pw <= 0 {
+		pw = 1
+	}
+	b.add(DisplayOp{
+		Kind:       DisplayStroke,
+		Path:       path,
+		Color:      b.color(b.r.rgba(s.lineColor)),
+		LineWidth:  pw,
+		Dashes:     dashArray(s.lineStyle, s.dashPattern, int(math.Max(pw, 1))),
+		LineJoin:   s.lineJoin,
+		MiterLimit: float64(s.miterLimit) / 100000,
+	})
+	return true
+}
+
+// picture adds a picture as a cropped image, on the same terms as
+// svgWriter.picture except that any format the renderer decodes is kept.
+func (b *displayListBuilder) picture(s *DrawingShape) bool {
+	data := b.r.plainPictureData(s)
+	if len(data) == 0 {
+		return false
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return false
+	}
+	bounds := img.Bounds()
+	if vx, vy, vw, vh, ok := pictureCrop(s, float64(bounds.Dx()), float64(bounds.Dy())); ok {
+		src := image.Rect(int(math.Round(vx)), int(math.Round(vy)), int(math.Round(vx+vw)), int(math.Round(vy+vh))).Add(bounds.Min)
+		if !src.Empty() {
+			cropped := image.NewRGBA(image.Rect(0, 0, src.Dx(), src.Dy()))
+			draw.Draw(cropped, cropped.Bounds(), img, src.Min, draw.Src)
+			img = cropped
+		}
+	}
+	x, y, wd, ht := b.r.shapeBox(&s.BaseShape)
+	b.add(DisplayOp{
+		Kind:      DisplayImage,
+		Image:     img,
+		Rect:      DisplayRect{x, y, wd, ht},
+		Opacity:   b.alpha * s.opacity(),
+		Pixelated: b.r.pixelatedPicture(s, img),
+		Transform: b.shapeTransform(&s.BaseShape),
+	})
+	return true
+}
+
+// raster adds shape, without its text, as an image rendered at
+// svgRasterScale times the output resolution.
+func (b *displayListBuilder) raster(shape Shape) {
+	img, x, y, wd, ht := rasterizeShape(b.r, shape)
+	if img == nil {
+		return
+	}
+	b.add(DisplayOp{Kind: DisplayImage, Image: img, Rect: DisplayRect{x, y, wd, ht}, Opacity: b.alpha, Transform: identityTransform})
+}
+
+// text lays out the text of shape with the renderer and adds each run.
+func (b *displayListBuilder) text(shape Shape) {
+	switch shape.(type) {
+	case *AutoShape, *RichTextShape, *PlaceholderShape, *TableShape:
+	default:
+		return
+	}
+	var runs []svgTextRun
+	b.r.svgText = &svgTextSink{matrix: identityTransform, runs: &runs}
+	b.r.renderShape(shape)
+	b.r.svgText = nil
+
+	for _, run := range runs {
+		f := run.font
+		if strings.TrimSpace(run.text) == "" && (f.Underline == "" || f.Underline == UnderlineNone) && !f.Strikethrough {
+			continue
+		}
+		op := DisplayOp{
+			Kind:      DisplayText,
+			Text:      run.text,
+			Font:      f,
+			FontSize:  run.size,
+			Origin:    DisplayPoint{float64(run.x), float64(run.y)},
+			TextWidth: float64(run.width),
+			Color:     b.color(run.color),
+			Transform: run.matrix,
+		}
+		if run.stroke > 0 {
+			op.OutlineColor = b.color(run.outline)
+			op.OutlineWidth = run.stroke
+		}
+		b.add(op)
+	}
+}
+
+// transformed returns a copy of path with its points mapped by t.
+func (path DisplayPath) transformed(t DisplayTransform) DisplayPath {
+	if t == identityTransform {
+		return path
+	}
+	out := make(DisplayPath, len(path))
+	for i, seg := range path {
+		pts := make([]DisplayPoint, len(seg.Points))
+		for j, p := range seg.Points {
+			pts[j].X, pts[j].Y = t.Apply(p.X, p.Y)
+		}
+		out[i] = DisplayPathSegment{Verb: seg.Verb, Points: pts}
+	}
+	return out
+}
+
+func (path *DisplayPath) moveTo(x, y float64) {
+	*path = append(*path, DisplayPathSegment{Verb: DisplayMoveTo, Points: []DisplayPoint{{x, y}}})
+}
+
+func (path *DisplayPath) lineTo(x, y float64) {
+	*path = append(*path, DisplayPathSegment{Verb: DisplayLineTo, Points: []DisplayPoint{{x, y}}})
+}
+
+func (path *DisplayPath) cubicTo(x1, y1, x2, y2, x3, y3 float64) {
+	*path = append(*path, DisplayPathSegment{Verb: DisplayCubicTo, Points: []DisplayPoint{{x1, y1}, {x2, y2}, {x3, y3}}})
+}
+
+func (path *DisplayPath) close() {
+	*path = append(*path, DisplayPathSegment{Verb: DisplayClose})
+}
+
+// arc continues path along the ellipse centred on (cx, cy) from angle st
+// through sw radians, in cubic curves of at most a quarter turn each. The
+// path is expected to be at the arc's start.
+func (path *DisplayPath) arc(cx, cy, rx, ry, st, sw float64) {
+	n := int(math.Ceil(math.Abs(sw) / (math.Pi / 2)))
+	if n == 0 {
+		return
+	}
+	step := sw / float64(n)
+	k := 4.0 / 3 * math.Tan(step/4)
+	for i := 0; i < n; i++ {
+		a0, a1 := st+float64(i)*step, st+float64(i+1)*step
+		x0, y0 := cx+rx*math.Cos(a0), cy+ry*math.Sin(a0)
+		x3, y3 := cx+rx*math.Cos(a1), cy+ry*math.Sin(a1)
+		path.cubicTo(x0-k*rx*math.Sin(a0), y0+k*ry*math.Cos(a0),
+			x3+k*rx*math.Sin(a1), y3-k*ry*math.Cos(a1), x3, y3)
+	}
+}
+
+// rectPath returns the outline of a box.
+func rectPath(x, y, w, h float64) DisplayPath {
+	var path DisplayPath
+	path.moveTo(x, y)
+	path.lineTo(x+w, y)
+	path.lineTo(x+w, y+h)
+	path.lineTo(x, y+h)
+	path.close()
+	return path
+}
+
+// presetDisplayPath returns the outline of preset geometry kind in the
+// given box, or false for presets display lists do not support, the same
+// ones as SVG output.
+func presetDisplayPath(kind AutoShapeType, adj map[string]int, x, y, w, h float64) (DisplayPath, bool) {
+	var path DisplayPath
+	switch kind {
+	case "", AutoShapeRectangle:
+		return rectPath(x, y, w, h), true
+	case AutoShapeRoundedRect:
+		radius := math.Min(w, h) * 16667 / 100000
+		if v, ok := adj["adj"]; ok {
+			radius = math.Min(w, h) * float64(v) / 200000
+		}
+		path.moveTo(x+radius, y)
+		path.lineTo(x+w-radius, y)
+		path.arc(x+w-radius, y+radius, radius, radius, -math.Pi/2, math.Pi/2)
+		path.lineTo(x+w, y+h-radius)
+		path.arc(x+w-radius, y+h-radius, radius, radius, 0, math.Pi/2)
+		path.lineTo(x+radius, y+h)
+		path.arc(x+radius, y+h-radius, radius, radius, math.Pi/2, math.Pi/2)
+		path.lineTo(x, y+radius)
+		path.arc(x+radius, y+radius, radius, radius, math.Pi, math.Pi/2)
+		path.close()
+		return path, true
+	case AutoShapeEllipse:
+		path.moveTo(x+w, y+h/2)
+		path.arc(x+w/2, y+h/2, w/2, h/2, 0, 2*math.Pi)
+		path.close()
+		return path, true
+	}
+	return presetOutline(kind, adj, x, y, w, h)
+}
+
+// customDisplayPath returns the outline of a custom geometry drawn in the
+// given box, with quadratic curves and arcs as cubic curves.
+func customDisplayPath(cp *CustomGeomPath, x, y, w, h float64) DisplayPath {
+	if cp.Width <= 0 || cp.Height <= 0 {
+		return nil
+	}
+	scX := w / float64(cp.Width)
+	scY := h / float64(cp.Height)
+	pt := func(p PathPoint) fpoint {
+		return fpoint{x + float64(p.X)*scX, y + float64(p.Y)*scY}
+	}
+	var path DisplayPath
+	var last fpoint
+	started := false
+	// A path that does not start with moveTo starts at its first point.
+	begin := func(p fpoint) {
+		if !started {
+			path.moveTo(p.x, p.y)
+			started = true
+		}
+	}
+	for _, c := range cp.Commands {
+		switch c.Type {
+		case "moveTo":
+			if len(c.Pts) > 0 {
+				last = pt(c.Pts[0])
+				path.moveTo(last.x, last.y)
+				started = true
+			}
+		case "lnTo":
+			if len(c.Pts) > 0 {
+				p := pt(c.Pts[0])
+				begin(p)
+				path.lineTo(p.x, p.y)
+				last = p
+			}
+		case "cubicBezTo":
+			if len(c.Pts) >= 3 {
+				p1, p2, p3 := pt(c.Pts[0]), pt(c.Pts[1]), pt(c.Pts[2])
+				begin(p1)
+				path.cubicTo(p1.x, p1.y, p2.x, p2.y, p3.x, p3.y)
+				last = p3
+			}
+		case "quadBezTo":
+			if len(c.Pts) >= 2 {
+				q, p := pt(c.Pts[0]), pt(c.Pts[1])
+				begin(q)
+				path.cubicTo(last.x+2*(q.x-last.x)/3, last.y+2*(q.y-last.y)/3,
+					p.x+2*(q.x-p.x)/3, p.y+2*(q.y-p.y)/3, p.x, p.y)
+				last = p
+			}
+		case "close":
+			if started {
+				path.close()
+				// The next segment starts where the subpath did.
+				for i := len(path) - 1; i >= 0; i-- {
+					if path[i].Verb == DisplayMoveTo {
+						last = fpoint{path[i].Points[0].X, path[i].Points[0].Y}
+						break
+					}
+				}
+			}
+		case "arcTo":
+			// The arc starts at the current point, on an ellipse whose
+			// angles are in 60000ths of a degree.
+			wR, hR := float64(c.WR)*scX, float64(c.HR)*scY
+			if wR < 0.5 || hR < 0.5 || !started {
+				break
+			}
+			st := float64(c.StAng) / 60000 * math.Pi / 180
+			sw := float64(c.SwAng) / 60000 * math.Pi / 180
+			cx, cy := last.x-wR*math.Cos(st), last.y-hR*math.Sin(st)
+			path.arc(cx, cy, wR, hR, st, sw)
+			last = fpoint{cx + wR*math.Cos(st+sw), cy + hR*math.Sin(st+sw)}
+		}
+	}
+	return path
+}
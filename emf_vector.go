@@ -113,13 +113,19 @@ func renderEMFVector(data []byte) image.Image {
 	offX := float64(-boundsL)*scale + 1
 	offY := float64(-boundsT)*scale + 1
 
+	// The world transform maps record coordinates to page space before the
+	// window/viewport mapping. SaveDC/RestoreDC save and restore it.
+	xf := identityEMFXform
+	var xfStack []emfXform
+
 	toImg := func(lx, ly int) (float64, float64) {
+		px, py := xf.apply(float64(lx), float64(ly))
 		var dx, dy float64
 		if winExtX != 0 {
-			dx = float64(lx-winOrgX) * float64(vpExtX) / float64(winExtX)
+			dx = (px - float64(winOrgX)) * float64(vpExtX) / float64(winExtX)
 		}
 		if winExtY != 0 {
-			dy = float64(ly-winOrgY) * float64(vpExtY) / float64(winExtY)
+			dy = (py - float64(winOrgY)) * float64(vpExtY) / float64(winExtY)
 		}
 		dx += float64(vpOrgX)
 		dy += float64(vpOrgY)
@@ -415,6 +421,40 @@ func renderEMFVector(data []byte) image.Image {
 					}
 				}
 			}
+		case 0x23: // SETWORLDTRANSFORM
+			if len(rec) >= 32 {
+				xf = readEMFXform(rec[8:32])
+			}
+		case 0x24: // MODIFYWORLDTRANSFORM
+			if len(rec) >= 36 {
+				m := readEMFXform(rec[8:32])
+				switch u32(rec[32:36]) {
+				case 1: // MWT_IDENTITY
+					xf = identityEMFXform
+				case 2: // MWT_LEFTMULTIPLY
+					xf = m.then(xf)
+				case 3: // MWT_RIGHTMULTIPLY
+					xf = xf.then(m)
+				case 4: // MWT_SET
+					xf = m
+				}
+			}
+		case 0x21: // SAVEDC
+			xfStack = append(xfStack, xf)
+		case 0x22: // RESTOREDC
+			if len(rec) >= 12 {
+				// A negative index is relative to the top of the stack,
+				// a positive one is the absolute 1-based level.
+				n := int(i32(rec[8:12]))
+				level := n - 1
+				if n < 0 {
+					level = len(xfStack) + n
+				}
+				if level >= 0 && level < len(xfStack) {
+					xf = xfStack[level]
+					xfStack = xfStack[:level]
+				}
+			}
 		case 0x1B: // MOVETOEX
 			if len(rec) >= 16 {
 				curX, curY = toImg(int(i32(rec[8:12])), int(i32(rec[12:16])))
@@ -505,9 +545,12 @@ func renderEMFVector(data []byte) image.Image {
 			}
 		case 0x2B: // RECTANGLE
 			if len(rec) >= 24 {
-				x0, y0 := toImg(int(i32(rec[8:12])), int(i32(rec[12:16])))
-				x1, y1 := toImg(int(i32(rec[16:20])), int(i32(rec[20:24])))
-				pts := []pp{{x0, y0}, {x1, y0}, {x1, y1}, {x0, y1}}
+				l, t, r, b := int(i32(rec[8:12])), int(i32(rec[12:16])), int(i32(rec[16:20])), int(i32(rec[20:24]))
+				// Map all four corners: the world transform may rotate or shear.
+				pts := make([]pp, 4)
+				for i, c := range [4][2]int{{l, t}, {r, t}, {r, b}, {l, b}} {
+					pts[i].x, pts[i].y = toImg(c[0], c[1])
+				}
 				bc := brushColor()
 				if bc.A > 0 {
 					emfFill(pts, bc)
@@ -525,3 +568,34 @@ func renderEMFVector(data []byte) image.Image {
 	}
 	return img
 }
+
+// emfXform is an EMF XFORM: page = (x*M11 + y*M21 + Dx, x*M12 + y*M22 + Dy).
+type emfXform struct {
+	m11, m12, m21, m22, dx, dy float64
+}
+
+var identityEMFXform = emfXform{m11: 1, m22: 1}
+
+// readEMFXform decodes the six little-endian float32 fields of an XFORM.
+func readEMFXform(b []byte) emfXform {
+	f := func(i int) float64 {
+		return float64(math.Float32frombits(uint32(b[i]) | uint32(b[i+1])<<8 | uint32(b[i+2])<<16 | uint32(b[i+3])<<24))
+	}
+	return emfXform{f(0), f(4), f(8), f(12), f(16), f(20)}
+}
+
+func (m emfXform) apply(x, y float64) (float64, float64) {
+	return x*m.m11 + y*m.m21 + m.dx, x*m.m12 + y*m.m22 + m.dy
+}
+
+// then returns the transform that applies m followed by n.
+func (m emfXform) then(n emfXform) emfXform {
+	return emfXform{
+		m11: m.m11*n.m11 + m.m12*n.m21,
+		m12: m.m11*n.m12 + m.m12*n.m22,
+		m21: m.m21*n.m11 + m.m22*n.m21,
+		m22: m.m21*n.m12 + m.m22*n.m22,
+		dx:  m.dx*n.m11 + m.dy*n.m21 + n.dx,
+		dy:  m.dx*n.m12 + m.dy*n.m22 + n.dy,
+	}
+}
@@ -15,10 +15,11 @@ import (
 
 // fontKey uniquely identifies a font face by name, size, bold, and italic.
 type fontKey struct {
-	name   string
-	size   float64
-	bold   bool
-	italic bool
+	name    string
+	size    float64
+	bold    bool
+	italic  bool
+	hinting font.Hinting
 }
 
 // FontCache manages TrueType font loading and face caching.
@@ -45,12 +46,38 @@ func NewFontCache(extraDirs ...string) *FontCache {
 	}
 }
 
+// Worker returns a FontCache with the fonts parsed by fc and an empty face
+// cache. Faces are not safe for concurrent use, so each goroutine that
+// renders concurrently with others must draw through its own worker, while
+// the parsed fonts, which are, are shared.
+func (fc *FontCache) Worker() *FontCache {
+	fc.ensureScanned()
+	fc.mu.RLock()
+	defer fc.mu.RUnlock()
+	w := &FontCache{
+		dirs:         fc.dirs,
+		fonts:        make(map[string]*opentype.Font, len(fc.fonts)),
+		faces:        make(map[fontKey]font.Face),
+		measureFaces: make(map[fontKey]font.Face),
+		scanned:      true,
+	}
+	for k, f := range fc.fonts {
+		w.fonts[k] = f
+	}
+	return w
+}
+
 // GetFace returns a font.Face for the given font properties.
 // It tries to find a matching TrueType font; returns nil if not found.
 func (fc *FontCache) GetFace(name string, sizePt float64, bold, italic bool) font.Face {
+	return fc.getFace(name, sizePt, bold, italic, font.HintingFull)
+}
+
+// getFace is GetFace with a selectable hinting mode.
+func (fc *FontCache) getFace(name string, sizePt float64, bold, italic bool, hinting font.Hinting) font.Face {
 	fc.ensureScanned()
 
-	key := fontKey{name: strings.ToLower(name), size: sizePt, bold: bold, italic: italic}
+	key := fontKey{name: strings.ToLower(name), size: sizePt, bold: bold, italic: italic, hinting: hinting}
 
 	fc.mu.RLock()
 	if face, ok := fc.faces[key]; ok {
@@ -68,7 +95,7 @@ func (fc *FontCache) GetFace(name string, sizePt float64, bold, italic bool) fon
 	var style opentype.FaceOptions
 	style.Size = sizePt
 	style.DPI = 72
-	style.Hinting = font.HintingFull
+	style.Hinting = hinting
 
 	face, err := opentype.NewFace(f, &style)
 	if err != nil {
@@ -118,6 +145,23 @@ func (fc *FontCache) GetMeasureFace(name string, sizePt float64, bold, italic bo
 	return face
 }
 
+// underlineMetrics returns the underline position (distance of the top of
+// the line below the baseline) and thickness from the post table of the named
+// font, as fractions of the em size.
+func (fc *FontCache) underlineMetrics(name string, bold, italic bool) (pos, thickness float64, ok bool) {
+	fc.ensureScanned()
+	f := fc.findFont(name, bold, italic)
+	if f == nil {
+		return 0, 0, false
+	}
+	post := f.PostTable()
+	upem := float64(f.UnitsPerEm())
+	if post == nil || upem <= 0 || post.UnderlineThickness <= 0 {
+		return 0, 0, false
+	}
+	return -float64(post.UnderlinePosition) / upem, float64(post.UnderlineThickness) / upem, true
+}
+
 // findFont looks up a parsed font by name, trying style-specific variants first.
 func (fc *FontCache) findFont(name string, bold, italic bool) *opentype.Font {
 	fc.mu.RLock()
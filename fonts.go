@@ -0,0 +1,163 @@
+package gopresentation
+
+import (
+	"sort"
+	"strings"
+)
+
+// FontUsage describes a typeface referenced by a presentation.
+type FontUsage struct {
+	Typeface      string
+	Latin         bool  // used as a latin font (<a:latin>)
+	EastAsian     bool  // used as an East Asian font (<a:ea>)
+	ComplexScript bool  // used as a complex script font (<a:cs>)
+	Symbol        bool  // used for bullet characters or as a run's symbol font (<a:sym>)
+	Theme         bool  // defined by the theme font scheme
+	Embedded      bool  // embedded in the package
+	Slides        []int // 0-based indices of the slides that use the typeface
+}
+
+// FontsUsed returns every typeface referenced by the presentation, sorted by
+// name. It covers run fonts, bullet fonts and chart text on each slide, the
+// fonts placeholders inherit from their layout and slide master, the major
+// and minor fonts of every theme, and fonts embedded in the package, so that
+// callers can check font availability before rendering. Theme font
+// references such as "+mn-lt" count as the typeface the theme names.
+func (p *Presentation) FontsUsed() []FontUsage {
+	byName := make(map[string]*FontUsage)
+	get := func(name string) *FontUsage {
+		u, ok := byName[name]
+		if !ok {
+			u = &FontUsage{Typeface: name}
+			byName[name] = u
+		}
+		return u
+	}
+	addSlide := func(u *FontUsage, idx int) {
+		if n := len(u.Slides); n == 0 || u.Slides[n-1] != idx {
+			u.Slides = append(u.Slides, idx)
+		}
+	}
+
+	for i, slide := range p.slides {
+		for _, shape := range slide.shapes {
+			collectShapeFonts(shape, func(f *Font) {
+				if f == nil {
+					return
+				}
+				if name := p.themeFont(f.Name); name != "" {
+					u := get(name)
+					u.Latin = true
+					addSlide(u, i)
+				}
+				if name := p.themeFont(f.NameEA); name != "" {
+					u := get(name)
+					u.EastAsian = true
+					addSlide(u, i)
+				}
+				if name := p.themeFont(f.NameCS); name != "" {
+					u := get(name)
+					u.ComplexScript = true
+					addSlide(u, i)
+				}
+			}, func(name string) {
+				if name = p.themeFont(name); name == "" {
+					return
+				}
+				u := get(name)
+				u.Symbol = true
+				addSlide(u, i)
+			})
+		}
+	}
+
+	themes := p.themeFontSets
+	if len(themes) == 0 && p.themeFonts != nil {
+		themes = []map[string]string{p.themeFonts}
+	}
+	for _, fonts := range themes {
+		for ref, name := range fonts {
+			u := get(name)
+			u.Theme = true
+			switch ref[len(ref)-2:] {
+			case "lt":
+				u.Latin = true
+			case "ea":
+				u.EastAsian = true
+			case "cs":
+				u.ComplexScript = true
+			}
+		}
+	}
+	for _, name := range p.embeddedFonts {
+		get(name).Embedded = true
+	}
+
+	out := make([]FontUsage, 0, len(byName))
+	for _, u := range byName {
+		out = append(out, *u)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Typeface < out[j].Typeface })
+	return out
+}
+
+// themeFont returns name, or the typeface the theme gives a theme font
+// reference such as "+mj-lt", which is "" when the theme has none.
+func (p *Presentation) themeFont(name string) string {
+	if !strings.HasPrefix(name, "+") {
+		return name
+	}
+	if p == nil {
+		return ""
+	}
+	return p.themeFonts[name]
+}
+
+// collectShapeFonts calls fontFn for every text font in shape and bulletFn for
+// every bullet or run symbol font, descending into groups and tables.
+func collectShapeFonts(shape Shape, fontFn func(*Font), bulletFn func(string)) {
+	paras := func(ps []*Paragraph) {
+		for _, para := range ps {
+			if para.bullet != nil && para.bullet.Type == BulletTypeChar && para.bullet.Font != "" {
+				bulletFn(para.bullet.Font)
+			}
+			for _, elem := range para.elements {
+				if tr, ok := elem.(*TextRun); ok {
+					fontFn(tr.font)
+					if tr.font != nil && tr.font.NameSym != "" {
+						bulletFn(tr.font.NameSym)
+					}
+				}
+			}
+		}
+	}
+	switch s := shape.(type) {
+	case *RichTextShape:
+		paras(s.paragraphs)
+	case *PlaceholderShape:
+		paras(s.paragraphs)
+		// The fonts the placeholder takes from its layout and master.
+		fontFn(s.promptFont)
+	case *AutoShape:
+		paras(s.paragraphs)
+	case *TableShape:
+		for _, row := range s.rows {
+			for _, cell := range row {
+				if cell != nil {
+					paras(cell.paragraphs)
+				}
+			}
+		}
+	case *ChartShape:
+		if s.title != nil {
+			fontFn(s.title.Font)
+		}
+		if s.legend != nil {
+			fontFn(s.legend.Font)
+		}
+	case *GroupShape:
+		for _, gs := range s.shapes {
+			collectShapeFonts(gs, fontFn, bulletFn)
+		}
+	}
+}
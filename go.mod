@@ -4,4 +4,4 @@ go 1.25.5
 
 require golang.org/x/image v0.36.0
 
-require golang.org/x/text v0.34.0 // indirect
+require golang.org/x/text v0.34.0
@@ -0,0 +1,121 @@
+package gopresentation
+
+import (
+	"image/color"
+	"math"
+)
+
+// GradientSpace selects the color space in which gradient fills are
+// interpolated between their stops.
+type GradientSpace int
+
+const (
+	// GradientSpaceSRGB blends the gamma-encoded sRGB values, as most
+	// renderers do. Blends of saturated complementary colors pass through
+	// a dull, dark midtone.
+	GradientSpaceSRGB GradientSpace = iota
+	// GradientSpaceLinear blends light intensities (linear-light sRGB),
+	// which keeps midtones bright.
+	GradientSpaceLinear
+	// GradientSpaceOkLab blends in the perceptually uniform OkLab space,
+	// which keeps midtones bright and the perceived hue change even, and
+	// is closest to PowerPoint's gradients.
+	GradientSpaceOkLab
+)
+
+// gradientRampSize is the number of precomputed steps of a gradient ramp
+// outside sRGB; more than 8-bit channels can distinguish between two stops.
+const gradientRampSize = 1024
+
+// gradientRamp returns the color at t (0 to 1) of a gradient from a to b
+// in the renderer's gradient space.
+func (r *renderer) gradientRamp(a, b color.RGBA) func(t float64) color.RGBA {
+	return newGradientRamp(a, b, r.gradientSpace)
+}
+
+func newGradientRamp(a, b color.RGBA, space GradientSpace) func(t float64) color.RGBA {
+	if (space != GradientSpaceLinear && space != GradientSpaceOkLab) || a == b {
+		return func(t float64) color.RGBA { return lerpColor(a, b, t) }
+	}
+	// Colors are interpolated unpremultiplied, as lerpColor does; alpha is
+	// not gamma-encoded and stays linear.
+	lut := make([]color.RGBA, gradientRampSize)
+	var ca, cb [3]float64
+	if space == GradientSpaceOkLab {
+		ca, cb = srgbToOkLab(a), srgbToOkLab(b)
+	} else {
+		ca, cb = srgbToLinear(a), srgbToLinear(b)
+	}
+	for i := range lut {
+		t := float64(i) / float64(gradientRampSize-1)
+		var m [3]float64
+		for k := range m {
+			m[k] = ca[k] + (cb[k]-ca[k])*t
+		}
+		var c color.RGBA
+		if space == GradientSpaceOkLab {
+			c = okLabToSRGB(m)
+		} else {
+			c = linearToSRGB(m)
+		}
+		c.A = uint8(float64(a.A)*(1-t) + float64(b.A)*t)
+		lut[i] = c
+	}
+	return func(t float64) color.RGBA {
+		return lut[int(t*(gradientRampSize-1)+0.5)]
+	}
+}
+
+// srgbDecode converts an 8-bit sRGB channel to linear light (0 to 1).
+func srgbDecode(v uint8) float64 {
+	c := float64(v) / 255
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+// srgbEncode converts linear light to an 8-bit sRGB channel.
+func srgbEncode(c float64) uint8 {
+	c = math.Max(0, math.Min(1, c))
+	if c <= 0.0031308 {
+		c *= 12.92
+	} else {
+		c = 1.055*math.Pow(c, 1/2.4) - 0.055
+	}
+	return uint8(math.Round(c * 255))
+}
+
+func srgbToLinear(c color.RGBA) [3]float64 {
+	return [3]float64{srgbDecode(c.R), srgbDecode(c.G), srgbDecode(c.B)}
+}
+
+func linearToSRGB(l [3]float64) color.RGBA {
+	return color.RGBA{R: srgbEncode(l[0]), G: srgbEncode(l[1]), B: srgbEncode(l[2]), A: 255}
+}
+
+// srgbToOkLab converts a color to OkLab (L, a, b), using Björn Ottosson's
+// reference matrices.
+func srgbToOkLab(c color.RGBA) [3]float64 {
+	lin := srgbToLinear(c)
+	l := math.Cbrt(0.4122214708*lin[0] + 0.5363325363*lin[1] + 0.0514459929*lin[2])
+	m := math.Cbrt(0.2119034982*lin[0] + 0.6806995451*lin[1] + 0.1073969566*lin[2])
+	s := math.Cbrt(0.0883024619*lin[0] + 0.2817188376*lin[1] + 0.6299787005*lin[2])
+	return [3]float64{
+		0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		0.0259040371*l + 0.7827717662*m - 0.8086757660*s,
+	}
+}
+
+func okLabToSRGB(lab [3]float64) color.RGBA {
+	l := lab[0] + 0.3963377774*lab[1] + 0.2158037573*lab[2]
+	m := lab[0] - 0.1055613458*lab[1] - 0.0638541728*lab[2]
+	s := lab[0] - 0.0894841775*lab[1] - 1.2914855480*lab[2]
+	l, m, s = l*l*l, m*m*m, s*s*s
+	return linearToSRGB([3]float64{
+		4.0767416621*l - 3.3077115913*m + 0.2309699292*s,
+		-1.2684380046*l + 2.6097574011*m - 0.3413193965*s,
+		-0.0041960863*l - 0.7034186147*m + 1.7076147010*s,
+	})
+}
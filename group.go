@@ -38,6 +38,25 @@ func (g *GroupShape) AddShape(s Shape) *GroupShape {
 func (g *GroupShape) GetShapes() []Shape {
 	return g.shapes
 }
+// slideSpaceChildren returns the children with their geometry transformed
+// from the group's child space (chOff/chExt) to group space (off/ext). The
+// children themselves are not modified.
+func (g *GroupShape) slideSpaceChildren() []Shape {
+	if g.childExtX <= 0 || g.childExtY <= 0 {
+		return g.shapes
+	}
+	children := make([]Shape, len(g.shapes))
+	for i, gs := range g.shapes {
+		bs := gs.base()
+		children[i] = withGeometry(gs,
+			g.offsetX+(bs.offsetX-g.childOffX)*g.width/g.childExtX,
+			g.offsetY+(bs.offsetY-g.childOffY)*g.height/g.childExtY,
+			bs.width*g.width/g.childExtX,
+			bs.height*g.height/g.childExtY)
+	}
+	return children
+}
+
 // GetGroupFill returns the group-level fill (from grpSpPr), if any.
 func (g *GroupShape) GetGroupFill() *Fill {
 	return g.groupFill
@@ -60,8 +79,10 @@ func (g *GroupShape) RemoveShape(index int) error {
 // PlaceholderShape represents a placeholder shape (title, body, etc.).
 type PlaceholderShape struct {
 	RichTextShape
-	phType PlaceholderType
-	phIdx  int
+	phType     PlaceholderType
+	phIdx      int
+	prompt     string // custom prompt text from the layout (hasCustomPrompt)
+	promptFont *Font  // layout font used to draw the prompt
 }
 
 // ShapeTypePlaceholder is the shape type for placeholders.
@@ -126,6 +147,37 @@ func (p *PlaceholderShape) ClearAll() {
 	p.activeParagraph = 0
 }
 
+// IsEmpty reports whether the placeholder has no text of its own. An empty
+// placeholder shows only its prompt while editing and nothing in a slide show.
+func (p *PlaceholderShape) IsEmpty() bool {
+	for _, para := range p.paragraphs {
+		for _, elem := range para.elements {
+			if tr, ok := elem.(*TextRun); ok && tr.text != "" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// GetPromptText returns the prompt PowerPoint shows in the empty placeholder:
+// the layout's custom prompt if it has one, otherwise the generic prompt for
+// the placeholder type. Date, footer and slide number placeholders have none.
+func (p *PlaceholderShape) GetPromptText() string {
+	if p.prompt != "" {
+		return p.prompt
+	}
+	switch p.phType {
+	case PlaceholderTitle, PlaceholderCtrTitle:
+		return "Click to add title"
+	case PlaceholderSubTitle:
+		return "Click to add subtitle"
+	case PlaceholderDate, PlaceholderFooter, PlaceholderSlideNum, "hdr":
+		return ""
+	}
+	return "Click to add text"
+}
+
 // Remove removes this placeholder from the given slide.
 // Returns true if the placeholder was found and removed.
 func (p *PlaceholderShape) Remove(slide *Slide) bool {
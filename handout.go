@@ -0,0 +1,227 @@
+package gopresentation
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// handoutGrid returns the columns and rows PowerPoint uses for a handout
+// layout with n slides per page.
+func handoutGrid(n int) (cols, rows int, ok bool) {
+	switch n {
+	case 1:
+		return 1, 1, true
+	case 2:
+		return 1, 2, true
+	case 3:
+		return 1, 3, true
+	case 4:
+		return 2, 2, true
+	case 6:
+		return 2, 3, true
+	case 9:
+		return 3, 3, true
+	}
+	return 0, 0, false
+}
+
+// notesPageSize returns the notes/handout page size in EMU. When the
+// presentation does not specify one, the slide size rotated to portrait is used.
+func (p *Presentation) notesPageSize() (cx, cy int64) {
+	if p.notesSize.CX > 0 && p.notesSize.CY > 0 {
+		return p.notesSize.CX, p.notesSize.CY
+	}
+	return p.layout.CY, p.layout.CX
+}
+
+// handoutFrame is the frame of a handout master placeholder, in EMU.
+type handoutFrame struct {
+	x, y, cx, cy int64
+}
+
+// handoutSideMargin and handoutGap are the side margin and the spacing
+// around slides that PowerPoint uses on handout pages; the handout master
+// does not store them.
+const (
+	handoutSideMargin = emuPerInch / 2
+	handoutGap        = emuPerInch / 4
+)
+
+// handoutArea returns the part of the handout page, in EMU, that the
+// slides share: below the handout master's header and date placeholders,
+// above its footer and slide number placeholders, and within their width
+// less the side margins. Placeholders the master lacks take PowerPoint's
+// default half-inch bands across the page.
+func (p *Presentation) handoutArea() (x, y, cx, cy int64) {
+	pageCX, pageCY := p.notesPageSize()
+	left, right := int64(0), pageCX
+	top, bottom := int64(emuPerInch/2), pageCY-emuPerInch/2
+	var seenTop, seenBottom, seenSide bool
+	for typ, f := range p.handoutPlaceholders {
+		switch typ {
+		case "hdr", "dt":
+			if !seenTop || f.y+f.cy > top {
+				top = f.y + f.cy
+			}
+			seenTop = true
+		case "ftr", "sldNum":
+			if !seenBottom || f.y < bottom {
+				bottom = f.y
+			}
+			seenBottom = true
+		default:
+			continue
+		}
+		if !seenSide || f.x < left {
+			left = f.x
+		}
+		if !seenSide || f.x+f.cx > right {
+			right = f.x + f.cx
+		}
+		seenSide = true
+	}
+	x, cx = left+handoutSideMargin, right-left-2*handoutSideMargin
+	y, cy = top+handoutGap, bottom-top-2*handoutGap
+	if cx <= 0 || cy <= 0 {
+		// A master whose placeholders leave no room is ignored.
+		return handoutSideMargin, emuPerInch/2 + handoutGap, pageCX - 2*handoutSideMargin, pageCY - emuPerInch - 2*handoutGap
+	}
+	return x, y, cx, cy
+}
+
+// HandoutToImages renders the presentation as handout pages with
+// slidesPerPage slides on each page (1, 2, 3, 4, 6 or 9), placed between
+// the header and footer placeholders of the handout master as PowerPoint
+// places them. Pages use the notes page size; opts.Width sets the page
+// width in pixels. The 3-per-page layout leaves ruled lines beside each
+// slide for notes. Hidden slides are skipped.
+func (p *Presentation) HandoutToImages(slidesPerPage int, opts *RenderOptions) ([]image.Image, error) {
+	cols, rows, ok := handoutGrid(slidesPerPage)
+	if !ok {
+		return nil, fmt.Errorf("unsupported handout layout: %d slides per page", slidesPerPage)
+	}
+	if opts == nil {
+		opts = DefaultRenderOptions()
+	}
+	pageOpts := *opts
+	if pageOpts.Width <= 0 {
+		pageOpts.Width = 960
+	}
+	if pageOpts.FontCache == nil {
+		pageOpts.FontCache = NewFontCache(pageOpts.FontDirs...)
+	}
+
+	var visible []int
+	for i, s := range p.slides {
+		if s.IsVisible() {
+			visible = append(visible, i)
+		}
+	}
+
+	pageCX, pageCY := p.notesPageSize()
+	scale := float64(pageOpts.Width) / float64(pageCX)
+	pageW := pageOpts.Width
+	pageH := int(float64(pageCY) * scale)
+
+	ax, ay, acx, acy := p.handoutArea()
+	areaX := int(float64(ax) * scale)
+	areaY := int(float64(ay) * scale)
+	areaW := int(float64(acx) * scale)
+	areaH := int(float64(acy) * scale)
+	gap := int(float64(handoutGap) * scale)
+	notesX2 := areaX + areaW // right end of the note lines
+	if cols == 1 && rows == 3 {
+		areaW = areaW / 2 // right half holds the note lines
+	}
+	cellW := (areaW - gap*(cols-1)) / cols
+	cellH := (areaH - gap*(rows-1)) / rows
+	if cellW <= 0 || cellH <= 0 {
+		return nil, fmt.Errorf("page width %d is too small for %d slides per page", pageW, slidesPerPage)
+	}
+
+	// Fit the slide aspect ratio into a cell.
+	aspect := float64(p.layout.CY) / float64(p.layout.CX)
+	slideW := cellW
+	slideH := int(float64(slideW) * aspect)
+	if slideH > cellH {
+		slideH = cellH
+		slideW = int(float64(slideH) / aspect)
+	}
+	slideOpts := pageOpts
+	slideOpts.Width = slideW
+	slideOpts.Bleed, slideOpts.Margin, slideOpts.CropMarks = 0, 0, false
+	slideOpts.ColorMode = ColorModeRGBA
+
+	// The slides are rendered first, so that they can be spread over
+	// opts.Parallelism goroutines.
+	slides := make([]image.Image, len(p.slides))
+	err := p.forEachSlide(&slideOpts, func(i int, opts *RenderOptions) error {
+		if !p.slides[i].IsVisible() {
+			return nil
+		}
+		img, err := p.SlideToImage(i, opts)
+		if err != nil {
+			return fmt.Errorf("slide %d: %w", i, err)
+		}
+		slides[i] = img
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	frame := color.RGBA{R: 128, G: 128, B: 128, A: 255}
+	numPages := maxInt((len(visible)+slidesPerPage-1)/slidesPerPage, 1)
+	pages := make([]image.Image, 0, numPages)
+	for pg := 0; pg < numPages; pg++ {
+		start := pg * slidesPerPage
+		page := image.NewRGBA(image.Rect(0, 0, pageW, pageH))
+		r := &renderer{img: page}
+		r.fillRectFast(page.Bounds(), color.RGBA{R: 255, G: 255, B: 255, A: 255})
+		for k := 0; k < slidesPerPage && start+k < len(visible); k++ {
+			col, row := k%cols, k/cols
+			cx := areaX + col*(cellW+gap) + (cellW-slideW)/2
+			cy := areaY + row*(cellH+gap) + (cellH-slideH)/2
+			img := slides[visible[start+k]]
+			dst := image.Rect(cx, cy, cx+img.Bounds().Dx(), cy+img.Bounds().Dy())
+			draw.Draw(page, dst, img, img.Bounds().Min, draw.Src)
+			r.drawRect(dst.Inset(-1), frame, 1)
+			if cols == 1 && rows == 3 {
+				r.drawHandoutNoteLines(areaX+areaW+gap, notesX2, cy, cy+slideH, frame)
+			}
+		}
+		pages = append(pages, convertColorMode(page, pageOpts.ColorMode))
+	}
+	return pages, nil
+}
+
+// drawHandoutNoteLines draws evenly spaced ruled lines for hand-written notes.
+func (r *renderer) drawHandoutNoteLines(x1, x2, y1, y2 int, c color.RGBA) {
+	const lines = 6
+	step := (y2 - y1) / lines
+	if step <= 0 || x2 <= x1 {
+		return
+	}
+	for i := 1; i <= lines; i++ {
+		y := y1 + i*step
+		r.drawLine(x1, y, x2, y, c)
+	}
+}
+
+// SaveHandoutsAsImages renders handout pages and saves them to files.
+// The pattern should contain %d for the page number (1-based), e.g. "handout_%d.png".
+func (p *Presentation) SaveHandoutsAsImages(pattern string, slidesPerPage int, opts *RenderOptions) error {
+	pages, err := p.HandoutToImages(slidesPerPage, opts)
+	if err != nil {
+		return err
+	}
+	for i, img := range pages {
+		path := fmt.Sprintf(pattern, i+1)
+		if err := saveImage(img, path, opts); err != nil {
+			return fmt.Errorf("page %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,502 @@
+package gopresentation
+
+import (
+	"bufio"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"sort"
+)
+
+// JPEGSubsampling selects the chroma subsampling of JPEG output.
+type JPEGSubsampling int
+
+const (
+	// JPEGSubsampling420 halves chroma resolution in both directions, as
+	// image/jpeg does. It gives the smallest files.
+	JPEGSubsampling420 JPEGSubsampling = iota
+	// JPEGSubsampling444 keeps full chroma resolution, avoiding the color
+	// fringes that 4:2:0 leaves around small colored text and thin lines.
+	JPEGSubsampling444
+)
+
+// JPEGPreset is a set of JPEG settings tuned for a kind of slide content.
+type JPEGPreset int
+
+const (
+	// JPEGPresetText suits text-heavy slides: high quality and 4:4:4 chroma
+	// keep glyph edges free of ringing and color bleed.
+	JPEGPresetText JPEGPreset = iota
+	// JPEGPresetPhoto suits photographic slides, where 4:2:0 is invisible.
+	JPEGPresetPhoto
+	// JPEGPresetThumbnail favors small files for previews.
+	JPEGPresetThumbnail
+)
+
+// ApplyJPEGPreset switches the options to JPEG output with the quality and
+// chroma subsampling of preset.
+func (o *RenderOptions) ApplyJPEGPreset(preset JPEGPreset) {
+	o.Format = ImageFormatJPEG
+	switch preset {
+	case JPEGPresetText:
+		o.JPEGQuality, o.JPEGSubsampling = 92, JPEGSubsampling444
+	case JPEGPresetPhoto:
+		o.JPEGQuality, o.JPEGSubsampling = 85, JPEGSubsampling420
+	case JPEGPresetThumbnail:
+		o.JPEGQuality, o.JPEGSubsampling = 70, JPEGSubsampling420
+	}
+}
+
+// --- Baseline JPEG encoder with 4:4:4 chroma ---
+
+// jpegUnzig maps zig-zag order to natural (row-major) order within a block.
+var jpegUnzig = [64]int{
+	0, 1, 8, 16, 9, 2, 3, 10,
+	17, 24, 32, 25, 18, 11, 4, 5,
+	12, 19, 26, 33, 40, 48, 41, 34,
+	27, 20, 13, 6, 7, 14, 21, 28,
+	35, 42, 49, 56, 57, 50, 43, 36,
+	29, 22, 15, 23, 30, 37, 44, 51,
+	58, 59, 52, 45, 38, 31, 39, 46,
+	53, 60, 61, 54, 47, 55, 62, 63,
+}
+
+// jpegBaseQuant holds the luminance and chrominance quantization tables of
+// the JPEG spec (section K.1), in zig-zag order.
+var jpegBaseQuant = [2][64]byte{
+	{
+		16, 11, 12, 14, 12, 10, 16, 14,
+		13, 14, 18, 17, 16, 19, 24, 40,
+		26, 24, 22, 22, 24, 49, 35, 37,
+		29, 40, 58, 51, 61, 60, 57, 51,
+		56, 55, 64, 72, 92, 78, 64, 68,
+		87, 69, 55, 56, 80, 109, 81, 87,
+		95, 98, 103, 104, 103, 62, 77, 113,
+		121, 112, 100, 120, 92, 101, 103, 99,
+	},
+	{
+		17, 18, 18, 24, 21, 24, 47, 26,
+		26, 47, 99, 66, 56, 66, 99, 99,
+		99, 99, 99, 99, 99, 99, 99, 99,
+		99, 99, 99, 99, 99, 99, 99, 99,
+		99, 99, 99, 99, 99, 99, 99, 99,
+		99, 99, 99, 99, 99, 99, 99, 99,
+		99, 99, 99, 99, 99, 99, 99, 99,
+		99, 99, 99, 99, 99, 99, 99, 99,
+	},
+}
+
+// jpegHuffSpec is a Huffman table: the number of codes of each length 1-16
+// and the symbols in code order.
+type jpegHuffSpec struct {
+	count [16]byte
+	value []byte
+}
+
+// jpegHuffSpecs are the standard tables of the JPEG spec (section K.3):
+// luminance DC, luminance AC, chrominance DC and chrominance AC.
+var jpegHuffSpecs = [4]jpegHuffSpec{
+	{
+		[16]byte{0, 1, 5, 1, 1, 1, 1, 1, 1, 0, 0, 0, 0, 0, 0, 0},
+		[]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+	},
+	{
+		[16]byte{0, 2, 1, 3, 3, 2, 4, 3, 5, 5, 4, 4, 0, 0, 1, 125},
+		[]byte{
+			0x01, 0x02, 0x03, 0x00, 0x04, 0x11, 0x05, 0x12,
+			0x21, 0x31, 0x41, 0x06, 0x13, 0x51, 0x61, 0x07,
+			0x22, 0x71, 0x14, 0x32, 0x81, 0x91, 0xa1, 0x08,
+			0x23, 0x42, 0xb1, 0xc1, 0x15, 0x52, 0xd1, 0xf0,
+			0x24, 0x33, 0x62, 0x72, 0x82, 0x09, 0x0a, 0x16,
+			0x17, 0x18, 0x19, 0x1a, 0x25, 0x26, 0x27, 0x28,
+			0x29, 0x2a, 0x34, 0x35, 0x36, 0x37, 0x38, 0x39,
+			0x3a, 0x43, 0x44, 0x45, 0x46, 0x47, 0x48, 0x49,
+			0x4a, 0x53, 0x54, 0x55, 0x56, 0x57, 0x58, 0x59,
+			0x5a, 0x63, 0x64, 0x65, 0x66, 0x67, 0x68, 0x69,
+			0x6a, 0x73, 0x74, 0x75, 0x76, 0x77, 0x78, 0x79,
+			0x7a, 0x83, 0x84, 0x85, 0x86, 0x87, 0x88, 0x89,
+			0x8a, 0x92, 0x93, 0x94, 0x95, 0x96, 0x97, 0x98,
+			0x99, 0x9a, 0xa2, 0xa3, 0xa4, 0xa5, 0xa6, 0xa7,
+			0xa8, 0xa9, 0xaa, 0xb2, 0xb3, 0xb4, 0xb5, 0xb6,
+			0xb7, 0xb8, 0xb9, 0xba, 0xc2, 0xc3, 0xc4, 0xc5,
+			0xc6, 0xc7, 0xc8, 0xc9, 0xca, 0xd2, 0xd3, 0xd4,
+			0xd5, 0xd6, 0xd7, 0xd8, 0xd9, 0xda, 0xe1, 0xe2,
+			0xe3, 0xe4, 0xe5, 0xe6, 0xe7, 0xe8, 0xe9, 0xea,
+			0xf1, 0xf2, 0xf3, 0xf4, 0xf5, 0xf6, 0xf7, 0xf8,
+			0xf9, 0xfa,
+		},
+	},
+	{
+		[16]byte{0, 3, 1, 1, 1, 1, 1, 1, 1, 1, 1, 0, 0, 0, 0, 0},
+		[]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+	},
+	{
+		[16]byte{0, 2, 1, 2, 4, 4, 3, 4, 7, 5, 4, 4, 0, 1, 2, 119},
+		[]byte{
+			0x00, 0x01, 0x02, 0x03, 0x11, 0x04, 0x05, 0x21,
+			0x31, 0x06, 0x12, 0x41, 0x51, 0x07, 0x61, 0x71,
+			0x13, 0x22, 0x32, 0x81, 0x08, 0x14, 0x42, 0x91,
+			0xa1, 0xb1, 0xc1, 0x09, 0x23, 0x33, 0x52, 0xf0,
+			0x15, 0x62, 0x72, 0xd1, 0x0a, 0x16, 0x24, 0x34,
+			0xe1, 0x25, 0xf1, 0x17, 0x18, 0x19, 0x1a, 0x26,
+			0x27, 0x28, 0x29, 0x2a, 0x35, 0x36, 0x37, 0x38,
+			0x39, 0x3a, 0x43, 0x44, 0x45, 0x46, 0x47, 0x48,
+			0x49, 0x4a, 0x53, 0x54, 0x55, 0x56, 0x57, 0x58,
+			0x59, 0x5a, 0x63, 0x64, 0x65, 0x66, 0x67, 0x68,
+			0x69, 0x6a, 0x73, 0x74, 0x75, 0x76, 0x77, 0x78,
+			0x79, 0x7a, 0x82, 0x83, 0x84, 0x85, 0x86, 0x87,
+			0x88, 0x89, 0x8a, 0x92, 0x93, 0x94, 0x95, 0x96,
+			0x97, 0x98, 0x99, 0x9a, 0xa2, 0xa3, 0xa4, 0xa5,
+			0xa6, 0xa7, 0xa8, 0xa9, 0xaa, 0xb2, 0xb3, 0xb4,
+			0xb5, 0xb6, 0xb7, 0xb8, 0xb9, 0xba, 0xc2, 0xc3,
+			0xc4, 0xc5, 0xc6, 0xc7, 0xc8, 0xc9, 0xca, 0xd2,
+			0xd3, 0xd4, 0xd5, 0xd6, 0xd7, 0xd8, 0xd9, 0xda,
+			0xe2, 0xe3, 0xe4, 0xe5, 0xe6, 0xe7, 0xe8, 0xe9,
+			0xea, 0xf2, 0xf3, 0xf4, 0xf5, 0xf6, 0xf7, 0xf8,
+			0xf9, 0xfa,
+		},
+	},
+}
+
+// jpegHuffCode is a Huffman code of length bits.
+type jpegHuffCode struct {
+	code uint32
+	bits uint
+}
+
+// jpegDCTCos[u][x] is C(u)/2 * cos((2x+1)uπ/16), the 1-D DCT basis.
+var jpegDCTCos = func() (t [8][8]float64) {
+	for u := 0; u < 8; u++ {
+		c := 0.5
+		if u == 0 {
+			c = 0.5 / math.Sqrt2
+		}
+		for x := 0; x < 8; x++ {
+			t[u][x] = c * math.Cos(float64(2*x+1)*float64(u)*math.Pi/16)
+		}
+	}
+	return t
+}()
+
+// jpegEncoder writes a baseline JPEG with three full-resolution components.
+type jpegEncoder struct {
+	w     *bufio.Writer
+	err   error
+	acc   uint32 // pending bits, left-aligned after the bits already written
+	nacc  uint
+	quant [2][64]float64 // zig-zag order
+	huff  [4][256]jpegHuffCode
+}
+
+// encodeJPEG444 encodes img as a baseline JPEG without chroma subsampling.
+func encodeJPEG444(w io.Writer, img image.Image, quality int) error {
+	if quality < 1 {
+		quality = 1
+	} else if quality > 100 {
+		quality = 100
+	}
+	scale := 200 - 2*quality
+	if quality < 50 {
+		scale = 5000 / quality
+	}
+
+	e := &jpegEncoder{w: bufio.NewWriter(w)}
+	var dqt [2][64]byte
+	for t := range jpegBaseQuant {
+		for i, b := range jpegBaseQuant[t] {
+			q := (int(b)*scale + 50) / 100
+			q = minInt(maxInt(q, 1), 255)
+			dqt[t][i] = byte(q)
+			e.quant[t][i] = float64(q)
+		}
+	}
+	for i, spec := range jpegHuffSpecs {
+		code, k := uint32(0), 0
+		for n, count := range spec.count {
+			for j := 0; j < int(count); j++ {
+				e.huff[i][spec.value[k]] = jpegHuffCode{code, uint(n + 1)}
+				code++
+				k++
+			}
+			code <<= 1
+		}
+	}
+
+	b := img.Bounds()
+	wd, ht := b.Dx(), b.Dy()
+
+	e.write([]byte{0xFF, 0xD8})
+	// Quantization tables.
+	e.write([]byte{0xFF, 0xDB, 0, 132})
+	for t := range dqt {
+		e.write([]byte{byte(t)})
+		e.write(dqt[t][:])
+	}
+	// Frame header: 8-bit precision, three components sampled 1x1.
+	e.write([]byte{0xFF, 0xC0, 0, 17, 8, byte(ht >> 8), byte(ht), byte(wd >> 8), byte(wd), 3,
+		1, 0x11, 0, 2, 0x11, 1, 3, 0x11, 1})
+	// Huffman tables.
+	dhtLen := 2
+	for _, spec := range jpegHuffSpecs {
+		dhtLen += 17 + len(spec.value)
+	}
+	e.write([]byte{0xFF, 0xC4, byte(dhtLen >> 8), byte(dhtLen)})
+	for i, spec := range jpegHuffSpecs {
+		e.write([]byte{byte(i&1)<<4 | byte(i>>1)})
+		e.write(spec.count[:])
+		e.write(spec.value)
+	}
+	// Scan header.
+	e.write([]byte{0xFF, 0xDA, 0, 12, 3, 1, 0x00, 2, 0x11, 3, 0x11, 0, 63, 0})
+
+	rgba, _ := img.(*image.RGBA)
+	var blocks [3][64]float64
+	var prevDC [3]int
+	for by := 0; by < ht; by += 8 {
+		for bx := 0; bx < wd; bx += 8 {
+			for y := 0; y < 8; y++ {
+				sy := b.Min.Y + minInt(by+y, ht-1)
+				for x := 0; x < 8; x++ {
+					sx := b.Min.X + minInt(bx+x, wd-1)
+					var r, g, bl uint8
+					if rgba != nil {
+						i := rgba.PixOffset(sx, sy)
+						r, g, bl = rgba.Pix[i], rgba.Pix[i+1], rgba.Pix[i+2]
+					} else {
+						c := color.RGBAModel.Convert(img.At(sx, sy)).(color.RGBA)
+						r, g, bl = c.R, c.G, c.B
+					}
+					yy, cb, cr := color.RGBToYCbCr(r, g, bl)
+					blocks[0][y*8+x] = float64(yy) - 128
+					blocks[1][y*8+x] = float64(cb) - 128
+					blocks[2][y*8+x] = float64(cr) - 128
+				}
+			}
+			for c := 0; c < 3; c++ {
+				t := 0
+				if c > 0 {
+					t = 1
+				}
+				prevDC[c] = e.writeBlock(&blocks[c], t, prevDC[c])
+			}
+		}
+	}
+	// Pad the last byte with 1 bits.
+	e.emit(0x7F, 7)
+	e.write([]byte{0xFF, 0xD9})
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+func (e *jpegEncoder) write(p []byte) {
+	if e.err == nil {
+		_, e.err = e.w.Write(p)
+	}
+}
+
+// emit appends the low n bits of bits to the entropy-coded data, stuffing a
+// zero byte after every 0xFF.
+func (e *jpegEncoder) emit(bits uint32, n uint) {
+	bits &= 1<<n - 1
+	e.acc |= bits << (32 - e.nacc - n)
+	e.nacc += n
+	for e.nacc >= 8 {
+		c := byte(e.acc >> 24)
+		e.write([]byte{c})
+		if c == 0xFF {
+			e.write([]byte{0})
+		}
+		e.acc <<= 8
+		e.nacc -= 8
+	}
+}
+
+// emitValue writes symbol with Huffman table h followed by the size low bits
+// of v in the JPEG magnitude encoding.
+func (e *jpegEncoder) emitValue(h int, symbol byte, v int, size uint) {
+	hc := e.huff[h][symbol]
+	e.emit(hc.code, hc.bits)
+	if size > 0 {
+		if v < 0 {
+			v--
+		}
+		e.emit(uint32(v), size)
+	}
+}
+
+// writeBlock transforms, quantizes and entropy-codes one 8x8 block with
+// quantization table t, and returns its DC coefficient.
+func (e *jpegEncoder) writeBlock(block *[64]float64, t, prevDC int) int {
+	var tmp, coef [64]float64
+	for y := 0; y < 8; y++ {
+		for u := 0; u < 8; u++ {
+			s := 0.0
+			for x := 0; x < 8; x++ {
+				s += jpegDCTCos[u][x] * block[y*8+x]
+			}
+			tmp[y*8+u] = s
+		}
+	}
+	for u := 0; u < 8; u++ {
+		for v := 0; v < 8; v++ {
+			s := 0.0
+			for y := 0; y < 8; y++ {
+				s += jpegDCTCos[v][y] * tmp[y*8+u]
+			}
+			coef[v*8+u] = s
+		}
+	}
+
+	var q [64]int
+	for k := 0; k < 64; k++ {
+		q[k] = int(math.Round(coef[jpegUnzig[k]] / e.quant[t][k]))
+	}
+
+	dcTable, acTable := 2*t, 2*t+1
+	diff := q[0] - prevDC
+	e.emitValue(dcTable, byte(bitLength(diff)), diff, bitLength(diff))
+	run := 0
+	for k := 1; k < 64; k++ {
+		if q[k] == 0 {
+			run++
+			continue
+		}
+		for run > 15 {
+			e.emitValue(acTable, 0xF0, 0, 0)
+			run -= 16
+		}
+		n := bitLength(q[k])
+		e.emitValue(acTable, byte(run<<4)|byte(n), q[k], n)
+		run = 0
+	}
+	if run > 0 {
+		e.emitValue(acTable, 0x00, 0, 0)
+	}
+	return q[0]
+}
+
+// bitLength returns the number of bits needed for |v|.
+func bitLength(v int) uint {
+	if v < 0 {
+		v = -v
+	}
+	n := uint(0)
+	for v > 0 {
+		n++
+		v >>= 1
+	}
+	return n
+}
+
+// --- PNG palette quantization ---
+
+// quantizePalette reduces img to at most n colors chosen by median cut, for
+// small PNG thumbnails. Colors are grouped into 15-bit buckets first, so
+// flat slide backgrounds and text colors map to a single entry each.
+func quantizePalette(img image.Image, n int) *image.Paletted {
+	n = minInt(maxInt(n, 2), 256)
+	b := img.Bounds()
+	rgba, _ := img.(*image.RGBA)
+	at := func(x, y int) (uint8, uint8, uint8, uint8) {
+		if rgba != nil {
+			i := rgba.PixOffset(x, y)
+			return rgba.Pix[i], rgba.Pix[i+1], rgba.Pix[i+2], rgba.Pix[i+3]
+		}
+		c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+		return c.R, c.G, c.B, c.A
+	}
+	key := func(r, g, bl uint8) int { return int(r>>3)<<10 | int(g>>3)<<5 | int(bl>>3) }
+
+	type bucket struct {
+		key             int
+		r, g, b, a, cnt int
+	}
+	buckets := make(map[int]*bucket)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r, g, bl, a := at(x, y)
+			k := key(r, g, bl)
+			bk := buckets[k]
+			if bk == nil {
+				bk = &bucket{key: k}
+				buckets[k] = bk
+			}
+			bk.r += int(r)
+			bk.g += int(g)
+			bk.b += int(bl)
+			bk.a += int(a)
+			bk.cnt++
+		}
+	}
+	all := make([]*bucket, 0, len(buckets))
+	for _, bk := range buckets {
+		all = append(all, bk)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].key < all[j].key })
+
+	// channel returns the 5-bit value of channel ch (0 red, 1 green, 2 blue).
+	channel := func(bk *bucket, ch int) int { return bk.key >> (10 - 5*ch) & 31 }
+	boxes := [][]*bucket{all}
+	for len(boxes) < n {
+		// Split the box with the widest channel range at its pixel median.
+		best, bestCh, bestRange := -1, 0, 0
+		for i, box := range boxes {
+			if len(box) < 2 {
+				continue
+			}
+			for ch := 0; ch < 3; ch++ {
+				lo, hi := 31, 0
+				for _, bk := range box {
+					v := channel(bk, ch)
+					lo, hi = minInt(lo, v), maxInt(hi, v)
+				}
+				if hi-lo > bestRange {
+					best, bestCh, bestRange = i, ch, hi-lo
+				}
+			}
+		}
+		if best < 0 {
+			break
+		}
+		box := boxes[best]
+		sort.Slice(box, func(i, j int) bool { return channel(box[i], bestCh) < channel(box[j], bestCh) })
+		total := 0
+		for _, bk := range box {
+			total += bk.cnt
+		}
+		split, seen := 1, 0
+		for i, bk := range box[:len(box)-1] {
+			seen += bk.cnt
+			split = i + 1
+			if seen*2 >= total {
+				break
+			}
+		}
+		boxes[best] = box[:split]
+		boxes = append(boxes, box[split:])
+	}
+
+	pal := make(color.Palette, len(boxes))
+	index := make(map[int]uint8, len(all))
+	for i, box := range boxes {
+		var r, g, bl, a, cnt int
+		for _, bk := range box {
+			r, g, bl, a, cnt = r+bk.r, g+bk.g, bl+bk.b, a+bk.a, cnt+bk.cnt
+			index[bk.key] = uint8(i)
+		}
+		pal[i] = color.NRGBA{R: uint8(r / cnt), G: uint8(g / cnt), B: uint8(bl / cnt), A: uint8(a / cnt)}
+	}
+
+	out := image.NewPaletted(b, pal)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		row := out.Pix[(y-b.Min.Y)*out.Stride:]
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r, g, bl, _ := at(x, y)
+			row[x-b.Min.X] = index[key(r, g, bl)]
+		}
+	}
+	return out
+}
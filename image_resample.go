@@ -0,0 +1,264 @@
+package gopresentation
+
+import (
+	"image"
+	"image/draw"
+	"math"
+)
+
+// ImageFilter selects how pictures are resampled to their size on the slide.
+type ImageFilter int
+
+const (
+	// ImageFilterAuto averages the covered source pixels when a picture is
+	// shrunk to less than half its size, uses Lanczos when it is enlarged
+	// and bilinear interpolation otherwise.
+	ImageFilterAuto ImageFilter = iota
+	// ImageFilterBilinear interpolates between the four nearest source
+	// pixels. It is fast, but aliases when shrinking a lot, which shows as
+	// moiré on photographed text and charts.
+	ImageFilterBilinear
+	// ImageFilterAreaAverage averages the source pixels covered by each
+	// output pixel. It is the best choice for shrinking.
+	ImageFilterAreaAverage
+	// ImageFilterLanczos uses a three-lobed Lanczos kernel, which keeps
+	// enlarged pictures sharp.
+	ImageFilterLanczos
+	// ImageFilterNearest takes the nearest source pixel, keeping hard
+	// edges in enlarged pixel art, QR codes and barcodes. It aliases badly
+	// when shrinking.
+	ImageFilterNearest
+)
+
+// pixelatedScaleSnap is the least ImageScaleSnap tolerance used for
+// pixelated pictures, so that the modules of a QR code stay equal in size.
+const pixelatedScaleSnap = 0.1
+
+// lanczosLobes is the radius of the Lanczos kernel in source pixels.
+const lanczosLobes = 3
+
+// scaleImageFiltered scales src to dstW by dstH pixels with filter.
+func scaleImageFiltered(src image.Image, dstW, dstH int, filter ImageFilter) *image.RGBA {
+	b := src.Bounds()
+	if filter == ImageFilterAuto {
+		switch {
+		case dstW*2 <= b.Dx() || dstH*2 <= b.Dy():
+			filter = ImageFilterAreaAverage
+		case dstW > b.Dx() || dstH > b.Dy():
+			filter = ImageFilterLanczos
+		default:
+			filter = ImageFilterBilinear
+		}
+	}
+	if filter == ImageFilterNearest && dstW > 0 && dstH > 0 {
+		return scaleImageNearest(src, dstW, dstH)
+	}
+	if filter == ImageFilterBilinear || dstW <= 0 || dstH <= 0 || b.Dx() <= 0 || b.Dy() <= 0 {
+		return scaleImageBilinear(src, dstW, dstH)
+	}
+
+	rgba, ok := src.(*image.RGBA)
+	if !ok {
+		rgba = image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+		draw.Draw(rgba, rgba.Rect, src, b.Min, draw.Src)
+	} else if rgba.Rect != b {
+		rgba = rgba.SubImage(b).(*image.RGBA)
+	}
+	var xw, yw []resampleTaps
+	if filter == ImageFilterAreaAverage {
+		xw, yw = areaTaps(b.Dx(), dstW), areaTaps(b.Dy(), dstH)
+	} else {
+		xw, yw = lanczosTaps(b.Dx(), dstW), lanczosTaps(b.Dy(), dstH)
+	}
+	return resampleSeparable(rgba, dstW, dstH, xw, yw)
+}
+
+// resampleTaps are the weights of the source pixels from first on that make
+// up one output pixel. They sum to 1.
+type resampleTaps struct {
+	first   int
+	weights []float32
+}
+
+// areaTaps weighs each source pixel by how much of it an output pixel
+// covers.
+func areaTaps(srcN, dstN int) []resampleTaps {
+	taps := make([]resampleTaps, dstN)
+	ratio := float64(srcN) / float64(dstN)
+	for i := range taps {
+		lo, hi := float64(i)*ratio, float64(i+1)*ratio
+		first := int(lo)
+		last := minInt(int(math.Ceil(hi)), srcN)
+		w := make([]float32, 0, last-first)
+		for s := first; s < last; s++ {
+			w = append(w, float32((math.Min(hi, float64(s+1))-math.Max(lo, float64(s)))/ratio))
+		}
+		taps[i] = resampleTaps{first: first, weights: w}
+	}
+	return taps
+}
+
+// lanczosTaps samples the Lanczos kernel around the centre of each output
+// pixel, widened when shrinking so that every source pixel contributes.
+func lanczosTaps(srcN, dstN int) []resampleTaps {
+	taps := make([]resampleTaps, dstN)
+	ratio := float64(srcN) / float64(dstN)
+	scale := math.Max(ratio, 1)
+	support := lanczosLobes * scale
+	for i := range taps {
+		centre := (float64(i)+0.5)*ratio - 0.5
+		first := maxInt(int(math.Ceil(centre-support)), 0)
+		last := minInt(int(math.Floor(centre+support)), srcN-1)
+		w := make([]float32, 0, last-first+1)
+		var sum float64
+		for s := first; s <= last; s++ {
+			v := lanczos((float64(s) - centre) / scale)
+			w = append(w, float32(v))
+			sum += v
+		}
+		if sum != 0 {
+			for k := range w {
+				w[k] = float32(float64(w[k]) / sum)
+			}
+		}
+		taps[i] = resampleTaps{first: first, weights: w}
+	}
+	return taps
+}
+
+// lanczos is the Lanczos window of lanczosLobes lobes.
+func lanczos(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	if x <= -lanczosLobes || x >= lanczosLobes {
+		return 0
+	}
+	px := math.Pi * x
+	return lanczosLobes * math.Sin(px) * math.Sin(px/lanczosLobes) / (px * px)
+}
+
+// resampleSeparable scales the premultiplied src horizontally and then
+// vertically with the given taps. Results are clamped so that no color
+// exceeds its alpha where a negative lobe overshoots.
+func resampleSeparable(src *image.RGBA, dstW, dstH int, xw, yw []resampleTaps) *image.RGBA {
+	srcH := src.Rect.Dy()
+	tmp := make([]float32, srcH*dstW*4)
+	for y := 0; y < srcH; y++ {
+		row := src.Pix[y*src.Stride:]
+		out := tmp[y*dstW*4:]
+		for x, t := range xw {
+			var r, g, b, a float32
+			for k, w := range t.weights {
+				p := row[(t.first+k)*4:]
+				r += float32(p[0]) * w
+				g += float32(p[1]) * w
+				b += float32(p[2]) * w
+				a += float32(p[3]) * w
+			}
+			out[x*4], out[x*4+1], out[x*4+2], out[x*4+3] = r, g, b, a
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	acc := make([]float32, dstW*4)
+	for y, t := range yw {
+		clear(acc)
+		for k, w := range t.weights {
+			row := tmp[(t.first+k)*dstW*4:][:dstW*4]
+			for i, v := range row {
+				acc[i] += v * w
+			}
+		}
+		out := dst.Pix[y*dst.Stride:]
+		for i := 0; i < len(acc); i += 4 {
+			a := clamp8(float64(acc[i+3]))
+			out[i] = min(clamp8(float64(acc[i])), a)
+			out[i+1] = min(clamp8(float64(acc[i+1])), a)
+			out[i+2] = min(clamp8(float64(acc[i+2])), a)
+			out[i+3] = a
+		}
+	}
+	return dst
+}
+
+// scaleImageNearest scales src to dstW by dstH pixels, taking each output
+// pixel from the source pixel under its centre.
+func scaleImageNearest(src image.Image, dstW, dstH int) *image.RGBA {
+	b := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	if b.Dx() <= 0 || b.Dy() <= 0 {
+		return dst
+	}
+	rgba, ok := src.(*image.RGBA)
+	if !ok {
+		rgba = image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+		draw.Draw(rgba, rgba.Rect, src, b.Min, draw.Src)
+		b = rgba.Rect
+	}
+	for y := 0; y < dstH; y++ {
+		sy := b.Min.Y + (2*y+1)*b.Dy()/(2*dstH)
+		row := dst.Pix[y*dst.Stride : y*dst.Stride+dstW*4]
+		for x := 0; x < dstW; x++ {
+			sx := b.Min.X + (2*x+1)*b.Dx()/(2*dstW)
+			i := rgba.PixOffset(sx, sy)
+			copy(row[x*4:x*4+4], rgba.Pix[i:i+4])
+		}
+	}
+	return dst
+}
+
+// snapImageScale returns the size of a srcW by srcH picture scaled by the
+// whole number, or one over the whole number, nearest its scale to dstW by
+// dstH, if that size is within tol of the destination in both directions.
+func snapImageScale(srcW, srcH, dstW, dstH int, tol float64) (w, h int, ok bool) {
+	if tol <= 0 || srcW <= 0 || srcH <= 0 || dstW <= 0 || dstH <= 0 {
+		return 0, 0, false
+	}
+	if scale := float64(dstW) / float64(srcW); scale >= 1 {
+		k := int(math.Round(scale))
+		w, h = srcW*k, srcH*k
+	} else {
+		k := math.Round(1 / scale)
+		w, h = maxInt(int(math.Round(float64(srcW)/k)), 1), maxInt(int(math.Round(float64(srcH)/k)), 1)
+	}
+	if math.Abs(float64(w-dstW)) > tol*float64(dstW) || math.Abs(float64(h-dstH)) > tol*float64(dstH) {
+		return 0, 0, false
+	}
+	return w, h, true
+}
+
+// isBitonalImage reports whether img is high-contrast two-tone, like a QR
+// code or barcode: nearly all of its visible pixels are dark or light, with
+// both present. A few in-between pixels from compression are allowed.
+func isBitonalImage(img image.Image) bool {
+	b := img.Bounds()
+	total := b.Dx() * b.Dy()
+	if total == 0 {
+		return false
+	}
+	allowed := total / 50
+	mid := 0
+	dark, light := false, false
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r, g, bl, a := img.At(x, y).RGBA()
+			if a < 0x8000 {
+				continue
+			}
+			// Luma of the unpremultiplied color, 0-255.
+			l := (299*r + 587*g + 114*bl) / (1000 * a / 255)
+			switch {
+			case l < 64:
+				dark = true
+			case l > 192:
+				light = true
+			default:
+				if mid++; mid > allowed {
+					return false
+				}
+			}
+		}
+	}
+	return dark && light
+}
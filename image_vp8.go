@@ -0,0 +1,833 @@
+package gopresentation
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// --- WebP lossy encoder (VP8) ---
+//
+// Lossy WebP output is a single VP8 key frame. Each 16x16 macroblock takes
+// the DC, TrueMotion, vertical or horizontal predictor that leaves the
+// smallest error, for its luma and its 8x8 chroma blocks alike; the
+// residuals are transformed, quantized with the step the quality asks for
+// and arithmetic coded, with token probabilities fitted to the image by a
+// first counting pass. Predictions are made from the pixels as the decoder
+// rebuilds them, so quantization errors do not build up across the slide.
+// Transparent images carry their alpha losslessly in an ALPH chunk.
+
+// Dimensions of the token probability tables.
+const (
+	vp8Planes     = 4
+	vp8Bands      = 8
+	vp8Contexts   = 3
+	vp8TokenProbs = 11
+)
+
+// Token planes: luma blocks whose DC is coded in the Y2 block, the Y2 block
+// of luma DCs, and chroma blocks. Plane 3, luma blocks with their own DC,
+// belongs to 4x4 prediction, which the encoder does not use.
+const (
+	vp8PlaneYAC = iota
+	vp8PlaneY2
+	vp8PlaneUV
+)
+
+// Intra predictors of 16x16 luma and 8x8 chroma blocks.
+const (
+	vp8PredDC = iota
+	vp8PredTM
+	vp8PredVE
+	vp8PredHE
+	vp8Preds
+)
+
+var (
+	// vp8BandOf maps a coefficient position to its probability band; the
+	// 17th entry serves the position after the last.
+	vp8BandOf = [17]uint8{0, 1, 2, 3, 6, 4, 5, 6, 6, 6, 6, 6, 6, 6, 6, 7, 0}
+	// vp8Zigzag maps coding order to the row-major position in a 4x4 block.
+	vp8Zigzag = [16]uint8{0, 1, 4, 8, 5, 2, 3, 6, 9, 12, 13, 10, 7, 11, 14, 15}
+	// vp8CatProbs are the fixed probabilities of the extra bits of the
+	// token categories 3 to 6, most significant first.
+	vp8CatProbs = [4][]uint8{
+		{173, 148, 140},
+		{176, 155, 140, 135},
+		{180, 157, 141, 134, 130},
+		{254, 254, 243, 230, 196, 177, 153, 140, 133, 130, 129},
+	}
+)
+
+// vp8MaxLevel is the largest quantized coefficient the tokens can code.
+const vp8MaxLevel = 2047
+
+// vp8TokenTable holds a probability for each token branch, by plane, band
+// and context.
+type vp8TokenTable [vp8Planes][vp8Bands][vp8Contexts][vp8TokenProbs]uint8
+
+// vp8Macroblock holds the choices and quantized levels of a macroblock.
+type vp8Macroblock struct {
+	yMode, uvMode uint8
+	skip          bool // no level is non-zero
+	// levels are in coding order: 16 luma blocks in raster order, 4 U and
+	// 4 V blocks, then the Y2 block.
+	levels [25][16]int16
+}
+
+// vp8Encoder encodes one key frame.
+type vp8Encoder struct {
+	mbw, mbh         int
+	yStride, cStride int
+	// Source planes, padded to whole macroblocks, and the planes as the
+	// decoder rebuilds them.
+	y, u, v    []uint8
+	ry, ru, rv []uint8
+	// Quantizer steps of the DC and AC coefficients.
+	y1, y2, uv [2]int32
+	mbs        []vp8Macroblock
+}
+
+// encodeWebPLossy writes the ARGB pixels as a lossy WebP file at quality
+// 1-99.
+func encodeWebPLossy(w io.Writer, argb []uint32, alpha bool, width, height, quality int) error {
+	e := newVP8Encoder(argb, width, height, vp8QuantIndex(quality))
+	for mby := 0; mby < e.mbh; mby++ {
+		for mbx := 0; mbx < e.mbw; mbx++ {
+			e.encodeMacroblock(mbx, mby)
+		}
+	}
+	frame := e.frame(width, height, vp8QuantIndex(quality))
+	if !alpha {
+		return writeWebPChunks(w, webpChunk{"VP8 ", frame})
+	}
+
+	// The alpha plane is coded as the green channel of a lossless image
+	// stream, without the VP8L header.
+	a := make([]uint32, len(argb))
+	for i, c := range argb {
+		a[i] = 0xff000000 | c>>24<<8
+	}
+	alph := webpLosslessStream(a, width, height, false)
+	alph[4] = 1 // ALPH header: lossless, no filtering or preprocessing
+	var vp8x [10]byte
+	vp8x[0] = 0x10 // alpha
+	putUint24(vp8x[4:], uint32(width-1))
+	putUint24(vp8x[7:], uint32(height-1))
+	return writeWebPChunks(w, webpChunk{"VP8X", vp8x[:]}, webpChunk{"ALPH", alph[4:]}, webpChunk{"VP8 ", frame})
+}
+
+// putUint24 stores v in three little-endian bytes.
+func putUint24(b []byte, v uint32) {
+	b[0], b[1], b[2] = byte(v), byte(v>>8), byte(v>>16)
+}
+
+// vp8QuantIndex maps quality 1-99 to a quantizer index 0-127 along a cube
+// root curve, which spends the fine steps on the high qualities where
+// differences are still visible.
+func vp8QuantIndex(quality int) int {
+	c := float64(min(max(quality, 1), 100)) / 100
+	linear := 2*c - 1
+	if c < 0.75 {
+		linear = c * 2 / 3
+	}
+	return min(max(int(math.Round(127*(1-math.Cbrt(linear)))), 0), 127)
+}
+
+// newVP8Encoder converts the pixels to limited-range BT.601 YCbCr 4:2:0,
+// as WebP decoders expect, padding the planes to whole macroblocks by
+// repeating the last row and column.
+func newVP8Encoder(argb []uint32, width, height, qi int) *vp8Encoder {
+	e := &vp8Encoder{mbw: (width + 15) / 16, mbh: (height + 15) / 16}
+	e.yStride, e.cStride = 16*e.mbw, 8*e.mbw
+	ySize, cSize := e.yStride*16*e.mbh, e.cStride*8*e.mbh
+	e.y, e.ry = make([]uint8, ySize), make([]uint8, ySize)
+	e.u, e.ru = make([]uint8, cSize), make([]uint8, cSize)
+	e.v, e.rv = make([]uint8, cSize), make([]uint8, cSize)
+	e.mbs = make([]vp8Macroblock, e.mbw*e.mbh)
+
+	pixel := func(x, y int) (int32, int32, int32) {
+		c := argb[min(y, height-1)*width+min(x, width-1)]
+		return int32(c >> 16 & 0xff), int32(c >> 8 & 0xff), int32(c & 0xff)
+	}
+	for y := 0; y < 16*e.mbh; y++ {
+		for x := 0; x < e.yStride; x++ {
+			r, g, b := pixel(x, y)
+			e.y[y*e.yStride+x] = uint8((16839*r + 33059*g + 6420*b + 16<<16 + 1<<15) >> 16)
+		}
+	}
+	for y := 0; y < 8*e.mbh; y++ {
+		for x := 0; x < e.cStride; x++ {
+			var r, g, b int32
+			for _, d := range [4][2]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+				pr, pg, pb := pixel(2*x+d[0], 2*y+d[1])
+				r, g, b = r+pr, g+pg, b+pb
+			}
+			// r, g and b are sums of four pixels, hence the extra 2 bits.
+			e.u[y*e.cStride+x] = uint8((-9719*r - 19081*g + 28800*b + 128<<18 + 1<<17) >> 18)
+			e.v[y*e.cStride+x] = uint8((28800*r - 24116*g - 4684*b + 128<<18 + 1<<17) >> 18)
+		}
+	}
+
+	e.y1 = [2]int32{vp8DCSteps[qi], vp8ACSteps[qi]}
+	e.y2 = [2]int32{vp8DCSteps[qi] * 2, max(vp8ACSteps[qi]*155/100, 8)}
+	e.uv = [2]int32{vp8DCSteps[min(qi, 117)], vp8ACSteps[qi]}
+	return e
+}
+
+// encodeMacroblock predicts, transforms and quantizes a macroblock and
+// writes its reconstruction to the rebuilt planes.
+func (e *vp8Encoder) encodeMacroblock(mbx, mby int) {
+	mb := &e.mbs[mby*e.mbw+mbx]
+
+	// Luma: one 16x16 prediction, with the DCs of the 16 blocks gathered in
+	// the Y2 block.
+	var pred [256]uint8
+	mb.yMode = e.predict(e.y, e.ry, e.yStride, 16, mbx, mby, pred[:])
+	var coeffs [16][16]int32
+	var dcs [16]int32
+	for b := range coeffs {
+		e.residual(e.y, e.yStride, 16, mbx, mby, b%4*4, b/4*4, pred[:], &coeffs[b])
+		dcs[b] = coeffs[b][0]
+	}
+	var y2 [16]int32
+	vp8WHT(&dcs, &y2)
+	nz := vp8Quantize(&y2, &mb.levels[24], e.y2, 0)
+	vp8InverseWHT(&y2, &dcs)
+	for b := range coeffs {
+		if vp8Quantize(&coeffs[b], &mb.levels[b], e.y1, 1) {
+			nz = true
+		}
+		coeffs[b][0] = dcs[b]
+		vp8InverseDCT(&coeffs[b], pred[b/4*4*16+b%4*4:], 16)
+	}
+	e.store(e.ry, e.yStride, 16, mbx, mby, pred[:])
+
+	// Chroma: both planes take the predictor that suits them together.
+	var predU, predV [64]uint8
+	mb.uvMode = e.predictChroma(mbx, mby, predU[:], predV[:])
+	for i, pl := range [2]struct {
+		src, rec []uint8
+		pred     []uint8
+	}{{e.u, e.ru, predU[:]}, {e.v, e.rv, predV[:]}} {
+		for b := 0; b < 4; b++ {
+			var c [16]int32
+			e.residual(pl.src, e.cStride, 8, mbx, mby, b%2*4, b/2*4, pl.pred, &c)
+			if vp8Quantize(&c, &mb.levels[16+4*i+b], e.uv, 0) {
+				nz = true
+			}
+			vp8InverseDCT(&c, pl.pred[b/2*4*8+b%2*4:], 8)
+		}
+		e.store(pl.rec, e.cStride, 8, mbx, mby, pl.pred)
+	}
+	mb.skip = !nz
+}
+
+// edges returns the rebuilt pixels above (after the corner pixel) and
+// left of an n x n block, with the values the decoder assumes beyond the
+// frame's top and left edges.
+func (e *vp8Encoder) edges(rec []uint8, stride, n, mbx, mby int) (top [17]uint8, left [16]uint8) {
+	x0, y0 := mbx*n, mby*n
+	if mby == 0 {
+		for i := range top {
+			top[i] = 127
+		}
+	} else {
+		row := rec[(y0-1)*stride:]
+		copy(top[1:], row[x0:x0+n])
+		top[0] = 129
+		if mbx > 0 {
+			top[0] = row[x0-1]
+		}
+	}
+	for j := 0; j < n; j++ {
+		left[j] = 129
+		if mbx > 0 {
+			left[j] = rec[(y0+j)*stride+x0-1]
+		}
+	}
+	return top, left
+}
+
+// fillPrediction writes the n x n prediction of mode into dst.
+func fillPrediction(dst []uint8, mode, n, mbx, mby int, top *[17]uint8, left *[16]uint8) {
+	switch mode {
+	case vp8PredDC:
+		sum, count := 0, 0
+		if mby > 0 {
+			for _, v := range top[1 : n+1] {
+				sum += int(v)
+			}
+			count += n
+		}
+		if mbx > 0 {
+			for _, v := range left[:n] {
+				sum += int(v)
+			}
+			count += n
+		}
+		dc := uint8(128)
+		if count > 0 {
+			dc = uint8((sum + count/2) / count)
+		}
+		for i := range dst[:n*n] {
+			dst[i] = dc
+		}
+	case vp8PredTM:
+		for j := 0; j < n; j++ {
+			for i := 0; i < n; i++ {
+				dst[j*n+i] = vp8Clip(int(left[j]) + int(top[1+i]) - int(top[0]))
+			}
+		}
+	case vp8PredVE:
+		for j := 0; j < n; j++ {
+			copy(dst[j*n:j*n+n], top[1:n+1])
+		}
+	case vp8PredHE:
+		for j := 0; j < n; j++ {
+			for i := 0; i < n; i++ {
+				dst[j*n+i] = left[j]
+			}
+		}
+	}
+}
+
+// predict writes the best prediction of the n x n block at (mbx, mby) into
+// pred and returns its mode.
+func (e *vp8Encoder) predict(src, rec []uint8, stride, n, mbx, mby int, pred []uint8) uint8 {
+	top, left := e.edges(rec, stride, n, mbx, mby)
+	best, bestErr := 0, -1
+	for mode := 0; mode < vp8Preds; mode++ {
+		fillPrediction(pred, mode, n, mbx, mby, &top, &left)
+		if err := blockError(src, stride, n, mbx, mby, pred); bestErr < 0 || err < bestErr {
+			best, bestErr = mode, err
+		}
+	}
+	fillPrediction(pred, best, n, mbx, mby, &top, &left)
+	return uint8(best)
+}
+
+// predictChroma is predict for the U and V blocks, which share one mode.
+func (e *vp8Encoder) predictChroma(mbx, mby int, predU, predV []uint8) uint8 {
+	topU, leftU := e.edges(e.ru, e.cStride, 8, mbx, mby)
+	topV, leftV := e.edges(e.rv, e.cStride, 8, mbx, mby)
+	best, bestErr := 0, -1
+	for mode := 0; mode < vp8Preds; mode++ {
+		fillPrediction(predU, mode, 8, mbx, mby, &topU, &leftU)
+		fillPrediction(predV, mode, 8, mbx, mby, &topV, &leftV)
+		err := blockError(e.u, e.cStride, 8, mbx, mby, predU) + blockError(e.v, e.cStride, 8, mbx, mby, predV)
+		if bestErr < 0 || err < bestErr {
+			best, bestErr = mode, err
+		}
+	}
+	fillPrediction(predU, best, 8, mbx, mby, &topU, &leftU)
+	fillPrediction(predV, best, 8, mbx, mby, &topV, &leftV)
+	return uint8(best)
+}
+
+// blockError returns the squared error of the n x n prediction against the
+// source block at (mbx, mby).
+func blockError(src []uint8, stride, n, mbx, mby int, pred []uint8) int {
+	sum := 0
+	for j := 0; j < n; j++ {
+		row := src[(mby*n+j)*stride+mbx*n:]
+		for i := 0; i < n; i++ {
+			d := int(row[i]) - int(pred[j*n+i])
+			sum += d * d
+		}
+	}
+	return sum
+}
+
+// residual transforms the difference between the source and the
+// prediction of the 4x4 block at (bx, by) within the n x n block.
+func (e *vp8Encoder) residual(src []uint8, stride, n, mbx, mby, bx, by int, pred []uint8, out *[16]int32) {
+	var d [16]int32
+	for j := 0; j < 4; j++ {
+		row := src[(mby*n+by+j)*stride+mbx*n+bx:]
+		for i := 0; i < 4; i++ {
+			d[j*4+i] = int32(row[i]) - int32(pred[(by+j)*n+bx+i])
+		}
+	}
+	vp8DCT(&d, out)
+}
+
+// store copies the rebuilt n x n block into the plane.
+func (e *vp8Encoder) store(rec []uint8, stride, n, mbx, mby int, block []uint8) {
+	for j := 0; j < n; j++ {
+		copy(rec[(mby*n+j)*stride+mbx*n:], block[j*n:j*n+n])
+	}
+}
+
+// vp8Quantize quantizes the row-major coefficients c from coding position
+// first on, storing the levels in coding order and replacing c with the
+// values the decoder will see. It reports whether any level is non-zero.
+func vp8Quantize(c *[16]int32, levels *[16]int16, steps [2]int32, first int) bool {
+	nz := false
+	for n := first; n < 16; n++ {
+		z := vp8Zigzag[n]
+		// Rounding a little below the half step zeroes more coefficients
+		// that are barely above it, which costs little quality.
+		step, bias := steps[1], steps[1]*110>>8
+		if z == 0 {
+			step, bias = steps[0], steps[0]*96>>8
+		}
+		v := c[z]
+		l := min((abs32(v)+bias)/step, vp8MaxLevel)
+		if v < 0 {
+			l = -l
+		}
+		levels[n] = int16(l)
+		c[z] = l * step
+		nz = nz || l != 0
+	}
+	return nz
+}
+
+func abs32(v int32) int32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// vp8DCT is the forward 4x4 transform of the VP8 reference encoder.
+func vp8DCT(in, out *[16]int32) {
+	var t [16]int32
+	for i := 0; i < 4; i++ {
+		r := in[4*i : 4*i+4]
+		a := (r[0] + r[3]) * 8
+		b := (r[1] + r[2]) * 8
+		c := (r[1] - r[2]) * 8
+		d := (r[0] - r[3]) * 8
+		t[4*i] = a + b
+		t[4*i+2] = a - b
+		t[4*i+1] = (c*2217 + d*5352 + 14500) >> 12
+		t[4*i+3] = (d*2217 - c*5352 + 7500) >> 12
+	}
+	for i := 0; i < 4; i++ {
+		a := t[i] + t[12+i]
+		b := t[4+i] + t[8+i]
+		c := t[4+i] - t[8+i]
+		d := t[i] - t[12+i]
+		out[i] = (a + b + 7) >> 4
+		out[8+i] = (a - b + 7) >> 4
+		out[4+i] = (c*2217 + d*5352 + 12000) >> 16
+		if d != 0 {
+			out[4+i]++
+		}
+		out[12+i] = (d*2217 - c*5352 + 51000) >> 16
+	}
+}
+
+// vp8InverseDCT adds the inverse transform of c to the 4x4 block at dst,
+// rounding exactly as decoders do.
+func vp8InverseDCT(c *[16]int32, dst []uint8, stride int) {
+	const (
+		c1 = 85627 // 65536 * cos(pi/8) * sqrt(2)
+		c2 = 35468 // 65536 * sin(pi/8) * sqrt(2)
+	)
+	var m [4][4]int32
+	for i := 0; i < 4; i++ {
+		a := c[i] + c[8+i]
+		b := c[i] - c[8+i]
+		t := (c[4+i]*c2)>>16 - (c[12+i]*c1)>>16
+		d := (c[4+i]*c1)>>16 + (c[12+i]*c2)>>16
+		m[i] = [4]int32{a + d, b + t, b - t, a - d}
+	}
+	for j := 0; j < 4; j++ {
+		dc := m[0][j] + 4
+		a := dc + m[2][j]
+		b := dc - m[2][j]
+		t := (m[1][j]*c2)>>16 - (m[3][j]*c1)>>16
+		d := (m[1][j]*c1)>>16 + (m[3][j]*c2)>>16
+		row := dst[j*stride : j*stride+4]
+		for i, v := range [4]int32{a + d, b + t, b - t, a - d} {
+			row[i] = vp8Clip(int(row[i]) + int(v>>3))
+		}
+	}
+}
+
+// vp8WHT is the forward Walsh-Hadamard transform of the 16 luma DCs of the
+// VP8 reference encoder.
+func vp8WHT(in, out *[16]int32) {
+	var t [16]int32
+	for i := 0; i < 4; i++ {
+		r := in[4*i : 4*i+4]
+		a := (r[0] + r[2]) * 4
+		d := (r[1] + r[3]) * 4
+		c := (r[1] - r[3]) * 4
+		b := (r[0] - r[2]) * 4
+		t[4*i] = a + d
+		if a != 0 {
+			t[4*i]++
+		}
+		t[4*i+1] = b + c
+		t[4*i+2] = b - c
+		t[4*i+3] = a - d
+	}
+	for i := 0; i < 4; i++ {
+		a := t[i] + t[8+i]
+		d := t[4+i] + t[12+i]
+		c := t[4+i] - t[12+i]
+		b := t[i] - t[8+i]
+		for k, v := range [4]int32{a + d, b + c, b - c, a - d} {
+			if v < 0 {
+				v++
+			}
+			out[4*k+i] = (v + 3) >> 3
+		}
+	}
+}
+
+// vp8InverseWHT turns the dequantized Y2 block back into the 16 luma DCs,
+// rounding exactly as decoders do.
+func vp8InverseWHT(in, dcs *[16]int32) {
+	var m [16]int32
+	for i := 0; i < 4; i++ {
+		a0 := in[i] + in[12+i]
+		a1 := in[4+i] + in[8+i]
+		a2 := in[4+i] - in[8+i]
+		a3 := in[i] - in[12+i]
+		m[i] = a0 + a1
+		m[8+i] = a0 - a1
+		m[4+i] = a3 + a2
+		m[12+i] = a3 - a2
+	}
+	for i := 0; i < 4; i++ {
+		dc := m[4*i] + 3
+		a0 := dc + m[4*i+3]
+		a1 := m[4*i+1] + m[4*i+2]
+		a2 := m[4*i+1] - m[4*i+2]
+		a3 := dc - m[4*i+3]
+		dcs[4*i] = (a0 + a1) >> 3
+		dcs[4*i+1] = (a3 + a2) >> 3
+		dcs[4*i+2] = (a0 - a1) >> 3
+		dcs[4*i+3] = (a3 - a2) >> 3
+	}
+}
+
+// frame codes the macroblocks as a VP8 key frame: the frame header, the
+// first partition with the frame settings and the macroblock modes, and
+// the partition of coefficient tokens.
+func (e *vp8Encoder) frame(width, height, qi int) []byte {
+	// Count how often each token branch is taken and replace the default
+	// probabilities where the saving pays for the update.
+	var stats [vp8Planes][vp8Bands][vp8Contexts][vp8TokenProbs][2]uint32
+	e.writeTokens(&vp8TokenCoder{probs: &vp8DefaultTokenProb, stats: &stats})
+	probs := vp8DefaultTokenProb
+	var update [vp8Planes][vp8Bands][vp8Contexts][vp8TokenProbs]bool
+	for i := range probs {
+		for j := range probs[i] {
+			for k := range probs[i][j] {
+				for l, old := range probs[i][j][k] {
+					n0, n1 := stats[i][j][k][l][0], stats[i][j][k][l][1]
+					if n0+n1 == 0 {
+						continue
+					}
+					p := uint8(min(max((255*n0+(n0+n1)/2)/(n0+n1), 1), 255))
+					up := vp8TokenUpdateProb[i][j][k][l]
+					keep := vp8BitCost(old, n0, n1) + vp8BitCost(up, 1, 0)
+					change := vp8BitCost(p, n0, n1) + vp8BitCost(up, 0, 1) + 8
+					if change < keep {
+						probs[i][j][k][l] = p
+						update[i][j][k][l] = true
+					}
+				}
+			}
+		}
+	}
+	tokens := &vp8BoolEncoder{rng: 255, bitCount: 24}
+	e.writeTokens(&vp8TokenCoder{enc: tokens, probs: &probs})
+
+	skipped := 0
+	for i := range e.mbs {
+		if e.mbs[i].skip {
+			skipped++
+		}
+	}
+	hdr := &vp8BoolEncoder{rng: 255, bitCount: 24}
+	hdr.put(128, false) // color space
+	hdr.put(128, false) // clamping required
+	hdr.put(128, false) // no segmentation
+	hdr.put(128, false) // normal loop filter
+	hdr.putLiteral(uint32(min(qi*5/8, 63)), 6)
+	hdr.putLiteral(0, 3) // sharpness
+	hdr.put(128, false)  // no filter adjustments
+	hdr.putLiteral(0, 2) // one token partition
+	hdr.putLiteral(uint32(qi), 7)
+	for i := 0; i < 5; i++ {
+		hdr.put(128, false) // no quantizer deltas
+	}
+	hdr.put(128, false) // refresh entropy probabilities
+	for i := range probs {
+		for j := range probs[i] {
+			for k := range probs[i][j] {
+				for l, p := range probs[i][j][k] {
+					hdr.put(vp8TokenUpdateProb[i][j][k][l], update[i][j][k][l])
+					if update[i][j][k][l] {
+						hdr.putLiteral(uint32(p), 8)
+					}
+				}
+			}
+		}
+	}
+	useSkip := skipped > 0
+	skipProb := uint8(min(max((255*(len(e.mbs)-skipped)+len(e.mbs)/2)/len(e.mbs), 1), 254))
+	hdr.put(128, useSkip)
+	if useSkip {
+		hdr.putLiteral(uint32(skipProb), 8)
+	}
+	for i := range e.mbs {
+		mb := &e.mbs[i]
+		if useSkip {
+			hdr.put(skipProb, mb.skip)
+		}
+		hdr.put(145, true) // 16x16 luma prediction
+		switch mb.yMode {
+		case vp8PredDC:
+			hdr.put(156, false)
+			hdr.put(163, false)
+		case vp8PredVE:
+			hdr.put(156, false)
+			hdr.put(163, true)
+		case vp8PredHE:
+			hdr.put(156, true)
+			hdr.put(128, false)
+		case vp8PredTM:
+			hdr.put(156, true)
+			hdr.put(128, true)
+		}
+		hdr.put(142, mb.uvMode != vp8PredDC)
+		if mb.uvMode != vp8PredDC {
+			hdr.put(114, mb.uvMode != vp8PredVE)
+			if mb.uvMode != vp8PredVE {
+				hdr.put(183, mb.uvMode == vp8PredTM)
+			}
+		}
+	}
+
+	first, rest := hdr.flush(), tokens.flush()
+	out := make([]byte, 10, 10+len(first)+len(rest))
+	tag := uint32(1<<4 | len(first)<<5) // key frame, version 0, shown
+	putUint24(out, tag)
+	out[3], out[4], out[5] = 0x9d, 0x01, 0x2a
+	binary.LittleEndian.PutUint16(out[6:], uint16(width))
+	binary.LittleEndian.PutUint16(out[8:], uint16(height))
+	out = append(out, first...)
+	return append(out, rest...)
+}
+
+// vp8BitCost returns the cost in bits of n0 zeros and n1 ones coded with
+// the probability p of a zero.
+func vp8BitCost(p uint8, n0, n1 uint32) float64 {
+	q := float64(p) / 256
+	return -float64(n0)*math.Log2(q) - float64(n1)*math.Log2(1-q)
+}
+
+// writeTokens codes the levels of every macroblock that is not skipped.
+// A block's first probability depends on whether the blocks above and to
+// the left have non-zero levels.
+func (e *vp8Encoder) writeTokens(t *vp8TokenCoder) {
+	// Non-zero flags: 4 luma columns or rows, 2 U, 2 V, then Y2.
+	up := make([][9]uint8, e.mbw)
+	for mby := 0; mby < e.mbh; mby++ {
+		var left [9]uint8
+		for mbx := 0; mbx < e.mbw; mbx++ {
+			mb, above := &e.mbs[mby*e.mbw+mbx], &up[mbx]
+			if mb.skip {
+				left, *above = [9]uint8{}, [9]uint8{}
+				continue
+			}
+			nz := t.block(vp8PlaneY2, int(left[8]+above[8]), &mb.levels[24], 0)
+			left[8], above[8] = nz, nz
+			for b := 0; b < 16; b++ {
+				x, y := b%4, b/4
+				nz := t.block(vp8PlaneYAC, int(left[y]+above[x]), &mb.levels[b], 1)
+				left[y], above[x] = nz, nz
+			}
+			for b := 0; b < 8; b++ {
+				// U flags are at 4-5 and V flags at 6-7.
+				x, y := 4+b/4*2+b%2, 4+b/4*2+b%4/2
+				nz := t.block(vp8PlaneUV, int(left[y]+above[x]), &mb.levels[16+b], 0)
+				left[y], above[x] = nz, nz
+			}
+		}
+	}
+}
+
+// vp8TokenCoder codes coefficient tokens, or when enc is nil only counts
+// the branches taken at each token probability.
+type vp8TokenCoder struct {
+	enc   *vp8BoolEncoder
+	probs *vp8TokenTable
+	stats *[vp8Planes][vp8Bands][vp8Contexts][vp8TokenProbs][2]uint32
+}
+
+// put codes bit with token probability i.
+func (t *vp8TokenCoder) put(plane, band, ctx, i int, bit bool) {
+	if t.enc != nil {
+		t.enc.put(t.probs[plane][band][ctx][i], bit)
+	} else {
+		t.stats[plane][band][ctx][i][boolBit(bit)]++
+	}
+}
+
+// putFixed codes bit with a probability that does not adapt.
+func (t *vp8TokenCoder) putFixed(prob uint8, bit bool) {
+	if t.enc != nil {
+		t.enc.put(prob, bit)
+	}
+}
+
+// block codes the levels from coding position first on and returns 1 if
+// any of them is non-zero. ctx is the number of neighbouring blocks with
+// non-zero levels.
+func (t *vp8TokenCoder) block(plane, ctx int, levels *[16]int16, first int) uint8 {
+	last := -1
+	for n := 15; n >= first; n-- {
+		if levels[n] != 0 {
+			last = n
+			break
+		}
+	}
+	n, band := first, int(vp8BandOf[first])
+	t.put(plane, band, ctx, 0, last >= 0)
+	if last < 0 {
+		return 0
+	}
+	for {
+		v := int(levels[n])
+		n++
+		if v == 0 {
+			// A zero is never last, so no end of block check follows it.
+			t.put(plane, band, ctx, 1, false)
+			band, ctx = int(vp8BandOf[n]), 0
+			continue
+		}
+		t.put(plane, band, ctx, 1, true)
+		neg := v < 0
+		if neg {
+			v = -v
+		}
+		t.level(plane, band, ctx, v)
+		band, ctx = int(vp8BandOf[n]), min(v, 2)
+		t.putFixed(128, neg)
+		if n == 16 {
+			return 1
+		}
+		t.put(plane, band, ctx, 0, n <= last)
+		if n > last {
+			return 1
+		}
+	}
+}
+
+// level codes the size of a non-zero level v.
+func (t *vp8TokenCoder) level(plane, band, ctx, v int) {
+	put := func(i int, bit bool) { t.put(plane, band, ctx, i, bit) }
+	put(2, v > 1)
+	switch {
+	case v == 1:
+	case v <= 4:
+		put(3, false)
+		put(4, v > 2)
+		if v > 2 {
+			put(5, v == 4)
+		}
+	case v <= 10:
+		put(3, true)
+		put(6, false)
+		put(7, v > 6)
+		if v <= 6 {
+			t.putFixed(159, v == 6)
+		} else {
+			t.putFixed(165, (v-7)&2 != 0)
+			t.putFixed(145, (v-7)&1 != 0)
+		}
+	default:
+		put(3, true)
+		put(6, true)
+		cat := 0
+		for cat < 3 && v >= 3+(8<<(cat+1)) {
+			cat++
+		}
+		put(8, cat >= 2)
+		put(9+cat/2, cat&1 != 0)
+		extra, probs := v-(3+(8<<cat)), vp8CatProbs[cat]
+		for i, p := range probs {
+			t.putFixed(p, extra>>(len(probs)-1-i)&1 != 0)
+		}
+	}
+}
+
+// vp8BoolEncoder is the boolean entropy coder of VP8 (RFC 6386 section 7).
+type vp8BoolEncoder struct {
+	buf      []byte
+	rng      uint32
+	bottom   uint32
+	bitCount int
+}
+
+// put codes bit, whose probability of being false is prob/256.
+func (e *vp8BoolEncoder) put(prob uint8, bit bool) {
+	split := 1 + (e.rng-1)*uint32(prob)>>8
+	if bit {
+		e.bottom += split
+		e.rng -= split
+	} else {
+		e.rng = split
+	}
+	for e.rng < 128 {
+		e.rng <<= 1
+		if e.bottom&(1<<31) != 0 {
+			e.carry()
+		}
+		e.bottom <<= 1
+		if e.bitCount--; e.bitCount == 0 {
+			e.buf = append(e.buf, byte(e.bottom>>24))
+			e.bottom &= 1<<24 - 1
+			e.bitCount = 8
+		}
+	}
+}
+
+// putLiteral codes the low n bits of v, most significant first, at even
+// odds.
+func (e *vp8BoolEncoder) putLiteral(v uint32, n int) {
+	for i := n - 1; i >= 0; i-- {
+		e.put(128, v>>i&1 != 0)
+	}
+}
+
+// carry adds one to the bytes written so far.
+func (e *vp8BoolEncoder) carry() {
+	for i := len(e.buf) - 1; i >= 0; i-- {
+		e.buf[i]++
+		if e.buf[i] != 0 {
+			return
+		}
+	}
+}
+
+// flush writes out the remaining bits and returns the coded bytes.
+func (e *vp8BoolEncoder) flush() []byte {
+	c, v := e.bitCount, e.bottom
+	if v&(1<<(32-c)) != 0 {
+		e.carry()
+	}
+	v <<= c & 7
+	for c >>= 3; c > 0; c-- {
+		v <<= 8
+	}
+	for i := 0; i < 4; i++ {
+		e.buf = append(e.buf, byte(v>>24))
+		v <<= 8
+	}
+	return e.buf
+}
+
+// vp8Clip clamps v to 0-255.
+func vp8Clip(v int) uint8 {
+	return uint8(min(max(v, 0), 255))
+}
@@ -0,0 +1,632 @@
+package gopresentation
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math/bits"
+	"sort"
+)
+
+// --- WebP encoder (VP8L) ---
+//
+// Lossless output uses the VP8L format: the subtract-green and predictor
+// transforms, backward references to the pixel on the left and the pixel
+// above (which cover the flat fills and repeated rows slides are made of),
+// and one set of prefix codes for the whole image. Lossy output is VP8 (see
+// image_vp8.go), which is far smaller for photos and gradients but softens
+// text edges; slides of flat fills and text are often no larger lossless.
+
+// maxWebPDimension is the largest width or height VP8L can store.
+const maxWebPDimension = 1 << 14
+
+// encodeWebP writes img as a WebP file. quality 100 is lossless; lower
+// values encode lossy VP8 at that quality.
+func encodeWebP(w io.Writer, img image.Image, quality int) error {
+	b := img.Bounds()
+	width, height := b.Dx(), b.Dy()
+	if width <= 0 || height <= 0 || width > maxWebPDimension || height > maxWebPDimension {
+		return fmt.Errorf("webp: image size %dx%d out of range (1-%d)", width, height, maxWebPDimension)
+	}
+	argb, alpha := webpPixels(img)
+	if quality < 100 {
+		return encodeWebPLossy(w, argb, alpha, width, height, quality)
+	}
+	return writeWebPChunks(w, webpChunk{"VP8L", webpLosslessStream(argb, width, height, alpha)})
+}
+
+// webpLosslessStream returns the VP8L bitstream of the ARGB pixels,
+// starting with its 5-byte header. argb is overwritten.
+func webpLosslessStream(argb []uint32, width, height int, alpha bool) []byte {
+	bw := &webpBitWriter{}
+	bw.write(0x2f, 8) // VP8L signature
+	bw.write(uint32(width-1), 14)
+	bw.write(uint32(height-1), 14)
+	bw.write(boolBit(alpha), 1)
+	bw.write(0, 3) // version
+
+	// Subtract-green transform: red and blue are stored as differences
+	// from green, which is close to zero for gray text and neutral fills.
+	bw.write(1, 1)
+	bw.write(2, 2)
+	for i, c := range argb {
+		g := c >> 8 & 0xff
+		r := (c>>16 - g) & 0xff
+		bl := (c - g) & 0xff
+		argb[i] = c&0xff00ff00 | r<<16 | bl
+	}
+	// Predictor transform: each 16x16 tile stores the residuals from the
+	// prediction mode that makes them smallest, which turns gradients and
+	// anti-aliased edges into runs of small values.
+	bw.write(1, 1)
+	bw.write(0, 2)
+	bw.write(webpTileBits-2, 3)
+	modes, tilesX := webpPredict(argb, width, height)
+	bw.write(0, 1) // no color cache
+	writeWebPImageData(bw, modes, tilesX)
+
+	bw.write(0, 1) // no more transforms
+
+	bw.write(0, 1) // no color cache
+	bw.write(0, 1) // no meta prefix codes
+	writeWebPImageData(bw, argb, width)
+	return bw.bytes()
+}
+
+// webpChunk is a chunk of a WebP file's RIFF container.
+type webpChunk struct {
+	fourCC string
+	data   []byte
+}
+
+// writeWebPChunks writes the chunks in a RIFF WEBP container, padding each
+// to an even length.
+func writeWebPChunks(w io.Writer, chunks ...webpChunk) error {
+	size := 4
+	for _, c := range chunks {
+		size += 8 + len(c.data) + len(c.data)&1
+	}
+	var hdr [12]byte
+	copy(hdr[0:], "RIFF")
+	binary.LittleEndian.PutUint32(hdr[4:], uint32(size))
+	copy(hdr[8:], "WEBP")
+	if _, err := w.Write(hdr[:]); err != nil {
+		return err
+	}
+	for _, c := range chunks {
+		var ch [8]byte
+		copy(ch[0:], c.fourCC)
+		binary.LittleEndian.PutUint32(ch[4:], uint32(len(c.data)))
+		if _, err := w.Write(ch[:]); err != nil {
+			return err
+		}
+		if _, err := w.Write(c.data); err != nil {
+			return err
+		}
+		if len(c.data)&1 != 0 {
+			if _, err := w.Write([]byte{0}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// webpPixels returns the non-premultiplied ARGB pixels of img and whether
+// any of them is not opaque.
+func webpPixels(img image.Image) ([]uint32, bool) {
+	b := img.Bounds()
+	width, height := b.Dx(), b.Dy()
+	out := make([]uint32, 0, width*height)
+	alpha := false
+	switch m := img.(type) {
+	case *image.RGBA:
+		for y := 0; y < height; y++ {
+			row := m.Pix[y*m.Stride : y*m.Stride+width*4]
+			for x := 0; x < len(row); x += 4 {
+				r, g, bl, a := uint32(row[x]), uint32(row[x+1]), uint32(row[x+2]), uint32(row[x+3])
+				if a != 255 {
+					alpha = true
+					if a == 0 {
+						r, g, bl = 0, 0, 0
+					} else {
+						r, g, bl = (r*255+a/2)/a, (g*255+a/2)/a, (bl*255+a/2)/a
+					}
+				}
+				out = append(out, a<<24|r<<16|g<<8|bl)
+			}
+		}
+	case *image.Gray:
+		for y := 0; y < height; y++ {
+			for _, v := range m.Pix[y*m.Stride : y*m.Stride+width] {
+				g := uint32(v)
+				out = append(out, 0xff000000|g<<16|g<<8|g)
+			}
+		}
+	default:
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+				if c.A != 255 {
+					alpha = true
+				}
+				out = append(out, uint32(c.A)<<24|uint32(c.R)<<16|uint32(c.G)<<8|uint32(c.B))
+			}
+		}
+	}
+	return out, alpha
+}
+
+// webpTileBits is the log2 of the predictor transform's tile size.
+const webpTileBits = 4
+
+// webpPredict replaces argb with its residuals from the best of the 14
+// VP8L prediction modes in each tile and returns the tile modes, stored in
+// green, and the number of tiles per row.
+func webpPredict(argb []uint32, width, height int) ([]uint32, int) {
+	const tile = 1 << webpTileBits
+	tilesX, tilesY := (width+tile-1)/tile, (height+tile-1)/tile
+	modes := make([]uint32, tilesX*tilesY)
+	res := make([]uint32, len(argb))
+	// The first row is predicted from the left and the first column from
+	// above, whatever the tile's mode.
+	at := func(mode uint32, i, x, y int) uint32 {
+		switch {
+		case x == 0 && y == 0:
+			return 0xff000000
+		case y == 0:
+			return argb[i-1]
+		case x == 0:
+			return argb[i-width]
+		}
+		// The pixel above and to the right of the last column is the first
+		// pixel of the current row, as it is in memory.
+		return webpPrediction(mode, argb[i-1], argb[i-width], argb[i-width-1], argb[i-width+1])
+	}
+	for ty := 0; ty < tilesY; ty++ {
+		for tx := 0; tx < tilesX; tx++ {
+			x0, y0 := tx*tile, ty*tile
+			x1, y1 := min(x0+tile, width), min(y0+tile, height)
+			best, bestCost := uint32(0), -1
+			for mode := uint32(0); mode < 14; mode++ {
+				cost := 0
+				for y := y0; y < y1; y++ {
+					for x := x0; x < x1; x++ {
+						i := y*width + x
+						d := webpSub(argb[i], at(mode, i, x, y))
+						for sh := 0; sh < 32; sh += 8 {
+							v := int(int8(d >> sh))
+							cost += max(v, -v)
+						}
+					}
+				}
+				if bestCost < 0 || cost < bestCost {
+					best, bestCost = mode, cost
+				}
+			}
+			modes[ty*tilesX+tx] = best << 8
+			for y := y0; y < y1; y++ {
+				for x := x0; x < x1; x++ {
+					i := y*width + x
+					res[i] = webpSub(argb[i], at(best, i, x, y))
+				}
+			}
+		}
+	}
+	copy(argb, res)
+	return modes, tilesX
+}
+
+// webpPrediction predicts a pixel from its left, top, top-left and
+// top-right neighbours with a VP8L prediction mode.
+func webpPrediction(mode, l, t, tl, tr uint32) uint32 {
+	switch mode {
+	case 0:
+		return 0xff000000
+	case 1:
+		return l
+	case 2:
+		return t
+	case 3:
+		return tr
+	case 4:
+		return tl
+	case 5:
+		return webpAvg(webpAvg(l, tr), t)
+	case 6:
+		return webpAvg(l, tl)
+	case 7:
+		return webpAvg(l, t)
+	case 8:
+		return webpAvg(tl, t)
+	case 9:
+		return webpAvg(t, tr)
+	case 10:
+		return webpAvg(webpAvg(l, tl), webpAvg(t, tr))
+	case 11:
+		// Select: whichever of L and T is closer to L+T-TL.
+		dl, dt := 0, 0
+		for sh := 0; sh < 32; sh += 8 {
+			c, a, b := int(tl>>sh&0xff), int(l>>sh&0xff), int(t>>sh&0xff)
+			dl += max(c-b, b-c)
+			dt += max(c-a, a-c)
+		}
+		if dl < dt {
+			return l
+		}
+		return t
+	case 12:
+		return webpChannels(func(sh int) int {
+			return int(l>>sh&0xff) + int(t>>sh&0xff) - int(tl>>sh&0xff)
+		})
+	default:
+		a := webpAvg(l, t)
+		return webpChannels(func(sh int) int {
+			v := int(a >> sh & 0xff)
+			return v + (v-int(tl>>sh&0xff))/2
+		})
+	}
+}
+
+// webpAvg averages two pixels channel by channel, rounding down.
+func webpAvg(a, b uint32) uint32 {
+	return (a^b)&0xfefefefe>>1 + a&b
+}
+
+// webpChannels builds a pixel from a function of each channel's shift,
+// clamping its results to 0-255.
+func webpChannels(f func(sh int) int) uint32 {
+	var p uint32
+	for sh := 0; sh < 32; sh += 8 {
+		p |= uint32(min(max(f(sh), 0), 255)) << sh
+	}
+	return p
+}
+
+// webpSub subtracts pixel b from a channel by channel, modulo 256.
+func webpSub(a, b uint32) uint32 {
+	var p uint32
+	for sh := 0; sh < 32; sh += 8 {
+		p |= (a>>sh - b>>sh) & 0xff << sh
+	}
+	return p
+}
+
+// webpToken is a literal pixel or a backward reference in the VP8L stream.
+type webpToken struct {
+	argb   uint32 // literal pixel, when length is 0
+	length int
+	dist   int // distance code
+}
+
+// Sizes of the VP8L alphabets. Green also holds the 24 length prefixes.
+const (
+	webpLengthCodes = 24
+	webpDistCodes   = 40
+	webpMaxLength   = 4096
+)
+
+// writeWebPImageData writes the entropy-coded pixels of the main image.
+func writeWebPImageData(bw *webpBitWriter, argb []uint32, width int) {
+	var tokens []webpToken
+	for i := 0; i < len(argb); {
+		// Run lengths for copying the pixel on the left and the one above.
+		limit := min(len(argb)-i, webpMaxLength)
+		left, up := 0, 0
+		if i >= 1 {
+			for left < limit && argb[i+left] == argb[i+left-1] {
+				left++
+			}
+		}
+		if i >= width {
+			for up < limit && argb[i+up] == argb[i+up-width] {
+				up++
+			}
+		}
+		switch {
+		case up >= 3 && up >= left:
+			// Distance code 1 is the pixel above in VP8L's distance map.
+			tokens = append(tokens, webpToken{length: up, dist: 1})
+			i += up
+		case left >= 3:
+			dist := 2 // the pixel on the left
+			if width == 1 {
+				dist = 1
+			}
+			tokens = append(tokens, webpToken{length: left, dist: dist})
+			i += left
+		default:
+			tokens = append(tokens, webpToken{argb: argb[i]})
+			i++
+		}
+	}
+
+	green := make([]int, 256+webpLengthCodes)
+	red := make([]int, 256)
+	blue := make([]int, 256)
+	alpha := make([]int, 256)
+	dist := make([]int, webpDistCodes)
+	for _, t := range tokens {
+		if t.length == 0 {
+			green[t.argb>>8&0xff]++
+			red[t.argb>>16&0xff]++
+			blue[t.argb&0xff]++
+			alpha[t.argb>>24]++
+			continue
+		}
+		lc, _, _ := webpPrefix(t.length)
+		green[256+lc]++
+		dc, _, _ := webpPrefix(t.dist)
+		dist[dc]++
+	}
+	gc := writeWebPCode(bw, green)
+	rc := writeWebPCode(bw, red)
+	bc := writeWebPCode(bw, blue)
+	ac := writeWebPCode(bw, alpha)
+	dc := writeWebPCode(bw, dist)
+
+	for _, t := range tokens {
+		if t.length == 0 {
+			gc.put(bw, int(t.argb>>8&0xff))
+			rc.put(bw, int(t.argb>>16&0xff))
+			bc.put(bw, int(t.argb&0xff))
+			ac.put(bw, int(t.argb>>24))
+			continue
+		}
+		code, n, extra := webpPrefix(t.length)
+		gc.put(bw, 256+code)
+		bw.write(uint32(extra), n)
+		code, n, extra = webpPrefix(t.dist)
+		dc.put(bw, code)
+		bw.write(uint32(extra), n)
+	}
+}
+
+// webpPrefix splits a length or distance code (1 or more) into its prefix
+// symbol and the extra bits that follow it.
+func webpPrefix(v int) (code int, nbits uint, extra int) {
+	v--
+	if v < 4 {
+		return v, 0, 0
+	}
+	h := bits.Len(uint(v)) - 1
+	second := v >> (h - 1) & 1
+	nbits = uint(h - 1)
+	return 2*h + second, nbits, v & (1<<nbits - 1)
+}
+
+// webpCode is a canonical prefix code with its codes bit-reversed, since
+// VP8L reads codes starting from their first bit.
+type webpCode struct {
+	lengths []uint8
+	codes   []uint16
+}
+
+func (c *webpCode) put(bw *webpBitWriter, sym int) {
+	bw.write(uint32(c.codes[sym]), uint(c.lengths[sym]))
+}
+
+// webpCodeLengthOrder is the order in which code length code lengths are
+// stored.
+var webpCodeLengthOrder = [19]int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+// writeWebPCode builds a prefix code for the symbol counts, writes it and
+// returns it.
+func writeWebPCode(bw *webpBitWriter, counts []int) *webpCode {
+	var used []int
+	for s, n := range counts {
+		if n > 0 {
+			used = append(used, s)
+		}
+	}
+	if len(used) == 0 {
+		used = []int{0}
+	}
+	if len(used) <= 2 && used[len(used)-1] < 256 {
+		// Simple code: one symbol takes no bits, two take one each.
+		bw.write(1, 1)
+		bw.write(uint32(len(used)-1), 1)
+		if used[0] < 2 {
+			bw.write(0, 1)
+			bw.write(uint32(used[0]), 1)
+		} else {
+			bw.write(1, 1)
+			bw.write(uint32(used[0]), 8)
+		}
+		lengths := make([]uint8, len(counts))
+		if len(used) == 2 {
+			bw.write(uint32(used[1]), 8)
+			lengths[used[0]], lengths[used[1]] = 1, 1
+		}
+		return newWebPCode(lengths)
+	}
+
+	lengths := webpCodeLengths(counts, 15)
+	bw.write(0, 1)
+
+	// Run-length code the lengths with the code length alphabet: 0-15
+	// literal lengths, 16 repeats the last non-zero length 3-6 times, 17
+	// and 18 give 3-10 and 11-138 zeros.
+	type clToken struct {
+		sym   int
+		extra uint32
+		nbits uint
+	}
+	var tokens []clToken
+	prev := uint8(8)
+	for i := 0; i < len(lengths); {
+		l := lengths[i]
+		run := 1
+		for i+run < len(lengths) && lengths[i+run] == l {
+			run++
+		}
+		i += run
+		if l == 0 {
+			for run >= 3 {
+				n := min(run, 138)
+				if n >= 11 {
+					tokens = append(tokens, clToken{18, uint32(n - 11), 7})
+				} else {
+					tokens = append(tokens, clToken{17, uint32(n - 3), 3})
+				}
+				run -= n
+			}
+		} else {
+			if l != prev {
+				tokens = append(tokens, clToken{sym: int(l)})
+				prev = l
+				run--
+			}
+			for run >= 3 {
+				n := min(run, 6)
+				tokens = append(tokens, clToken{16, uint32(n - 3), 2})
+				run -= n
+			}
+		}
+		for ; run > 0; run-- {
+			tokens = append(tokens, clToken{sym: int(l)})
+		}
+	}
+	clCounts := make([]int, len(webpCodeLengthOrder))
+	for _, t := range tokens {
+		clCounts[t.sym]++
+	}
+	clCode := newWebPCode(webpCodeLengths(clCounts, 7))
+	n := 4
+	for i, s := range webpCodeLengthOrder {
+		if clCode.lengths[s] > 0 {
+			n = max(n, i+1)
+		}
+	}
+	bw.write(uint32(n-4), 4)
+	for _, s := range webpCodeLengthOrder[:n] {
+		bw.write(uint32(clCode.lengths[s]), 3)
+	}
+	bw.write(0, 1) // lengths are given for the whole alphabet
+	for _, t := range tokens {
+		clCode.put(bw, t.sym)
+		bw.write(t.extra, t.nbits)
+	}
+	return newWebPCode(lengths)
+}
+
+// webpCodeLengths returns Huffman code lengths of at most maxLen bits for
+// the symbol counts. At least two symbols get a length, so that the code is
+// complete even when fewer are used.
+func webpCodeLengths(counts []int, maxLen int) []uint8 {
+	counts = append([]int(nil), counts...)
+	var used []int
+	for s, n := range counts {
+		if n > 0 {
+			used = append(used, s)
+		}
+	}
+	for s := 0; len(used) < 2; s++ {
+		if counts[s] == 0 {
+			counts[s] = 1
+			used = append(used, s)
+		}
+	}
+	lengths := make([]uint8, len(counts))
+	for {
+		// Build the tree from leaves sorted by count, merging the two
+		// lightest nodes each time.
+		type node struct {
+			count  int
+			parent int
+		}
+		nodes := make([]node, 0, 2*len(used))
+		sort.SliceStable(used, func(a, b int) bool { return counts[used[a]] < counts[used[b]] })
+		for _, s := range used {
+			nodes = append(nodes, node{count: counts[s], parent: -1})
+		}
+		leaf, inner := 0, len(used)
+		pick := func() int {
+			if leaf < len(used) && (inner >= len(nodes) || nodes[leaf].count <= nodes[inner].count) {
+				leaf++
+				return leaf - 1
+			}
+			inner++
+			return inner - 1
+		}
+		for i := 0; i < len(used)-1; i++ {
+			a, b := pick(), pick()
+			nodes = append(nodes, node{count: nodes[a].count + nodes[b].count, parent: -1})
+			nodes[a].parent, nodes[b].parent = len(nodes)-1, len(nodes)-1
+		}
+		longest := 0
+		for i, s := range used {
+			depth := 0
+			for p := nodes[i].parent; p >= 0; p = nodes[p].parent {
+				depth++
+			}
+			lengths[s] = uint8(depth)
+			longest = max(longest, depth)
+		}
+		if longest <= maxLen {
+			return lengths
+		}
+		// Flatten the distribution and try again.
+		for _, s := range used {
+			counts[s] = max(counts[s]>>1, 1)
+		}
+	}
+}
+
+// newWebPCode assigns canonical codes to the code lengths.
+func newWebPCode(lengths []uint8) *webpCode {
+	var blCount [16]uint16
+	for _, l := range lengths {
+		if l > 0 {
+			blCount[l]++
+		}
+	}
+	var next [16]uint16
+	code := uint16(0)
+	for l := 1; l < 16; l++ {
+		code = (code + blCount[l-1]) << 1
+		next[l] = code
+	}
+	c := &webpCode{lengths: lengths, codes: make([]uint16, len(lengths))}
+	for s, l := range lengths {
+		if l > 0 {
+			c.codes[s] = bits.Reverse16(next[l]) >> (16 - l)
+			next[l]++
+		}
+	}
+	return c
+}
+
+// webpBitWriter packs bits least significant first, as VP8L reads them.
+type webpBitWriter struct {
+	buf []byte
+	acc uint64
+	n   uint
+}
+
+func (w *webpBitWriter) write(v uint32, nbits uint) {
+	w.acc |= uint64(v) << w.n
+	w.n += nbits
+	for w.n >= 8 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc >>= 8
+		w.n -= 8
+	}
+}
+
+func (w *webpBitWriter) bytes() []byte {
+	if w.n > 0 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc, w.n = 0, 0
+	}
+	return w.buf
+}
+
+func boolBit(b bool) uint32 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,417 @@
+package gopresentation
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// InkShape is a pen drawing read from a p:contentPart that holds InkML ink.
+// Ink shapes are drawn by the renderer but are not written back when the
+// presentation is saved.
+type InkShape struct {
+	BaseShape
+	strokes []InkStroke
+	// Size of the drawing as read, which the strokes are relative to; the
+	// strokes are scaled with the shape when it is resized.
+	inkWidth, inkHeight int64
+}
+
+// InkStroke is one pen stroke of an ink drawing.
+type InkStroke struct {
+	// Points are in EMU, relative to the top-left corner of the shape at
+	// the size it was read with.
+	Points []InkPoint
+	Color  Color
+	Width  int64 // pen width in EMU
+}
+
+// InkPoint is a point of an ink stroke, in EMU.
+type InkPoint struct {
+	X, Y int64
+}
+
+// ShapeTypeInk is the shape type for ink drawings.
+const ShapeTypeInk ShapeType = 13
+
+func (s *InkShape) GetType() ShapeType { return ShapeTypeInk }
+
+// GetStrokes returns the pen strokes of the drawing.
+func (s *InkShape) GetStrokes() []InkStroke { return s.strokes }
+
+// xmlContentPart is a p:contentPart (or p14:contentPart) element.
+type xmlContentPart struct {
+	RID   string `xml:"id,attr"`
+	CNvPr struct {
+		ID    int    `xml:"id,attr"`
+		Name  string `xml:"name,attr"`
+		Descr string `xml:"descr,attr"`
+	} `xml:"nvContentPartPr>cNvPr"`
+	Xfrm *struct {
+		Rot   int  `xml:"rot,attr"`
+		FlipH bool `xml:"flipH,attr"`
+		FlipV bool `xml:"flipV,attr"`
+		Off   struct {
+			X int64 `xml:"x,attr"`
+			Y int64 `xml:"y,attr"`
+		} `xml:"off"`
+		Ext struct {
+			CX int64 `xml:"cx,attr"`
+			CY int64 `xml:"cy,attr"`
+		} `xml:"ext"`
+	} `xml:"xfrm"`
+}
+
+// nsInkML is the namespace of InkML, the format of ink content parts.
+const nsInkML = "http://www.w3.org/2003/InkML"
+
+// readContentPart reads the content part that starts with t. Ink becomes an
+// InkShape; other content, such as SVG, which the renderer cannot draw,
+// becomes an UnsupportedShape naming the part's content. It returns nil if
+// the part cannot be read.
+func readContentPart(d *xml.Decoder, t xml.StartElement, rels []xmlRelForRead, zr *zip.Reader, slidePath string) Shape {
+	var cp xmlContentPart
+	if err := d.DecodeElement(&cp, &t); err != nil {
+		return nil
+	}
+	var data []byte
+	for _, rel := range rels {
+		if rel.ID != cp.RID || rel.isExternal() {
+			continue
+		}
+		partPath := rel.Target
+		if !strings.HasPrefix(partPath, "ppt/") {
+			dir := strings.TrimSuffix(slidePath, "/"+lastPathComponent(slidePath))
+			partPath = resolveRelativePath(dir, partPath)
+		}
+		data, _ = readFileFromZip(zr, partPath)
+		break
+	}
+	if data == nil {
+		return nil
+	}
+
+	var shape Shape
+	var bs *BaseShape
+	if root := xmlRootName(data); root.Space == nsInkML && root.Local == "ink" {
+		ink := parseInkML(data)
+		if ink == nil {
+			return nil
+		}
+		shape, bs = ink, &ink.BaseShape
+		bs.width, bs.height = ink.inkWidth, ink.inkHeight
+	} else {
+		kind := "Content part"
+		if root.Local != "" {
+			kind += " (" + root.Local + ")"
+		}
+		u := &UnsupportedShape{kind: kind, uri: root.Space}
+		shape, bs = u, &u.BaseShape
+	}
+	bs.id = cp.CNvPr.ID
+	bs.name = cp.CNvPr.Name
+	bs.description = cp.CNvPr.Descr
+	if x := cp.Xfrm; x != nil {
+		bs.offsetX, bs.offsetY = x.Off.X, x.Off.Y
+		if x.Ext.CX > 0 && x.Ext.CY > 0 {
+			bs.width, bs.height = x.Ext.CX, x.Ext.CY
+		}
+		bs.rotation = x.Rot / 60000
+		bs.flipHorizontal, bs.flipVertical = x.FlipH, x.FlipV
+	}
+	return shape
+}
+
+// xmlRootName returns the name of the root element of an XML document.
+func xmlRootName(data []byte) xml.Name {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return xml.Name{}
+		}
+		if t, ok := tok.(xml.StartElement); ok {
+			return t.Name
+		}
+	}
+}
+
+// inkBrush holds the brush properties used by ink traces.
+type inkBrush struct {
+	color color.RGBA
+	width float64 // EMU
+}
+
+// parseInkML reads the traces of an InkML document into an InkShape whose
+// size is that of the drawing, or returns nil if it has no traces.
+func parseInkML(data []byte) *InkShape {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	brushes := map[string]*inkBrush{}
+	var brush *inkBrush
+	// X and Y channel positions and units in EMU. Office writes ink in
+	// himetric units, 1000 per centimetre, which is the default.
+	var channels []string
+	unit := map[string]float64{"X": 360, "Y": 360}
+	type trace struct {
+		pts   [][2]float64
+		brush *inkBrush
+	}
+	var traces []trace
+	var cur *trace
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			break
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "channel":
+				channels = append(channels, xmlAttr(t, "name"))
+			case "channelProperty":
+				ch := xmlAttr(t, "channel")
+				v, err := strconv.ParseFloat(xmlAttr(t, "value"), 64)
+				if xmlAttr(t, "name") != "resolution" || err != nil || v <= 0 {
+					break
+				}
+				if emu := inkLengthEMU(1, strings.TrimPrefix(xmlAttr(t, "units"), "1/")); emu > 0 {
+					unit[ch] = emu / v
+				}
+			case "brush":
+				brush = &inkBrush{color: color.RGBA{A: 255}, width: 0.05 * 360000}
+				brushes["#"+xmlAttr(t, "id")] = brush
+			case "brushProperty":
+				if brush == nil {
+					break
+				}
+				v := xmlAttr(t, "value")
+				switch xmlAttr(t, "name") {
+				case "width":
+					if f, err := strconv.ParseFloat(v, 64); err == nil && f > 0 {
+						brush.width = inkLengthEMU(f, xmlAttr(t, "units"))
+					}
+				case "color":
+					if c, err := strconv.ParseUint(strings.TrimPrefix(v, "#"), 16, 32); err == nil {
+						brush.color.R, brush.color.G, brush.color.B = uint8(c>>16), uint8(c>>8), uint8(c)
+					}
+				case "transparency":
+					if n, err := strconv.Atoi(v); err == nil && n >= 0 && n <= 255 {
+						brush.color.A = uint8(255 - n)
+					}
+				}
+			case "trace":
+				b := brushes[xmlAttr(t, "brushRef")]
+				if b == nil {
+					b = &inkBrush{color: color.RGBA{A: 255}, width: 0.05 * 360000}
+				}
+				traces = append(traces, trace{brush: b})
+				cur = &traces[len(traces)-1]
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "brush":
+				brush = nil
+			case "trace":
+				cur = nil
+			}
+		case xml.CharData:
+			if cur != nil {
+				xi, yi := 0, 1
+				for i, name := range channels {
+					switch name {
+					case "X":
+						xi = i
+					case "Y":
+						yi = i
+					}
+				}
+				for _, p := range decodeInkTrace(string(t), xi, yi) {
+					cur.pts = append(cur.pts, [2]float64{p[0] * unit["X"], p[1] * unit["Y"]})
+				}
+			}
+		}
+	}
+
+	// The drawing spans the traces and the pens drawing them.
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, tr := range traces {
+		h := tr.brush.width / 2
+		for _, p := range tr.pts {
+			minX, maxX = math.Min(minX, p[0]-h), math.Max(maxX, p[0]+h)
+			minY, maxY = math.Min(minY, p[1]-h), math.Max(maxY, p[1]+h)
+		}
+	}
+	if minX > maxX {
+		return nil
+	}
+	s := &InkShape{
+		inkWidth:  int64(math.Ceil(maxX - minX)),
+		inkHeight: int64(math.Ceil(maxY - minY)),
+	}
+	for _, tr := range traces {
+		if len(tr.pts) == 0 {
+			continue
+		}
+		st := InkStroke{
+			Points: make([]InkPoint, len(tr.pts)),
+			Color:  paletteColor(tr.brush.color),
+			Width:  int64(math.Round(tr.brush.width)),
+		}
+		for i, p := range tr.pts {
+			st.Points[i] = InkPoint{X: int64(math.Round(p[0] - minX)), Y: int64(math.Round(p[1] - minY))}
+		}
+		s.strokes = append(s.strokes, st)
+	}
+	return s
+}
+
+// xmlAttr returns the value of the attribute of t with the given local
+// name, or "".
+func xmlAttr(t xml.StartElement, local string) string {
+	for _, a := range t.Attr {
+		if a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// inkLengthEMU converts an InkML length in the given units to EMU. Unknown
+// units are taken as centimetres.
+func inkLengthEMU(v float64, units string) float64 {
+	switch units {
+	case "mm":
+		return v * 36000
+	case "in":
+		return v * 914400
+	case "pt":
+		return v * 12700
+	case "px":
+		return v * 9525
+	case "himetric":
+		return v * 360
+	}
+	return v * 360000
+}
+
+// decodeInkTrace decodes the points of an InkML trace, returning the values
+// of channels xi and yi. Points are separated by commas; a value prefixed
+// with ' is a difference from the channel's previous value and one with "
+// a difference from its previous difference, and a prefix holds for the
+// following values of its channel until another is given.
+func decodeInkTrace(s string, xi, yi int) [][2]float64 {
+	type channel struct {
+		mode   byte
+		v, vel float64
+		seen   bool
+	}
+	var chans []channel
+	var pts [][2]float64
+	for _, p := range strings.Split(s, ",") {
+		c := 0
+		var prefix byte
+		for i := 0; i < len(p); {
+			ch := p[i]
+			var v float64
+			switch {
+			case ch == '!' || ch == '\'' || ch == '"':
+				prefix = ch
+				i++
+				continue
+			case ch == '-' || ch == '+' || ch == '.' || ch >= '0' && ch <= '9':
+				j := i + 1
+				for j < len(p) && (p[j] == '.' || p[j] >= '0' && p[j] <= '9') {
+					j++
+				}
+				v, _ = strconv.ParseFloat(p[i:j], 64)
+				i = j
+			case ch == '?' || ch == '*':
+				// Unknown or repeated values keep the channel where it is.
+				i++
+				c++
+				continue
+			default:
+				i++
+				continue
+			}
+			for len(chans) <= c {
+				chans = append(chans, channel{mode: '!'})
+			}
+			st := &chans[c]
+			if prefix != 0 {
+				st.mode, prefix = prefix, 0
+			}
+			switch st.mode {
+			case '\'':
+				st.vel = v
+				st.v += v
+			case '"':
+				st.vel += v
+				st.v += st.vel
+			default:
+				if st.seen {
+					st.vel = v - st.v
+				}
+				st.v = v
+			}
+			st.seen = true
+			c++
+		}
+		if c > xi && c > yi && len(chans) > xi && len(chans) > yi {
+			pts = append(pts, [2]float64{chans[xi].v, chans[yi].v})
+		}
+	}
+	return pts
+}
+
+// renderInk draws the strokes of an ink drawing with round caps and joins,
+// scaled, flipped and rotated with its shape.
+func (r *renderer) renderInk(s *InkShape) {
+	if s.inkWidth <= 0 || s.inkHeight <= 0 {
+		return
+	}
+	sx := float64(s.width) / float64(s.inkWidth)
+	sy := float64(s.height) / float64(s.inkHeight)
+	cx := float64(s.offsetX) + float64(s.width)/2
+	cy := float64(s.offsetY) + float64(s.height)/2
+	sin, cos := math.Sincos(float64(s.rotation) * math.Pi / 180)
+	toPixel := func(p InkPoint) fpoint {
+		x := float64(p.X)*sx - float64(s.width)/2
+		y := float64(p.Y)*sy - float64(s.height)/2
+		if s.flipHorizontal {
+			x = -x
+		}
+		if s.flipVertical {
+			y = -y
+		}
+		x, y = x*cos-y*sin, x*sin+y*cos
+		return fpoint{(cx + x) * r.scaleX, (cy + y) * r.scaleY}
+	}
+	for _, st := range s.strokes {
+		if len(st.Points) == 0 {
+			continue
+		}
+		half := math.Max(float64(st.Width)*math.Sqrt(sx*sy)*r.scaleX/2, 0.5)
+		pieces := make([]strokePiece, 0, 2*len(st.Points))
+		prev := toPixel(st.Points[0])
+		pieces = append(pieces, strokePiece{centre: prev, radius: half})
+		for _, p := range st.Points[1:] {
+			q := toPixel(p)
+			if l := math.Hypot(q.x-prev.x, q.y-prev.y); l > 0 {
+				nx, ny := -(q.y-prev.y)/l*half, (q.x-prev.x)/l*half
+				pieces = append(pieces,
+					strokePiece{quad: [4]fpoint{
+						{prev.x + nx, prev.y + ny}, {q.x + nx, q.y + ny}, {q.x - nx, q.y - ny}, {prev.x - nx, prev.y - ny},
+					}},
+					strokePiece{centre: q, radius: half})
+			}
+			prev = q
+		}
+		r.fillStrokePieces(pieces, r.rgba(st.Color))
+	}
+}
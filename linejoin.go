@@ -0,0 +1,241 @@
+package gopresentation
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"math/bits"
+)
+
+// strokeRectJoined strokes the band of width pixels just inside rect, as
+// drawRect does, with its outer corners rounded or beveled for the join.
+func (r *renderer) strokeRectJoined(rect image.Rectangle, c color.RGBA, width int, join LineJoin) {
+	half := float64(width) / 2
+	cx, cy := float64(rect.Min.X+rect.Max.X)/2, float64(rect.Min.Y+rect.Max.Y)/2
+	// Half extents of the line's centre.
+	bx, by := float64(rect.Dx())/2-half, float64(rect.Dy())/2-half
+	if bx <= 0 || by <= 0 {
+		r.drawRect(rect, c, width)
+		return
+	}
+	cover := func(d float64) float64 { return math.Max(0, math.Min(1, 0.5-d)) }
+	box := rect.Intersect(r.img.Bounds())
+	for py := box.Min.Y; py < box.Max.Y; py++ {
+		inner := py >= rect.Min.Y+width && py < rect.Max.Y-width
+		qy := math.Abs(float64(py)+0.5-cy) - by
+		for px := box.Min.X; px < box.Max.X; px++ {
+			if inner && px == rect.Min.X+width && px < rect.Max.X-width {
+				if px = rect.Max.X - width; px >= box.Max.X {
+					break
+				}
+			}
+			qx := math.Abs(float64(px)+0.5-cx) - bx
+			// Signed distances to the outer edge and to the edge of the
+			// hole inside the band.
+			outer := math.Max(qx, qy) - half
+			if qx > 0 && qy > 0 {
+				if join == LineJoinRound {
+					outer = math.Hypot(qx, qy) - half
+				} else {
+					outer = math.Max(outer, (qx+qy-half)/math.Sqrt2)
+				}
+			}
+			cov := cover(outer) - cover(math.Max(qx, qy)+half)
+			if cov <= 0 {
+				continue
+			}
+			r.blendPixel(px, py, color.RGBA{R: c.R, G: c.G, B: c.B, A: uint8(float64(c.A)*cov + 0.5)})
+		}
+	}
+}
+
+// strokeJoinedPolyline strokes a polyline of width pixels with solid
+// segments and the given join at its corners, placed as drawLineAA would
+// place them; closed also strokes and joins the segment back to the start.
+// It draws nothing and returns false for thin lines and an empty join,
+// which are left to drawLineAA.
+func (r *renderer) strokeJoinedPolyline(pts []fpoint, closed bool, c color.RGBA, width int, join LineJoin, miterLimit int) bool {
+	if width <= 2 || join == "" || len(pts) < 2 {
+		return false
+	}
+	half := float64(width) / 2
+	n := len(pts)
+	segs := n - 1
+	if closed {
+		segs = n
+	}
+	pieces := make([]strokePiece, 0, 2*segs)
+	for i := 0; i < segs; i++ {
+		a, b := pixelCentre(pts[i]), pixelCentre(pts[(i+1)%n])
+		l := math.Hypot(b.x-a.x, b.y-a.y)
+		if l == 0 {
+			continue
+		}
+		nx, ny := -(b.y-a.y)/l*half, (b.x-a.x)/l*half
+		pieces = append(pieces, strokePiece{quad: [4]fpoint{
+			{a.x + nx, a.y + ny}, {b.x + nx, b.y + ny}, {b.x - nx, b.y - ny}, {a.x - nx, a.y - ny},
+		}})
+	}
+	pieces = append(pieces, lineJoinPieces(pts, closed, width, join, miterLimit)...)
+	r.fillStrokePieces(pieces, c)
+	return true
+}
+
+// pixelCentre returns the centre of the pixel drawLineAA starts or ends a
+// segment on for point p.
+func pixelCentre(p fpoint) fpoint {
+	return fpoint{float64(int(p.x)) + 0.5, float64(int(p.y)) + 0.5}
+}
+
+// lineJoinPieces returns the areas outside the corners of a stroked
+// polyline that the join adds to its straight segments.
+func lineJoinPieces(pts []fpoint, closed bool, width int, join LineJoin, miterLimit int) []strokePiece {
+	v := make([]fpoint, 0, len(pts))
+	for _, p := range pts {
+		q := pixelCentre(p)
+		if len(v) == 0 || v[len(v)-1] != q {
+			v = append(v, q)
+		}
+	}
+	if closed && len(v) > 1 && v[0] == v[len(v)-1] {
+		v = v[:len(v)-1]
+	}
+	n := len(v)
+	if n < 3 && !(n == 2 && closed) {
+		return nil
+	}
+	limit := 8.0
+	if miterLimit > 0 {
+		limit = float64(miterLimit) / 100000
+	}
+	half := float64(width) / 2
+	first, last := 1, n-1
+	if closed {
+		first, last = 0, n
+	}
+	var pieces []strokePiece
+	for i := first; i < last; i++ {
+		p, q, nx := v[(i+n-1)%n], v[i], v[(i+1)%n]
+		d1x, d1y := q.x-p.x, q.y-p.y
+		d2x, d2y := nx.x-q.x, nx.y-q.y
+		l1, l2 := math.Hypot(d1x, d1y), math.Hypot(d2x, d2y)
+		d1x, d1y, d2x, d2y = d1x/l1, d1y/l1, d2x/l2, d2y/l2
+		cross := d1x*d2y - d1y*d2x
+		if math.Abs(cross) < 1e-9 && d1x*d2x+d1y*d2y > 0 {
+			continue // no turn
+		}
+		if join == LineJoinRound {
+			pieces = append(pieces, strokePiece{centre: q, radius: half})
+			continue
+		}
+		// Unit normals pointing to the outside of the turn.
+		sign := 1.0
+		if cross > 0 {
+			sign = -1
+		}
+		n1 := fpoint{-d1y * sign, d1x * sign}
+		n2 := fpoint{-d2y * sign, d2x * sign}
+		a := fpoint{q.x + n1.x*half, q.y + n1.y*half}
+		b := fpoint{q.x + n2.x*half, q.y + n2.y*half}
+		dot := n1.x*n2.x + n1.y*n2.y
+		// A miter is 1/cos(θ/2) line widths long, θ being the angle between
+		// the normals; longer ones are beveled.
+		if join == LineJoinMiter && dot > -1 && math.Sqrt(2/(1+dot)) <= limit {
+			k := half / (1 + dot)
+			m := fpoint{q.x + (n1.x+n2.x)*k, q.y + (n1.y+n2.y)*k}
+			pieces = append(pieces, strokePiece{quad: [4]fpoint{q, a, m, b}})
+		} else {
+			pieces = append(pieces, strokePiece{quad: [4]fpoint{q, a, b, b}})
+		}
+	}
+	return pieces
+}
+
+// strokePiece is part of a stroke's outline: a convex quadrilateral, or a
+// disc when radius is set.
+type strokePiece struct {
+	quad   [4]fpoint
+	centre fpoint
+	radius float64
+}
+
+func (p strokePiece) bounds() (minX, minY, maxX, maxY float64) {
+	if p.radius > 0 {
+		return p.centre.x - p.radius, p.centre.y - p.radius, p.centre.x + p.radius, p.centre.y + p.radius
+	}
+	minX, minY = p.quad[0].x, p.quad[0].y
+	maxX, maxY = minX, minY
+	for _, q := range p.quad[1:] {
+		minX, maxX = math.Min(minX, q.x), math.Max(maxX, q.x)
+		minY, maxY = math.Min(minY, q.y), math.Max(maxY, q.y)
+	}
+	return minX, minY, maxX, maxY
+}
+
+func (p strokePiece) contains(x, y float64) bool {
+	if p.radius > 0 {
+		dx, dy := x-p.centre.x, y-p.centre.y
+		return dx*dx+dy*dy <= p.radius*p.radius
+	}
+	sign := 0.0
+	for i := 0; i < 4; i++ {
+		a, b := p.quad[i], p.quad[(i+1)%4]
+		cross := (b.x-a.x)*(y-a.y) - (b.y-a.y)*(x-a.x)
+		if cross == 0 {
+			continue
+		}
+		if sign == 0 {
+			sign = cross
+		} else if (cross > 0) != (sign > 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// fillStrokePieces fills the union of pieces, blending each pixel once by
+// the fraction of it they cover (4x4 supersampling), so that no seams show
+// where pieces meet.
+func (r *renderer) fillStrokePieces(pieces []strokePiece, c color.RGBA) {
+	if len(pieces) == 0 {
+		return
+	}
+	minX, minY, maxX, maxY := pieces[0].bounds()
+	for _, p := range pieces[1:] {
+		x0, y0, x1, y1 := p.bounds()
+		minX, minY = math.Min(minX, x0), math.Min(minY, y0)
+		maxX, maxY = math.Max(maxX, x1), math.Max(maxY, y1)
+	}
+	box := image.Rect(int(math.Floor(minX)), int(math.Floor(minY)), int(math.Floor(maxX))+1, int(math.Floor(maxY))+1).
+		Intersect(r.img.Bounds())
+	if box.Empty() {
+		return
+	}
+	const ss = 4
+	// One bit per sample of each pixel in box.
+	hits := make([]uint16, box.Dx()*box.Dy())
+	for _, p := range pieces {
+		x0, y0, x1, y1 := p.bounds()
+		pb := image.Rect(int(math.Floor(x0)), int(math.Floor(y0)), int(math.Floor(x1))+1, int(math.Floor(y1))+1).Intersect(box)
+		for py := pb.Min.Y; py < pb.Max.Y; py++ {
+			row := hits[(py-box.Min.Y)*box.Dx():]
+			for px := pb.Min.X; px < pb.Max.X; px++ {
+				m := row[px-box.Min.X]
+				for s := 0; s < ss*ss; s++ {
+					if m&(1<<s) == 0 && p.contains(float64(px)+(float64(s%ss)+0.5)/ss, float64(py)+(float64(s/ss)+0.5)/ss) {
+						m |= 1 << s
+					}
+				}
+				row[px-box.Min.X] = m
+			}
+		}
+	}
+	for py := box.Min.Y; py < box.Max.Y; py++ {
+		row := hits[(py-box.Min.Y)*box.Dx():]
+		for px := box.Min.X; px < box.Max.X; px++ {
+			if m := row[px-box.Min.X]; m != 0 {
+				r.blendPixelF(px, py, c, float64(bits.OnesCount16(m))/(ss*ss))
+			}
+		}
+	}
+}
@@ -0,0 +1,394 @@
+package gopresentation
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// formatNumber formats v with a spreadsheet number format code such as
+// "0%", "#,##0.00", "$#,##0;($#,##0)" or "0.0E+00", as used by chart
+// c:numFmt elements. It supports positive/negative/zero sections, digit
+// placeholders (0 # ?), thousands separators and scaling commas, percent,
+// scientific notation, fractions such as "# ?/?" or "?/8", quoted and
+// escaped literals, [$sym-lcid] currency symbols and @, which shows the
+// number as General. Date and time codes are not supported and fall back to
+// General.
+func formatNumber(v float64, code string) string {
+	if v == 0 {
+		v = 0 // drop the sign of negative zero
+	}
+	if code == "" || strings.EqualFold(code, "General") || math.IsNaN(v) || math.IsInf(v, 0) {
+		return formatGeneral(v)
+	}
+	sections := splitFormatSections(code)
+	sec := sections[0]
+	sign := ""
+	switch {
+	case v < 0 && len(sections) >= 2:
+		sec = sections[1]
+		v = -v
+	case v < 0:
+		sign = "-"
+		v = -v
+	case v == 0 && len(sections) >= 3:
+		sec = sections[2]
+	}
+	if isDateFormat(sec) {
+		return sign + formatGeneral(v)
+	}
+	return sign + formatSection(v, sec)
+}
+
+// formatGeneral renders v the way the General format does: up to ten
+// significant digits without trailing zeros.
+func formatGeneral(v float64) string {
+	if v == math.Trunc(v) && math.Abs(v) < 1e11 {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return strings.ToUpper(strconv.FormatFloat(v, 'g', 10, 64))
+}
+
+// splitFormatSections splits a format code on semicolons outside quotes.
+func splitFormatSections(code string) []string {
+	var out []string
+	start, quoted := 0, false
+	for i := 0; i < len(code); i++ {
+		switch code[i] {
+		case '"':
+			quoted = !quoted
+		case '\\':
+			i++
+		case ';':
+			if !quoted {
+				out = append(out, code[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(out, code[start:])
+}
+
+// isDateFormat reports whether sec contains unquoted date or time tokens.
+func isDateFormat(sec string) bool {
+	quoted, bracket := false, false
+	for i := 0; i < len(sec); i++ {
+		c := sec[i]
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '\\':
+			i++
+		case c == '[':
+			bracket = true
+		case c == ']':
+			bracket = false
+		case bracket:
+		case strings.IndexByte("yYmMdDhHsS", c) >= 0:
+			return true
+		}
+	}
+	return false
+}
+
+// formatSection formats a non-negative v with a single format section.
+func formatSection(v float64, sec string) string {
+	if i := unquotedIndex(sec, '/'); i >= 0 {
+		return formatFraction(v, sec, i)
+	}
+	var prefix, suffix, pattern strings.Builder
+	lit := &prefix
+	percent := 0
+	for i := 0; i < len(sec); i++ {
+		c := sec[i]
+		switch c {
+		case '"':
+			j := strings.IndexByte(sec[i+1:], '"')
+			if j < 0 {
+				j = len(sec) - i - 1
+			}
+			lit.WriteString(sec[i+1 : i+1+j])
+			i += j + 1
+		case '\\':
+			if i+1 < len(sec) {
+				lit.WriteByte(sec[i+1])
+				i++
+			}
+		case '_':
+			lit.WriteByte(' ')
+			i++
+		case '*':
+			i++
+		case '[':
+			j := strings.IndexByte(sec[i:], ']')
+			if j < 0 {
+				j = len(sec) - i - 1
+			}
+			if tag := sec[i+1 : i+j]; strings.HasPrefix(tag, "$") {
+				if k := strings.IndexByte(tag, '-'); k >= 0 {
+					tag = tag[:k]
+				}
+				lit.WriteString(tag[1:])
+			}
+			i += j
+		case '%':
+			percent++
+			lit.WriteByte('%')
+		case '0', '#', '?', '.', ',':
+			if c == ',' && pattern.Len() == 0 {
+				lit.WriteByte(c)
+				continue
+			}
+			pattern.WriteByte(c)
+			lit = &suffix
+		case 'E', 'e':
+			if pattern.Len() > 0 && i+1 < len(sec) && (sec[i+1] == '+' || sec[i+1] == '-') {
+				pattern.WriteString(sec[i : i+2])
+				i++
+				continue
+			}
+			lit.WriteByte(c)
+		case '@':
+			pattern.WriteString("General")
+			lit = &suffix
+		default:
+			if strings.HasPrefix(sec[i:], "General") {
+				pattern.WriteString("General")
+				lit = &suffix
+				i += len("General") - 1
+				continue
+			}
+			lit.WriteByte(c)
+		}
+	}
+	for ; percent > 0; percent-- {
+		v *= 100
+	}
+	return prefix.String() + formatDigits(v, pattern.String()) + suffix.String()
+}
+
+// formatDigits renders v with a digit pattern such as "#,##0.00" or
+// "0.0E+00". An empty pattern renders nothing.
+func formatDigits(v float64, pat string) string {
+	switch {
+	case pat == "":
+		return ""
+	case strings.Contains(pat, "General"):
+		return formatGeneral(v)
+	}
+	if i := strings.IndexAny(pat, "Ee"); i >= 0 {
+		return formatScientific(v, pat[:i], pat[i+1:])
+	}
+	for strings.HasSuffix(pat, ",") {
+		pat = pat[:len(pat)-1]
+		v /= 1000
+	}
+	intPat, fracPat := pat, ""
+	if i := strings.IndexByte(pat, '.'); i >= 0 {
+		intPat, fracPat = pat[:i], pat[i+1:]
+	}
+	grouped := strings.Contains(intPat, ",")
+	intPat = strings.ReplaceAll(intPat, ",", "")
+	fracPat = strings.ReplaceAll(fracPat, ",", "")
+
+	// Round half away from zero like spreadsheet applications do, rather
+	// than to even.
+	if p := math.Pow10(len(fracPat)); !math.IsInf(v*p, 0) {
+		v = math.Round(v*p) / p
+	}
+	s := strconv.FormatFloat(v, 'f', len(fracPat), 64)
+	intStr, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intStr, frac = s[:i], s[i+1:]
+	}
+	// Trailing zeros under # go and under ? become spaces.
+	fb := []byte(frac)
+	for i := len(fb) - 1; i >= 0 && fb[i] == '0' && fracPat[i] != '0'; i-- {
+		if fracPat[i] == '?' {
+			fb[i] = ' '
+		} else {
+			fb = fb[:i]
+		}
+	}
+	frac = string(fb)
+	intStr = padDigits(intStr, intPat)
+	if grouped {
+		intStr = groupThousands(intStr)
+	}
+	if strings.Contains(pat, ".") {
+		return intStr + "." + frac
+	}
+	return intStr
+}
+
+// padDigits pads the integer digits s on the left to the placeholders of
+// pat: with zeros for 0 and spaces for ?. A lone zero under # or ? is
+// dropped.
+func padDigits(s, pat string) string {
+	if pat == "" || s == "0" && pat[len(pat)-1] != '0' {
+		s = ""
+	}
+	for i := len(pat) - 1 - len(s); i >= 0; i-- {
+		switch pat[i] {
+		case '0':
+			s = "0" + s
+		case '?':
+			s = " " + s
+		}
+	}
+	return s
+}
+
+// unquotedIndex returns the index of the first c in sec outside quotes and
+// escapes, or -1.
+func unquotedIndex(sec string, c byte) int {
+	quoted := false
+	for i := 0; i < len(sec); i++ {
+		switch {
+		case sec[i] == '"':
+			quoted = !quoted
+		case quoted:
+		case sec[i] == '\\':
+			i++
+		case sec[i] == c:
+			return i
+		}
+	}
+	return -1
+}
+
+// formatFraction formats a non-negative v with a fraction section such as
+// "# ?/?", "# ??/??", "?/?" or "# ?/8", whose slash is at index slash. With
+// an integer part the fraction shows the remainder; without one it is
+// improper. A placeholder denominator is the closest one of at most as many
+// digits, a digit denominator is fixed.
+func formatFraction(v float64, sec string, slash int) string {
+	isDigit := func(c byte) bool { return c >= '0' && c <= '9' }
+	isPlaceholder := func(c byte) bool { return c == '0' || c == '#' || c == '?' }
+
+	end := slash + 1
+	for end < len(sec) && (isPlaceholder(sec[end]) || isDigit(sec[end])) {
+		end++
+	}
+	denPat := sec[slash+1 : end]
+	start := slash
+	for start > 0 && isPlaceholder(sec[start-1]) {
+		start--
+	}
+	numPat := sec[start:slash]
+	intEnd := start
+	for intEnd > 0 && sec[intEnd-1] == ' ' {
+		intEnd--
+	}
+	intStart := intEnd
+	for intStart > 0 && (isPlaceholder(sec[intStart-1]) || sec[intStart-1] == ',') {
+		intStart--
+	}
+	if intStart == intEnd {
+		intEnd = start
+		intStart = start
+	}
+	intPat, sep := sec[intStart:intEnd], sec[intEnd:start]
+
+	whole, f := 0.0, v
+	if intPat != "" {
+		whole = math.Floor(v)
+		f = v - whole
+	}
+	var num, den int
+	if fixed, err := strconv.Atoi(denPat); err == nil && denPat != "" && isDigit(denPat[0]) && fixed > 0 {
+		den = fixed
+		num = int(math.Round(f * float64(den)))
+	} else {
+		maxDen := int(math.Pow10(max(len(denPat), 1))) - 1
+		best := math.Inf(1)
+		for d := 1; d <= maxDen; d++ {
+			n := math.Round(f * float64(d))
+			if e := math.Abs(f - n/float64(d)); e < best-1e-12 {
+				best, num, den = e, int(n), d
+			}
+		}
+	}
+	if intPat != "" && num == den {
+		whole++
+		num = 0
+	}
+
+	var out strings.Builder
+	out.WriteString(formatSection(v, sec[:intStart]))
+	intStr := ""
+	if intPat != "" {
+		intStr = formatDigits(whole, intPat)
+		out.WriteString(intStr)
+	}
+	fracWidth := len(sep) + len(numPat) + 1 + len(denPat)
+	switch {
+	case num == 0 && intPat != "":
+		if intStr == "" {
+			out.WriteString("0")
+		}
+		out.WriteString(strings.Repeat(" ", fracWidth))
+	case num == 0:
+		out.WriteString("0")
+	default:
+		if intStr != "" {
+			out.WriteString(sep)
+		}
+		out.WriteString(padDigits(strconv.Itoa(num), numPat))
+		out.WriteByte('/')
+		d := strconv.Itoa(den)
+		out.WriteString(d)
+		if !isDigit(denPat[0]) {
+			out.WriteString(strings.Repeat(" ", max(len(denPat)-len(d), 0)))
+		}
+	}
+	out.WriteString(formatSection(v, sec[end:]))
+	return out.String()
+}
+
+// formatScientific renders v as mantissa and exponent, where exp is the
+// part of the pattern after the E, starting with its sign.
+func formatScientific(v float64, mant, exp string) string {
+	e := 0
+	if v != 0 {
+		e = int(math.Floor(math.Log10(v)))
+		v /= math.Pow(10, float64(e))
+	}
+	m := formatDigits(v, mant)
+	if strings.HasPrefix(m, "10") {
+		e++
+		m = formatDigits(v/10, mant)
+	}
+	sign := ""
+	if e < 0 {
+		sign = "-"
+		e = -e
+	} else if strings.HasPrefix(exp, "+") {
+		sign = "+"
+	}
+	digits := strconv.Itoa(e)
+	for len(digits) < strings.Count(exp, "0") {
+		digits = "0" + digits
+	}
+	return m + "E" + sign + digits
+}
+
+// groupThousands inserts a comma between every group of three digits.
+func groupThousands(s string) string {
+	if len(s) <= 3 {
+		return s
+	}
+	var b strings.Builder
+	head := len(s) % 3
+	if head > 0 {
+		b.WriteString(s[:head])
+	}
+	for i := head; i < len(s); i += 3 {
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(s[i : i+3])
+	}
+	return b.String()
+}
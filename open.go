@@ -16,6 +16,12 @@ func Open(path string) (*Presentation, error) {
 	return reader.Read(path)
 }
 
+// OpenWithOptions reads a PPTX file from disk with the given reader options.
+func OpenWithOptions(path string, opts ReaderOptions) (*Presentation, error) {
+	reader := &PPTXReader{Options: opts}
+	return reader.Read(path)
+}
+
 // ReadFrom reads a PPTX from an io.ReaderAt with the given size.
 func ReadFrom(r io.ReaderAt, size int64) (*Presentation, error) {
 	reader, err := NewReader(ReaderPowerPoint2007)
@@ -25,6 +31,15 @@ func ReadFrom(r io.ReaderAt, size int64) (*Presentation, error) {
 	return reader.ReadFromReader(r, size)
 }
 
+// ReadBytes reads a PPTX held in memory.
+func ReadBytes(data []byte) (*Presentation, error) {
+	reader, err := NewReader(ReaderPowerPoint2007)
+	if err != nil {
+		return nil, err
+	}
+	return reader.ReadBytes(data)
+}
+
 // OpenTemplate opens a PPTX template file and returns a Presentation.
 // Unlike Open, this removes all existing slides so you can add new ones
 // using the template's layouts. The slide layouts and masters are preserved.
@@ -71,6 +86,8 @@ func (p *Presentation) Close() error {
 	p.properties = nil
 	p.presentationProperties = nil
 	p.layout = nil
+	p.source = nil
+	p.packageIssues = nil
 	return nil
 }
 
@@ -146,12 +163,17 @@ func (p *Presentation) CopySlide(index int) (*Slide, error) {
 	dst.name = src.name
 	dst.notes = src.notes
 	dst.visible = src.visible
+	dst.timing = src.timing
 	if src.transition != nil {
 		t := *src.transition
 		dst.transition = &t
 	}
 	if src.background != nil {
 		bg := *src.background
+		if bg.Tile != nil {
+			tile := *bg.Tile
+			bg.Tile = &tile
+		}
 		dst.background = &bg
 	}
 	// Copy shapes slice (shapes are reference types)
@@ -0,0 +1,56 @@
+package gopresentation
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// forEachSlide calls fn for every slide index. With opts.Parallelism above
+// 1 the slides are spread over that many goroutines, each passing fn its
+// own copy of opts whose font cache shares the parsed fonts of
+// opts.FontCache. After a failure no further slides are started, and the
+// error of the lowest failing slide is returned.
+func (p *Presentation) forEachSlide(opts *RenderOptions, fn func(i int, opts *RenderOptions) error) error {
+	n := len(p.slides)
+	workers := minInt(opts.Parallelism, n)
+	if workers < 2 {
+		for i := 0; i < n; i++ {
+			if err := fn(i, opts); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	fc := opts.FontCache
+	if fc == nil {
+		fc = NewFontCache(opts.FontDirs...)
+	}
+	errs := make([]error, n)
+	var next atomic.Int64
+	var failed atomic.Bool
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wopts := *opts
+		wopts.FontCache = fc.Worker()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !failed.Load() {
+				i := int(next.Add(1) - 1)
+				if i >= n {
+					return
+				}
+				if errs[i] = fn(i, &wopts); errs[i] != nil {
+					failed.Store(true)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package gopresentation
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// patternBits holds the 8x8 tiles of the DrawingML preset patterns
+// (ST_PresetPatternVal). Each byte is a row with the leftmost pixel in the
+// high bit; set bits take the foreground color. Patterns missing here are
+// drawn as an even mix of the two colors.
+var patternBits = func() map[string][8]uint8 {
+	m := map[string][8]uint8{
+		"pct5":       {0x80, 0, 0, 0, 0x08, 0, 0, 0},
+		"pct10":      {0x80, 0, 0x08, 0, 0x80, 0, 0x08, 0},
+		"pct20":      {0x88, 0, 0x22, 0, 0x88, 0, 0x22, 0},
+		"pct25":      {0x88, 0x22, 0x88, 0x22, 0x88, 0x22, 0x88, 0x22},
+		"pct30":      {0xAA, 0x40, 0xAA, 0x04, 0xAA, 0x40, 0xAA, 0x04},
+		"pct40":      {0xAA, 0x55, 0xAA, 0x11, 0xAA, 0x55, 0xAA, 0x44},
+		"pct50":      {0xAA, 0x55, 0xAA, 0x55, 0xAA, 0x55, 0xAA, 0x55},
+		"ltHorz":     {0xFF, 0, 0, 0, 0, 0, 0, 0},
+		"horz":       {0xFF, 0, 0, 0, 0xFF, 0, 0, 0},
+		"narHorz":    {0xFF, 0, 0xFF, 0, 0xFF, 0, 0xFF, 0},
+		"dkHorz":     {0xFF, 0xFF, 0, 0, 0xFF, 0xFF, 0, 0},
+		"dashHorz":   {0xF0, 0, 0, 0, 0x0F, 0, 0, 0},
+		"ltVert":     {0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80},
+		"vert":       {0x88, 0x88, 0x88, 0x88, 0x88, 0x88, 0x88, 0x88},
+		"narVert":    {0xAA, 0xAA, 0xAA, 0xAA, 0xAA, 0xAA, 0xAA, 0xAA},
+		"dkVert":     {0xCC, 0xCC, 0xCC, 0xCC, 0xCC, 0xCC, 0xCC, 0xCC},
+		"dashVert":   {0x80, 0x80, 0x80, 0x80, 0x08, 0x08, 0x08, 0x08},
+		"dnDiag":     {0x88, 0x44, 0x22, 0x11, 0x88, 0x44, 0x22, 0x11},
+		"ltDnDiag":   {0x80, 0x40, 0x20, 0x10, 0x08, 0x04, 0x02, 0x01},
+		"dkDnDiag":   {0xCC, 0x66, 0x33, 0x99, 0xCC, 0x66, 0x33, 0x99},
+		"wdDnDiag":   {0xC1, 0xE0, 0x70, 0x38, 0x1C, 0x0E, 0x07, 0x83},
+		"dashDnDiag": {0x88, 0x44, 0, 0, 0x88, 0x44, 0, 0},
+		"smGrid":     {0xFF, 0x88, 0x88, 0x88, 0xFF, 0x88, 0x88, 0x88},
+		"lgGrid":     {0xFF, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80},
+		"cross":      {0xFF, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80},
+		"diagCross":  {0x81, 0x42, 0x24, 0x18, 0x18, 0x24, 0x42, 0x81},
+		"smCheck":    {0xCC, 0xCC, 0x33, 0x33, 0xCC, 0xCC, 0x33, 0x33},
+		"lgCheck":    {0xF0, 0xF0, 0xF0, 0xF0, 0x0F, 0x0F, 0x0F, 0x0F},
+		"openDmnd":   {0x80, 0x41, 0x22, 0x14, 0x08, 0x14, 0x22, 0x41},
+		"solidDmnd":  {0x08, 0x1C, 0x3E, 0x7F, 0x3E, 0x1C, 0x08, 0},
+		"horzBrick":  {0xFF, 0x80, 0x80, 0x80, 0xFF, 0x08, 0x08, 0x08},
+		"dotGrid":    {0xAA, 0, 0x80, 0, 0x80, 0, 0x80, 0},
+	}
+	// The dense percentages are the inverses of the light ones, and the
+	// upward diagonals are the downward ones mirrored.
+	invert := func(b [8]uint8) [8]uint8 {
+		for i := range b {
+			b[i] = ^b[i]
+		}
+		return b
+	}
+	mirror := func(b [8]uint8) [8]uint8 {
+		for i := range b {
+			var v uint8
+			for j := 0; j < 8; j++ {
+				if b[i]&(1<<j) != 0 {
+					v |= 0x80 >> j
+				}
+			}
+			b[i] = v
+		}
+		return b
+	}
+	m["pct60"] = invert(m["pct40"])
+	m["pct70"] = invert(m["pct30"])
+	m["pct75"] = invert(m["pct25"])
+	m["pct80"] = invert(m["pct20"])
+	m["pct90"] = invert(m["pct10"])
+	m["upDiag"] = mirror(m["dnDiag"])
+	m["ltUpDiag"] = mirror(m["ltDnDiag"])
+	m["dkUpDiag"] = mirror(m["dkDnDiag"])
+	m["wdUpDiag"] = mirror(m["wdDnDiag"])
+	m["dashUpDiag"] = mirror(m["dashDnDiag"])
+	return m
+}()
+
+// fillPattern tiles a preset pattern over rect. A pattern pixel is one
+// pixel at 96 DPI, scaled with the slide; the tiles start at the rect's
+// top-left corner.
+func (r *renderer) fillPattern(rect image.Rectangle, fill *Fill) {
+	fg := r.scaleAlpha(r.rgba(fill.Color))
+	bg := r.scaleAlpha(r.rgba(fill.EndColor))
+	bits, ok := patternBits[fill.Pattern]
+	if !ok {
+		r.fillRectBlend(rect, lerpColor(fg, bg, 0.5))
+		return
+	}
+	cell := maxInt(int(math.Round(9525*r.scaleX)), 1)
+	clip := rect.Intersect(r.img.Bounds())
+	for y := clip.Min.Y; y < clip.Max.Y; y++ {
+		row := bits[((y-rect.Min.Y)/cell)%8]
+		for x := clip.Min.X; x < clip.Max.X; x++ {
+			if row&(0x80>>(((x-rect.Min.X)/cell)%8)) != 0 {
+				r.blendPixel(x, y, fg)
+			} else {
+				r.blendPixel(x, y, bg)
+			}
+		}
+	}
+}
+
+// fillPicture stretches a picture fill over rect, or tiles it when the
+// fill has tile settings. Images that cannot be decoded leave the area
+// unfilled.
+func (r *renderer) fillPicture(rect image.Rectangle, fill *Fill) {
+	if rect.Dx() <= 0 || rect.Dy() <= 0 || len(fill.Picture) == 0 {
+		return
+	}
+	src, _, err := image.Decode(bytes.NewReader(fill.Picture))
+	if err != nil {
+		if src = decodeMetafileBitmap(fill.Picture, r.fontCache); src == nil {
+			return
+		}
+	}
+	if fill.Tile != nil {
+		r.tilePicture(rect, src, fill.Tile)
+		return
+	}
+	draw.Draw(r.img, rect, scaleImageFiltered(src, rect.Dx(), rect.Dy(), r.imageFilter), image.Point{}, draw.Over)
+}
+
+// fillMeanColor returns the average color of a fill, for areas too small
+// to show its variation: the mean of a gradient's or pattern's two colors,
+// or the mean of a picture's pixels weighted by their alpha. Pictures that
+// cannot be decoded are transparent, as fillPicture leaves them unfilled.
+func (r *renderer) fillMeanColor(fill *Fill) color.RGBA {
+	switch fill.Type {
+	case FillGradientLinear, FillGradientPath, FillPattern:
+		return lerpColor(r.rgba(fill.Color), r.rgba(fill.EndColor), 0.5)
+	case FillPicture:
+		src, _, err := image.Decode(bytes.NewReader(fill.Picture))
+		if err != nil {
+			if src = decodeMetafileBitmap(fill.Picture, r.fontCache); src == nil {
+				return color.RGBA{}
+			}
+		}
+		return meanImageColor(src)
+	}
+	return r.rgba(fill.Color)
+}
+
+// meanImageColor averages the pixels of img, sampling at most a 64x64 grid
+// of them.
+func meanImageColor(img image.Image) color.RGBA {
+	b := img.Bounds()
+	if b.Empty() {
+		return color.RGBA{}
+	}
+	nx, ny := min(b.Dx(), 64), min(b.Dy(), 64)
+	var sr, sg, sb, sa uint64
+	for j := 0; j < ny; j++ {
+		y := b.Min.Y + (2*j+1)*b.Dy()/(2*ny)
+		for i := 0; i < nx; i++ {
+			// RGBA is alpha-premultiplied, so the color sums are weighted
+			// by alpha.
+			cr, cg, cb, ca := img.At(b.Min.X+(2*i+1)*b.Dx()/(2*nx), y).RGBA()
+			sr, sg, sb, sa = sr+uint64(cr), sg+uint64(cg), sb+uint64(cb), sa+uint64(ca)
+		}
+	}
+	if sa == 0 {
+		return color.RGBA{}
+	}
+	n := uint64(nx * ny)
+	return color.RGBA{
+		R: uint8(sr * 255 / sa),
+		G: uint8(sg * 255 / sa),
+		B: uint8(sb * 255 / sa),
+		A: uint8(sa / n >> 8),
+	}
+}
+
+// tilePicture repeats src over rect at the tile's scale of the picture's
+// size at 96 dpi, starting from the tile's alignment point and offset.
+func (r *renderer) tilePicture(rect image.Rectangle, src image.Image, t *PictureTile) {
+	const emuPerPixel96 = 9525
+	b := src.Bounds()
+	tw := int(math.Round(float64(b.Dx()) * emuPerPixel96 * math.Abs(float64(t.ScaleX)) / 100000 * r.scaleX))
+	th := int(math.Round(float64(b.Dy()) * emuPerPixel96 * math.Abs(float64(t.ScaleY)) / 100000 * r.scaleY))
+	if tw <= 0 || th <= 0 {
+		return
+	}
+	// Tiles in odd columns and rows are mirrored for the flip modes.
+	var tiles [4]*image.RGBA
+	tiles[0] = scaleImageFiltered(src, tw, th, r.imageFilter)
+	variant := func(i, j int) *image.RGBA {
+		k := 0
+		if (t.Flip == "x" || t.Flip == "xy") && i&1 != 0 {
+			k |= 1
+		}
+		if (t.Flip == "y" || t.Flip == "xy") && j&1 != 0 {
+			k |= 2
+		}
+		if tiles[k] == nil {
+			img := image.NewRGBA(tiles[0].Rect)
+			copy(img.Pix, tiles[0].Pix)
+			if k&1 != 0 {
+				flipRGBAHorizontal(img)
+			}
+			if k&2 != 0 {
+				flipRGBAVertical(img)
+			}
+			tiles[k] = img
+		}
+		return tiles[k]
+	}
+	ox, oy := rect.Min.X, rect.Min.Y
+	switch t.Align {
+	case "t", "ctr", "b":
+		ox = rect.Min.X + (rect.Dx()-tw)/2
+	case "tr", "r", "br":
+		ox = rect.Max.X - tw
+	}
+	switch t.Align {
+	case "l", "ctr", "r":
+		oy = rect.Min.Y + (rect.Dy()-th)/2
+	case "bl", "b", "br":
+		oy = rect.Max.Y - th
+	}
+	ox += int(math.Round(float64(t.OffsetX) * r.scaleX))
+	oy += int(math.Round(float64(t.OffsetY) * r.scaleY))
+	floorDiv := func(a, n int) int {
+		if a < 0 {
+			return -((-a + n - 1) / n)
+		}
+		return a / n
+	}
+	clip := rect.Intersect(r.img.Bounds())
+	for j := floorDiv(clip.Min.Y-oy, th); oy+j*th < clip.Max.Y; j++ {
+		for i := floorDiv(clip.Min.X-ox, tw); ox+i*tw < clip.Max.X; i++ {
+			at := image.Pt(ox+i*tw, oy+j*th)
+			dst := image.Rect(at.X, at.Y, at.X+tw, at.Y+th).Intersect(clip)
+			if !dst.Empty() {
+				draw.Draw(r.img, dst, variant(i, j), dst.Min.Sub(at), draw.Over)
+			}
+		}
+	}
+}
@@ -0,0 +1,691 @@
+package gopresentation
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// SaveAsPDF renders the presentation and saves it as a PDF file with one
+// page per slide. See WritePDF.
+func (p *Presentation) SaveAsPDF(path string, opts *RenderOptions) error {
+	dir := filepath.Dir(path)
+	if dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			return fmt.Errorf("create directory: %w", err)
+		}
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("create file: %w", err)
+	}
+	writeErr := p.WritePDF(f, opts)
+	closeErr := f.Close()
+	if writeErr != nil {
+		return writeErr
+	}
+	return closeErr
+}
+
+// WritePDF renders the presentation and writes it to w as a PDF with one
+// page per slide, sized like the slide plus any bleed and margin. Hidden
+// slides are skipped, as in PowerPoint's PDF export.
+//
+// Each page holds the rendered slide as an image, JPEG-compressed when
+// opts.Format is ImageFormatJPEG and losslessly compressed otherwise, with
+// the slide's text laid invisibly over it so that it can be searched and
+// copied. The text is tagged with the slide's Structure (headings,
+// paragraphs, tables and figures with their alt text) for screen readers,
+// and each slide gets a bookmark named after its title. The file is marked
+// as PDF/UA: the text layer's font is embedded, the catalog gives the
+// document language (the Language property, en-US when unset) and XMP
+// metadata repeats the document properties. A presentation without a
+// Title property is titled after its first slide with one.
+func (p *Presentation) WritePDF(w io.Writer, opts *RenderOptions) error {
+	if opts == nil {
+		opts = DefaultRenderOptions()
+	}
+	pageOpts := *opts
+	if pageOpts.Width <= 0 {
+		pageOpts.Width = 960
+	}
+	if pageOpts.FontCache == nil {
+		pageOpts.FontCache = NewFontCache(pageOpts.FontDirs...)
+	}
+
+	pages := make([]*pdfPage, len(p.slides))
+	err := p.forEachSlide(&pageOpts, func(i int, opts *RenderOptions) error {
+		if !p.slides[i].visible {
+			return nil
+		}
+		img, err := p.SlideToImage(i, opts)
+		if err != nil {
+			return fmt.Errorf("slide %d: %w", i+1, err)
+		}
+		page, err := newPDFPage(img, opts)
+		if err != nil {
+			return fmt.Errorf("slide %d: %w", i+1, err)
+		}
+		page.slide = p.slides[i]
+		page.number = i + 1
+		pages[i] = page
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// The page is as many points wide as the slide plus bleed and margin.
+	edge := float64(max(pageOpts.Bleed, 0)+max(pageOpts.Margin, 0)) / 12700
+	slideW := float64(p.layout.CX) / 12700
+	pw := &pdfWriter{w: bufio.NewWriter(w)}
+	doc := &pdfDocument{pw: pw, edge: edge, ptPerPixel: slideW / float64(pageOpts.Width)}
+	for _, page := range pages {
+		if page != nil {
+			doc.pages = append(doc.pages, page)
+		}
+	}
+	if len(doc.pages) == 0 {
+		return errors.New("no visible slides to export")
+	}
+	var props DocumentProperties
+	if p.properties != nil {
+		props = *p.properties
+	}
+	for _, page := range doc.pages {
+		if props.Title != "" {
+			break
+		}
+		props.Title = strings.Join(strings.Fields(page.slide.GetTitle()), " ")
+	}
+	if err := doc.write(&props); err != nil {
+		return err
+	}
+	return pw.w.Flush()
+}
+
+// pdfPage is a rendered slide ready to be written as a PDF page.
+type pdfPage struct {
+	slide      *Slide
+	number     int // 1-based slide number
+	width      int // image size in pixels
+	height     int
+	data       []byte // compressed image samples
+	filter     string // DCTDecode or FlateDecode
+	colorSpace string // DeviceRGB or DeviceGray
+}
+
+// newPDFPage compresses a rendered slide for embedding.
+func newPDFPage(img image.Image, opts *RenderOptions) (*pdfPage, error) {
+	b := img.Bounds()
+	page := &pdfPage{width: b.Dx(), height: b.Dy(), colorSpace: "DeviceRGB"}
+	gray, isGray := img.(*image.Gray)
+	if isGray {
+		page.colorSpace = "DeviceGray"
+	}
+	var buf bytes.Buffer
+	if opts.Format == ImageFormatJPEG {
+		jpegOpts := *opts
+		jpegOpts.Format = ImageFormatJPEG
+		if err := EncodeImage(&buf, img, &jpegOpts); err != nil {
+			return nil, err
+		}
+		page.data, page.filter = buf.Bytes(), "DCTDecode"
+		return page, nil
+	}
+	zw := zlib.NewWriter(&buf)
+	var row []byte
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		row = row[:0]
+		switch {
+		case isGray:
+			off := (y - b.Min.Y) * gray.Stride
+			row = append(row, gray.Pix[off:off+b.Dx()]...)
+		default:
+			for x := b.Min.X; x < b.Max.X; x++ {
+				r, g, bl, _ := img.At(x, y).RGBA()
+				row = append(row, uint8(r>>8), uint8(g>>8), uint8(bl>>8))
+			}
+		}
+		if _, err := zw.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	page.data, page.filter = buf.Bytes(), "FlateDecode"
+	return page, nil
+}
+
+// pdfWriter writes numbered PDF objects and remembers their offsets for
+// the cross-reference table.
+type pdfWriter struct {
+	w       *bufio.Writer
+	n       int64
+	offsets []int64 // by object number - 1; 0 until written
+	err     error
+}
+
+func (pw *pdfWriter) printf(format string, args ...any) {
+	if pw.err != nil {
+		return
+	}
+	n, err := fmt.Fprintf(pw.w, format, args...)
+	pw.n += int64(n)
+	pw.err = err
+}
+
+func (pw *pdfWriter) write(data []byte) {
+	if pw.err != nil {
+		return
+	}
+	n, err := pw.w.Write(data)
+	pw.n += int64(n)
+	pw.err = err
+}
+
+// alloc reserves an object number.
+func (pw *pdfWriter) alloc() int {
+	pw.offsets = append(pw.offsets, 0)
+	return len(pw.offsets)
+}
+
+// object writes object id with the given dictionary or value.
+func (pw *pdfWriter) object(id int, body string) {
+	pw.offsets[id-1] = pw.n
+	pw.printf("%d 0 obj\n%s\nendobj\n", id, body)
+}
+
+// stream writes object id as a stream with the given dictionary entries.
+func (pw *pdfWriter) stream(id int, dict string, data []byte) {
+	pw.offsets[id-1] = pw.n
+	pw.printf("%d 0 obj\n<<%s /Length %d>>\nstream\n", id, dict, len(data))
+	pw.write(data)
+	pw.printf("\nendstream\nendobj\n")
+}
+
+// pdfDocument lays out the pages, text layer, structure tree and outline.
+type pdfDocument struct {
+	pw         *pdfWriter
+	pages      []*pdfPage
+	edge       float64 // bleed plus margin in points
+	ptPerPixel float64
+
+	structRoot int
+	docElem    int
+	elems      []string // struct element objects, written at the end
+	elemIDs    []int
+	parents    []string // ParentTree arrays by page
+}
+
+// addElem reserves a structure element to be written with dict once the
+// pages are done.
+func (d *pdfDocument) addElem(dict string) int {
+	id := d.pw.alloc()
+	d.elemIDs = append(d.elemIDs, id)
+	d.elems = append(d.elems, dict)
+	return id
+}
+
+func (d *pdfDocument) write(props *DocumentProperties) error {
+	pw := d.pw
+	pw.printf("%%PDF-1.7\n%%\xe2\xe3\xcf\xd3\n")
+
+	catalog := pw.alloc()
+	pagesID := pw.alloc()
+	fontID := pw.alloc()
+	d.structRoot = pw.alloc()
+	d.docElem = pw.alloc()
+	outlines := pw.alloc()
+
+	d.writeFont(fontID)
+
+	pageIDs := make([]int, len(d.pages))
+	for i := range d.pages {
+		pageIDs[i] = pw.alloc()
+	}
+	var docKids []string
+	for i, page := range d.pages {
+		kids := d.writePage(i, pageIDs[i], pagesID, fontID, page)
+		docKids = append(docKids, kids...)
+	}
+
+	refs := make([]string, len(pageIDs))
+	for i, id := range pageIDs {
+		refs[i] = fmt.Sprintf("%d 0 R", id)
+	}
+	pw.object(pagesID, fmt.Sprintf("<</Type /Pages /Kids [%s] /Count %d>>", strings.Join(refs, " "), len(pageIDs)))
+
+	for i, id := range d.elemIDs {
+		pw.object(id, d.elems[i])
+	}
+	pw.object(d.docElem, fmt.Sprintf("<</Type /StructElem /S /Document /P %d 0 R /K [%s]>>", d.structRoot, strings.Join(docKids, " ")))
+	var nums strings.Builder
+	for i, arr := range d.parents {
+		fmt.Fprintf(&nums, " %d [%s]", i, arr)
+	}
+	pw.object(d.structRoot, fmt.Sprintf("<</Type /StructTreeRoot /K %d 0 R /ParentTree <</Nums [%s]>> /ParentTreeNextKey %d>>",
+		d.docElem, nums.String(), len(d.parents)))
+
+	d.writeOutline(outlines, pageIDs)
+
+	info := pw.alloc()
+	pw.object(info, pdfInfo(props))
+	metadata := pw.alloc()
+	pw.stream(metadata, "/Type /Metadata /Subtype /XML", pdfXMP(props))
+	lang := "en-US"
+	if props != nil && props.Language != "" {
+		lang = props.Language
+	}
+	cat := fmt.Sprintf("<</Type /Catalog /Pages %d 0 R /Outlines %d 0 R /PageMode /UseOutlines /MarkInfo <</Marked true>> /StructTreeRoot %d 0 R /Lang <%s> /Metadata %d 0 R",
+		pagesID, outlines, d.structRoot, pdfUTF16WithBOM(lang), metadata)
+	if props != nil && props.Title != "" {
+		cat += " /ViewerPreferences <</DisplayDocTitle true>>"
+	}
+	pw.object(catalog, cat+">>")
+
+	xref := pw.n
+	pw.printf("xref\n0 %d\n0000000000 65535 f \n", len(pw.offsets)+1)
+	for _, off := range pw.offsets {
+		pw.printf("%010d 00000 n \n", off)
+	}
+	pw.printf("trailer\n<</Size %d /Root %d 0 R /Info %d 0 R>>\nstartxref\n%d\n%%%%EOF\n", len(pw.offsets)+1, catalog, info, xref)
+	return pw.err
+}
+
+// writeFont writes the font of the invisible text layer: a CID font whose
+// character codes are UTF-16 code units, mapped back to Unicode for text
+// extraction. Its glyphs are never drawn, so the embedded program is a
+// TrueType font with one blank glyph that every code maps to.
+func (d *pdfDocument) writeFont(id int) {
+	pw := d.pw
+	cid := pw.alloc()
+	desc := pw.alloc()
+	fontFile := pw.alloc()
+	gidMap := pw.alloc()
+	toUnicode := pw.alloc()
+	pw.object(id, fmt.Sprintf("<</Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R>>", pdfTextFontName, cid, toUnicode))
+	pw.object(cid, fmt.Sprintf("<</Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo <</Registry (Adobe) /Ordering (Identity) /Supplement 0>> /FontDescriptor %d 0 R /DW %d /CIDToGIDMap %d 0 R>>", pdfTextFontName, desc, pdfTextAdvance, gidMap))
+	pw.object(desc, fmt.Sprintf("<</Type /FontDescriptor /FontName /%s /Flags 4 /FontBBox [0 -200 %d 800] /ItalicAngle 0 /Ascent 800 /Descent -200 /CapHeight 700 /StemV 80 /FontFile2 %d 0 R>>", pdfTextFontName, pdfTextAdvance, fontFile))
+	program := pdfTextFontProgram()
+	pw.stream(fontFile, fmt.Sprintf("/Filter /FlateDecode /Length1 %d", len(program)), pdfDeflate(program))
+	gids := make([]byte, 2*0x10000)
+	for i := 1; i < len(gids); i += 2 {
+		gids[i] = 1
+	}
+	pw.stream(gidMap, "/Filter /FlateDecode", pdfDeflate(gids))
+
+	var cmap strings.Builder
+	cmap.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo <</Registry (Adobe) /Ordering (UCS) /Supplement 0>> def\n" +
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	// bfrange entries may only vary in the last byte, and a block holds
+	// at most 100 of them.
+	for hi := 0; hi < 256; hi += 100 {
+		n := min(100, 256-hi)
+		fmt.Fprintf(&cmap, "%d beginbfrange\n", n)
+		for b := hi; b < hi+n; b++ {
+			fmt.Fprintf(&cmap, "<%02X00> <%02XFF> <%02X00>\n", b, b, b)
+		}
+		cmap.WriteString("endbfrange\n")
+	}
+	cmap.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	pw.stream(toUnicode, "", []byte(cmap.String()))
+}
+
+// pdfTextAdvance is the advance of every character of the text layer in
+// thousandths of the font size.
+const pdfTextAdvance = 500
+
+// pdfTextFontName is the name of the text layer's font.
+const pdfTextFontName = "GoPPT-TextLayer"
+
+// pdfTextFontProgram returns the text layer's TrueType font: a .notdef
+// glyph and the blank glyph 1, both pdfTextAdvance wide on a 1000-unit em,
+// with only the tables a PDF CIDFontType2 program needs.
+func pdfTextFontProgram() []byte {
+	be := binary.BigEndian
+	head := make([]byte, 54)
+	be.PutUint32(head[0:], 0x00010000)  // version
+	be.PutUint32(head[4:], 0x00010000)  // fontRevision
+	be.PutUint32(head[12:], 0x5F0F3CF5) // magicNumber
+	be.PutUint16(head[16:], 0x000B)     // flags: y=0 baseline, x=0 lsb, integer ppem
+	be.PutUint16(head[18:], 1000)       // unitsPerEm
+	be.PutUint16(head[46:], 8)          // lowestRecPPEM
+	be.PutUint16(head[48:], 2)          // fontDirectionHint
+
+	hhea := make([]byte, 36)
+	be.PutUint32(hhea[0:], 0x00010000)
+	be.PutUint16(hhea[4:], 800)
+	be.PutUint16(hhea[6:], uint16(0x10000-200)) // descender -200
+	be.PutUint16(hhea[10:], uint16(pdfTextAdvance))
+	be.PutUint16(hhea[18:], 1) // caretSlopeRise
+	be.PutUint16(hhea[34:], 2) // numberOfHMetrics
+
+	maxp := make([]byte, 32)
+	be.PutUint32(maxp[0:], 0x00010000)
+	be.PutUint16(maxp[4:], 2)  // numGlyphs
+	be.PutUint16(maxp[14:], 2) // maxZones
+
+	// cmap maps no character: the PDF addresses glyphs by CID.
+	cmap := make([]byte, 36)
+	be.PutUint16(cmap[2:], 1)  // numTables
+	be.PutUint16(cmap[4:], 3)  // platformID: Windows
+	be.PutUint16(cmap[6:], 1)  // encodingID: Unicode BMP
+	be.PutUint32(cmap[8:], 12) // subtable offset
+	be.PutUint16(cmap[12:], 4) // format
+	be.PutUint16(cmap[14:], 24)
+	be.PutUint16(cmap[18:], 2) // segCountX2: the final 0xFFFF segment only
+	be.PutUint16(cmap[20:], 2) // searchRange
+	be.PutUint16(cmap[26:], 0xFFFF)
+	be.PutUint16(cmap[30:], 0xFFFF)
+	be.PutUint16(cmap[32:], 1)
+
+	post := make([]byte, 32)
+	be.PutUint32(post[0:], 0x00030000) // no glyph names
+	be.PutUint16(post[8:], uint16(0x10000-100))
+	be.PutUint16(post[10:], 50)
+	be.PutUint32(post[12:], 1) // isFixedPitch
+
+	hmtx := make([]byte, 8)
+	be.PutUint16(hmtx[0:], uint16(pdfTextAdvance))
+	be.PutUint16(hmtx[4:], uint16(pdfTextAdvance))
+
+	tables := []struct {
+		tag  string
+		data []byte
+	}{
+		// Sorted by tag. Both glyphs are empty, so glyf is too and
+		// every short loca offset is 0.
+		{"cmap", cmap},
+		{"glyf", nil},
+		{"head", head},
+		{"hhea", hhea},
+		{"hmtx", hmtx},
+		{"loca", make([]byte, 6)},
+		{"maxp", maxp},
+		{"post", post},
+	}
+	checksum := func(b []byte) uint32 {
+		var sum uint32
+		for i := 0; i < len(b); i += 4 {
+			var w [4]byte
+			copy(w[:], b[i:])
+			sum += be.Uint32(w[:])
+		}
+		return sum
+	}
+
+	n := len(tables)
+	font := make([]byte, 12+16*n)
+	be.PutUint32(font[0:], 0x00010000)
+	be.PutUint16(font[4:], uint16(n))
+	sel := bits.Len(uint(n)) - 1
+	be.PutUint16(font[6:], uint16(16<<sel))       // searchRange
+	be.PutUint16(font[8:], uint16(sel))           // entrySelector
+	be.PutUint16(font[10:], uint16(16*n-16<<sel)) // rangeShift
+	for i, t := range tables {
+		rec := font[12+16*i:]
+		copy(rec, t.tag)
+		be.PutUint32(rec[4:], checksum(t.data))
+		be.PutUint32(rec[8:], uint32(len(font)))
+		be.PutUint32(rec[12:], uint32(len(t.data)))
+		font = append(font, t.data...)
+		for len(font)%4 != 0 {
+			font = append(font, 0)
+		}
+	}
+	// head's checkSumAdjustment makes the whole font sum to the magic
+	// value; head is the third table.
+	headOff := be.Uint32(font[12+32+8:])
+	be.PutUint32(font[headOff+8:], 0xB1B0AFBA-checksum(font))
+	return font
+}
+
+// pdfDeflate zlib-compresses data for a FlateDecode stream.
+func pdfDeflate(data []byte) []byte {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	zw.Write(data)
+	zw.Close()
+	return buf.Bytes()
+}
+
+// writePage writes page i with its image, content and text layer, and
+// returns the references of its top-level structure elements.
+func (d *pdfDocument) writePage(i, pageID, pagesID, fontID int, page *pdfPage) []string {
+	pw := d.pw
+	w := float64(page.width) * d.ptPerPixel
+	h := float64(page.height) * d.ptPerPixel
+
+	imgID := pw.alloc()
+	pw.stream(imgID, fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /%s",
+		page.width, page.height, page.colorSpace, page.filter), page.data)
+
+	var content bytes.Buffer
+	fmt.Fprintf(&content, "/Artifact BMC\nq %s 0 0 %s 0 0 cm /Im0 Do Q\nEMC\n", pdfNum(w), pdfNum(h))
+
+	var kids []string  // top-level elements of this page
+	var mcids []string // structure element of each MCID
+	pageRef := fmt.Sprintf("%d 0 R", pageID)
+	// mark adds a structure element from dict, which lacks its closing
+	// and kids, with a marked-content sequence holding text set at x, y.
+	mark := func(tag, dict string, x, y, size float64, text string) int {
+		mcid := len(mcids)
+		id := d.addElem(fmt.Sprintf("%s /Pg %s /K %d>>", dict, pageRef, mcid))
+		mcids = append(mcids, fmt.Sprintf("%d 0 R", id))
+		fmt.Fprintf(&content, "/%s <</MCID %d>> BDC\n", tag, mcid)
+		if text != "" {
+			fmt.Fprintf(&content, "BT 3 Tr /F1 %s Tf 1 0 0 1 %s %s Tm <%s> Tj ET\n",
+				pdfNum(size), pdfNum(x), pdfNum(h-y-size), pdfUTF16(text))
+		}
+		content.WriteString("EMC\n")
+		return id
+	}
+
+	// Text is placed at the top left of its shape, one line per
+	// paragraph, sized to roughly span the shape's width.
+	lines := map[Shape]float64{}
+	place := func(shape Shape, text string) (x, y, size float64) {
+		b := shape.base()
+		x = float64(b.offsetX)/12700 + d.edge
+		y = float64(b.offsetY)/12700 + d.edge
+		bw, bh := float64(b.width)/12700, float64(b.height)/12700
+		n := len(utf16.Encode([]rune(text)))
+		size = 12
+		if n > 0 && bw > 0 {
+			size = bw * 1000 / pdfTextAdvance / float64(n)
+		}
+		if bh > 0 {
+			size = min(size, bh)
+		}
+		size = max(min(size, 48), 2)
+		y += lines[shape]
+		lines[shape] += size * 1.2
+		x = max(min(x, w-1), 0)
+		y = max(min(y, h-size), 0)
+		return x, y, size
+	}
+
+	for _, el := range page.slide.Structure() {
+		switch el.Role {
+		case StructHeading1, StructHeading2, StructParagraph:
+			x, y, size := place(el.Shape, el.Text)
+			id := mark(string(el.Role), fmt.Sprintf("<</Type /StructElem /S /%s /P %d 0 R", el.Role, d.docElem), x, y, size, el.Text)
+			kids = append(kids, fmt.Sprintf("%d 0 R", id))
+		case StructFigure:
+			b := el.Shape.base()
+			x0 := float64(b.offsetX)/12700 + d.edge
+			y0 := h - float64(b.offsetY)/12700 - d.edge
+			bbox := fmt.Sprintf("[%s %s %s %s]", pdfNum(x0), pdfNum(y0-float64(b.height)/12700), pdfNum(x0+float64(b.width)/12700), pdfNum(y0))
+			alt := ""
+			if el.Alt != "" {
+				alt = " /Alt <" + pdfUTF16WithBOM(el.Alt) + ">"
+			}
+			id := mark("Figure", fmt.Sprintf("<</Type /StructElem /S /Figure /P %d 0 R%s /A <</O /Layout /BBox %s>>", d.docElem, alt, bbox), 0, 0, 0, "")
+			kids = append(kids, fmt.Sprintf("%d 0 R", id))
+		case StructTable:
+			table := d.pw.alloc()
+			var rows []string
+			for _, cells := range el.Cells {
+				tr := d.pw.alloc()
+				var tds []string
+				for _, cell := range cells {
+					dict := fmt.Sprintf("<</Type /StructElem /S /TD /P %d 0 R", tr)
+					text := strings.ReplaceAll(cell, "\n", " ")
+					var td int
+					if text != "" {
+						x, y, size := place(el.Shape, text)
+						td = mark("TD", dict, x, y, size, text)
+					} else {
+						td = mark("TD", dict, 0, 0, 0, "")
+					}
+					tds = append(tds, fmt.Sprintf("%d 0 R", td))
+				}
+				d.elemIDs = append(d.elemIDs, tr)
+				d.elems = append(d.elems, fmt.Sprintf("<</Type /StructElem /S /TR /P %d 0 R /K [%s]>>", table, strings.Join(tds, " ")))
+				rows = append(rows, fmt.Sprintf("%d 0 R", tr))
+			}
+			d.elemIDs = append(d.elemIDs, table)
+			d.elems = append(d.elems, fmt.Sprintf("<</Type /StructElem /S /Table /P %d 0 R /K [%s]>>", d.docElem, strings.Join(rows, " ")))
+			kids = append(kids, fmt.Sprintf("%d 0 R", table))
+		}
+	}
+	d.parents = append(d.parents, strings.Join(mcids, " "))
+
+	contentID := pw.alloc()
+	pw.stream(contentID, "/Filter /FlateDecode", pdfDeflate(content.Bytes()))
+	pw.object(pageID, fmt.Sprintf("<</Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources <</XObject <</Im0 %d 0 R>> /Font <</F1 %d 0 R>>>> /Contents %d 0 R /StructParents %d /Tabs /S>>",
+		pagesID, pdfNum(w), pdfNum(h), imgID, fontID, contentID, i))
+	return kids
+}
+
+// writeOutline writes a bookmark for each page, named after the slide's
+// title or its number.
+func (d *pdfDocument) writeOutline(id int, pageIDs []int) {
+	pw := d.pw
+	items := make([]int, len(d.pages))
+	for i := range items {
+		items[i] = pw.alloc()
+	}
+	for i, page := range d.pages {
+		title := strings.Join(strings.Fields(page.slide.GetTitle()), " ")
+		if title == "" {
+			title = fmt.Sprintf("Slide %d", page.number)
+		}
+		dict := fmt.Sprintf("<</Title <%s> /Parent %d 0 R /Dest [%d 0 R /Fit]", pdfUTF16WithBOM(title), id, pageIDs[i])
+		if i > 0 {
+			dict += fmt.Sprintf(" /Prev %d 0 R", items[i-1])
+		}
+		if i < len(items)-1 {
+			dict += fmt.Sprintf(" /Next %d 0 R", items[i+1])
+		}
+		pw.object(items[i], dict+">>")
+	}
+	pw.object(id, fmt.Sprintf("<</Type /Outlines /First %d 0 R /Last %d 0 R /Count %d>>", items[0], items[len(items)-1], len(items)))
+}
+
+// pdfInfo returns the document information dictionary for props.
+func pdfInfo(props *DocumentProperties) string {
+	var sb strings.Builder
+	sb.WriteString("<<")
+	if props != nil {
+		for _, e := range []struct{ key, value string }{
+			{"Title", props.Title},
+			{"Author", props.Creator},
+			{"Subject", props.Subject},
+			{"Keywords", props.Keywords},
+		} {
+			if e.value != "" {
+				fmt.Fprintf(&sb, "/%s <%s> ", e.key, pdfUTF16WithBOM(e.value))
+			}
+		}
+		if !props.Created.IsZero() {
+			fmt.Fprintf(&sb, "/CreationDate (%s) ", pdfDate(props.Created))
+		}
+		if !props.Modified.IsZero() {
+			fmt.Fprintf(&sb, "/ModDate (%s) ", pdfDate(props.Modified))
+		}
+	}
+	sb.WriteString(">>")
+	return sb.String()
+}
+
+// pdfXMP returns the XMP metadata of the document: the PDF/UA
+// identification and the properties of the information dictionary.
+func pdfXMP(props *DocumentProperties) []byte {
+	var sb strings.Builder
+	sb.WriteString("<?xpacket begin=\"\ufeff\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>\n" +
+		"<x:xmpmeta xmlns:x=\"adobe:ns:meta/\">\n" +
+		"<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\">\n" +
+		"<rdf:Description rdf:about=\"\" xmlns:dc=\"http://purl.org/dc/elements/1.1/\" xmlns:pdf=\"http://ns.adobe.com/pdf/1.3/\"" +
+		" xmlns:xmp=\"http://ns.adobe.com/xap/1.0/\" xmlns:pdfuaid=\"http://www.aiim.org/pdfua/ns/id/\">\n" +
+		"<pdfuaid:part>1</pdfuaid:part>\n<dc:format>application/pdf</dc:format>\n")
+	if props != nil {
+		if props.Title != "" {
+			fmt.Fprintf(&sb, "<dc:title><rdf:Alt><rdf:li xml:lang=\"x-default\">%s</rdf:li></rdf:Alt></dc:title>\n", xmlEscape(props.Title))
+		}
+		if props.Creator != "" {
+			fmt.Fprintf(&sb, "<dc:creator><rdf:Seq><rdf:li>%s</rdf:li></rdf:Seq></dc:creator>\n", xmlEscape(props.Creator))
+		}
+		if props.Subject != "" {
+			fmt.Fprintf(&sb, "<dc:description><rdf:Alt><rdf:li xml:lang=\"x-default\">%s</rdf:li></rdf:Alt></dc:description>\n", xmlEscape(props.Subject))
+		}
+		if props.Keywords != "" {
+			fmt.Fprintf(&sb, "<pdf:Keywords>%s</pdf:Keywords>\n", xmlEscape(props.Keywords))
+		}
+		if !props.Created.IsZero() {
+			fmt.Fprintf(&sb, "<xmp:CreateDate>%s</xmp:CreateDate>\n", props.Created.UTC().Format("2006-01-02T15:04:05Z"))
+		}
+		if !props.Modified.IsZero() {
+			fmt.Fprintf(&sb, "<xmp:ModifyDate>%s</xmp:ModifyDate>\n", props.Modified.UTC().Format("2006-01-02T15:04:05Z"))
+		}
+	}
+	sb.WriteString("</rdf:Description>\n</rdf:RDF>\n</x:xmpmeta>\n<?xpacket end=\"w\"?>")
+	return []byte(sb.String())
+}
+
+// pdfDate formats t as a PDF date string in UTC.
+func pdfDate(t time.Time) string {
+	return t.UTC().Format("D:20060102150405Z")
+}
+
+// pdfUTF16 hex-encodes s as UTF-16BE code units, the character codes of
+// the text layer font.
+func pdfUTF16(s string) string {
+	units := utf16.Encode([]rune(s))
+	buf := make([]byte, 0, 2*len(units))
+	for _, u := range units {
+		buf = append(buf, byte(u>>8), byte(u))
+	}
+	return strings.ToUpper(hex.EncodeToString(buf))
+}
+
+// pdfUTF16WithBOM hex-encodes s as a PDF text string.
+func pdfUTF16WithBOM(s string) string {
+	return "FEFF" + pdfUTF16(s)
+}
+
+// pdfNum formats v with at most two decimals.
+func pdfNum(v float64) string {
+	s := fmt.Sprintf("%.2f", v)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" || s == "" {
+		return "0"
+	}
+	return s
+}
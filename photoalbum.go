@@ -0,0 +1,214 @@
+package gopresentation
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// PhotoAlbumLayout is the picture layout of a photo album (ST_PhotoAlbumLayout).
+type PhotoAlbumLayout string
+
+const (
+	PhotoAlbumFitToSlide PhotoAlbumLayout = "fitToSlide"
+	PhotoAlbum1Pic       PhotoAlbumLayout = "1pic"
+	PhotoAlbum2Pic       PhotoAlbumLayout = "2pic"
+	PhotoAlbum4Pic       PhotoAlbumLayout = "4pic"
+	PhotoAlbum1PicTitle  PhotoAlbumLayout = "1picTitle"
+	PhotoAlbum2PicTitle  PhotoAlbumLayout = "2picTitle"
+	PhotoAlbum4PicTitle  PhotoAlbumLayout = "4picTitle"
+)
+
+// PhotoAlbumFrame is the frame shape given to the pictures of a photo album
+// (ST_PhotoAlbumFrameShape).
+type PhotoAlbumFrame string
+
+const (
+	PhotoAlbumFrameRectangle     PhotoAlbumFrame = "frameStyle1"
+	PhotoAlbumFrameRounded       PhotoAlbumFrame = "frameStyle2"
+	PhotoAlbumFrameSimpleWhite   PhotoAlbumFrame = "frameStyle3"
+	PhotoAlbumFrameSimpleBlack   PhotoAlbumFrame = "frameStyle4"
+	PhotoAlbumFrameCompoundBlack PhotoAlbumFrame = "frameStyle5"
+	PhotoAlbumFrameCenterShadow  PhotoAlbumFrame = "frameStyle6"
+	PhotoAlbumFrameSoftEdge      PhotoAlbumFrame = "frameStyle7"
+)
+
+// PhotoAlbum holds the settings of a presentation made with PowerPoint's
+// photo album feature (p:photoAlbum).
+//
+// PowerPoint writes most of a frame into each picture, but parts of it can
+// come from the layout and theme, which are not resolved for pictures. When
+// rendering, pictures that carry no outline, effects or geometry of their
+// own are therefore given the album's frame, and all pictures are drawn in
+// grayscale when BlackAndWhite is set.
+type PhotoAlbum struct {
+	BlackAndWhite bool
+	ShowCaptions  bool
+	Layout        PhotoAlbumLayout // "" means fitToSlide
+	Frame         PhotoAlbumFrame  // "" means frameStyle1
+}
+
+// GetPhotoAlbum returns the photo album settings, or nil if the
+// presentation is not a photo album.
+func (p *Presentation) GetPhotoAlbum() *PhotoAlbum { return p.photoAlbum }
+
+// SetPhotoAlbum sets the photo album settings; nil removes them.
+func (p *Presentation) SetPhotoAlbum(a *PhotoAlbum) { p.photoAlbum = a }
+
+// pictureFrame is the outline and effects a picture is drawn with.
+type pictureFrame struct {
+	border   *Border
+	shadow   *Shadow
+	radius   int  // corner radius in pixels; 0 for square corners
+	softEdge int  // feather width in pixels
+	compound bool // draw the border as a thick outer and thin inner line
+	gray     bool
+}
+
+// pictureFrame returns how s is framed: its own outline and effects, or
+// the photo album's frame for pictures that have none.
+func (r *renderer) pictureFrame(s *DrawingShape, w, h int) pictureFrame {
+	f := pictureFrame{border: s.border, shadow: s.shadow, gray: s.grayscale}
+	if s.softEdge > 0 {
+		f.softEdge = maxInt(int(math.Round(float64(s.softEdge)*r.scaleX)), 1)
+	}
+	if s.geometry == "roundRect" {
+		f.radius = minInt(w, h) * 16667 / 100000
+		if adj, ok := s.adjustValues["adj"]; ok {
+			f.radius = minInt(w, h) * adj / 200000
+		}
+	}
+	album := r.photoAlbum
+	if album == nil {
+		return f
+	}
+	if album.BlackAndWhite {
+		f.gray = true
+	}
+	if s.border != nil || s.shadow != nil || s.softEdge > 0 || (s.geometry != "" && s.geometry != "rect") {
+		return f
+	}
+	pt := func(v float64) int { return maxInt(int(math.Round(v*12700*r.scaleX)), 1) }
+	frameShadow := func(blur, dist, alpha int) *Shadow {
+		return &Shadow{Visible: true, BlurRadius: blur, Distance: dist, Direction: 45, Alpha: alpha, Color: NewColor("FF000000")}
+	}
+	switch album.Frame {
+	case PhotoAlbumFrameRounded:
+		f.radius = minInt(w, h) * 16667 / 100000
+	case PhotoAlbumFrameSimpleWhite:
+		f.border = &Border{Style: BorderSolid, Width: 7, Color: NewColor("FFFFFFFF")}
+		f.shadow = frameShadow(5, 2, 40)
+	case PhotoAlbumFrameSimpleBlack:
+		f.border = &Border{Style: BorderSolid, Width: 7, Color: NewColor("FF000000")}
+	case PhotoAlbumFrameCompoundBlack:
+		f.border = &Border{Style: BorderSolid, Width: 7, Color: NewColor("FF000000")}
+		f.compound = true
+	case PhotoAlbumFrameCenterShadow:
+		f.shadow = frameShadow(12, 0, 60)
+	case PhotoAlbumFrameSoftEdge:
+		f.softEdge = pt(8)
+	}
+	return f
+}
+
+// frameMask returns the coverage of a w x h picture with rounded corners
+// and feathered edges, or nil when the picture is a plain rectangle.
+func (r *renderer) frameMask(w, h int, f pictureFrame) *image.Alpha {
+	if (f.radius <= 0 && f.softEdge <= 0) || w <= 0 || h <= 0 {
+		return nil
+	}
+	mask := image.NewAlpha(image.Rect(0, 0, w, h))
+	if f.radius > 0 {
+		shape := image.NewRGBA(mask.Rect)
+		r.withImage(shape).fillRoundedRect(0, 0, w, h, f.radius, color.RGBA{A: 255})
+		for i := range mask.Pix {
+			mask.Pix[i] = shape.Pix[i*4+3]
+		}
+	} else {
+		for i := range mask.Pix {
+			mask.Pix[i] = 255
+		}
+	}
+	if f.softEdge > 0 {
+		// Fade linearly over the feather width, measured from the nearest
+		// edge of the bounding box.
+		fw := float64(f.softEdge)
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				d := math.Min(math.Min(float64(x)+0.5, float64(w-x)-0.5), math.Min(float64(y)+0.5, float64(h-y)-0.5))
+				if d < fw {
+					i := y*mask.Stride + x
+					mask.Pix[i] = uint8(float64(mask.Pix[i]) * d / fw)
+				}
+			}
+		}
+	}
+	return mask
+}
+
+// applyFrameMask multiplies the premultiplied pixels of img by mask.
+func applyFrameMask(img *image.RGBA, mask *image.Alpha) {
+	b := img.Bounds()
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			a := uint32(mask.Pix[y*mask.Stride+x])
+			if a == 255 {
+				continue
+			}
+			i := y*img.Stride + x*4
+			for c := 0; c < 4; c++ {
+				img.Pix[i+c] = uint8((uint32(img.Pix[i+c])*a + 127) / 255)
+			}
+		}
+	}
+}
+
+// applyGrayscale replaces each pixel of img with its luma.
+func applyGrayscale(img *image.RGBA) {
+	for i := 0; i+3 < len(img.Pix); i += 4 {
+		r, g, b := uint32(img.Pix[i]), uint32(img.Pix[i+1]), uint32(img.Pix[i+2])
+		l := uint8((19595*r + 38470*g + 7471*b + 1<<15) >> 16)
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2] = l, l, l
+	}
+}
+
+// drawPictureShadow draws the shadow of a framed picture occupying rect.
+func (r *renderer) drawPictureShadow(f pictureFrame, rect image.Rectangle) {
+	if f.shadow == nil || !f.shadow.Visible {
+		return
+	}
+	if b := f.border; b != nil && b.Style != BorderNone {
+		// The shadow is cast by the outline too.
+		pw := maxInt(int(float64(maxInt(b.Width, 1))*12700.0*r.scaleX), 1)
+		rect = rect.Inset(-pw / 2)
+	}
+	if f.radius > 0 {
+		r.renderShadowRounded(f.shadow, rect, f.radius)
+	} else {
+		r.renderShadow(f.shadow, rect)
+	}
+}
+
+// drawPictureBorder draws the outline of a framed picture occupying rect.
+func (r *renderer) drawPictureBorder(f pictureFrame, rect image.Rectangle) {
+	b := f.border
+	if b == nil || b.Style == BorderNone {
+		return
+	}
+	bc := r.rgba(b.Color)
+	pw := maxInt(int(float64(maxInt(b.Width, 1))*12700.0*r.scaleX), 1)
+	// The line is centred on the picture's edge.
+	if f.compound {
+		// Thick outer line, gap, thin inner line, as in a "thickThin" compound.
+		outer := maxInt(pw*3/5, 1)
+		inner := maxInt(pw/5, 1)
+		r.drawRect(rect.Inset(-pw/2), bc, outer)
+		r.drawRect(rect.Inset(pw-pw/2-inner), bc, inner)
+		return
+	}
+	if f.radius > 0 {
+		r.drawRoundedRectBorder(rect.Min.X, rect.Min.Y, rect.Dx(), rect.Dy(), f.radius, bc, pw, b.Style, b.DashPattern)
+		return
+	}
+	r.drawRectBorder(rect.Inset(-pw/2), bc, pw, b.Style, b.DashPattern, b.Join)
+}
@@ -9,6 +9,7 @@ package gopresentation
 
 import (
 	"errors"
+	"maps"
 	"time"
 )
 
@@ -20,9 +21,34 @@ type Presentation struct {
 	slideMasters           []*SlideMaster
 	activeSlideIndex       int
 	layout                 *DocumentLayout
+	// notesSize is the notes/handout page size from p:notesSz (zero when unknown).
+	notesSize DocumentLayout
+	// handoutPlaceholders holds the frames of the handout master's header,
+	// date, footer and slide number placeholders by type (hdr, dt, ftr,
+	// sldNum); nil when the file has no handout master.
+	handoutPlaceholders map[string]handoutFrame
 	// themeColors maps scheme color names (dk1, dk2, lt1, lt2, accent1..accent6,
 	// hlink, folHlink) to ARGB hex strings (e.g. "FF000000").
 	themeColors map[string]string
+	// themeFonts maps theme font references (+mj-lt, +mj-ea, +mj-cs, +mn-lt,
+	// +mn-ea, +mn-cs) to typeface names.
+	themeFonts map[string]string
+	// themeFontSets holds the theme font references of every theme in the
+	// package, the first theme's (themeFonts) included.
+	themeFontSets []map[string]string
+	// tableStyles maps table style IDs (from ppt/tableStyles.xml) to the
+	// borders and part fills they define.
+	tableStyles map[string]*tableStyle
+	// embeddedFonts lists the typefaces embedded in the package (p:embeddedFontLst).
+	embeddedFonts []string
+	// photoAlbum holds the p:photoAlbum settings; nil for other presentations.
+	photoAlbum *PhotoAlbum
+	// source is the package the presentation was read from, if any; saving
+	// copies its unchanged parts.
+	source *sourcePackage
+	// packageIssues are the problems of the package found while reading
+	// with ReaderOptions.CheckPackage.
+	packageIssues []packageIssue
 }
 
 // New creates a new Presentation with one default blank slide.
@@ -45,6 +71,14 @@ func (p *Presentation) GetDocumentProperties() *DocumentProperties {
 	return p.properties
 }
 
+// CoreProperties returns the document properties: title, author, dates
+// and the rest of docProps/core.xml, the company from docProps/app.xml and
+// the custom properties. This is an alias for GetDocumentProperties
+// matching unioffice naming.
+func (p *Presentation) CoreProperties() *DocumentProperties {
+	return p.properties
+}
+
 // SetDocumentProperties sets the document properties.
 func (p *Presentation) SetDocumentProperties(props *DocumentProperties) {
 	p.properties = props
@@ -65,6 +99,18 @@ func (p *Presentation) SetLayout(layout *DocumentLayout) {
 	p.layout = layout
 }
 
+// ThemeColors returns the color scheme of the presentation's theme as ARGB
+// hex strings keyed by scheme name: dk1, lt1, dk2, lt2, accent1 to accent6,
+// hlink and folHlink, plus tx1, bg1, tx2 and bg2 for dk1, lt1, dk2 and
+// lt2. It is empty for presentations not read from a file. The map
+// is a copy; see RenderOptions.ThemeColorOverrides to draw with other
+// colors.
+func (p *Presentation) ThemeColors() map[string]string {
+	colors := make(map[string]string, len(p.themeColors))
+	maps.Copy(colors, p.themeColors)
+	return colors
+}
+
 // CreateSlide creates a new slide and adds it to the presentation.
 func (p *Presentation) CreateSlide() *Slide {
 	slide := newSlide()
@@ -181,6 +227,7 @@ type DocumentProperties struct {
 	Subject        string
 	Keywords       string
 	Category       string
+	Language       string // dc:language, e.g. "en-US"
 	Company        string
 	Status         string
 	Revision       string
@@ -0,0 +1,455 @@
+package gopresentation
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Preset geometries are described as in the presetShapeDefinitions part of
+// ECMA-376: adjust values (avLst), guide formulas (gdLst) evaluated in
+// order, and one or more paths drawn from the guides. presetGeometrySource
+// holds them in a compact text form, one item per line:
+//
+//	av <name> <value>              adjust value and its default
+//	gd <name> <op> <args...>       guide formula, e.g. "gd x1 */ w adj 100000"
+//	path [w=N] [h=N] [fill=MODE] [stroke=false]
+//	M x y | L x y | A wR hR stAng swAng | Q x1 y1 x2 y2 | C x1 y1 x2 y2 x3 y3 | Z
+//
+// Arguments are guide names or numbers. Angles are in 60000ths of a degree,
+// and a path with w and h draws numbers in that coordinate space scaled to
+// the shape.
+
+// presetGuide is one formula of an avLst or gdLst.
+type presetGuide struct {
+	name string
+	op   string
+	args []string
+}
+
+// presetPathCmd is one command of a preset path.
+type presetPathCmd struct {
+	verb byte
+	args []string
+}
+
+// presetPathDef is one path of a preset geometry.
+type presetPathDef struct {
+	w, h   float64 // path coordinate space; 0 draws in shape coordinates
+	fill   string  // "norm", "none", "darken", "darkenLess", "lighten" or "lightenLess"
+	stroke bool
+	cmds   []presetPathCmd
+}
+
+// presetGeometry is a parsed preset geometry definition.
+type presetGeometry struct {
+	av, gd []presetGuide
+	paths  []presetPathDef
+}
+
+// presetShapePath is a path of a preset geometry drawn in a box.
+type presetShapePath struct {
+	path   DisplayPath
+	fill   string
+	stroke bool
+}
+
+// presetGeometryCatalog parses presetGeometrySource on first use.
+var presetGeometryCatalog = sync.OnceValue(func() map[AutoShapeType]*presetGeometry {
+	m := make(map[AutoShapeType]*presetGeometry, len(presetGeometrySource))
+	for kind, src := range presetGeometrySource {
+		m[kind] = parsePresetGeometry(src)
+	}
+	return m
+})
+
+func parsePresetGeometry(src string) *presetGeometry {
+	g := &presetGeometry{}
+	var cur *presetPathDef
+	for _, line := range strings.Split(src, "\n") {
+		f := strings.Fields(line)
+		if len(f) == 0 {
+			continue
+		}
+		switch f[0] {
+		case "av":
+			g.av = append(g.av, presetGuide{name: f[1], op: "val", args: f[2:]})
+		case "gd":
+			g.gd = append(g.gd, presetGuide{name: f[1], op: f[2], args: f[3:]})
+		case "path":
+			g.paths = append(g.paths, presetPathDef{fill: "norm", stroke: true})
+			cur = &g.paths[len(g.paths)-1]
+			for _, attr := range f[1:] {
+				k, v, _ := strings.Cut(attr, "=")
+				switch k {
+				case "w":
+					cur.w, _ = strconv.ParseFloat(v, 64)
+				case "h":
+					cur.h, _ = strconv.ParseFloat(v, 64)
+				case "fill":
+					cur.fill = v
+				case "stroke":
+					cur.stroke = v != "false"
+				}
+			}
+		default:
+			if cur != nil {
+				cur.cmds = append(cur.cmds, presetPathCmd{verb: f[0][0], args: f[1:]})
+			}
+		}
+	}
+	return g
+}
+
+// presetGuideEnv holds the guide values of a preset drawn at one size.
+type presetGuideEnv map[string]float64
+
+func newPresetGuideEnv(w, h float64) presetGuideEnv {
+	ss, ls := math.Min(w, h), math.Max(w, h)
+	env := presetGuideEnv{
+		"w": w, "h": h, "l": 0, "t": 0, "r": w, "b": h,
+		"hc": w / 2, "vc": h / 2, "ss": ss, "ls": ls,
+		"cd2": 10800000, "cd4": 5400000, "cd8": 2700000,
+		"3cd4": 16200000, "3cd8": 8100000, "5cd8": 13500000, "7cd8": 18900000,
+	}
+	for _, n := range []int{2, 3, 4, 5, 6, 8, 10, 12, 32} {
+		env["wd"+strconv.Itoa(n)] = w / float64(n)
+		env["hd"+strconv.Itoa(n)] = h / float64(n)
+	}
+	for _, n := range []int{2, 4, 6, 8, 16, 32} {
+		env["ssd"+strconv.Itoa(n)] = ss / float64(n)
+	}
+	return env
+}
+
+// value returns a guide or a number.
+func (env presetGuideEnv) value(arg string) float64 {
+	if v, ok := env[arg]; ok {
+		return v
+	}
+	v, _ := strconv.ParseFloat(arg, 64)
+	return v
+}
+
+// presetAngle converts an angle in 60000ths of a degree to radians.
+func presetAngle(a float64) float64 {
+	return a / 60000 * math.Pi / 180
+}
+
+// eval evaluates one guide formula.
+func (env presetGuideEnv) eval(g presetGuide) float64 {
+	var x, y, z float64
+	if len(g.args) > 0 {
+		x = env.value(g.args[0])
+	}
+	if len(g.args) > 1 {
+		y = env.value(g.args[1])
+	}
+	if len(g.args) > 2 {
+		z = env.value(g.args[2])
+	}
+	switch g.op {
+	case "val":
+		return x
+	case "*/":
+		if z == 0 {
+			return 0
+		}
+		return x * y / z
+	case "+-":
+		return x + y - z
+	case "+/":
+		if z == 0 {
+			return 0
+		}
+		return (x + y) / z
+	case "?:":
+		if x > 0 {
+			return y
+		}
+		return z
+	case "abs":
+		return math.Abs(x)
+	case "at2":
+		return math.Atan2(y, x) * 180 / math.Pi * 60000
+	case "cat2":
+		return x * math.Cos(math.Atan2(z, y))
+	case "sat2":
+		return x * math.Sin(math.Atan2(z, y))
+	case "cos":
+		return x * math.Cos(presetAngle(y))
+	case "sin":
+		return x * math.Sin(presetAngle(y))
+	case "tan":
+		return x * math.Tan(presetAngle(y))
+	case "max":
+		return math.Max(x, y)
+	case "min":
+		return math.Min(x, y)
+	case "mod":
+		return math.Sqrt(x*x + y*y + z*z)
+	case "pin":
+		if y < x {
+			return x
+		}
+		if y > z {
+			return z
+		}
+		return y
+	case "sqrt":
+		return math.Sqrt(math.Max(x, 0))
+	}
+	return 0
+}
+
+// presetShapePaths returns the paths of preset geometry kind drawn in the
+// given box, or false if kind is not in the catalog or its formulas break
+// down for this size. adj overrides the default adjust values.
+func presetShapePaths(kind AutoShapeType, adj map[string]int, x, y, w, h float64) ([]presetShapePath, bool) {
+	g := presetGeometryCatalog()[kind]
+	if g == nil || w <= 0 || h <= 0 {
+		return nil, false
+	}
+	env := newPresetGuideEnv(w, h)
+	for _, a := range g.av {
+		if v, ok := adj[a.name]; ok {
+			env[a.name] = float64(v)
+		} else {
+			env[a.name] = env.eval(a)
+		}
+	}
+	for _, gd := range g.gd {
+		env[gd.name] = env.eval(gd)
+	}
+	paths := make([]presetShapePath, 0, len(g.paths))
+	for i := range g.paths {
+		p, ok := env.path(&g.paths[i], x, y, w, h)
+		if !ok {
+			return nil, false
+		}
+		paths = append(paths, p)
+	}
+	return paths, true
+}
+
+// presetOutline returns the outline of preset geometry kind in the given
+// box as one path, or false unless kind is in the catalog and all its paths
+// are filled and stroked alike.
+func presetOutline(kind AutoShapeType, adj map[string]int, x, y, w, h float64) (DisplayPath, bool) {
+	paths, ok := presetShapePaths(kind, adj, x, y, w, h)
+	if !ok {
+		return nil, false
+	}
+	var out DisplayPath
+	for _, p := range paths {
+		if p.fill != "norm" || !p.stroke {
+			return nil, false
+		}
+		out = append(out, p.path...)
+	}
+	return out, true
+}
+
+// path draws one preset path with its guides evaluated in env.
+func (env presetGuideEnv) path(def *presetPathDef, x, y, w, h float64) (presetShapePath, bool) {
+	scX, scY := 1.0, 1.0
+	if def.w > 0 && def.h > 0 {
+		scX, scY = w/def.w, h/def.h
+	}
+	arg := func(args []string, i int) float64 {
+		if i < len(args) {
+			return env.value(args[i])
+		}
+		return 0
+	}
+	pt := func(args []string, i int) (float64, float64) {
+		return x + arg(args, i)*scX, y + arg(args, i+1)*scY
+	}
+	var path DisplayPath
+	var cx, cy, sx, sy float64
+	started := false
+	begin := func() {
+		if !started {
+			path.moveTo(cx, cy)
+			started = true
+		}
+	}
+	for _, c := range def.cmds {
+		switch c.verb {
+		case 'M':
+			cx, cy = pt(c.args, 0)
+			sx, sy = cx, cy
+			path.moveTo(cx, cy)
+			started = true
+		case 'L':
+			begin()
+			cx, cy = pt(c.args, 0)
+			path.lineTo(cx, cy)
+		case 'C':
+			begin()
+			x1, y1 := pt(c.args, 0)
+			x2, y2 := pt(c.args, 2)
+			cx, cy = pt(c.args, 4)
+			path.cubicTo(x1, y1, x2, y2, cx, cy)
+		case 'Q':
+			begin()
+			qx, qy := pt(c.args, 0)
+			ex, ey := pt(c.args, 2)
+			path.cubicTo(cx+2*(qx-cx)/3, cy+2*(qy-cy)/3, ex+2*(qx-ex)/3, ey+2*(qy-ey)/3, ex, ey)
+			cx, cy = ex, ey
+		case 'A':
+			begin()
+			wR, hR := math.Abs(arg(c.args, 0)*scX), math.Abs(arg(c.args, 1)*scY)
+			st, sw := presetAngle(arg(c.args, 2)), presetAngle(arg(c.args, 3))
+			if wR == 0 || hR == 0 {
+				break
+			}
+			// Arc angles are true angles from the ellipse centre; the
+			// path draws parametric angles, so convert both ends.
+			param := func(a float64) float64 {
+				return math.Atan2(wR*math.Sin(a), hR*math.Cos(a))
+			}
+			t0, t1 := param(st), param(st+sw)
+			sweep := t1 - t0
+			sweep += 2 * math.Pi * math.Round((sw-sweep)/(2*math.Pi))
+			ox, oy := cx-wR*math.Cos(t0), cy-hR*math.Sin(t0)
+			path.arc(ox, oy, wR, hR, t0, sweep)
+			cx, cy = ox+wR*math.Cos(t0+sweep), oy+hR*math.Sin(t0+sweep)
+		case 'Z':
+			if started {
+				path.close()
+				cx, cy = sx, sy
+			}
+		}
+		if math.IsNaN(cx) || math.IsNaN(cy) || math.IsInf(cx, 0) || math.IsInf(cy, 0) {
+			return presetShapePath{}, false
+		}
+	}
+	return presetShapePath{path: path, fill: def.fill, stroke: def.stroke}, true
+}
+
+// fillPresetGeometry fills the paths of preset geometry kind in the given
+// box and reports whether kind is in the catalog. Paths that darken or
+// lighten the fill, such as the side of a cube, are painted in a shade of
+// its color.
+func (r *renderer) fillPresetGeometry(kind AutoShapeType, adj map[string]int, fill *Fill, x, y, w, h int) bool {
+	paths, ok := presetShapePaths(kind, adj, float64(x), float64(y), float64(w), float64(h))
+	if !ok {
+		return false
+	}
+	fc := r.scaleAlpha(r.rgba(fill.Color))
+	for _, p := range paths {
+		if p.fill == "none" {
+			continue
+		}
+		rings, _ := r.displayPathRings(p.path)
+		if p.fill == "norm" && (fill.Type == FillGradientLinear || fill.Type == FillGradientPath) {
+			r.fillRingsGradient(rings, fill)
+		} else {
+			r.fillRings(rings, shadeColor(fc, p.fill))
+		}
+	}
+	return true
+}
+
+// strokePresetGeometry outlines the paths of preset geometry kind in the
+// given box and reports whether kind is in the catalog.
+func (r *renderer) strokePresetGeometry(kind AutoShapeType, adj map[string]int, b *Border, bc color.RGBA, pw, x, y, w, h int) bool {
+	paths, ok := presetShapePaths(kind, adj, float64(x), float64(y), float64(w), float64(h))
+	if !ok {
+		return false
+	}
+	dashes := dashArray(b.Style, b.DashPattern, pw)
+	for _, p := range paths {
+		if !p.stroke {
+			continue
+		}
+		rings, closed := r.displayPathRings(p.path)
+		for i, ring := range rings {
+			switch {
+			case len(ring) < 2:
+			case dashes != nil:
+				if closed[i] {
+					ring = append(ring, ring[0])
+				}
+				r.drawDashedPolylineAA(ring, bc, pw, dashes)
+			case closed[i]:
+				r.drawPolygon(ring, bc, pw, b)
+			case !r.strokeJoinedPolyline(ring, false, bc, pw, b.Join, b.MiterLimit):
+				for j := 1; j < len(ring); j++ {
+					r.drawLineAA(int(ring[j-1].x), int(ring[j-1].y), int(ring[j].x), int(ring[j].y), bc, pw)
+				}
+			}
+		}
+	}
+	return true
+}
+
+// displayPathRings flattens path into one polyline per subpath and reports
+// which of them are closed.
+func (r *renderer) displayPathRings(path DisplayPath) ([][]fpoint, []bool) {
+	var rings [][]fpoint
+	var closed []bool
+	var cur []fpoint
+	var start fpoint
+	flush := func(c bool) {
+		if len(cur) > 1 {
+			rings = append(rings, cur)
+			closed = append(closed, c)
+		}
+		cur = nil
+	}
+	// A segment after a close starts where the subpath did.
+	resume := func() {
+		if len(cur) == 0 {
+			cur = append(cur, start)
+		}
+	}
+	for _, seg := range path {
+		switch seg.Verb {
+		case DisplayMoveTo:
+			flush(false)
+			start = fpoint{seg.Points[0].X, seg.Points[0].Y}
+			cur = append(cur, start)
+		case DisplayLineTo:
+			resume()
+			cur = append(cur, fpoint{seg.Points[0].X, seg.Points[0].Y})
+		case DisplayCubicTo:
+			resume()
+			last := cur[len(cur)-1]
+			p1, p2, p3 := seg.Points[0], seg.Points[1], seg.Points[2]
+			cur = append(cur, r.flattenCubicBezier(last.x, last.y, p1.X, p1.Y, p2.X, p2.Y, p3.X, p3.Y, 0)...)
+			cur = append(cur, fpoint{p3.X, p3.Y})
+		case DisplayClose:
+			flush(true)
+		}
+	}
+	flush(false)
+	return rings, closed
+}
+
+// shadeColor returns c shaded for a path fill mode of a preset geometry.
+func shadeColor(c color.RGBA, mode string) color.RGBA {
+	var k float64
+	switch mode {
+	case "darken":
+		k = -0.4
+	case "darkenLess":
+		k = -0.2
+	case "lighten":
+		k = 0.4
+	case "lightenLess":
+		k = 0.2
+	default:
+		return c
+	}
+	shade := func(v uint8) uint8 {
+		if k < 0 {
+			return uint8(float64(v) * (1 + k))
+		}
+		return uint8(float64(v) + (255-float64(v))*k)
+	}
+	return color.RGBA{R: shade(c.R), G: shade(c.G), B: shade(c.B), A: c.A}
+}
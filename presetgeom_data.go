@@ -0,0 +1,6771 @@
+package gopresentation
+
+// presetGeometrySource holds the DrawingML preset geometries, in the text
+// form described in presetgeom.go.
+var presetGeometrySource = map[AutoShapeType]string{
+	"line": `
+path fill=none
+M l t
+L r b
+`,
+	"lineInv": `
+path fill=none
+M l b
+L r t
+`,
+	"triangle": `
+av adj 50000
+gd a pin 0 adj 100000
+gd x2 */ w a 100000
+path
+M l b
+L x2 t
+L r b
+Z
+`,
+	"rtTriangle": `
+path
+M l t
+L r b
+L l b
+Z
+`,
+	"rect": `
+path
+M l t
+L r t
+L r b
+L l b
+Z
+`,
+	"diamond": `
+path
+M l vc
+L hc t
+L r vc
+L hc b
+Z
+`,
+	"parallelogram": `
+av adj 25000
+gd maxAdj */ 100000 w ss
+gd a pin 0 adj maxAdj
+gd x2 */ ss a 100000
+gd x5 +- r 0 x2
+path
+M l b
+L x2 t
+L r t
+L x5 b
+Z
+`,
+	"trapezoid": `
+av adj 25000
+gd maxAdj */ 50000 w ss
+gd a pin 0 adj maxAdj
+gd x2 */ ss a 100000
+gd x3 +- r 0 x2
+path
+M l b
+L x2 t
+L x3 t
+L r b
+Z
+`,
+	"nonIsoscelesTrapezoid": `
+av adj1 25000
+av adj2 25000
+gd maxAdj */ 50000 w ss
+gd a1 pin 0 adj1 maxAdj
+gd a2 pin 0 adj2 maxAdj
+gd x2 */ ss a1 100000
+gd dx3 */ ss a2 100000
+gd x3 +- r 0 dx3
+path
+M l b
+L x2 t
+L x3 t
+L r b
+Z
+`,
+	"pentagon": `
+av hf 105146
+av vf 110557
+gd swd2 */ wd2 hf 100000
+gd shd2 */ hd2 vf 100000
+gd svc */ vc vf 100000
+gd dx1 cos swd2 1080000
+gd dx2 cos swd2 18360000
+gd dy1 sin shd2 1080000
+gd dy2 sin shd2 18360000
+gd x1 +- hc 0 dx1
+gd x2 +- hc 0 dx2
+gd x3 +- hc dx2 0
+gd x4 +- hc dx1 0
+gd y1 +- svc 0 dy1
+gd y2 +- svc 0 dy2
+path
+M x1 y1
+L hc t
+L x4 y1
+L x3 y2
+L x2 y2
+Z
+`,
+	"hexagon": `
+av adj 25000
+av vf 115470
+gd maxAdj */ 50000 w ss
+gd a pin 0 adj maxAdj
+gd shd2 */ hd2 vf 100000
+gd x1 */ ss a 100000
+gd x2 +- r 0 x1
+gd dy1 sin shd2 3600000
+gd y1 +- vc 0 dy1
+gd y2 +- vc dy1 0
+path
+M l vc
+L x1 y1
+L x2 y1
+L r vc
+L x2 y2
+L x1 y2
+Z
+`,
+	"heptagon": `
+av hf 102572
+av vf 105210
+gd swd2 */ wd2 hf 100000
+gd shd2 */ hd2 vf 100000
+gd svc */ vc vf 100000
+gd dx1 */ swd2 97493 100000
+gd dx2 */ swd2 78183 100000
+gd dx3 */ swd2 43388 100000
+gd dy1 */ shd2 62349 100000
+gd dy2 */ shd2 22252 100000
+gd dy3 */ shd2 90097 100000
+gd x1 +- hc 0 dx1
+gd x2 +- hc 0 dx2
+gd x3 +- hc 0 dx3
+gd x4 +- hc dx3 0
+gd x5 +- hc dx2 0
+gd x6 +- hc dx1 0
+gd y1 +- svc 0 dy1
+gd y2 +- svc dy2 0
+gd y3 +- svc dy3 0
+path
+M x1 y2
+L x2 y1
+L hc t
+L x5 y1
+L x6 y2
+L x4 y3
+L x3 y3
+Z
+`,
+	"octagon": `
+av adj 29289
+gd a pin 0 adj 50000
+gd x1 */ ss a 100000
+gd x2 +- r 0 x1
+gd y2 +- b 0 x1
+path
+M l x1
+L x1 t
+L x2 t
+L r x1
+L r y2
+L x2 b
+L x1 b
+L l y2
+Z
+`,
+	"decagon": `
+av vf 105146
+gd shd2 */ hd2 vf 100000
+gd dx1 cos wd2 2160000
+gd dx2 cos wd2 4320000
+gd x1 +- hc 0 dx1
+gd x2 +- hc 0 dx2
+gd x3 +- hc dx2 0
+gd x4 +- hc dx1 0
+gd dy1 sin shd2 4320000
+gd dy2 sin shd2 2160000
+gd y1 +- vc 0 dy1
+gd y2 +- vc 0 dy2
+gd y3 +- vc dy2 0
+gd y4 +- vc dy1 0
+path
+M l vc
+L x1 y2
+L x2 y1
+L x3 y1
+L x4 y2
+L r vc
+L x4 y3
+L x3 y4
+L x2 y4
+L x1 y3
+Z
+`,
+	"dodecagon": `
+gd x1 */ w 2894 21600
+gd x2 */ w 7906 21600
+gd x3 */ w 13694 21600
+gd x4 */ w 18706 21600
+gd y1 */ h 2894 21600
+gd y2 */ h 7906 21600
+gd y3 */ h 13694 21600
+gd y4 */ h 18706 21600
+path
+M l y2
+L x1 y1
+L x2 t
+L x3 t
+L x4 y1
+L r y2
+L r y3
+L x4 y4
+L x3 b
+L x2 b
+L x1 y4
+L l y3
+Z
+`,
+	"star5": `
+av adj 19098
+av hf 105146
+av vf 110557
+gd a pin 0 adj 50000
+gd swd2 */ wd2 hf 100000
+gd shd2 */ hd2 vf 100000
+gd svc */ vc vf 100000
+gd dx1 cos swd2 1080000
+gd dx2 cos swd2 18360000
+gd dy1 sin shd2 1080000
+gd dy2 sin shd2 18360000
+gd x1 +- hc 0 dx1
+gd x2 +- hc 0 dx2
+gd x3 +- hc dx2 0
+gd x4 +- hc dx1 0
+gd y1 +- svc 0 dy1
+gd y2 +- svc 0 dy2
+gd iwd2 */ swd2 a 50000
+gd ihd2 */ shd2 a 50000
+gd sdx1 cos iwd2 20520000
+gd sdx2 cos iwd2 3240000
+gd sdy1 sin ihd2 3240000
+gd sdy2 sin ihd2 20520000
+gd sx1 +- hc 0 sdx1
+gd sx2 +- hc 0 sdx2
+gd sx3 +- hc sdx2 0
+gd sx4 +- hc sdx1 0
+gd sy1 +- svc 0 sdy1
+gd sy2 +- svc 0 sdy2
+gd sy3 +- svc ihd2 0
+path
+M x1 y1
+L sx2 sy1
+L hc t
+L sx3 sy1
+L x4 y1
+L sx4 sy2
+L x3 y2
+L hc sy3
+L x2 y2
+L sx1 sy2
+Z
+`,
+	"star6": `
+av adj 28868
+av hf 115470
+gd a pin 0 adj 50000
+gd swd2 */ wd2 hf 100000
+gd dx1 cos swd2 1800000
+gd x1 +- hc 0 dx1
+gd x2 +- hc dx1 0
+gd y2 +- vc hd4 0
+gd iwd2 */ swd2 a 50000
+gd ihd2 */ hd2 a 50000
+gd sdx2 */ iwd2 1 2
+gd sx1 +- hc 0 iwd2
+gd sx2 +- hc 0 sdx2
+gd sx3 +- hc sdx2 0
+gd sx4 +- hc iwd2 0
+gd sdy1 sin ihd2 3600000
+gd sy1 +- vc 0 sdy1
+gd sy2 +- vc sdy1 0
+path
+M x1 hd4
+L sx2 sy1
+L hc t
+L sx3 sy1
+L x2 hd4
+L sx4 vc
+L x2 y2
+L sx3 sy2
+L hc b
+L sx2 sy2
+L x1 y2
+L sx1 vc
+Z
+`,
+	"star7": `
+av adj 34601
+av hf 102572
+av vf 105210
+gd a pin 0 adj 50000
+gd swd2 */ wd2 hf 100000
+gd shd2 */ hd2 vf 100000
+gd svc */ vc vf 100000
+gd dx1 */ swd2 97493 100000
+gd dx2 */ swd2 78183 100000
+gd dx3 */ swd2 43388 100000
+gd dy1 */ shd2 62349 100000
+gd dy2 */ shd2 22252 100000
+gd dy3 */ shd2 90097 100000
+gd x1 +- hc 0 dx1
+gd x2 +- hc 0 dx2
+gd x3 +- hc 0 dx3
+gd x4 +- hc dx3 0
+gd x5 +- hc dx2 0
+gd x6 +- hc dx1 0
+gd y1 +- svc 0 dy1
+gd y2 +- svc dy2 0
+gd y3 +- svc dy3 0
+gd iwd2 */ swd2 a 50000
+gd ihd2 */ shd2 a 50000
+gd sdx1 */ iwd2 97493 100000
+gd sdx2 */ iwd2 78183 100000
+gd sdx3 */ iwd2 43388 100000
+gd sx1 +- hc 0 sdx1
+gd sx2 +- hc 0 sdx2
+gd sx3 +- hc 0 sdx3
+gd sx4 +- hc sdx3 0
+gd sx5 +- hc sdx2 0
+gd sx6 +- hc sdx1 0
+gd sdy1 */ ihd2 90097 100000
+gd sdy2 */ ihd2 22252 100000
+gd sdy3 */ ihd2 62349 100000
+gd sy1 +- svc 0 sdy1
+gd sy2 +- svc 0 sdy2
+gd sy3 +- svc sdy3 0
+gd sy4 +- svc ihd2 0
+path
+M x1 y2
+L sx1 sy2
+L x2 y1
+L sx3 sy1
+L hc t
+L sx4 sy1
+L x5 y1
+L sx6 sy2
+L x6 y2
+L sx5 sy3
+L x4 y3
+L hc sy4
+L x3 y3
+L sx2 sy3
+Z
+`,
+	"star4": `
+av adj 12500
+gd a pin 0 adj 50000
+gd iwd2 */ wd2 a 50000
+gd ihd2 */ hd2 a 50000
+gd o0dx cos wd2 10800000
+gd o0dy sin hd2 10800000
+gd o0x +- hc o0dx 0
+gd o0y +- vc o0dy 0
+gd i0dx cos iwd2 13500000
+gd i0dy sin ihd2 13500000
+gd i0x +- hc i0dx 0
+gd i0y +- vc i0dy 0
+gd o1dx cos wd2 16200000
+gd o1dy sin hd2 16200000
+gd o1x +- hc o1dx 0
+gd o1y +- vc o1dy 0
+gd i1dx cos iwd2 18900000
+gd i1dy sin ihd2 18900000
+gd i1x +- hc i1dx 0
+gd i1y +- vc i1dy 0
+gd o2dx cos wd2 0
+gd o2dy sin hd2 0
+gd o2x +- hc o2dx 0
+gd o2y +- vc o2dy 0
+gd i2dx cos iwd2 2700000
+gd i2dy sin ihd2 2700000
+gd i2x +- hc i2dx 0
+gd i2y +- vc i2dy 0
+gd o3dx cos wd2 5400000
+gd o3dy sin hd2 5400000
+gd o3x +- hc o3dx 0
+gd o3y +- vc o3dy 0
+gd i3dx cos iwd2 8100000
+gd i3dy sin ihd2 8100000
+gd i3x +- hc i3dx 0
+gd i3y +- vc i3dy 0
+path
+M o0x o0y
+L i0x i0y
+L o1x o1y
+L i1x i1y
+L o2x o2y
+L i2x i2y
+L o3x o3y
+L i3x i3y
+Z
+`,
+	"star8": `
+av adj 37500
+gd a pin 0 adj 50000
+gd iwd2 */ wd2 a 50000
+gd ihd2 */ hd2 a 50000
+gd o0dx cos wd2 10800000
+gd o0dy sin hd2 10800000
+gd o0x +- hc o0dx 0
+gd o0y +- vc o0dy 0
+gd i0dx cos iwd2 12150000
+gd i0dy sin ihd2 12150000
+gd i0x +- hc i0dx 0
+gd i0y +- vc i0dy 0
+gd o1dx cos wd2 13500000
+gd o1dy sin hd2 13500000
+gd o1x +- hc o1dx 0
+gd o1y +- vc o1dy 0
+gd i1dx cos iwd2 14850000
+gd i1dy sin ihd2 14850000
+gd i1x +- hc i1dx 0
+gd i1y +- vc i1dy 0
+gd o2dx cos wd2 16200000
+gd o2dy sin hd2 16200000
+gd o2x +- hc o2dx 0
+gd o2y +- vc o2dy 0
+gd i2dx cos iwd2 17550000
+gd i2dy sin ihd2 17550000
+gd i2x +- hc i2dx 0
+gd i2y +- vc i2dy 0
+gd o3dx cos wd2 18900000
+gd o3dy sin hd2 18900000
+gd o3x +- hc o3dx 0
+gd o3y +- vc o3dy 0
+gd i3dx cos iwd2 20250000
+gd i3dy sin ihd2 20250000
+gd i3x +- hc i3dx 0
+gd i3y +- vc i3dy 0
+gd o4dx cos wd2 0
+gd o4dy sin hd2 0
+gd o4x +- hc o4dx 0
+gd o4y +- vc o4dy 0
+gd i4dx cos iwd2 1350000
+gd i4dy sin ihd2 1350000
+gd i4x +- hc i4dx 0
+gd i4y +- vc i4dy 0
+gd o5dx cos wd2 2700000
+gd o5dy sin hd2 2700000
+gd o5x +- hc o5dx 0
+gd o5y +- vc o5dy 0
+gd i5dx cos iwd2 4050000
+gd i5dy sin ihd2 4050000
+gd i5x +- hc i5dx 0
+gd i5y +- vc i5dy 0
+gd o6dx cos wd2 5400000
+gd o6dy sin hd2 5400000
+gd o6x +- hc o6dx 0
+gd o6y +- vc o6dy 0
+gd i6dx cos iwd2 6750000
+gd i6dy sin ihd2 6750000
+gd i6x +- hc i6dx 0
+gd i6y +- vc i6dy 0
+gd o7dx cos wd2 8100000
+gd o7dy sin hd2 8100000
+gd o7x +- hc o7dx 0
+gd o7y +- vc o7dy 0
+gd i7dx cos iwd2 9450000
+gd i7dy sin ihd2 9450000
+gd i7x +- hc i7dx 0
+gd i7y +- vc i7dy 0
+path
+M o0x o0y
+L i0x i0y
+L o1x o1y
+L i1x i1y
+L o2x o2y
+L i2x i2y
+L o3x o3y
+L i3x i3y
+L o4x o4y
+L i4x i4y
+L o5x o5y
+L i5x i5y
+L o6x o6y
+L i6x i6y
+L o7x o7y
+L i7x i7y
+Z
+`,
+	"star10": `
+av adj 42533
+av hf 105146
+gd a pin 0 adj 50000
+gd swd2 */ wd2 hf 100000
+gd iwd2 */ swd2 a 50000
+gd ihd2 */ hd2 a 50000
+gd o0dx cos swd2 11880000
+gd o0dy sin hd2 11880000
+gd o0x +- hc o0dx 0
+gd o0y +- vc o0dy 0
+gd i0dx cos iwd2 12960000
+gd i0dy sin ihd2 12960000
+gd i0x +- hc i0dx 0
+gd i0y +- vc i0dy 0
+gd o1dx cos swd2 14040000
+gd o1dy sin hd2 14040000
+gd o1x +- hc o1dx 0
+gd o1y +- vc o1dy 0
+gd i1dx cos iwd2 15120000
+gd i1dy sin ihd2 15120000
+gd i1x +- hc i1dx 0
+gd i1y +- vc i1dy 0
+gd o2dx cos swd2 16200000
+gd o2dy sin hd2 16200000
+gd o2x +- hc o2dx 0
+gd o2y +- vc o2dy 0
+gd i2dx cos iwd2 17280000
+gd i2dy sin ihd2 17280000
+gd i2x +- hc i2dx 0
+gd i2y +- vc i2dy 0
+gd o3dx cos swd2 18360000
+gd o3dy sin hd2 18360000
+gd o3x +- hc o3dx 0
+gd o3y +- vc o3dy 0
+gd i3dx cos iwd2 19440000
+gd i3dy sin ihd2 19440000
+gd i3x +- hc i3dx 0
+gd i3y +- vc i3dy 0
+gd o4dx cos swd2 20520000
+gd o4dy sin hd2 20520000
+gd o4x +- hc o4dx 0
+gd o4y +- vc o4dy 0
+gd i4dx cos iwd2 0
+gd i4dy sin ihd2 0
+gd i4x +- hc i4dx 0
+gd i4y +- vc i4dy 0
+gd o5dx cos swd2 1080000
+gd o5dy sin hd2 1080000
+gd o5x +- hc o5dx 0
+gd o5y +- vc o5dy 0
+gd i5dx cos iwd2 2160000
+gd i5dy sin ihd2 2160000
+gd i5x +- hc i5dx 0
+gd i5y +- vc i5dy 0
+gd o6dx cos swd2 3240000
+gd o6dy sin hd2 3240000
+gd o6x +- hc o6dx 0
+gd o6y +- vc o6dy 0
+gd i6dx cos iwd2 4320000
+gd i6dy sin ihd2 4320000
+gd i6x +- hc i6dx 0
+gd i6y +- vc i6dy 0
+gd o7dx cos swd2 5400000
+gd o7dy sin hd2 5400000
+gd o7x +- hc o7dx 0
+gd o7y +- vc o7dy 0
+gd i7dx cos iwd2 6480000
+gd i7dy sin ihd2 6480000
+gd i7x +- hc i7dx 0
+gd i7y +- vc i7dy 0
+gd o8dx cos swd2 7560000
+gd o8dy sin hd2 7560000
+gd o8x +- hc o8dx 0
+gd o8y +- vc o8dy 0
+gd i8dx cos iwd2 8640000
+gd i8dy sin ihd2 8640000
+gd i8x +- hc i8dx 0
+gd i8y +- vc i8dy 0
+gd o9dx cos swd2 9720000
+gd o9dy sin hd2 9720000
+gd o9x +- hc o9dx 0
+gd o9y +- vc o9dy 0
+gd i9dx cos iwd2 10800000
+gd i9dy sin ihd2 10800000
+gd i9x +- hc i9dx 0
+gd i9y +- vc i9dy 0
+path
+M o0x o0y
+L i0x i0y
+L o1x o1y
+L i1x i1y
+L o2x o2y
+L i2x i2y
+L o3x o3y
+L i3x i3y
+L o4x o4y
+L i4x i4y
+L o5x o5y
+L i5x i5y
+L o6x o6y
+L i6x i6y
+L o7x o7y
+L i7x i7y
+L o8x o8y
+L i8x i8y
+L o9x o9y
+L i9x i9y
+Z
+`,
+	"star12": `
+av adj 37500
+gd a pin 0 adj 50000
+gd iwd2 */ wd2 a 50000
+gd ihd2 */ hd2 a 50000
+gd o0dx cos wd2 10800000
+gd o0dy sin hd2 10800000
+gd o0x +- hc o0dx 0
+gd o0y +- vc o0dy 0
+gd i0dx cos iwd2 11700000
+gd i0dy sin ihd2 11700000
+gd i0x +- hc i0dx 0
+gd i0y +- vc i0dy 0
+gd o1dx cos wd2 12600000
+gd o1dy sin hd2 12600000
+gd o1x +- hc o1dx 0
+gd o1y +- vc o1dy 0
+gd i1dx cos iwd2 13500000
+gd i1dy sin ihd2 13500000
+gd i1x +- hc i1dx 0
+gd i1y +- vc i1dy 0
+gd o2dx cos wd2 14400000
+gd o2dy sin hd2 14400000
+gd o2x +- hc o2dx 0
+gd o2y +- vc o2dy 0
+gd i2dx cos iwd2 15300000
+gd i2dy sin ihd2 15300000
+gd i2x +- hc i2dx 0
+gd i2y +- vc i2dy 0
+gd o3dx cos wd2 16200000
+gd o3dy sin hd2 16200000
+gd o3x +- hc o3dx 0
+gd o3y +- vc o3dy 0
+gd i3dx cos iwd2 17100000
+gd i3dy sin ihd2 17100000
+gd i3x +- hc i3dx 0
+gd i3y +- vc i3dy 0
+gd o4dx cos wd2 18000000
+gd o4dy sin hd2 18000000
+gd o4x +- hc o4dx 0
+gd o4y +- vc o4dy 0
+gd i4dx cos iwd2 18900000
+gd i4dy sin ihd2 18900000
+gd i4x +- hc i4dx 0
+gd i4y +- vc i4dy 0
+gd o5dx cos wd2 19800000
+gd o5dy sin hd2 19800000
+gd o5x +- hc o5dx 0
+gd o5y +- vc o5dy 0
+gd i5dx cos iwd2 20700000
+gd i5dy sin ihd2 20700000
+gd i5x +- hc i5dx 0
+gd i5y +- vc i5dy 0
+gd o6dx cos wd2 0
+gd o6dy sin hd2 0
+gd o6x +- hc o6dx 0
+gd o6y +- vc o6dy 0
+gd i6dx cos iwd2 900000
+gd i6dy sin ihd2 900000
+gd i6x +- hc i6dx 0
+gd i6y +- vc i6dy 0
+gd o7dx cos wd2 1800000
+gd o7dy sin hd2 1800000
+gd o7x +- hc o7dx 0
+gd o7y +- vc o7dy 0
+gd i7dx cos iwd2 2700000
+gd i7dy sin ihd2 2700000
+gd i7x +- hc i7dx 0
+gd i7y +- vc i7dy 0
+gd o8dx cos wd2 3600000
+gd o8dy sin hd2 3600000
+gd o8x +- hc o8dx 0
+gd o8y +- vc o8dy 0
+gd i8dx cos iwd2 4500000
+gd i8dy sin ihd2 4500000
+gd i8x +- hc i8dx 0
+gd i8y +- vc i8dy 0
+gd o9dx cos wd2 5400000
+gd o9dy sin hd2 5400000
+gd o9x +- hc o9dx 0
+gd o9y +- vc o9dy 0
+gd i9dx cos iwd2 6300000
+gd i9dy sin ihd2 6300000
+gd i9x +- hc i9dx 0
+gd i9y +- vc i9dy 0
+gd o10dx cos wd2 7200000
+gd o10dy sin hd2 7200000
+gd o10x +- hc o10dx 0
+gd o10y +- vc o10dy 0
+gd i10dx cos iwd2 8100000
+gd i10dy sin ihd2 8100000
+gd i10x +- hc i10dx 0
+gd i10y +- vc i10dy 0
+gd o11dx cos wd2 9000000
+gd o11dy sin hd2 9000000
+gd o11x +- hc o11dx 0
+gd o11y +- vc o11dy 0
+gd i11dx cos iwd2 9900000
+gd i11dy sin ihd2 9900000
+gd i11x +- hc i11dx 0
+gd i11y +- vc i11dy 0
+path
+M o0x o0y
+L i0x i0y
+L o1x o1y
+L i1x i1y
+L o2x o2y
+L i2x i2y
+L o3x o3y
+L i3x i3y
+L o4x o4y
+L i4x i4y
+L o5x o5y
+L i5x i5y
+L o6x o6y
+L i6x i6y
+L o7x o7y
+L i7x i7y
+L o8x o8y
+L i8x i8y
+L o9x o9y
+L i9x i9y
+L o10x o10y
+L i10x i10y
+L o11x o11y
+L i11x i11y
+Z
+`,
+	"star16": `
+av adj 37500
+gd a pin 0 adj 50000
+gd iwd2 */ wd2 a 50000
+gd ihd2 */ hd2 a 50000
+gd o0dx cos wd2 10800000
+gd o0dy sin hd2 10800000
+gd o0x +- hc o0dx 0
+gd o0y +- vc o0dy 0
+gd i0dx cos iwd2 11475000
+gd i0dy sin ihd2 11475000
+gd i0x +- hc i0dx 0
+gd i0y +- vc i0dy 0
+gd o1dx cos wd2 12150000
+gd o1dy sin hd2 12150000
+gd o1x +- hc o1dx 0
+gd o1y +- vc o1dy 0
+gd i1dx cos iwd2 12825000
+gd i1dy sin ihd2 12825000
+gd i1x +- hc i1dx 0
+gd i1y +- vc i1dy 0
+gd o2dx cos wd2 13500000
+gd o2dy sin hd2 13500000
+gd o2x +- hc o2dx 0
+gd o2y +- vc o2dy 0
+gd i2dx cos iwd2 14175000
+gd i2dy sin ihd2 14175000
+gd i2x +- hc i2dx 0
+gd i2y +- vc i2dy 0
+gd o3dx cos wd2 14850000
+gd o3dy sin hd2 14850000
+gd o3x +- hc o3dx 0
+gd o3y +- vc o3dy 0
+gd i3dx cos iwd2 15525000
+gd i3dy sin ihd2 15525000
+gd i3x +- hc i3dx 0
+gd i3y +- vc i3dy 0
+gd o4dx cos wd2 16200000
+gd o4dy sin hd2 16200000
+gd o4x +- hc o4dx 0
+gd o4y +- vc o4dy 0
+gd i4dx cos iwd2 16875000
+gd i4dy sin ihd2 16875000
+gd i4x +- hc i4dx 0
+gd i4y +- vc i4dy 0
+gd o5dx cos wd2 17550000
+gd o5dy sin hd2 17550000
+gd o5x +- hc o5dx 0
+gd o5y +- vc o5dy 0
+gd i5dx cos iwd2 18225000
+gd i5dy sin ihd2 18225000
+gd i5x +- hc i5dx 0
+gd i5y +- vc i5dy 0
+gd o6dx cos wd2 18900000
+gd o6dy sin hd2 18900000
+gd o6x +- hc o6dx 0
+gd o6y +- vc o6dy 0
+gd i6dx cos iwd2 19575000
+gd i6dy sin ihd2 19575000
+gd i6x +- hc i6dx 0
+gd i6y +- vc i6dy 0
+gd o7dx cos wd2 20250000
+gd o7dy sin hd2 20250000
+gd o7x +- hc o7dx 0
+gd o7y +- vc o7dy 0
+gd i7dx cos iwd2 20925000
+gd i7dy sin ihd2 20925000
+gd i7x +- hc i7dx 0
+gd i7y +- vc i7dy 0
+gd o8dx cos wd2 0
+gd o8dy sin hd2 0
+gd o8x +- hc o8dx 0
+gd o8y +- vc o8dy 0
+gd i8dx cos iwd2 675000
+gd i8dy sin ihd2 675000
+gd i8x +- hc i8dx 0
+gd i8y +- vc i8dy 0
+gd o9dx cos wd2 1350000
+gd o9dy sin hd2 1350000
+gd o9x +- hc o9dx 0
+gd o9y +- vc o9dy 0
+gd i9dx cos iwd2 2025000
+gd i9dy sin ihd2 2025000
+gd i9x +- hc i9dx 0
+gd i9y +- vc i9dy 0
+gd o10dx cos wd2 2700000
+gd o10dy sin hd2 2700000
+gd o10x +- hc o10dx 0
+gd o10y +- vc o10dy 0
+gd i10dx cos iwd2 3375000
+gd i10dy sin ihd2 3375000
+gd i10x +- hc i10dx 0
+gd i10y +- vc i10dy 0
+gd o11dx cos wd2 4050000
+gd o11dy sin hd2 4050000
+gd o11x +- hc o11dx 0
+gd o11y +- vc o11dy 0
+gd i11dx cos iwd2 4725000
+gd i11dy sin ihd2 4725000
+gd i11x +- hc i11dx 0
+gd i11y +- vc i11dy 0
+gd o12dx cos wd2 5400000
+gd o12dy sin hd2 5400000
+gd o12x +- hc o12dx 0
+gd o12y +- vc o12dy 0
+gd i12dx cos iwd2 6075000
+gd i12dy sin ihd2 6075000
+gd i12x +- hc i12dx 0
+gd i12y +- vc i12dy 0
+gd o13dx cos wd2 6750000
+gd o13dy sin hd2 6750000
+gd o13x +- hc o13dx 0
+gd o13y +- vc o13dy 0
+gd i13dx cos iwd2 7425000
+gd i13dy sin ihd2 7425000
+gd i13x +- hc i13dx 0
+gd i13y +- vc i13dy 0
+gd o14dx cos wd2 8100000
+gd o14dy sin hd2 8100000
+gd o14x +- hc o14dx 0
+gd o14y +- vc o14dy 0
+gd i14dx cos iwd2 8775000
+gd i14dy sin ihd2 8775000
+gd i14x +- hc i14dx 0
+gd i14y +- vc i14dy 0
+gd o15dx cos wd2 9450000
+gd o15dy sin hd2 9450000
+gd o15x +- hc o15dx 0
+gd o15y +- vc o15dy 0
+gd i15dx cos iwd2 10125000
+gd i15dy sin ihd2 10125000
+gd i15x +- hc i15dx 0
+gd i15y +- vc i15dy 0
+path
+M o0x o0y
+L i0x i0y
+L o1x o1y
+L i1x i1y
+L o2x o2y
+L i2x i2y
+L o3x o3y
+L i3x i3y
+L o4x o4y
+L i4x i4y
+L o5x o5y
+L i5x i5y
+L o6x o6y
+L i6x i6y
+L o7x o7y
+L i7x i7y
+L o8x o8y
+L i8x i8y
+L o9x o9y
+L i9x i9y
+L o10x o10y
+L i10x i10y
+L o11x o11y
+L i11x i11y
+L o12x o12y
+L i12x i12y
+L o13x o13y
+L i13x i13y
+L o14x o14y
+L i14x i14y
+L o15x o15y
+L i15x i15y
+Z
+`,
+	"star24": `
+av adj 37500
+gd a pin 0 adj 50000
+gd iwd2 */ wd2 a 50000
+gd ihd2 */ hd2 a 50000
+gd o0dx cos wd2 10800000
+gd o0dy sin hd2 10800000
+gd o0x +- hc o0dx 0
+gd o0y +- vc o0dy 0
+gd i0dx cos iwd2 11250000
+gd i0dy sin ihd2 11250000
+gd i0x +- hc i0dx 0
+gd i0y +- vc i0dy 0
+gd o1dx cos wd2 11700000
+gd o1dy sin hd2 11700000
+gd o1x +- hc o1dx 0
+gd o1y +- vc o1dy 0
+gd i1dx cos iwd2 12150000
+gd i1dy sin ihd2 12150000
+gd i1x +- hc i1dx 0
+gd i1y +- vc i1dy 0
+gd o2dx cos wd2 12600000
+gd o2dy sin hd2 12600000
+gd o2x +- hc o2dx 0
+gd o2y +- vc o2dy 0
+gd i2dx cos iwd2 13050000
+gd i2dy sin ihd2 13050000
+gd i2x +- hc i2dx 0
+gd i2y +- vc i2dy 0
+gd o3dx cos wd2 13500000
+gd o3dy sin hd2 13500000
+gd o3x +- hc o3dx 0
+gd o3y +- vc o3dy 0
+gd i3dx cos iwd2 13950000
+gd i3dy sin ihd2 13950000
+gd i3x +- hc i3dx 0
+gd i3y +- vc i3dy 0
+gd o4dx cos wd2 14400000
+gd o4dy sin hd2 14400000
+gd o4x +- hc o4dx 0
+gd o4y +- vc o4dy 0
+gd i4dx cos iwd2 14850000
+gd i4dy sin ihd2 14850000
+gd i4x +- hc i4dx 0
+gd i4y +- vc i4dy 0
+gd o5dx cos wd2 15300000
+gd o5dy sin hd2 15300000
+gd o5x +- hc o5dx 0
+gd o5y +- vc o5dy 0
+gd i5dx cos iwd2 15750000
+gd i5dy sin ihd2 15750000
+gd i5x +- hc i5dx 0
+gd i5y +- vc i5dy 0
+gd o6dx cos wd2 16200000
+gd o6dy sin hd2 16200000
+gd o6x +- hc o6dx 0
+gd o6y +- vc o6dy 0
+gd i6dx cos iwd2 16650000
+gd i6dy sin ihd2 16650000
+gd i6x +- hc i6dx 0
+gd i6y +- vc i6dy 0
+gd o7dx cos wd2 17100000
+gd o7dy sin hd2 17100000
+gd o7x +- hc o7dx 0
+gd o7y +- vc o7dy 0
+gd i7dx cos iwd2 17550000
+gd i7dy sin ihd2 17550000
+gd i7x +- hc i7dx 0
+gd i7y +- vc i7dy 0
+gd o8dx cos wd2 18000000
+gd o8dy sin hd2 18000000
+gd o8x +- hc o8dx 0
+gd o8y +- vc o8dy 0
+gd i8dx cos iwd2 18450000
+gd i8dy sin ihd2 18450000
+gd i8x +- hc i8dx 0
+gd i8y +- vc i8dy 0
+gd o9dx cos wd2 18900000
+gd o9dy sin hd2 18900000
+gd o9x +- hc o9dx 0
+gd o9y +- vc o9dy 0
+gd i9dx cos iwd2 19350000
+gd i9dy sin ihd2 19350000
+gd i9x +- hc i9dx 0
+gd i9y +- vc i9dy 0
+gd o10dx cos wd2 19800000
+gd o10dy sin hd2 19800000
+gd o10x +- hc o10dx 0
+gd o10y +- vc o10dy 0
+gd i10dx cos iwd2 20250000
+gd i10dy sin ihd2 20250000
+gd i10x +- hc i10dx 0
+gd i10y +- vc i10dy 0
+gd o11dx cos wd2 20700000
+gd o11dy sin hd2 20700000
+gd o11x +- hc o11dx 0
+gd o11y +- vc o11dy 0
+gd i11dx cos iwd2 21150000
+gd i11dy sin ihd2 21150000
+gd i11x +- hc i11dx 0
+gd i11y +- vc i11dy 0
+gd o12dx cos wd2 0
+gd o12dy sin hd2 0
+gd o12x +- hc o12dx 0
+gd o12y +- vc o12dy 0
+gd i12dx cos iwd2 450000
+gd i12dy sin ihd2 450000
+gd i12x +- hc i12dx 0
+gd i12y +- vc i12dy 0
+gd o13dx cos wd2 900000
+gd o13dy sin hd2 900000
+gd o13x +- hc o13dx 0
+gd o13y +- vc o13dy 0
+gd i13dx cos iwd2 1350000
+gd i13dy sin ihd2 1350000
+gd i13x +- hc i13dx 0
+gd i13y +- vc i13dy 0
+gd o14dx cos wd2 1800000
+gd o14dy sin hd2 1800000
+gd o14x +- hc o14dx 0
+gd o14y +- vc o14dy 0
+gd i14dx cos iwd2 2250000
+gd i14dy sin ihd2 2250000
+gd i14x +- hc i14dx 0
+gd i14y +- vc i14dy 0
+gd o15dx cos wd2 2700000
+gd o15dy sin hd2 2700000
+gd o15x +- hc o15dx 0
+gd o15y +- vc o15dy 0
+gd i15dx cos iwd2 3150000
+gd i15dy sin ihd2 3150000
+gd i15x +- hc i15dx 0
+gd i15y +- vc i15dy 0
+gd o16dx cos wd2 3600000
+gd o16dy sin hd2 3600000
+gd o16x +- hc o16dx 0
+gd o16y +- vc o16dy 0
+gd i16dx cos iwd2 4050000
+gd i16dy sin ihd2 4050000
+gd i16x +- hc i16dx 0
+gd i16y +- vc i16dy 0
+gd o17dx cos wd2 4500000
+gd o17dy sin hd2 4500000
+gd o17x +- hc o17dx 0
+gd o17y +- vc o17dy 0
+gd i17dx cos iwd2 4950000
+gd i17dy sin ihd2 4950000
+gd i17x +- hc i17dx 0
+gd i17y +- vc i17dy 0
+gd o18dx cos wd2 5400000
+gd o18dy sin hd2 5400000
+gd o18x +- hc o18dx 0
+gd o18y +- vc o18dy 0
+gd i18dx cos iwd2 5850000
+gd i18dy sin ihd2 5850000
+gd i18x +- hc i18dx 0
+gd i18y +- vc i18dy 0
+gd o19dx cos wd2 6300000
+gd o19dy sin hd2 6300000
+gd o19x +- hc o19dx 0
+gd o19y +- vc o19dy 0
+gd i19dx cos iwd2 6750000
+gd i19dy sin ihd2 6750000
+gd i19x +- hc i19dx 0
+gd i19y +- vc i19dy 0
+gd o20dx cos wd2 7200000
+gd o20dy sin hd2 7200000
+gd o20x +- hc o20dx 0
+gd o20y +- vc o20dy 0
+gd i20dx cos iwd2 7650000
+gd i20dy sin ihd2 7650000
+gd i20x +- hc i20dx 0
+gd i20y +- vc i20dy 0
+gd o21dx cos wd2 8100000
+gd o21dy sin hd2 8100000
+gd o21x +- hc o21dx 0
+gd o21y +- vc o21dy 0
+gd i21dx cos iwd2 8550000
+gd i21dy sin ihd2 8550000
+gd i21x +- hc i21dx 0
+gd i21y +- vc i21dy 0
+gd o22dx cos wd2 9000000
+gd o22dy sin hd2 9000000
+gd o22x +- hc o22dx 0
+gd o22y +- vc o22dy 0
+gd i22dx cos iwd2 9450000
+gd i22dy sin ihd2 9450000
+gd i22x +- hc i22dx 0
+gd i22y +- vc i22dy 0
+gd o23dx cos wd2 9900000
+gd o23dy sin hd2 9900000
+gd o23x +- hc o23dx 0
+gd o23y +- vc o23dy 0
+gd i23dx cos iwd2 10350000
+gd i23dy sin ihd2 10350000
+gd i23x +- hc i23dx 0
+gd i23y +- vc i23dy 0
+path
+M o0x o0y
+L i0x i0y
+L o1x o1y
+L i1x i1y
+L o2x o2y
+L i2x i2y
+L o3x o3y
+L i3x i3y
+L o4x o4y
+L i4x i4y
+L o5x o5y
+L i5x i5y
+L o6x o6y
+L i6x i6y
+L o7x o7y
+L i7x i7y
+L o8x o8y
+L i8x i8y
+L o9x o9y
+L i9x i9y
+L o10x o10y
+L i10x i10y
+L o11x o11y
+L i11x i11y
+L o12x o12y
+L i12x i12y
+L o13x o13y
+L i13x i13y
+L o14x o14y
+L i14x i14y
+L o15x o15y
+L i15x i15y
+L o16x o16y
+L i16x i16y
+L o17x o17y
+L i17x i17y
+L o18x o18y
+L i18x i18y
+L o19x o19y
+L i19x i19y
+L o20x o20y
+L i20x i20y
+L o21x o21y
+L i21x i21y
+L o22x o22y
+L i22x i22y
+L o23x o23y
+L i23x i23y
+Z
+`,
+	"star32": `
+av adj 37500
+gd a pin 0 adj 50000
+gd iwd2 */ wd2 a 50000
+gd ihd2 */ hd2 a 50000
+gd o0dx cos wd2 10800000
+gd o0dy sin hd2 10800000
+gd o0x +- hc o0dx 0
+gd o0y +- vc o0dy 0
+gd i0dx cos iwd2 11137500
+gd i0dy sin ihd2 11137500
+gd i0x +- hc i0dx 0
+gd i0y +- vc i0dy 0
+gd o1dx cos wd2 11475000
+gd o1dy sin hd2 11475000
+gd o1x +- hc o1dx 0
+gd o1y +- vc o1dy 0
+gd i1dx cos iwd2 11812500
+gd i1dy sin ihd2 11812500
+gd i1x +- hc i1dx 0
+gd i1y +- vc i1dy 0
+gd o2dx cos wd2 12150000
+gd o2dy sin hd2 12150000
+gd o2x +- hc o2dx 0
+gd o2y +- vc o2dy 0
+gd i2dx cos iwd2 12487500
+gd i2dy sin ihd2 12487500
+gd i2x +- hc i2dx 0
+gd i2y +- vc i2dy 0
+gd o3dx cos wd2 12825000
+gd o3dy sin hd2 12825000
+gd o3x +- hc o3dx 0
+gd o3y +- vc o3dy 0
+gd i3dx cos iwd2 13162500
+gd i3dy sin ihd2 13162500
+gd i3x +- hc i3dx 0
+gd i3y +- vc i3dy 0
+gd o4dx cos wd2 13500000
+gd o4dy sin hd2 13500000
+gd o4x +- hc o4dx 0
+gd o4y +- vc o4dy 0
+gd i4dx cos iwd2 13837500
+gd i4dy sin ihd2 13837500
+gd i4x +- hc i4dx 0
+gd i4y +- vc i4dy 0
+gd o5dx cos wd2 14175000
+gd o5dy sin hd2 14175000
+gd o5x +- hc o5dx 0
+gd o5y +- vc o5dy 0
+gd i5dx cos iwd2 14512500
+gd i5dy sin ihd2 14512500
+gd i5x +- hc i5dx 0
+gd i5y +- vc i5dy 0
+gd o6dx cos wd2 14850000
+gd o6dy sin hd2 14850000
+gd o6x +- hc o6dx 0
+gd o6y +- vc o6dy 0
+gd i6dx cos iwd2 15187500
+gd i6dy sin ihd2 15187500
+gd i6x +- hc i6dx 0
+gd i6y +- vc i6dy 0
+gd o7dx cos wd2 15525000
+gd o7dy sin hd2 15525000
+gd o7x +- hc o7dx 0
+gd o7y +- vc o7dy 0
+gd i7dx cos iwd2 15862500
+gd i7dy sin ihd2 15862500
+gd i7x +- hc i7dx 0
+gd i7y +- vc i7dy 0
+gd o8dx cos wd2 16200000
+gd o8dy sin hd2 16200000
+gd o8x +- hc o8dx 0
+gd o8y +- vc o8dy 0
+gd i8dx cos iwd2 16537500
+gd i8dy sin ihd2 16537500
+gd i8x +- hc i8dx 0
+gd i8y +- vc i8dy 0
+gd o9dx cos wd2 16875000
+gd o9dy sin hd2 16875000
+gd o9x +- hc o9dx 0
+gd o9y +- vc o9dy 0
+gd i9dx cos iwd2 17212500
+gd i9dy sin ihd2 17212500
+gd i9x +- hc i9dx 0
+gd i9y +- vc i9dy 0
+gd o10dx cos wd2 17550000
+gd o10dy sin hd2 17550000
+gd o10x +- hc o10dx 0
+gd o10y +- vc o10dy 0
+gd i10dx cos iwd2 17887500
+gd i10dy sin ihd2 17887500
+gd i10x +- hc i10dx 0
+gd i10y +- vc i10dy 0
+gd o11dx cos wd2 18225000
+gd o11dy sin hd2 18225000
+gd o11x +- hc o11dx 0
+gd o11y +- vc o11dy 0
+gd i11dx cos iwd2 18562500
+gd i11dy sin ihd2 18562500
+gd i11x +- hc i11dx 0
+gd i11y +- vc i11dy 0
+gd o12dx cos wd2 18900000
+gd o12dy sin hd2 18900000
+gd o12x +- hc o12dx 0
+gd o12y +- vc o12dy 0
+gd i12dx cos iwd2 19237500
+gd i12dy sin ihd2 19237500
+gd i12x +- hc i12dx 0
+gd i12y +- vc i12dy 0
+gd o13dx cos wd2 19575000
+gd o13dy sin hd2 19575000
+gd o13x +- hc o13dx 0
+gd o13y +- vc o13dy 0
+gd i13dx cos iwd2 19912500
+gd i13dy sin ihd2 19912500
+gd i13x +- hc i13dx 0
+gd i13y +- vc i13dy 0
+gd o14dx cos wd2 20250000
+gd o14dy sin hd2 20250000
+gd o14x +- hc o14dx 0
+gd o14y +- vc o14dy 0
+gd i14dx cos iwd2 20587500
+gd i14dy sin ihd2 20587500
+gd i14x +- hc i14dx 0
+gd i14y +- vc i14dy 0
+gd o15dx cos wd2 20925000
+gd o15dy sin hd2 20925000
+gd o15x +- hc o15dx 0
+gd o15y +- vc o15dy 0
+gd i15dx cos iwd2 21262500
+gd i15dy sin ihd2 21262500
+gd i15x +- hc i15dx 0
+gd i15y +- vc i15dy 0
+gd o16dx cos wd2 0
+gd o16dy sin hd2 0
+gd o16x +- hc o16dx 0
+gd o16y +- vc o16dy 0
+gd i16dx cos iwd2 337500
+gd i16dy sin ihd2 337500
+gd i16x +- hc i16dx 0
+gd i16y +- vc i16dy 0
+gd o17dx cos wd2 675000
+gd o17dy sin hd2 675000
+gd o17x +- hc o17dx 0
+gd o17y +- vc o17dy 0
+gd i17dx cos iwd2 1012500
+gd i17dy sin ihd2 1012500
+gd i17x +- hc i17dx 0
+gd i17y +- vc i17dy 0
+gd o18dx cos wd2 1350000
+gd o18dy sin hd2 1350000
+gd o18x +- hc o18dx 0
+gd o18y +- vc o18dy 0
+gd i18dx cos iwd2 1687500
+gd i18dy sin ihd2 1687500
+gd i18x +- hc i18dx 0
+gd i18y +- vc i18dy 0
+gd o19dx cos wd2 2025000
+gd o19dy sin hd2 2025000
+gd o19x +- hc o19dx 0
+gd o19y +- vc o19dy 0
+gd i19dx cos iwd2 2362500
+gd i19dy sin ihd2 2362500
+gd i19x +- hc i19dx 0
+gd i19y +- vc i19dy 0
+gd o20dx cos wd2 2700000
+gd o20dy sin hd2 2700000
+gd o20x +- hc o20dx 0
+gd o20y +- vc o20dy 0
+gd i20dx cos iwd2 3037500
+gd i20dy sin ihd2 3037500
+gd i20x +- hc i20dx 0
+gd i20y +- vc i20dy 0
+gd o21dx cos wd2 3375000
+gd o21dy sin hd2 3375000
+gd o21x +- hc o21dx 0
+gd o21y +- vc o21dy 0
+gd i21dx cos iwd2 3712500
+gd i21dy sin ihd2 3712500
+gd i21x +- hc i21dx 0
+gd i21y +- vc i21dy 0
+gd o22dx cos wd2 4050000
+gd o22dy sin hd2 4050000
+gd o22x +- hc o22dx 0
+gd o22y +- vc o22dy 0
+gd i22dx cos iwd2 4387500
+gd i22dy sin ihd2 4387500
+gd i22x +- hc i22dx 0
+gd i22y +- vc i22dy 0
+gd o23dx cos wd2 4725000
+gd o23dy sin hd2 4725000
+gd o23x +- hc o23dx 0
+gd o23y +- vc o23dy 0
+gd i23dx cos iwd2 5062500
+gd i23dy sin ihd2 5062500
+gd i23x +- hc i23dx 0
+gd i23y +- vc i23dy 0
+gd o24dx cos wd2 5400000
+gd o24dy sin hd2 5400000
+gd o24x +- hc o24dx 0
+gd o24y +- vc o24dy 0
+gd i24dx cos iwd2 5737500
+gd i24dy sin ihd2 5737500
+gd i24x +- hc i24dx 0
+gd i24y +- vc i24dy 0
+gd o25dx cos wd2 6075000
+gd o25dy sin hd2 6075000
+gd o25x +- hc o25dx 0
+gd o25y +- vc o25dy 0
+gd i25dx cos iwd2 6412500
+gd i25dy sin ihd2 6412500
+gd i25x +- hc i25dx 0
+gd i25y +- vc i25dy 0
+gd o26dx cos wd2 6750000
+gd o26dy sin hd2 6750000
+gd o26x +- hc o26dx 0
+gd o26y +- vc o26dy 0
+gd i26dx cos iwd2 7087500
+gd i26dy sin ihd2 7087500
+gd i26x +- hc i26dx 0
+gd i26y +- vc i26dy 0
+gd o27dx cos wd2 7425000
+gd o27dy sin hd2 7425000
+gd o27x +- hc o27dx 0
+gd o27y +- vc o27dy 0
+gd i27dx cos iwd2 7762500
+gd i27dy sin ihd2 7762500
+gd i27x +- hc i27dx 0
+gd i27y +- vc i27dy 0
+gd o28dx cos wd2 8100000
+gd o28dy sin hd2 8100000
+gd o28x +- hc o28dx 0
+gd o28y +- vc o28dy 0
+gd i28dx cos iwd2 8437500
+gd i28dy sin ihd2 8437500
+gd i28x +- hc i28dx 0
+gd i28y +- vc i28dy 0
+gd o29dx cos wd2 8775000
+gd o29dy sin hd2 8775000
+gd o29x +- hc o29dx 0
+gd o29y +- vc o29dy 0
+gd i29dx cos iwd2 9112500
+gd i29dy sin ihd2 9112500
+gd i29x +- hc i29dx 0
+gd i29y +- vc i29dy 0
+gd o30dx cos wd2 9450000
+gd o30dy sin hd2 9450000
+gd o30x +- hc o30dx 0
+gd o30y +- vc o30dy 0
+gd i30dx cos iwd2 9787500
+gd i30dy sin ihd2 9787500
+gd i30x +- hc i30dx 0
+gd i30y +- vc i30dy 0
+gd o31dx cos wd2 10125000
+gd o31dy sin hd2 10125000
+gd o31x +- hc o31dx 0
+gd o31y +- vc o31dy 0
+gd i31dx cos iwd2 10462500
+gd i31dy sin ihd2 10462500
+gd i31x +- hc i31dx 0
+gd i31y +- vc i31dy 0
+path
+M o0x o0y
+L i0x i0y
+L o1x o1y
+L i1x i1y
+L o2x o2y
+L i2x i2y
+L o3x o3y
+L i3x i3y
+L o4x o4y
+L i4x i4y
+L o5x o5y
+L i5x i5y
+L o6x o6y
+L i6x i6y
+L o7x o7y
+L i7x i7y
+L o8x o8y
+L i8x i8y
+L o9x o9y
+L i9x i9y
+L o10x o10y
+L i10x i10y
+L o11x o11y
+L i11x i11y
+L o12x o12y
+L i12x i12y
+L o13x o13y
+L i13x i13y
+L o14x o14y
+L i14x i14y
+L o15x o15y
+L i15x i15y
+L o16x o16y
+L i16x i16y
+L o17x o17y
+L i17x i17y
+L o18x o18y
+L i18x i18y
+L o19x o19y
+L i19x i19y
+L o20x o20y
+L i20x i20y
+L o21x o21y
+L i21x i21y
+L o22x o22y
+L i22x i22y
+L o23x o23y
+L i23x i23y
+L o24x o24y
+L i24x i24y
+L o25x o25y
+L i25x i25y
+L o26x o26y
+L i26x i26y
+L o27x o27y
+L i27x i27y
+L o28x o28y
+L i28x i28y
+L o29x o29y
+L i29x i29y
+L o30x o30y
+L i30x i30y
+L o31x o31y
+L i31x i31y
+Z
+`,
+	"roundRect": `
+av adj 16667
+gd a pin 0 adj 50000
+gd x1 */ ss a 100000
+gd x2 +- r 0 x1
+gd y2 +- b 0 x1
+path
+M l x1
+A x1 x1 cd2 cd4
+L x2 t
+A x1 x1 3cd4 cd4
+L r y2
+A x1 x1 0 cd4
+L x1 b
+A x1 x1 cd4 cd4
+Z
+`,
+	"round1Rect": `
+av adj 16667
+gd a pin 0 adj 50000
+gd dx1 */ ss a 100000
+gd x1 +- r 0 dx1
+path
+M l t
+L x1 t
+A dx1 dx1 3cd4 cd4
+L r b
+L l b
+Z
+`,
+	"round2SameRect": `
+av adj1 16667
+av adj2 0
+gd a1 pin 0 adj1 50000
+gd a2 pin 0 adj2 50000
+gd tx1 */ ss a1 100000
+gd tx2 +- r 0 tx1
+gd bx1 */ ss a2 100000
+gd bx2 +- r 0 bx1
+gd by1 +- b 0 bx1
+path
+M tx1 t
+L tx2 t
+A tx1 tx1 3cd4 cd4
+L r by1
+A bx1 bx1 0 cd4
+L bx1 b
+A bx1 bx1 cd4 cd4
+L l tx1
+A tx1 tx1 cd2 cd4
+Z
+`,
+	"round2DiagRect": `
+av adj1 16667
+av adj2 0
+gd a1 pin 0 adj1 50000
+gd a2 pin 0 adj2 50000
+gd x1 */ ss a1 100000
+gd y1 +- b 0 x1
+gd a */ ss a2 100000
+gd x2 +- r 0 a
+gd y2 +- b 0 a
+gd x3 +- r 0 x1
+path
+M x1 t
+L x2 t
+A a a 3cd4 cd4
+L r y1
+A x1 x1 0 cd4
+L a b
+A a a cd4 cd4
+L l x1
+A x1 x1 cd2 cd4
+Z
+`,
+	"snipRoundRect": `
+av adj1 16667
+av adj2 16667
+gd a1 pin 0 adj1 50000
+gd a2 pin 0 adj2 50000
+gd x1 */ ss a1 100000
+gd dx2 */ ss a2 100000
+gd x2 +- r 0 dx2
+path
+M x1 t
+L x2 t
+L r dx2
+L r b
+L l b
+L l x1
+A x1 x1 cd2 cd4
+Z
+`,
+	"snip1Rect": `
+av adj 16667
+gd a pin 0 adj 50000
+gd dx1 */ ss a 100000
+gd x1 +- r 0 dx1
+path
+M l t
+L x1 t
+L r dx1
+L r b
+L l b
+Z
+`,
+	"snip2SameRect": `
+av adj1 16667
+av adj2 0
+gd a1 pin 0 adj1 50000
+gd a2 pin 0 adj2 50000
+gd tx1 */ ss a1 100000
+gd tx2 +- r 0 tx1
+gd bx1 */ ss a2 100000
+gd bx2 +- r 0 bx1
+gd by1 +- b 0 bx1
+path
+M tx1 t
+L tx2 t
+L r tx1
+L r by1
+L bx2 b
+L bx1 b
+L l by1
+L l tx1
+Z
+`,
+	"snip2DiagRect": `
+av adj1 0
+av adj2 16667
+gd a1 pin 0 adj1 50000
+gd a2 pin 0 adj2 50000
+gd lx1 */ ss a1 100000
+gd lx2 +- r 0 lx1
+gd ly1 +- b 0 lx1
+gd rx1 */ ss a2 100000
+gd rx2 +- r 0 rx1
+gd ry1 +- b 0 rx1
+path
+M lx1 t
+L rx2 t
+L r rx1
+L r ly1
+L lx2 b
+L rx1 b
+L l ry1
+L l lx1
+Z
+`,
+	"plaque": `
+av adj 16667
+gd a pin 0 adj 50000
+gd x1 */ ss a 100000
+gd x2 +- r 0 x1
+gd y2 +- b 0 x1
+path
+M l x1
+A x1 x1 cd4 -5400000
+L x2 t
+A x1 x1 cd2 -5400000
+L r y2
+A x1 x1 3cd4 -5400000
+L x1 b
+A x1 x1 0 -5400000
+Z
+`,
+	"ellipse": `
+path
+M l vc
+A wd2 hd2 cd2 cd4
+A wd2 hd2 3cd4 cd4
+A wd2 hd2 0 cd4
+A wd2 hd2 cd4 cd4
+Z
+`,
+	"teardrop": `
+av adj 100000
+gd a pin 0 adj 200000
+gd r2 sqrt 2
+gd tw */ r2 wd2 1
+gd th */ r2 hd2 1
+gd sw */ tw a 100000
+gd sh */ th a 100000
+gd dx1 cos sw 2700000
+gd dy1 sin sh 2700000
+gd x1 +- hc dx1 0
+gd y1 +- vc 0 dy1
+gd x2 +/ hc x1 2
+gd y2 +/ vc y1 2
+path
+M l vc
+A wd2 hd2 cd2 cd4
+Q x2 t x1 y1
+Q r y2 r vc
+A wd2 hd2 0 cd4
+A wd2 hd2 cd4 cd4
+Z
+`,
+	"homePlate": `
+av adj 50000
+gd maxAdj */ 100000 w ss
+gd a pin 0 adj maxAdj
+gd dx1 */ ss a 100000
+gd x1 +- r 0 dx1
+path
+M l t
+L x1 t
+L r vc
+L x1 b
+L l b
+Z
+`,
+	"chevron": `
+av adj 50000
+gd maxAdj */ 100000 w ss
+gd a pin 0 adj maxAdj
+gd x1 */ ss a 100000
+gd x2 +- r 0 x1
+path
+M l t
+L x2 t
+L r vc
+L x2 b
+L l b
+L x1 vc
+Z
+`,
+	"pieWedge": `
+path
+M l b
+A w h cd2 cd4
+L r b
+Z
+`,
+	"pie": `
+av adj1 0
+av adj2 16200000
+gd stAng pin 0 adj1 21599999
+gd enAng pin 0 adj2 21599999
+gd sw1 +- enAng 0 stAng
+gd sw2 +- sw1 21600000 0
+gd swAng ?: sw1 sw1 sw2
+gd wt1 sin wd2 stAng
+gd ht1 cos hd2 stAng
+gd dx1 cat2 wd2 ht1 wt1
+gd dy1 sat2 hd2 ht1 wt1
+gd x1 +- hc dx1 0
+gd y1 +- vc dy1 0
+path
+M x1 y1
+A wd2 hd2 stAng swAng
+L hc vc
+Z
+`,
+	"chord": `
+av adj1 2700000
+av adj2 16200000
+gd stAng pin 0 adj1 21599999
+gd enAng pin 0 adj2 21599999
+gd sw1 +- enAng 0 stAng
+gd sw2 +- sw1 21600000 0
+gd swAng ?: sw1 sw1 sw2
+gd wt1 sin wd2 stAng
+gd ht1 cos hd2 stAng
+gd dx1 cat2 wd2 ht1 wt1
+gd dy1 sat2 hd2 ht1 wt1
+gd x1 +- hc dx1 0
+gd y1 +- vc dy1 0
+path
+M x1 y1
+A wd2 hd2 stAng swAng
+Z
+`,
+	"arc": `
+av adj1 16200000
+av adj2 0
+gd stAng pin 0 adj1 21599999
+gd enAng pin 0 adj2 21599999
+gd sw11 +- enAng 0 stAng
+gd sw12 +- sw11 21600000 0
+gd swAng ?: sw11 sw11 sw12
+gd wt1 sin wd2 stAng
+gd ht1 cos hd2 stAng
+gd dx1 cat2 wd2 ht1 wt1
+gd dy1 sat2 hd2 ht1 wt1
+gd x1 +- hc dx1 0
+gd y1 +- vc dy1 0
+path stroke=false
+M x1 y1
+A wd2 hd2 stAng swAng
+L hc vc
+Z
+path fill=none
+M x1 y1
+A wd2 hd2 stAng swAng
+`,
+	"blockArc": `
+av adj1 10800000
+av adj2 0
+av adj3 25000
+gd stAng pin 0 adj1 21599999
+gd istAng pin 0 adj2 21599999
+gd a3 pin 0 adj3 50000
+gd sw11 +- istAng 0 stAng
+gd sw12 +- sw11 21600000 0
+gd swAng ?: sw11 sw11 sw12
+gd iswAng +- 0 0 swAng
+gd wt1 sin wd2 stAng
+gd ht1 cos hd2 stAng
+gd dx1 cat2 wd2 ht1 wt1
+gd dy1 sat2 hd2 ht1 wt1
+gd x1 +- hc dx1 0
+gd y1 +- vc dy1 0
+gd dr */ ss a3 100000
+gd iwd2 +- wd2 0 dr
+gd ihd2 +- hd2 0 dr
+gd wt2 sin iwd2 istAng
+gd ht2 cos ihd2 istAng
+gd dx2 cat2 iwd2 ht2 wt2
+gd dy2 sat2 ihd2 ht2 wt2
+gd x2 +- hc dx2 0
+gd y2 +- vc dy2 0
+path
+M x1 y1
+A wd2 hd2 stAng swAng
+L x2 y2
+A iwd2 ihd2 istAng iswAng
+Z
+`,
+	"donut": `
+av adj 25000
+gd a pin 0 adj 50000
+gd dr */ ss a 100000
+gd iwd2 +- wd2 0 dr
+gd ihd2 +- hd2 0 dr
+path
+M l vc
+A wd2 hd2 cd2 cd4
+A wd2 hd2 3cd4 cd4
+A wd2 hd2 0 cd4
+A wd2 hd2 cd4 cd4
+Z
+M dr vc
+A iwd2 ihd2 cd2 -5400000
+A iwd2 ihd2 cd4 -5400000
+A iwd2 ihd2 0 -5400000
+A iwd2 ihd2 3cd4 -5400000
+Z
+`,
+	"noSmoking": `
+av adj 18750
+gd a pin 0 adj 50000
+gd dr */ ss a 100000
+gd iwd2 +- wd2 0 dr
+gd ihd2 +- hd2 0 dr
+gd ang at2 w h
+gd ct cos ihd2 ang
+gd st sin iwd2 ang
+gd m mod ct st 0
+gd n */ iwd2 ihd2 m
+gd drd2 */ dr 1 2
+gd dang at2 n drd2
+gd dang2 */ dang 2 1
+gd swAng +- -10800000 dang2 0
+gd t3 at2 w h
+gd stAng1 +- t3 0 dang
+gd stAng2 +- stAng1 0 cd2
+gd ct1 cos ihd2 stAng1
+gd st1 sin iwd2 stAng1
+gd m1 mod ct1 st1 0
+gd n1 */ iwd2 ihd2 m1
+gd dx1 cos n1 stAng1
+gd dy1 sin n1 stAng1
+gd x1 +- hc dx1 0
+gd y1 +- vc dy1 0
+gd x2 +- hc 0 dx1
+gd y2 +- vc 0 dy1
+path
+M l vc
+A wd2 hd2 cd2 cd4
+A wd2 hd2 3cd4 cd4
+A wd2 hd2 0 cd4
+A wd2 hd2 cd4 cd4
+Z
+M x1 y1
+A iwd2 ihd2 stAng1 swAng
+Z
+M x2 y2
+A iwd2 ihd2 stAng2 swAng
+Z
+`,
+	"rightArrow": `
+av adj1 50000
+av adj2 50000
+gd maxAdj2 */ 100000 w ss
+gd a1 pin 0 adj1 100000
+gd a2 pin 0 adj2 maxAdj2
+gd dx1 */ ss a2 100000
+gd x1 +- r 0 dx1
+gd dy1 */ h a1 200000
+gd y1 +- vc 0 dy1
+gd y2 +- vc dy1 0
+path
+M l y1
+L x1 y1
+L x1 t
+L r vc
+L x1 b
+L x1 y2
+L l y2
+Z
+`,
+	"leftArrow": `
+av adj1 50000
+av adj2 50000
+gd maxAdj2 */ 100000 w ss
+gd a1 pin 0 adj1 100000
+gd a2 pin 0 adj2 maxAdj2
+gd dx2 */ ss a2 100000
+gd x2 +- l dx2 0
+gd dy1 */ h a1 200000
+gd y1 +- vc 0 dy1
+gd y2 +- vc dy1 0
+path
+M l vc
+L x2 t
+L x2 y1
+L r y1
+L r y2
+L x2 y2
+L x2 b
+Z
+`,
+	"upArrow": `
+av adj1 50000
+av adj2 50000
+gd maxAdj2 */ 100000 h ss
+gd a1 pin 0 adj1 100000
+gd a2 pin 0 adj2 maxAdj2
+gd dy2 */ ss a2 100000
+gd y2 +- t dy2 0
+gd dx1 */ w a1 200000
+gd x1 +- hc 0 dx1
+gd x2 +- hc dx1 0
+path
+M l y2
+L hc t
+L r y2
+L x2 y2
+L x2 b
+L x1 b
+L x1 y2
+Z
+`,
+	"downArrow": `
+av adj1 50000
+av adj2 50000
+gd maxAdj2 */ 100000 h ss
+gd a1 pin 0 adj1 100000
+gd a2 pin 0 adj2 maxAdj2
+gd dy1 */ ss a2 100000
+gd y1 +- b 0 dy1
+gd dx1 */ w a1 200000
+gd x1 +- hc 0 dx1
+gd x2 +- hc dx1 0
+path
+M l y1
+L x1 y1
+L x1 t
+L x2 t
+L x2 y1
+L r y1
+L hc b
+Z
+`,
+	"leftRightArrow": `
+av adj1 50000
+av adj2 50000
+gd maxAdj2 */ 50000 w ss
+gd a1 pin 0 adj1 100000
+gd a2 pin 0 adj2 maxAdj2
+gd x2 */ ss a2 100000
+gd x3 +- r 0 x2
+gd dy */ h a1 200000
+gd y1 +- vc 0 dy
+gd y2 +- vc dy 0
+path
+M l vc
+L x2 t
+L x2 y1
+L x3 y1
+L x3 t
+L r vc
+L x3 b
+L x3 y2
+L x2 y2
+L x2 b
+Z
+`,
+	"upDownArrow": `
+av adj1 50000
+av adj2 50000
+gd maxAdj2 */ 50000 h ss
+gd a1 pin 0 adj1 100000
+gd a2 pin 0 adj2 maxAdj2
+gd y2 */ ss a2 100000
+gd y3 +- b 0 y2
+gd dx1 */ w a1 200000
+gd x1 +- hc 0 dx1
+gd x2 +- hc dx1 0
+path
+M l y2
+L hc t
+L r y2
+L x2 y2
+L x2 y3
+L r y3
+L hc b
+L l y3
+L x1 y3
+L x1 y2
+Z
+`,
+	"stripedRightArrow": `
+av adj1 50000
+av adj2 50000
+gd maxAdj2 */ 84375 w ss
+gd a1 pin 0 adj1 100000
+gd a2 pin 0 adj2 maxAdj2
+gd x4 */ ss 5 32
+gd dx5 */ ss a2 100000
+gd x5 +- r 0 dx5
+gd dy1 */ h a1 200000
+gd y1 +- vc 0 dy1
+gd y2 +- vc dy1 0
+path
+M l y1
+L ssd32 y1
+L ssd32 y2
+L l y2
+Z
+M ssd16 y1
+L ssd8 y1
+L ssd8 y2
+L ssd16 y2
+Z
+M x4 y1
+L x5 y1
+L x5 t
+L r vc
+L x5 b
+L x5 y2
+L x4 y2
+Z
+`,
+	"notchedRightArrow": `
+av adj1 50000
+av adj2 50000
+gd maxAdj2 */ 100000 w ss
+gd a1 pin 0 adj1 100000
+gd a2 pin 0 adj2 maxAdj2
+gd dx2 */ ss a2 100000
+gd x2 +- r 0 dx2
+gd dy1 */ h a1 200000
+gd y1 +- vc 0 dy1
+gd y2 +- vc dy1 0
+gd x1 */ dy1 dx2 hd2
+path
+M l y1
+L x2 y1
+L x2 t
+L r vc
+L x2 b
+L x2 y2
+L l y2
+L x1 vc
+Z
+`,
+	"bentUpArrow": `
+av adj1 25000
+av adj2 25000
+av adj3 25000
+gd a1 pin 0 adj1 50000
+gd a2 pin 0 adj2 50000
+gd a3 pin 0 adj3 50000
+gd y1 */ ss a3 100000
+gd dx1 */ ss a2 50000
+gd x1 +- r 0 dx1
+gd dx3 */ ss a2 100000
+gd x3 +- r 0 dx3
+gd dx2 */ ss a1 200000
+gd x2 +- x3 0 dx2
+gd x4 +- x3 dx2 0
+gd dy2 */ ss a1 100000
+gd y2 +- b 0 dy2
+path
+M l y2
+L x2 y2
+L x2 y1
+L x1 y1
+L x3 t
+L r y1
+L x4 y1
+L x4 b
+L l b
+Z
+`,
+	"leftUpArrow": `
+av adj1 25000
+av adj2 25000
+av adj3 25000
+gd a2 pin 0 adj2 50000
+gd maxAdj1 */ a2 2 1
+gd a1 pin 0 adj1 maxAdj1
+gd maxAdj3 +- 100000 0 maxAdj1
+gd a3 pin 0 adj3 maxAdj3
+gd x1 */ ss a3 100000
+gd dx2 */ ss a2 50000
+gd x2 +- r 0 dx2
+gd y2 +- b 0 dx2
+gd dx4 */ ss a2 100000
+gd x4 +- r 0 dx4
+gd y4 +- b 0 dx4
+gd dx3 */ ss a1 200000
+gd x3 +- x4 0 dx3
+gd x5 +- x4 dx3 0
+gd y3 +- y4 0 dx3
+gd y5 +- y4 dx3 0
+path
+M l y4
+L x1 y2
+L x1 y3
+L x3 y3
+L x3 x1
+L x2 x1
+L x4 t
+L r x1
+L x5 x1
+L x5 y5
+L x1 y5
+L x1 b
+Z
+`,
+	"leftRightUpArrow": `
+av adj1 25000
+av adj2 25000
+av adj3 25000
+gd a2 pin 0 adj2 50000
+gd maxAdj1 */ a2 2 1
+gd a1 pin 0 adj1 maxAdj1
+gd q1 +- 100000 0 maxAdj1
+gd maxAdj3 */ q1 1 2
+gd a3 pin 0 adj3 maxAdj3
+gd x1 */ ss a3 100000
+gd dx2 */ ss a2 100000
+gd x2 +- hc 0 dx2
+gd x5 +- hc dx2 0
+gd dx3 */ ss a1 200000
+gd x3 +- hc 0 dx3
+gd x4 +- hc dx3 0
+gd x6 +- r 0 x1
+gd dy2 */ ss a2 50000
+gd y2 +- b 0 dy2
+gd y4 +- b 0 dx2
+gd y3 +- y4 0 dx3
+gd y5 +- y4 dx3 0
+path
+M l y4
+L x1 y2
+L x1 y3
+L x3 y3
+L x3 x1
+L x2 x1
+L hc t
+L x5 x1
+L x4 x1
+L x4 y3
+L x6 y3
+L x6 y2
+L r y4
+L x6 b
+L x6 y5
+L x1 y5
+L x1 b
+Z
+`,
+	"quadArrow": `
+av adj1 22500
+av adj2 22500
+av adj3 22500
+gd a2 pin 0 adj2 50000
+gd maxAdj1 */ a2 2 1
+gd a1 pin 0 adj1 maxAdj1
+gd q1 +- 100000 0 maxAdj1
+gd maxAdj3 */ q1 1 2
+gd a3 pin 0 adj3 maxAdj3
+gd x1 */ ss a3 100000
+gd dx2 */ ss a2 100000
+gd x2 +- hc 0 dx2
+gd x5 +- hc dx2 0
+gd dx3 */ ss a1 200000
+gd x3 +- hc 0 dx3
+gd x4 +- hc dx3 0
+gd x6 +- r 0 x1
+gd y2 +- vc 0 dx2
+gd y5 +- vc dx2 0
+gd y3 +- vc 0 dx3
+gd y4 +- vc dx3 0
+gd y6 +- b 0 x1
+path
+M l vc
+L x1 y2
+L x1 y3
+L x3 y3
+L x3 x1
+L x2 x1
+L hc t
+L x5 x1
+L x4 x1
+L x4 y3
+L x6 y3
+L x6 y2
+L r vc
+L x6 y5
+L x6 y4
+L x4 y4
+L x4 y6
+L x5 y6
+L hc b
+L x2 y6
+L x3 y6
+L x3 y4
+L x1 y4
+L x1 y5
+Z
+`,
+	"leftArrowCallout": `
+av adj1 25000
+av adj2 25000
+av adj3 25000
+av adj4 64977
+gd maxAdj2 */ 50000 h ss
+gd a2 pin 0 adj2 maxAdj2
+gd maxAdj1 */ a2 2 1
+gd a1 pin 0 adj1 maxAdj1
+gd maxAdj3 */ 100000 w ss
+gd a3 pin 0 adj3 maxAdj3
+gd q2 */ a3 ss w
+gd maxAdj4 +- 100000 0 q2
+gd a4 pin 0 adj4 maxAdj4
+gd dy1 */ ss a2 100000
+gd dy2 */ ss a1 200000
+gd y1 +- vc 0 dy1
+gd y2 +- vc 0 dy2
+gd y3 +- vc dy2 0
+gd y4 +- vc dy1 0
+gd x1 */ ss a3 100000
+gd dx2 */ w a4 100000
+gd x2 +- r 0 dx2
+path
+M l vc
+L x1 y1
+L x1 y2
+L x2 y2
+L x2 t
+L r t
+L r b
+L x2 b
+L x2 y3
+L x1 y3
+L x1 y4
+Z
+`,
+	"rightArrowCallout": `
+av adj1 25000
+av adj2 25000
+av adj3 25000
+av adj4 64977
+gd maxAdj2 */ 50000 h ss
+gd a2 pin 0 adj2 maxAdj2
+gd maxAdj1 */ a2 2 1
+gd a1 pin 0 adj1 maxAdj1
+gd maxAdj3 */ 100000 w ss
+gd a3 pin 0 adj3 maxAdj3
+gd q2 */ a3 ss w
+gd maxAdj4 +- 100000 0 q2
+gd a4 pin 0 adj4 maxAdj4
+gd dy1 */ ss a2 100000
+gd dy2 */ ss a1 200000
+gd y1 +- vc 0 dy1
+gd y2 +- vc 0 dy2
+gd y3 +- vc dy2 0
+gd y4 +- vc dy1 0
+gd dx3 */ ss a3 100000
+gd x3 +- r 0 dx3
+gd x2 */ w a4 100000
+path
+M l t
+L x2 t
+L x2 y2
+L x3 y2
+L x3 y1
+L r vc
+L x3 y4
+L x3 y3
+L x2 y3
+L x2 b
+L l b
+Z
+`,
+	"upArrowCallout": `
+av adj1 25000
+av adj2 25000
+av adj3 25000
+av adj4 64977
+gd maxAdj2 */ 50000 w ss
+gd a2 pin 0 adj2 maxAdj2
+gd maxAdj1 */ a2 2 1
+gd a1 pin 0 adj1 maxAdj1
+gd maxAdj3 */ 100000 h ss
+gd a3 pin 0 adj3 maxAdj3
+gd q2 */ a3 ss h
+gd maxAdj4 +- 100000 0 q2
+gd a4 pin 0 adj4 maxAdj4
+gd dx1 */ ss a2 100000
+gd dx2 */ ss a1 200000
+gd x1 +- hc 0 dx1
+gd x2 +- hc 0 dx2
+gd x3 +- hc dx2 0
+gd x4 +- hc dx1 0
+gd y1 */ ss a3 100000
+gd dy2 */ h a4 100000
+gd y2 +- b 0 dy2
+path
+M l y2
+L x2 y2
+L x2 y1
+L x1 y1
+L hc t
+L x4 y1
+L x3 y1
+L x3 y2
+L r y2
+L r b
+L l b
+Z
+`,
+	"downArrowCallout": `
+av adj1 25000
+av adj2 25000
+av adj3 25000
+av adj4 64977
+gd maxAdj2 */ 50000 w ss
+gd a2 pin 0 adj2 maxAdj2
+gd maxAdj1 */ a2 2 1
+gd a1 pin 0 adj1 maxAdj1
+gd maxAdj3 */ 100000 h ss
+gd a3 pin 0 adj3 maxAdj3
+gd q2 */ a3 ss h
+gd maxAdj4 +- 100000 0 q2
+gd a4 pin 0 adj4 maxAdj4
+gd dx1 */ ss a2 100000
+gd dx2 */ ss a1 200000
+gd x1 +- hc 0 dx1
+gd x2 +- hc 0 dx2
+gd x3 +- hc dx2 0
+gd x4 +- hc dx1 0
+gd dy3 */ ss a3 100000
+gd y3 +- b 0 dy3
+gd y2 */ h a4 100000
+path
+M l t
+L r t
+L r y2
+L x3 y2
+L x3 y3
+L x4 y3
+L hc b
+L x1 y3
+L x2 y3
+L x2 y2
+L l y2
+Z
+`,
+	"leftRightArrowCallout": `
+av adj1 25000
+av adj2 25000
+av adj3 25000
+av adj4 48123
+gd maxAdj2 */ 50000 h ss
+gd a2 pin 0 adj2 maxAdj2
+gd maxAdj1 */ a2 2 1
+gd a1 pin 0 adj1 maxAdj1
+gd maxAdj3 */ 50000 w ss
+gd a3 pin 0 adj3 maxAdj3
+gd q2 */ a3 ss wd2
+gd maxAdj4 +- 100000 0 q2
+gd a4 pin 0 adj4 maxAdj4
+gd dy1 */ ss a2 100000
+gd dy2 */ ss a1 200000
+gd y1 +- vc 0 dy1
+gd y2 +- vc 0 dy2
+gd y3 +- vc dy2 0
+gd y4 +- vc dy1 0
+gd x1 */ ss a3 100000
+gd x4 +- r 0 x1
+gd dx2 */ w a4 200000
+gd x2 +- hc 0 dx2
+gd x3 +- hc dx2 0
+path
+M l vc
+L x1 y1
+L x1 y2
+L x2 y2
+L x2 t
+L x3 t
+L x3 y2
+L x4 y2
+L x4 y1
+L r vc
+L x4 y4
+L x4 y3
+L x3 y3
+L x3 b
+L x2 b
+L x2 y3
+L x1 y3
+L x1 y4
+Z
+`,
+	"upDownArrowCallout": `
+av adj1 25000
+av adj2 25000
+av adj3 25000
+av adj4 48123
+gd maxAdj2 */ 50000 w ss
+gd a2 pin 0 adj2 maxAdj2
+gd maxAdj1 */ a2 2 1
+gd a1 pin 0 adj1 maxAdj1
+gd maxAdj3 */ 50000 h ss
+gd a3 pin 0 adj3 maxAdj3
+gd q2 */ a3 ss hd2
+gd maxAdj4 +- 100000 0 q2
+gd a4 pin 0 adj4 maxAdj4
+gd dx1 */ ss a2 100000
+gd dx2 */ ss a1 200000
+gd x1 +- hc 0 dx1
+gd x2 +- hc 0 dx2
+gd x3 +- hc dx2 0
+gd x4 +- hc dx1 0
+gd y1 */ ss a3 100000
+gd y4 +- b 0 y1
+gd dy2 */ h a4 200000
+gd y2 +- vc 0 dy2
+gd y3 +- vc dy2 0
+path
+M l y2
+L x2 y2
+L x2 y1
+L x1 y1
+L hc t
+L x4 y1
+L x3 y1
+L x3 y2
+L r y2
+L r y3
+L x3 y3
+L x3 y4
+L x4 y4
+L hc b
+L x1 y4
+L x2 y4
+L x2 y3
+L l y3
+Z
+`,
+	"quadArrowCallout": `
+av adj1 18515
+av adj2 18515
+av adj3 18515
+av adj4 48123
+gd a2 pin 0 adj2 50000
+gd maxAdj1 */ a2 2 1
+gd a1 pin 0 adj1 maxAdj1
+gd maxAdj3 +- 50000 0 a2
+gd a3 pin 0 adj3 maxAdj3
+gd q2 */ a3 2 1
+gd maxAdj4 +- 100000 0 q2
+gd a4 pin a1 adj4 maxAdj4
+gd dx2 */ ss a2 100000
+gd dx3 */ ss a1 200000
+gd ah */ ss a3 100000
+gd dx1 */ w a4 200000
+gd dy1 */ h a4 200000
+gd x8 +- r 0 ah
+gd x2 +- hc 0 dx1
+gd x7 +- hc dx1 0
+gd x3 +- hc 0 dx2
+gd x6 +- hc dx2 0
+gd x4 +- hc 0 dx3
+gd x5 +- hc dx3 0
+gd y8 +- b 0 ah
+gd y2 +- vc 0 dy1
+gd y7 +- vc dy1 0
+gd y3 +- vc 0 dx2
+gd y6 +- vc dx2 0
+gd y4 +- vc 0 dx3
+gd y5 +- vc dx3 0
+path
+M l vc
+L ah y3
+L ah y4
+L x2 y4
+L x2 y2
+L x4 y2
+L x4 ah
+L x3 ah
+L hc t
+L x6 ah
+L x5 ah
+L x5 y2
+L x7 y2
+L x7 y4
+L x8 y4
+L x8 y3
+L r vc
+L x8 y6
+L x8 y5
+L x7 y5
+L x7 y7
+L x5 y7
+L x5 y8
+L x6 y8
+L hc b
+L x3 y8
+L x4 y8
+L x4 y7
+L x2 y7
+L x2 y5
+L ah y5
+L ah y6
+Z
+`,
+	"bentArrow": `
+av adj1 25000
+av adj2 25000
+av adj3 25000
+av adj4 43750
+gd a2 pin 0 adj2 50000
+gd maxAdj1 */ a2 2 1
+gd a1 pin 0 adj1 maxAdj1
+gd a3 pin 0 adj3 50000
+gd th */ ss a1 100000
+gd aw2 */ ss a2 100000
+gd th2 */ th 1 2
+gd dh2 +- aw2 0 th2
+gd ah */ ss a3 100000
+gd bw +- r 0 ah
+gd bh +- b 0 dh2
+gd bs min bw bh
+gd maxAdj4 */ 100000 bs ss
+gd a4 pin 0 adj4 maxAdj4
+gd bd */ ss a4 100000
+gd bd3 +- bd 0 th
+gd bd2 max bd3 0
+gd x3 +- th bd2 0
+gd x4 +- r 0 ah
+gd y3 +- dh2 th 0
+gd y4 +- y3 dh2 0
+gd y5 +- dh2 bd 0
+path
+M l b
+L l y5
+A bd bd cd2 cd4
+L x4 dh2
+L x4 t
+L r aw2
+L x4 y4
+L x4 y3
+L x3 y3
+A bd2 bd2 3cd4 -5400000
+L th b
+Z
+`,
+	"uturnArrow": `
+av adj1 25000
+av adj2 25000
+av adj3 25000
+av adj4 43750
+av adj5 75000
+gd a2 pin 0 adj2 25000
+gd maxAdj1 */ a2 2 1
+gd a1 pin 0 adj1 maxAdj1
+gd q2 */ a1 ss h
+gd q3 +- 100000 0 q2
+gd maxAdj3 */ q3 h ss
+gd a3 pin 0 adj3 maxAdj3
+gd q1 +- a3 a1 0
+gd minAdj5 */ q1 ss h
+gd a5 pin minAdj5 adj5 100000
+gd th */ ss a1 100000
+gd aw2 */ ss a2 100000
+gd th2 */ th 1 2
+gd dh2 +- aw2 0 th2
+gd y5 */ h a5 100000
+gd ah */ ss a3 100000
+gd y4 +- y5 0 ah
+gd x9 +- r 0 dh2
+gd bw */ x9 1 2
+gd bs min bw y4
+gd maxAdj4 */ bs 100000 ss
+gd a4 pin 0 adj4 maxAdj4
+gd bd */ ss a4 100000
+gd bd3 +- bd 0 th
+gd bd2 max bd3 0
+gd x3 +- th bd2 0
+gd x8 +- r 0 aw2
+gd x6 +- x8 0 aw2
+gd x7 +- x6 dh2 0
+gd x4 +- x9 0 bd
+gd x5 +- x7 0 bd2
+path
+M l b
+L l bd
+A bd bd cd2 cd4
+L x4 t
+A bd bd 3cd4 cd4
+L x9 y4
+L r y4
+L x8 y5
+L x6 y4
+L x7 y4
+L x7 x3
+A bd2 bd2 0 -5400000
+L x3 th
+A bd2 bd2 3cd4 -5400000
+L th b
+Z
+`,
+	"curvedRightArrow": `
+av adj1 25000
+av adj2 50000
+av adj3 25000
+gd maxAdj2 */ 50000 h ss
+gd a2 pin 0 adj2 maxAdj2
+gd a1 pin 0 adj1 a2
+gd th */ ss a1 100000
+gd aw */ ss a2 100000
+gd q1 +/ th aw 4
+gd hR +- hd2 0 q1
+gd q7 */ hR 2 1
+gd q8 */ q7 q7 1
+gd q9 */ th th 1
+gd q10 +- q8 0 q9
+gd q11 sqrt q10
+gd idx */ q11 w q7
+gd maxAdj3 */ 100000 idx ss
+gd a3 pin 0 adj3 maxAdj3
+gd ah */ ss a3 100000
+gd y3 +- hR th 0
+gd q2 */ w w 1
+gd q3 */ ah ah 1
+gd q4 +- q2 0 q3
+gd q5 sqrt q4
+gd dy */ q5 hR w
+gd y5 +- hR dy 0
+gd y7 +- y3 dy 0
+gd q6 +- aw 0 th
+gd dh */ q6 1 2
+gd y4 +- y5 0 dh
+gd y8 +- y7 dh 0
+gd aw2 */ aw 1 2
+gd y6 +- b 0 aw2
+gd x1 +- r 0 ah
+gd swAng at2 ah dy
+gd stAng +- cd2 0 swAng
+gd mswAng +- 0 0 swAng
+gd ix +- r 0 idx
+gd iy +/ hR y3 2
+gd q12 */ th 1 2
+gd dang2 at2 idx q12
+gd stAng2 +- cd2 dang2 0
+gd swAng2 +- cd4 0 dang2
+gd swAng3 +- -5400000 0 dang2
+path fill=darkenLess stroke=false
+M ix iy
+A w hR stAng2 swAng2
+L r t
+A w hR 3cd4 swAng3
+Z
+path stroke=false
+M r y6
+L x1 y4
+L x1 y5
+A w hR stAng swAng
+L l y3
+A w hR cd2 mswAng
+L x1 y8
+Z
+path fill=none
+M ix iy
+A w hR stAng2 swAng2
+L r t
+A w hR 3cd4 -5400000
+L l y3
+A w hR cd2 mswAng
+L x1 y8
+L r y6
+L x1 y4
+L x1 y5
+A w hR stAng swAng
+`,
+	"curvedLeftArrow": `
+av adj1 25000
+av adj2 50000
+av adj3 25000
+gd maxAdj2 */ 50000 h ss
+gd a2 pin 0 adj2 maxAdj2
+gd a1 pin 0 adj1 a2
+gd th */ ss a1 100000
+gd aw */ ss a2 100000
+gd q1 +/ th aw 4
+gd hR +- hd2 0 q1
+gd q7 */ hR 2 1
+gd q8 */ q7 q7 1
+gd q9 */ th th 1
+gd q10 +- q8 0 q9
+gd q11 sqrt q10
+gd idx */ q11 w q7
+gd maxAdj3 */ 100000 idx ss
+gd a3 pin 0 adj3 maxAdj3
+gd ah */ ss a3 100000
+gd y3 +- hR th 0
+gd q2 */ w w 1
+gd q3 */ ah ah 1
+gd q4 +- q2 0 q3
+gd q5 sqrt q4
+gd dy */ q5 hR w
+gd y5 +- hR dy 0
+gd y7 +- y3 dy 0
+gd q6 +- aw 0 th
+gd dh */ q6 1 2
+gd y4 +- y5 0 dh
+gd y8 +- y7 dh 0
+gd aw2 */ aw 1 2
+gd y6 +- b 0 aw2
+gd x1 +- l ah 0
+gd swAng at2 ah dy
+gd mswAng +- 0 0 swAng
+gd ix +- l idx 0
+gd iy +/ hR y3 2
+gd q12 */ th 1 2
+gd dang2 at2 idx q12
+gd stAng2 +- 0 0 dang2
+gd swAng2 +- dang2 0 cd4
+gd swAng3 +- cd4 dang2 0
+path fill=darkenLess stroke=false
+M ix iy
+A w hR stAng2 swAng2
+L l t
+A w hR 3cd4 swAng3
+Z
+path stroke=false
+M l y6
+L x1 y4
+L x1 y5
+A w hR swAng mswAng
+L r y3
+A w hR 0 swAng
+L x1 y8
+Z
+path fill=none
+M ix iy
+A w hR stAng2 swAng2
+L l t
+A w hR 3cd4 cd4
+L r y3
+A w hR 0 swAng
+L x1 y8
+L l y6
+L x1 y4
+L x1 y5
+A w hR swAng mswAng
+`,
+	"curvedDownArrow": `
+av adj1 25000
+av adj2 50000
+av adj3 25000
+gd maxAdj2 */ 50000 w ss
+gd a2 pin 0 adj2 maxAdj2
+gd a1 pin 0 adj1 a2
+gd th */ ss a1 100000
+gd aw */ ss a2 100000
+gd q1 +/ th aw 4
+gd wR +- wd2 0 q1
+gd q7 */ wR 2 1
+gd q8 */ q7 q7 1
+gd q9 */ th th 1
+gd q10 +- q8 0 q9
+gd q11 sqrt q10
+gd idy */ q11 h q7
+gd maxAdj3 */ 100000 idy ss
+gd a3 pin 0 adj3 maxAdj3
+gd ah */ ss a3 100000
+gd x3 +- wR th 0
+gd q2 */ h h 1
+gd q3 */ ah ah 1
+gd q4 +- q2 0 q3
+gd q5 sqrt q4
+gd dx */ q5 wR h
+gd x5 +- wR dx 0
+gd x7 +- x3 dx 0
+gd q6 +- aw 0 th
+gd dh */ q6 1 2
+gd x4 +- x5 0 dh
+gd x8 +- x7 dh 0
+gd aw2 */ aw 1 2
+gd x6 +- r 0 aw2
+gd y1 +- b 0 ah
+gd swAng at2 ah dx
+gd mswAng +- 0 0 swAng
+gd iy +- b 0 idy
+gd ix +/ wR x3 2
+gd q12 */ th 1 2
+gd dang2 at2 idy q12
+gd stAng +- 3cd4 swAng 0
+gd stAng2 +- 3cd4 0 dang2
+gd swAng2 +- dang2 0 cd4
+gd swAng3 +- cd4 dang2 0
+path fill=darkenLess stroke=false
+M ix iy
+A wR h stAng2 swAng2
+L l b
+A wR h cd2 swAng3
+Z
+path stroke=false
+M x6 b
+L x4 y1
+L x5 y1
+A wR h stAng mswAng
+L x3 t
+A wR h 3cd4 swAng
+L x8 y1
+Z
+path fill=none
+M ix iy
+A wR h stAng2 swAng2
+L l b
+A wR h cd2 cd4
+L x3 t
+A wR h 3cd4 swAng
+L x8 y1
+L x6 b
+L x4 y1
+L x5 y1
+A wR h stAng mswAng
+`,
+	"curvedUpArrow": `
+av adj1 25000
+av adj2 50000
+av adj3 25000
+gd maxAdj2 */ 50000 w ss
+gd a2 pin 0 adj2 maxAdj2
+gd a1 pin 0 adj1 a2
+gd th */ ss a1 100000
+gd aw */ ss a2 100000
+gd q1 +/ th aw 4
+gd wR +- wd2 0 q1
+gd q7 */ wR 2 1
+gd q8 */ q7 q7 1
+gd q9 */ th th 1
+gd q10 +- q8 0 q9
+gd q11 sqrt q10
+gd idy */ q11 h q7
+gd maxAdj3 */ 100000 idy ss
+gd a3 pin 0 adj3 maxAdj3
+gd ah */ ss a3 100000
+gd x3 +- wR th 0
+gd q2 */ h h 1
+gd q3 */ ah ah 1
+gd q4 +- q2 0 q3
+gd q5 sqrt q4
+gd dx */ q5 wR h
+gd x5 +- wR dx 0
+gd x7 +- x3 dx 0
+gd q6 +- aw 0 th
+gd dh */ q6 1 2
+gd x4 +- x5 0 dh
+gd x8 +- x7 dh 0
+gd aw2 */ aw 1 2
+gd x6 +- r 0 aw2
+gd y1 +- t ah 0
+gd swAng at2 ah dx
+gd mswAng +- 0 0 swAng
+gd iy +- t idy 0
+gd ix +/ wR x3 2
+gd q12 */ th 1 2
+gd dang2 at2 idy q12
+gd stAng +- cd4 0 swAng
+gd stAng2 +- cd4 dang2 0
+gd swAng2 +- cd4 0 dang2
+gd swAng3 +- -5400000 0 dang2
+path fill=darkenLess stroke=false
+M ix iy
+A wR h stAng2 swAng2
+L l t
+A wR h cd2 swAng3
+Z
+path stroke=false
+M x6 t
+L x4 y1
+L x5 y1
+A wR h stAng swAng
+L x3 b
+A wR h cd4 mswAng
+L x8 y1
+Z
+path fill=none
+M ix iy
+A wR h stAng2 swAng2
+L l t
+A wR h cd2 -5400000
+L x3 b
+A wR h cd4 mswAng
+L x8 y1
+L x6 t
+L x4 y1
+L x5 y1
+A wR h stAng swAng
+`,
+	"circularArrow": `
+av adj1 12500
+av adj2 1142319
+av adj3 20457681
+av adj4 10800000
+av adj5 12500
+gd a5 pin 0 adj5 25000
+gd maxAdj1 */ a5 2 1
+gd a1 pin 0 adj1 maxAdj1
+gd th */ ss a1 100000
+gd thh */ ss a5 100000
+gd th2 */ th 1 2
+gd rw1 +- wd2 0 thh
+gd rh1 +- hd2 0 thh
+gd rw2 +- rw1 th2 0
+gd rh2 +- rh1 th2 0
+gd rw3 +- rw1 0 th2
+gd rh3 +- rh1 0 th2
+gd rw4 +- rw1 thh 0
+gd rh4 +- rh1 thh 0
+gd rw5 +- rw1 0 thh
+gd rh5 +- rh1 0 thh
+gd a2 pin -5400000 adj2 5400000
+gd enAng pin 0 adj3 21599999
+gd stAng pin 0 adj4 21599999
+gd ptAng +- enAng a2 0
+gd sw0 +- enAng 0 stAng
+gd sw1 +- sw0 21600000 0
+gd swP ?: sw0 sw0 sw1
+gd sw2 +- sw0 0 21600000
+gd swN ?: sw0 sw2 sw0
+gd sw ?: a2 swP swN
+gd msw +- 0 0 sw
+gd o0c cos rh2 stAng
+gd o0s sin rw2 stAng
+gd o0m mod o0c o0s 0
+gd o0dx */ rw2 o0c o0m
+gd o0dy */ rh2 o0s o0m
+gd o0x +- hc o0dx 0
+gd o0y +- vc o0dy 0
+gd g1c cos rh4 enAng
+gd g1s sin rw4 enAng
+gd g1m mod g1c g1s 0
+gd g1dx */ rw4 g1c g1m
+gd g1dy */ rh4 g1s g1m
+gd g1x +- hc g1dx 0
+gd g1y +- vc g1dy 0
+gd t1c cos rh1 ptAng
+gd t1s sin rw1 ptAng
+gd t1m mod t1c t1s 0
+gd t1dx */ rw1 t1c t1m
+gd t1dy */ rh1 t1s t1m
+gd t1x +- hc t1dx 0
+gd t1y +- vc t1dy 0
+gd e1c cos rh5 enAng
+gd e1s sin rw5 enAng
+gd e1m mod e1c e1s 0
+gd e1dx */ rw5 e1c e1m
+gd e1dy */ rh5 e1s e1m
+gd e1x +- hc e1dx 0
+gd e1y +- vc e1dy 0
+gd i1c cos rh3 enAng
+gd i1s sin rw3 enAng
+gd i1m mod i1c i1s 0
+gd i1dx */ rw3 i1c i1m
+gd i1dy */ rh3 i1s i1m
+gd i1x +- hc i1dx 0
+gd i1y +- vc i1dy 0
+path
+M o0x o0y
+A rw2 rh2 stAng sw
+L g1x g1y
+L t1x t1y
+L e1x e1y
+L i1x i1y
+A rw3 rh3 enAng msw
+Z
+`,
+	"leftCircularArrow": `
+av adj1 12500
+av adj2 -1142319
+av adj3 1142319
+av adj4 10800000
+av adj5 12500
+gd a5 pin 0 adj5 25000
+gd maxAdj1 */ a5 2 1
+gd a1 pin 0 adj1 maxAdj1
+gd th */ ss a1 100000
+gd thh */ ss a5 100000
+gd th2 */ th 1 2
+gd rw1 +- wd2 0 thh
+gd rh1 +- hd2 0 thh
+gd rw2 +- rw1 th2 0
+gd rh2 +- rh1 th2 0
+gd rw3 +- rw1 0 th2
+gd rh3 +- rh1 0 th2
+gd rw4 +- rw1 thh 0
+gd rh4 +- rh1 thh 0
+gd rw5 +- rw1 0 thh
+gd rh5 +- rh1 0 thh
+gd a2 pin -5400000 adj2 5400000
+gd enAng pin 0 adj3 21599999
+gd stAng pin 0 adj4 21599999
+gd ptAng +- enAng a2 0
+gd sw0 +- enAng 0 stAng
+gd sw1 +- sw0 21600000 0
+gd swP ?: sw0 sw0 sw1
+gd sw2 +- sw0 0 21600000
+gd swN ?: sw0 sw2 sw0
+gd sw ?: a2 swP swN
+gd msw +- 0 0 sw
+gd o0c cos rh2 stAng
+gd o0s sin rw2 stAng
+gd o0m mod o0c o0s 0
+gd o0dx */ rw2 o0c o0m
+gd o0dy */ rh2 o0s o0m
+gd o0x +- hc o0dx 0
+gd o0y +- vc o0dy 0
+gd g1c cos rh4 enAng
+gd g1s sin rw4 enAng
+gd g1m mod g1c g1s 0
+gd g1dx */ rw4 g1c g1m
+gd g1dy */ rh4 g1s g1m
+gd g1x +- hc g1dx 0
+gd g1y +- vc g1dy 0
+gd t1c cos rh1 ptAng
+gd t1s sin rw1 ptAng
+gd t1m mod t1c t1s 0
+gd t1dx */ rw1 t1c t1m
+gd t1dy */ rh1 t1s t1m
+gd t1x +- hc t1dx 0
+gd t1y +- vc t1dy 0
+gd e1c cos rh5 enAng
+gd e1s sin rw5 enAng
+gd e1m mod e1c e1s 0
+gd e1dx */ rw5 e1c e1m
+gd e1dy */ rh5 e1s e1m
+gd e1x +- hc e1dx 0
+gd e1y +- vc e1dy 0
+gd i1c cos rh3 enAng
+gd i1s sin rw3 enAng
+gd i1m mod i1c i1s 0
+gd i1dx */ rw3 i1c i1m
+gd i1dy */ rh3 i1s i1m
+gd i1x +- hc i1dx 0
+gd i1y +- vc i1dy 0
+path
+M o0x o0y
+A rw2 rh2 stAng sw
+L g1x g1y
+L t1x t1y
+L e1x e1y
+L i1x i1y
+A rw3 rh3 enAng msw
+Z
+`,
+	"leftRightCircularArrow": `
+av adj1 12500
+av adj2 1142319
+av adj3 20457681
+av adj4 11942319
+av adj5 12500
+gd a5 pin 0 adj5 25000
+gd maxAdj1 */ a5 2 1
+gd a1 pin 0 adj1 maxAdj1
+gd th */ ss a1 100000
+gd thh */ ss a5 100000
+gd th2 */ th 1 2
+gd rw1 +- wd2 0 thh
+gd rh1 +- hd2 0 thh
+gd rw2 +- rw1 th2 0
+gd rh2 +- rh1 th2 0
+gd rw3 +- rw1 0 th2
+gd rh3 +- rh1 0 th2
+gd rw4 +- rw1 thh 0
+gd rh4 +- rh1 thh 0
+gd rw5 +- rw1 0 thh
+gd rh5 +- rh1 0 thh
+gd a2 pin -5400000 adj2 5400000
+gd enAng pin 0 adj3 21599999
+gd stAng pin 0 adj4 21599999
+gd ptAng +- enAng a2 0
+gd sw0 +- enAng 0 stAng
+gd sw1 +- sw0 21600000 0
+gd swP ?: sw0 sw0 sw1
+gd sw2 +- sw0 0 21600000
+gd swN ?: sw0 sw2 sw0
+gd sw ?: a2 swP swN
+gd msw +- 0 0 sw
+gd o0c cos rh2 stAng
+gd o0s sin rw2 stAng
+gd o0m mod o0c o0s 0
+gd o0dx */ rw2 o0c o0m
+gd o0dy */ rh2 o0s o0m
+gd o0x +- hc o0dx 0
+gd o0y +- vc o0dy 0
+gd g1c cos rh4 enAng
+gd g1s sin rw4 enAng
+gd g1m mod g1c g1s 0
+gd g1dx */ rw4 g1c g1m
+gd g1dy */ rh4 g1s g1m
+gd g1x +- hc g1dx 0
+gd g1y +- vc g1dy 0
+gd t1c cos rh1 ptAng
+gd t1s sin rw1 ptAng
+gd t1m mod t1c t1s 0
+gd t1dx */ rw1 t1c t1m
+gd t1dy */ rh1 t1s t1m
+gd t1x +- hc t1dx 0
+gd t1y +- vc t1dy 0
+gd e1c cos rh5 enAng
+gd e1s sin rw5 enAng
+gd e1m mod e1c e1s 0
+gd e1dx */ rw5 e1c e1m
+gd e1dy */ rh5 e1s e1m
+gd e1x +- hc e1dx 0
+gd e1y +- vc e1dy 0
+gd i1c cos rh3 enAng
+gd i1s sin rw3 enAng
+gd i1m mod i1c i1s 0
+gd i1dx */ rw3 i1c i1m
+gd i1dy */ rh3 i1s i1m
+gd i1x +- hc i1dx 0
+gd i1y +- vc i1dy 0
+gd pt0Ang +- stAng 0 a2
+gd e0c cos rh5 stAng
+gd e0s sin rw5 stAng
+gd e0m mod e0c e0s 0
+gd e0dx */ rw5 e0c e0m
+gd e0dy */ rh5 e0s e0m
+gd e0x +- hc e0dx 0
+gd e0y +- vc e0dy 0
+gd t0c cos rh1 pt0Ang
+gd t0s sin rw1 pt0Ang
+gd t0m mod t0c t0s 0
+gd t0dx */ rw1 t0c t0m
+gd t0dy */ rh1 t0s t0m
+gd t0x +- hc t0dx 0
+gd t0y +- vc t0dy 0
+gd g0c cos rh4 stAng
+gd g0s sin rw4 stAng
+gd g0m mod g0c g0s 0
+gd g0dx */ rw4 g0c g0m
+gd g0dy */ rh4 g0s g0m
+gd g0x +- hc g0dx 0
+gd g0y +- vc g0dy 0
+path
+M o0x o0y
+A rw2 rh2 stAng sw
+L g1x g1y
+L t1x t1y
+L e1x e1y
+L i1x i1y
+A rw3 rh3 enAng msw
+L e0x e0y
+L t0x t0y
+L g0x g0y
+Z
+`,
+	"swooshArrow": `
+av adj1 25000
+av adj2 16667
+gd a1 pin 1 adj1 75000
+gd maxAdj2 */ 70000 w ss
+gd a2 pin 0 adj2 maxAdj2
+gd ad1 */ h a1 100000
+gd ad2 */ ss a2 100000
+gd xB +- r 0 ad2
+gd yB +- t ssd8 0
+gd alfa */ cd4 1 14
+gd dx0 tan ssd8 alfa
+gd xC +- xB 0 dx0
+gd dx1 tan ad1 alfa
+gd yF +- yB ad1 0
+gd xF +- xB dx1 0
+gd xE +- xF dx0 0
+gd yE +- yF ssd8 0
+gd dy2 +- yE 0 t
+gd dy22 */ dy2 1 2
+gd dy3 */ h 1 20
+gd yD +- t dy22 dy3
+gd dy4 */ hd6 1 1
+gd yP1 +- hd6 dy4 0
+gd xP1 val wd6
+gd dy5 */ hd6 1 2
+gd yP2 +- yF dy5 0
+gd xP2 val wd4
+path
+M l b
+Q xP1 yP1 xB yB
+L xC t
+L r yD
+L xE yE
+L xF yF
+Q xP2 yP2 l b
+Z
+`,
+	"plus": `
+av adj 25000
+gd a pin 0 adj 50000
+gd x1 */ ss a 100000
+gd x2 +- r 0 x1
+gd y2 +- b 0 x1
+path
+M l x1
+L x1 x1
+L x1 t
+L x2 t
+L x2 x1
+L r x1
+L r y2
+L x2 y2
+L x2 b
+L x1 b
+L x1 y2
+L l y2
+Z
+`,
+	"cube": `
+av adj 25000
+gd a pin 0 adj 100000
+gd y1 */ ss a 100000
+gd y4 +- b 0 y1
+gd x4 +- r 0 y1
+path stroke=false
+M l y1
+L x4 y1
+L x4 b
+L l b
+Z
+path fill=darkenLess stroke=false
+M x4 y1
+L r t
+L r y4
+L x4 b
+Z
+path fill=lightenLess stroke=false
+M l y1
+L y1 t
+L r t
+L x4 y1
+Z
+path fill=none
+M l y1
+L y1 t
+L r t
+L r y4
+L x4 b
+L l b
+Z
+M l y1
+L x4 y1
+L r t
+M x4 y1
+L x4 b
+`,
+	"can": `
+av adj 25000
+gd maxAdj */ 50000 h ss
+gd a pin 0 adj maxAdj
+gd y1 */ ss a 200000
+gd y3 +- b 0 y1
+path stroke=false
+M l y1
+A wd2 y1 cd2 -10800000
+L r y3
+A wd2 y1 0 cd2
+Z
+path fill=lighten stroke=false
+M l y1
+A wd2 y1 cd2 cd2
+A wd2 y1 0 cd2
+Z
+path fill=none
+M r y1
+A wd2 y1 0 cd2
+A wd2 y1 cd2 cd2
+L r y3
+A wd2 y1 0 cd2
+L l y1
+`,
+	"lightningBolt": `
+path w=21600 h=21600
+M 8472 0
+L 12860 6080
+L 11050 6797
+L 16577 12007
+L 14767 12877
+L 21600 21600
+L 10012 14915
+L 12222 13987
+L 5022 9705
+L 7602 8382
+L 0 3890
+Z
+`,
+	"heart": `
+gd dx1 */ w 49 48
+gd dx2 */ w 10 48
+gd x1 +- hc 0 dx1
+gd x2 +- hc 0 dx2
+gd x3 +- hc dx2 0
+gd x4 +- hc dx1 0
+gd y1 +- t 0 hd3
+path
+M hc hd4
+C x3 y1 x4 hd4 hc b
+C x1 hd4 x2 y1 hc hd4
+Z
+`,
+	"sun": `
+av adj 25000
+gd a pin 12500 adj 46875
+gd g0 +- 50000 0 a
+gd ga */ a 1 4
+gd rb +- g0 ga 0
+gd wb */ w rb 100000
+gd hb */ h rb 100000
+gd x19 */ w a 100000
+gd wR */ w g0 100000
+gd hR */ h g0 100000
+gd dx00 cos wd2 0
+gd dy00 sin hd2 0
+gd x00 +- hc dx00 0
+gd y00 +- vc dy00 0
+gd dx01 cos wb 600000
+gd dy01 sin hb 600000
+gd x01 +- hc dx01 0
+gd y01 +- vc dy01 0
+gd dx02 cos wb 21000000
+gd dy02 sin hb 21000000
+gd x02 +- hc dx02 0
+gd y02 +- vc dy02 0
+gd dx10 cos wd2 2700000
+gd dy10 sin hd2 2700000
+gd x10 +- hc dx10 0
+gd y10 +- vc dy10 0
+gd dx11 cos wb 3300000
+gd dy11 sin hb 3300000
+gd x11 +- hc dx11 0
+gd y11 +- vc dy11 0
+gd dx12 cos wb 2100000
+gd dy12 sin hb 2100000
+gd x12 +- hc dx12 0
+gd y12 +- vc dy12 0
+gd dx20 cos wd2 5400000
+gd dy20 sin hd2 5400000
+gd x20 +- hc dx20 0
+gd y20 +- vc dy20 0
+gd dx21 cos wb 6000000
+gd dy21 sin hb 6000000
+gd x21 +- hc dx21 0
+gd y21 +- vc dy21 0
+gd dx22 cos wb 4800000
+gd dy22 sin hb 4800000
+gd x22 +- hc dx22 0
+gd y22 +- vc dy22 0
+gd dx30 cos wd2 8100000
+gd dy30 sin hd2 8100000
+gd x30 +- hc dx30 0
+gd y30 +- vc dy30 0
+gd dx31 cos wb 8700000
+gd dy31 sin hb 8700000
+gd x31 +- hc dx31 0
+gd y31 +- vc dy31 0
+gd dx32 cos wb 7500000
+gd dy32 sin hb 7500000
+gd x32 +- hc dx32 0
+gd y32 +- vc dy32 0
+gd dx40 cos wd2 10800000
+gd dy40 sin hd2 10800000
+gd x40 +- hc dx40 0
+gd y40 +- vc dy40 0
+gd dx41 cos wb 11400000
+gd dy41 sin hb 11400000
+gd x41 +- hc dx41 0
+gd y41 +- vc dy41 0
+gd dx42 cos wb 10200000
+gd dy42 sin hb 10200000
+gd x42 +- hc dx42 0
+gd y42 +- vc dy42 0
+gd dx50 cos wd2 13500000
+gd dy50 sin hd2 13500000
+gd x50 +- hc dx50 0
+gd y50 +- vc dy50 0
+gd dx51 cos wb 14100000
+gd dy51 sin hb 14100000
+gd x51 +- hc dx51 0
+gd y51 +- vc dy51 0
+gd dx52 cos wb 12900000
+gd dy52 sin hb 12900000
+gd x52 +- hc dx52 0
+gd y52 +- vc dy52 0
+gd dx60 cos wd2 16200000
+gd dy60 sin hd2 16200000
+gd x60 +- hc dx60 0
+gd y60 +- vc dy60 0
+gd dx61 cos wb 16800000
+gd dy61 sin hb 16800000
+gd x61 +- hc dx61 0
+gd y61 +- vc dy61 0
+gd dx62 cos wb 15600000
+gd dy62 sin hb 15600000
+gd x62 +- hc dx62 0
+gd y62 +- vc dy62 0
+gd dx70 cos wd2 18900000
+gd dy70 sin hd2 18900000
+gd x70 +- hc dx70 0
+gd y70 +- vc dy70 0
+gd dx71 cos wb 19500000
+gd dy71 sin hb 19500000
+gd x71 +- hc dx71 0
+gd y71 +- vc dy71 0
+gd dx72 cos wb 18300000
+gd dy72 sin hb 18300000
+gd x72 +- hc dx72 0
+gd y72 +- vc dy72 0
+path
+M x00 y00
+L x01 y01
+L x02 y02
+Z
+M x10 y10
+L x11 y11
+L x12 y12
+Z
+M x20 y20
+L x21 y21
+L x22 y22
+Z
+M x30 y30
+L x31 y31
+L x32 y32
+Z
+M x40 y40
+L x41 y41
+L x42 y42
+Z
+M x50 y50
+L x51 y51
+L x52 y52
+Z
+M x60 y60
+L x61 y61
+L x62 y62
+Z
+M x70 y70
+L x71 y71
+L x72 y72
+Z
+M x19 vc
+A wR hR cd2 21600000
+Z
+`,
+	"moon": `
+av adj 50000
+gd a pin 0 adj 87500
+gd g0 */ ss a 100000
+gd g0w */ g0 w ss
+gd iw +- w 0 g0w
+path
+M r b
+A w hd2 cd4 cd2
+A iw hd2 3cd4 -10800000
+Z
+`,
+	"smileyFace": `
+av adj 4653
+gd a pin -4653 adj 4653
+gd x1 */ w 4969 21699
+gd x2 */ w 6215 21600
+gd x3 */ w 13135 21600
+gd x4 */ w 16640 21600
+gd y1 */ h 7570 21600
+gd y3 */ h 16515 21600
+gd dy2 */ h a 100000
+gd y2 +- y3 0 dy2
+gd y4 +- y3 dy2 0
+gd dy3 */ h a 50000
+gd y5 +- y4 dy3 0
+gd wR */ w 1323 21600
+gd hR */ h 1323 21600
+gd x5 +- x2 0 wR
+gd x6 +- x3 0 wR
+path stroke=false
+M l vc
+A wd2 hd2 cd2 21600000
+Z
+path fill=darkenLess
+M x5 y1
+A wR hR cd2 21600000
+Z
+M x6 y1
+A wR hR cd2 21600000
+Z
+path fill=none
+M x1 y2
+Q hc y5 x4 y2
+path fill=none
+M l vc
+A wd2 hd2 cd2 21600000
+Z
+`,
+	"irregularSeal1": `
+path w=21600 h=21600
+M 10800 5800
+L 14522 0
+L 14155 5325
+L 18380 4457
+L 16702 7315
+L 21097 8137
+L 17607 10475
+L 21600 13290
+L 16837 12942
+L 18145 18095
+L 14020 14457
+L 13247 19737
+L 10532 14935
+L 8485 21600
+L 7715 15627
+L 4762 17617
+L 5667 13937
+L 135 14587
+L 3722 11775
+L 0 8615
+L 4627 7617
+L 370 2295
+L 7312 6320
+L 8352 2295
+Z
+`,
+	"irregularSeal2": `
+path w=21600 h=21600
+M 11462 4342
+L 14790 0
+L 14525 5777
+L 18007 3172
+L 16380 6532
+L 21600 6645
+L 16985 9402
+L 18270 11290
+L 16380 12310
+L 18877 15632
+L 14640 14350
+L 14942 17370
+L 12180 15935
+L 11612 18842
+L 9872 17370
+L 8700 19712
+L 7527 18125
+L 4917 21600
+L 4805 18240
+L 1285 17825
+L 3330 15370
+L 0 12877
+L 3935 11592
+L 1172 8270
+L 5372 7817
+L 4502 3625
+L 8550 6382
+L 9722 1887
+Z
+`,
+	"foldedCorner": `
+av adj 16667
+gd a pin 0 adj 50000
+gd dy2 */ ss a 100000
+gd dy1 */ dy2 1 5
+gd x1 +- r 0 dy2
+gd x2 +- x1 dy1 0
+gd y2 +- b 0 dy2
+gd y1 +- y2 dy1 0
+path stroke=false
+M l t
+L r t
+L r y2
+L x1 b
+L l b
+Z
+path fill=darkenLess stroke=false
+M x1 b
+L x2 y1
+L r y2
+Z
+path fill=none
+M x1 b
+L x2 y1
+L r y2
+L x1 b
+L l b
+L l t
+L r t
+L r y2
+`,
+	"bevel": `
+av adj 12500
+gd a pin 0 adj 50000
+gd x1 */ ss a 100000
+gd x2 +- r 0 x1
+gd y2 +- b 0 x1
+path stroke=false
+M x1 x1
+L x2 x1
+L x2 y2
+L x1 y2
+Z
+path fill=lightenLess stroke=false
+M l t
+L r t
+L x2 x1
+L x1 x1
+Z
+path fill=darkenLess stroke=false
+M l b
+L x1 y2
+L x2 y2
+L r b
+Z
+path fill=lighten stroke=false
+M l t
+L x1 x1
+L x1 y2
+L l b
+Z
+path fill=darken stroke=false
+M r t
+L r b
+L x2 y2
+L x2 x1
+Z
+path fill=none
+M l t
+L r t
+L r b
+L l b
+Z
+M x1 x1
+L x2 x1
+L x2 y2
+L x1 y2
+Z
+M l t
+L x1 x1
+M l b
+L x1 y2
+M r t
+L x2 x1
+M r b
+L x2 y2
+`,
+	"frame": `
+av adj1 12500
+gd a1 pin 0 adj1 50000
+gd x1 */ ss a1 100000
+gd x4 +- r 0 x1
+gd y4 +- b 0 x1
+path
+M l t
+L r t
+L r b
+L l b
+Z
+M x1 x1
+L x1 y4
+L x4 y4
+L x4 x1
+Z
+`,
+	"halfFrame": `
+av adj1 33333
+av adj2 33333
+gd maxAdj2 */ 100000 w ss
+gd a2 pin 0 adj2 maxAdj2
+gd x1 */ ss a2 100000
+gd g1 */ h x1 w
+gd g2 +- h 0 g1
+gd maxAdj1 */ 100000 g2 ss
+gd a1 pin 0 adj1 maxAdj1
+gd y1 */ ss a1 100000
+gd dx2 */ y1 w h
+gd x2 +- r 0 dx2
+gd dy2 */ x1 h w
+gd y2 +- b 0 dy2
+path
+M l t
+L r t
+L x2 y1
+L x1 y1
+L x1 y2
+L l b
+Z
+`,
+	"corner": `
+av adj1 50000
+av adj2 50000
+gd maxAdj1 */ 100000 h ss
+gd maxAdj2 */ 100000 w ss
+gd a1 pin 0 adj1 maxAdj1
+gd a2 pin 0 adj2 maxAdj2
+gd x1 */ ss a2 100000
+gd dy1 */ ss a1 100000
+gd y1 +- b 0 dy1
+path
+M l t
+L x1 t
+L x1 y1
+L r y1
+L r b
+L l b
+Z
+`,
+	"diagStripe": `
+av adj 50000
+gd a pin 0 adj 100000
+gd x2 */ w a 100000
+gd y2 */ h a 100000
+path
+M l y2
+L x2 t
+L r t
+L l b
+Z
+`,
+	"leftBracket": `
+av adj 8333
+gd maxAdj */ 50000 h ss
+gd a pin 0 adj maxAdj
+gd y1 */ ss a 100000
+path stroke=false
+M r b
+A w y1 cd4 cd4
+L l y1
+A w y1 cd2 cd4
+Z
+path fill=none
+M r b
+A w y1 cd4 cd4
+L l y1
+A w y1 cd2 cd4
+`,
+	"rightBracket": `
+av adj 8333
+gd maxAdj */ 50000 h ss
+gd a pin 0 adj maxAdj
+gd y1 */ ss a 100000
+gd y2 +- b 0 y1
+path stroke=false
+M l t
+A w y1 3cd4 cd4
+L r y2
+A w y1 0 cd4
+Z
+path fill=none
+M l t
+A w y1 3cd4 cd4
+L r y2
+A w y1 0 cd4
+`,
+	"leftBrace": `
+av adj1 8333
+av adj2 50000
+gd a2 pin 0 adj2 100000
+gd q1 +- 100000 0 a2
+gd q2 min q1 a2
+gd q3 */ q2 1 2
+gd maxAdj1 */ q3 h ss
+gd a1 pin 0 adj1 maxAdj1
+gd y1 */ ss a1 100000
+gd y3 */ h a2 100000
+gd y4 +- y3 y1 0
+path stroke=false
+M r b
+A wd2 y1 cd4 cd4
+L hc y4
+A wd2 y1 0 -5400000
+A wd2 y1 cd4 -5400000
+L hc y1
+A wd2 y1 cd2 cd4
+Z
+path fill=none
+M r b
+A wd2 y1 cd4 cd4
+L hc y4
+A wd2 y1 0 -5400000
+A wd2 y1 cd4 -5400000
+L hc y1
+A wd2 y1 cd2 cd4
+`,
+	"rightBrace": `
+av adj1 8333
+av adj2 50000
+gd a2 pin 0 adj2 100000
+gd q1 +- 100000 0 a2
+gd q2 min q1 a2
+gd q3 */ q2 1 2
+gd maxAdj1 */ q3 h ss
+gd a1 pin 0 adj1 maxAdj1
+gd y1 */ ss a1 100000
+gd y3 */ h a2 100000
+gd y2 +- y3 0 y1
+gd y5 +- b 0 y1
+path stroke=false
+M l t
+A wd2 y1 3cd4 cd4
+L hc y2
+A wd2 y1 cd2 -5400000
+A wd2 y1 3cd4 -5400000
+L hc y5
+A wd2 y1 0 cd4
+Z
+path fill=none
+M l t
+A wd2 y1 3cd4 cd4
+L hc y2
+A wd2 y1 cd2 -5400000
+A wd2 y1 3cd4 -5400000
+L hc y5
+A wd2 y1 0 cd4
+`,
+	"bracketPair": `
+av adj 16667
+gd a pin 0 adj 50000
+gd x1 */ ss a 100000
+gd x2 +- r 0 x1
+gd y2 +- b 0 x1
+path stroke=false
+M l x1
+A x1 x1 cd2 cd4
+L x2 t
+A x1 x1 3cd4 cd4
+L r y2
+A x1 x1 0 cd4
+L x1 b
+A x1 x1 cd4 cd4
+Z
+path fill=none
+M x1 b
+A x1 x1 cd4 cd4
+L l x1
+A x1 x1 cd2 cd4
+M x2 t
+A x1 x1 3cd4 cd4
+L r y2
+A x1 x1 0 cd4
+`,
+	"bracePair": `
+av adj 8333
+gd a pin 0 adj 25000
+gd x1 */ ss a 100000
+gd x2 */ ss a 50000
+gd x3 +- r 0 x2
+gd x4 +- r 0 x1
+gd y2 +- vc 0 x1
+gd y3 +- vc x1 0
+gd y4 +- b 0 x1
+path stroke=false
+M x2 b
+A x1 x1 cd4 cd4
+L x1 y3
+A x1 x1 0 -5400000
+A x1 x1 cd4 -5400000
+L x1 x1
+A x1 x1 cd2 cd4
+L x3 t
+A x1 x1 3cd4 cd4
+L x4 y2
+A x1 x1 cd2 -5400000
+A x1 x1 3cd4 -5400000
+L x4 y4
+A x1 x1 0 cd4
+Z
+path fill=none
+M x2 b
+A x1 x1 cd4 cd4
+L x1 y3
+A x1 x1 0 -5400000
+A x1 x1 cd4 -5400000
+L x1 x1
+A x1 x1 cd2 cd4
+M x3 t
+A x1 x1 3cd4 cd4
+L x4 y2
+A x1 x1 cd2 -5400000
+A x1 x1 3cd4 -5400000
+L x4 y4
+A x1 x1 0 cd4
+`,
+	"straightConnector1": `
+path fill=none
+M l t
+L r b
+`,
+	"bentConnector2": `
+path fill=none
+M l t
+L r t
+L r b
+`,
+	"bentConnector3": `
+av adj1 50000
+gd x1 */ w adj1 100000
+path fill=none
+M l t
+L x1 t
+L x1 b
+L r b
+`,
+	"bentConnector4": `
+av adj1 50000
+av adj2 50000
+gd x1 */ w adj1 100000
+gd y2 */ h adj2 100000
+path fill=none
+M l t
+L x1 t
+L x1 y2
+L r y2
+L r b
+`,
+	"bentConnector5": `
+av adj1 50000
+av adj2 50000
+av adj3 50000
+gd x1 */ w adj1 100000
+gd x3 */ w adj3 100000
+gd y2 */ h adj2 100000
+path fill=none
+M l t
+L x1 t
+L x1 y2
+L x3 y2
+L x3 b
+L r b
+`,
+	"curvedConnector2": `
+path fill=none
+M l t
+C wd2 t r hd2 r b
+`,
+	"curvedConnector3": `
+av adj1 50000
+gd x2 */ w adj1 100000
+gd x1 +/ l x2 2
+gd x3 +/ r x2 2
+gd y3 */ h 3 4
+path fill=none
+M l t
+C x1 t x2 hd4 x2 vc
+C x2 y3 x3 b r b
+`,
+	"curvedConnector4": `
+av adj1 50000
+av adj2 50000
+gd x2 */ w adj1 100000
+gd x1 +/ l x2 2
+gd x3 +/ r x2 2
+gd x4 +/ x2 x3 2
+gd x5 +/ x3 r 2
+gd y4 */ h adj2 100000
+gd y1 +/ t y4 2
+gd y2 +/ t y1 2
+gd y3 +/ y1 y4 2
+gd y5 +/ b y4 2
+path fill=none
+M l t
+C x1 t x2 y2 x2 y1
+C x2 y3 x4 y4 x3 y4
+C x5 y4 r y5 r b
+`,
+	"curvedConnector5": `
+av adj1 50000
+av adj2 50000
+av adj3 50000
+gd x3 */ w adj1 100000
+gd x6 */ w adj3 100000
+gd x1 +/ x3 x6 2
+gd x2 +/ l x3 2
+gd x4 +/ x3 x1 2
+gd x5 +/ x6 x1 2
+gd x7 +/ x6 r 2
+gd y4 */ h adj2 100000
+gd y1 +/ t y4 2
+gd y2 +/ t y1 2
+gd y3 +/ y1 y4 2
+gd y5 +/ b y4 2
+gd y6 +/ y5 y4 2
+gd y7 +/ y5 b 2
+path fill=none
+M l t
+C x2 t x3 y2 x3 y1
+C x3 y3 x4 y4 x1 y4
+C x5 y4 x6 y6 x6 y5
+C x6 y7 x7 b r b
+`,
+	"callout1": `
+av adj1 18750
+av adj2 -8333
+av adj3 112500
+av adj4 -38333
+gd y1 */ h adj1 100000
+gd x1 */ w adj2 100000
+gd y2 */ h adj3 100000
+gd x2 */ w adj4 100000
+path stroke=false
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=none
+M x1 y1
+L x2 y2
+`,
+	"callout2": `
+av adj1 18750
+av adj2 -8333
+av adj3 18750
+av adj4 -16667
+av adj5 112500
+av adj6 -46667
+gd y1 */ h adj1 100000
+gd x1 */ w adj2 100000
+gd y2 */ h adj3 100000
+gd x2 */ w adj4 100000
+gd y3 */ h adj5 100000
+gd x3 */ w adj6 100000
+path stroke=false
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=none
+M x1 y1
+L x2 y2
+L x3 y3
+`,
+	"callout3": `
+av adj1 18750
+av adj2 -8333
+av adj3 18750
+av adj4 -16667
+av adj5 100000
+av adj6 -16667
+av adj7 112963
+av adj8 -8333
+gd y1 */ h adj1 100000
+gd x1 */ w adj2 100000
+gd y2 */ h adj3 100000
+gd x2 */ w adj4 100000
+gd y3 */ h adj5 100000
+gd x3 */ w adj6 100000
+gd y4 */ h adj7 100000
+gd x4 */ w adj8 100000
+path stroke=false
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=none
+M x1 y1
+L x2 y2
+L x3 y3
+L x4 y4
+`,
+	"accentCallout1": `
+av adj1 18750
+av adj2 -8333
+av adj3 112500
+av adj4 -38333
+gd y1 */ h adj1 100000
+gd x1 */ w adj2 100000
+gd y2 */ h adj3 100000
+gd x2 */ w adj4 100000
+path stroke=false
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=none
+M x1 t
+L x1 b
+path fill=none
+M x1 y1
+L x2 y2
+`,
+	"accentCallout2": `
+av adj1 18750
+av adj2 -8333
+av adj3 18750
+av adj4 -16667
+av adj5 112500
+av adj6 -46667
+gd y1 */ h adj1 100000
+gd x1 */ w adj2 100000
+gd y2 */ h adj3 100000
+gd x2 */ w adj4 100000
+gd y3 */ h adj5 100000
+gd x3 */ w adj6 100000
+path stroke=false
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=none
+M x1 t
+L x1 b
+path fill=none
+M x1 y1
+L x2 y2
+L x3 y3
+`,
+	"accentCallout3": `
+av adj1 18750
+av adj2 -8333
+av adj3 18750
+av adj4 -16667
+av adj5 100000
+av adj6 -16667
+av adj7 112963
+av adj8 -8333
+gd y1 */ h adj1 100000
+gd x1 */ w adj2 100000
+gd y2 */ h adj3 100000
+gd x2 */ w adj4 100000
+gd y3 */ h adj5 100000
+gd x3 */ w adj6 100000
+gd y4 */ h adj7 100000
+gd x4 */ w adj8 100000
+path stroke=false
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=none
+M x1 t
+L x1 b
+path fill=none
+M x1 y1
+L x2 y2
+L x3 y3
+L x4 y4
+`,
+	"borderCallout1": `
+av adj1 18750
+av adj2 -8333
+av adj3 112500
+av adj4 -38333
+gd y1 */ h adj1 100000
+gd x1 */ w adj2 100000
+gd y2 */ h adj3 100000
+gd x2 */ w adj4 100000
+path
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=none
+M x1 y1
+L x2 y2
+`,
+	"borderCallout2": `
+av adj1 18750
+av adj2 -8333
+av adj3 18750
+av adj4 -16667
+av adj5 112500
+av adj6 -46667
+gd y1 */ h adj1 100000
+gd x1 */ w adj2 100000
+gd y2 */ h adj3 100000
+gd x2 */ w adj4 100000
+gd y3 */ h adj5 100000
+gd x3 */ w adj6 100000
+path
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=none
+M x1 y1
+L x2 y2
+L x3 y3
+`,
+	"borderCallout3": `
+av adj1 18750
+av adj2 -8333
+av adj3 18750
+av adj4 -16667
+av adj5 100000
+av adj6 -16667
+av adj7 112963
+av adj8 -8333
+gd y1 */ h adj1 100000
+gd x1 */ w adj2 100000
+gd y2 */ h adj3 100000
+gd x2 */ w adj4 100000
+gd y3 */ h adj5 100000
+gd x3 */ w adj6 100000
+gd y4 */ h adj7 100000
+gd x4 */ w adj8 100000
+path
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=none
+M x1 y1
+L x2 y2
+L x3 y3
+L x4 y4
+`,
+	"accentBorderCallout1": `
+av adj1 18750
+av adj2 -8333
+av adj3 112500
+av adj4 -38333
+gd y1 */ h adj1 100000
+gd x1 */ w adj2 100000
+gd y2 */ h adj3 100000
+gd x2 */ w adj4 100000
+path
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=none
+M x1 t
+L x1 b
+path fill=none
+M x1 y1
+L x2 y2
+`,
+	"accentBorderCallout2": `
+av adj1 18750
+av adj2 -8333
+av adj3 18750
+av adj4 -16667
+av adj5 112500
+av adj6 -46667
+gd y1 */ h adj1 100000
+gd x1 */ w adj2 100000
+gd y2 */ h adj3 100000
+gd x2 */ w adj4 100000
+gd y3 */ h adj5 100000
+gd x3 */ w adj6 100000
+path
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=none
+M x1 t
+L x1 b
+path fill=none
+M x1 y1
+L x2 y2
+L x3 y3
+`,
+	"accentBorderCallout3": `
+av adj1 18750
+av adj2 -8333
+av adj3 18750
+av adj4 -16667
+av adj5 100000
+av adj6 -16667
+av adj7 112963
+av adj8 -8333
+gd y1 */ h adj1 100000
+gd x1 */ w adj2 100000
+gd y2 */ h adj3 100000
+gd x2 */ w adj4 100000
+gd y3 */ h adj5 100000
+gd x3 */ w adj6 100000
+gd y4 */ h adj7 100000
+gd x4 */ w adj8 100000
+path
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=none
+M x1 t
+L x1 b
+path fill=none
+M x1 y1
+L x2 y2
+L x3 y3
+L x4 y4
+`,
+	"wedgeRectCallout": `
+av adj1 -20833
+av adj2 62500
+gd dxPos */ w adj1 100000
+gd dyPos */ h adj2 100000
+gd xPos +- hc dxPos 0
+gd yPos +- vc dyPos 0
+gd dq */ dxPos h w
+gd ady abs dyPos
+gd adq abs dq
+gd dz +- ady 0 adq
+gd xg1 ?: dxPos 7 2
+gd xg2 ?: dxPos 10 5
+gd x1 */ w xg1 12
+gd x2 */ w xg2 12
+gd yg1 ?: dyPos 7 2
+gd yg2 ?: dyPos 10 5
+gd y1 */ h yg1 12
+gd y2 */ h yg2 12
+gd t1 ?: dxPos l xPos
+gd xl ?: dz l t1
+gd t2 ?: dyPos x1 xPos
+gd xt ?: dz t2 x1
+gd t3 ?: dxPos xPos r
+gd xr ?: dz r t3
+gd t4 ?: dyPos xPos x1
+gd xb ?: dz t4 x1
+gd t5 ?: dxPos y1 yPos
+gd yl ?: dz y1 t5
+gd t6 ?: dyPos t yPos
+gd yt ?: dz t6 t
+gd t7 ?: dxPos yPos y1
+gd yr ?: dz y1 t7
+gd t8 ?: dyPos yPos b
+gd yb ?: dz t8 b
+path
+M l t
+L x1 t
+L xt yt
+L x2 t
+L r t
+L r y1
+L xr yr
+L r y2
+L r b
+L x2 b
+L xb yb
+L x1 b
+L l b
+L l y2
+L xl yl
+L l y1
+Z
+`,
+	"wedgeRoundRectCallout": `
+av adj1 -20833
+av adj2 62500
+av adj3 16667
+gd dxPos */ w adj1 100000
+gd dyPos */ h adj2 100000
+gd xPos +- hc dxPos 0
+gd yPos +- vc dyPos 0
+gd dq */ dxPos h w
+gd ady abs dyPos
+gd adq abs dq
+gd dz +- ady 0 adq
+gd xg1 ?: dxPos 7 2
+gd xg2 ?: dxPos 10 5
+gd x1 */ w xg1 12
+gd x2 */ w xg2 12
+gd yg1 ?: dyPos 7 2
+gd yg2 ?: dyPos 10 5
+gd y1 */ h yg1 12
+gd y2 */ h yg2 12
+gd t1 ?: dxPos l xPos
+gd xl ?: dz l t1
+gd t2 ?: dyPos x1 xPos
+gd xt ?: dz t2 x1
+gd t3 ?: dxPos xPos r
+gd xr ?: dz r t3
+gd t4 ?: dyPos xPos x1
+gd xb ?: dz t4 x1
+gd t5 ?: dxPos y1 yPos
+gd yl ?: dz y1 t5
+gd t6 ?: dyPos t yPos
+gd yt ?: dz t6 t
+gd t7 ?: dxPos yPos y1
+gd yr ?: dz y1 t7
+gd t8 ?: dyPos yPos b
+gd yb ?: dz t8 b
+gd u1 */ ss adj3 100000
+gd u2 +- r 0 u1
+gd v2 +- b 0 u1
+path
+M l u1
+A u1 u1 cd2 cd4
+L x1 t
+L xt yt
+L x2 t
+L u2 t
+A u1 u1 3cd4 cd4
+L r y1
+L xr yr
+L r y2
+L r v2
+A u1 u1 0 cd4
+L x2 b
+L xb yb
+L x1 b
+L u1 b
+A u1 u1 cd4 cd4
+L l y2
+L xl yl
+L l y1
+Z
+`,
+	"wedgeEllipseCallout": `
+av adj1 -20833
+av adj2 62500
+gd dxPos */ w adj1 100000
+gd dyPos */ h adj2 100000
+gd xPos +- hc dxPos 0
+gd yPos +- vc dyPos 0
+gd sdx */ dxPos h 1
+gd sdy */ dyPos w 1
+gd pang at2 sdx sdy
+gd stAng +- pang 660000 0
+gd enAng +- pang 0 660000
+gd dx1 cos wd2 stAng
+gd dy1 sin hd2 stAng
+gd x1 +- hc dx1 0
+gd y1 +- vc dy1 0
+gd dx2 cos wd2 enAng
+gd dy2 sin hd2 enAng
+gd stAng1 at2 dx1 dy1
+gd enAng1 at2 dx2 dy2
+gd swAng1 +- enAng1 0 stAng1
+gd swAng2 +- swAng1 21600000 0
+gd swAng ?: swAng1 swAng1 swAng2
+path
+M xPos yPos
+L x1 y1
+A wd2 hd2 stAng1 swAng
+L xPos yPos
+Z
+`,
+	"cloud": `
+path w=43200 h=43200
+M 3900 14370
+A 6753 9190 -11429249 7426832
+A 5333 7267 -8646143 5396714
+A 4365 5945 -8748475 5983381
+A 4857 6595 -7859164 7034504
+A 5333 7273 -4722533 6541615
+A 6775 9220 -2776035 8522553
+A 5785 7867 37501 6842000
+A 6752 9215 1347096 6910353
+A 7720 10543 3974558 4542661
+A 4360 5918 -16496525 8804134
+A 4345 5945 -14809710 9151131
+Z
+path w=43200 h=43200 fill=none
+M 4693 26177
+A 4345 5945 5204520 1585770
+M 6928 34899
+A 4360 5918 4416628 686848
+M 16478 39090
+A 6752 9215 8257449 844866
+M 28827 34751
+A 6752 9215 387196 959901
+M 34129 22954
+A 5785 7867 -4217541 2836258
+M 29078 3952
+A 4857 6595 -8950887 1091062
+M 22141 4720
+A 4365 5945 -9809656 1061229
+M 14000 5192
+A 6753 9190 -11713008 1145508
+M 4127 15789
+A 6753 9190 -11429249 1568546
+`,
+	"cloudCallout": `
+av adj1 -20833
+av adj2 62500
+gd dxPos */ w adj1 100000
+gd dyPos */ h adj2 100000
+gd xPos +- hc dxPos 0
+gd yPos +- vc dyPos 0
+gd sdx */ dxPos h 1
+gd sdy */ dyPos w 1
+gd pang at2 sdx sdy
+gd edx cos wd2 pang
+gd edy sin hd2 pang
+gd ex +- hc edx 0
+gd ey +- vc edy 0
+gd ddx +- xPos 0 ex
+gd ddy +- yPos 0 ey
+gd r1 */ ss 1 48
+gd r2 */ ss 1 30
+gd r3 */ ss 1 18
+gd c2dx */ ddx 7 10
+gd c2dy */ ddy 7 10
+gd c3dx */ ddx 2 5
+gd c3dy */ ddy 2 5
+gd c1x +- xPos 0 r1
+gd c2x +- ex c2dx r2
+gd c2y +- ey c2dy 0
+gd c3x +- ex c3dx r3
+gd c3y +- ey c3dy 0
+path
+M c1x yPos
+A r1 r1 cd2 21600000
+Z
+path
+M c2x c2y
+A r2 r2 cd2 21600000
+Z
+path
+M c3x c3y
+A r3 r3 cd2 21600000
+Z
+path w=43200 h=43200
+M 3900 14370
+A 6753 9190 -11429249 7426832
+A 5333 7267 -8646143 5396714
+A 4365 5945 -8748475 5983381
+A 4857 6595 -7859164 7034504
+A 5333 7273 -4722533 6541615
+A 6775 9220 -2776035 8522553
+A 5785 7867 37501 6842000
+A 6752 9215 1347096 6910353
+A 7720 10543 3974558 4542661
+A 4360 5918 -16496525 8804134
+A 4345 5945 -14809710 9151131
+Z
+path w=43200 h=43200 fill=none
+M 4693 26177
+A 4345 5945 5204520 1585770
+M 6928 34899
+A 4360 5918 4416628 686848
+M 16478 39090
+A 6752 9215 8257449 844866
+M 28827 34751
+A 6752 9215 387196 959901
+M 34129 22954
+A 5785 7867 -4217541 2836258
+M 29078 3952
+A 4857 6595 -8950887 1091062
+M 22141 4720
+A 4365 5945 -9809656 1061229
+M 14000 5192
+A 6753 9190 -11713008 1145508
+M 4127 15789
+A 6753 9190 -11429249 1568546
+`,
+	"ribbon": `
+av adj1 16667
+av adj2 50000
+gd a1 pin 0 adj1 33333
+gd a2 pin 25000 adj2 75000
+gd x10 +- r 0 wd8
+gd dx2 */ w a2 200000
+gd x2 +- hc 0 dx2
+gd x9 +- hc dx2 0
+gd x3 +- x2 wd32 0
+gd x8 +- x9 0 wd32
+gd x5 +- x2 wd8 0
+gd x6 +- x9 0 wd8
+gd x4 +- x5 0 wd32
+gd x7 +- x6 wd32 0
+gd y1 */ h a1 200000
+gd y2 */ h a1 100000
+gd y4 +- b 0 y2
+gd y3 */ y4 1 2
+gd hR */ h a1 400000
+gd y5 +- b 0 hR
+gd y6 +- y2 0 hR
+path stroke=false
+M l t
+L x4 t
+A wd32 hR 3cd4 cd2
+L x3 y1
+A wd32 hR 3cd4 -10800000
+L x8 y2
+A wd32 hR cd4 -10800000
+L x7 y1
+A wd32 hR cd4 cd2
+L r t
+L x10 y3
+L r y4
+L x9 y4
+L x9 y5
+A wd32 hR 0 cd4
+L x3 b
+A wd32 hR cd4 cd4
+L x2 y4
+L l y4
+L wd8 y3
+Z
+path fill=darkenLess stroke=false
+M x5 hR
+A wd32 hR 0 cd4
+L x3 y1
+A wd32 hR 3cd4 -10800000
+L x5 y2
+Z
+M x6 hR
+A wd32 hR cd2 -5400000
+L x8 y1
+A wd32 hR 3cd4 cd2
+L x6 y2
+Z
+path fill=none
+M l t
+L x4 t
+A wd32 hR 3cd4 cd2
+L x3 y1
+A wd32 hR 3cd4 -10800000
+L x8 y2
+A wd32 hR cd4 -10800000
+L x7 y1
+A wd32 hR cd4 cd2
+L r t
+L x10 y3
+L r y4
+L x9 y4
+L x9 y5
+A wd32 hR 0 cd4
+L x3 b
+A wd32 hR cd4 cd4
+L x2 y4
+L l y4
+L wd8 y3
+Z
+M x5 hR
+L x5 y2
+M x6 y2
+L x6 hR
+M x2 y4
+L x2 y6
+M x9 y6
+L x9 y4
+`,
+	"ribbon2": `
+av adj1 16667
+av adj2 50000
+gd a1 pin 0 adj1 33333
+gd a2 pin 25000 adj2 75000
+gd x10 +- r 0 wd8
+gd dx2 */ w a2 200000
+gd x2 +- hc 0 dx2
+gd x9 +- hc dx2 0
+gd x3 +- x2 wd32 0
+gd x8 +- x9 0 wd32
+gd x5 +- x2 wd8 0
+gd x6 +- x9 0 wd8
+gd x4 +- x5 0 wd32
+gd x7 +- x6 wd32 0
+gd y1 */ h a1 200000
+gd y2 */ h a1 100000
+gd y4 +- b 0 y2
+gd y3 */ y4 1 2
+gd hR */ h a1 400000
+gd y5 +- b 0 hR
+gd y6 +- y2 0 hR
+gd hRf +- b 0 hR
+gd y1f +- b 0 y1
+gd y2f +- b 0 y2
+gd y3f +- b 0 y3
+gd y4f +- b 0 y4
+gd y5f +- b 0 y5
+gd y6f +- b 0 y6
+path stroke=false
+M l b
+L x4 b
+A wd32 hR cd4 -10800000
+L x3 y1f
+A wd32 hR cd4 cd2
+L x8 y2f
+A wd32 hR 3cd4 cd2
+L x7 y1f
+A wd32 hR 3cd4 -10800000
+L r b
+L x10 y3f
+L r y4f
+L x9 y4f
+L x9 y5f
+A wd32 hR 0 -5400000
+L x3 t
+A wd32 hR 3cd4 -5400000
+L x2 y4f
+L l y4f
+L wd8 y3f
+Z
+path fill=darkenLess stroke=false
+M x5 hRf
+A wd32 hR 0 -5400000
+L x3 y1f
+A wd32 hR cd4 cd2
+L x5 y2f
+Z
+M x6 hRf
+A wd32 hR cd2 cd4
+L x8 y1f
+A wd32 hR cd4 -10800000
+L x6 y2f
+Z
+path fill=none
+M l b
+L x4 b
+A wd32 hR cd4 -10800000
+L x3 y1f
+A wd32 hR cd4 cd2
+L x8 y2f
+A wd32 hR 3cd4 cd2
+L x7 y1f
+A wd32 hR 3cd4 -10800000
+L r b
+L x10 y3f
+L r y4f
+L x9 y4f
+L x9 y5f
+A wd32 hR 0 -5400000
+L x3 t
+A wd32 hR 3cd4 -5400000
+L x2 y4f
+L l y4f
+L wd8 y3f
+Z
+M x5 hRf
+L x5 y2f
+M x6 y2f
+L x6 hRf
+M x2 y4f
+L x2 y6f
+M x9 y6f
+L x9 y4f
+`,
+	"ellipseRibbon": `
+av adj1 25000
+av adj2 50000
+av adj3 12500
+gd a1 pin 0 adj1 100000
+gd a2 pin 25000 adj2 75000
+gd q10 +- 100000 0 a1
+gd q11 */ q10 1 2
+gd q12 +- a1 0 q11
+gd minAdj3 max 0 q12
+gd a3 pin minAdj3 adj3 a1
+gd dx2 */ w a2 200000
+gd x2 +- hc 0 dx2
+gd x3 +- x2 wd8 0
+gd x4 +- r 0 x3
+gd x5 +- r 0 x2
+gd x6 +- r 0 wd8
+gd dy1 */ h a3 100000
+gd f1 */ 4 dy1 w
+gd q1 */ x3 x3 w
+gd q2 +- x3 0 q1
+gd y1 */ f1 q2 1
+gd cx1 */ x3 1 2
+gd cy1 */ f1 cx1 1
+gd cx2 +- r 0 cx1
+gd q1 */ h a1 100000
+gd dy3 +- q1 0 dy1
+gd q3 */ x2 x2 w
+gd q4 +- x2 0 q3
+gd q5 */ f1 q4 1
+gd y3 +- q5 dy3 0
+gd q6 +- dy1 dy3 y3
+gd q7 +- q6 dy1 0
+gd cy3 +- q7 dy3 0
+gd rh +- b 0 q1
+gd q8 */ dy1 14 16
+gd y2 +/ q8 rh 2
+gd y5 +- q5 rh 0
+gd y6 +- y3 rh 0
+gd cx4 */ x2 1 2
+gd q9 */ f1 cx4 1
+gd cy4 +- q9 rh 0
+gd cx5 +- r 0 cx4
+gd cy6 +- cy3 rh 0
+gd y7 +- y1 dy3 0
+gd cy7 +- q1 q1 y7
+path stroke=false
+M l t
+Q cx1 cy1 x3 y1
+L x2 y3
+Q hc cy3 x5 y3
+L x4 y1
+Q cx2 cy1 r t
+L x6 y2
+L r rh
+Q cx5 cy4 x5 y5
+L x5 y6
+Q hc cy6 x2 y6
+L x2 y5
+Q cx4 cy4 l rh
+L wd8 y2
+Z
+path fill=darkenLess stroke=false
+M x3 y7
+L x3 y1
+L x2 y3
+Q hc cy3 x5 y3
+L x4 y1
+L x4 y7
+Q hc cy7 x3 y7
+Z
+path fill=none
+M l t
+Q cx1 cy1 x3 y1
+L x2 y3
+Q hc cy3 x5 y3
+L x4 y1
+Q cx2 cy1 r t
+L x6 y2
+L r rh
+Q cx5 cy4 x5 y5
+L x5 y6
+Q hc cy6 x2 y6
+L x2 y5
+Q cx4 cy4 l rh
+L wd8 y2
+Z
+M x2 y5
+L x2 y3
+M x5 y3
+L x5 y5
+M x3 y1
+L x3 y7
+M x4 y7
+L x4 y1
+`,
+	"ellipseRibbon2": `
+av adj1 25000
+av adj2 50000
+av adj3 12500
+gd a1 pin 0 adj1 100000
+gd a2 pin 25000 adj2 75000
+gd q10 +- 100000 0 a1
+gd q11 */ q10 1 2
+gd q12 +- a1 0 q11
+gd minAdj3 max 0 q12
+gd a3 pin minAdj3 adj3 a1
+gd dx2 */ w a2 200000
+gd x2 +- hc 0 dx2
+gd x3 +- x2 wd8 0
+gd x4 +- r 0 x3
+gd x5 +- r 0 x2
+gd x6 +- r 0 wd8
+gd dy1 */ h a3 100000
+gd f1 */ 4 dy1 w
+gd q1 */ x3 x3 w
+gd q2 +- x3 0 q1
+gd y1 */ f1 q2 1
+gd cx1 */ x3 1 2
+gd cy1 */ f1 cx1 1
+gd cx2 +- r 0 cx1
+gd q1 */ h a1 100000
+gd dy3 +- q1 0 dy1
+gd q3 */ x2 x2 w
+gd q4 +- x2 0 q3
+gd q5 */ f1 q4 1
+gd y3 +- q5 dy3 0
+gd q6 +- dy1 dy3 y3
+gd q7 +- q6 dy1 0
+gd cy3 +- q7 dy3 0
+gd rh +- b 0 q1
+gd q8 */ dy1 14 16
+gd y2 +/ q8 rh 2
+gd y5 +- q5 rh 0
+gd y6 +- y3 rh 0
+gd cx4 */ x2 1 2
+gd q9 */ f1 cx4 1
+gd cy4 +- q9 rh 0
+gd cx5 +- r 0 cx4
+gd cy6 +- cy3 rh 0
+gd y7 +- y1 dy3 0
+gd cy7 +- q1 q1 y7
+gd cy1f +- b 0 cy1
+gd cy3f +- b 0 cy3
+gd cy4f +- b 0 cy4
+gd cy6f +- b 0 cy6
+gd cy7f +- b 0 cy7
+gd rhf +- b 0 rh
+gd y1f +- b 0 y1
+gd y2f +- b 0 y2
+gd y3f +- b 0 y3
+gd y5f +- b 0 y5
+gd y6f +- b 0 y6
+gd y7f +- b 0 y7
+path stroke=false
+M l b
+Q cx1 cy1f x3 y1f
+L x2 y3f
+Q hc cy3f x5 y3f
+L x4 y1f
+Q cx2 cy1f r b
+L x6 y2f
+L r rhf
+Q cx5 cy4f x5 y5f
+L x5 y6f
+Q hc cy6f x2 y6f
+L x2 y5f
+Q cx4 cy4f l rhf
+L wd8 y2f
+Z
+path fill=darkenLess stroke=false
+M x3 y7f
+L x3 y1f
+L x2 y3f
+Q hc cy3f x5 y3f
+L x4 y1f
+L x4 y7f
+Q hc cy7f x3 y7f
+Z
+path fill=none
+M l b
+Q cx1 cy1f x3 y1f
+L x2 y3f
+Q hc cy3f x5 y3f
+L x4 y1f
+Q cx2 cy1f r b
+L x6 y2f
+L r rhf
+Q cx5 cy4f x5 y5f
+L x5 y6f
+Q hc cy6f x2 y6f
+L x2 y5f
+Q cx4 cy4f l rhf
+L wd8 y2f
+Z
+M x2 y5f
+L x2 y3f
+M x5 y3f
+L x5 y5f
+M x3 y1f
+L x3 y7f
+M x4 y7f
+L x4 y1f
+`,
+	"leftRightRibbon": `
+av adj1 50000
+av adj2 50000
+av adj3 16667
+gd a3 pin 0 adj3 33333
+gd maxAdj1 +- 100000 0 a3
+gd a1 pin 0 adj1 maxAdj1
+gd w1 +- wd2 0 wd32
+gd maxAdj2 */ 100000 w1 ss
+gd a2 pin 0 adj2 maxAdj2
+gd x1 */ ss a2 100000
+gd x4 +- r 0 x1
+gd dy1 */ h a1 200000
+gd dy2 */ h a3 -200000
+gd ly1 +- vc dy2 dy1
+gd ry4 +- vc dy1 dy2
+gd ly2 +- ly1 dy1 0
+gd ry3 +- b 0 ly2
+gd ly4 */ ly2 2 1
+gd ry1 +- b 0 ly4
+gd ly3 +- ly4 0 ly1
+gd ry2 +- b 0 ly3
+gd hR */ a3 ss 400000
+gd x2 +- hc 0 wd32
+gd x3 +- hc wd32 0
+gd y1 +- ly1 hR 0
+gd y2 +- ry2 0 hR
+path stroke=false
+M l ly2
+L x1 t
+L x1 ly1
+L hc ly1
+A wd32 hR 3cd4 cd2
+A wd32 hR 3cd4 -10800000
+L x4 ry2
+L x4 ry1
+L r ry3
+L x4 b
+L x4 ry4
+L hc ry4
+A wd32 hR cd4 cd4
+L x2 ly3
+L x1 ly3
+L x1 ly4
+Z
+path fill=darkenLess stroke=false
+M x3 y1
+A wd32 hR 0 cd4
+A wd32 hR 3cd4 -10800000
+L x3 ry2
+Z
+path fill=none
+M l ly2
+L x1 t
+L x1 ly1
+L hc ly1
+A wd32 hR 3cd4 cd2
+A wd32 hR 3cd4 -10800000
+L x4 ry2
+L x4 ry1
+L r ry3
+L x4 b
+L x4 ry4
+L hc ry4
+A wd32 hR cd4 cd4
+L x2 ly3
+L x1 ly3
+L x1 ly4
+Z
+M x3 y1
+L x3 ry2
+M x2 y2
+L x2 ly3
+`,
+	"verticalScroll": `
+av adj 12500
+gd a pin 0 adj 25000
+gd ch */ ss a 100000
+gd ch2 */ ch 1 2
+gd ch4 */ ch 1 4
+gd x6 +- r 0 ch
+gd x7 +- r 0 ch2
+gd y3 +- b 0 ch
+gd y4 +- b 0 ch2
+gd cx1 +- ch 0 ch4
+gd cx2 +- x6 0 ch4
+path
+M ch ch
+L x6 ch
+L x6 y3
+L ch y3
+Z
+path
+M ch t
+L x7 t
+A ch2 ch2 3cd4 cd2
+L ch ch
+A ch2 ch2 cd4 cd2
+Z
+path
+M ch2 y3
+L x6 y3
+A ch2 ch2 3cd4 cd2
+L ch2 b
+A ch2 ch2 cd4 cd2
+Z
+path fill=darkenLess
+M cx1 ch2
+A ch4 ch4 cd2 21600000
+Z
+M cx2 y4
+A ch4 ch4 cd2 21600000
+Z
+`,
+	"horizontalScroll": `
+av adj 12500
+gd a pin 0 adj 25000
+gd ch */ ss a 100000
+gd ch2 */ ch 1 2
+gd ch4 */ ch 1 4
+gd x6 +- r 0 ch
+gd x7 +- r 0 ch2
+gd y4 +- b 0 ch2
+gd y6 +- b 0 ch
+gd cx1 +- ch2 0 ch4
+gd cx2 +- x7 0 ch4
+path
+M ch ch
+L x6 ch
+L x6 y6
+L ch y6
+Z
+path
+M l ch
+L l y4
+A ch2 ch2 cd2 -10800000
+L ch ch
+A ch2 ch2 0 -10800000
+Z
+path
+M x6 ch2
+L x6 y6
+A ch2 ch2 cd2 -10800000
+L r ch2
+A ch2 ch2 0 -10800000
+Z
+path fill=darkenLess
+M cx1 ch
+A ch4 ch4 cd2 21600000
+Z
+M cx2 y6
+A ch4 ch4 cd2 21600000
+Z
+`,
+	"wave": `
+av adj1 12500
+av adj2 0
+gd a1 pin 0 adj1 20000
+gd a2 pin -10000 adj2 10000
+gd y1 */ h a1 100000
+gd dy2 */ y1 10 3
+gd y2 +- y1 0 dy2
+gd y3 +- y1 dy2 0
+gd y4 +- b 0 y1
+gd y5 +- y4 0 dy2
+gd y6 +- y4 dy2 0
+gd of2 */ w a2 50000
+gd dx2 ?: of2 0 of2
+gd x2 +- l 0 dx2
+gd dx5 ?: of2 of2 0
+gd x5 +- r 0 dx5
+gd dx3 +/ dx2 x5 3
+gd x3 +- x2 dx3 0
+gd x4 +/ x3 x5 2
+gd x6 +- l dx5 0
+gd x10 +- r dx2 0
+gd x7 +- x6 dx3 0
+gd x8 +/ x7 x10 2
+path
+M x2 y1
+C x3 y2 x4 y3 x5 y1
+L x10 y4
+C x8 y6 x7 y5 x6 y4
+Z
+`,
+	"doubleWave": `
+av adj1 6250
+av adj2 0
+gd a1 pin 0 adj1 12500
+gd a2 pin -10000 adj2 10000
+gd y1 */ h a1 100000
+gd dy2 */ y1 10 3
+gd y2 +- y1 0 dy2
+gd y3 +- y1 dy2 0
+gd y4 +- b 0 y1
+gd y5 +- y4 0 dy2
+gd y6 +- y4 dy2 0
+gd of2 */ w a2 50000
+gd dx2 ?: of2 0 of2
+gd x2 +- l 0 dx2
+gd dx8 ?: of2 of2 0
+gd x8 +- r 0 dx8
+gd dx3 +/ dx2 x8 6
+gd x3 +- x2 dx3 0
+gd dx4 +/ dx2 x8 3
+gd x4 +- x2 dx4 0
+gd x5 +/ x2 x8 2
+gd x6 +- x5 dx3 0
+gd x7 +/ x6 x8 2
+gd x9 +- l dx8 0
+gd x15 +- r dx2 0
+gd x10 +- x9 dx3 0
+gd x11 +- x9 dx4 0
+gd x12 +/ x9 x15 2
+gd x13 +- x12 dx3 0
+gd x14 +/ x13 x15 2
+path
+M x2 y1
+C x3 y2 x4 y3 x5 y1
+C x6 y2 x7 y3 x8 y1
+L x15 y4
+C x14 y6 x13 y5 x12 y4
+C x11 y6 x10 y5 x9 y4
+Z
+`,
+	"flowChartProcess": `
+path w=1 h=1
+M 0 0
+L 1 0
+L 1 1
+L 0 1
+Z
+`,
+	"flowChartAlternateProcess": `
+gd x2 +- r 0 ssd6
+gd y2 +- b 0 ssd6
+path
+M l ssd6
+A ssd6 ssd6 cd2 cd4
+L x2 t
+A ssd6 ssd6 3cd4 cd4
+L r y2
+A ssd6 ssd6 0 cd4
+L ssd6 b
+A ssd6 ssd6 cd4 cd4
+Z
+`,
+	"flowChartDecision": `
+path w=2 h=2
+M 0 1
+L 1 0
+L 2 1
+L 1 2
+Z
+`,
+	"flowChartInputOutput": `
+path w=5 h=5
+M 0 5
+L 1 0
+L 5 0
+L 4 5
+Z
+`,
+	"flowChartPredefinedProcess": `
+path w=1 h=1 stroke=false
+M 0 0
+L 1 0
+L 1 1
+L 0 1
+Z
+path w=8 h=8 fill=none
+M 1 0
+L 1 8
+M 7 0
+L 7 8
+path w=1 h=1 fill=none
+M 0 0
+L 1 0
+L 1 1
+L 0 1
+Z
+`,
+	"flowChartInternalStorage": `
+path w=1 h=1 stroke=false
+M 0 0
+L 1 0
+L 1 1
+L 0 1
+Z
+path w=8 h=8 fill=none
+M 1 0
+L 1 8
+M 0 1
+L 8 1
+path w=1 h=1 fill=none
+M 0 0
+L 1 0
+L 1 1
+L 0 1
+Z
+`,
+	"flowChartDocument": `
+path w=21600 h=21600
+M 0 0
+L 21600 0
+L 21600 17322
+C 10800 17322 10800 23922 0 20172
+Z
+`,
+	"flowChartMultidocument": `
+path w=21600 h=21600 stroke=false
+M 0 20782
+C 9298 23542 9298 18022 18595 18022
+L 18595 3675
+L 0 3675
+Z
+M 1532 3675
+L 1532 1815
+L 20000 1815
+L 20000 16252
+C 19298 16252 18595 16352 18595 16352
+L 18595 3675
+Z
+M 2972 1815
+L 2972 0
+L 21600 0
+L 21600 14392
+C 20800 14392 20000 14467 20000 14467
+L 20000 1815
+Z
+path w=21600 h=21600 fill=none
+M 0 3675
+L 18595 3675
+L 18595 18022
+C 9298 18022 9298 23542 0 20782
+Z
+M 1532 3675
+L 1532 1815
+L 20000 1815
+L 20000 16252
+C 19298 16252 18595 16352 18595 16352
+M 2972 1815
+L 2972 0
+L 21600 0
+L 21600 14392
+C 20800 14392 20000 14467 20000 14467
+`,
+	"flowChartTerminator": `
+path w=21600 h=21600
+M 3475 0
+L 18125 0
+A 3475 10800 3cd4 cd2
+L 3475 21600
+A 3475 10800 cd4 cd2
+Z
+`,
+	"flowChartPreparation": `
+path w=10 h=10
+M 0 5
+L 2 0
+L 8 0
+L 10 5
+L 8 10
+L 2 10
+Z
+`,
+	"flowChartManualInput": `
+path w=5 h=5
+M 0 1
+L 5 0
+L 5 5
+L 0 5
+Z
+`,
+	"flowChartManualOperation": `
+path w=5 h=5
+M 0 0
+L 5 0
+L 4 5
+L 1 5
+Z
+`,
+	"flowChartConnector": `
+path
+M l vc
+A wd2 hd2 cd2 cd4
+A wd2 hd2 3cd4 cd4
+A wd2 hd2 0 cd4
+A wd2 hd2 cd4 cd4
+Z
+`,
+	"flowChartOffpageConnector": `
+path w=10 h=10
+M 0 0
+L 10 0
+L 10 8
+L 5 10
+L 0 8
+Z
+`,
+	"flowChartPunchedCard": `
+path w=5 h=5
+M 0 1
+L 1 0
+L 5 0
+L 5 5
+L 0 5
+Z
+`,
+	"flowChartPunchedTape": `
+path w=20 h=20
+M 0 2
+A 5 2 cd2 -10800000
+A 5 2 cd2 cd2
+L 20 18
+A 5 2 0 -10800000
+A 5 2 0 cd2
+Z
+`,
+	"flowChartSummingJunction": `
+gd idx cos wd2 2700000
+gd idy sin hd2 2700000
+gd il +- hc 0 idx
+gd ir +- hc idx 0
+gd it +- vc 0 idy
+gd ib +- vc idy 0
+path stroke=false
+M l vc
+A wd2 hd2 cd2 21600000
+Z
+path fill=none
+M il it
+L ir ib
+M ir it
+L il ib
+path fill=none
+M l vc
+A wd2 hd2 cd2 21600000
+Z
+`,
+	"flowChartOr": `
+path stroke=false
+M l vc
+A wd2 hd2 cd2 21600000
+Z
+path fill=none
+M hc t
+L hc b
+M l vc
+L r vc
+path fill=none
+M l vc
+A wd2 hd2 cd2 21600000
+Z
+`,
+	"flowChartCollate": `
+path w=2 h=2
+M 0 0
+L 2 0
+L 1 1
+L 2 2
+L 0 2
+L 1 1
+Z
+`,
+	"flowChartSort": `
+path w=2 h=2 stroke=false
+M 0 1
+L 1 0
+L 2 1
+L 1 2
+Z
+path w=2 h=2 fill=none
+M 0 1
+L 2 1
+path w=2 h=2 fill=none
+M 0 1
+L 1 0
+L 2 1
+L 1 2
+Z
+`,
+	"flowChartExtract": `
+path w=2 h=2
+M 0 2
+L 1 0
+L 2 2
+Z
+`,
+	"flowChartMerge": `
+path w=2 h=2
+M 0 0
+L 2 0
+L 1 2
+Z
+`,
+	"flowChartOfflineStorage": `
+path w=2 h=2 stroke=false
+M 0 0
+L 2 0
+L 1 2
+Z
+path w=5 h=5 fill=none
+M 2 4
+L 3 4
+path w=2 h=2 fill=none
+M 0 0
+L 2 0
+L 1 2
+Z
+`,
+	"flowChartOnlineStorage": `
+path w=6 h=6
+M 1 0
+L 6 0
+A 1 3 3cd4 -10800000
+L 1 6
+A 1 3 cd4 cd2
+Z
+`,
+	"flowChartDelay": `
+path
+M l t
+L hc t
+A wd2 hd2 3cd4 cd2
+L l b
+Z
+`,
+	"flowChartMagneticTape": `
+gd idy sin hd2 2700000
+gd ib +- vc idy 0
+gd ang1 at2 w h
+path
+M hc b
+A wd2 hd2 cd4 cd4
+A wd2 hd2 cd2 cd4
+A wd2 hd2 3cd4 cd4
+A wd2 hd2 0 ang1
+L r ib
+L r b
+Z
+`,
+	"flowChartMagneticDisk": `
+path w=6 h=6 stroke=false
+M 0 1
+A 3 1 cd2 cd2
+L 6 5
+A 3 1 0 cd2
+Z
+path w=6 h=6 fill=none
+M 6 1
+A 3 1 0 cd2
+path w=6 h=6 fill=none
+M 0 1
+A 3 1 cd2 cd2
+L 6 5
+A 3 1 0 cd2
+Z
+`,
+	"flowChartMagneticDrum": `
+path w=6 h=6 stroke=false
+M 1 0
+L 5 0
+A 1 3 3cd4 cd2
+L 1 6
+A 1 3 cd4 cd2
+Z
+path w=6 h=6 fill=none
+M 5 6
+A 1 3 cd4 cd2
+path w=6 h=6 fill=none
+M 1 0
+L 5 0
+A 1 3 3cd4 cd2
+L 1 6
+A 1 3 cd4 cd2
+Z
+`,
+	"flowChartDisplay": `
+path w=6 h=6
+M 0 3
+L 1 0
+L 5 0
+A 1 3 3cd4 cd2
+L 1 6
+Z
+`,
+	"gear6": `
+av adj1 15000
+av adj2 3526
+gd a1 pin 0 adj1 20000
+gd a2 pin 0 adj2 5000
+gd th */ ss a1 100000
+gd tw */ ss a2 50000
+gd rw +- wd2 0 th
+gd rh +- hd2 0 th
+gd ro min rw rh
+gd dO at2 ro tw
+gd dB +- dO 450000 0
+gd b0 +- 16200000 0 dB
+gd o0 +- 16200000 0 dO
+gd p0 +- 16200000 dO 0
+gd e0 +- 16200000 dB 0
+gd b0dx cos rw b0
+gd b0dy sin rh b0
+gd b0x +- hc b0dx 0
+gd b0y +- vc b0dy 0
+gd o0dx cos wd2 o0
+gd o0dy sin hd2 o0
+gd o0x +- hc o0dx 0
+gd o0y +- vc o0dy 0
+gd p0dx cos wd2 p0
+gd p0dy sin hd2 p0
+gd p0x +- hc p0dx 0
+gd p0y +- vc p0dy 0
+gd e0dx cos rw e0
+gd e0dy sin rh e0
+gd e0x +- hc e0dx 0
+gd e0y +- vc e0dy 0
+gd g0dx cos rw 18000000
+gd g0dy sin rh 18000000
+gd g0x +- hc g0dx 0
+gd g0y +- vc g0dy 0
+gd b1 +- 19800000 0 dB
+gd o1 +- 19800000 0 dO
+gd p1 +- 19800000 dO 0
+gd e1 +- 19800000 dB 0
+gd b1dx cos rw b1
+gd b1dy sin rh b1
+gd b1x +- hc b1dx 0
+gd b1y +- vc b1dy 0
+gd o1dx cos wd2 o1
+gd o1dy sin hd2 o1
+gd o1x +- hc o1dx 0
+gd o1y +- vc o1dy 0
+gd p1dx cos wd2 p1
+gd p1dy sin hd2 p1
+gd p1x +- hc p1dx 0
+gd p1y +- vc p1dy 0
+gd e1dx cos rw e1
+gd e1dy sin rh e1
+gd e1x +- hc e1dx 0
+gd e1y +- vc e1dy 0
+gd g1dx cos rw 0
+gd g1dy sin rh 0
+gd g1x +- hc g1dx 0
+gd g1y +- vc g1dy 0
+gd b2 +- 1800000 0 dB
+gd o2 +- 1800000 0 dO
+gd p2 +- 1800000 dO 0
+gd e2 +- 1800000 dB 0
+gd b2dx cos rw b2
+gd b2dy sin rh b2
+gd b2x +- hc b2dx 0
+gd b2y +- vc b2dy 0
+gd o2dx cos wd2 o2
+gd o2dy sin hd2 o2
+gd o2x +- hc o2dx 0
+gd o2y +- vc o2dy 0
+gd p2dx cos wd2 p2
+gd p2dy sin hd2 p2
+gd p2x +- hc p2dx 0
+gd p2y +- vc p2dy 0
+gd e2dx cos rw e2
+gd e2dy sin rh e2
+gd e2x +- hc e2dx 0
+gd e2y +- vc e2dy 0
+gd g2dx cos rw 3600000
+gd g2dy sin rh 3600000
+gd g2x +- hc g2dx 0
+gd g2y +- vc g2dy 0
+gd b3 +- 5400000 0 dB
+gd o3 +- 5400000 0 dO
+gd p3 +- 5400000 dO 0
+gd e3 +- 5400000 dB 0
+gd b3dx cos rw b3
+gd b3dy sin rh b3
+gd b3x +- hc b3dx 0
+gd b3y +- vc b3dy 0
+gd o3dx cos wd2 o3
+gd o3dy sin hd2 o3
+gd o3x +- hc o3dx 0
+gd o3y +- vc o3dy 0
+gd p3dx cos wd2 p3
+gd p3dy sin hd2 p3
+gd p3x +- hc p3dx 0
+gd p3y +- vc p3dy 0
+gd e3dx cos rw e3
+gd e3dy sin rh e3
+gd e3x +- hc e3dx 0
+gd e3y +- vc e3dy 0
+gd g3dx cos rw 7200000
+gd g3dy sin rh 7200000
+gd g3x +- hc g3dx 0
+gd g3y +- vc g3dy 0
+gd b4 +- 9000000 0 dB
+gd o4 +- 9000000 0 dO
+gd p4 +- 9000000 dO 0
+gd e4 +- 9000000 dB 0
+gd b4dx cos rw b4
+gd b4dy sin rh b4
+gd b4x +- hc b4dx 0
+gd b4y +- vc b4dy 0
+gd o4dx cos wd2 o4
+gd o4dy sin hd2 o4
+gd o4x +- hc o4dx 0
+gd o4y +- vc o4dy 0
+gd p4dx cos wd2 p4
+gd p4dy sin hd2 p4
+gd p4x +- hc p4dx 0
+gd p4y +- vc p4dy 0
+gd e4dx cos rw e4
+gd e4dy sin rh e4
+gd e4x +- hc e4dx 0
+gd e4y +- vc e4dy 0
+gd g4dx cos rw 10800000
+gd g4dy sin rh 10800000
+gd g4x +- hc g4dx 0
+gd g4y +- vc g4dy 0
+gd b5 +- 12600000 0 dB
+gd o5 +- 12600000 0 dO
+gd p5 +- 12600000 dO 0
+gd e5 +- 12600000 dB 0
+gd b5dx cos rw b5
+gd b5dy sin rh b5
+gd b5x +- hc b5dx 0
+gd b5y +- vc b5dy 0
+gd o5dx cos wd2 o5
+gd o5dy sin hd2 o5
+gd o5x +- hc o5dx 0
+gd o5y +- vc o5dy 0
+gd p5dx cos wd2 p5
+gd p5dy sin hd2 p5
+gd p5x +- hc p5dx 0
+gd p5y +- vc p5dy 0
+gd e5dx cos rw e5
+gd e5dy sin rh e5
+gd e5x +- hc e5dx 0
+gd e5y +- vc e5dy 0
+gd g5dx cos rw 14400000
+gd g5dy sin rh 14400000
+gd g5x +- hc g5dx 0
+gd g5y +- vc g5dy 0
+path
+M b0x b0y
+L o0x o0y
+L p0x p0y
+L e0x e0y
+L g0x g0y
+L b1x b1y
+L o1x o1y
+L p1x p1y
+L e1x e1y
+L g1x g1y
+L b2x b2y
+L o2x o2y
+L p2x p2y
+L e2x e2y
+L g2x g2y
+L b3x b3y
+L o3x o3y
+L p3x p3y
+L e3x e3y
+L g3x g3y
+L b4x b4y
+L o4x o4y
+L p4x p4y
+L e4x e4y
+L g4x g4y
+L b5x b5y
+L o5x o5y
+L p5x p5y
+L e5x e5y
+L g5x g5y
+Z
+`,
+	"gear9": `
+av adj1 10000
+av adj2 1763
+gd a1 pin 0 adj1 20000
+gd a2 pin 0 adj2 5000
+gd th */ ss a1 100000
+gd tw */ ss a2 50000
+gd rw +- wd2 0 th
+gd rh +- hd2 0 th
+gd ro min rw rh
+gd dO at2 ro tw
+gd dB +- dO 300000 0
+gd b0 +- 16200000 0 dB
+gd o0 +- 16200000 0 dO
+gd p0 +- 16200000 dO 0
+gd e0 +- 16200000 dB 0
+gd b0dx cos rw b0
+gd b0dy sin rh b0
+gd b0x +- hc b0dx 0
+gd b0y +- vc b0dy 0
+gd o0dx cos wd2 o0
+gd o0dy sin hd2 o0
+gd o0x +- hc o0dx 0
+gd o0y +- vc o0dy 0
+gd p0dx cos wd2 p0
+gd p0dy sin hd2 p0
+gd p0x +- hc p0dx 0
+gd p0y +- vc p0dy 0
+gd e0dx cos rw e0
+gd e0dy sin rh e0
+gd e0x +- hc e0dx 0
+gd e0y +- vc e0dy 0
+gd g0dx cos rw 17400000
+gd g0dy sin rh 17400000
+gd g0x +- hc g0dx 0
+gd g0y +- vc g0dy 0
+gd b1 +- 18600000 0 dB
+gd o1 +- 18600000 0 dO
+gd p1 +- 18600000 dO 0
+gd e1 +- 18600000 dB 0
+gd b1dx cos rw b1
+gd b1dy sin rh b1
+gd b1x +- hc b1dx 0
+gd b1y +- vc b1dy 0
+gd o1dx cos wd2 o1
+gd o1dy sin hd2 o1
+gd o1x +- hc o1dx 0
+gd o1y +- vc o1dy 0
+gd p1dx cos wd2 p1
+gd p1dy sin hd2 p1
+gd p1x +- hc p1dx 0
+gd p1y +- vc p1dy 0
+gd e1dx cos rw e1
+gd e1dy sin rh e1
+gd e1x +- hc e1dx 0
+gd e1y +- vc e1dy 0
+gd g1dx cos rw 19800000
+gd g1dy sin rh 19800000
+gd g1x +- hc g1dx 0
+gd g1y +- vc g1dy 0
+gd b2 +- 21000000 0 dB
+gd o2 +- 21000000 0 dO
+gd p2 +- 21000000 dO 0
+gd e2 +- 21000000 dB 0
+gd b2dx cos rw b2
+gd b2dy sin rh b2
+gd b2x +- hc b2dx 0
+gd b2y +- vc b2dy 0
+gd o2dx cos wd2 o2
+gd o2dy sin hd2 o2
+gd o2x +- hc o2dx 0
+gd o2y +- vc o2dy 0
+gd p2dx cos wd2 p2
+gd p2dy sin hd2 p2
+gd p2x +- hc p2dx 0
+gd p2y +- vc p2dy 0
+gd e2dx cos rw e2
+gd e2dy sin rh e2
+gd e2x +- hc e2dx 0
+gd e2y +- vc e2dy 0
+gd g2dx cos rw 600000
+gd g2dy sin rh 600000
+gd g2x +- hc g2dx 0
+gd g2y +- vc g2dy 0
+gd b3 +- 1800000 0 dB
+gd o3 +- 1800000 0 dO
+gd p3 +- 1800000 dO 0
+gd e3 +- 1800000 dB 0
+gd b3dx cos rw b3
+gd b3dy sin rh b3
+gd b3x +- hc b3dx 0
+gd b3y +- vc b3dy 0
+gd o3dx cos wd2 o3
+gd o3dy sin hd2 o3
+gd o3x +- hc o3dx 0
+gd o3y +- vc o3dy 0
+gd p3dx cos wd2 p3
+gd p3dy sin hd2 p3
+gd p3x +- hc p3dx 0
+gd p3y +- vc p3dy 0
+gd e3dx cos rw e3
+gd e3dy sin rh e3
+gd e3x +- hc e3dx 0
+gd e3y +- vc e3dy 0
+gd g3dx cos rw 3000000
+gd g3dy sin rh 3000000
+gd g3x +- hc g3dx 0
+gd g3y +- vc g3dy 0
+gd b4 +- 4200000 0 dB
+gd o4 +- 4200000 0 dO
+gd p4 +- 4200000 dO 0
+gd e4 +- 4200000 dB 0
+gd b4dx cos rw b4
+gd b4dy sin rh b4
+gd b4x +- hc b4dx 0
+gd b4y +- vc b4dy 0
+gd o4dx cos wd2 o4
+gd o4dy sin hd2 o4
+gd o4x +- hc o4dx 0
+gd o4y +- vc o4dy 0
+gd p4dx cos wd2 p4
+gd p4dy sin hd2 p4
+gd p4x +- hc p4dx 0
+gd p4y +- vc p4dy 0
+gd e4dx cos rw e4
+gd e4dy sin rh e4
+gd e4x +- hc e4dx 0
+gd e4y +- vc e4dy 0
+gd g4dx cos rw 5400000
+gd g4dy sin rh 5400000
+gd g4x +- hc g4dx 0
+gd g4y +- vc g4dy 0
+gd b5 +- 6600000 0 dB
+gd o5 +- 6600000 0 dO
+gd p5 +- 6600000 dO 0
+gd e5 +- 6600000 dB 0
+gd b5dx cos rw b5
+gd b5dy sin rh b5
+gd b5x +- hc b5dx 0
+gd b5y +- vc b5dy 0
+gd o5dx cos wd2 o5
+gd o5dy sin hd2 o5
+gd o5x +- hc o5dx 0
+gd o5y +- vc o5dy 0
+gd p5dx cos wd2 p5
+gd p5dy sin hd2 p5
+gd p5x +- hc p5dx 0
+gd p5y +- vc p5dy 0
+gd e5dx cos rw e5
+gd e5dy sin rh e5
+gd e5x +- hc e5dx 0
+gd e5y +- vc e5dy 0
+gd g5dx cos rw 7800000
+gd g5dy sin rh 7800000
+gd g5x +- hc g5dx 0
+gd g5y +- vc g5dy 0
+gd b6 +- 9000000 0 dB
+gd o6 +- 9000000 0 dO
+gd p6 +- 9000000 dO 0
+gd e6 +- 9000000 dB 0
+gd b6dx cos rw b6
+gd b6dy sin rh b6
+gd b6x +- hc b6dx 0
+gd b6y +- vc b6dy 0
+gd o6dx cos wd2 o6
+gd o6dy sin hd2 o6
+gd o6x +- hc o6dx 0
+gd o6y +- vc o6dy 0
+gd p6dx cos wd2 p6
+gd p6dy sin hd2 p6
+gd p6x +- hc p6dx 0
+gd p6y +- vc p6dy 0
+gd e6dx cos rw e6
+gd e6dy sin rh e6
+gd e6x +- hc e6dx 0
+gd e6y +- vc e6dy 0
+gd g6dx cos rw 10200000
+gd g6dy sin rh 10200000
+gd g6x +- hc g6dx 0
+gd g6y +- vc g6dy 0
+gd b7 +- 11400000 0 dB
+gd o7 +- 11400000 0 dO
+gd p7 +- 11400000 dO 0
+gd e7 +- 11400000 dB 0
+gd b7dx cos rw b7
+gd b7dy sin rh b7
+gd b7x +- hc b7dx 0
+gd b7y +- vc b7dy 0
+gd o7dx cos wd2 o7
+gd o7dy sin hd2 o7
+gd o7x +- hc o7dx 0
+gd o7y +- vc o7dy 0
+gd p7dx cos wd2 p7
+gd p7dy sin hd2 p7
+gd p7x +- hc p7dx 0
+gd p7y +- vc p7dy 0
+gd e7dx cos rw e7
+gd e7dy sin rh e7
+gd e7x +- hc e7dx 0
+gd e7y +- vc e7dy 0
+gd g7dx cos rw 12600000
+gd g7dy sin rh 12600000
+gd g7x +- hc g7dx 0
+gd g7y +- vc g7dy 0
+gd b8 +- 13800000 0 dB
+gd o8 +- 13800000 0 dO
+gd p8 +- 13800000 dO 0
+gd e8 +- 13800000 dB 0
+gd b8dx cos rw b8
+gd b8dy sin rh b8
+gd b8x +- hc b8dx 0
+gd b8y +- vc b8dy 0
+gd o8dx cos wd2 o8
+gd o8dy sin hd2 o8
+gd o8x +- hc o8dx 0
+gd o8y +- vc o8dy 0
+gd p8dx cos wd2 p8
+gd p8dy sin hd2 p8
+gd p8x +- hc p8dx 0
+gd p8y +- vc p8dy 0
+gd e8dx cos rw e8
+gd e8dy sin rh e8
+gd e8x +- hc e8dx 0
+gd e8y +- vc e8dy 0
+gd g8dx cos rw 15000000
+gd g8dy sin rh 15000000
+gd g8x +- hc g8dx 0
+gd g8y +- vc g8dy 0
+path
+M b0x b0y
+L o0x o0y
+L p0x p0y
+L e0x e0y
+L g0x g0y
+L b1x b1y
+L o1x o1y
+L p1x p1y
+L e1x e1y
+L g1x g1y
+L b2x b2y
+L o2x o2y
+L p2x p2y
+L e2x e2y
+L g2x g2y
+L b3x b3y
+L o3x o3y
+L p3x p3y
+L e3x e3y
+L g3x g3y
+L b4x b4y
+L o4x o4y
+L p4x p4y
+L e4x e4y
+L g4x g4y
+L b5x b5y
+L o5x o5y
+L p5x p5y
+L e5x e5y
+L g5x g5y
+L b6x b6y
+L o6x o6y
+L p6x p6y
+L e6x e6y
+L g6x g6y
+L b7x b7y
+L o7x o7y
+L p7x p7y
+L e7x e7y
+L g7x g7y
+L b8x b8y
+L o8x o8y
+L p8x p8y
+L e8x e8y
+L g8x g8y
+Z
+`,
+	"funnel": `
+gd d */ ss 1 20
+gd eh */ h 1 8
+gd rb */ h 1 16
+gd bx2 +- hc wd8 0
+gd by +- b 0 rb
+gd iw +- wd2 0 d
+gd ih +- eh 0 d
+gd ix +- l d 0
+path
+M l eh
+A wd2 eh cd2 cd2
+L bx2 by
+A wd8 rb 0 cd2
+Z
+M ix eh
+A iw ih cd2 21600000
+Z
+`,
+	"mathPlus": `
+av adj1 23520
+gd a1 pin 0 adj1 73490
+gd dx1 */ w 73490 200000
+gd dy1 */ h 73490 200000
+gd dx2 */ ss a1 200000
+gd x1 +- hc 0 dx1
+gd x2 +- hc 0 dx2
+gd x3 +- hc dx2 0
+gd x4 +- hc dx1 0
+gd y1 +- vc 0 dy1
+gd y2 +- vc 0 dx2
+gd y3 +- vc dx2 0
+gd y4 +- vc dy1 0
+path
+M x1 y2
+L x2 y2
+L x2 y1
+L x3 y1
+L x3 y2
+L x4 y2
+L x4 y3
+L x3 y3
+L x3 y4
+L x2 y4
+L x2 y3
+L x1 y3
+Z
+`,
+	"mathMinus": `
+av adj1 23520
+gd a1 pin 0 adj1 100000
+gd dy1 */ h a1 200000
+gd dx1 */ w 73490 200000
+gd y1 +- vc 0 dy1
+gd y2 +- vc dy1 0
+gd x1 +- hc 0 dx1
+gd x2 +- hc dx1 0
+path
+M x1 y1
+L x2 y1
+L x2 y2
+L x1 y2
+Z
+`,
+	"mathMultiply": `
+av adj1 23520
+gd a1 pin 0 adj1 51965
+gd th */ ss a1 100000
+gd th2 */ th 1 2
+gd len mod w h 0
+gd nx */ th2 h len
+gd ny */ th2 w len
+gd dxL */ w 36745 100000
+gd dyL */ h 36745 100000
+gd yT */ th2 len w
+gd xR */ th2 len h
+gd xA +- hc dxL nx
+gd xB +- hc dxL 0
+gd xB +- xB nx 0
+gd xC +- hc nx dxL
+gd xD +- hc 0 dxL
+gd xD +- xD 0 nx
+gd yA +- vc 0 dyL
+gd yA +- yA 0 ny
+gd yB +- vc ny dyL
+gd yC +- vc dyL ny
+gd yD +- vc dyL 0
+gd yD +- yD ny 0
+gd y1 +- vc 0 yT
+gd y2 +- vc yT 0
+gd x1 +- hc 0 xR
+gd x2 +- hc xR 0
+path
+M hc y1
+L xA yA
+L xB yB
+L x2 vc
+L xB yC
+L xA yD
+L hc y2
+L xC yD
+L xD yC
+L x1 vc
+L xD yB
+L xC yA
+Z
+`,
+	"mathDivide": `
+av adj1 23520
+av adj2 5880
+av adj3 11760
+gd a1 pin 1000 adj1 36745
+gd a2 pin 0 adj2 36745
+gd a3 pin 1000 adj3 36745
+gd dy1 */ h a1 200000
+gd gap */ h a2 100000
+gd rad */ h a3 100000
+gd dx1 */ w 73490 200000
+gd y3 +- vc 0 dy1
+gd y4 +- vc dy1 0
+gd yt +- y3 0 gap
+gd y1 +- yt 0 rad
+gd yb +- y4 gap 0
+gd y5 +- yb rad 0
+gd x1 +- hc 0 dx1
+gd x3 +- hc dx1 0
+gd x2 +- hc 0 rad
+path
+M x2 y1
+A rad rad cd2 21600000
+Z
+M x2 y5
+A rad rad cd2 21600000
+Z
+M x1 y3
+L x3 y3
+L x3 y4
+L x1 y4
+Z
+`,
+	"mathEqual": `
+av adj1 23520
+av adj2 11760
+gd a1 pin 0 adj1 36745
+gd a1x2 */ a1 2 1
+gd mAdj2 +- 100000 0 a1x2
+gd a2 pin 0 adj2 mAdj2
+gd dy1 */ h a1 100000
+gd dy2 */ h a2 200000
+gd dx1 */ w 73490 200000
+gd y2 +- vc 0 dy2
+gd y3 +- vc dy2 0
+gd y1 +- y2 0 dy1
+gd y4 +- y3 dy1 0
+gd x1 +- hc 0 dx1
+gd x2 +- hc dx1 0
+path
+M x1 y1
+L x2 y1
+L x2 y2
+L x1 y2
+Z
+M x1 y3
+L x2 y3
+L x2 y4
+L x1 y4
+Z
+`,
+	"mathNotEqual": `
+av adj1 23520
+av adj2 6600000
+av adj3 11760
+gd a1 pin 0 adj1 50000
+gd crAng pin 4200000 adj2 6600000
+gd a1x2 */ a1 2 1
+gd maxAdj3 +- 100000 0 a1x2
+gd a3 pin 0 adj3 maxAdj3
+gd dy1 */ h a1 100000
+gd dy2 */ h a3 200000
+gd dx1 */ w 73490 200000
+gd x1 +- hc 0 dx1
+gd x8 +- hc dx1 0
+gd y2 +- vc 0 dy2
+gd y3 +- vc dy2 0
+gd y1 +- y2 0 dy1
+gd y4 +- y3 dy1 0
+gd cs cos 100000 crAng
+gd sn sin 100000 crAng
+gd th2 */ dy1 1 2
+gd hw */ th2 100000 sn
+gd e1 +- dy2 dy1 0
+gd oT */ vc cs sn
+gd o1 */ e1 cs sn
+gd o2 */ dy2 cs sn
+gd cxT +- hc 0 oT
+gd cx1 +- hc 0 o1
+gd cx2 +- hc 0 o2
+gd cx3 +- hc o2 0
+gd cx4 +- hc o1 0
+gd cxB +- hc oT 0
+gd lT +- cxT 0 hw
+gd rT +- cxT hw 0
+gd l1 +- cx1 0 hw
+gd r1 +- cx1 hw 0
+gd l2 +- cx2 0 hw
+gd r2 +- cx2 hw 0
+gd l3 +- cx3 0 hw
+gd r3 +- cx3 hw 0
+gd l4 +- cx4 0 hw
+gd r4 +- cx4 hw 0
+gd lB +- cxB 0 hw
+gd rB +- cxB hw 0
+path
+M x1 y1
+L l1 y1
+L lT t
+L rT t
+L r1 y1
+L x8 y1
+L x8 y2
+L r2 y2
+L r3 y3
+L x8 y3
+L x8 y4
+L r4 y4
+L rB b
+L lB b
+L l4 y4
+L x1 y4
+L x1 y3
+L l3 y3
+L l2 y2
+L x1 y2
+Z
+`,
+	"cornerTabs": `
+gd md mod w h 0
+gd dx */ md 1 20
+gd x1 +- r 0 dx
+gd y1 +- b 0 dx
+path
+M l t
+L dx t
+L l dx
+Z
+M l y1
+L dx b
+L l b
+Z
+M x1 t
+L r t
+L r dx
+Z
+M r y1
+L r b
+L x1 b
+Z
+`,
+	"squareTabs": `
+gd md mod w h 0
+gd dx */ md 1 20
+gd x2 +- r 0 dx
+gd y2 +- b 0 dx
+path
+M l t
+L dx t
+L dx dx
+L l dx
+Z
+M l y2
+L dx y2
+L dx b
+L l b
+Z
+M x2 t
+L r t
+L r dx
+L x2 dx
+Z
+M x2 y2
+L r y2
+L r b
+L x2 b
+Z
+`,
+	"plaqueTabs": `
+gd md mod w h 0
+gd dx */ md 1 20
+gd x2 +- r 0 dx
+gd y2 +- b 0 dx
+path
+M l t
+L dx t
+A dx dx 0 cd4
+Z
+M l y2
+A dx dx 3cd4 cd4
+L l b
+Z
+M r dx
+A dx dx cd4 cd4
+L r t
+Z
+M x2 b
+A dx dx cd2 cd4
+L r b
+Z
+`,
+	"chartX": `
+path w=10 h=10 stroke=false
+M 0 0
+L 10 0
+L 10 10
+L 0 10
+Z
+path w=10 h=10 fill=none
+M 0 0
+L 10 10
+M 0 10
+L 10 0
+`,
+	"chartStar": `
+path w=10 h=10 stroke=false
+M 0 0
+L 10 0
+L 10 10
+L 0 10
+Z
+path w=10 h=10 fill=none
+M 0 0
+L 10 10
+M 0 10
+L 10 0
+M 5 0
+L 5 10
+`,
+	"chartPlus": `
+path w=10 h=10 stroke=false
+M 0 0
+L 10 0
+L 10 10
+L 0 10
+Z
+path w=10 h=10 fill=none
+M 5 0
+L 5 10
+M 0 5
+L 10 5
+`,
+	"actionButtonBlank": `
+path stroke=false
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=none
+M l t
+L r t
+L r b
+L l b
+Z
+`,
+	"actionButtonHome": `
+gd dx2 */ ss 3 8
+gd g9 +- vc 0 dx2
+gd g10 +- vc dx2 0
+gd g11 +- hc 0 dx2
+gd g12 +- hc dx2 0
+gd dx3 */ dx2 1 2
+gd x1 +- g11 dx3 0
+gd x2 +- g12 0 dx3
+gd dx4 */ dx2 1 4
+gd x3 +- hc 0 dx4
+gd x4 +- hc dx4 0
+gd y1 +- vc dx3 0
+path stroke=false
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=darkenLess
+M hc g9
+L g12 vc
+L x2 vc
+L x2 g10
+L x4 g10
+L x4 y1
+L x3 y1
+L x3 g10
+L x1 g10
+L x1 vc
+L g11 vc
+Z
+path fill=none
+M l t
+L r t
+L r b
+L l b
+Z
+`,
+	"actionButtonHelp": `
+gd dx2 */ ss 3 8
+gd g9 +- vc 0 dx2
+gd g10 +- vc dx2 0
+gd g11 +- hc 0 dx2
+gd g12 +- hc dx2 0
+gd rad */ dx2 1 2
+gd ri */ rad 1 2
+gd y1 +- g9 rad 0
+gd x1 +- hc 0 rad
+gd y2 +- y1 ri 0
+gd sw +- rad 0 ri
+gd x3 +- hc 0 sw
+gd y3 +- y1 rad 0
+gd y4 +- y3 rad 0
+gd dr */ sw 3 4
+gd x5 +/ x3 hc 2
+gd x6 +- x5 0 dr
+gd y5 +- g10 0 dr
+path stroke=false
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=darken
+M x1 y1
+A rad rad cd2 16200000
+L hc y2
+A ri ri cd4 -16200000
+Z
+M x3 y3
+L hc y3
+L hc y4
+L x3 y4
+Z
+M x6 y5
+A dr dr cd2 21600000
+Z
+path fill=none
+M l t
+L r t
+L r b
+L l b
+Z
+`,
+	"actionButtonInformation": `
+gd dx2 */ ss 3 8
+gd g9 +- vc 0 dx2
+gd g10 +- vc dx2 0
+gd g11 +- hc 0 dx2
+gd g12 +- hc dx2 0
+gd dot */ dx2 1 8
+gd x1 +- hc 0 dot
+gd x2 +- hc dot 0
+gd dx3 */ dx2 1 2
+gd y1 +- vc 0 dx3
+gd dy2 */ dx2 1 4
+gd y2 +- vc 0 dy2
+gd dy3 */ dx2 5 8
+gd y3 +- vc dy3 0
+path stroke=false
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=darken
+M g11 vc
+A dx2 dx2 cd2 21600000
+Z
+path fill=lighten
+M x1 y1
+A dot dot cd2 21600000
+Z
+M x1 y2
+L x2 y2
+L x2 y3
+L x1 y3
+Z
+path fill=none
+M l t
+L r t
+L r b
+L l b
+Z
+`,
+	"actionButtonForwardNext": `
+gd dx2 */ ss 3 8
+gd g9 +- vc 0 dx2
+gd g10 +- vc dx2 0
+gd g11 +- hc 0 dx2
+gd g12 +- hc dx2 0
+path stroke=false
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=darken
+M g12 vc
+L g11 g10
+L g11 g9
+Z
+path fill=none
+M l t
+L r t
+L r b
+L l b
+Z
+`,
+	"actionButtonBackPrevious": `
+gd dx2 */ ss 3 8
+gd g9 +- vc 0 dx2
+gd g10 +- vc dx2 0
+gd g11 +- hc 0 dx2
+gd g12 +- hc dx2 0
+path stroke=false
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=darken
+M g11 vc
+L g12 g9
+L g12 g10
+Z
+path fill=none
+M l t
+L r t
+L r b
+L l b
+Z
+`,
+	"actionButtonEnd": `
+gd dx2 */ ss 3 8
+gd g9 +- vc 0 dx2
+gd g10 +- vc dx2 0
+gd g11 +- hc 0 dx2
+gd g12 +- hc dx2 0
+gd bw */ dx2 1 4
+gd bw2 */ bw 1 2
+gd x1 +- g12 0 bw
+gd x2 +- x1 0 bw2
+path stroke=false
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=darken
+M x2 vc
+L g11 g10
+L g11 g9
+Z
+M x1 g9
+L g12 g9
+L g12 g10
+L x1 g10
+Z
+path fill=none
+M l t
+L r t
+L r b
+L l b
+Z
+`,
+	"actionButtonBeginning": `
+gd dx2 */ ss 3 8
+gd g9 +- vc 0 dx2
+gd g10 +- vc dx2 0
+gd g11 +- hc 0 dx2
+gd g12 +- hc dx2 0
+gd bw */ dx2 1 4
+gd x1 +- g11 bw 0
+gd x2 +/ x1 bw 2
+path stroke=false
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=darken
+M x2 vc
+L g12 g9
+L g12 g10
+Z
+M g11 g9
+L x1 g9
+L x1 g10
+L g11 g10
+Z
+path fill=none
+M l t
+L r t
+L r b
+L l b
+Z
+`,
+	"actionButtonReturn": `
+gd dx2 */ ss 3 8
+gd g9 +- vc 0 dx2
+gd g10 +- vc dx2 0
+gd g11 +- hc 0 dx2
+gd g12 +- hc dx2 0
+gd tb */ dx2 1 2
+gd ri +- dx2 0 tb
+gd th2 */ tb 1 2
+gd tb3 */ tb 3 2
+gd yb +- g9 tb3 0
+gd xh1 +- g11 0 th2
+gd xt +- g11 th2 0
+gd x3 +- g11 tb 0
+gd xh2 +- x3 th2 0
+gd x4 +- g12 0 tb
+path stroke=false
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=darken
+M g12 g9
+L g12 vc
+A dx2 dx2 0 cd2
+L g11 yb
+L xh1 yb
+L xt g9
+L xh2 yb
+L x3 yb
+L x3 vc
+A ri ri cd2 -10800000
+L x4 g9
+Z
+path fill=none
+M l t
+L r t
+L r b
+L l b
+Z
+`,
+	"actionButtonDocument": `
+gd dx2 */ ss 3 8
+gd g9 +- vc 0 dx2
+gd g10 +- vc dx2 0
+gd g11 +- hc 0 dx2
+gd g12 +- hc dx2 0
+gd dx3 */ dx2 3 4
+gd x1 +- hc 0 dx3
+gd x2 +- hc dx3 0
+gd f */ dx2 1 2
+gd x3 +- x2 0 f
+gd y1 +- g9 f 0
+path stroke=false
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=lightenLess
+M x1 g9
+L x3 g9
+L x2 y1
+L x2 g10
+L x1 g10
+Z
+path fill=darken
+M x3 g9
+L x3 y1
+L x2 y1
+Z
+path fill=none
+M l t
+L r t
+L r b
+L l b
+Z
+`,
+	"actionButtonSound": `
+gd dx2 */ ss 3 8
+gd g9 +- vc 0 dx2
+gd g10 +- vc dx2 0
+gd g11 +- hc 0 dx2
+gd g12 +- hc dx2 0
+gd dy1 */ dx2 1 3
+gd y1 +- vc 0 dy1
+gd y2 +- vc dy1 0
+gd dx3 */ dx2 1 2
+gd x1 +- g11 dx3 0
+gd dx4 */ dx2 1 4
+gd x3 +- hc dx4 0
+gd y3 +- vc 0 dx3
+gd y4 +- vc dx3 0
+path stroke=false
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=darken
+M g11 y1
+L x1 y1
+L hc g9
+L hc g10
+L x1 y2
+L g11 y2
+Z
+path fill=none
+M x3 vc
+L g12 vc
+M x3 y3
+L g12 g9
+M x3 y4
+L g12 g10
+path fill=none
+M l t
+L r t
+L r b
+L l b
+Z
+`,
+	"actionButtonMovie": `
+gd dx2 */ ss 3 8
+gd g9 +- vc 0 dx2
+gd g10 +- vc dx2 0
+gd g11 +- hc 0 dx2
+gd g12 +- hc dx2 0
+gd dy1 */ dx2 1 2
+gd y1 +- vc 0 dy1
+gd y2 +- vc dy1 0
+gd dx3 */ dx2 1 3
+gd x1 +- hc dx3 0
+path stroke=false
+M l t
+L r t
+L r b
+L l b
+Z
+path fill=darken
+M g11 y1
+L x1 y1
+L x1 y2
+L g11 y2
+Z
+M x1 vc
+L g12 y1
+L g12 y2
+Z
+path fill=none
+M l t
+L r t
+L r b
+L l b
+Z
+`,
+}
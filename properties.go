@@ -9,6 +9,13 @@ type PresentationProperties struct {
 	markedAsFinal  bool
 	thumbnailPath  string
 	thumbnailData  []byte
+	guides         []Guide
+}
+
+// Guide is a drawing guide shown in PowerPoint's slide view.
+type Guide struct {
+	Vertical bool  // a vertical guide; otherwise horizontal
+	Position int64 // distance from the left (vertical) or top (horizontal) slide edge in EMU
 }
 
 // ViewType represents the last view type.
@@ -123,6 +130,16 @@ func (pp *PresentationProperties) GetThumbnailData() []byte {
 	return pp.thumbnailData
 }
 
+// GetGuides returns the slide view drawing guides.
+func (pp *PresentationProperties) GetGuides() []Guide {
+	return pp.guides
+}
+
+// SetGuides sets the slide view drawing guides.
+func (pp *PresentationProperties) SetGuides(guides []Guide) {
+	pp.guides = guides
+}
+
 // DocumentLayout represents the slide dimensions.
 type DocumentLayout struct {
 	CX   int64 // width in EMU (English Metric Units)
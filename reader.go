@@ -2,6 +2,7 @@ package gopresentation
 
 import (
 	"archive/zip"
+	"bytes"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -13,6 +14,7 @@ import (
 type Reader interface {
 	Read(path string) (*Presentation, error)
 	ReadFromReader(r io.ReaderAt, size int64) (*Presentation, error)
+	ReadBytes(data []byte) (*Presentation, error)
 }
 
 // ReaderType represents the input format.
@@ -32,8 +34,31 @@ func NewReader(format ReaderType) (Reader, error) {
 	}
 }
 
+// ReaderOptions controls what PPTXReader loads.
+type ReaderOptions struct {
+	// SkipMedia leaves the bytes of pictures and picture fills unread.
+	// Pictures keep their part name and size (DrawingShape.GetMediaPart and
+	// GetMediaSize), which is enough for text extraction and outlines and
+	// saves the memory and time of loading every image. Such a presentation
+	// renders without its pictures and cannot be saved while it has any.
+	SkipMedia bool
+	// KeepSource keeps the package in memory, so that saving the
+	// presentation copies the parts it did not change byte for byte and
+	// writes only the slides that were edited. Everything the model does
+	// not cover, such as animations, SmartArt or embedded objects, then
+	// survives the round trip. Without it a presentation is saved from the
+	// model alone. It has no effect with SkipMedia.
+	KeepSource bool
+	// CheckPackage looks for missing parts, dangling relationship IDs and
+	// invalid geometry while reading, for Validate to report. It reads the
+	// relationships and slide-like parts a second time.
+	CheckPackage bool
+}
+
 // PPTXReader reads PPTX files.
-type PPTXReader struct{}
+type PPTXReader struct {
+	Options ReaderOptions
+}
 
 // zipIndex builds a map from file name to *zip.File for O(1) lookups.
 func zipIndex(zr *zip.Reader) map[string]*zip.File {
@@ -60,6 +85,12 @@ func (r *PPTXReader) Read(path string) (*Presentation, error) {
 	return r.ReadFromReader(f, info.Size())
 }
 
+// ReadBytes reads a presentation held in memory, such as one received over
+// the network, without writing it to a file.
+func (r *PPTXReader) ReadBytes(data []byte) (*Presentation, error) {
+	return r.ReadFromReader(bytes.NewReader(data), int64(len(data)))
+}
+
 // ReadFromReader reads a presentation from an io.ReaderAt.
 func (r *PPTXReader) ReadFromReader(reader io.ReaderAt, size int64) (*Presentation, error) {
 	if size <= 0 {
@@ -79,7 +110,9 @@ func (r *PPTXReader) ReadFromReader(reader io.ReaderAt, size int64) (*Presentati
 	}
 
 	pres := &Presentation{
-		properties:             NewDocumentProperties(),
+		// The properties start blank rather than with New's defaults, so
+		// that a file without them does not report this library as author.
+		properties:             &DocumentProperties{customProps: make(map[string]*CustomProperty)},
 		presentationProperties: NewPresentationProperties(),
 		slides:                 make([]*Slide, 0),
 		slideMasters:           make([]*SlideMaster, 0),
@@ -88,27 +121,34 @@ func (r *PPTXReader) ReadFromReader(reader io.ReaderAt, size int64) (*Presentati
 
 	// Read core properties (non-fatal: missing properties are acceptable)
 	_ = r.readCoreProperties(zr, pres)
+	r.readAppProperties(zr, pres)
+	r.readCustomProperties(zr, pres)
 
 	// Read theme colors (non-fatal)
 	r.readThemeColors(zr, pres)
+	r.readThemeFonts(zr, pres)
+	r.readTableStyles(zr, pres)
 
 	// Read presentation.xml to get slide list and layout
 	slideRels, err := r.readPresentation(zr, pres)
 	if err != nil {
 		return nil, err
 	}
+	r.readViewProps(zr, pres)
 
 	// Read presentation relationships
 	presRels, err := r.readRelationships(zr, "ppt/_rels/presentation.xml.rels")
 	if err != nil {
 		return nil, err
 	}
+	r.readHandoutMaster(zr, pres, presRels)
 
 	// Read slides
+	var slideParts []string
 	for _, relID := range slideRels {
 		target := ""
 		for _, rel := range presRels {
-			if rel.ID == relID {
+			if rel.ID == relID && !rel.isExternal() {
 				target = rel.Target
 				break
 			}
@@ -127,11 +167,76 @@ func (r *PPTXReader) ReadFromReader(reader io.ReaderAt, size int64) (*Presentati
 			return nil, fmt.Errorf("failed to read slide %s: %w", target, err)
 		}
 		pres.slides = append(pres.slides, slide)
+		slideParts = append(slideParts, target)
+	}
+	resolveSlideLinks(pres, slideParts)
+	if r.Options.CheckPackage {
+		pres.packageIssues = checkPackage(zr, slideParts, pres.slides)
+	}
+	if r.Options.KeepSource && !r.Options.SkipMedia {
+		if err := keepSource(zr, pres, slideParts); err != nil {
+			return nil, err
+		}
 	}
 
 	return pres, nil
 }
 
+// resolveSlideLinks sets the slide numbers of links that jump to another
+// slide, now that the order of the slide parts is known. Links to slides
+// that are not in the presentation are dropped.
+func resolveSlideLinks(pres *Presentation, slideParts []string) {
+	numbers := make(map[string]int, len(slideParts))
+	for i, part := range slideParts {
+		numbers[part] = i + 1
+	}
+	resolve := func(h **Hyperlink) {
+		if *h == nil || (*h).slidePart == "" {
+			return
+		}
+		if n, ok := numbers[(*h).slidePart]; ok {
+			(*h).SlideNumber = n
+			(*h).slidePart = ""
+		} else {
+			*h = nil
+		}
+	}
+	resolveParagraphs := func(paragraphs []*Paragraph) {
+		for _, para := range paragraphs {
+			for _, elem := range para.elements {
+				if tr, ok := elem.(*TextRun); ok {
+					resolve(&tr.hyperlink)
+				}
+			}
+		}
+	}
+	var walk func(shapes []Shape)
+	walk = func(shapes []Shape) {
+		for _, shape := range shapes {
+			resolve(&shape.base().hyperlink)
+			switch s := shape.(type) {
+			case *PlaceholderShape:
+				resolveParagraphs(s.paragraphs)
+			case *RichTextShape:
+				resolveParagraphs(s.paragraphs)
+			case *AutoShape:
+				resolveParagraphs(s.paragraphs)
+			case *TableShape:
+				for _, row := range s.rows {
+					for _, cell := range row {
+						resolveParagraphs(cell.paragraphs)
+					}
+				}
+			case *GroupShape:
+				walk(s.shapes)
+			}
+		}
+	}
+	for _, slide := range pres.slides {
+		walk(slide.shapes)
+	}
+}
+
 // maxZipEntrySize is the maximum allowed size for a single file extracted from a ZIP.
 // This prevents zip bomb attacks. 50 MB is generous for any legitimate PPTX part.
 const maxZipEntrySize = 50 << 20 // 50 MB
@@ -148,27 +253,47 @@ func readFileFromZip(zr *zip.Reader, name string) ([]byte, error) {
 	}
 	for _, f := range zr.File {
 		if f.Name == name {
-			if f.UncompressedSize64 > maxZipEntrySize {
-				return nil, fmt.Errorf("file %s exceeds maximum allowed size (%d bytes)", name, maxZipEntrySize)
-			}
-			rc, err := f.Open()
-			if err != nil {
-				return nil, fmt.Errorf("failed to open %s in zip: %w", name, err)
-			}
-			defer rc.Close()
-			data, err := io.ReadAll(io.LimitReader(rc, int64(maxZipEntrySize)+1))
-			if err != nil {
-				return nil, fmt.Errorf("failed to read %s from zip: %w", name, err)
-			}
-			if int64(len(data)) > int64(maxZipEntrySize) {
-				return nil, fmt.Errorf("file %s actual size exceeds maximum allowed size", name)
-			}
-			return data, nil
+			return readZipFile(f)
 		}
 	}
 	return nil, fmt.Errorf("file not found in zip: %s", name)
 }
 
+// readZipFile reads one entry of a ZIP, within the size limits.
+func readZipFile(f *zip.File) ([]byte, error) {
+	if f.UncompressedSize64 > maxZipEntrySize {
+		return nil, fmt.Errorf("file %s exceeds maximum allowed size (%d bytes)", f.Name, maxZipEntrySize)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s in zip: %w", f.Name, err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, int64(maxZipEntrySize)+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s from zip: %w", f.Name, err)
+	}
+	if int64(len(data)) > int64(maxZipEntrySize) {
+		return nil, fmt.Errorf("file %s actual size exceeds maximum allowed size", f.Name)
+	}
+	return data, nil
+}
+
+// readMedia reads a media part and returns it with its size. With
+// Options.SkipMedia it only looks the part up and returns nil data.
+func (r *PPTXReader) readMedia(zr *zip.Reader, name string) ([]byte, int64, error) {
+	if !r.Options.SkipMedia {
+		data, err := readFileFromZip(zr, name)
+		return data, int64(len(data)), err
+	}
+	for _, f := range zr.File {
+		if f.Name == name {
+			return nil, int64(f.UncompressedSize64), nil
+		}
+	}
+	return nil, 0, fmt.Errorf("file not found in zip: %s", name)
+}
+
 // --- Relationship reading ---
 
 type xmlRelForRead struct {
@@ -178,6 +303,12 @@ type xmlRelForRead struct {
 	TargetMode string `xml:"TargetMode,attr"`
 }
 
+// isExternal reports whether the relationship points outside the package
+// (TargetMode="External"), such as a linked image or a hyperlink.
+func (rel xmlRelForRead) isExternal() bool {
+	return strings.EqualFold(rel.TargetMode, "External")
+}
+
 type xmlRelsForRead struct {
 	XMLName       xml.Name         `xml:"Relationships"`
 	Relationships []xmlRelForRead  `xml:"Relationship"`
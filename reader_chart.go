@@ -0,0 +1,669 @@
+package gopresentation
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// chartPartPath returns the chart part that relationship chartID of the
+// slide at slidePath points to, or "".
+func chartPartPath(rels []xmlRelForRead, slidePath, chartID string) string {
+	for _, rel := range rels {
+		if rel.ID == chartID && !rel.isExternal() {
+			return resolveRelativePath(path.Dir(slidePath), rel.Target)
+		}
+	}
+	return ""
+}
+
+// readChart reads the chart part chartPart into a chart shape, or returns
+// nil when the part is missing or holds no chart type the model has.
+func (r *PPTXReader) readChart(zr *zip.Reader, chartPart string, pres *Presentation) *ChartShape {
+	data, err := readFileFromZip(zr, chartPart)
+	if err != nil {
+		return nil
+	}
+	return parseChartXML(data, pres)
+}
+
+// chartAxisRead is an axis of the plot area with what decides whether it
+// is the X or the Y axis.
+type chartAxisRead struct {
+	kind string // catAx, dateAx, valAx or serAx
+	pos  string // axPos: b, t, l or r
+	axis *ChartAxis
+}
+
+// parseChartXML parses a c:chartSpace part. Only the first chart type of
+// the plot area is kept, as the model has one per chart; series values and
+// categories come from the caches the part keeps of the embedded workbook.
+func parseChartXML(data []byte, pres *Presentation) *ChartShape {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	chart := NewChartShape()
+	chart.legend.Visible = false
+
+	var (
+		stack     []string
+		ct        ChartType
+		ctElem    string // element of ct while it is open
+		bar       *BarChart
+		series    []*ChartSeries
+		smooth    bool
+		ser       *ChartSeries
+		ownLbls   []bool // whether each series has its own c:dLbls
+		serFill   *Color
+		serLine   *Color
+		cats      []string
+		vals      []float64
+		ptIdx     int
+		lbls      *ChartSeries // receives the c:dLbls being read
+		typeLbls  *ChartSeries // c:dLbls of the chart type, for series without their own
+		axes      []*chartAxisRead
+		hasTitle  bool
+		ax        *chartAxisRead
+		lastColor *Color
+		text      strings.Builder
+		titleText []string
+		axisTitle []string
+	)
+
+	parent := func() string {
+		if len(stack) < 2 {
+			return ""
+		}
+		return stack[len(stack)-2]
+	}
+	inside := func(name string) bool {
+		for _, s := range stack[:max(len(stack)-1, 0)] {
+			if s == name {
+				return true
+			}
+		}
+		return false
+	}
+	// pathIs reports whether the open elements end with names.
+	pathIs := func(names ...string) bool {
+		if len(names) > len(stack) {
+			return false
+		}
+		for i, n := range names {
+			if stack[len(stack)-len(names)+i] != n {
+				return false
+			}
+		}
+		return true
+	}
+
+	mc := newMCFilter()
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		if mc.skip(decoder, token) {
+			continue
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "extLst" {
+				decoder.Skip()
+				continue
+			}
+			stack = append(stack, t.Name.Local)
+			val, hasVal := "", false
+			for _, attr := range t.Attr {
+				if attr.Name.Local == "val" {
+					val, hasVal = attr.Value, true
+				}
+			}
+			// Boolean elements are true when they have no val.
+			on := !hasVal || val == "1" || val == "true"
+
+			switch t.Name.Local {
+			case "barChart", "bar3DChart", "lineChart", "line3DChart", "areaChart", "area3DChart",
+				"pieChart", "pie3DChart", "ofPieChart", "doughnutChart", "scatterChart", "radarChart":
+				if ct != nil || !inside("plotArea") {
+					decoder.Skip()
+					stack = stack[:len(stack)-1]
+					continue
+				}
+				ctElem = t.Name.Local
+				switch t.Name.Local {
+				case "barChart":
+					bar = NewBarChart()
+					ct = bar
+				case "bar3DChart":
+					c := NewBar3DChart()
+					bar = &c.BarChart
+					ct = c
+				case "lineChart", "line3DChart":
+					ct = NewLineChart()
+				case "areaChart", "area3DChart":
+					ct = NewAreaChart()
+				case "pieChart", "ofPieChart":
+					ct = NewPieChart()
+				case "pie3DChart":
+					ct = NewPie3DChart()
+				case "doughnutChart":
+					ct = NewDoughnutChart()
+				case "scatterChart":
+					ct = NewScatterChart()
+				case "radarChart":
+					ct = NewRadarChart()
+				}
+			case "barDir":
+				if bar != nil && hasVal {
+					bar.BarDirection = val
+				}
+			case "grouping":
+				if bar != nil && hasVal {
+					if val == "standard" {
+						val = BarGroupingClustered
+					}
+					bar.BarGrouping = val
+				}
+			case "gapWidth":
+				if bar != nil {
+					if v, err := strconv.Atoi(strings.TrimSuffix(val, "%")); err == nil {
+						bar.GapWidthPercent = v
+					}
+				}
+			case "overlap":
+				if bar != nil {
+					if v, err := strconv.Atoi(strings.TrimSuffix(val, "%")); err == nil {
+						bar.OverlapPercent = v
+					}
+				}
+			case "holeSize":
+				if d, ok := ct.(*DoughnutChart); ok {
+					if v, err := strconv.Atoi(strings.TrimSuffix(val, "%")); err == nil {
+						d.HoleSize = v
+					}
+				}
+			case "smooth":
+				if ser != nil && on {
+					smooth = true
+				}
+			case "ser":
+				if ct != nil && parent() == ctElem {
+					ser = &ChartSeries{Values: make(map[string]float64), Font: NewFont(), Separator: ","}
+					serFill, serLine = nil, nil
+					ownLbls = append(ownLbls, false)
+					cats, vals = nil, nil
+				}
+			case "dLbls":
+				switch {
+				case ser != nil && parent() == "ser":
+					lbls = ser
+					ownLbls[len(ownLbls)-1] = true
+				case ct != nil && parent() == ctElem:
+					typeLbls = &ChartSeries{Separator: ","}
+					lbls = typeLbls
+				}
+			case "showVal", "showCatName", "showPercent", "showSerName", "showLegendKey":
+				if lbls != nil && parent() == "dLbls" {
+					switch t.Name.Local {
+					case "showVal":
+						lbls.ShowValue = on
+					case "showCatName":
+						lbls.ShowCategoryName = on
+					case "showPercent":
+						lbls.ShowPercentage = on
+					case "showSerName":
+						lbls.ShowSeriesName = on
+					case "showLegendKey":
+						lbls.ShowLegendKey = on
+					}
+				}
+			case "dLblPos":
+				if lbls != nil && parent() == "dLbls" {
+					lbls.LabelPosition = val
+				}
+			case "numFmt":
+				code := ""
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "formatCode" {
+						code = attr.Value
+					}
+				}
+				if code == "General" {
+					code = ""
+				}
+				if lbls != nil && parent() == "dLbls" {
+					lbls.NumberFormat = code
+				} else if ax != nil && parent() == ax.kind {
+					ax.axis.NumberFormat = code
+				}
+			case "symbol":
+				if ser != nil && pathIs("ser", "marker", "symbol") {
+					if ser.Marker == nil {
+						ser.Marker = &SeriesMarker{}
+					}
+					ser.Marker.Symbol = val
+				}
+			case "size":
+				if ser != nil && pathIs("ser", "marker", "size") {
+					if ser.Marker == nil {
+						ser.Marker = &SeriesMarker{Symbol: MarkerNone}
+					}
+					ser.Marker.Size, _ = strconv.Atoi(val)
+				}
+			case "ptCount":
+				if ser != nil {
+					if n, err := strconv.Atoi(val); err == nil && n >= 0 && n <= 1<<16 {
+						if inside("cat") || inside("xVal") {
+							cats = growTo(cats, n)
+						} else if inside("val") || inside("yVal") {
+							vals = growTo(vals, n)
+						}
+					}
+				}
+			case "pt":
+				ptIdx = 0
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "idx" {
+						ptIdx, _ = strconv.Atoi(attr.Value)
+					}
+				}
+			case "v", "t", "separator":
+				text.Reset()
+
+			case "title":
+				if ax != nil && parent() == ax.kind {
+					axisTitle = nil
+				} else if parent() == "chart" {
+					titleText, hasTitle = nil, true
+				}
+			case "autoTitleDeleted":
+				if parent() == "chart" {
+					chart.title.Visible = !on
+				}
+			case "overlay":
+				if pathIs("chart", "title", "overlay") {
+					chart.title.Overlay = on
+				}
+			case "rPr", "defRPr":
+				if pathIs("chart", "title", "tx", "rich", "p", "r", "rPr") || pathIs("chart", "title", "tx", "rich", "p", "pPr", "defRPr") {
+					for _, attr := range t.Attr {
+						switch attr.Name.Local {
+						case "sz":
+							if v, err := strconv.Atoi(attr.Value); err == nil {
+								chart.title.Font.Size = v / 100
+							}
+						case "b":
+							chart.title.Font.Bold = attr.Value == "1" || attr.Value == "true"
+						}
+					}
+				}
+
+			case "catAx", "dateAx", "valAx", "serAx":
+				if parent() == "plotArea" {
+					ax = &chartAxisRead{kind: t.Name.Local, axis: NewChartAxis()}
+					axes = append(axes, ax)
+				}
+			case "axPos":
+				if ax != nil && parent() == ax.kind {
+					ax.pos = val
+				}
+			case "delete":
+				if ax != nil && parent() == ax.kind {
+					ax.axis.Visible = !on
+				}
+			case "orientation":
+				if ax != nil && pathIs(ax.kind, "scaling", "orientation") {
+					ax.axis.ReversedOrder = val == "maxMin"
+				}
+			case "min", "max", "majorUnit", "minorUnit":
+				if ax != nil && (pathIs(ax.kind, "scaling", t.Name.Local) || parent() == ax.kind) {
+					if v, err := strconv.ParseFloat(val, 64); err == nil {
+						switch t.Name.Local {
+						case "min":
+							ax.axis.MinBounds = &v
+						case "max":
+							ax.axis.MaxBounds = &v
+						case "majorUnit":
+							ax.axis.MajorUnit = &v
+						case "minorUnit":
+							ax.axis.MinorUnit = &v
+						}
+					}
+				}
+			case "crosses":
+				if ax != nil && parent() == ax.kind && hasVal {
+					ax.axis.CrossesAt = val
+				}
+			case "majorTickMark", "minorTickMark", "tickLblPos":
+				if ax != nil && parent() == ax.kind {
+					if !hasVal {
+						val = TickMarkCross
+						if t.Name.Local == "tickLblPos" {
+							val = TickLabelPosNextTo
+						}
+					}
+					switch t.Name.Local {
+					case "majorTickMark":
+						ax.axis.MajorTickMark = val
+					case "minorTickMark":
+						ax.axis.MinorTickMark = val
+					case "tickLblPos":
+						ax.axis.TickLabelPos = val
+					}
+				}
+			case "tickLblSkip":
+				if ax != nil && parent() == ax.kind {
+					ax.axis.TickLabelSkip, _ = strconv.Atoi(val)
+				}
+			case "majorGridlines", "minorGridlines":
+				if ax != nil && parent() == ax.kind {
+					if t.Name.Local == "majorGridlines" {
+						ax.axis.MajorGridlines = NewGridlines()
+					} else {
+						ax.axis.MinorGridlines = NewGridlines()
+					}
+				}
+			case "bodyPr":
+				if ax != nil && pathIs(ax.kind, "txPr", "bodyPr") {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "rot" {
+							if v, err := strconv.Atoi(attr.Value); err == nil {
+								ax.axis.LabelRotation = v / 60000
+							}
+						}
+					}
+				}
+			case "ln":
+				if ax != nil && (pathIs("majorGridlines", "spPr", "ln") || pathIs("minorGridlines", "spPr", "ln")) {
+					if gl := axisGridlines(ax.axis, stack[len(stack)-3]); gl != nil {
+						for _, attr := range t.Attr {
+							if attr.Name.Local == "w" {
+								if v, err := strconv.Atoi(attr.Value); err == nil {
+									gl.Width = max(v/12700, 1)
+								}
+							}
+						}
+					}
+				}
+
+			case "legend":
+				if parent() == "chart" {
+					chart.legend.Visible = true
+					chart.legend.Position = LegendRight
+				}
+			case "legendPos":
+				if parent() == "legend" && hasVal {
+					chart.legend.Position = LegendPosition(val)
+				}
+			case "rotX", "rotY", "depthPercent", "hPercent", "rAngAx":
+				if parent() == "view3D" {
+					v, err := strconv.Atoi(strings.TrimSuffix(val, "%"))
+					switch t.Name.Local {
+					case "rotX":
+						if err == nil {
+							chart.view3D.RotX = v
+						}
+					case "rotY":
+						if err == nil {
+							chart.view3D.RotY = v
+						}
+					case "depthPercent":
+						if err == nil {
+							chart.view3D.DepthPercent = v
+						}
+					case "hPercent":
+						if err == nil {
+							chart.view3D.HeightPercent = &v
+						}
+					case "rAngAx":
+						chart.view3D.RightAngleAxes = on
+					}
+				}
+			case "dispBlanksAs":
+				if parent() == "chart" && hasVal {
+					chart.displayBlankAs = val
+				}
+
+			case "srgbClr", "schemeClr", "sysClr", "prstClr":
+				lastColor = nil
+				c, ok := colorElementValue(t, pres)
+				if !ok || parent() != "solidFill" {
+					break
+				}
+				switch {
+				case ser != nil && pathIs("ser", "spPr", "solidFill", t.Name.Local):
+					serFill = &c
+					lastColor = serFill
+				case ser != nil && pathIs("ser", "spPr", "ln", "solidFill", t.Name.Local):
+					serLine = &c
+					lastColor = serLine
+				case ax != nil && inside("spPr") && (inside("majorGridlines") || inside("minorGridlines")):
+					if gl := axisGridlines(ax.axis, stack[len(stack)-5]); gl != nil {
+						gl.Color = c
+						lastColor = &gl.Color
+					}
+				case pathIs("chart", "title", "tx", "rich", "p", "r", "rPr", "solidFill", t.Name.Local):
+					chart.title.Font.Color = c
+					lastColor = &chart.title.Font.Color
+				}
+			case "lumMod", "lumOff", "tint", "shade":
+				if lastColor != nil {
+					if v, err := strconv.Atoi(val); err == nil {
+						modifyColor(lastColor, t.Name.Local, float64(v)/100000.0)
+					}
+				}
+			}
+
+		case xml.CharData:
+			if len(stack) > 0 {
+				switch stack[len(stack)-1] {
+				case "v", "t", "separator":
+					text.Write(t)
+				}
+			}
+
+		case xml.EndElement:
+			if len(stack) == 0 {
+				continue
+			}
+			switch t.Name.Local {
+			case "v":
+				s := text.String()
+				switch {
+				case ser != nil && inside("tx") && !inside("dLbls"):
+					ser.Title += s
+				case ser != nil && (inside("cat") || inside("xVal")):
+					cats = setAt(cats, ptIdx, s)
+				case ser != nil && (inside("val") || inside("yVal")):
+					if v, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+						vals = setAt(vals, ptIdx, v)
+					}
+				case ax == nil && inside("title") && !inside("plotArea"):
+					titleText = append(titleText, s)
+				}
+			case "t":
+				if ax != nil && inside("title") && inside(ax.kind) {
+					axisTitle = append(axisTitle, text.String())
+				} else if inside("title") && !inside("plotArea") {
+					titleText = append(titleText, text.String())
+				}
+			case "separator":
+				if lbls != nil && parent() == "dLbls" {
+					lbls.Separator = text.String()
+				}
+			case "p":
+				if inside("title") && inside("rich") {
+					if ax != nil && inside(ax.kind) {
+						axisTitle = append(axisTitle, " ")
+					} else if !inside("plotArea") {
+						titleText = append(titleText, " ")
+					}
+				}
+			case "title":
+				if ax != nil && parent() == ax.kind {
+					ax.axis.Title = strings.TrimSpace(strings.Join(axisTitle, ""))
+				} else if parent() == "chart" {
+					chart.title.Text = strings.TrimSpace(strings.Join(titleText, ""))
+				}
+			case "srgbClr", "schemeClr", "sysClr", "prstClr":
+				lastColor = nil
+			case "dLbls":
+				lbls = nil
+			case "ser":
+				if ser != nil && parent() == ctElem {
+					switch ct.(type) {
+					case *LineChart, *ScatterChart, *RadarChart:
+						if serLine != nil {
+							ser.FillColor = *serLine
+						} else if serFill != nil {
+							ser.FillColor = *serFill
+						}
+					default:
+						if serFill != nil {
+							ser.FillColor = *serFill
+						} else if serLine != nil {
+							ser.FillColor = *serLine
+						}
+					}
+					if len(cats) == 0 {
+						// Without categories the points are numbered.
+						for i := range vals {
+							cats = append(cats, strconv.Itoa(i+1))
+						}
+					}
+					ser.Categories = cats
+					for i, cat := range cats {
+						if i < len(vals) {
+							ser.Values[cat] = vals[i]
+						} else {
+							ser.Values[cat] = 0
+						}
+					}
+					series = append(series, ser)
+					ser = nil
+				}
+			case ctElem:
+				if ctElem != "" && parent() == "plotArea" {
+					// The chart type's c:dLbls follows its series and
+					// applies to those without their own.
+					for i, s := range series {
+						if typeLbls != nil && !ownLbls[i] {
+							s.ShowValue = typeLbls.ShowValue
+							s.ShowCategoryName = typeLbls.ShowCategoryName
+							s.ShowPercentage = typeLbls.ShowPercentage
+							s.ShowSeriesName = typeLbls.ShowSeriesName
+							s.ShowLegendKey = typeLbls.ShowLegendKey
+							s.Separator = typeLbls.Separator
+							s.LabelPosition = typeLbls.LabelPosition
+							s.NumberFormat = typeLbls.NumberFormat
+						}
+					}
+					setChartSeries(ct, series)
+					switch c := ct.(type) {
+					case *LineChart:
+						c.IsSmooth = smooth
+					case *ScatterChart:
+						c.IsSmooth = smooth
+					}
+					ctElem = ""
+				}
+			case "catAx", "dateAx", "valAx", "serAx":
+				if ax != nil && parent() == "plotArea" {
+					ax = nil
+				}
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	if ct == nil {
+		return nil
+	}
+	chart.plotArea.chartType = ct
+	// Category and date axes are X; of two value axes, as in scatter
+	// charts, the one along the bottom or top is.
+	var xSet, ySet bool
+	for _, a := range axes {
+		switch {
+		case a.kind == "serAx":
+		case !xSet && (a.kind == "catAx" || a.kind == "dateAx"):
+			chart.plotArea.axisX, xSet = a.axis, true
+		}
+	}
+	for _, a := range axes {
+		if a.kind != "valAx" {
+			continue
+		}
+		if !xSet && (a.pos == "b" || a.pos == "t") {
+			chart.plotArea.axisX, xSet = a.axis, true
+		} else if !ySet {
+			chart.plotArea.axisY, ySet = a.axis, true
+		}
+	}
+	// A chart with a single series and a title without text shows the
+	// series name, as PowerPoint titles it automatically.
+	if chart.title.Visible && chart.title.Text == "" && hasTitle && len(series) == 1 {
+		chart.title.Text = series[0].Title
+	}
+	return chart
+}
+
+// axisGridlines returns the gridlines of a that element name, majorGridlines
+// or minorGridlines, describes.
+func axisGridlines(a *ChartAxis, name string) *Gridlines {
+	switch name {
+	case "majorGridlines":
+		return a.MajorGridlines
+	case "minorGridlines":
+		return a.MinorGridlines
+	}
+	return nil
+}
+
+// setChartSeries sets the series of any chart type.
+func setChartSeries(ct ChartType, series []*ChartSeries) {
+	if series == nil {
+		series = make([]*ChartSeries, 0)
+	}
+	switch c := ct.(type) {
+	case *BarChart:
+		c.Series = series
+	case *Bar3DChart:
+		c.Series = series
+	case *LineChart:
+		c.Series = series
+	case *AreaChart:
+		c.Series = series
+	case *PieChart:
+		c.Series = series
+	case *Pie3DChart:
+		c.Series = series
+	case *DoughnutChart:
+		c.Series = series
+	case *ScatterChart:
+		c.Series = series
+	case *RadarChart:
+		c.Series = series
+	}
+}
+
+// growTo returns s extended with zero values to at least n elements.
+func growTo[T any](s []T, n int) []T {
+	if n > len(s) {
+		s = append(s, make([]T, n-len(s))...)
+	}
+	return s
+}
+
+// setAt sets s[i], growing s as needed, and returns s. Negative or very
+// large indexes from a damaged part are ignored.
+func setAt[T any](s []T, i int, v T) []T {
+	if i < 0 || i > 1<<16 {
+		return s
+	}
+	s = growTo(s, i+1)
+	s[i] = v
+	return s
+}
@@ -0,0 +1,102 @@
+package gopresentation
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// mcSupported lists the namespaces the reader understands when choosing an
+// mc:Choice. Choices that require anything else, such as p14 ink, cx chart
+// extensions or am3d 3D models, are skipped in favor of the Fallback.
+var mcSupported = map[string]bool{
+	nsPresentationML: true,
+	nsDrawingML:      true,
+	nsOfficeDocRels:  true,
+	nsMarkupCompat:   true,
+	"http://schemas.openxmlformats.org/drawingml/2006/picture": true,
+	"http://schemas.openxmlformats.org/drawingml/2006/chart":   true,
+}
+
+// mcFilter selects one branch of each mc:AlternateContent in a token
+// stream: the first mc:Choice whose Requires namespaces are all supported,
+// or else the mc:Fallback. Parsers call skip for every token and ignore
+// the tokens it reports; the other branches are skipped in the decoder.
+type mcFilter struct {
+	prefixes map[string]string // namespace prefixes declared so far
+	chosen   []bool            // per open AlternateContent: a branch was taken
+	// extra holds namespaces the caller supports in its current context,
+	// in addition to mcSupported.
+	extra map[string]bool
+}
+
+// nsP14 is the PowerPoint 2010 namespace. Its Choice branches carry
+// transition durations, which the reader supports, but also ink content
+// parts, which it does not.
+const nsP14 = "http://schemas.microsoft.com/office/powerpoint/2010/main"
+
+// mcTransitions is the extra namespace set for slide-level content.
+var mcTransitions = map[string]bool{nsP14: true}
+
+func newMCFilter() *mcFilter {
+	return &mcFilter{prefixes: make(map[string]string)}
+}
+
+// skip reports whether tok should be ignored by the parser. It skips
+// unselected branches in d and hides the AlternateContent, Choice and
+// Fallback wrappers themselves.
+func (m *mcFilter) skip(d *xml.Decoder, tok xml.Token) bool {
+	switch t := tok.(type) {
+	case xml.StartElement:
+		for _, attr := range t.Attr {
+			if attr.Name.Space == "xmlns" {
+				m.prefixes[attr.Name.Local] = attr.Value
+			}
+		}
+		if t.Name.Space != nsMarkupCompat && t.Name.Space != "mc" {
+			return false
+		}
+		switch t.Name.Local {
+		case "AlternateContent":
+			m.chosen = append(m.chosen, false)
+		case "Choice", "Fallback":
+			n := len(m.chosen)
+			if n == 0 || m.chosen[n-1] || (t.Name.Local == "Choice" && !m.supported(t)) {
+				d.Skip()
+				return true
+			}
+			m.chosen[n-1] = true
+		}
+		return true
+	case xml.EndElement:
+		if t.Name.Space != nsMarkupCompat && t.Name.Space != "mc" {
+			return false
+		}
+		switch t.Name.Local {
+		case "AlternateContent":
+			if n := len(m.chosen); n > 0 {
+				m.chosen = m.chosen[:n-1]
+			}
+		case "Choice", "Fallback":
+		default:
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// supported reports whether every prefix in a Choice's Requires list is
+// bound to a supported namespace.
+func (m *mcFilter) supported(t xml.StartElement) bool {
+	for _, attr := range t.Attr {
+		if attr.Name.Local != "Requires" {
+			continue
+		}
+		for _, p := range strings.Fields(attr.Value) {
+			if ns := m.prefixes[p]; !mcSupported[ns] && !m.extra[ns] {
+				return false
+			}
+		}
+	}
+	return true
+}
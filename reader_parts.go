@@ -2,8 +2,10 @@ package gopresentation
 
 import (
 	"archive/zip"
+	"bytes"
 	"encoding/xml"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -50,14 +52,18 @@ func (r *PPTXReader) readCoreProperties(zr *zip.Reader, pres *Presentation) erro
 				props.Keywords = text
 			case "category":
 				props.Category = text
+			case "language":
+				props.Language = text
 			case "revision":
 				props.Revision = text
+			case "contentStatus":
+				props.Status = text
 			case "created":
-				if t, err := time.Parse("2006-01-02T15:04:05Z", text); err == nil {
+				if t, ok := parseW3CDTF(text); ok {
 					props.Created = t
 				}
 			case "modified":
-				if t, err := time.Parse("2006-01-02T15:04:05Z", text); err == nil {
+				if t, ok := parseW3CDTF(text); ok {
 					props.Modified = t
 				}
 			}
@@ -66,6 +72,95 @@ func (r *PPTXReader) readCoreProperties(zr *zip.Reader, pres *Presentation) erro
 	return nil
 }
 
+// w3cdtfLayouts are the forms of W3CDTF dates used in document properties,
+// most precise first.
+var w3cdtfLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02",
+	"2006-01",
+	"2006",
+}
+
+// parseW3CDTF parses a W3CDTF date, such as "2024-03-01T09:30:00.5+01:00"
+// or "2024-03-01". Dates without a zone are taken as UTC.
+func parseW3CDTF(s string) (time.Time, bool) {
+	for _, layout := range w3cdtfLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// readAppProperties reads the company from docProps/app.xml.
+func (r *PPTXReader) readAppProperties(zr *zip.Reader, pres *Presentation) {
+	data, err := readFileFromZip(zr, "docProps/app.xml")
+	if err != nil {
+		return
+	}
+	var app struct {
+		Company string `xml:"Company"`
+	}
+	if xml.Unmarshal(data, &app) == nil {
+		pres.properties.Company = strings.TrimSpace(app.Company)
+	}
+}
+
+// xmlCustomProperty is a property of docProps/custom.xml; its one child
+// element (vt:lpwstr, vt:i4, vt:bool, ...) holds the typed value.
+type xmlCustomProperty struct {
+	Name  string `xml:"name,attr"`
+	Value []struct {
+		XMLName xml.Name
+		Text    string `xml:",chardata"`
+	} `xml:",any"`
+}
+
+// readCustomProperties reads docProps/custom.xml into the custom document
+// properties. Values of types without a Go counterpart are kept as text
+// with PropertyTypeUnknown.
+func (r *PPTXReader) readCustomProperties(zr *zip.Reader, pres *Presentation) {
+	data, err := readFileFromZip(zr, "docProps/custom.xml")
+	if err != nil {
+		return
+	}
+	var custom struct {
+		Properties []xmlCustomProperty `xml:"property"`
+	}
+	if xml.Unmarshal(data, &custom) != nil {
+		return
+	}
+	for _, p := range custom.Properties {
+		if p.Name == "" || len(p.Value) == 0 {
+			continue
+		}
+		text := strings.TrimSpace(p.Value[0].Text)
+		var value interface{} = text
+		propType := PropertyTypeUnknown
+		switch p.Value[0].XMLName.Local {
+		case "lpwstr", "lpstr", "bstr":
+			value, propType = p.Value[0].Text, PropertyTypeString
+		case "bool":
+			value, propType = text == "true" || text == "1", PropertyTypeBoolean
+		case "i1", "i2", "i4", "i8", "int", "ui1", "ui2", "ui4", "ui8", "uint":
+			if n, err := strconv.Atoi(text); err == nil {
+				value, propType = n, PropertyTypeInteger
+			}
+		case "r4", "r8", "decimal":
+			if f, err := strconv.ParseFloat(text, 64); err == nil {
+				value, propType = f, PropertyTypeFloat
+			}
+		case "filetime", "date":
+			if t, ok := parseW3CDTF(text); ok {
+				value, propType = t, PropertyTypeDate
+			}
+		}
+		pres.properties.SetCustomProperty(p.Name, value, propType)
+	}
+}
+
 // --- Presentation ---
 
 type xmlPresentation struct {
@@ -112,6 +207,8 @@ func (r *PPTXReader) readPresentation(zr *zip.Reader, pres *Presentation) ([]str
 	// Parse using streaming to handle namespaces properly
 	decoder := xml.NewDecoder(strings.NewReader(string(data)))
 	var slideRelIDs []string
+	inEmbeddedFont := false
+	inSldGuideLst := false
 
 	for {
 		token, err := decoder.Token()
@@ -137,6 +234,52 @@ func (r *PPTXReader) readPresentation(zr *zip.Reader, pres *Presentation) ([]str
 						pres.layout.Name = attr.Value
 					}
 				}
+			case "notesSz":
+				for _, attr := range t.Attr {
+					switch attr.Name.Local {
+					case "cx":
+						if v, err := strconv.ParseInt(attr.Value, 10, 64); err == nil {
+							pres.notesSize.CX = v
+						}
+					case "cy":
+						if v, err := strconv.ParseInt(attr.Value, 10, 64); err == nil {
+							pres.notesSize.CY = v
+						}
+					}
+				}
+			case "photoAlbum":
+				album := &PhotoAlbum{}
+				for _, attr := range t.Attr {
+					switch attr.Name.Local {
+					case "bw":
+						album.BlackAndWhite = attr.Value == "1" || attr.Value == "true"
+					case "showCaptions":
+						album.ShowCaptions = attr.Value == "1" || attr.Value == "true"
+					case "layout":
+						album.Layout = PhotoAlbumLayout(attr.Value)
+					case "frame":
+						album.Frame = PhotoAlbumFrame(attr.Value)
+					}
+				}
+				pres.photoAlbum = album
+			case "embeddedFont":
+				inEmbeddedFont = true
+			case "sldGuideLst":
+				// PowerPoint 2013 and later keep the slide guides in p15:sldGuideLst.
+				inSldGuideLst = true
+			case "guide":
+				if inSldGuideLst {
+					pp := pres.presentationProperties
+					pp.guides = append(pp.guides, parseGuide(t.Attr))
+				}
+			case "font":
+				if inEmbeddedFont {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "typeface" && attr.Value != "" {
+							pres.embeddedFonts = append(pres.embeddedFonts, attr.Value)
+						}
+					}
+				}
 			case "sldId":
 				for _, attr := range t.Attr {
 					if attr.Name.Local == "id" && attr.Name.Space != "" {
@@ -146,6 +289,13 @@ func (r *PPTXReader) readPresentation(zr *zip.Reader, pres *Presentation) ([]str
 					}
 				}
 			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "embeddedFont":
+				inEmbeddedFont = false
+			case "sldGuideLst":
+				inSldGuideLst = false
+			}
 		}
 	}
 
@@ -164,6 +314,141 @@ func (r *PPTXReader) readPresentation(zr *zip.Reader, pres *Presentation) ([]str
 	return slideRelIDs, nil
 }
 
+// parseGuide reads a p:guide or p15:guide element. Positions are in
+// eighths of a point.
+func parseGuide(attrs []xml.Attr) Guide {
+	var g Guide
+	for _, attr := range attrs {
+		switch attr.Name.Local {
+		case "orient":
+			g.Vertical = attr.Value == "vert"
+		case "pos":
+			if v, err := strconv.ParseInt(attr.Value, 10, 64); err == nil {
+				g.Position = v * 12700 / 8
+			}
+		}
+	}
+	return g
+}
+
+// --- View Properties ---
+
+// readViewProps reads the slide view guides of older files, which keep them
+// in viewProps.xml rather than in presentation.xml.
+func (r *PPTXReader) readViewProps(zr *zip.Reader, pres *Presentation) {
+	pp := pres.presentationProperties
+	if len(pp.guides) > 0 {
+		return
+	}
+	data, err := readFileFromZip(zr, "ppt/viewProps.xml")
+	if err != nil {
+		return
+	}
+	decoder := xml.NewDecoder(strings.NewReader(string(data)))
+	inSlideView := false
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "slideViewPr":
+				inSlideView = true
+			case "guide":
+				if inSlideView {
+					pp.guides = append(pp.guides, parseGuide(t.Attr))
+				}
+			}
+		case xml.EndElement:
+			if t.Name.Local == "slideViewPr" {
+				inSlideView = false
+			}
+		}
+	}
+}
+
+// readHandoutMaster reads the frames of the handout master's placeholders,
+// which HandoutToImages places the slides between.
+func (r *PPTXReader) readHandoutMaster(zr *zip.Reader, pres *Presentation, presRels []xmlRelForRead) {
+	var part string
+	for _, rel := range presRels {
+		if rel.Type == relTypeHandoutMaster && !rel.isExternal() {
+			part = resolveRelativePath("ppt", rel.Target)
+			break
+		}
+	}
+	if part == "" {
+		return
+	}
+	data, err := readFileFromZip(zr, part)
+	if err != nil {
+		return
+	}
+	decoder := xml.NewDecoder(strings.NewReader(string(data)))
+	placeholders := make(map[string]handoutFrame)
+	var phType string
+	var frame handoutFrame
+	inSp, inXfrm := false, false
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "sp":
+				inSp, phType, frame = true, "", handoutFrame{}
+			case "ph":
+				if inSp {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "type" {
+							phType = attr.Value
+						}
+					}
+				}
+			case "xfrm":
+				inXfrm = inSp
+			case "off", "ext":
+				if !inXfrm {
+					break
+				}
+				for _, attr := range t.Attr {
+					v, err := strconv.ParseInt(attr.Value, 10, 64)
+					if err != nil {
+						continue
+					}
+					switch attr.Name.Local {
+					case "x":
+						frame.x = v
+					case "y":
+						frame.y = v
+					case "cx":
+						frame.cx = v
+					case "cy":
+						frame.cy = v
+					}
+				}
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "xfrm":
+				inXfrm = false
+			case "sp":
+				if inSp && phType != "" && frame.cx > 0 && frame.cy > 0 {
+					placeholders[phType] = frame
+				}
+				inSp = false
+			}
+		}
+	}
+	if len(placeholders) > 0 {
+		pres.handoutPlaceholders = placeholders
+	}
+}
+
 // --- Theme Colors ---
 
 // readThemeColors reads the theme XML and extracts the color scheme.
@@ -243,3 +528,254 @@ func (r *PPTXReader) readThemeColors(zr *zip.Reader, pres *Presentation) {
 		}
 	}
 }
+
+// --- Theme Fonts ---
+
+// readThemeFonts reads the font schemes of the package's themes. The first
+// theme's populates pres.themeFonts with the theme font references used in
+// text properties, e.g. "+mj-lt" → "Calibri Light"; pres.themeFontSets
+// keeps those of every theme, such as the notes and handout masters' own.
+func (r *PPTXReader) readThemeFonts(zr *zip.Reader, pres *Presentation) {
+	var parts []string
+	for _, f := range zr.File {
+		name := strings.TrimPrefix(f.Name, "ppt/theme/")
+		if name != f.Name && !strings.Contains(name, "/") && strings.HasSuffix(name, ".xml") {
+			parts = append(parts, f.Name)
+		}
+	}
+	// theme2.xml comes before theme10.xml.
+	sort.Slice(parts, func(i, j int) bool {
+		if len(parts[i]) != len(parts[j]) {
+			return len(parts[i]) < len(parts[j])
+		}
+		return parts[i] < parts[j]
+	})
+	for _, part := range parts {
+		data, err := readFileFromZip(zr, part)
+		if err != nil {
+			continue
+		}
+		fonts := parseThemeFonts(data)
+		if len(fonts) == 0 {
+			continue
+		}
+		if pres.themeFonts == nil {
+			pres.themeFonts = fonts
+		}
+		pres.themeFontSets = append(pres.themeFontSets, fonts)
+	}
+}
+
+// parseThemeFonts reads the major and minor fonts of a theme's font
+// scheme, keyed by theme font reference.
+func parseThemeFonts(data []byte) map[string]string {
+	fonts := make(map[string]string)
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	prefix := ""
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "majorFont":
+				prefix = "+mj-"
+			case "minorFont":
+				prefix = "+mn-"
+			case "latin", "ea", "cs":
+				if prefix == "" {
+					continue
+				}
+				script := "lt"
+				if t.Name.Local != "latin" {
+					script = t.Name.Local
+				}
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "typeface" && attr.Value != "" {
+						fonts[prefix+script] = attr.Value
+					}
+				}
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "majorFont", "minorFont":
+				prefix = ""
+			case "fontScheme":
+				return fonts
+			}
+		}
+	}
+	return fonts
+}
+
+// --- Table Styles ---
+
+// readTableStyles reads ppt/tableStyles.xml and records, for each style in
+// pres.tableStyles, the whole-table borders and the cell fill of each part
+// (wholeTbl, band1H, firstRow, ...). Scheme colors are resolved against the
+// theme, so it must run after readThemeColors.
+func (r *PPTXReader) readTableStyles(zr *zip.Reader, pres *Presentation) {
+	data, err := readFileFromZip(zr, "ppt/tableStyles.xml")
+	if err != nil {
+		return
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(string(data)))
+	var styleID, part string
+	var style *tableStyle
+	var current *Border
+	var fill *Fill
+	inTcBdr, inTcStyle := false, false
+
+	// target returns the color being parsed: a border's or a part fill's.
+	target := func() *Color {
+		if current != nil {
+			return &current.Color
+		}
+		if fill != nil && fill.Type == FillSolid {
+			return &fill.Color
+		}
+		return nil
+	}
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "tblStyle":
+				styleID = ""
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "styleId" {
+						styleID = attr.Value
+					}
+				}
+				style = &tableStyle{borders: &TableBorders{}, fills: make(map[string]*Fill)}
+			case "wholeTbl", "band1H", "band2H", "band1V", "band2V", "firstRow", "lastRow",
+				"firstCol", "lastCol", "nwCell", "neCell", "swCell", "seCell":
+				part = t.Name.Local
+			case "tcStyle":
+				inTcStyle = part != ""
+			case "tcBdr":
+				inTcBdr = inTcStyle && part == "wholeTbl"
+			case "fill":
+				if inTcStyle && style != nil {
+					fill = NewFill()
+					style.fills[part] = fill
+				}
+			case "solidFill":
+				if fill != nil && current == nil {
+					fill.Type = FillSolid
+					fill.Color = ColorBlack
+				}
+			case "left", "right", "top", "bottom", "insideH", "insideV":
+				if !inTcBdr || style == nil {
+					continue
+				}
+				current = &Border{Style: BorderSolid, Width: 1, Color: ColorBlack}
+				borders := style.borders
+				switch t.Name.Local {
+				case "left":
+					borders.Left = current
+				case "right":
+					borders.Right = current
+				case "top":
+					borders.Top = current
+				case "bottom":
+					borders.Bottom = current
+				case "insideH":
+					borders.InsideH = current
+				case "insideV":
+					borders.InsideV = current
+				}
+			case "ln":
+				if current != nil {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "w" {
+							if v, err := strconv.Atoi(attr.Value); err == nil {
+								current.Width = v / 12700
+							}
+						}
+					}
+				}
+			case "noFill":
+				if current != nil {
+					current.Style = BorderNone
+				}
+			case "prstDash":
+				if current != nil {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "val" {
+							if style, ok := parseDashStyle(attr.Value); ok && style != BorderSolid {
+								current.Style = style
+							}
+						}
+					}
+				}
+			case "srgbClr":
+				if c := target(); c != nil {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "val" {
+							*c = NewColor("FF" + strings.ToUpper(attr.Value))
+						}
+					}
+				}
+			case "schemeClr":
+				if c := target(); c != nil {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "val" {
+							if argb, ok := pres.themeColors[attr.Value]; ok && argb != "" {
+								*c = schemeColor(attr.Value, argb)
+							}
+						}
+					}
+				}
+			case "lumMod", "lumOff", "tint", "shade":
+				if c := target(); c != nil {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "val" {
+							if v, err := strconv.Atoi(attr.Value); err == nil {
+								switch t.Name.Local {
+								case "lumMod", "lumOff", "shade":
+									modifyColor(c, t.Name.Local, float64(v)/100000.0)
+								case "tint":
+									// val is the share of the color kept; the rest is white.
+									modifyColor(c, "tint", 1-float64(v)/100000.0)
+								}
+							}
+						}
+					}
+				}
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "left", "right", "top", "bottom", "insideH", "insideV":
+				current = nil
+			case "tcBdr":
+				inTcBdr = false
+			case "fill":
+				fill = nil
+			case "tcStyle":
+				inTcStyle = false
+			case "wholeTbl", "band1H", "band2H", "band1V", "band2V", "firstRow", "lastRow",
+				"firstCol", "lastCol", "nwCell", "neCell", "swCell", "seCell":
+				part = ""
+			case "tblStyle":
+				if styleID != "" && style != nil {
+					if pres.tableStyles == nil {
+						pres.tableStyles = make(map[string]*tableStyle)
+					}
+					pres.tableStyles[styleID] = style
+				}
+				style = nil
+			}
+		}
+	}
+}
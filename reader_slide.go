@@ -5,8 +5,10 @@ import (
 	"bytes"
 	"encoding/xml"
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
+	"time"
 )
 
 func (r *PPTXReader) readSlide(zr *zip.Reader, path string, pres *Presentation) (*Slide, error) {
@@ -16,6 +18,7 @@ func (r *PPTXReader) readSlide(zr *zip.Reader, path string, pres *Presentation)
 	}
 
 	slide := newSlide()
+	slide.rawXML = data
 	decoder := xml.NewDecoder(bytes.NewReader(data))
 
 	// Read slide relationships for images, charts, comments, notes
@@ -40,7 +43,7 @@ func (r *PPTXReader) readSlide(zr *zip.Reader, path string, pres *Presentation)
 
 func (r *PPTXReader) readSlideComments(zr *zip.Reader, slide *Slide, rels []xmlRelForRead, slidePath string) {
 	for _, rel := range rels {
-		if rel.Type == relTypeComment {
+		if rel.Type == relTypeComment && !rel.isExternal() {
 			target := rel.Target
 			if !strings.HasPrefix(target, "ppt/") {
 				dir := strings.TrimSuffix(slidePath, "/"+lastPathComponent(slidePath))
@@ -116,9 +119,38 @@ func (r *PPTXReader) parseCommentsXML(data []byte, slide *Slide) {
 	}
 }
 
+// readChartPreview returns a picture of the cached preview image of the
+// chart part chartPart, or nil. The preview, usually an EMF, is an image
+// relationship of the chart part that the chart itself does not use as a
+// fill.
+func (r *PPTXReader) readChartPreview(zr *zip.Reader, chartPart string) *DrawingShape {
+	chartData, err := readFileFromZip(zr, chartPart)
+	if err != nil {
+		return nil
+	}
+	chartRels, _ := r.readRelationships(zr, path.Join(path.Dir(chartPart), "_rels", path.Base(chartPart)+".rels"))
+	for _, rel := range chartRels {
+		if rel.Type != relTypeImage || rel.isExternal() || bytes.Contains(chartData, []byte(`"`+rel.ID+`"`)) {
+			continue
+		}
+		imgPath := resolveRelativePath(path.Dir(chartPart), rel.Target)
+		data, size, err := r.readMedia(zr, imgPath)
+		if err != nil {
+			continue
+		}
+		ds := NewDrawingShape()
+		ds.data = data
+		ds.mimeType = guessMimeType(imgPath)
+		ds.mediaPart = imgPath
+		ds.mediaSize = size
+		return ds
+	}
+	return nil
+}
+
 func (r *PPTXReader) readSlideNotes(zr *zip.Reader, slide *Slide, rels []xmlRelForRead, slidePath string) {
 	for _, rel := range rels {
-		if rel.Type == relTypeNotesSlide {
+		if rel.Type == relTypeNotesSlide && !rel.isExternal() {
 			target := rel.Target
 			if !strings.HasPrefix(target, "ppt/") {
 				dir := strings.TrimSuffix(slidePath, "/"+lastPathComponent(slidePath))
@@ -141,11 +173,15 @@ func (r *PPTXReader) parseNotesXML(data []byte) string {
 	var inText bool
 	var texts []string
 
+	mc := newMCFilter()
 	for {
 		token, err := decoder.Token()
 		if err != nil {
 			break
 		}
+		if mc.skip(decoder, token) {
+			continue
+		}
 		switch t := token.(type) {
 		case xml.StartElement:
 			switch t.Name.Local {
@@ -184,6 +220,46 @@ func (r *PPTXReader) parseNotesXML(data []byte) string {
 	return strings.Join(texts, "")
 }
 
+// readHyperlink reads an <a:hlinkClick>. External targets must use an
+// allowed URL scheme; slide jumps keep the target part, which the reader
+// turns into a slide number once all slides are known. Other actions, such
+// as jumps to the next slide or macros, yield nil.
+func readHyperlink(t xml.StartElement, rels []xmlRelForRead, slidePath string) *Hyperlink {
+	var id, action, tooltip string
+	for _, attr := range t.Attr {
+		switch attr.Name.Local {
+		case "id":
+			id = attr.Value
+		case "action":
+			action = attr.Value
+		case "tooltip":
+			tooltip = attr.Value
+		}
+	}
+	for _, rel := range rels {
+		if rel.ID != id || id == "" {
+			continue
+		}
+		var h *Hyperlink
+		switch {
+		case action == "ppaction://hlinksldjump" && !rel.isExternal():
+			target := rel.Target
+			if !strings.HasPrefix(target, "ppt/") {
+				dir := strings.TrimSuffix(slidePath, "/"+lastPathComponent(slidePath))
+				target = resolveRelativePath(dir, target)
+			}
+			h = &Hyperlink{IsInternal: true, slidePart: target}
+		case action == "" && rel.isExternal():
+			h = NewHyperlink(rel.Target)
+		}
+		if h != nil {
+			h.Tooltip = tooltip
+		}
+		return h
+	}
+	return nil
+}
+
 func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xmlRelForRead, zr *zip.Reader, slidePath string, pres *Presentation) error {
 	type parseState struct {
 		inSpTree       bool
@@ -208,6 +284,9 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 		inTcPrSolidFill bool
 		inTcPrLn        bool
 		tcPrLnSide      string // "L", "R", "T", "B" or "" for generic
+		inTcPrBlipFill  bool
+		inTcPrPattFill  bool
+		inPattBgClr     bool
 		inNvSpPr       bool
 		inSolidFill    bool
 		inSpPr         bool
@@ -243,8 +322,13 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 		// extLst tracking (to ignore hiddenFill etc.)
 		inExtLst bool
 
+		// <p:transition>
+		inTransition bool
+
 		// blipFill inside spPr (shape image fill)
 		inSpPrBlipFill bool
+		inDuotone      bool
+		inClrChange    bool
 
 		// blipFill inside bgPr (slide background image)
 		inBgBlipFill bool
@@ -255,6 +339,9 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 		inGs          bool
 		gradFillPos   int // current gs position (0-100000)
 		inRunPropsGradFill bool // gradFill inside rPr (text color gradient)
+		inUFill            bool // uFill inside rPr (underline fill)
+		inULn              bool // uLn inside rPr (underline stroke)
+		inRunPropsLn       bool // ln inside rPr (text outline)
 
 		// avLst tracking (adjustment values for preset geometry)
 		inAvLst bool
@@ -280,6 +367,7 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 	var currentFont *Font
 	var currentTableRow int
 	var currentTableCol int
+	inTableStyleID := false
 
 	// Pending custom geometry path
 	var pendingCustomPath *CustomGeomPath
@@ -290,6 +378,8 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 
 	// lstStyle-level default font (from <a:lstStyle>/<a:lvl1pPr>/<a:defRPr>)
 	var lstStyleFont *Font
+	// lstStyle-level paragraph indentation (marL, indent, defTabSz of lvl1pPr)
+	var lstStyleAlign *Alignment
 
 	// lastColor tracks the most recently parsed srgbClr/schemeClr so that child
 	// elements like <a:alpha> can modify it.
@@ -298,10 +388,22 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 	var offX, offY, extCX, extCY int64
 	var chOffX, chOffY, chExtCX, chExtCY int64
 	var shapeName, shapeDescr string
+	// graphicFrame content: graphicData URI, OLE progId and the number of
+	// shapes in the container when the frame started.
+	var frameURI, frameProgID string
+	var frameShapes int
+	var shapeLocks *ShapeLocks
+	// frameChartID is the relationship of a chart frame's chart part.
+	var frameChartID string
+	var shapeID int
+	// Click hyperlinks of the current shape (cNvPr) and text run (rPr)
+	var shapeLink, runLink *Hyperlink
+	var shapeTextBox bool
 	var flipH, flipV bool
 	var shapeRotation int
 	var prstGeom string
 	var textAnchor TextAnchorType
+	var textAnchorCtr bool
 	var textDir string
 
 	// Font color from <p:style>/<a:fontRef>/<a:schemeClr> (default text color for shape)
@@ -332,12 +434,61 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 	// Deferred blipFill image data (spPr blipFill for shapes)
 	var pendingBlipFillData []byte
 	var pendingBlipFillMime string
+	var pendingBlipFillPart string
+	var pendingBlipFillSize int64
+	pendingBlipFillAlpha := -1 // alphaModFix amount; -1 when not given
+	var pendingBlipFillURL string
+	var pendingBlipFillDuotone []Color
+	var pendingBlipFillClrChange *ColorChange
+	var duotoneColors []Color
+	var clrChangeColors []Color
+	var clrChangeUseA bool
+
+	// Pattern fill of a table cell: preset and fgClr/bgClr colors
+	var pattPrst string
+	var pattFg, pattBg *Color
 
-	// Background blipFill image data (bgPr blipFill)
-	// TODO: use these to set slide.background as an image fill
-	var bgBlipFillData []byte
-	var bgBlipFillMime string
-	_, _ = bgBlipFillData, bgBlipFillMime
+	// appendBlipColor collects a color of a <a:duotone> or <a:clrChange> blip
+	// effect, or of a cell's <a:pattFill>, and returns it so that child
+	// modifiers can adjust it.
+	appendBlipColor := func(c Color) *Color {
+		if state.inTcPrPattFill {
+			if state.inPattBgClr {
+				pattBg = &c
+				return pattBg
+			}
+			pattFg = &c
+			return pattFg
+		}
+		dst := &duotoneColors
+		if state.inClrChange {
+			dst = &clrChangeColors
+		}
+		*dst = append(*dst, c)
+		return &(*dst)[len(*dst)-1]
+	}
+	// tcPrCell returns the table cell whose tcPr is being read, or nil.
+	tcPrCell := func() *TableCell {
+		if currentTable != nil && currentTableRow >= 0 && currentTableCol >= 0 &&
+			currentTableRow < len(currentTable.rows) && currentTableCol < len(currentTable.rows[currentTableRow]) {
+			return currentTable.rows[currentTableRow][currentTableCol]
+		}
+		return nil
+	}
+	// setRunColor stores a run-level solidFill color: the underline color
+	// inside uFill or uLn, the text color otherwise.
+	setRunColor := func(c Color) *Color {
+		if state.inRunPropsLn {
+			currentFont.OutlineColor = &c
+			return currentFont.OutlineColor
+		}
+		if state.inUFill || state.inULn {
+			currentFont.UnderlineColor = &c
+			return currentFont.UnderlineColor
+		}
+		currentFont.Color = c
+		return &currentFont.Color
+	}
 
 	// Group shape nesting
 	grpDepth := 0
@@ -347,6 +498,9 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 		group    *GroupShape
 		name     string
 		descr    string
+		locks    *ShapeLocks
+		link     *Hyperlink
+		id       int
 		offX     int64
 		offY     int64
 		extCX    int64
@@ -362,11 +516,49 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 	}
 	var grpStack []*grpSaved
 
+	// Byte ranges of the shape elements being parsed, so that each shape can
+	// keep its raw XML. n is the length of the enclosing container when the
+	// element started: if it grew by the end, the last entry is the shape.
+	type rawSaved struct {
+		start     int64
+		container *[]Shape
+		n         int
+	}
+	var rawStack []rawSaved
+	isShapeElement := func(name string) bool {
+		switch name {
+		case "sp", "pic", "cxnSp", "graphicFrame", "grpSp":
+			return true
+		}
+		return false
+	}
+
+	mc := newMCFilter()
+
 	for {
+		tokStart := decoder.InputOffset()
 		token, err := decoder.Token()
 		if err != nil {
 			break
 		}
+		// Outside the shape tree p14 only marks transitions, which the
+		// Choice describes more fully than the Fallback.
+		if state.inSpTree {
+			mc.extra = nil
+		} else {
+			mc.extra = mcTransitions
+		}
+		if mc.skip(decoder, token) {
+			continue
+		}
+
+		if se, ok := token.(xml.StartElement); ok && state.inSpTree && isShapeElement(se.Name.Local) {
+			container := &slide.shapes
+			if state.inGrpSp && currentGroup != nil {
+				container = &currentGroup.shapes
+			}
+			rawStack = append(rawStack, rawSaved{start: tokStart, container: container, n: len(*container)})
+		}
 
 		switch t := token.(type) {
 		case xml.StartElement:
@@ -389,6 +581,10 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 					offX, offY, extCX, extCY = 0, 0, 0, 0
 					chOffX, chOffY, chExtCX, chExtCY = 0, 0, 0, 0
 					shapeName = ""
+					shapeLocks = nil
+					shapeLink = nil
+					shapeID = 0
+					shapeTextBox = false
 					shapeDescr = ""
 					prstGeom = ""
 					shapeRotation = 0
@@ -404,10 +600,15 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 					state.phIdx = 0
 					offX, offY, extCX, extCY = 0, 0, 0, 0
 					shapeName = ""
+					shapeLocks = nil
+					shapeLink = nil
+					shapeID = 0
+					shapeTextBox = false
 					shapeDescr = ""
 					prstGeom = ""
 					shapeRotation = 0
 					textAnchor = TextAnchorNone
+					textAnchorCtr = false
 					textDir = ""
 					pendingShapeFill = nil
 					pendingBorder = nil
@@ -417,8 +618,19 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 					pendingShadow = nil
 					pendingBlipFillData = nil
 					pendingBlipFillMime = ""
+					pendingBlipFillPart = ""
+					pendingBlipFillSize = 0
+					pendingBlipFillAlpha = -1
+					pendingBlipFillURL = ""
+					pendingBlipFillDuotone = nil
+					pendingBlipFillClrChange = nil
 					pendingCustomPath = nil
 					fontRefColor = nil
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "useBgFill" && (attr.Value == "1" || attr.Value == "true") {
+							pendingShapeFill = &Fill{Type: FillBackground}
+						}
+					}
 				}
 			case "pic":
 				if state.inSpTree || state.inGrpSp {
@@ -426,9 +638,16 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 					currentDrawing = NewDrawingShape()
 					offX, offY, extCX, extCY = 0, 0, 0, 0
 					shapeName = ""
+					shapeLocks = nil
+					shapeLink = nil
+					shapeID = 0
+					shapeTextBox = false
 					shapeDescr = ""
 					prstGeom = ""
 					shapeRotation = 0
+					pendingBorder = nil
+					pendingShadow = nil
+					pendingAdjustValues = nil
 				}
 			case "cxnSp":
 				if state.inSpTree || state.inGrpSp {
@@ -436,6 +655,10 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 					currentLine = NewLineShape()
 					offX, offY, extCX, extCY = 0, 0, 0, 0
 					shapeName = ""
+					shapeLocks = nil
+					shapeLink = nil
+					shapeID = 0
+					shapeTextBox = false
 					prstGeom = ""
 					shapeRotation = 0
 					pendingCustomPath = nil
@@ -445,8 +668,53 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 					state.inGraphicFrame = true
 					offX, offY, extCX, extCY = 0, 0, 0, 0
 					shapeName = ""
+					shapeDescr = ""
+					frameURI, frameProgID = "", ""
+					frameShapes = len(slide.shapes)
+					if state.inGrpSp && currentGroup != nil {
+						frameShapes = len(currentGroup.shapes)
+					}
+					shapeLocks = nil
+					shapeLink = nil
+					shapeID = 0
+					shapeTextBox = false
 					prstGeom = ""
 					shapeRotation = 0
+					frameChartID = ""
+				}
+			case "chart":
+				if state.inGraphicFrame {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "id" {
+							frameChartID = attr.Value
+						}
+					}
+				}
+			case "contentPart":
+				if state.inSpTree {
+					if shape := readContentPart(decoder, t, rels, zr, slidePath); shape != nil {
+						if state.inGrpSp && currentGroup != nil {
+							currentGroup.AddShape(shape)
+						} else {
+							slide.shapes = append(slide.shapes, shape)
+						}
+					}
+				}
+			case "graphicData":
+				if state.inGraphicFrame {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "uri" {
+							frameURI = attr.Value
+						}
+					}
+				}
+			case "oleObj":
+				if state.inGraphicFrame {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "progId" {
+							frameProgID = attr.Value
+						}
+					}
 				}
 			case "tbl":
 				if state.inGraphicFrame {
@@ -455,6 +723,31 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 					currentTable.rows = nil
 					currentTableRow = -1
 				}
+			case "tblPr":
+				if state.inTbl && currentTable != nil {
+					// Absent flags are off.
+					look := TableLook{}
+					for _, attr := range t.Attr {
+						on := attr.Value == "1" || attr.Value == "true"
+						switch attr.Name.Local {
+						case "firstRow":
+							look.FirstRow = on
+						case "lastRow":
+							look.LastRow = on
+						case "firstCol":
+							look.FirstCol = on
+						case "lastCol":
+							look.LastCol = on
+						case "bandRow":
+							look.BandRow = on
+						case "bandCol":
+							look.BandCol = on
+						}
+					}
+					currentTable.look = look
+				}
+			case "tableStyleId":
+				inTableStyleID = state.inTbl && currentTable != nil
 			case "gridCol":
 				if state.inTbl && currentTable != nil {
 					currentTable.numCols++
@@ -487,6 +780,7 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 					currentTableCol++
 					cell := NewTableCell()
 					cell.paragraphs = nil
+					cell.fill = nil
 					for _, attr := range t.Attr {
 						switch attr.Name.Local {
 						case "gridSpan":
@@ -513,71 +807,89 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 				if state.inTc && currentTable != nil && currentTableRow >= 0 && currentTableCol >= 0 &&
 					currentTableRow < len(currentTable.rows) && currentTableCol < len(currentTable.rows[currentTableRow]) {
 					state.inTcPr = true
-				}
-			case "lnL":
-				if state.inTcPr {
-					state.inTcPrLn = true
-					state.tcPrLnSide = "L"
+					cell := currentTable.rows[currentTableRow][currentTableCol]
 					for _, attr := range t.Attr {
-						if attr.Name.Local == "w" {
-							if v, err := strconv.Atoi(attr.Value); err == nil {
-								if currentTable != nil && currentTableRow >= 0 && currentTableCol >= 0 &&
-									currentTableRow < len(currentTable.rows) && currentTableCol < len(currentTable.rows[currentTableRow]) {
-									currentTable.rows[currentTableRow][currentTableCol].border.Left.Width = v / 12700
-									currentTable.rows[currentTableRow][currentTableCol].border.Left.Style = BorderSolid
-								}
+						v, err := strconv.ParseInt(attr.Value, 10, 64)
+						switch attr.Name.Local {
+						case "marL":
+							if err == nil && v >= 0 {
+								cell.marginLeft = v
 							}
-						}
-					}
-				}
-			case "lnR":
-				if state.inTcPr {
-					state.inTcPrLn = true
-					state.tcPrLnSide = "R"
-					for _, attr := range t.Attr {
-						if attr.Name.Local == "w" {
-							if v, err := strconv.Atoi(attr.Value); err == nil {
-								if currentTable != nil && currentTableRow >= 0 && currentTableCol >= 0 &&
-									currentTableRow < len(currentTable.rows) && currentTableCol < len(currentTable.rows[currentTableRow]) {
-									currentTable.rows[currentTableRow][currentTableCol].border.Right.Width = v / 12700
-									currentTable.rows[currentTableRow][currentTableCol].border.Right.Style = BorderSolid
-								}
+						case "marR":
+							if err == nil && v >= 0 {
+								cell.marginRight = v
+							}
+						case "marT":
+							if err == nil && v >= 0 {
+								cell.marginTop = v
 							}
+						case "marB":
+							if err == nil && v >= 0 {
+								cell.marginBottom = v
+							}
+						case "anchor":
+							cell.anchor = TextAnchorType(attr.Value)
 						}
 					}
 				}
-			case "lnT":
+			case "lnL", "lnR", "lnT", "lnB":
 				if state.inTcPr {
 					state.inTcPrLn = true
-					state.tcPrLnSide = "T"
-					for _, attr := range t.Attr {
-						if attr.Name.Local == "w" {
-							if v, err := strconv.Atoi(attr.Value); err == nil {
-								if currentTable != nil && currentTableRow >= 0 && currentTableCol >= 0 &&
-									currentTableRow < len(currentTable.rows) && currentTableCol < len(currentTable.rows[currentTableRow]) {
-									currentTable.rows[currentTableRow][currentTableCol].border.Top.Width = v / 12700
-									currentTable.rows[currentTableRow][currentTableCol].border.Top.Style = BorderSolid
+					state.tcPrLnSide = t.Name.Local[2:]
+					// A side the cell mentions keeps its own line, even none,
+					// over the table style's border.
+					if cell := tcPrCell(); cell != nil && cell.border != nil {
+						cell.bordersSet += state.tcPrLnSide
+						side := cellBorderSidePtr(cell.border, state.tcPrLnSide)
+						if *side == nil {
+							*side = NewBorder()
+						}
+						for _, attr := range t.Attr {
+							if attr.Name.Local == "w" {
+								if v, err := strconv.Atoi(attr.Value); err == nil {
+									(*side).Width = v / 12700
+									(*side).Style = BorderSolid
 								}
 							}
 						}
 					}
 				}
-			case "lnB":
-				if state.inTcPr {
-					state.inTcPrLn = true
-					state.tcPrLnSide = "B"
-					for _, attr := range t.Attr {
-						if attr.Name.Local == "w" {
-							if v, err := strconv.Atoi(attr.Value); err == nil {
-								if currentTable != nil && currentTableRow >= 0 && currentTableCol >= 0 &&
-									currentTableRow < len(currentTable.rows) && currentTableCol < len(currentTable.rows[currentTableRow]) {
-									currentTable.rows[currentTableRow][currentTableCol].border.Bottom.Width = v / 12700
-									currentTable.rows[currentTableRow][currentTableCol].border.Bottom.Style = BorderSolid
-								}
-							}
+			case "transition":
+				// Inside mc:AlternateContent the p14 Choice is read, for
+				// its dur attribute.
+				state.inTransition = true
+				if slide.transition == nil {
+					slide.transition = &Transition{}
+				}
+				for _, attr := range t.Attr {
+					switch attr.Name.Local {
+					case "spd":
+						slide.transition.Speed = TransitionSpeed(attr.Value)
+					case "dur":
+						if v, err := strconv.Atoi(attr.Value); err == nil {
+							slide.transition.Duration = v
+						}
+					case "advClick":
+						slide.timing.AdvanceOnClick = attr.Value != "0" && attr.Value != "false"
+					case "advTm":
+						if v, err := strconv.Atoi(attr.Value); err == nil {
+							slide.timing.AutoAdvance = true
+							slide.timing.AdvanceAfter = time.Duration(v) * time.Millisecond
 						}
 					}
 				}
+			case "fade", "push", "wipe", "split", "cover", "pull", "dissolve":
+				if state.inTransition && slide.transition != nil {
+					slide.transition.Type = map[string]TransitionType{
+						"fade":     TransitionFade,
+						"push":     TransitionPush,
+						"wipe":     TransitionWipe,
+						"split":    TransitionSplit,
+						"cover":    TransitionCover,
+						"pull":     TransitionUncover,
+						"dissolve": TransitionDissolve,
+					}[t.Name.Local]
+				}
 			case "cNvPr":
 				if state.inNvSpPr {
 					for _, attr := range t.Attr {
@@ -586,9 +898,29 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 							shapeName = attr.Value
 						case "descr":
 							shapeDescr = attr.Value
+						case "id":
+							shapeID, _ = strconv.Atoi(attr.Value)
+						}
+					}
+				}
+			case "hlinkClick":
+				if state.inNvSpPr {
+					shapeLink = readHyperlink(t, rels, slidePath)
+				} else if state.inRunProps {
+					runLink = readHyperlink(t, rels, slidePath)
+				}
+			case "cNvSpPr":
+				if state.inNvSpPr {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "txBox" {
+							shapeTextBox = attr.Value == "1" || attr.Value == "true"
 						}
 					}
 				}
+			case "spLocks", "picLocks", "cxnSpLocks", "grpSpLocks", "graphicFrameLocks":
+				if state.inNvSpPr {
+					shapeLocks = parseShapeLocks(t.Attr)
+				}
 			case "ph":
 				if state.inNvSpPr && state.inSp {
 					state.isPlaceholder = true
@@ -607,6 +939,7 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 				if state.inSp {
 					state.inTxBody = true
 					lstStyleFont = nil // reset for new text body
+					lstStyleAlign = nil
 					if state.isPlaceholder {
 						if currentPlaceholder == nil {
 							currentPlaceholder = NewPlaceholderShape(PlaceholderType(state.phType))
@@ -629,6 +962,26 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 			case "lvl1pPr":
 				if state.inLstStyle {
 					state.inLstStyleLvl1 = true
+					if state.inTxBody {
+						a, set := &Alignment{}, false
+						for _, attr := range t.Attr {
+							v, err := strconv.ParseInt(attr.Value, 10, 64)
+							if err != nil {
+								continue
+							}
+							switch attr.Name.Local {
+							case "marL":
+								a.MarginLeft, set = v, true
+							case "indent":
+								a.Indent, set = v, true
+							case "defTabSz":
+								a.DefTabSize, set = v, true
+							}
+						}
+						if set {
+							lstStyleAlign = a
+						}
+					}
 				}
 			case "bodyPr":
 				if state.inTxBody {
@@ -661,6 +1014,8 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 						switch attr.Name.Local {
 						case "anchor":
 							textAnchor = TextAnchorType(attr.Value)
+						case "anchorCtr":
+							textAnchorCtr = attr.Value == "1" || attr.Value == "true"
 						case "vert":
 							textDir = attr.Value
 							if currentRichText != nil {
@@ -780,6 +1135,11 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 				} else if state.inTxBody {
 					state.inParagraph = true
 					currentParagraph = NewParagraph()
+					if lstStyleAlign != nil {
+						currentParagraph.alignment.MarginLeft = lstStyleAlign.MarginLeft
+						currentParagraph.alignment.Indent = lstStyleAlign.Indent
+						currentParagraph.alignment.DefTabSize = lstStyleAlign.DefTabSize
+					}
 					if state.isPlaceholder && currentPlaceholder != nil {
 						currentPlaceholder.paragraphs = append(currentPlaceholder.paragraphs, currentParagraph)
 					} else if currentRichText != nil {
@@ -805,6 +1165,10 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 							if v, err := strconv.ParseInt(attr.Value, 10, 64); err == nil {
 								currentParagraph.alignment.Indent = v
 							}
+						case "defTabSz":
+							if v, err := strconv.ParseInt(attr.Value, 10, 64); err == nil {
+								currentParagraph.alignment.DefTabSize = v
+							}
 						}
 					}
 				}
@@ -913,18 +1277,20 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 					for _, attr := range t.Attr {
 						if attr.Name.Local == "val" {
 							if v, err := strconv.Atoi(attr.Value); err == nil {
-								if state.inLnSpc {
-									// Store as negative to distinguish from spcPts
+								// Store as negative to distinguish from spcPts
+								if state.inSpcBef {
+									currentParagraph.spaceBefore = -v
+								} else if state.inSpcAft {
+									currentParagraph.spaceAfter = -v
+								} else if state.inLnSpc {
 									currentParagraph.lineSpacing = -v
 								}
-								// spcPct inside spcBef/spcAft with val=0 means no spacing;
-								// non-zero percentage spacing before/after is rare and
-								// would need line-height-relative calculation at render time.
 							}
 						}
 					}
 				}
 			case "r":
+				runLink = nil
 				if state.inTcParagraph {
 					state.inTcRun = true
 					currentFont = NewFont()
@@ -957,6 +1323,18 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 						if lstStyleFont.NameEA != "" {
 							currentFont.NameEA = lstStyleFont.NameEA
 						}
+						if lstStyleFont.NameCS != "" {
+							currentFont.NameCS = lstStyleFont.NameCS
+						}
+						if lstStyleFont.NameSym != "" {
+							currentFont.NameSym = lstStyleFont.NameSym
+						}
+						if lstStyleFont.Kern != nil {
+							currentFont.Kern = lstStyleFont.Kern
+						}
+						if lstStyleFont.Spacing != 0 {
+							currentFont.Spacing = lstStyleFont.Spacing
+						}
 						if lstStyleFont.Color.ARGB != "FF000000" && lstStyleFont.Color.ARGB != "" {
 							currentFont.Color = lstStyleFont.Color
 						}
@@ -978,6 +1356,18 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 						if defFont.NameEA != "" {
 							currentFont.NameEA = defFont.NameEA
 						}
+						if defFont.NameCS != "" {
+							currentFont.NameCS = defFont.NameCS
+						}
+						if defFont.NameSym != "" {
+							currentFont.NameSym = defFont.NameSym
+						}
+						if defFont.Kern != nil {
+							currentFont.Kern = defFont.Kern
+						}
+						if defFont.Spacing != 0 {
+							currentFont.Spacing = defFont.Spacing
+						}
 						if defFont.Color.ARGB != "FF000000" && defFont.Color.ARGB != "" {
 							currentFont.Color = defFont.Color
 						}
@@ -999,7 +1389,32 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 						case "u":
 							currentFont.Underline = UnderlineType(attr.Value)
 						case "strike":
-							currentFont.Strikethrough = attr.Value == "sngStrike"
+							currentFont.SetStrike(StrikeType(attr.Value))
+						case "kumimoji":
+							currentFont.Kumimoji = attr.Value == "1" || attr.Value == "true"
+						case "kern":
+							if v, err := strconv.Atoi(attr.Value); err == nil {
+								currentFont.Kern = &v
+							}
+						case "spc":
+							if v, err := strconv.Atoi(attr.Value); err == nil {
+								currentFont.Spacing = v
+							}
+						}
+					}
+				}
+			case "uFill":
+				if state.inRunProps && currentFont != nil {
+					state.inUFill = true
+				}
+			case "uLn":
+				if state.inRunProps && currentFont != nil {
+					state.inULn = true
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "w" {
+							if v, err := strconv.ParseInt(attr.Value, 10, 64); err == nil {
+								currentFont.UnderlineWidth = v
+							}
 						}
 					}
 				}
@@ -1020,6 +1435,14 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 								lstStyleFont.Bold = attr.Value == "1"
 							case "i":
 								lstStyleFont.Italic = attr.Value == "1"
+							case "kern":
+								if v, err := strconv.Atoi(attr.Value); err == nil {
+									lstStyleFont.Kern = &v
+								}
+							case "spc":
+								if v, err := strconv.Atoi(attr.Value); err == nil {
+									lstStyleFont.Spacing = v
+								}
 							}
 						}
 					} else {
@@ -1036,6 +1459,14 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 								defFont.Bold = attr.Value == "1"
 							case "i":
 								defFont.Italic = attr.Value == "1"
+							case "kern":
+								if v, err := strconv.Atoi(attr.Value); err == nil {
+									defFont.Kern = &v
+								}
+							case "spc":
+								if v, err := strconv.Atoi(attr.Value); err == nil {
+									defFont.Spacing = v
+								}
 							}
 						}
 					}
@@ -1063,6 +1494,15 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 					state.inSolidFill = true
 				}
 			case "noFill":
+				// <a:noFill/> inside rPr leaves the glyphs hollow; inside its
+				// ln it removes the outline.
+				if state.inRunProps && currentFont != nil && !state.inUFill && !state.inULn {
+					if state.inRunPropsLn {
+						currentFont.OutlineColor = nil
+					} else {
+						currentFont.NoFill = true
+					}
+				}
 				// <a:noFill/> inside spPr means the shape has no fill
 				if state.inSpPr && !state.inTxBody && !state.inLn && !state.inExtLst {
 					if state.inSp {
@@ -1070,6 +1510,14 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 						pendingShapeFill.Type = FillNone
 					}
 				}
+				// <a:noFill/> inside a picture's ln means no outline, which
+				// keeps a photo album frame from being applied.
+				if state.inPic && state.inSpPr && state.inLn {
+					if pendingBorder == nil {
+						pendingBorder = &Border{}
+					}
+					pendingBorder.Style = BorderNone
+				}
 				// <a:noFill/> inside tcPr means the cell has no fill
 				if state.inTcPr && !state.inTcPrLn {
 					if currentTable != nil && currentTableRow >= 0 && currentTableCol >= 0 &&
@@ -1109,7 +1557,7 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 					}
 				}
 			case "gradFill":
-				if state.inRunProps && currentFont != nil {
+				if state.inRunProps && currentFont != nil && !state.inUFill && !state.inULn && !state.inRunPropsLn {
 					// gradFill inside rPr — use first stop color as text color
 					state.inRunPropsGradFill = true
 					state.inGradFill = true
@@ -1121,6 +1569,18 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 					gradStopColors = nil
 					gradStopPositions = nil
 					gradAngle = 0
+				} else if state.inTcPr && !state.inTcPrLn {
+					// Table cell gradient fill
+					state.inGradFill = true
+					gradStopColors = nil
+					gradStopPositions = nil
+					gradAngle = 0
+				} else if state.inTcPrLn || (state.inLn && !state.inRunProps && !state.inExtLst) {
+					// Gradient outline of a cell side, connector or shape.
+					state.inGradFill = true
+					gradStopColors = nil
+					gradStopPositions = nil
+					gradAngle = 0
 				} else if state.inBgPr {
 					state.inGradFill = true
 					gradStopColors = nil
@@ -1160,6 +1620,23 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 				} else if state.inBgPr {
 					// <a:blipFill> inside bgPr — slide background image
 					state.inBgBlipFill = true
+				} else if state.inTcPr && !state.inTcPrLn {
+					state.inTcPrBlipFill = true
+				}
+			case "pattFill":
+				if state.inTcPr && !state.inTcPrLn {
+					state.inTcPrPattFill = true
+					pattPrst = ""
+					pattFg, pattBg = nil, nil
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "prst" {
+							pattPrst = attr.Value
+						}
+					}
+				}
+			case "fgClr", "bgClr":
+				if state.inTcPrPattFill {
+					state.inPattBgClr = t.Name.Local == "bgClr"
 				}
 			case "extLst":
 				if state.inSpPr {
@@ -1168,6 +1645,12 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 			case "srgbClr":
 				state.inSrgbClr = true
 				lastColor = nil
+				if state.inDuotone || state.inClrChange || state.inTcPrPattFill {
+					if c, ok := colorElementValue(t, pres); ok {
+						lastColor = appendBlipColor(c)
+					}
+					break
+				}
 				if state.inGs {
 					// Gradient stop color
 					for _, attr := range t.Attr {
@@ -1238,8 +1721,7 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 				} else if state.inSolidFill && state.inRunProps && currentFont != nil {
 					for _, attr := range t.Attr {
 						if attr.Name.Local == "val" {
-							currentFont.Color = NewColor("FF" + attr.Value)
-							lastColor = &currentFont.Color
+							lastColor = setRunColor(NewColor("FF" + attr.Value))
 						}
 					}
 				} else if state.inSolidFill && state.inLn && !state.inRunProps {
@@ -1250,7 +1732,7 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 							if state.inCxnSp && currentLine != nil {
 								currentLine.lineColor = c
 								lastColor = &currentLine.lineColor
-							} else if state.inSp {
+							} else if state.inSp || state.inPic {
 								if pendingBorder == nil {
 									pendingBorder = &Border{Style: BorderSolid}
 								}
@@ -1264,7 +1746,11 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 					for _, attr := range t.Attr {
 						if attr.Name.Local == "val" {
 							c := NewColor("FF" + attr.Value)
-							if state.inGrpSp && !state.inSp && len(grpStack) > 0 {
+							if state.inCxnSp && currentLine != nil {
+								// Closed custGeom connectors may carry a fill
+								currentLine.GetFill().SetSolid(c)
+								lastColor = &currentLine.GetFill().Color
+							} else if state.inGrpSp && !state.inSp && len(grpStack) > 0 {
 								// solidFill inside grpSpPr — store as group fill
 								f := NewFill()
 								f.SetSolid(c)
@@ -1322,6 +1808,12 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 			case "prstClr":
 				state.inSrgbClr = true // reuse for alpha child handling
 				lastColor = nil
+				if state.inDuotone || state.inClrChange || state.inTcPrPattFill {
+					if c, ok := colorElementValue(t, pres); ok {
+						lastColor = appendBlipColor(c)
+					}
+					break
+				}
 				var prstName string
 				for _, attr := range t.Attr {
 					if attr.Name.Local == "val" {
@@ -1340,13 +1832,12 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 					fontRefColor = &c
 					lastColor = fontRefColor
 				} else if state.inSolidFill && state.inRunProps && currentFont != nil && !state.inLn {
-					currentFont.Color = c
-					lastColor = &currentFont.Color
+					lastColor = setRunColor(c)
 				} else if state.inSolidFill && state.inLn && !state.inRunProps {
 					if state.inCxnSp && currentLine != nil {
 						currentLine.lineColor = c
 						lastColor = &currentLine.lineColor
-					} else if state.inSp {
+					} else if state.inSp || state.inPic {
 						if pendingBorder == nil {
 							pendingBorder = &Border{Style: BorderSolid}
 						}
@@ -1354,7 +1845,10 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 						lastColor = &pendingBorder.Color
 					}
 				} else if state.inSolidFill && state.inSpPr && !state.inRunProps && !state.inTxBody && !state.inLn {
-					if state.inGrpSp && !state.inSp && len(grpStack) > 0 {
+					if state.inCxnSp && currentLine != nil {
+						currentLine.GetFill().SetSolid(c)
+						lastColor = &currentLine.GetFill().Color
+					} else if state.inGrpSp && !state.inSp && len(grpStack) > 0 {
 						f := NewFill()
 						f.SetSolid(c)
 						grpStack[len(grpStack)-1].grpFill = f
@@ -1389,6 +1883,12 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 			case "schemeClr":
 				state.inSrgbClr = true // reuse for alpha child handling
 				lastColor = nil
+				if state.inDuotone || state.inClrChange || state.inTcPrPattFill {
+					if c, ok := colorElementValue(t, pres); ok {
+						lastColor = appendBlipColor(c)
+					}
+					break
+				}
 				if pres != nil && pres.themeColors != nil {
 					var schemeName string
 					for _, attr := range t.Attr {
@@ -1397,7 +1897,7 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 						}
 					}
 					if argb, ok := pres.themeColors[schemeName]; ok && argb != "" {
-						c := NewColor(argb)
+						c := schemeColor(schemeName, argb)
 						if state.inGs {
 							gradStopColors = append(gradStopColors, c)
 							gradStopPositions = append(gradStopPositions, state.gradFillPos)
@@ -1442,13 +1942,12 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 							fontRefColor = &c
 							lastColor = fontRefColor
 						} else if state.inSolidFill && state.inRunProps && currentFont != nil {
-							currentFont.Color = c
-							lastColor = &currentFont.Color
+							lastColor = setRunColor(c)
 						} else if state.inSolidFill && state.inLn && !state.inRunProps {
 							if state.inCxnSp && currentLine != nil {
 								currentLine.lineColor = c
 								lastColor = &currentLine.lineColor
-							} else if state.inSp {
+							} else if state.inSp || state.inPic {
 								if pendingBorder == nil {
 									pendingBorder = &Border{Style: BorderSolid}
 								}
@@ -1456,7 +1955,10 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 								lastColor = &pendingBorder.Color
 							}
 						} else if state.inSolidFill && state.inSpPr && !state.inRunProps && !state.inTxBody && !state.inLn {
-							if state.inGrpSp && !state.inSp && len(grpStack) > 0 {
+							if state.inCxnSp && currentLine != nil {
+								currentLine.GetFill().SetSolid(c)
+								lastColor = &currentLine.GetFill().Color
+							} else if state.inGrpSp && !state.inSp && len(grpStack) > 0 {
 								f := NewFill()
 								f.SetSolid(c)
 								grpStack[len(grpStack)-1].grpFill = f
@@ -1493,6 +1995,12 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 				// <a:sysClr val="window" lastClr="FFFFFF"/> — system color
 				state.inSrgbClr = true // reuse for alpha/lumMod child handling
 				lastColor = nil
+				if state.inDuotone || state.inClrChange || state.inTcPrPattFill {
+					if c, ok := colorElementValue(t, pres); ok {
+						lastColor = appendBlipColor(c)
+					}
+					break
+				}
 				var sysLastClr string
 				for _, attr := range t.Attr {
 					if attr.Name.Local == "lastClr" {
@@ -1540,13 +2048,12 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 						fontRefColor = &c
 						lastColor = fontRefColor
 					} else if state.inSolidFill && state.inRunProps && currentFont != nil {
-						currentFont.Color = c
-						lastColor = &currentFont.Color
+						lastColor = setRunColor(c)
 					} else if state.inSolidFill && state.inLn && !state.inRunProps {
 						if state.inCxnSp && currentLine != nil {
 							currentLine.lineColor = c
 							lastColor = &currentLine.lineColor
-						} else if state.inSp {
+						} else if state.inSp || state.inPic {
 							if pendingBorder == nil {
 								pendingBorder = &Border{Style: BorderSolid}
 							}
@@ -1554,7 +2061,10 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 							lastColor = &pendingBorder.Color
 						}
 					} else if state.inSolidFill && state.inSpPr && !state.inRunProps && !state.inTxBody && !state.inLn {
-						if state.inGrpSp && !state.inSp && len(grpStack) > 0 {
+						if state.inCxnSp && currentLine != nil {
+							currentLine.GetFill().SetSolid(c)
+							lastColor = &currentLine.GetFill().Color
+						} else if state.inGrpSp && !state.inSp && len(grpStack) > 0 {
 							f := NewFill()
 							f.SetSolid(c)
 							grpStack[len(grpStack)-1].grpFill = f
@@ -1618,7 +2128,7 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 					for _, attr := range t.Attr {
 						if attr.Name.Local == "val" {
 							if v, err := strconv.Atoi(attr.Value); err == nil {
-								applyLumMod(lastColor, float64(v)/100000.0)
+								modifyColor(lastColor, "lumMod", float64(v)/100000.0)
 							}
 						}
 					}
@@ -1629,7 +2139,7 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 					for _, attr := range t.Attr {
 						if attr.Name.Local == "val" {
 							if v, err := strconv.Atoi(attr.Value); err == nil {
-								applyLumOff(lastColor, float64(v)/100000.0)
+								modifyColor(lastColor, "lumOff", float64(v)/100000.0)
 							}
 						}
 					}
@@ -1640,7 +2150,7 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 					for _, attr := range t.Attr {
 						if attr.Name.Local == "val" {
 							if v, err := strconv.Atoi(attr.Value); err == nil {
-								applyTint(lastColor, float64(v)/100000.0)
+								modifyColor(lastColor, "tint", float64(v)/100000.0)
 							}
 						}
 					}
@@ -1651,7 +2161,7 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 					for _, attr := range t.Attr {
 						if attr.Name.Local == "val" {
 							if v, err := strconv.Atoi(attr.Value); err == nil {
-								applyShade(lastColor, float64(v)/100000.0)
+								modifyColor(lastColor, "shade", float64(v)/100000.0)
 							}
 						}
 					}
@@ -1697,33 +2207,75 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 						}
 					}
 				}
-			case "t":
-				if state.inTcRun {
-					state.inTcText = true
-				} else if state.inRun {
-					state.inText = true
-				}
-			case "br":
-				if state.inParagraph && currentParagraph != nil {
-					currentParagraph.CreateBreak()
-				}
-			case "xfrm":
-				flipH = false
-				flipV = false
-				shapeRotation = 0
-				for _, attr := range t.Attr {
-					switch attr.Name.Local {
-					case "flipH":
-						flipH = attr.Value == "1" || attr.Value == "true"
-					case "flipV":
-						flipV = attr.Value == "1" || attr.Value == "true"
-					case "rot":
-						// rotation in 60000ths of a degree
-						if v, err := strconv.Atoi(attr.Value); err == nil {
-							shapeRotation = v / 60000
+			case "cs":
+				// Complex script font
+				if state.inRunProps && currentFont != nil {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "typeface" && !strings.HasPrefix(attr.Value, "+") {
+							currentFont.NameCS = attr.Value
 						}
 					}
-				}
+				} else if state.inDefRPr && state.inLstStyleLvl1 && lstStyleFont != nil {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "typeface" && !strings.HasPrefix(attr.Value, "+") {
+							lstStyleFont.NameCS = attr.Value
+						}
+					}
+				} else if state.inDefRPr && defFont != nil {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "typeface" && !strings.HasPrefix(attr.Value, "+") {
+							defFont.NameCS = attr.Value
+						}
+					}
+				}
+			case "sym":
+				// Symbol font, used for characters in the symbol range
+				if state.inRunProps && currentFont != nil {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "typeface" && !strings.HasPrefix(attr.Value, "+") {
+							currentFont.NameSym = attr.Value
+						}
+					}
+				} else if state.inDefRPr && state.inLstStyleLvl1 && lstStyleFont != nil {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "typeface" && !strings.HasPrefix(attr.Value, "+") {
+							lstStyleFont.NameSym = attr.Value
+						}
+					}
+				} else if state.inDefRPr && defFont != nil {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "typeface" && !strings.HasPrefix(attr.Value, "+") {
+							defFont.NameSym = attr.Value
+						}
+					}
+				}
+			case "t":
+				if state.inTcRun {
+					state.inTcText = true
+				} else if state.inRun {
+					state.inText = true
+				}
+			case "br":
+				if state.inParagraph && currentParagraph != nil {
+					currentParagraph.CreateBreak()
+				}
+			case "xfrm":
+				flipH = false
+				flipV = false
+				shapeRotation = 0
+				for _, attr := range t.Attr {
+					switch attr.Name.Local {
+					case "flipH":
+						flipH = attr.Value == "1" || attr.Value == "true"
+					case "flipV":
+						flipV = attr.Value == "1" || attr.Value == "true"
+					case "rot":
+						// rotation in 60000ths of a degree
+						if v, err := strconv.Atoi(attr.Value); err == nil {
+							shapeRotation = v / 60000
+						}
+					}
+				}
 			case "off":
 				for _, attr := range t.Attr {
 					switch attr.Name.Local {
@@ -1779,18 +2331,26 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 			case "blip":
 				if state.inPic {
 					for _, attr := range t.Attr {
-						if attr.Name.Local == "embed" {
+						// r:link names an external (linked) image; a picture
+						// may carry both a link and an embedded copy.
+						if attr.Name.Local == "embed" || attr.Name.Local == "link" {
 							for _, rel := range rels {
 								if rel.ID == attr.Value {
+									if rel.isExternal() {
+										currentDrawing.externalURL = rel.Target
+										break
+									}
 									imgPath := rel.Target
 									if !strings.HasPrefix(imgPath, "ppt/") {
 										dir := strings.TrimSuffix(slidePath, "/"+lastPathComponent(slidePath))
 										imgPath = resolveRelativePath(dir, imgPath)
 									}
-									imgData, err := readFileFromZip(zr, imgPath)
+									imgData, size, err := r.readMedia(zr, imgPath)
 									if err == nil {
 										currentDrawing.data = imgData
 										currentDrawing.mimeType = guessMimeType(imgPath)
+										currentDrawing.mediaPart = imgPath
+										currentDrawing.mediaSize = size
 									}
 									break
 								}
@@ -1800,18 +2360,24 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 				} else if state.inSpPrBlipFill {
 					// <a:blip> inside <a:blipFill> inside <p:spPr> — shape image fill
 					for _, attr := range t.Attr {
-						if attr.Name.Local == "embed" {
+						if attr.Name.Local == "embed" || attr.Name.Local == "link" {
 							for _, rel := range rels {
 								if rel.ID == attr.Value {
+									if rel.isExternal() {
+										pendingBlipFillURL = rel.Target
+										break
+									}
 									imgPath := rel.Target
 									if !strings.HasPrefix(imgPath, "ppt/") {
 										dir := strings.TrimSuffix(slidePath, "/"+lastPathComponent(slidePath))
 										imgPath = resolveRelativePath(dir, imgPath)
 									}
-									imgData, err := readFileFromZip(zr, imgPath)
+									imgData, size, err := r.readMedia(zr, imgPath)
 									if err == nil {
 										pendingBlipFillData = imgData
 										pendingBlipFillMime = guessMimeType(imgPath)
+										pendingBlipFillPart = imgPath
+										pendingBlipFillSize = size
 									}
 									break
 								}
@@ -1824,15 +2390,42 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 						if attr.Name.Local == "embed" {
 							for _, rel := range rels {
 								if rel.ID == attr.Value {
+									if rel.isExternal() {
+										break
+									}
+									imgPath := rel.Target
+									if !strings.HasPrefix(imgPath, "ppt/") {
+										dir := strings.TrimSuffix(slidePath, "/"+lastPathComponent(slidePath))
+										imgPath = resolveRelativePath(dir, imgPath)
+									}
+									imgData, _, err := r.readMedia(zr, imgPath)
+									if err == nil {
+										slide.background = NewFill().SetPicture(imgData)
+									}
+									break
+								}
+							}
+						}
+					}
+				} else if state.inTcPrBlipFill {
+					// <a:blip> inside <a:blipFill> inside <a:tcPr> — cell picture fill
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "embed" {
+							for _, rel := range rels {
+								if rel.ID == attr.Value {
+									if rel.isExternal() {
+										break
+									}
 									imgPath := rel.Target
 									if !strings.HasPrefix(imgPath, "ppt/") {
 										dir := strings.TrimSuffix(slidePath, "/"+lastPathComponent(slidePath))
 										imgPath = resolveRelativePath(dir, imgPath)
 									}
-									imgData, err := readFileFromZip(zr, imgPath)
+									imgData, _, err := r.readMedia(zr, imgPath)
 									if err == nil {
-										bgBlipFillData = imgData
-										bgBlipFillMime = guessMimeType(imgPath)
+										if cell := tcPrCell(); cell != nil {
+											cell.fill = NewFill().SetPicture(imgData)
+										}
 									}
 									break
 								}
@@ -1841,15 +2434,37 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 					}
 				}
 			case "alphaModFix":
-				if state.inPic && currentDrawing != nil {
-					for _, attr := range t.Attr {
-						if attr.Name.Local == "amt" {
-							if v, err := strconv.Atoi(attr.Value); err == nil {
-								currentDrawing.alpha = v
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "amt" {
+						if v, err := strconv.Atoi(attr.Value); err == nil {
+							if state.inPic && currentDrawing != nil {
+								currentDrawing.SetAlphaValue(v)
+							} else if state.inSpPrBlipFill {
+								pendingBlipFillAlpha = v
 							}
 						}
 					}
 				}
+			case "duotone":
+				if (state.inPic && currentDrawing != nil) || state.inSpPrBlipFill {
+					state.inDuotone = true
+					duotoneColors = nil
+				}
+			case "clrChange":
+				if (state.inPic && currentDrawing != nil) || state.inSpPrBlipFill {
+					state.inClrChange = true
+					clrChangeColors = nil
+					clrChangeUseA = true
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "useA" {
+							clrChangeUseA = attr.Value == "1" || attr.Value == "true"
+						}
+					}
+				}
+			case "tile":
+				if state.inBgBlipFill && slide.background != nil && slide.background.Type == FillPicture {
+					slide.background.Tile = parsePictureTile(t.Attr)
+				}
 			case "srcRect":
 				if state.inPic && currentDrawing != nil {
 					for _, attr := range t.Attr {
@@ -1877,6 +2492,16 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 				if state.inSpPr {
 					state.inLn = true
 				}
+				if state.inRunProps && currentFont != nil {
+					state.inRunPropsLn = true
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "w" {
+							if v, err := strconv.ParseInt(attr.Value, 10, 64); err == nil {
+								currentFont.OutlineWidth = v
+							}
+						}
+					}
+				}
 				if state.inCxnSp && currentLine != nil {
 					for _, attr := range t.Attr {
 						if attr.Name.Local == "w" {
@@ -1886,7 +2511,7 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 							}
 						}
 					}
-				} else if state.inSp && state.inSpPr {
+				} else if (state.inSp || state.inPic) && state.inSpPr {
 					for _, attr := range t.Attr {
 						if attr.Name.Local == "w" {
 							if v, err := strconv.Atoi(attr.Value); err == nil {
@@ -1966,36 +2591,64 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 				if state.inLn && state.inCxnSp && currentLine != nil {
 					for _, attr := range t.Attr {
 						if attr.Name.Local == "val" {
-							switch attr.Value {
-							case "dash", "lgDash", "sysDash":
-								currentLine.lineStyle = BorderDash
-							case "dot", "sysDot":
-								currentLine.lineStyle = BorderDot
-							case "solid":
-								currentLine.lineStyle = BorderSolid
+							if style, ok := parseDashStyle(attr.Value); ok {
+								currentLine.lineStyle = style
 							}
 						}
 					}
-				} else if state.inLn && state.inSp {
+				} else if state.inLn && (state.inSp || state.inPic) {
 					for _, attr := range t.Attr {
 						if attr.Name.Local == "val" {
-							switch attr.Value {
-							case "dash", "lgDash", "sysDash":
+							if style, ok := parseDashStyle(attr.Value); ok && style != BorderSolid {
 								if pendingBorder == nil {
-									pendingBorder = &Border{Style: BorderDash}
+									pendingBorder = &Border{Style: style}
 								} else {
-									pendingBorder.Style = BorderDash
+									pendingBorder.Style = style
 								}
-							case "dot", "sysDot":
-								if pendingBorder == nil {
-									pendingBorder = &Border{Style: BorderDot}
-								} else {
-									pendingBorder.Style = BorderDot
+							}
+						}
+					}
+				} else if state.inTcPrLn {
+					// A side without a line (noFill) stays undrawn.
+					if cell := tcPrCell(); cell != nil {
+						if b := cellBorderSide(cell.border, state.tcPrLnSide); b != nil && b.Style != BorderNone {
+							for _, attr := range t.Attr {
+								if attr.Name.Local == "val" {
+									if style, ok := parseDashStyle(attr.Value); ok {
+										b.Style = style
+									}
 								}
 							}
 						}
 					}
 				}
+			case "ds":
+				// <a:custDash><a:ds d="..." sp="..."/>: lengths in 1/1000 percent of the line width.
+				if state.inLn && state.inCxnSp && currentLine != nil {
+					currentLine.lineStyle = BorderDash
+					currentLine.dashPattern = append(currentLine.dashPattern, parseDashStop(t.Attr)...)
+				} else if state.inLn && (state.inSp || state.inPic) {
+					if pendingBorder == nil {
+						pendingBorder = &Border{}
+					}
+					pendingBorder.Style = BorderDash
+					pendingBorder.DashPattern = append(pendingBorder.DashPattern, parseDashStop(t.Attr)...)
+				} else if state.inTcPrLn {
+					if cell := tcPrCell(); cell != nil {
+						if b := cellBorderSide(cell.border, state.tcPrLnSide); b != nil && b.Style != BorderNone {
+							b.Style = BorderDash
+							b.DashPattern = append(b.DashPattern, parseDashStop(t.Attr)...)
+						}
+					}
+				}
+			case "round", "bevel", "miter":
+				if state.inLn && state.inCxnSp && currentLine != nil {
+					currentLine.lineJoin, currentLine.miterLimit = parseLineJoin(t)
+				} else if state.inLn && (state.inSp || state.inPic) && pendingBorder != nil {
+					// The join follows the line's width and fill, so an outline
+					// without them comes from the theme, which is not read.
+					pendingBorder.Join, pendingBorder.MiterLimit = parseLineJoin(t)
+				}
 			case "effectLst":
 				if state.inSpPr && !state.inLn {
 					state.inEffectLst = true
@@ -2022,6 +2675,24 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 						}
 					}
 				}
+			case "softEdge":
+				if state.inEffectLst && state.inPic && currentDrawing != nil {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "rad" {
+							if v, err := strconv.ParseInt(attr.Value, 10, 64); err == nil {
+								currentDrawing.softEdge = v
+							}
+						}
+					}
+				}
+			case "reflection":
+				if state.inEffectLst && state.inPic && currentDrawing != nil {
+					currentDrawing.reflection = parseReflection(t.Attr)
+				}
+			case "grayscl":
+				if state.inPic && currentDrawing != nil {
+					currentDrawing.grayscale = true
+				}
 			case "spPr", "grpSpPr":
 				if state.inSp || state.inPic || state.inCxnSp || state.inGrpSp {
 					state.inSpPr = true
@@ -2170,16 +2841,25 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 
 		case xml.CharData:
 			text := string(t)
+			if inTableStyleID && currentTable != nil && pres != nil {
+				if ts, ok := pres.tableStyles[strings.TrimSpace(text)]; ok {
+					borders := *ts.borders
+					currentTable.borders = &borders
+					currentTable.style = ts
+				}
+			}
 			if state.inTcText && currentParagraph != nil {
 				tr := currentParagraph.CreateTextRun(text)
 				if currentFont != nil {
 					tr.font = currentFont
 				}
+				tr.hyperlink = runLink
 			} else if state.inText && currentParagraph != nil {
 				tr := currentParagraph.CreateTextRun(text)
 				if currentFont != nil {
 					tr.font = currentFont
 				}
+				tr.hyperlink = runLink
 			}
 
 		case xml.EndElement:
@@ -2203,6 +2883,9 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 						if g != nil {
 							g.name = top.name
 							g.description = top.descr
+							g.locks = top.locks
+							g.hyperlink = top.link
+							g.id = top.id
 							g.offsetX = top.offX
 							g.offsetY = top.offY
 							g.width = top.extCX
@@ -2237,6 +2920,10 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 					state.inSp = false
 					if state.isPlaceholder && currentPlaceholder != nil {
 						currentPlaceholder.name = shapeName
+						currentPlaceholder.locks = shapeLocks
+						currentPlaceholder.hyperlink = shapeLink
+						currentPlaceholder.id = shapeID
+						currentPlaceholder.textBox = shapeTextBox
 						currentPlaceholder.description = shapeDescr
 						currentPlaceholder.offsetX = offX
 						currentPlaceholder.offsetY = offY
@@ -2245,6 +2932,24 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 						currentPlaceholder.flipHorizontal = flipH
 						currentPlaceholder.flipVertical = flipV
 						currentPlaceholder.rotation = shapeRotation
+						if prstGeom != "" && prstGeom != "rect" {
+							currentPlaceholder.geometry = AutoShapeType(prstGeom)
+							currentPlaceholder.adjustValues = pendingAdjustValues
+							pendingAdjustValues = nil
+						}
+						// Apply deferred shape-level fill, border and shadow
+						if pendingShapeFill != nil {
+							currentPlaceholder.fill = pendingShapeFill
+							pendingShapeFill = nil
+						}
+						if pendingBorder != nil {
+							currentPlaceholder.border = pendingBorder
+							pendingBorder = nil
+						}
+						if pendingShadow != nil {
+							currentPlaceholder.shadow = pendingShadow
+							pendingShadow = nil
+						}
 						if state.inGrpSp && currentGroup != nil {
 							currentGroup.AddShape(currentPlaceholder)
 						} else {
@@ -2255,6 +2960,10 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 						// Non-rect geometry → AutoShape
 						autoShape := NewAutoShape()
 						autoShape.name = shapeName
+						autoShape.locks = shapeLocks
+						autoShape.hyperlink = shapeLink
+						autoShape.id = shapeID
+						autoShape.textBox = shapeTextBox
 						autoShape.description = shapeDescr
 						autoShape.offsetX = offX
 						autoShape.offsetY = offY
@@ -2297,6 +3006,7 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 						if currentRichText != nil && len(currentRichText.paragraphs) > 0 {
 							autoShape.paragraphs = currentRichText.paragraphs
 							autoShape.textAnchor = textAnchor
+							autoShape.anchorCtr = textAnchorCtr
 							autoShape.textDirection = textDir
 							autoShape.fontScale = currentRichText.fontScale
 							// Copy text insets from richtext body properties
@@ -2326,10 +3036,14 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 						} else {
 							slide.shapes = append(slide.shapes, autoShape)
 						}
-					} else if len(pendingBlipFillData) > 0 {
+					} else if len(pendingBlipFillData) > 0 || pendingBlipFillPart != "" || pendingBlipFillURL != "" {
 						// Shape has blipFill — convert to DrawingShape
 						ds := NewDrawingShape()
 						ds.name = shapeName
+						ds.locks = shapeLocks
+						ds.hyperlink = shapeLink
+						ds.id = shapeID
+						ds.textBox = shapeTextBox
 						ds.description = shapeDescr
 						ds.offsetX = offX
 						ds.offsetY = offY
@@ -2340,8 +3054,17 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 						ds.rotation = shapeRotation
 						ds.data = pendingBlipFillData
 						ds.mimeType = pendingBlipFillMime
+						ds.mediaPart = pendingBlipFillPart
+						ds.mediaSize = pendingBlipFillSize
+						if pendingBlipFillAlpha >= 0 {
+							ds.SetAlphaValue(pendingBlipFillAlpha)
+						}
+						ds.externalURL = pendingBlipFillURL
+						ds.duotone = pendingBlipFillDuotone
+						ds.clrChange = pendingBlipFillClrChange
 						pendingBlipFillData = nil
 						pendingBlipFillMime = ""
+						pendingBlipFillPart = ""
 						if state.inGrpSp && currentGroup != nil {
 							currentGroup.AddShape(ds)
 						} else {
@@ -2349,6 +3072,10 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 						}
 					} else if currentRichText != nil {
 						currentRichText.name = shapeName
+						currentRichText.locks = shapeLocks
+						currentRichText.hyperlink = shapeLink
+						currentRichText.id = shapeID
+						currentRichText.textBox = shapeTextBox
 						currentRichText.description = shapeDescr
 						currentRichText.offsetX = offX
 						currentRichText.offsetY = offY
@@ -2358,6 +3085,7 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 						currentRichText.flipVertical = flipV
 						currentRichText.rotation = shapeRotation
 						currentRichText.textAnchor = textAnchor
+						currentRichText.anchorCtr = textAnchorCtr
 						// Apply deferred shape-level fill (spPr comes before txBody)
 						if pendingShapeFill != nil {
 							currentRichText.fill = pendingShapeFill
@@ -2397,6 +3125,10 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 						// RichTextShape to carry the custom path, fill, and border.
 						rt := NewRichTextShape()
 						rt.name = shapeName
+						rt.locks = shapeLocks
+						rt.hyperlink = shapeLink
+						rt.id = shapeID
+						rt.textBox = shapeTextBox
 						rt.description = shapeDescr
 						rt.offsetX = offX
 						rt.offsetY = offY
@@ -2432,11 +3164,16 @@ func (r *PPTXReader) parseSlideXML(decoder *xml.Decoder, slide *Slide, rels []xm
 						} else {
 							slide.shapes = append(slide.shapes, rt)
 						}
-					} else if prstGeom != "" && (pendingShapeFill != nil || pendingBorder != nil || pendingShadow != nil) {
+					} else if prstGeom != "" && (pendingShapeFill != nil || pendingBorder != nil || pendingShadow != nil || shapeLink != nil) {
 						// Shape with geometry (including rect) that has fill or border
 						// but no text body — create an AutoShape so it gets rendered.
+						// Invisible shapes are kept when they carry a click link.
 						autoShape := NewAutoShape()
 						autoShape.name = shapeName
+						autoShape.locks = shapeLocks
+						autoShape.hyperlink = shapeLink
+						autoShape.id = shapeID
+						autoShape.textBox = shapeTextBox
 						autoShape.description = shapeDescr
 						autoShape.offsetX = offX
 						autoShape.offsetY = offY
@@ -2485,6 +3222,9 @@ if state.inGrpSp && currentGroup != nil {
 					state.inPic = false
 					if currentDrawing != nil {
 						currentDrawing.name = shapeName
+						currentDrawing.locks = shapeLocks
+						currentDrawing.hyperlink = shapeLink
+						currentDrawing.id = shapeID
 						currentDrawing.description = shapeDescr
 						currentDrawing.offsetX = offX
 						currentDrawing.offsetY = offY
@@ -2493,6 +3233,15 @@ if state.inGrpSp && currentGroup != nil {
 						currentDrawing.flipHorizontal = flipH
 						currentDrawing.flipVertical = flipV
 						currentDrawing.rotation = shapeRotation
+						currentDrawing.border = pendingBorder
+						currentDrawing.shadow = pendingShadow
+						if prstGeom != "rect" {
+							currentDrawing.geometry = prstGeom
+						}
+						currentDrawing.adjustValues = pendingAdjustValues
+						pendingBorder = nil
+						pendingShadow = nil
+						pendingAdjustValues = nil
 						if state.inGrpSp && currentGroup != nil {
 							currentGroup.AddShape(currentDrawing)
 						} else {
@@ -2506,6 +3255,9 @@ if state.inGrpSp && currentGroup != nil {
 					state.inCxnSp = false
 					if currentLine != nil {
 						currentLine.name = shapeName
+						currentLine.locks = shapeLocks
+						currentLine.hyperlink = shapeLink
+						currentLine.id = shapeID
 						currentLine.offsetX = offX
 						currentLine.offsetY = offY
 						currentLine.width = extCX
@@ -2535,30 +3287,95 @@ if state.inGrpSp && currentGroup != nil {
 					state.inGraphicFrame = false
 					if currentTable != nil {
 						currentTable.name = shapeName
+						currentTable.locks = shapeLocks
+						currentTable.hyperlink = shapeLink
+						currentTable.id = shapeID
 						currentTable.offsetX = offX
 						currentTable.offsetY = offY
 						currentTable.width = extCX
 						currentTable.height = extCY
 						slide.shapes = append(slide.shapes, currentTable)
+					} else if frameURI != "" {
+						// Keep a stand-in for content we cannot read, unless
+						// the frame already produced a shape (e.g. the
+						// preview picture of an OLE object).
+						n := len(slide.shapes)
+						if state.inGrpSp && currentGroup != nil {
+							n = len(currentGroup.shapes)
+						}
+						// Charts are read into a chart shape, which keeps
+						// the cached preview picture to draw while its data
+						// is unchanged; a chart type the model lacks falls
+						// back to the preview alone.
+						var frame Shape
+						if n == frameShapes && frameChartID != "" {
+							if part := chartPartPath(rels, slidePath, frameChartID); part != "" {
+								preview := r.readChartPreview(zr, part)
+								if chart := r.readChart(zr, part, pres); chart != nil {
+									chart.setPreview(preview)
+									frame = chart
+								} else if preview != nil {
+									frame = preview
+								}
+							}
+						}
+						if frame == nil && n == frameShapes {
+							u := &UnsupportedShape{kind: graphicDataKind(frameURI), uri: frameURI}
+							if frameProgID != "" {
+								u.kind += " (" + frameProgID + ")"
+							}
+							frame = u
+						}
+						if frame != nil {
+							b := frame.base()
+							b.name = shapeName
+							b.description = shapeDescr
+							b.locks = shapeLocks
+							b.hyperlink = shapeLink
+							b.id = shapeID
+							b.offsetX = offX
+							b.offsetY = offY
+							b.width = extCX
+							b.height = extCY
+							if state.inGrpSp && currentGroup != nil {
+								currentGroup.AddShape(frame)
+							} else {
+								slide.shapes = append(slide.shapes, frame)
+							}
+						}
 					}
 					currentTable = nil
 				}
 			case "tbl":
 				state.inTbl = false
+			case "tableStyleId":
+				inTableStyleID = false
 			case "tr":
 				state.inTr = false
 			case "tc":
+				if state.inTc && currentTable != nil && currentTableRow >= 0 && currentTableCol >= 0 &&
+					currentTableRow < len(currentTable.rows) && currentTableCol < len(currentTable.rows[currentTableRow]) {
+					cell := currentTable.rows[currentTableRow][currentTableCol]
+					cell.fillSet = cell.fill != nil
+					if cell.fill == nil {
+						cell.fill = NewFill()
+					}
+				}
 				state.inTc = false
 				state.inTcTxBody = false
 				state.inTcPr = false
 				state.inTcPrSolidFill = false
 				state.inTcPrLn = false
 				state.tcPrLnSide = ""
+				state.inTcPrBlipFill = false
+				state.inTcPrPattFill = false
 			case "tcPr":
 				state.inTcPr = false
 				state.inTcPrSolidFill = false
 				state.inTcPrLn = false
 				state.tcPrLnSide = ""
+				state.inTcPrBlipFill = false
+				state.inTcPrPattFill = false
 			case "lnL", "lnR", "lnT", "lnB":
 				if state.inTcPr {
 					state.inTcPrLn = false
@@ -2605,6 +3422,10 @@ if state.inGrpSp && currentGroup != nil {
 				state.inRunProps = false
 				state.inSolidFill = false
 				state.inRunPropsGradFill = false
+			case "uFill":
+				state.inUFill = false
+			case "uLn":
+				state.inULn = false
 			case "defRPr":
 				state.inDefRPr = false
 				state.inSolidFill = false
@@ -2625,9 +3446,35 @@ if state.inGrpSp && currentGroup != nil {
 				state.inGsLst = false
 			case "gradFill":
 				if state.inRunPropsGradFill && currentFont != nil && len(gradStopColors) >= 1 {
-					// Use first gradient stop color as text color
+					// The first stop color doubles as the plain text color
 					currentFont.Color = gradStopColors[0]
+					if len(gradStopColors) >= 2 {
+						currentFont.Gradient = NewFill().SetGradientLinear(gradStopColors[0], gradStopColors[len(gradStopColors)-1], gradAngle)
+					}
 					state.inRunPropsGradFill = false
+				} else if state.inGradFill && (state.inTcPrLn || state.inLn) {
+					// Gradient strokes are drawn in the stop color covering
+					// most of the line.
+					if len(gradStopColors) > 0 {
+						c := dominantStopColor(gradStopColors, gradStopPositions)
+						if state.inTcPrLn {
+							if currentTable != nil && currentTableRow >= 0 && currentTableCol >= 0 &&
+								currentTableRow < len(currentTable.rows) && currentTableCol < len(currentTable.rows[currentTableRow]) {
+								cell := currentTable.rows[currentTableRow][currentTableCol]
+								if b := cellBorderSide(cell.border, state.tcPrLnSide); b != nil {
+									b.Color = c
+									b.Style = BorderSolid
+								}
+							}
+						} else if state.inCxnSp && currentLine != nil {
+							currentLine.lineColor = c
+						} else if state.inSp {
+							if pendingBorder == nil {
+								pendingBorder = &Border{Style: BorderSolid}
+							}
+							pendingBorder.Color = c
+						}
+					}
 				} else if state.inGradFill && len(gradStopColors) >= 2 {
 					startColor := gradStopColors[0]
 					endColor := gradStopColors[len(gradStopColors)-1]
@@ -2639,12 +3486,57 @@ if state.inGrpSp && currentGroup != nil {
 					} else if state.inSpPr && state.inSp {
 						pendingShapeFill = NewFill()
 						pendingShapeFill.SetGradientLinear(startColor, endColor, gradAngle)
+					} else if state.inTcPr {
+						if cell := tcPrCell(); cell != nil {
+							cell.fill = NewFill().SetGradientLinear(startColor, endColor, gradAngle)
+						}
 					}
 				}
 				state.inGradFill = false
+			case "duotone":
+				if state.inDuotone && len(duotoneColors) == 2 {
+					if state.inPic && currentDrawing != nil {
+						currentDrawing.duotone = duotoneColors
+					} else if state.inSpPrBlipFill {
+						pendingBlipFillDuotone = duotoneColors
+					}
+				}
+				state.inDuotone = false
+			case "clrChange":
+				if state.inClrChange && len(clrChangeColors) == 2 {
+					cc := &ColorChange{From: clrChangeColors[0], To: clrChangeColors[1], UseAlpha: clrChangeUseA}
+					if state.inPic && currentDrawing != nil {
+						currentDrawing.clrChange = cc
+					} else if state.inSpPrBlipFill {
+						pendingBlipFillClrChange = cc
+					}
+				}
+				state.inClrChange = false
 			case "blipFill":
 				state.inSpPrBlipFill = false
 				state.inBgBlipFill = false
+				state.inTcPrBlipFill = false
+			case "pattFill":
+				if state.inTcPrPattFill {
+					// The preset defaults to 5%, black on white, when the
+					// attribute or colors are left out.
+					fg, bg := ColorBlack, ColorWhite
+					if pattFg != nil {
+						fg = *pattFg
+					}
+					if pattBg != nil {
+						bg = *pattBg
+					}
+					if pattPrst == "" {
+						pattPrst = "pct5"
+					}
+					if cell := tcPrCell(); cell != nil {
+						cell.fill = NewFill().SetPattern(pattPrst, fg, bg)
+					}
+					state.inTcPrPattFill = false
+				}
+			case "fgClr", "bgClr":
+				state.inPattBgClr = false
 			case "srgbClr":
 				state.inSrgbClr = false
 			case "schemeClr":
@@ -2678,6 +3570,7 @@ if state.inGrpSp && currentGroup != nil {
 				}
 			case "ln":
 				state.inLn = false
+				state.inRunPropsLn = false
 			case "extLst":
 				state.inExtLst = false
 			case "avLst":
@@ -2697,6 +3590,8 @@ if state.inGrpSp && currentGroup != nil {
 			case "style":
 				state.inStyle = false
 				state.inFontRef = false
+			case "transition":
+				state.inTransition = false
 			case "fontRef":
 				state.inFontRef = false
 			case "t":
@@ -2711,27 +3606,203 @@ if state.inGrpSp && currentGroup != nil {
 					if top.name == "" {
 						top.name = shapeName
 						top.descr = shapeDescr
+						top.locks = shapeLocks
+						top.link = shapeLink
+						top.id = shapeID
 					}
 				}
 			}
 		}
+
+		if ee, ok := token.(xml.EndElement); ok && isShapeElement(ee.Name.Local) && len(rawStack) > 0 {
+			top := rawStack[len(rawStack)-1]
+			rawStack = rawStack[:len(rawStack)-1]
+			if shapes := *top.container; len(shapes) > top.n && int(decoder.InputOffset()) <= len(slide.rawXML) {
+				shapes[len(shapes)-1].base().rawXML = slide.rawXML[top.start:decoder.InputOffset()]
+			}
+		}
 	}
 
-	// If slide has a blipFill background image, prepend as full-slide drawing
-	if len(bgBlipFillData) > 0 && pres != nil {
-		ds := NewDrawingShape()
-		ds.data = bgBlipFillData
-		ds.mimeType = bgBlipFillMime
-		ds.offsetX = 0
-		ds.offsetY = 0
-		ds.width = pres.layout.CX
-		ds.height = pres.layout.CY
-		slide.shapes = append([]Shape{ds}, slide.shapes...)
+	return nil
+}
+
+// cellBorderSide returns the border of side "L", "R", "T" or "B" of b.
+func cellBorderSide(b *CellBorders, side string) *Border {
+	if p := cellBorderSidePtr(b, side); p != nil {
+		return *p
 	}
+	return nil
+}
 
+// cellBorderSidePtr returns the field of b holding the given side ("L", "R",
+// "T" or "B"), or nil for an unknown side.
+func cellBorderSidePtr(b *CellBorders, side string) **Border {
+	if b == nil {
+		return nil
+	}
+	switch side {
+	case "L":
+		return &b.Left
+	case "R":
+		return &b.Right
+	case "T":
+		return &b.Top
+	case "B":
+		return &b.Bottom
+	}
 	return nil
 }
 
+// colorElementValue resolves a srgbClr, schemeClr, prstClr or sysClr element
+// to a color, without any child modifiers applied.
+func colorElementValue(t xml.StartElement, pres *Presentation) (Color, bool) {
+	val := ""
+	for _, attr := range t.Attr {
+		if (t.Name.Local == "sysClr" && attr.Name.Local == "lastClr") ||
+			(t.Name.Local != "sysClr" && attr.Name.Local == "val") {
+			val = attr.Value
+		}
+	}
+	if val == "" {
+		return Color{}, false
+	}
+	switch t.Name.Local {
+	case "srgbClr", "sysClr":
+		return NewColor("FF" + val), true
+	case "prstClr":
+		return presetColorToColor(val), true
+	case "schemeClr":
+		if pres != nil {
+			if argb, ok := pres.themeColors[val]; ok && argb != "" {
+				return schemeColor(val, argb), true
+			}
+		}
+	}
+	return Color{}, false
+}
+
+// parseShapeLocks reads the flags of a *Locks element, returning nil when no
+// lock is set.
+func parseShapeLocks(attrs []xml.Attr) *ShapeLocks {
+	l := &ShapeLocks{}
+	set := false
+	for _, attr := range attrs {
+		if attr.Value != "1" && attr.Value != "true" {
+			continue
+		}
+		for _, a := range l.attrs() {
+			if a.name == attr.Name.Local {
+				*a.v = true
+				set = true
+			}
+		}
+	}
+	if !set {
+		return nil
+	}
+	return l
+}
+
+// parseDashStop converts the d and sp attributes of an <a:ds> element, given
+// in thousandths of a percent of the line width, to a dash/gap pair in
+// multiples of the line width.
+func parseDashStop(attrs []xml.Attr) []float64 {
+	var d, sp float64
+	for _, attr := range attrs {
+		v, err := strconv.Atoi(attr.Value)
+		if err != nil {
+			continue
+		}
+		switch attr.Name.Local {
+		case "d":
+			d = float64(v) / 100000
+		case "sp":
+			sp = float64(v) / 100000
+		}
+	}
+	return []float64{d, sp}
+}
+
+// parseDashStyle returns the border style of an <a:prstDash> value, or
+// false if val is not a preset.
+func parseDashStyle(val string) (BorderStyle, bool) {
+	switch style := BorderStyle(val); style {
+	case BorderSolid, BorderDash, BorderDot, BorderLgDash, BorderDashDot, BorderLgDashDot, BorderLgDashDotDot,
+		BorderSysDash, BorderSysDot, BorderSysDashDot, BorderSysDashDotDot:
+		return style, true
+	}
+	return "", false
+}
+
+// parseLineJoin reads an <a:round>, <a:bevel> or <a:miter lim="..."> line
+// join.
+func parseLineJoin(t xml.StartElement) (LineJoin, int) {
+	join := LineJoin(t.Name.Local)
+	lim := 0
+	if join == LineJoinMiter {
+		for _, attr := range t.Attr {
+			if attr.Name.Local == "lim" {
+				lim, _ = strconv.Atoi(attr.Value)
+			}
+		}
+	}
+	return join, lim
+}
+
+// parsePictureTile reads the <a:tile> settings of a picture fill.
+func parsePictureTile(attrs []xml.Attr) *PictureTile {
+	tile := &PictureTile{ScaleX: 100000, ScaleY: 100000}
+	for _, attr := range attrs {
+		v, _ := strconv.ParseInt(attr.Value, 10, 64)
+		switch attr.Name.Local {
+		case "tx":
+			tile.OffsetX = v
+		case "ty":
+			tile.OffsetY = v
+		case "sx":
+			tile.ScaleX = int(v)
+		case "sy":
+			tile.ScaleY = int(v)
+		case "flip":
+			if attr.Value != "none" {
+				tile.Flip = attr.Value
+			}
+		case "algn":
+			tile.Align = attr.Value
+		}
+	}
+	return tile
+}
+
+// parseReflection reads an <a:reflection> effect, starting from the
+// schema's defaults for omitted attributes.
+func parseReflection(attrs []xml.Attr) *Reflection {
+	ref := &Reflection{StartAlpha: 100000, EndPosition: 100000, ScaleY: 100000}
+	for _, attr := range attrs {
+		v, err := strconv.ParseInt(attr.Value, 10, 64)
+		if err != nil {
+			continue
+		}
+		switch attr.Name.Local {
+		case "blurRad":
+			ref.BlurRadius = v
+		case "dist":
+			ref.Distance = v
+		case "stA":
+			ref.StartAlpha = int(v)
+		case "stPos":
+			ref.StartPosition = int(v)
+		case "endA":
+			ref.EndAlpha = int(v)
+		case "endPos":
+			ref.EndPosition = int(v)
+		case "sy":
+			ref.ScaleY = int(v)
+		}
+	}
+	return ref
+}
+
 func lastPathComponent(path string) string {
 	parts := strings.Split(path, "/")
 	return parts[len(parts)-1]
@@ -2815,6 +3886,8 @@ type layoutPlaceholder struct {
 	insetTop    int64
 	insetBottom int64
 	insetsSet   bool
+	// Text of a placeholder with hasCustomPrompt="1", shown while it is empty
+	prompt string
 }
 
 // applyLayoutInheritance reads the slide layout and applies inherited properties
@@ -2823,7 +3896,7 @@ func (r *PPTXReader) applyLayoutInheritance(zr *zip.Reader, slide *Slide, rels [
 	// Find the slide layout relationship
 	layoutPath := ""
 	for _, rel := range rels {
-		if rel.Type == relTypeSlideLayout {
+		if rel.Type == relTypeSlideLayout && !rel.isExternal() {
 			target := rel.Target
 			if !strings.HasPrefix(target, "ppt/") {
 				dir := strings.TrimSuffix(slidePath, "/"+lastPathComponent(slidePath))
@@ -2833,6 +3906,13 @@ func (r *PPTXReader) applyLayoutInheritance(zr *zip.Reader, slide *Slide, rels [
 			break
 		}
 	}
+	// Some generated decks omit layouts entirely; such slides inherit
+	// straight from the first slide master.
+	fromMaster := false
+	if layoutPath == "" {
+		layoutPath = r.firstMasterPath(zr)
+		fromMaster = true
+	}
 	if layoutPath == "" {
 		return
 	}
@@ -2843,7 +3923,8 @@ func (r *PPTXReader) applyLayoutInheritance(zr *zip.Reader, slide *Slide, rels [
 	}
 
 	// Read layout relationships for images
-	layoutRelsPath := strings.Replace(layoutPath, "slideLayouts/", "slideLayouts/_rels/", 1) + ".rels"
+	dir := strings.TrimSuffix(layoutPath, "/"+lastPathComponent(layoutPath))
+	layoutRelsPath := dir + "/_rels/" + lastPathComponent(layoutPath) + ".rels"
 	layoutRels, _ := r.readRelationships(zr, layoutRelsPath)
 
 	// Parse layout images and non-placeholder text shapes, prepend to slide (behind slide content)
@@ -2854,31 +3935,31 @@ func (r *PPTXReader) applyLayoutInheritance(zr *zip.Reader, slide *Slide, rels [
 
 	// Parse layout to extract placeholder definitions
 	layoutPHs := r.parseLayoutPlaceholders(data, pres)
+	if fromMaster {
+		applyMasterTextStyles(layoutPHs, r.parseMasterTextStyles(data, pres))
+	}
+	// The master's text styles give the fonts that neither the slide nor
+	// the layout sets, which the prompts are drawn in.
+	var masterStyles map[string]*Font
+	if !fromMaster {
+		for _, rel := range layoutRels {
+			if rel.Type != relTypeSlideMaster || rel.isExternal() {
+				continue
+			}
+			if masterData, err := readFileFromZip(zr, resolveRelativePath(dir, rel.Target)); err == nil {
+				masterStyles = r.parseMasterTextStyles(masterData, pres)
+			}
+			break
+		}
+	}
 
 	// Also parse layout background
-	layoutBg, bgImage := r.parseLayoutBackground(data, layoutRels, zr, layoutPath, pres)
+	layoutBg := r.parseLayoutBackground(data, layoutRels, zr, layoutPath, pres)
 
 	// Apply layout background if slide has no background
 	if slide.background == nil && layoutBg != nil {
 		slide.background = layoutBg
 	}
-	// If layout has a blipFill background image, prepend as full-slide drawing.
-	// Skip if slide already has a background image (first shape is a full-slide DrawingShape).
-	if bgImage != nil && slide.background == nil {
-		hasSlideBgImage := false
-		if len(slide.shapes) > 0 {
-			if ds, ok := slide.shapes[0].(*DrawingShape); ok && ds.offsetX == 0 && ds.offsetY == 0 && ds.width == pres.layout.CX && ds.height == pres.layout.CY {
-				hasSlideBgImage = true
-			}
-		}
-		if !hasSlideBgImage {
-			bgImage.offsetX = 0
-			bgImage.offsetY = 0
-			bgImage.width = pres.layout.CX
-			bgImage.height = pres.layout.CY
-			slide.shapes = append([]Shape{bgImage}, slide.shapes...)
-		}
-	}
 
 	if len(layoutPHs) == 0 {
 		return
@@ -2892,15 +3973,19 @@ func (r *PPTXReader) applyLayoutInheritance(zr *zip.Reader, slide *Slide, rels [
 		}
 
 		// Find matching layout placeholder by type and idx
+		phType := string(ph.phType)
+		if fromMaster {
+			phType = masterPlaceholderType(phType)
+		}
 		var match *layoutPlaceholder
 		for i := range layoutPHs {
 			lp := &layoutPHs[i]
-			if lp.phType == string(ph.phType) && lp.phIdx == ph.phIdx {
+			if lp.phType == phType && lp.phIdx == ph.phIdx {
 				match = lp
 				break
 			}
 			// Also match by type alone if idx is 0 (default)
-			if lp.phType == string(ph.phType) && ph.phIdx == 0 && lp.phIdx == 0 {
+			if lp.phType == phType && ph.phIdx == 0 && lp.phIdx == 0 {
 				match = lp
 				break
 			}
@@ -2909,7 +3994,7 @@ func (r *PPTXReader) applyLayoutInheritance(zr *zip.Reader, slide *Slide, rels [
 			// Try matching by type only (ignoring idx)
 			for i := range layoutPHs {
 				lp := &layoutPHs[i]
-				if lp.phType == string(ph.phType) {
+				if lp.phType == phType {
 					match = lp
 					break
 				}
@@ -2927,6 +4012,29 @@ func (r *PPTXReader) applyLayoutInheritance(zr *zip.Reader, slide *Slide, rels [
 			ph.height = match.extCY
 		}
 
+		// Remember the prompt and its font for template previews
+		ph.prompt = match.prompt
+		ph.promptFont = NewFont()
+		st := masterTextStyle(masterStyles, phType)
+		if match.fontName != "" {
+			ph.promptFont.Name = match.fontName
+		} else if st != nil && st.Name != "" {
+			ph.promptFont.Name = st.Name
+		}
+		ph.promptFont.NameEA = match.fontEA
+		if match.fontEA == "" && st != nil {
+			ph.promptFont.NameEA = st.NameEA
+		}
+		if match.fontSize > 0 {
+			ph.promptFont.Size = match.fontSize
+		} else {
+			ph.promptFont.Size = 18
+		}
+		ph.promptFont.Bold = match.fontBold
+		if match.fontColor.ARGB != "" {
+			ph.promptFont.Color = match.fontColor
+		}
+
 		// Apply text insets from layout if not set on the placeholder
 		if !ph.insetsSet && match.insetsSet {
 			ph.insetLeft = match.insetLeft
@@ -2969,6 +4077,160 @@ func (r *PPTXReader) applyLayoutInheritance(zr *zip.Reader, slide *Slide, rels [
 	}
 }
 
+// firstMasterPath returns the part name of the presentation's first slide
+// master, or "" if it has none.
+func (r *PPTXReader) firstMasterPath(zr *zip.Reader) string {
+	rels, err := r.readRelationships(zr, "ppt/_rels/presentation.xml.rels")
+	if err != nil {
+		return ""
+	}
+	for _, rel := range rels {
+		if rel.Type == relTypeSlideMaster && !rel.isExternal() {
+			target := rel.Target
+			if !strings.HasPrefix(target, "ppt/") {
+				target = resolveRelativePath("ppt", target)
+			}
+			return target
+		}
+	}
+	return ""
+}
+
+// masterPlaceholderType maps a slide placeholder type to the master
+// placeholder it inherits from: masters only define title, body and the
+// footer placeholders.
+func masterPlaceholderType(phType string) string {
+	switch PlaceholderType(phType) {
+	case PlaceholderCtrTitle:
+		return string(PlaceholderTitle)
+	case PlaceholderSubTitle, "obj", "":
+		return string(PlaceholderBody)
+	}
+	return phType
+}
+
+// parseMasterTextStyles reads the level-1 run defaults of a slide master's
+// p:txStyles, keyed by style element name (titleStyle, bodyStyle and
+// otherStyle). Unset properties are left zero.
+func (r *PPTXReader) parseMasterTextStyles(data []byte, pres *Presentation) map[string]*Font {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	styles := make(map[string]*Font)
+	var style string
+	var cur *Font
+	inTxStyles, inLvl1, inSolidFill := false, false, false
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "txStyles":
+				inTxStyles = true
+			case "titleStyle", "bodyStyle", "otherStyle":
+				if inTxStyles {
+					style = t.Name.Local
+				}
+			case "lvl1pPr":
+				inLvl1 = style != ""
+			case "defRPr":
+				if inLvl1 {
+					cur = &Font{}
+					styles[style] = cur
+					for _, attr := range t.Attr {
+						switch attr.Name.Local {
+						case "sz":
+							if v, err := strconv.Atoi(attr.Value); err == nil {
+								cur.Size = v / 100
+							}
+						case "b":
+							cur.Bold = attr.Value == "1"
+						}
+					}
+				}
+			case "solidFill":
+				inSolidFill = cur != nil
+			case "srgbClr", "schemeClr", "sysClr", "prstClr":
+				if inSolidFill {
+					if c, ok := colorElementValue(t, pres); ok {
+						cur.Color = c
+					}
+				}
+			case "latin", "ea":
+				if cur != nil {
+					for _, attr := range t.Attr {
+						if attr.Name.Local != "typeface" {
+							continue
+						}
+						name := pres.themeFont(attr.Value)
+						if t.Name.Local == "latin" {
+							cur.Name = name
+						} else {
+							cur.NameEA = name
+						}
+					}
+				}
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "txStyles":
+				return styles
+			case "titleStyle", "bodyStyle", "otherStyle":
+				style = ""
+			case "lvl1pPr":
+				inLvl1 = false
+			case "defRPr":
+				cur = nil
+				inSolidFill = false
+			case "solidFill":
+				inSolidFill = false
+			}
+		}
+	}
+	return styles
+}
+
+// masterTextStyle returns the p:txStyles run defaults that a placeholder
+// of type phType takes from its master, or nil.
+func masterTextStyle(styles map[string]*Font, phType string) *Font {
+	switch PlaceholderType(masterPlaceholderType(phType)) {
+	case PlaceholderTitle:
+		return styles["titleStyle"]
+	case PlaceholderBody:
+		return styles["bodyStyle"]
+	}
+	return styles["otherStyle"]
+}
+
+// applyMasterTextStyles fills in the font properties that master
+// placeholders leave to p:txStyles.
+func applyMasterTextStyles(phs []layoutPlaceholder, styles map[string]*Font) {
+	for i := range phs {
+		lp := &phs[i]
+		st := masterTextStyle(styles, lp.phType)
+		if st == nil {
+			continue
+		}
+		if lp.fontName == "" {
+			lp.fontName = st.Name
+		}
+		if lp.fontEA == "" {
+			lp.fontEA = st.NameEA
+		}
+		if lp.fontSize == 0 {
+			lp.fontSize = st.Size
+		}
+		if !lp.fontBold {
+			lp.fontBold = st.Bold
+		}
+		if lp.fontColor.ARGB == "" {
+			lp.fontColor = st.Color
+		}
+	}
+}
+
 // parseLayoutPlaceholders extracts placeholder definitions from a slide layout XML.
 func (r *PPTXReader) parseLayoutPlaceholders(data []byte, pres *Presentation) []layoutPlaceholder {
 	decoder := xml.NewDecoder(bytes.NewReader(data))
@@ -2992,12 +4254,19 @@ func (r *PPTXReader) parseLayoutPlaceholders(data []byte, pres *Presentation) []
 	var fontColor Color
 	var insetLeft, insetRight, insetTop, insetBottom int64
 	var insetsSet bool
+	customPrompt := false
+	inText := false
+	var prompt strings.Builder
 
+	mc := newMCFilter()
 	for {
 		token, err := decoder.Token()
 		if err != nil {
 			break
 		}
+		if mc.skip(decoder, token) {
+			continue
+		}
 
 		switch t := token.(type) {
 		case xml.StartElement:
@@ -3017,6 +4286,8 @@ func (r *PPTXReader) parseLayoutPlaceholders(data []byte, pres *Presentation) []
 				insetLeft, insetRight = 91440, 91440
 				insetTop, insetBottom = 45720, 45720
 				insetsSet = false
+				customPrompt = false
+				prompt.Reset()
 			case "nvSpPr":
 				if inSp {
 					inNvSpPr = true
@@ -3032,9 +4303,17 @@ func (r *PPTXReader) parseLayoutPlaceholders(data []byte, pres *Presentation) []
 							if v, err := strconv.Atoi(attr.Value); err == nil {
 								phIdx = v
 							}
+						case "hasCustomPrompt":
+							customPrompt = attr.Value == "1" || attr.Value == "true"
 						}
 					}
 				}
+			case "t":
+				inText = inTxBody && !inLstStyle
+			case "p":
+				if inTxBody && prompt.Len() > 0 {
+					prompt.WriteString("\n")
+				}
 			case "spPr":
 				if inSp && !inNvSpPr {
 					inSpPr = true
@@ -3149,7 +4428,7 @@ func (r *PPTXReader) parseLayoutPlaceholders(data []byte, pres *Presentation) []
 					}
 					if pres != nil && pres.themeColors != nil {
 						if argb, ok := pres.themeColors[schemeName]; ok && argb != "" {
-							fontColor = NewColor(argb)
+							fontColor = schemeColor(schemeName, argb)
 						}
 					}
 				}
@@ -3157,7 +4436,7 @@ func (r *PPTXReader) parseLayoutPlaceholders(data []byte, pres *Presentation) []
 				if inDefRPr {
 					for _, attr := range t.Attr {
 						if attr.Name.Local == "typeface" {
-							fontName = attr.Value
+							fontName = pres.themeFont(attr.Value)
 						}
 					}
 				}
@@ -3165,17 +4444,24 @@ func (r *PPTXReader) parseLayoutPlaceholders(data []byte, pres *Presentation) []
 				if inDefRPr {
 					for _, attr := range t.Attr {
 						if attr.Name.Local == "typeface" {
-							fontEA = attr.Value
+							fontEA = pres.themeFont(attr.Value)
 						}
 					}
 				}
 			}
 
+		case xml.CharData:
+			if inText {
+				prompt.Write(t)
+			}
+
 		case xml.EndElement:
 			switch t.Name.Local {
+			case "t":
+				inText = false
 			case "sp":
 				if inSp && isPH {
-					phs = append(phs, layoutPlaceholder{
+					lp := layoutPlaceholder{
 						phType:      phType,
 						phIdx:       phIdx,
 						offX:        offX,
@@ -3192,7 +4478,11 @@ func (r *PPTXReader) parseLayoutPlaceholders(data []byte, pres *Presentation) []
 						insetTop:    insetTop,
 						insetBottom: insetBottom,
 						insetsSet:   insetsSet,
-					})
+					}
+					if customPrompt {
+						lp.prompt = strings.TrimSpace(prompt.String())
+					}
+					phs = append(phs, lp)
 				}
 				inSp = false
 				inSpPr = false
@@ -3220,20 +4510,25 @@ func (r *PPTXReader) parseLayoutPlaceholders(data []byte, pres *Presentation) []
 	return phs
 }
 
-// parseLayoutBackground extracts the background fill from a slide layout XML.
-// It also handles blipFill backgrounds by returning an image shape via bgImage.
-func (r *PPTXReader) parseLayoutBackground(data []byte, rels []xmlRelForRead, zr *zip.Reader, layoutPath string, pres *Presentation) (*Fill, *DrawingShape) {
+// parseLayoutBackground extracts the background fill, which may be a
+// picture, from a slide layout XML.
+func (r *PPTXReader) parseLayoutBackground(data []byte, rels []xmlRelForRead, zr *zip.Reader, layoutPath string, pres *Presentation) *Fill {
 	decoder := xml.NewDecoder(bytes.NewReader(data))
 	inBg := false
 	inBgPr := false
 	inSolidFill := false
 	inBlipFill := false
+	var picture *Fill
 
+	mc := newMCFilter()
 	for {
 		token, err := decoder.Token()
 		if err != nil {
 			break
 		}
+		if mc.skip(decoder, token) {
+			continue
+		}
 
 		switch t := token.(type) {
 		case xml.StartElement:
@@ -3248,6 +4543,12 @@ func (r *PPTXReader) parseLayoutBackground(data []byte, rels []xmlRelForRead, zr
 				if inBgPr {
 					inSolidFill = true
 				}
+			case "bgRef":
+				// A theme background style reference; its color child
+				// is the fill color of the usual solid styles.
+				if inBg {
+					inSolidFill = true
+				}
 			case "blipFill":
 				if inBgPr {
 					inBlipFill = true
@@ -3258,17 +4559,17 @@ func (r *PPTXReader) parseLayoutBackground(data []byte, rels []xmlRelForRead, zr
 						if attr.Name.Local == "embed" {
 							for _, rel := range rels {
 								if rel.ID == attr.Value {
+									if rel.isExternal() {
+										break
+									}
 									imgPath := rel.Target
 									if !strings.HasPrefix(imgPath, "ppt/") {
 										dir := strings.TrimSuffix(layoutPath, "/"+lastPathComponent(layoutPath))
 										imgPath = resolveRelativePath(dir, imgPath)
 									}
-									imgData, err := readFileFromZip(zr, imgPath)
+									imgData, _, err := r.readMedia(zr, imgPath)
 									if err == nil {
-										ds := NewDrawingShape()
-										ds.data = imgData
-										ds.mimeType = guessMimeType(imgPath)
-										return nil, ds
+										picture = NewFill().SetPicture(imgData)
 									}
 									break
 								}
@@ -3276,13 +4577,17 @@ func (r *PPTXReader) parseLayoutBackground(data []byte, rels []xmlRelForRead, zr
 						}
 					}
 				}
+			case "tile":
+				if inBlipFill && picture != nil {
+					picture.Tile = parsePictureTile(t.Attr)
+				}
 			case "srgbClr":
 				if inSolidFill {
 					for _, attr := range t.Attr {
 						if attr.Name.Local == "val" {
 							fill := NewFill()
 							fill.SetSolid(NewColor("FF" + attr.Value))
-							return fill, nil
+							return fill
 						}
 					}
 				}
@@ -3297,32 +4602,35 @@ func (r *PPTXReader) parseLayoutBackground(data []byte, rels []xmlRelForRead, zr
 					if pres != nil && pres.themeColors != nil {
 						if argb, ok := pres.themeColors[schemeName]; ok && argb != "" {
 							fill := NewFill()
-							fill.SetSolid(NewColor(argb))
-							return fill, nil
+							fill.SetSolid(schemeColor(schemeName, argb))
+							return fill
 						}
 					}
 					// Fallback: treat bg1 as white
 					if schemeName == "bg1" {
 						fill := NewFill()
 						fill.SetSolid(ColorWhite)
-						return fill, nil
+						return fill
 					}
 				}
 			}
 		case xml.EndElement:
 			switch t.Name.Local {
 			case "bg":
-				return nil, nil // bg found but no recognized fill
+				return nil // bg found but no recognized fill
 			case "bgPr":
 				inBgPr = false
-			case "solidFill":
+			case "solidFill", "bgRef":
 				inSolidFill = false
 			case "blipFill":
 				inBlipFill = false
+				if picture != nil {
+					return picture
+				}
 			}
 		}
 	}
-	return nil, nil
+	return nil
 }
 
 // parseLayoutImages extracts image shapes and non-placeholder text shapes from a slide layout XML.
@@ -3349,13 +4657,14 @@ func (r *PPTXReader) parseLayoutImages(data []byte, rels []xmlRelForRead, zr *zi
 	inPPr := false
 	inPPrDefRPr := false
 	inPPrDefSolidFill := false
+	inRunLn := false // ln inside rPr (text outline)
 	inLn := false
 	inLnSolidFill := false
 	isPH := false
 	var offX, offY, extCX, extCY int64
 	var embedID string
 	var flipH, flipV bool
-	var picAlpha int // alphaModFix amount for pic blip
+	picAlpha := -1 // alphaModFix amount for pic blip; -1 when not given
 	var cropL, cropT, cropR, cropB int // srcRect crop percentages
 
 	// For cxnSp (line connector) shapes
@@ -3378,11 +4687,26 @@ func (r *PPTXReader) parseLayoutImages(data []byte, rels []xmlRelForRead, zr *zi
 	inFontRef := false
 	var fontRefColor *Color
 
+	// setRunColor stores a run-level solidFill color: the outline color
+	// inside ln, the text color otherwise.
+	setRunColor := func(c Color) *Color {
+		if inRunLn {
+			currentFont.OutlineColor = &c
+			return currentFont.OutlineColor
+		}
+		currentFont.Color = c
+		return &currentFont.Color
+	}
+
+	mc := newMCFilter()
 	for {
 		token, err := decoder.Token()
 		if err != nil {
 			break
 		}
+		if mc.skip(decoder, token) {
+			continue
+		}
 
 		switch t := token.(type) {
 		case xml.StartElement:
@@ -3391,7 +4715,7 @@ func (r *PPTXReader) parseLayoutImages(data []byte, rels []xmlRelForRead, zr *zi
 				inPic = true
 				offX, offY, extCX, extCY = 0, 0, 0, 0
 				embedID = ""
-				picAlpha = 0
+				picAlpha = -1
 				cropL, cropT, cropR, cropB = 0, 0, 0, 0
 			case "sp":
 				inSp = true
@@ -3513,6 +4837,16 @@ func (r *PPTXReader) parseLayoutImages(data []byte, rels []xmlRelForRead, zr *zi
 					}
 				}
 			case "ln":
+				if inRunProps && currentFont != nil {
+					inRunLn = true
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "w" {
+							if v, err := strconv.ParseInt(attr.Value, 10, 64); err == nil {
+								currentFont.OutlineWidth = v
+							}
+						}
+					}
+				}
 				if inSpPr && inCxnSp {
 					inLn = true
 					for _, attr := range t.Attr {
@@ -3564,17 +4898,21 @@ func (r *PPTXReader) parseLayoutImages(data []byte, rels []xmlRelForRead, zr *zi
 				if inLn && inCxnSp && currentLine != nil {
 					for _, attr := range t.Attr {
 						if attr.Name.Local == "val" {
-							switch attr.Value {
-							case "dash", "lgDash", "sysDash":
-								currentLine.lineStyle = BorderDash
-							case "dot", "sysDot":
-								currentLine.lineStyle = BorderDot
-							case "solid":
-								currentLine.lineStyle = BorderSolid
+							if style, ok := parseDashStyle(attr.Value); ok {
+								currentLine.lineStyle = style
 							}
 						}
 					}
 				}
+			case "ds":
+				if inLn && inCxnSp && currentLine != nil {
+					currentLine.lineStyle = BorderDash
+					currentLine.dashPattern = append(currentLine.dashPattern, parseDashStop(t.Attr)...)
+				}
+			case "round", "bevel", "miter":
+				if inLn && inCxnSp && currentLine != nil {
+					currentLine.lineJoin, currentLine.miterLimit = parseLineJoin(t)
+				}
 			case "txBody":
 				if inSp && !isPH {
 					inTxBody = true
@@ -3584,8 +4922,11 @@ func (r *PPTXReader) parseLayoutImages(data []byte, rels []xmlRelForRead, zr *zi
 			case "bodyPr":
 				if inTxBody && currentRichText != nil {
 					for _, attr := range t.Attr {
-						if attr.Name.Local == "anchor" {
+						switch attr.Name.Local {
+						case "anchor":
 							textAnchor = TextAnchorType(attr.Value)
+						case "anchorCtr":
+							currentRichText.anchorCtr = attr.Value == "1" || attr.Value == "true"
 						}
 					}
 				}
@@ -3625,6 +4966,14 @@ func (r *PPTXReader) parseLayoutImages(data []byte, rels []xmlRelForRead, zr *zi
 							lstStyleFont.Bold = attr.Value == "1"
 						case "i":
 							lstStyleFont.Italic = attr.Value == "1"
+						case "kern":
+							if v, err := strconv.Atoi(attr.Value); err == nil {
+								lstStyleFont.Kern = &v
+							}
+						case "spc":
+							if v, err := strconv.Atoi(attr.Value); err == nil {
+								lstStyleFont.Spacing = v
+							}
 						}
 					}
 				} else if inPPr {
@@ -3641,6 +4990,14 @@ func (r *PPTXReader) parseLayoutImages(data []byte, rels []xmlRelForRead, zr *zi
 							defFont.Bold = attr.Value == "1"
 						case "i":
 							defFont.Italic = attr.Value == "1"
+						case "kern":
+							if v, err := strconv.Atoi(attr.Value); err == nil {
+								defFont.Kern = &v
+							}
+						case "spc":
+							if v, err := strconv.Atoi(attr.Value); err == nil {
+								defFont.Spacing = v
+							}
 						}
 					}
 				}
@@ -3654,6 +5011,16 @@ func (r *PPTXReader) parseLayoutImages(data []byte, rels []xmlRelForRead, zr *zi
 				} else if inLn {
 					inLnSolidFill = true
 				}
+			case "noFill":
+				// <a:noFill/> inside rPr leaves the glyphs hollow; inside its
+				// ln it removes the outline.
+				if inRunProps && currentFont != nil {
+					if inRunLn {
+						currentFont.OutlineColor = nil
+					} else {
+						currentFont.NoFill = true
+					}
+				}
 			case "srgbClr":
 				inSrgbClr = true
 				lastColor = nil
@@ -3689,8 +5056,7 @@ func (r *PPTXReader) parseLayoutImages(data []byte, rels []xmlRelForRead, zr *zi
 				} else if inRunSolidFill && currentFont != nil {
 					for _, attr := range t.Attr {
 						if attr.Name.Local == "val" {
-							currentFont.Color = NewColor("FF" + attr.Value)
-							lastColor = &currentFont.Color
+							lastColor = setRunColor(NewColor("FF" + attr.Value))
 						}
 					}
 				}
@@ -3717,8 +5083,7 @@ func (r *PPTXReader) parseLayoutImages(data []byte, rels []xmlRelForRead, zr *zi
 					defFont.Color = c
 					lastColor = &defFont.Color
 				} else if inRunSolidFill && currentFont != nil {
-					currentFont.Color = c
-					lastColor = &currentFont.Color
+					lastColor = setRunColor(c)
 				}
 			case "schemeClr":
 				inSrgbClr = true
@@ -3731,7 +5096,7 @@ func (r *PPTXReader) parseLayoutImages(data []byte, rels []xmlRelForRead, zr *zi
 						}
 					}
 					if argb, ok := pres.themeColors[schemeName]; ok && argb != "" {
-						c := NewColor(argb)
+						c := schemeColor(schemeName, argb)
 						if inFontRef {
 							fontRefColor = &c
 							lastColor = fontRefColor
@@ -3745,8 +5110,7 @@ func (r *PPTXReader) parseLayoutImages(data []byte, rels []xmlRelForRead, zr *zi
 							defFont.Color = c
 							lastColor = &defFont.Color
 						} else if inRunSolidFill && currentFont != nil {
-							currentFont.Color = c
-							lastColor = &currentFont.Color
+							lastColor = setRunColor(c)
 						}
 					}
 				}
@@ -3775,8 +5139,7 @@ func (r *PPTXReader) parseLayoutImages(data []byte, rels []xmlRelForRead, zr *zi
 						defFont.Color = c
 						lastColor = &defFont.Color
 					} else if inRunSolidFill && currentFont != nil {
-						currentFont.Color = c
-						lastColor = &currentFont.Color
+						lastColor = setRunColor(c)
 					}
 				}
 			case "alpha":
@@ -3837,6 +5200,46 @@ func (r *PPTXReader) parseLayoutImages(data []byte, rels []xmlRelForRead, zr *zi
 						}
 					}
 				}
+			case "cs":
+				if inDefRPr && lstStyleFont != nil {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "typeface" && !strings.HasPrefix(attr.Value, "+") {
+							lstStyleFont.NameCS = attr.Value
+						}
+					}
+				} else if inPPrDefRPr && defFont != nil {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "typeface" && !strings.HasPrefix(attr.Value, "+") {
+							defFont.NameCS = attr.Value
+						}
+					}
+				} else if inRunProps && currentFont != nil {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "typeface" && !strings.HasPrefix(attr.Value, "+") {
+							currentFont.NameCS = attr.Value
+						}
+					}
+				}
+			case "sym":
+				if inDefRPr && lstStyleFont != nil {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "typeface" && !strings.HasPrefix(attr.Value, "+") {
+							lstStyleFont.NameSym = attr.Value
+						}
+					}
+				} else if inPPrDefRPr && defFont != nil {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "typeface" && !strings.HasPrefix(attr.Value, "+") {
+							defFont.NameSym = attr.Value
+						}
+					}
+				} else if inRunProps && currentFont != nil {
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "typeface" && !strings.HasPrefix(attr.Value, "+") {
+							currentFont.NameSym = attr.Value
+						}
+					}
+				}
 			case "p":
 				if inTxBody && currentRichText != nil {
 					inParagraph = true
@@ -3878,6 +5281,18 @@ func (r *PPTXReader) parseLayoutImages(data []byte, rels []xmlRelForRead, zr *zi
 						if lstStyleFont.NameEA != "" {
 							currentFont.NameEA = lstStyleFont.NameEA
 						}
+						if lstStyleFont.NameCS != "" {
+							currentFont.NameCS = lstStyleFont.NameCS
+						}
+						if lstStyleFont.NameSym != "" {
+							currentFont.NameSym = lstStyleFont.NameSym
+						}
+						if lstStyleFont.Kern != nil {
+							currentFont.Kern = lstStyleFont.Kern
+						}
+						if lstStyleFont.Spacing != 0 {
+							currentFont.Spacing = lstStyleFont.Spacing
+						}
 						if lstStyleFont.Color.ARGB != "FF000000" && lstStyleFont.Color.ARGB != "" {
 							currentFont.Color = lstStyleFont.Color
 						}
@@ -3899,6 +5314,18 @@ func (r *PPTXReader) parseLayoutImages(data []byte, rels []xmlRelForRead, zr *zi
 						if defFont.NameEA != "" {
 							currentFont.NameEA = defFont.NameEA
 						}
+						if defFont.NameCS != "" {
+							currentFont.NameCS = defFont.NameCS
+						}
+						if defFont.NameSym != "" {
+							currentFont.NameSym = defFont.NameSym
+						}
+						if defFont.Kern != nil {
+							currentFont.Kern = defFont.Kern
+						}
+						if defFont.Spacing != 0 {
+							currentFont.Spacing = defFont.Spacing
+						}
 						if defFont.Color.ARGB != "FF000000" && defFont.Color.ARGB != "" {
 							currentFont.Color = defFont.Color
 						}
@@ -3917,6 +5344,14 @@ func (r *PPTXReader) parseLayoutImages(data []byte, rels []xmlRelForRead, zr *zi
 							currentFont.Bold = attr.Value == "1"
 						case "i":
 							currentFont.Italic = attr.Value == "1"
+						case "kern":
+							if v, err := strconv.Atoi(attr.Value); err == nil {
+								currentFont.Kern = &v
+							}
+						case "spc":
+							if v, err := strconv.Atoi(attr.Value); err == nil {
+								currentFont.Spacing = v
+							}
 						}
 					}
 				}
@@ -3948,12 +5383,15 @@ func (r *PPTXReader) parseLayoutImages(data []byte, rels []xmlRelForRead, zr *zi
 				if inPic && embedID != "" {
 					for _, rel := range rels {
 						if rel.ID == embedID {
+							if rel.isExternal() {
+								break
+							}
 							imgPath := rel.Target
 							if !strings.HasPrefix(imgPath, "ppt/") {
 								dir := strings.TrimSuffix(layoutPath, "/"+lastPathComponent(layoutPath))
 								imgPath = resolveRelativePath(dir, imgPath)
 							}
-							imgData, err := readFileFromZip(zr, imgPath)
+							imgData, size, err := r.readMedia(zr, imgPath)
 							if err == nil {
 								ds := NewDrawingShape()
 								ds.offsetX = offX
@@ -3962,7 +5400,11 @@ func (r *PPTXReader) parseLayoutImages(data []byte, rels []xmlRelForRead, zr *zi
 								ds.height = extCY
 								ds.data = imgData
 								ds.mimeType = guessMimeType(imgPath)
-								ds.alpha = picAlpha
+								ds.mediaPart = imgPath
+								ds.mediaSize = size
+								if picAlpha >= 0 {
+									ds.SetAlphaValue(picAlpha)
+								}
 								ds.cropLeft = cropL
 								ds.cropTop = cropT
 								ds.cropRight = cropR
@@ -4064,6 +5506,7 @@ func (r *PPTXReader) parseLayoutImages(data []byte, rels []xmlRelForRead, zr *zi
 			case "ln":
 				inLn = false
 				inLnSolidFill = false
+				inRunLn = false
 			case "p":
 				inParagraph = false
 				currentParagraph = nil
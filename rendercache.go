@@ -0,0 +1,106 @@
+package gopresentation
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"sync"
+)
+
+// RenderCache stores rendered slide images. SlideToImage consults the cache
+// set in RenderOptions.Cache before rendering and stores what it renders.
+// Keys combine Presentation.SlideHash with the options that affect the
+// pixels, so a key never maps to a different image. Implementations must be
+// safe for concurrent use. Cached images are shared and must not be
+// modified by callers.
+type RenderCache interface {
+	Get(key string) (image.Image, bool)
+	Put(key string, img image.Image)
+}
+
+// MemoryRenderCache is a RenderCache that keeps the most recently used
+// images in memory.
+type MemoryRenderCache struct {
+	mu      sync.Mutex
+	max     int
+	entries map[string]*list.Element
+	order   *list.List // front is most recently used
+}
+
+type memoryCacheEntry struct {
+	key string
+	img image.Image
+}
+
+// NewMemoryRenderCache returns a cache holding at most maxEntries images.
+// maxEntries <= 0 means no limit.
+func NewMemoryRenderCache(maxEntries int) *MemoryRenderCache {
+	return &MemoryRenderCache{
+		max:     maxEntries,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// Get returns the image stored under key.
+func (c *MemoryRenderCache) Get(key string) (image.Image, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*memoryCacheEntry).img, true
+}
+
+// Put stores img under key, evicting the least recently used image when
+// the cache is full.
+func (c *MemoryRenderCache) Put(key string, img image.Image) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*memoryCacheEntry).img = img
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&memoryCacheEntry{key: key, img: img})
+	if c.max > 0 && c.order.Len() > c.max {
+		last := c.order.Back()
+		c.order.Remove(last)
+		delete(c.entries, last.Value.(*memoryCacheEntry).key)
+	}
+}
+
+// Len returns the number of cached images.
+func (c *MemoryRenderCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// renderCacheKey returns the cache key for rendering a slide with opts. It
+// covers the options that change the pixels, but not the output encoding,
+// the font cache or the warning callback.
+func (p *Presentation) renderCacheKey(slideIndex int, opts *RenderOptions) (string, error) {
+	slideHash, err := p.SlideHash(slideIndex)
+	if err != nil {
+		return "", err
+	}
+	var guides []Guide
+	if p.presentationProperties != nil {
+		guides = p.presentationProperties.guides
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%d|%g|%v|%q|%g|%d|%d|%t|%t|%t|%t|%d|%d|%d|%g|%g|%t|%d|%d|%t|%t|%t|%v|%d|%g|%t|%v",
+		slideHash, opts.Width, opts.DPI, opts.BackgroundColor, opts.FontDirs,
+		opts.OverlayOpacityScale, opts.Bleed, opts.Margin, opts.CropMarks,
+		opts.TextOnly, opts.ShowPlaceholderPrompts, opts.ShowUnsupportedPlaceholders,
+		opts.ColorMode, opts.TextHinting, opts.TextLineSnap, opts.TextGamma,
+		opts.StemDarkening, opts.DebugOverlay, opts.GradientInterpolation,
+		opts.Supersample, opts.VideoRange, opts.ShowGuides, opts.ShowSafeAreas,
+		guides, opts.ImageFilter, opts.ImageScaleSnap, opts.SharpBitonalImages, opts.ThemeColorOverrides)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
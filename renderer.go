@@ -2,6 +2,7 @@ package gopresentation
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"image"
 	"image/color"
@@ -9,12 +10,15 @@ import (
 	_ "image/gif"
 	"image/jpeg"
 	"image/png"
+	"io"
 	"math"
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"golang.org/x/image/font"
 	"golang.org/x/image/font/basicfont"
@@ -29,17 +33,49 @@ type ImageFormat int
 const (
 	ImageFormatPNG ImageFormat = iota
 	ImageFormatJPEG
+	// ImageFormatWebP writes WebP files, lossless or lossy VP8 at
+	// WebPQuality.
+	ImageFormatWebP
+)
+
+// ColorMode selects the pixel format of rendered slide images.
+type ColorMode int
+
+const (
+	// ColorModeRGBA produces an *image.RGBA.
+	ColorModeRGBA ColorMode = iota
+	// ColorModeGray produces an 8-bit *image.Gray.
+	ColorModeGray
+	// ColorModeBilevel produces an *image.Gray holding only black and white
+	// pixels, Floyd-Steinberg dithered, for e-ink and fax-like output.
+	ColorModeBilevel
 )
 
 // RenderOptions configures slide-to-image rendering.
 type RenderOptions struct {
 	// Width is the output image width in pixels. Height is calculated from slide aspect ratio.
+	// Text that shrinks to fit its shape gets the same font scale at every width.
 	// Default: 960
 	Width int
-	// Format is the output image format (PNG or JPEG).
+	// Format is the output image format (PNG, JPEG or WebP).
 	Format ImageFormat
 	// JPEGQuality is the JPEG quality (1-100). Default: 90.
 	JPEGQuality int
+	// JPEGSubsampling selects the chroma subsampling of JPEG output.
+	// Default: 4:2:0. ApplyJPEGPreset sets it together with the quality.
+	JPEGSubsampling JPEGSubsampling
+	// PNGPaletteSize, when between 2 and 256, quantizes PNG output to a
+	// palette of at most that many colors for smaller files, e.g. for
+	// thumbnails. 0 writes full-color PNGs.
+	PNGPaletteSize int
+	// WebPLossless writes WebP output without any loss.
+	WebPLossless bool
+	// WebPQuality (1-100) sets the quality of lossy (VP8) WebP output when
+	// WebPLossless is not set, as JPEGQuality does for JPEG. Lossy output
+	// pays off for photos and gradients; slides of flat fills and text are
+	// often as small lossless and keep sharper edges. 100 is lossless.
+	// Default: 90.
+	WebPQuality int
 	// BackgroundColor overrides the slide background. Nil means use slide background or white.
 	BackgroundColor *color.RGBA
 	// DPI is the rendering DPI for font sizing. Default: 96.
@@ -50,11 +86,154 @@ type RenderOptions struct {
 	// FontCache allows sharing a pre-configured FontCache across multiple renders.
 	// If nil, a new FontCache is created using FontDirs.
 	FontCache *FontCache
+	// Parallelism is the number of slides SlidesToImages, the SaveSlides
+	// functions, HandoutToImages and WritePDF render at once, each on its
+	// own goroutine with its own font faces over the fonts of FontCache.
+	// Values below 2 render one slide at a time. Memory use grows with the
+	// slides in flight.
+	Parallelism int
 	// OverlayOpacityScale scales the opacity of semi-transparent shape fills.
 	// Value between 0.0 and 1.0. Default 0 means use 1.0 (no change).
 	// Set to e.g. 0.5 to halve the opacity of overlays, making dark backgrounds brighter.
 	OverlayOpacityScale float64
-}
+	// Bleed extends the rendered slide content past each trim edge by this
+	// many EMU, for print output. Width still sets the trimmed slide width.
+	Bleed int64
+	// Margin adds a white border of this many EMU around the bleed area.
+	Margin int64
+	// CropMarks draws crop marks at the trim edges inside the margin.
+	// It has no effect without a margin.
+	CropMarks bool
+	// TextOnly renders black text on a white background and omits images,
+	// lines, charts, fills, borders and shadows. The high-contrast output is
+	// intended for OCR and for diffing rendered text against extracted text.
+	TextOnly bool
+	// ShowPlaceholderPrompts draws the prompt text ("Click to add title", or
+	// the layout's custom prompt) in empty placeholders, as PowerPoint's
+	// editor does, for template previews. By default empty placeholders show
+	// no text, as in a slide show.
+	ShowPlaceholderPrompts bool
+	// ShowUnsupportedPlaceholders draws a neutral box labelled with the
+	// object type and alt text where a slide holds content that cannot be
+	// rendered, such as SmartArt, 3D models, OLE objects without a preview
+	// picture or ActiveX controls. By default such objects are left blank.
+	ShowUnsupportedPlaceholders bool
+	// ColorMode selects the pixel format of the returned image. Gray and
+	// bilevel output is converted from the RGBA raster before SlideToImage
+	// returns, so only a quarter of the memory is retained.
+	ColorMode ColorMode
+	// TextHinting selects how glyph metrics are fitted to the pixel grid.
+	// Default: TextHintingFull.
+	TextHinting TextHinting
+	// TextLineSnap selects how lines of text are placed on the pixel grid.
+	// Default: TextLineSnapPixel.
+	TextLineSnap TextLineSnap
+	// TextGamma darkens anti-aliased glyph edges when above 1, approximating
+	// the heavier look of ClearType text; values around 1.4 to 1.8 work well
+	// for small light-on-dark text. 0 or 1 leaves coverage unchanged.
+	TextGamma float64
+	// StemDarkening widens glyph stems by up to one pixel (0 to 1) so that
+	// thin strokes keep their weight at small sizes. 0 disables it.
+	StemDarkening float64
+	// GradientInterpolation selects the color space in which gradient
+	// fills blend between their stops. Default: GradientSpaceSRGB.
+	GradientInterpolation GradientSpace
+	// Supersample, when above 1, draws the slide at that many times the
+	// output size and scales it down, smoothing the edges of shapes and
+	// lines that are drawn without anti-aliasing. Memory use and render
+	// time grow with its square.
+	Supersample int
+	// VideoRange maps colors into the limited 16-235 range of broadcast
+	// video, so that video pipelines expecting it do not clip blacks and
+	// whites.
+	VideoRange bool
+	// ImageFilter selects how pictures are resampled to their size on the
+	// slide. Default: ImageFilterAuto.
+	ImageFilter ImageFilter
+	// ImageScaleSnap, when above 0, draws a picture whose scale on the
+	// slide is within this fraction of a whole number, or of one over a
+	// whole number, at exactly that scale, centred in its frame. Enlarged
+	// pictures then repeat whole pixels, keeping screenshots and QR codes
+	// crisp at the cost of a size off by up to the fraction; 0.05 suits
+	// pictures placed at a slightly different DPI than they were made for.
+	ImageScaleSnap float64
+	// SharpBitonalImages renders pictures that are high-contrast two-tone,
+	// such as QR codes and barcodes, as if marked with
+	// DrawingShape.SetPixelated, so that resampling does not blur them
+	// into being unscannable.
+	SharpBitonalImages bool
+	// ThemeColorOverrides replaces theme colors, keyed by scheme name as in
+	// Presentation.ThemeColors (e.g. "accent1"), for previews of other
+	// color schemes. Colors that refer to a replaced theme color take the
+	// replacement, with their own transparency and luminance modifiers;
+	// explicit colors are drawn as they are.
+	ThemeColorOverrides map[string]Color
+	// DebugOverlay draws each shape's bounding box with its name, type and
+	// placeholder type, plus the line boxes and baselines of laid-out text,
+	// on top of the slide, for diagnosing layout differences.
+	DebugOverlay bool
+	// ShowGuides draws the presentation's drawing guides (see
+	// PresentationProperties.GetGuides) as dashed lines, or the guides
+	// through the slide centre that PowerPoint shows by default when it
+	// has none, to check template layouts against them.
+	ShowGuides bool
+	// ShowSafeAreas outlines the action-safe (90%) and title-safe (80%)
+	// areas of the slide, for slides meant for video output.
+	ShowSafeAreas bool
+	// ProgressStep is the number of shapes drawn between the calls of a
+	// SlideToImageProgressive callback. Values below 1 mean every shape.
+	ProgressStep int
+	// Cache, if set, is consulted before a slide is rendered and receives
+	// the rendered image, so that unchanged slides are not rendered again.
+	// Cache hits return the stored image without calling OnWarning.
+	Cache RenderCache
+	// OnWarning, if set, is called for problems that did not stop the
+	// render, such as a malformed shape that could not be drawn and was
+	// replaced by a placeholder box. It may be called from several
+	// goroutines when slides are rendered concurrently.
+	OnWarning func(RenderWarning)
+}
+
+// RenderWarning describes a problem found while rendering a slide.
+type RenderWarning struct {
+	SlideIndex int    // 0-based slide index, or -1 outside a slide
+	ShapeName  string // name of the shape concerned, if any
+	Message    string
+}
+
+func (w RenderWarning) String() string {
+	if w.ShapeName != "" {
+		return fmt.Sprintf("slide %d, shape %q: %s", w.SlideIndex+1, w.ShapeName, w.Message)
+	}
+	return fmt.Sprintf("slide %d: %s", w.SlideIndex+1, w.Message)
+}
+
+// TextHinting selects how glyph metrics are fitted to the pixel grid.
+type TextHinting int
+
+const (
+	// TextHintingFull rounds glyph advances and line metrics to whole
+	// pixels, giving crisp but slightly uneven letter spacing.
+	TextHintingFull TextHinting = iota
+	// TextHintingVertical keeps fractional horizontal advances, as
+	// DirectWrite does, while baselines stay on whole pixels.
+	TextHintingVertical
+)
+
+// TextLineSnap selects how lines of text are placed on the pixel grid.
+type TextLineSnap int
+
+const (
+	// TextLineSnapPixel rounds line heights up to whole pixels, so that
+	// every baseline sits on a pixel row and lines of one size are evenly
+	// spaced, for crisp UI-style output.
+	TextLineSnapPixel TextLineSnap = iota
+	// TextLineSnapNone advances lines by their exact fractional heights,
+	// so that tall text blocks keep PowerPoint's overall height. Baselines
+	// are rounded to whole pixels, so the gaps between lines can differ by
+	// a pixel.
+	TextLineSnapNone
+)
 
 // DefaultRenderOptions returns default rendering options.
 func DefaultRenderOptions() *RenderOptions {
@@ -62,12 +241,68 @@ func DefaultRenderOptions() *RenderOptions {
 		Width:       960,
 		Format:      ImageFormatPNG,
 		JPEGQuality: 90,
+		WebPQuality: 90,
 		DPI:         96,
 	}
 }
 
+// RenderPreset is a set of render options for a common output target.
+type RenderPreset int
+
+const (
+	// RenderPresetPreview720 renders 1280 pixel wide JPEG previews.
+	RenderPresetPreview720 RenderPreset = iota
+	// RenderPresetFullHD renders 1920 pixel wide, 2x supersampled JPEG
+	// frames in video range, for HD video.
+	RenderPresetFullHD
+	// RenderPresetUHD4K renders 3840 pixel wide JPEG frames in video range,
+	// for 4K video.
+	RenderPresetUHD4K
+	// RenderPresetPrintA4 renders full-range PNG pages 3508 pixels wide,
+	// the long side of A4 at 300 dpi, and sets DPI to 300.
+	RenderPresetPrintA4
+)
+
+// ApplyRenderPreset sets the width, supersampling, output format and color
+// range of the options for preset, and the DPI of the print preset. Other options are left as they are.
+func (o *RenderOptions) ApplyRenderPreset(preset RenderPreset) {
+	switch preset {
+	case RenderPresetPreview720:
+		o.Width, o.Supersample, o.VideoRange = 1280, 0, false
+		o.ApplyJPEGPreset(JPEGPresetPhoto)
+	case RenderPresetFullHD:
+		o.Width, o.Supersample, o.VideoRange = 1920, 2, true
+		o.ApplyJPEGPreset(JPEGPresetText)
+	case RenderPresetUHD4K:
+		o.Width, o.Supersample, o.VideoRange = 3840, 0, true
+		o.ApplyJPEGPreset(JPEGPresetText)
+	case RenderPresetPrintA4:
+		o.Width, o.Supersample, o.VideoRange = 3508, 0, false
+		o.Format, o.PNGPaletteSize = ImageFormatPNG, 0
+		o.DPI = 300
+	}
+}
+
 // SlideToImage renders a single slide to an image.
 func (p *Presentation) SlideToImage(slideIndex int, opts *RenderOptions) (image.Image, error) {
+	return p.slideToImage(slideIndex, opts, nil)
+}
+
+// ProgressFunc receives the partly drawn slide of a progressive render after
+// shapesDone of the slide's total top-level shapes have been drawn. The
+// image is only valid during the call and must not be modified.
+type ProgressFunc func(partial image.Image, shapesDone, total int)
+
+// SlideToImageProgressive renders a slide like SlideToImage, calling
+// progress every opts.ProgressStep shapes so that an interactive viewer can
+// show the slide building up during a long render. The last call passes the
+// finished image with shapesDone equal to total. Progress is called on the
+// rendering goroutine.
+func (p *Presentation) SlideToImageProgressive(slideIndex int, opts *RenderOptions, progress ProgressFunc) (image.Image, error) {
+	return p.slideToImage(slideIndex, opts, progress)
+}
+
+func (p *Presentation) slideToImage(slideIndex int, opts *RenderOptions, progress ProgressFunc) (image.Image, error) {
 	if slideIndex < 0 || slideIndex >= len(p.slides) {
 		return nil, fmt.Errorf("slide index %d out of range (0-%d)", slideIndex, len(p.slides)-1)
 	}
@@ -78,66 +313,526 @@ func (p *Presentation) SlideToImage(slideIndex int, opts *RenderOptions) (image.
 		opts.Width = 960
 	}
 
+	var cacheKey string
+	if opts.Cache != nil {
+		key, err := p.renderCacheKey(slideIndex, opts)
+		if err != nil {
+			return nil, err
+		}
+		if img, ok := opts.Cache.Get(key); ok {
+			if progress != nil {
+				progress(img, len(p.slides[slideIndex].shapes), len(p.slides[slideIndex].shapes))
+			}
+			return img, nil
+		}
+		cacheKey = key
+	}
+
 	slide := p.slides[slideIndex]
 	layout := p.layout
 
 	slideW := float64(layout.CX)
 	slideH := float64(layout.CY)
-	imgW := opts.Width
-	imgH := int(float64(imgW) * slideH / slideW)
+	// With supersampling the slide is drawn ss times larger, keeping every
+	// size a multiple of ss so that the image scales down evenly.
+	ss := maxInt(opts.Supersample, 1)
+	imgW := opts.Width * ss
+	imgH := int(float64(opts.Width)*slideH/slideW) * ss
 
 	scaleX := float64(imgW) / slideW
 	scaleY := float64(imgH) / slideH
 
-	img := image.NewRGBA(image.Rect(0, 0, imgW, imgH))
+	bleedPx := ss * maxInt(int(math.Round(float64(opts.Bleed)*scaleX/float64(ss))), 0)
+	marginPx := ss * maxInt(int(math.Round(float64(opts.Margin)*scaleX/float64(ss))), 0)
+	off := bleedPx + marginPx
+	img := image.NewRGBA(image.Rect(0, 0, imgW+2*off, imgH+2*off))
+	bgRect := image.Rect(marginPx, marginPx, marginPx+imgW+2*bleedPx, marginPx+imgH+2*bleedPx)
 
-	fc := opts.FontCache
-	if fc == nil {
-		fc = NewFontCache(opts.FontDirs...)
-	}
-	dpi := opts.DPI
-	if dpi <= 0 {
-		dpi = 96
-	}
-
-	r := &renderer{
-		img:                 img,
-		scaleX:              scaleX,
-		scaleY:              scaleY,
-		fontCache:           fc,
-		dpi:                 dpi,
-		overlayOpacityScale: opts.OverlayOpacityScale,
+	r := p.newRenderer(slideIndex, opts, img, scaleX, scaleY)
+	if opts.DebugOverlay {
+		r.debug = &debugOverlay{}
 	}
 
 	// Fill background
-	bgColor := color.RGBA{R: 255, G: 255, B: 255, A: 255}
-	drawn := false
-	if opts.BackgroundColor != nil {
-		bgColor = *opts.BackgroundColor
-	} else if slide.background != nil {
-		switch slide.background.Type {
-		case FillSolid:
-			bgColor = argbToRGBA(slide.background.Color)
-		case FillGradientLinear:
-			r.fillGradientLinear(img.Bounds(), slide.background)
-			drawn = true
-		case FillGradientPath:
-			r.fillGradientPath(img.Bounds(), slide.background)
-			drawn = true
-		}
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	if marginPx > 0 {
+		r.fillRectFast(img.Bounds(), white)
 	}
-	if !drawn {
-		r.fillRectFast(img.Bounds(), bgColor)
+	r.fillSlideBackground(slide, opts, bgRect)
+
+	// Shift the slide so that its trim box starts inside the bleed and margin.
+	var dx, dy int64
+	if off > 0 {
+		dx = int64(math.Round(float64(off) / scaleX))
+		dy = int64(math.Round(float64(off) / scaleY))
 	}
 
 	// Render shapes in their original XML order (z-order).
 	// Shapes that appear earlier in the spTree are behind shapes that appear later,
 	// matching PowerPoint's rendering behavior.
-	for _, shape := range slide.shapes {
+	step := maxInt(opts.ProgressStep, 1)
+	for i, shape := range slide.shapes {
+		if off > 0 {
+			shape = translatedShape(shape, dx, dy)
+		}
 		r.renderShape(shape)
+		if progress != nil && (i+1)%step == 0 && i+1 < len(slide.shapes) {
+			partial := image.Image(img)
+			if ss > 1 {
+				partial = downsampleRGBA(img, ss)
+			}
+			progress(partial, i+1, len(slide.shapes))
+		}
+	}
+	if r.debug != nil {
+		r.drawDebugOverlay()
+	}
+
+	if marginPx > 0 {
+		r.clearOutside(bgRect, white)
+	}
+	if ss > 1 {
+		img = downsampleRGBA(img, ss)
+		r = r.withImage(img)
+		imgW, imgH, off, bleedPx, marginPx = imgW/ss, imgH/ss, off/ss, bleedPx/ss, marginPx/ss
+	}
+	trim := image.Rect(off, off, off+imgW, off+imgH)
+	if marginPx > 0 && opts.CropMarks {
+		r.drawCropMarks(trim, bleedPx, marginPx)
+	}
+	// Overlays are drawn after supersampling so that their lines and labels
+	// stay crisp.
+	if opts.ShowSafeAreas {
+		r.drawSafeAreas(trim)
+	}
+	if opts.ShowGuides {
+		var guides []Guide
+		if p.presentationProperties != nil {
+			guides = p.presentationProperties.guides
+		}
+		r.drawGuides(trim, guides, slideW, slideH)
+	}
+	if opts.VideoRange {
+		toVideoRange(img)
+	}
+
+	out := convertColorMode(img, opts.ColorMode)
+	if opts.Cache != nil {
+		opts.Cache.Put(cacheKey, out)
+	}
+	if progress != nil {
+		progress(out, len(slide.shapes), len(slide.shapes))
+	}
+	return out, nil
+}
+
+// debugOverlay collects what RenderOptions.DebugOverlay draws once all
+// shapes have been rendered.
+type debugOverlay struct {
+	shapes []Shape     // shapes in render order, with group children in slide coordinates
+	lines  []debugLine // laid-out text lines
+}
+
+type debugLine struct {
+	box      image.Rectangle
+	baseline int
+}
+
+// drawDebugOverlay draws the collected line boxes, baselines and shape boxes
+// with labels in colors that stand out against typical slide content.
+func (r *renderer) drawDebugOverlay() {
+	lineColor := color.RGBA{R: 0, G: 200, B: 255, A: 160}
+	baseColor := color.RGBA{R: 255, G: 40, B: 40, A: 255}
+	for _, l := range r.debug.lines {
+		r.drawRect(l.box, lineColor, 1)
+		r.drawLine(l.box.Min.X, l.baseline, l.box.Max.X, l.baseline, baseColor)
+	}
+	shapeColor := color.RGBA{R: 255, G: 0, B: 255, A: 255}
+	phColor := color.RGBA{R: 255, G: 140, B: 0, A: 255}
+	face := basicfont.Face7x13
+	for _, shape := range r.debug.shapes {
+		b := shape.base()
+		box := image.Rect(r.emuToPixelX(b.offsetX), r.emuToPixelY(b.offsetY),
+			r.emuToPixelX(b.offsetX+b.width), r.emuToPixelY(b.offsetY+b.height))
+		c := shapeColor
+		label := debugShapeKind(shape)
+		if ph, ok := shape.(*PlaceholderShape); ok {
+			c = phColor
+			label = fmt.Sprintf("ph:%s idx=%d", ph.phType, ph.phIdx)
+		}
+		if b.name != "" {
+			label = b.name + " [" + label + "]"
+		}
+		if b.rotation != 0 {
+			label += fmt.Sprintf(" rot=%d", b.rotation)
+		}
+		r.drawRect(box, c, 1)
+		tw := font.MeasureString(face, label).Ceil()
+		r.fillRectFast(image.Rect(box.Min.X, box.Min.Y, box.Min.X+tw+4, box.Min.Y+14), c)
+		d := &font.Drawer{
+			Dst:  r.img,
+			Src:  image.NewUniform(color.White),
+			Face: face,
+			Dot:  fixed.P(box.Min.X+2, box.Min.Y+11),
+		}
+		d.DrawString(label)
+	}
+}
+
+// drawSafeAreas outlines the action-safe and title-safe areas of the trim
+// box, labelled in their top-left corners.
+func (r *renderer) drawSafeAreas(trim image.Rectangle) {
+	face := basicfont.Face7x13
+	areas := []struct {
+		label string
+		inset int // percent of the slide size on each side
+		c     color.RGBA
+	}{
+		{"action safe", 5, color.RGBA{R: 0, G: 170, B: 255, A: 255}},
+		{"title safe", 10, color.RGBA{R: 255, G: 170, B: 0, A: 255}},
+	}
+	for _, a := range areas {
+		dx := trim.Dx() * a.inset / 100
+		dy := trim.Dy() * a.inset / 100
+		box := image.Rect(trim.Min.X+dx, trim.Min.Y+dy, trim.Max.X-dx, trim.Max.Y-dy)
+		r.drawRect(box, a.c, 1)
+		d := &font.Drawer{
+			Dst:  r.img,
+			Src:  image.NewUniform(a.c),
+			Face: face,
+			Dot:  fixed.P(box.Min.X+3, box.Min.Y+12),
+		}
+		d.DrawString(a.label)
+	}
+}
+
+// drawGuides draws guides across the trim box of a slide of slideW by
+// slideH EMU as dashed lines, or the centre guides when there are none.
+func (r *renderer) drawGuides(trim image.Rectangle, guides []Guide, slideW, slideH float64) {
+	if len(guides) == 0 {
+		guides = []Guide{
+			{Vertical: true, Position: int64(slideW / 2)},
+			{Position: int64(slideH / 2)},
+		}
+	}
+	c := color.RGBA{R: 128, G: 128, B: 128, A: 200}
+	dashes := []float64{6, 4}
+	for _, g := range guides {
+		if g.Vertical {
+			x := trim.Min.X + int(math.Round(float64(g.Position)*float64(trim.Dx())/slideW))
+			r.drawDashedLineAA(x, trim.Min.Y, x, trim.Max.Y-1, c, 1, dashes)
+		} else {
+			y := trim.Min.Y + int(math.Round(float64(g.Position)*float64(trim.Dy())/slideH))
+			r.drawDashedLineAA(trim.Min.X, y, trim.Max.X-1, y, c, 1, dashes)
+		}
+	}
+}
+
+// debugShapeKind names the concrete type of shape for the debug overlay.
+func debugShapeKind(shape Shape) string {
+	switch s := shape.(type) {
+	case *RichTextShape:
+		return "text"
+	case *AutoShape:
+		return "auto:" + string(s.shapeType)
+	case *DrawingShape:
+		return "picture"
+	case *LineShape:
+		return "line"
+	case *TableShape:
+		return "table"
+	case *ChartShape:
+		return "chart"
+	case *GroupShape:
+		return "group"
+	case *UnsupportedShape:
+		return "unsupported"
+	case *InkShape:
+		return "ink"
+	}
+	return "shape"
+}
+
+// convertColorMode converts a rendered RGBA image to the pixel format
+// selected by mode.
+func convertColorMode(img *image.RGBA, mode ColorMode) image.Image {
+	switch mode {
+	case ColorModeGray:
+		return toGray(img)
+	case ColorModeBilevel:
+		g := toGray(img)
+		ditherBilevel(g)
+		return g
+	}
+	return img
+}
+
+// downsampleRGBA scales img down by an integer factor, averaging each
+// factor x factor block of pixels into one.
+func downsampleRGBA(img *image.RGBA, factor int) *image.RGBA {
+	b := img.Bounds()
+	out := image.NewRGBA(image.Rect(0, 0, b.Dx()/factor, b.Dy()/factor))
+	n := uint32(factor * factor)
+	w, h := out.Rect.Dx(), out.Rect.Dy()
+	for y := 0; y < h; y++ {
+		dst := out.Pix[y*out.Stride : y*out.Stride+w*4]
+		for x := 0; x < w; x++ {
+			var sum [4]uint32
+			for sy := y * factor; sy < (y+1)*factor; sy++ {
+				src := img.Pix[sy*img.Stride+x*factor*4 : sy*img.Stride+(x+1)*factor*4]
+				for i, v := range src {
+					sum[i&3] += uint32(v)
+				}
+			}
+			for i := range sum {
+				dst[x*4+i] = uint8((sum[i] + n/2) / n)
+			}
+		}
+	}
+	return out
+}
+
+// toVideoRange compresses the colors of img in place from full range into
+// the 16-235 range of broadcast video.
+func toVideoRange(img *image.RGBA) {
+	var lut [256]uint8
+	for i := range lut {
+		lut[i] = uint8(16 + (i*219+127)/255)
+	}
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	for y := 0; y < h; y++ {
+		row := img.Pix[y*img.Stride : y*img.Stride+w*4]
+		for x := 0; x < len(row); x += 4 {
+			row[x], row[x+1], row[x+2] = lut[row[x]], lut[row[x+1]], lut[row[x+2]]
+		}
+	}
+}
+
+// toGray converts img to luma, using the same weights as color.GrayModel.
+func toGray(img *image.RGBA) *image.Gray {
+	b := img.Bounds()
+	g := image.NewGray(b)
+	w, h := b.Dx(), b.Dy()
+	for y := 0; y < h; y++ {
+		src := img.Pix[y*img.Stride : y*img.Stride+w*4]
+		dst := g.Pix[y*g.Stride : y*g.Stride+w]
+		for x := range dst {
+			r, gg, bb := uint32(src[x*4]), uint32(src[x*4+1]), uint32(src[x*4+2])
+			dst[x] = uint8((19595*r + 38470*gg + 7471*bb + 1<<15) >> 16)
+		}
+	}
+	return g
+}
+
+// ditherBilevel reduces g to black and white in place with Floyd-Steinberg
+// error diffusion.
+func ditherBilevel(g *image.Gray) {
+	w, h := g.Bounds().Dx(), g.Bounds().Dy()
+	cur := make([]int32, w+2)
+	next := make([]int32, w+2)
+	for y := 0; y < h; y++ {
+		row := g.Pix[y*g.Stride : y*g.Stride+w]
+		for x := range row {
+			v := int32(row[x]) + cur[x+1]/16
+			out := int32(0)
+			if v >= 128 {
+				out = 255
+			}
+			row[x] = uint8(out)
+			e := v - out
+			cur[x+2] += e * 7
+			next[x] += e * 3
+			next[x+1] += e * 5
+			next[x+2] += e
+		}
+		cur, next = next, cur
+		for i := range next {
+			next[i] = 0
+		}
+	}
+}
+
+// clearOutside paints everything outside rect with c, clipping content that
+// spilled past the bleed into the margin.
+func (r *renderer) clearOutside(rect image.Rectangle, c color.RGBA) {
+	b := r.img.Bounds()
+	r.fillRectFast(image.Rect(b.Min.X, b.Min.Y, b.Max.X, rect.Min.Y), c)
+	r.fillRectFast(image.Rect(b.Min.X, rect.Max.Y, b.Max.X, b.Max.Y), c)
+	r.fillRectFast(image.Rect(b.Min.X, rect.Min.Y, rect.Min.X, rect.Max.Y), c)
+	r.fillRectFast(image.Rect(rect.Max.X, rect.Min.Y, b.Max.X, rect.Max.Y), c)
+}
+
+// drawCropMarks draws the marks that show where to cut along the trim box.
+// The marks sit in the margin, starting just outside the bleed so they are
+// never printed on the finished piece.
+func (r *renderer) drawCropMarks(trim image.Rectangle, bleedPx, marginPx int) {
+	black := color.RGBA{A: 255}
+	gap := bleedPx + maxInt(marginPx/8, 2)
+	length := marginPx + bleedPx - gap
+	if length <= 0 {
+		return
+	}
+	for _, x := range []int{trim.Min.X, trim.Max.X - 1} {
+		r.drawLine(x, trim.Min.Y-gap-length, x, trim.Min.Y-gap, black)
+		r.drawLine(x, trim.Max.Y-1+gap, x, trim.Max.Y-1+gap+length, black)
+	}
+	for _, y := range []int{trim.Min.Y, trim.Max.Y - 1} {
+		r.drawLine(trim.Min.X-gap-length, y, trim.Min.X-gap, y, black)
+		r.drawLine(trim.Max.X-1+gap, y, trim.Max.X-1+gap+length, y, black)
+	}
+}
+
+// RenderShape renders a single shape to a tightly cropped image with a
+// transparent background, e.g. to export a logo or chart as a standalone asset.
+// Scale 1.0 renders at 96 DPI (one pixel per 9525 EMU). The shape is drawn
+// with default options and no theme; use Presentation.RenderShape for a
+// shape taken from a deck.
+func RenderShape(shape Shape, scale float64) (image.Image, error) {
+	return New().RenderShape(0, shape, scale, nil)
+}
+
+// RenderShape renders a shape of slide slideIndex to a tightly cropped image
+// with a transparent background, like the package-level RenderShape, but
+// with the presentation's theme and the slide's background, which shapes
+// filled with the background show. opts supplies the fonts and the other
+// drawing settings; its size, margin and overlay settings are ignored. A nil
+// opts uses DefaultRenderOptions.
+func (p *Presentation) RenderShape(slideIndex int, shape Shape, scale float64, opts *RenderOptions) (image.Image, error) {
+	if slideIndex < 0 || slideIndex >= len(p.slides) {
+		return nil, fmt.Errorf("slide index %d out of range (0-%d)", slideIndex, len(p.slides)-1)
+	}
+	if shape == nil {
+		return nil, errors.New("shape is nil")
+	}
+	if scale <= 0 {
+		return nil, fmt.Errorf("invalid scale %g", scale)
+	}
+	bs := shape.base()
+	if bs.width <= 0 && bs.height <= 0 {
+		return nil, errors.New("shape has no extent")
+	}
+	if opts == nil {
+		opts = DefaultRenderOptions()
+	}
+
+	pxPerEMU := scale / 9525.0
+	w := int(math.Ceil(float64(bs.width) * pxPerEMU))
+	h := int(math.Ceil(float64(bs.height) * pxPerEMU))
+	// Leave room for rotation, thick strokes, shadows and overflowing text.
+	margin := int(math.Ceil(math.Hypot(float64(w), float64(h))/2)) + int(math.Ceil(48*scale))
+	img := image.NewRGBA(image.Rect(0, 0, w+2*margin, h+2*margin))
+	r := p.newRenderer(slideIndex, opts, img, pxPerEMU, pxPerEMU)
+
+	// Move the shape so that its box starts at the canvas margin.
+	marginEMU := int64(float64(margin) / pxPerEMU)
+	dx, dy := marginEMU-bs.offsetX, marginEMU-bs.offsetY
+
+	// Lay the slide background out where the slide falls on the canvas, for
+	// shapes filled with it, and start from a transparent canvas again.
+	x, y := r.emuToPixelX(dx), r.emuToPixelY(dy)
+	r.fillSlideBackground(p.slides[slideIndex], opts, image.Rect(x, y, x+r.emuToPixelX(p.layout.CX), y+r.emuToPixelY(p.layout.CY)))
+	clear(img.Pix)
+
+	r.renderShape(translatedShape(shape, dx, dy))
+
+	crop := opaqueBounds(img)
+	if crop.Empty() {
+		return nil, errors.New("shape rendered no visible pixels")
+	}
+	out := image.NewRGBA(image.Rect(0, 0, crop.Dx(), crop.Dy()))
+	draw.Draw(out, out.Bounds(), img, crop.Min, draw.Src)
+	return out, nil
+}
+
+// withGeometry returns a shallow copy of shape placed at (x, y) with size
+// (w, h) EMU. The renderer positions shapes through such copies, never by
+// mutating the caller's shapes, so a presentation can be rendered from
+// several goroutines at once.
+func withGeometry(shape Shape, x, y, w, h int64) Shape {
+	var c Shape
+	switch s := shape.(type) {
+	case *RichTextShape:
+		cp := *s
+		c = &cp
+	case *PlaceholderShape:
+		cp := *s
+		c = &cp
+	case *DrawingShape:
+		cp := *s
+		c = &cp
+	case *AutoShape:
+		cp := *s
+		c = &cp
+	case *LineShape:
+		cp := *s
+		c = &cp
+	case *TableShape:
+		cp := *s
+		c = &cp
+	case *ChartShape:
+		cp := *s
+		c = &cp
+	case *GroupShape:
+		cp := *s
+		c = &cp
+	case *InkShape:
+		cp := *s
+		c = &cp
+	case *UnsupportedShape:
+		cp := *s
+		c = &cp
+	default:
+		return shape
+	}
+	bs := c.base()
+	bs.offsetX, bs.offsetY, bs.width, bs.height = x, y, w, h
+	return c
+}
+
+// translatedShape returns a copy of shape moved by (dx, dy) EMU. Children of
+// groups without a child coordinate space hold slide coordinates and are
+// moved with the group.
+func translatedShape(shape Shape, dx, dy int64) Shape {
+	bs := shape.base()
+	c := withGeometry(shape, bs.offsetX+dx, bs.offsetY+dy, bs.width, bs.height)
+	if g, ok := c.(*GroupShape); ok && (g.childExtX <= 0 || g.childExtY <= 0) {
+		g.shapes = make([]Shape, len(g.shapes))
+		for i, gs := range shape.(*GroupShape).shapes {
+			g.shapes[i] = translatedShape(gs, dx, dy)
+		}
 	}
+	return c
+}
 
-	return img, nil
+// opaqueBounds returns the smallest rectangle containing every pixel of img
+// with non-zero alpha.
+func opaqueBounds(img *image.RGBA) image.Rectangle {
+	b := img.Bounds()
+	minX, minY, maxX, maxY := b.Max.X, b.Max.Y, b.Min.X, b.Min.Y
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		row := (y - b.Min.Y) * img.Stride
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if img.Pix[row+(x-b.Min.X)*4+3] == 0 {
+				continue
+			}
+			if x < minX {
+				minX = x
+			}
+			if x >= maxX {
+				maxX = x + 1
+			}
+			if y < minY {
+				minY = y
+			}
+			if y >= maxY {
+				maxY = y + 1
+			}
+		}
+	}
+	if maxX <= minX || maxY <= minY {
+		return image.Rectangle{}
+	}
+	return image.Rect(minX, minY, maxX, maxY)
 }
 
 // SlidesToImages renders all slides to images.
@@ -149,12 +844,16 @@ func (p *Presentation) SlidesToImages(opts *RenderOptions) ([]image.Image, error
 		opts.FontCache = NewFontCache(opts.FontDirs...)
 	}
 	images := make([]image.Image, len(p.slides))
-	for i := range p.slides {
+	err := p.forEachSlide(opts, func(i int, opts *RenderOptions) error {
 		img, err := p.SlideToImage(i, opts)
 		if err != nil {
-			return nil, fmt.Errorf("slide %d: %w", i, err)
+			return fmt.Errorf("slide %d: %w", i, err)
 		}
 		images[i] = img
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return images, nil
 }
@@ -168,22 +867,146 @@ func (p *Presentation) SaveSlideAsImage(slideIndex int, path string, opts *Rende
 	return saveImage(img, path, opts)
 }
 
+// WriteSlideImage renders a slide and writes it to w in the format selected
+// by opts, without touching the filesystem.
+func (p *Presentation) WriteSlideImage(slideIndex int, w io.Writer, opts *RenderOptions) error {
+	img, err := p.SlideToImage(slideIndex, opts)
+	if err != nil {
+		return err
+	}
+	return EncodeImage(w, img, opts)
+}
+
 // SaveSlidesAsImages renders all slides and saves them to files.
 // The pattern should contain %d for the slide number (1-based), e.g. "slide_%d.png".
+// Use SaveSlidesAsImagesFunc for zero-padded or title-based names.
 func (p *Presentation) SaveSlidesAsImages(pattern string, opts *RenderOptions) error {
-	for i := range p.slides {
+	if opts == nil {
+		opts = DefaultRenderOptions()
+	}
+	return p.forEachSlide(opts, func(i int, opts *RenderOptions) error {
 		path := fmt.Sprintf(pattern, i+1)
 		if err := p.SaveSlideAsImage(i, path, opts); err != nil {
 			return fmt.Errorf("slide %d: %w", i+1, err)
 		}
+		return nil
+	})
+}
+
+// SlideInfo describes a slide to a SlideNameFunc.
+type SlideInfo struct {
+	Number int    // 1-based slide number
+	Count  int    // number of slides in the presentation
+	Title  string // text of the title placeholder, or ""
+	Name   string // slide name
+	Hidden bool   // slide is hidden in slide shows
+	Slide  *Slide
+}
+
+// SlideNameFunc returns the output file path for the slide at slideIndex
+// (0-based). The path may contain directories, which are created as needed.
+type SlideNameFunc func(slideIndex int, slide SlideInfo) string
+
+// SaveSlidesAsImagesFunc renders all slides and saves each one to the path
+// returned by name. Slides for which name returns "" are skipped. name is
+// called for every slide, in order, before any is rendered.
+func (p *Presentation) SaveSlidesAsImagesFunc(name SlideNameFunc, opts *RenderOptions) error {
+	if name == nil {
+		return errors.New("name function is nil")
+	}
+	if opts == nil {
+		opts = DefaultRenderOptions()
+	}
+	paths := make([]string, len(p.slides))
+	for i, slide := range p.slides {
+		paths[i] = name(i, SlideInfo{
+			Number: i + 1,
+			Count:  len(p.slides),
+			Title:  slide.GetTitle(),
+			Name:   slide.name,
+			Hidden: !slide.visible,
+			Slide:  slide,
+		})
+	}
+	return p.forEachSlide(opts, func(i int, opts *RenderOptions) error {
+		if paths[i] == "" {
+			return nil
+		}
+		if err := p.SaveSlideAsImage(i, paths[i], opts); err != nil {
+			return fmt.Errorf("slide %d: %w", i+1, err)
+		}
+		return nil
+	})
+}
+
+// PadSlideNumber formats number with leading zeros to the width of count,
+// e.g. 7 of 120 becomes "007", so that file names sort in slide order.
+func PadSlideNumber(number, count int) string {
+	return fmt.Sprintf("%0*d", len(strconv.Itoa(count)), number)
+}
+
+// PaddedSlideNames returns a SlideNameFunc that substitutes the zero-padded
+// slide number for the single %s in pattern, e.g. "out/slide_%s.png".
+func PaddedSlideNames(pattern string) SlideNameFunc {
+	return func(_ int, slide SlideInfo) string {
+		return fmt.Sprintf(pattern, PadSlideNumber(slide.Number, slide.Count))
 	}
-	return nil
 }
 
-func saveImage(img image.Image, path string, opts *RenderOptions) error {
+// SaveSlidesWithNotes renders all slides and saves each image together with
+// a text file holding the slide's speaker notes, so that the two can be
+// paired by name. pattern contains a single %s for the zero-padded slide
+// number and no extension; in a deck of 12 slides, "out/slide%s" writes
+// out/slide01.png and out/slide01.txt (.jpg with ImageFormatJPEG, .webp with
+// ImageFormatWebP) and so on.
+// Slides without notes get an empty text file.
+func (p *Presentation) SaveSlidesWithNotes(pattern string, opts *RenderOptions) error {
 	if opts == nil {
 		opts = DefaultRenderOptions()
 	}
+	ext := ".png"
+	switch opts.Format {
+	case ImageFormatJPEG:
+		ext = ".jpg"
+	case ImageFormatWebP:
+		ext = ".webp"
+	}
+	return p.forEachSlide(opts, func(i int, opts *RenderOptions) error {
+		base := fmt.Sprintf(pattern, PadSlideNumber(i+1, len(p.slides)))
+		if err := p.SaveSlideAsImage(i, base+ext, opts); err != nil {
+			return fmt.Errorf("slide %d: %w", i+1, err)
+		}
+		notes := p.slides[i].notes
+		if notes != "" && !strings.HasSuffix(notes, "\n") {
+			notes += "\n"
+		}
+		if err := os.WriteFile(base+".txt", []byte(notes), 0644); err != nil {
+			return fmt.Errorf("slide %d: write notes: %w", i+1, err)
+		}
+		return nil
+	})
+}
+
+// SanitizeFileName replaces characters that are not allowed in file names on
+// common file systems, such as path separators and ':', with '_', collapses
+// whitespace and trims the result to at most 100 bytes, for building file
+// names from slide titles.
+func SanitizeFileName(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	s = strings.Map(func(r rune) rune {
+		if r < 0x20 || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, s)
+	for len(s) > 100 {
+		_, size := utf8.DecodeLastRuneInString(s)
+		s = s[:len(s)-size]
+	}
+	return strings.Trim(s, " .")
+}
+
+func saveImage(img image.Image, path string, opts *RenderOptions) error {
 	dir := filepath.Dir(path)
 	if dir != "" && dir != "." {
 		if err := os.MkdirAll(dir, 0750); err != nil {
@@ -194,22 +1017,46 @@ func saveImage(img image.Image, path string, opts *RenderOptions) error {
 	if err != nil {
 		return fmt.Errorf("create file: %w", err)
 	}
-	var encodeErr error
+	encodeErr := EncodeImage(f, img, opts)
+	closeErr := f.Close()
+	if encodeErr != nil {
+		return encodeErr
+	}
+	return closeErr
+}
+
+// EncodeImage writes img to w in the format selected by opts, applying the
+// JPEG quality and subsampling, the WebP quality or the PNG palette size.
+// A nil opts writes a full-color PNG.
+func EncodeImage(w io.Writer, img image.Image, opts *RenderOptions) error {
+	if opts == nil {
+		opts = DefaultRenderOptions()
+	}
 	switch opts.Format {
 	case ImageFormatJPEG:
 		quality := opts.JPEGQuality
 		if quality <= 0 || quality > 100 {
 			quality = 90
 		}
-		encodeErr = jpeg.Encode(f, img, &jpeg.Options{Quality: quality})
+		if _, gray := img.(*image.Gray); opts.JPEGSubsampling == JPEGSubsampling444 && !gray {
+			return encodeJPEG444(w, img, quality)
+		}
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+	case ImageFormatWebP:
+		quality := opts.WebPQuality
+		if quality <= 0 || quality > 100 {
+			quality = 90
+		}
+		if opts.WebPLossless {
+			quality = 100
+		}
+		return encodeWebP(w, img, quality)
 	default:
-		encodeErr = png.Encode(f, img)
-	}
-	closeErr := f.Close()
-	if encodeErr != nil {
-		return encodeErr
+		if opts.PNGPaletteSize > 0 {
+			img = quantizePalette(img, opts.PNGPaletteSize)
+		}
+		return png.Encode(w, img)
 	}
-	return closeErr
 }
 
 // --- renderer core ---
@@ -222,14 +1069,167 @@ type renderer struct {
 	dpi                 float64
 	overlayOpacityScale float64 // 0 means 1.0 (no change)
 	fontScale           float64 // normAutofit font scale factor (0 or 1.0 = no scaling)
+	background          *Fill   // slide background, used by FillBackground shapes
+	backgroundRect      image.Rectangle // area the slide background covers
+	backgroundLayer     *image.RGBA     // background drawn over backgroundRect, made on first use
+	knockoutFill        *Fill           // replaces FillBackground while a knockout shape is drawn
+	textOnly            bool    // draw text only, in black (RenderOptions.TextOnly)
+	showPrompts         bool    // draw prompts in empty placeholders (RenderOptions.ShowPlaceholderPrompts)
+	showUnsupported     bool    // mark unsupported objects (RenderOptions.ShowUnsupportedPlaceholders)
+	textHinting         TextHinting
+	lineSnap            TextLineSnap
+	textTuning          *glyphTuning // gamma and stem darkening for glyph masks; nil for none
+	debug               *debugOverlay // collects boxes for RenderOptions.DebugOverlay; nil when off
+	vertText            string        // bodyPr vert mode of the rotated text being drawn; "" for horizontal text
+	slideIndex          int           // 0-based index of the slide being rendered, for warnings
+	warn                func(RenderWarning)
+	photoAlbum          *PhotoAlbum // frames pictures that have none; nil outside photo albums
+	gradientSpace       GradientSpace
+	imageFilter         ImageFilter
+	imageScaleSnap      float64
+	sharpBitonal        bool // treat two-tone pictures as pixelated (RenderOptions.SharpBitonalImages)
+	svgText             *svgTextSink // collects text runs instead of drawing them (SlideToSVG); nil when rasterizing
+	themeOverrides      map[string]Color // replacement theme colors by scheme name (RenderOptions.ThemeColorOverrides)
+}
+
+// newRenderer returns a renderer for slide slideIndex drawing into img at
+// the given pixels per EMU, set up from opts.
+func (p *Presentation) newRenderer(slideIndex int, opts *RenderOptions, img *image.RGBA, scaleX, scaleY float64) *renderer {
+	fc := opts.FontCache
+	if fc == nil {
+		fc = NewFontCache(opts.FontDirs...)
+	}
+	dpi := opts.DPI
+	if dpi <= 0 {
+		dpi = 96
+	}
+
+	return &renderer{
+		img:                 img,
+		scaleX:              scaleX,
+		scaleY:              scaleY,
+		fontCache:           fc,
+		dpi:                 dpi,
+		overlayOpacityScale: opts.OverlayOpacityScale,
+		textOnly:            opts.TextOnly,
+		showPrompts:         opts.ShowPlaceholderPrompts,
+		showUnsupported:     opts.ShowUnsupportedPlaceholders,
+		textHinting:         opts.TextHinting,
+		lineSnap:            opts.TextLineSnap,
+		textTuning:          newGlyphTuning(opts.TextGamma, opts.StemDarkening),
+		slideIndex:          slideIndex,
+		warn:                opts.OnWarning,
+		photoAlbum:          p.photoAlbum,
+		gradientSpace:       opts.GradientInterpolation,
+		imageFilter:         opts.ImageFilter,
+		imageScaleSnap:      opts.ImageScaleSnap,
+		sharpBitonal:        opts.SharpBitonalImages,
+		themeOverrides:      p.themeColorOverrides(opts.ThemeColorOverrides),
+	}
+}
+
+// fillSlideBackground paints the slide's background, or the one opts
+// override it with, over rect and remembers it for FillBackground shapes.
+func (r *renderer) fillSlideBackground(slide *Slide, opts *RenderOptions, rect image.Rectangle) {
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	bgColor := white
+	drawn := false
+	if opts.TextOnly {
+		// Keep the plain white background.
+	} else if opts.BackgroundColor != nil {
+		bgColor = *opts.BackgroundColor
+	} else if slide.background != nil {
+		switch slide.background.Type {
+		case FillSolid:
+			bgColor = r.rgba(slide.background.Color)
+		case FillGradientLinear:
+			r.fillGradientLinear(rect, slide.background)
+			drawn = true
+		case FillGradientPath:
+			r.fillGradientPath(rect, slide.background)
+			drawn = true
+		case FillPicture:
+			// Transparent parts of the picture show white.
+			r.fillRectFast(rect, white)
+			r.fillPicture(rect, slide.background)
+			drawn = true
+		}
+	}
+	r.backgroundRect = rect
+	if !drawn {
+		r.fillRectFast(rect, bgColor)
+		r.background = &Fill{Type: FillSolid, Color: Color{ARGB: fmt.Sprintf("%02X%02X%02X%02X", bgColor.A, bgColor.R, bgColor.G, bgColor.B)}}
+	} else {
+		r.background = slide.background
+	}
+}
+
+// withImage returns a copy of r that draws into img, for rendering into
+// temporary buffers with the same settings.
+func (r *renderer) withImage(img *image.RGBA) *renderer {
+	tmp := *r
+	tmp.img = img
+	// Temporary buffers are composited with a transform, so their boxes
+	// would land in the wrong place on the slide.
+	tmp.debug = nil
+	return &tmp
+}
+
+// autofitPxPerEMU is the scale text is fitted at: 192 pixels per inch,
+// fine enough that rounding font metrics to pixels does not decide the fit.
+const autofitPxPerEMU = 192.0 / emuPerInch
+
+// atAutofitScale returns a copy of r for measuring text at autofitPxPerEMU.
+// Fitting at one fixed scale makes exports at different widths and
+// supersampling factors pick the same font scale for a shape.
+func (r *renderer) atAutofitScale() *renderer {
+	m := *r
+	m.img = nil
+	m.debug = nil
+	m.scaleX, m.scaleY = autofitPxPerEMU, autofitPxPerEMU
+	return &m
 }
 
 func (r *renderer) renderShape(shape Shape) {
+	defer func() {
+		if v := recover(); v != nil {
+			r.shapeFailed(shape, v)
+		}
+	}()
+	if r.textOnly {
+		if shape = textOnlyShape(shape); shape == nil {
+			return
+		}
+	}
+	if fade := shape.base().fade; fade > 0 {
+		// A faded shape is drawn on its own layer, which is then blended
+		// in, so that its overlapping parts do not show through each other.
+		if fade < 1 {
+			b := r.img.Bounds()
+			layer := getRGBA(b)
+			r.withImage(layer).renderShapeKind(shape)
+			mask := image.NewUniform(color.Alpha{A: uint8(math.Round(255 * (1 - fade)))})
+			draw.DrawMask(r.img, b, layer, b.Min, mask, image.Point{}, draw.Over)
+			putRGBA(layer)
+		}
+	} else {
+		r.renderShapeKind(shape)
+	}
+	if r.debug != nil {
+		r.debug.shapes = append(r.debug.shapes, shape)
+	}
+}
+
+// renderShapeKind draws shape with the renderer for its type.
+func (r *renderer) renderShapeKind(shape Shape) {
+	if r.renderKnockout(shape) {
+		return
+	}
 	switch s := shape.(type) {
 	case *RichTextShape:
 		r.renderRichText(s)
 	case *PlaceholderShape:
-		r.renderRichText(&s.RichTextShape)
+		r.renderPlaceholder(s)
 	case *DrawingShape:
 		r.renderDrawing(s)
 	case *AutoShape:
@@ -242,7 +1242,207 @@ func (r *renderer) renderShape(shape Shape) {
 		r.renderChart(s)
 	case *GroupShape:
 		r.renderGroup(s)
+	case *UnsupportedShape:
+		r.renderUnsupported(s)
+	case *InkShape:
+		r.renderInk(s)
+	}
+}
+
+// renderKnockout draws a FillBackground shape over a gradient or picture
+// background, which has to line up with the background around the shape
+// rather than be stretched over the shape's box. The shape is drawn twice
+// on its own, with a black and a white fill: where the two differ the fill
+// shows, and there the background, laid out over the whole slide, is
+// painted before the shape is drawn without its fill. It reports false for
+// shapes resolveFill handles by itself.
+func (r *renderer) renderKnockout(shape Shape) bool {
+	if r.knockoutFill != nil || r.background == nil || r.backgroundRect.Empty() {
+		return false
+	}
+	switch r.background.Type {
+	case FillGradientLinear, FillGradientPath, FillPicture:
+	default:
+		return false
+	}
+	if f := shape.base().fill; f == nil || f.Type != FillBackground {
+		return false
+	}
+	b := r.img.Bounds()
+	layers := [2]*image.RGBA{getRGBA(b), getRGBA(b)}
+	for i, c := range [2]Color{ColorBlack, ColorWhite} {
+		m := r.withImage(layers[i])
+		m.knockoutFill = &Fill{Type: FillSolid, Color: c}
+		m.svgText = nil
+		m.warn = nil
+		m.renderShapeKind(shape)
+	}
+	mask := image.NewAlpha(b)
+	black, white := layers[0].Pix, layers[1].Pix
+	for i := range mask.Pix {
+		j := i * 4
+		d := max(abs(int(white[j])-int(black[j])), abs(int(white[j+1])-int(black[j+1])), abs(int(white[j+2])-int(black[j+2])))
+		mask.Pix[i] = uint8(d)
+	}
+	putRGBA(layers[0])
+	putRGBA(layers[1])
+	draw.DrawMask(r.img, b, r.slideBackgroundLayer(), b.Min, mask, b.Min, draw.Over)
+
+	r.knockoutFill = &Fill{Type: FillNone}
+	defer func() { r.knockoutFill = nil }()
+	r.renderShapeKind(shape)
+	return true
+}
+
+// slideBackgroundLayer returns the slide background drawn on its own, for
+// knockout shapes to show through.
+func (r *renderer) slideBackgroundLayer() *image.RGBA {
+	if r.backgroundLayer == nil {
+		layer := image.NewRGBA(r.img.Bounds())
+		tr := r.withImage(layer)
+		if r.background.Type == FillPicture {
+			// Transparent parts of the picture show white.
+			tr.fillRectFast(r.backgroundRect, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+		}
+		tr.renderFill(r.background, r.backgroundRect)
+		r.backgroundLayer = layer
+	}
+	return r.backgroundLayer
+}
+
+// shapeFailed reports a shape whose rendering panicked and draws a
+// placeholder box with a cross in its place, so that one malformed shape
+// does not abort the whole slide.
+func (r *renderer) shapeFailed(shape Shape, v any) {
+	name, kind := "", "unknown"
+	var rect image.Rectangle
+	func() {
+		defer func() { recover() }() // a nil or corrupt shape has no geometry
+		kind = debugShapeKind(shape)
+		bs := shape.base()
+		name = bs.name
+		x, y := r.emuToPixelX(bs.offsetX), r.emuToPixelY(bs.offsetY)
+		rect = image.Rect(x, y, x+r.emuToPixelX(bs.width), y+r.emuToPixelY(bs.height))
+	}()
+	if r.warn != nil {
+		r.warn(RenderWarning{
+			SlideIndex: r.slideIndex,
+			ShapeName:  name,
+			Message:    fmt.Sprintf("%s shape not rendered: %v", kind, v),
+		})
+	}
+	if rect.Dx() < 2 || rect.Dy() < 2 {
+		return
+	}
+	c := color.RGBA{R: 200, G: 40, B: 40, A: 255}
+	r.fillRectBlend(rect, color.RGBA{R: 200, G: 200, B: 200, A: 96})
+	r.drawRect(rect, c, 1)
+	r.drawLine(rect.Min.X, rect.Min.Y, rect.Max.X-1, rect.Max.Y-1, c)
+	r.drawLine(rect.Min.X, rect.Max.Y-1, rect.Max.X-1, rect.Min.Y, c)
+}
+
+// renderUnsupported marks an object that cannot be rendered with a light
+// box showing its type and alt text, when ShowUnsupportedPlaceholders is set.
+func (r *renderer) renderUnsupported(s *UnsupportedShape) {
+	if !r.showUnsupported || r.textOnly {
+		return
 	}
+	x, y := r.emuToPixelX(s.offsetX), r.emuToPixelY(s.offsetY)
+	rect := image.Rect(x, y, x+r.emuToPixelX(s.width), y+r.emuToPixelY(s.height))
+	if rect.Dx() < 2 || rect.Dy() < 2 {
+		return
+	}
+	r.fillRectBlend(rect, color.RGBA{R: 242, G: 242, B: 242, A: 255})
+	r.drawRect(rect, color.RGBA{R: 166, G: 166, B: 166, A: 255}, 1)
+
+	f := NewFont()
+	f.Size = 12
+	f.Bold = true
+	face := r.getFace(f)
+	lh := face.Metrics().Height.Ceil()
+	lines := []string{s.kind}
+	if s.description != "" {
+		lines = append(lines, s.description)
+	}
+	top := rect.Min.Y + (rect.Dy()-lh*len(lines))/2
+	for i, line := range lines {
+		if i == 1 {
+			f.Bold = false
+			face = r.getFace(f)
+		}
+		line = truncateToWidth(face, line, rect.Dx()-8)
+		lr := image.Rect(rect.Min.X, top+i*lh, rect.Max.X, top+(i+1)*lh)
+		r.drawStringCentered(line, face, color.RGBA{R: 89, G: 89, B: 89, A: 255}, lr.Intersect(rect))
+	}
+}
+
+// truncateToWidth shortens text with an ellipsis so that it fits in w pixels.
+func truncateToWidth(face font.Face, text string, w int) string {
+	if w <= 0 {
+		return ""
+	}
+	if font.MeasureString(face, text).Ceil() <= w {
+		return text
+	}
+	runes := []rune(text)
+	for n := len(runes) - 1; n > 0; n-- {
+		t := string(runes[:n]) + "…"
+		if font.MeasureString(face, t).Ceil() <= w {
+			return t
+		}
+	}
+	return ""
+}
+
+// textOnlyShape returns a copy of shape stripped of everything but its text,
+// or nil if the shape has no text to render. Groups are returned unchanged;
+// their children are stripped as they are rendered.
+func textOnlyShape(shape Shape) Shape {
+	strip := func(b *BaseShape) {
+		b.fill = nil
+		b.border = nil
+		b.shadow = nil
+	}
+	switch s := shape.(type) {
+	case *RichTextShape:
+		c := *s
+		strip(&c.BaseShape)
+		c.headEnd, c.tailEnd = nil, nil
+		return &c
+	case *PlaceholderShape:
+		c := *s
+		strip(&c.BaseShape)
+		c.headEnd, c.tailEnd = nil, nil
+		return &c
+	case *AutoShape:
+		if s.shapeType == AutoShapeArc {
+			return nil
+		}
+		c := *s
+		strip(&c.BaseShape)
+		return &c
+	case *TableShape:
+		c := *s
+		strip(&c.BaseShape)
+		c.borders = nil
+		c.rows = make([][]*TableCell, len(s.rows))
+		for i, row := range s.rows {
+			c.rows[i] = make([]*TableCell, len(row))
+			for j, cell := range row {
+				if cell == nil {
+					continue
+				}
+				cc := *cell
+				cc.fill = nil
+				cc.border = &CellBorders{}
+				c.rows[i][j] = &cc
+			}
+		}
+		return &c
+	case *GroupShape:
+		return s
+	}
+	return nil
 }
 
 func (r *renderer) emuToPixelX(emu int64) int { return int(math.Round(float64(emu) * r.scaleX)) }
@@ -260,6 +1460,35 @@ func argbToRGBA(c Color) color.RGBA {
 	return color.RGBA{R: c.GetRed(), G: c.GetGreen(), B: c.GetBlue(), A: c.GetAlpha()}
 }
 
+// rgba converts c for drawing, deriving colors read from overridden theme
+// colors (RenderOptions.ThemeColorOverrides) from their replacements.
+func (r *renderer) rgba(c Color) color.RGBA {
+	return argbToRGBA(c.withScheme(r.themeOverrides))
+}
+
+// themeColorOverrides returns the replacements of the theme colors named in
+// overrides that the theme defines, keyed by scheme name. tx1, bg1, tx2 and
+// bg2 follow dk1, lt1, dk2 and lt2 unless they are overridden themselves.
+func (p *Presentation) themeColorOverrides(overrides map[string]Color) map[string]Color {
+	if len(overrides) == 0 {
+		return nil
+	}
+	m := make(map[string]Color, len(overrides))
+	for name, c := range overrides {
+		if _, ok := p.themeColors[name]; ok {
+			m[name] = NewColor(c.ARGB)
+		}
+	}
+	for alias, name := range map[string]string{"tx1": "dk1", "bg1": "lt1", "tx2": "dk2", "bg2": "lt2"} {
+		if c, ok := m[name]; ok {
+			if _, set := m[alias]; !set {
+				m[alias] = c
+			}
+		}
+	}
+	return m
+}
+
 // --- Pixel operations (performance-critical) ---
 
 // blendPixel alpha-blends color c over the existing pixel at (x, y).
@@ -289,6 +1518,41 @@ func (r *renderer) blendPixel(x, y int, c color.RGBA) {
 	pix[off+3] = uint8(uint32(pix[off+3]) + (255-uint32(pix[off+3]))*a/255)
 }
 
+// blendPremultiplied composites a premultiplied RGBA pixel, as read from
+// an *image.RGBA, over the pixel at (x, y).
+func (r *renderer) blendPremultiplied(x, y int, c []uint8) {
+	b := r.img.Bounds()
+	if x < b.Min.X || x >= b.Max.X || y < b.Min.Y || y >= b.Max.Y || c[3] == 0 {
+		return
+	}
+	off := (y-b.Min.Y)*r.img.Stride + (x-b.Min.X)*4
+	ia := 255 - uint32(c[3])
+	for i := 0; i < 4; i++ {
+		r.img.Pix[off+i] = uint8(uint32(c[i]) + (uint32(r.img.Pix[off+i])*ia+127)/255)
+	}
+}
+
+// blendPixelAt alpha-blends c over the pixel at byte offset off in pix.
+// Transparent colors leave the pixel untouched.
+func blendPixelAt(pix []uint8, off int, c color.RGBA) {
+	switch c.A {
+	case 0:
+		return
+	case 255:
+		pix[off] = c.R
+		pix[off+1] = c.G
+		pix[off+2] = c.B
+		pix[off+3] = 255
+		return
+	}
+	a := uint32(c.A)
+	ia := 255 - a
+	pix[off] = uint8((uint32(c.R)*a + uint32(pix[off])*ia) / 255)
+	pix[off+1] = uint8((uint32(c.G)*a + uint32(pix[off+1])*ia) / 255)
+	pix[off+2] = uint8((uint32(c.B)*a + uint32(pix[off+2])*ia) / 255)
+	pix[off+3] = uint8(uint32(pix[off+3]) + (255-uint32(pix[off+3]))*a/255)
+}
+
 // blendPixelF blends with fractional coverage (0.0–1.0) for anti-aliasing.
 func (r *renderer) blendPixelF(x, y int, c color.RGBA, coverage float64) {
 	if coverage <= 0 {
@@ -422,18 +1686,35 @@ func (r *renderer) renderRotated(x, y, w, h, rotation int, flipH, flipV bool, dr
 // height, allowing text to overflow the shape bounds without being clipped.
 // The rotation center remains at the center of the original shape (w × h).
 func (r *renderer) renderRotatedExpanded(x, y, w, h, bufH, rotation int, flipH, flipV bool, drawFn func(tmp *renderer)) {
+	r.renderRotatedPadded(x, y, w, h, 0, bufH, rotation, flipH, flipV, drawFn)
+}
+
+// renderRotatedPadded is like renderRotatedExpanded but also extends the temp
+// buffer by padX pixels left and right of the shape, for text that does not
+// wrap and overflows sideways. drawFn still draws the shape at (0, 0); the
+// buffer's bounds start at (-padX, 0).
+func (r *renderer) renderRotatedPadded(x, y, w, h, padX, bufH, rotation int, flipH, flipV bool, drawFn func(tmp *renderer)) {
 	if w <= 0 || h <= 0 {
 		return
 	}
 	if bufH < h {
 		bufH = h
 	}
-	tmp := image.NewRGBA(image.Rect(0, 0, w, bufH))
-	tmpR := &renderer{img: tmp, scaleX: r.scaleX, scaleY: r.scaleY, fontCache: r.fontCache, dpi: r.dpi, fontScale: r.fontScale}
+	if padX < 0 {
+		padX = 0
+	}
+	tmp := getRGBA(image.Rect(-padX, 0, w+padX, bufH))
+	defer putRGBA(tmp)
+	tmpR := r.withImage(tmp)
+	tmpR.svgText = r.svgText.transformed(float64(x)+float64(w)/2, float64(y)+float64(h)/2, float64(w)/2, float64(h)/2, rotation, flipH, flipV)
+	// The buffer is in the shape's own space, which the slide background
+	// does not line up with.
+	tmpR.backgroundRect = image.Rectangle{}
+	tmpR.backgroundLayer = nil
 	drawFn(tmpR)
 
 	if rotation == 0 && !flipH && !flipV {
-		draw.Draw(r.img, image.Rect(x, y, x+w, y+bufH), tmp, image.Point{}, draw.Over)
+		draw.Draw(r.img, image.Rect(x-padX, y, x+w+padX, y+bufH), tmp, tmp.Rect.Min, draw.Over)
 		return
 	}
 
@@ -444,17 +1725,14 @@ func (r *renderer) renderRotatedExpanded(x, y, w, h, bufH, rotation int, flipH,
 			if flipV {
 				sy = bufH - 1 - py
 			}
-			for px := 0; px < w; px++ {
+			for px := -padX; px < w+padX; px++ {
 				sx := px
 				if flipH {
 					sx = w - 1 - px
 				}
-				sOff := sy*tmp.Stride + sx*4
+				sOff := sy*tmp.Stride + (sx+padX)*4
 				if tmp.Pix[sOff+3] > 0 {
-					r.blendPixel(x+px, y+py, color.RGBA{
-						R: tmp.Pix[sOff], G: tmp.Pix[sOff+1],
-						B: tmp.Pix[sOff+2], A: tmp.Pix[sOff+3],
-					})
+					r.blendPremultiplied(x+px, y+py, tmp.Pix[sOff:sOff+4])
 				}
 			}
 		}
@@ -476,7 +1754,9 @@ func (r *renderer) renderRotatedExpanded(x, y, w, h, bufH, rotation int, flipH,
 	destCX := float64(x) + cx
 	destCY := float64(y) + cy
 
-	bounds := rotatedBounds(destCX, destCY, w, bufH, rotation)
+	// The buffer reaches bufH-h/2 below the centre; cover that distance on
+	// every side so that overflowing text is not cut off.
+	bounds := rotatedBounds(destCX, destCY, w+2*padX, 2*bufH-h, rotation)
 	imgBounds := r.img.Bounds()
 	minDY := maxInt(bounds.Min.Y, imgBounds.Min.Y)
 	maxDY := minInt(bounds.Max.Y, imgBounds.Max.Y)
@@ -502,13 +1782,10 @@ func (r *renderer) renderRotatedExpanded(x, y, w, h, bufH, rotation int, flipH,
 			sx := ux + cx
 			sy := uy + cy
 			ix, iy := int(sx), int(sy)
-			if ix >= 0 && ix < w && iy >= 0 && iy < bufH {
-				sOff := iy*tmp.Stride + ix*4
+			if ix >= -padX && ix < w+padX && iy >= 0 && iy < bufH {
+				sOff := iy*tmp.Stride + (ix+padX)*4
 				if tmp.Pix[sOff+3] > 0 {
-					r.blendPixel(dx, dy, color.RGBA{
-						R: tmp.Pix[sOff], G: tmp.Pix[sOff+1],
-						B: tmp.Pix[sOff+2], A: tmp.Pix[sOff+3],
-					})
+					r.blendPremultiplied(dx, dy, tmp.Pix[sOff:sOff+4])
 				}
 			}
 		}
@@ -517,33 +1794,14 @@ func (r *renderer) renderRotatedExpanded(x, y, w, h, bufH, rotation int, flipH,
 
 
 func (r *renderer) renderGroup(g *GroupShape) {
-	// Transform child coordinates from child space (chOff/chExt) to group space (off/ext)
-	if g.childExtX > 0 && g.childExtY > 0 {
-		for _, gs := range g.shapes {
-			bs := gs.base()
-			origX := bs.offsetX
-			origY := bs.offsetY
-			origW := bs.width
-			origH := bs.height
-			bs.offsetX = g.offsetX + (origX-g.childOffX)*g.width/g.childExtX
-			bs.offsetY = g.offsetY + (origY-g.childOffY)*g.height/g.childExtY
-			bs.width = origW * g.width / g.childExtX
-			bs.height = origH * g.height / g.childExtY
-			defer func(s Shape, ox, oy, ow, oh int64) {
-				b := s.base()
-				b.offsetX = ox
-				b.offsetY = oy
-				b.width = ow
-				b.height = oh
-			}(gs, origX, origY, origW, origH)
-		}
-	}
+	// Draw transformed copies of the children in group space.
+	children := g.slideSpaceChildren()
 
 	rotation := g.GetRotation()
 	flipH := g.GetFlipHorizontal()
 	flipV := g.GetFlipVertical()
 	if rotation == 0 && !flipH && !flipV {
-		for _, gs := range g.shapes {
+		for _, gs := range children {
 			r.renderShape(gs)
 		}
 		return
@@ -553,53 +1811,154 @@ func (r *renderer) renderGroup(g *GroupShape) {
 	w := r.emuToPixelX(g.width)
 	h := r.emuToPixelY(g.height)
 	r.renderRotated(x, y, w, h, rotation, flipH, flipV, func(tmp *renderer) {
-		// Shift children to render relative to (0,0) in the temp buffer.
-		// Children have absolute slide coordinates; subtract group origin.
-		for _, gs := range g.shapes {
-			bs := gs.base()
-			bs.offsetX -= g.offsetX
-			bs.offsetY -= g.offsetY
-		}
-		defer func() {
-			for _, gs := range g.shapes {
-				bs := gs.base()
-				bs.offsetX += g.offsetX
-				bs.offsetY += g.offsetY
-			}
-		}()
-		for _, gs := range g.shapes {
-			tmp.renderShape(gs)
+		// Children have absolute slide coordinates; render them relative to
+		// (0,0) in the temp buffer.
+		for _, gs := range children {
+			tmp.renderShape(translatedShape(gs, -g.offsetX, -g.offsetY))
 		}
 	})
 }
 
 // --- Shape rendering ---
 
-func (r *renderer) renderRichText(s *RichTextShape) {
-	x := r.emuToPixelX(s.offsetX)
-	y := r.emuToPixelY(s.offsetY)
-	w := r.emuToPixelX(s.width)
-	h := r.emuToPixelY(s.height)
-	rotation := s.GetRotation()
-	flipH := s.GetFlipHorizontal()
-	flipV := s.GetFlipVertical()
+// renderSubPixelShape draws a shape that is less than one pixel wide or tall
+// after scaling as a box whose alpha is proportional to the pixel area it
+// covers, so hairline rules and tiny tick marks stay visible instead of
+// rounding away. It reports false, drawing nothing, for larger shapes.
+func (r *renderer) renderSubPixelShape(bs *BaseShape) bool {
+	fw := float64(bs.width) * r.scaleX
+	fh := float64(bs.height) * r.scaleY
+	if fw >= 1 && fh >= 1 {
+		return false
+	}
+	// A fill covers the shape's own area; an outline-only shape covers at
+	// least its stroke width in each direction.
+	var c color.RGBA
+	minW, minH := fw, fh
+	fill := r.resolveFill(bs.fill)
+	switch {
+	case fill != nil && fill.Type != FillNone:
+		c = r.scaleAlpha(r.fillMeanColor(fill))
+	case bs.border != nil && bs.border.Style != BorderNone:
+		c = r.rgba(bs.border.Color)
+		bw := float64(maxInt(bs.border.Width, 1)) * 12700.0 * r.scaleX
+		minW, minH = math.Max(fw, bw), math.Max(fh, bw)
+	default:
+		return true
+	}
+	c.A = uint8(math.Round(float64(c.A) * math.Min(minW, 1) * math.Min(minH, 1)))
 
-	// Apply normAutofit font scale
-	prevFontScale := r.fontScale
-	if s.fontScale > 0 && s.fontScale != 100000 {
-		r.fontScale = float64(s.fontScale) / 100000.0
+	// Draw the thin dimension one pixel wide; the alpha above carries the
+	// actual coverage.
+	fx := float64(bs.offsetX) * r.scaleX
+	fy := float64(bs.offsetY) * r.scaleY
+	cx, cy := fx+fw/2, fy+fh/2
+	fw, fh = math.Max(fw, 1), math.Max(fh, 1)
+	rad := float64(bs.rotation) * math.Pi / 180.0
+	cosA, sinA := math.Cos(rad), math.Sin(rad)
+	var quad [4]fpoint
+	for i, p := range [4]fpoint{{-fw / 2, -fh / 2}, {fw / 2, -fh / 2}, {fw / 2, fh / 2}, {-fw / 2, fh / 2}} {
+		quad[i] = fpoint{cx + p.x*cosA - p.y*sinA, cy + p.x*sinA + p.y*cosA}
+	}
+	r.fillQuadCoverage(quad, c)
+	return true
+}
+
+// fillQuadCoverage fills a convex quadrilateral, blending each pixel by the
+// fraction of it the quad covers (estimated with 4x4 supersampling).
+func (r *renderer) fillQuadCoverage(q [4]fpoint, c color.RGBA) {
+	minX, minY := q[0].x, q[0].y
+	maxX, maxY := minX, minY
+	for _, p := range q[1:] {
+		minX, maxX = math.Min(minX, p.x), math.Max(maxX, p.x)
+		minY, maxY = math.Min(minY, p.y), math.Max(maxY, p.y)
+	}
+	inside := func(x, y float64) bool {
+		sign := 0.0
+		for i := 0; i < 4; i++ {
+			a, b := q[i], q[(i+1)%4]
+			cross := (b.x-a.x)*(y-a.y) - (b.y-a.y)*(x-a.x)
+			if cross == 0 {
+				continue
+			}
+			if sign == 0 {
+				sign = cross
+			} else if (cross > 0) != (sign > 0) {
+				return false
+			}
+		}
+		return true
 	}
-	defer func() { r.fontScale = prevFontScale }()
+	const ss = 4
+	for py := int(math.Floor(minY)); py <= int(math.Floor(maxY)); py++ {
+		for px := int(math.Floor(minX)); px <= int(math.Floor(maxX)); px++ {
+			hits := 0
+			for sy := 0; sy < ss; sy++ {
+				for sx := 0; sx < ss; sx++ {
+					if inside(float64(px)+(float64(sx)+0.5)/ss, float64(py)+(float64(sy)+0.5)/ss) {
+						hits++
+					}
+				}
+			}
+			r.blendPixelF(px, py, c, float64(hits)/(ss*ss))
+		}
+	}
+}
+
+// renderPlaceholder draws a placeholder. An empty placeholder never shows the
+// prompt it inherits from the layout unless showPrompts is set, in which case
+// the prompt is drawn in the layout's font in place of the missing text.
+func (r *renderer) renderPlaceholder(s *PlaceholderShape) {
+	if !r.showPrompts || !s.IsEmpty() {
+		r.renderRichText(&s.RichTextShape)
+		return
+	}
+	prompt := s.GetPromptText()
+	if prompt == "" {
+		r.renderRichText(&s.RichTextShape)
+		return
+	}
+	rt := s.RichTextShape
+	rt.paragraphs = nil
+	for _, line := range strings.Split(prompt, "\n") {
+		para := NewParagraph()
+		if len(s.paragraphs) > 0 {
+			para.alignment = s.paragraphs[0].alignment
+		}
+		tr := para.CreateTextRun(line)
+		if s.promptFont != nil {
+			f := *s.promptFont
+			tr.font = &f
+		} else {
+			tr.font.Size = 18
+		}
+		rt.paragraphs = append(rt.paragraphs, para)
+	}
+	r.renderRichText(&rt)
+}
 
+// geometryShape returns an AutoShape with the text shape's box and preset
+// geometry, or nil if the shape is a plain rectangle or a freeform.
+func (s *RichTextShape) geometryShape() *AutoShape {
+	if s.customPath != nil || s.geometry == "" || s.geometry == AutoShapeRectangle {
+		return nil
+	}
+	return &AutoShape{BaseShape: s.BaseShape, shapeType: s.geometry, adjustValues: s.adjustValues}
+}
+
+// richTextArea returns the insets and the size of the text area of s drawn
+// in a w×h pixel box. Default insets give way when the text overflows.
+func (r *renderer) richTextArea(s *RichTextShape, w, h int) (pxL, pxR, pxT, pxB, tw, th int) {
+	wordWrap := s.wordWrap
 	// Text insets (padding). PowerPoint defaults: lIns=91440, rIns=91440, tIns=45720, bIns=45720
 	lIns, rIns, tIns, bIns := int64(91440), int64(91440), int64(45720), int64(45720)
 	if s.insetsSet {
 		lIns, rIns, tIns, bIns = s.insetLeft, s.insetRight, s.insetTop, s.insetBottom
 	}
-	pxL := r.emuToPixelX(lIns)
-	pxR := r.emuToPixelX(rIns)
-	pxT := r.emuToPixelY(tIns)
-	pxB := r.emuToPixelY(bIns)
+	pxL = r.emuToPixelX(lIns)
+	pxR = r.emuToPixelX(rIns)
+	pxT = r.emuToPixelY(tIns)
+	pxB = r.emuToPixelY(bIns)
 
 	// Clamp default insets when they consume too much of the shape dimensions.
 	// This happens for small shapes inside nested groups where group coordinate
@@ -619,19 +1978,9 @@ func (r *renderer) renderRichText(s *RichTextShape) {
 		}
 	}
 
-	// Vertical text direction adds implicit rotation
-	vertRotation := 0
-	if s.textDirection == "vert" || s.textDirection == "eaVert" || s.textDirection == "wordArtVert" {
-		vertRotation = 270
-	} else if s.textDirection == "vert270" {
-		vertRotation = 90
-	}
-
-	// Estimate total text height to detect overflow.
-	// PowerPoint does not clip text to the text box boundary, so we must
-	// expand the rendering buffer when text overflows.
-	tw := w - pxL - pxR
-	th := h - pxT - pxB
+	// Text area inside the insets.
+	tw = w - pxL - pxR
+	th = h - pxT - pxB
 	if tw < 1 {
 		tw = w
 	}
@@ -639,15 +1988,6 @@ func (r *renderer) renderRichText(s *RichTextShape) {
 		th = h
 	}
 
-	// spAutoFit: shape resizes to fit text. When the shape has word-wrap
-	// enabled, PowerPoint expands the shape vertically while keeping the
-	// width fixed. We cannot resize the shape at render time, but we
-	// should still honour word-wrap so text wraps within the available
-	// width instead of overflowing horizontally and overlapping adjacent
-	// shapes. Only disable word-wrap when the original shape had it off
-	// (rare case where the box expands horizontally).
-	wordWrap := s.wordWrap
-
 	// When default insets are used and text overflows, progressively reduce
 	// insets to make room. Font metric differences between systems can cause
 	// text to be slightly larger than the original authoring environment
@@ -672,6 +2012,19 @@ func (r *renderer) renderRichText(s *RichTextShape) {
 		}
 	}
 
+	return pxL, pxR, pxT, pxB, tw, th
+}
+
+// richTextFontScale returns the font scale the text of s is drawn at,
+// shrunk from r.fontScale to fit the box when the shape autofits and to
+// keep wrapped lines within its width. The fit is measured at
+// autofitPxPerEMU rather than the output scale, so that renders at every
+// width agree on the scale.
+func (r *renderer) richTextFontScale(s *RichTextShape) float64 {
+	m := r.atAutofitScale()
+	wordWrap := s.wordWrap
+	_, _, _, _, tw, th := m.richTextArea(s, m.emuToPixelX(s.width), m.emuToPixelY(s.height))
+
 	// Auto-shrink text when normAutofit is set without an explicit fontScale.
 	// PowerPoint dynamically calculates the scale to fit text within the box.
 	shouldAutoShrink := false
@@ -686,7 +2039,7 @@ func (r *renderer) renderRichText(s *RichTextShape) {
 	// preserves text readability while keeping text roughly within bounds.
 	isAutoFitShape := false
 	if s.autoFit == AutoFitShape && (s.fontScale == 0 || s.fontScale == 100000) && th > 0 {
-		textH := r.measureParagraphsHeight(s.paragraphs, tw, th, s.textAnchor, wordWrap)
+		textH := m.measureParagraphsHeight(s.paragraphs, tw, th, s.textAnchor, wordWrap)
 		if textH > th {
 			shouldAutoShrink = true
 			isAutoFitShape = true
@@ -698,7 +2051,7 @@ func (r *renderer) renderRichText(s *RichTextShape) {
 	// expanding the buffer height).
 	isAutoFitNone := s.autoFit == AutoFitNone
 	if shouldAutoShrink {
-		textH := r.measureParagraphsHeight(s.paragraphs, tw, th, s.textAnchor, wordWrap)
+		textH := m.measureParagraphsHeight(s.paragraphs, tw, th, s.textAnchor, wordWrap)
 		if textH > th && th > 0 {
 			// Binary search for the right scale factor.
 			// For AutoFitNone, use a high floor (0.85) since PowerPoint does
@@ -714,15 +2067,15 @@ func (r *renderer) renderRichText(s *RichTextShape) {
 			}
 			for i := 0; i < 15; i++ {
 				mid := (lo + hi) / 2
-				r.fontScale = mid
-				mh := r.measureParagraphsHeight(s.paragraphs, tw, th, s.textAnchor, wordWrap)
+				m.fontScale = mid
+				mh := m.measureParagraphsHeight(s.paragraphs, tw, th, s.textAnchor, wordWrap)
 				if mh > th {
 					hi = mid
 				} else {
 					lo = mid
 				}
 			}
-			r.fontScale = lo
+			m.fontScale = lo
 		}
 	}
 
@@ -734,14 +2087,14 @@ func (r *renderer) renderRichText(s *RichTextShape) {
 	// by the wrapping tolerance don't falsely trigger horizontal shrinking.
 	if wordWrap && tw > 0 {
 		hTolerance := tw * 103 / 100 // 3% tolerance matching wrapRunLine
-		maxLW := r.measureMaxLineWidth(s.paragraphs, tw, wordWrap)
+		maxLW := m.measureMaxLineWidth(s.paragraphs, tw, wordWrap)
 		if maxLW > hTolerance {
 			// Binary search for a scale that fits horizontally.
 			// For AutoFitNone, use a higher floor to avoid over-shrinking.
 			// Use the current fontScale as hi (may already be reduced by
 			// vertical shrink). Ensure the combined vertical+horizontal
 			// shrink doesn't go below a reasonable floor.
-			lo, hi := 0.5, r.fontScale
+			lo, hi := 0.5, m.fontScale
 			if isAutoFitNone && lo < 0.85 {
 				lo = 0.85
 			}
@@ -761,18 +2114,55 @@ func (r *renderer) renderRichText(s *RichTextShape) {
 			}
 			for i := 0; i < 12; i++ {
 				mid := (lo + hi) / 2
-				r.fontScale = mid
-				mw := r.measureMaxLineWidth(s.paragraphs, tw, wordWrap)
+				m.fontScale = mid
+				mw := m.measureMaxLineWidth(s.paragraphs, tw, wordWrap)
 				if mw > hTolerance {
 					hi = mid
 				} else {
 					lo = mid
 				}
 			}
-			r.fontScale = lo
+			m.fontScale = lo
 		}
 	}
 
+	return m.fontScale
+}
+
+func (r *renderer) renderRichText(s *RichTextShape) {
+	x := r.emuToPixelX(s.offsetX)
+	y := r.emuToPixelY(s.offsetY)
+	w := r.emuToPixelX(s.width)
+	h := r.emuToPixelY(s.height)
+	rotation := s.GetRotation()
+	flipH := s.GetFlipHorizontal()
+	flipV := s.GetFlipVertical()
+
+	// Apply normAutofit font scale
+	prevFontScale := r.fontScale
+	if s.fontScale > 0 && s.fontScale != 100000 {
+		r.fontScale = float64(s.fontScale) / 100000.0
+	}
+	defer func() { r.fontScale = prevFontScale }()
+
+	// Vertical text direction adds implicit rotation
+	vertRotation := verticalTextRotation(s.textDirection)
+
+	// spAutoFit: shape resizes to fit text. When the shape has word-wrap
+	// enabled, PowerPoint expands the shape vertically while keeping the
+	// width fixed. We cannot resize the shape at render time, but we
+	// should still honour word-wrap so text wraps within the available
+	// width instead of overflowing horizontally and overlapping adjacent
+	// shapes. Only disable word-wrap when the original shape had it off
+	// (rare case where the box expands horizontally).
+	wordWrap := s.wordWrap
+
+	pxL, _, pxT, pxB, tw, th := r.richTextArea(s, w, h)
+	r.fontScale = r.richTextFontScale(s)
+
+	// Estimate total text height to detect overflow.
+	// PowerPoint does not clip text to the text box boundary, so we must
+	// expand the rendering buffer when text overflows.
 	textH := r.measureParagraphsHeight(s.paragraphs, tw, th, s.textAnchor, wordWrap)
 	// Extra height needed beyond the shape box
 	overflowH := 0
@@ -781,6 +2171,12 @@ func (r *renderer) renderRichText(s *RichTextShape) {
 	}
 	// Use expanded height for the temp buffer when rotated
 	bufH := h + overflowH
+	// Lines that do not wrap may overflow the box sideways (centred lines
+	// on both sides, as in PowerPoint); widen the buffer to keep them.
+	padX := 0
+	if !wordWrap && vertRotation == 0 && (rotation != 0 || flipH || flipV) {
+		padX = maxInt(r.measureMaxLineWidth(s.paragraphs, tw, wordWrap)-tw, 0)
+	}
 
 	// skipText is used to split geometry and text rendering when flip is set.
 	// PowerPoint flips shape geometry but keeps text readable (un-flipped).
@@ -793,30 +2189,60 @@ func (r *renderer) renderRichText(s *RichTextShape) {
 		}
 		rect := image.Rect(ox, oy, ox+w, oy+h)
 
-		// Shadow BEFORE fill (so shadow appears behind)
-		if s.shadow != nil && s.shadow.Visible {
-			tr.renderShadow(s.shadow, rect)
-		}
-		if s.customPath != nil {
-			tr.renderCustomPathFill(s.customPath, s.fill, ox, oy, w, h)
+		if geom := s.geometryShape(); geom != nil {
+			// Preset geometry: draw the box like the equivalent AutoShape.
+			tr.renderAutoShapeShadow(geom, rect)
+			tr.renderAutoShapeFill(geom, ox, oy, w, h)
+			tr.renderAutoShapeBorder(geom, ox, oy, w, h)
 		} else {
-			tr.renderFill(s.fill, rect)
-		}
-		if s.border != nil && s.border.Style != BorderNone {
-			pw := maxInt(int(float64(maxInt(s.border.Width, 1))*12700.0*tr.scaleX), 1)
+			// Shadow BEFORE fill (so shadow appears behind)
+			if s.shadow != nil && s.shadow.Visible {
+				tr.renderShadow(s.shadow, rect)
+			}
 			if s.customPath != nil {
-				// Draw border along the custom geometry path
+				tr.renderCustomPathFill(s.customPath, tr.resolveFill(s.fill), ox, oy, w, h)
+			} else {
+				tr.renderFill(tr.resolveFill(s.fill), rect)
+			}
+			if s.border != nil && s.border.Style != BorderNone {
+				pw := maxInt(int(float64(maxInt(s.border.Width, 1))*12700.0*tr.scaleX), 1)
+				if s.customPath != nil {
+					// Draw border along the custom geometry path
+					pts := tr.customPathToPixelPoints(s.customPath, ox, oy, w, h)
+					bc := r.rgba(s.border.Color)
+					if len(pts) >= 2 {
+						if dashes := dashArray(s.border.Style, s.border.DashPattern, pw); dashes != nil {
+							tr.drawDashedPolylineAA(pts, bc, pw, dashes)
+						} else if !tr.strokeJoinedPolyline(pts, s.customPath.closed(), bc, pw, s.border.Join, s.border.MiterLimit) {
+							for i := 1; i < len(pts); i++ {
+								tr.drawLineAA(int(pts[i-1].x), int(pts[i-1].y), int(pts[i].x), int(pts[i].y), bc, pw)
+							}
+						}
+						// Draw arrowheads at the ends of the custom path
+						intPts := make([][2]int, len(pts))
+						for i, p := range pts {
+							intPts[i] = [2]int{int(p.x), int(p.y)}
+						}
+						if s.headEnd != nil && s.headEnd.Type != ArrowNone && s.headEnd.Type != "" {
+							tr.drawArrowOnPath(intPts[0][0], intPts[0][1], intPts, bc, pw, s.headEnd)
+						}
+						if s.tailEnd != nil && s.tailEnd.Type != ArrowNone && s.tailEnd.Type != "" {
+							last := intPts[len(intPts)-1]
+							tr.drawArrowOnPath(last[0], last[1], intPts, bc, pw, s.tailEnd)
+						}
+					}
+				} else {
+					tr.drawRectBorder(rect, r.rgba(s.border.Color), pw, s.border.Style, s.border.DashPattern, s.border.Join)
+				}
+			} else if s.customPath != nil && (s.headEnd != nil || s.tailEnd != nil) {
+				// No visible border but has arrowheads — still need to draw them along the path
 				pts := tr.customPathToPixelPoints(s.customPath, ox, oy, w, h)
-				bc := argbToRGBA(s.border.Color)
 				if len(pts) >= 2 {
-					if s.border.Style == BorderDash || s.border.Style == BorderDot {
-						tr.drawDashedPolylineAA(pts, bc, pw, s.border.Style)
-					} else {
-						for i := 1; i < len(pts); i++ {
-							tr.drawLineAA(int(pts[i-1].x), int(pts[i-1].y), int(pts[i].x), int(pts[i].y), bc, pw)
-						}
+					pw := maxInt(int(tr.scaleX*12700.0), 1)
+					bc := color.RGBA{A: 255} // default black
+					if s.border != nil {
+						bc = r.rgba(s.border.Color)
 					}
-					// Draw arrowheads at the ends of the custom path
 					intPts := make([][2]int, len(pts))
 					for i, p := range pts {
 						intPts[i] = [2]int{int(p.x), int(p.y)}
@@ -829,29 +2255,6 @@ func (r *renderer) renderRichText(s *RichTextShape) {
 						tr.drawArrowOnPath(last[0], last[1], intPts, bc, pw, s.tailEnd)
 					}
 				}
-			} else {
-				tr.drawRectBorder(rect, argbToRGBA(s.border.Color), pw, s.border.Style)
-			}
-		} else if s.customPath != nil && (s.headEnd != nil || s.tailEnd != nil) {
-			// No visible border but has arrowheads — still need to draw them along the path
-			pts := tr.customPathToPixelPoints(s.customPath, ox, oy, w, h)
-			if len(pts) >= 2 {
-				pw := maxInt(int(tr.scaleX*12700.0), 1)
-				bc := color.RGBA{A: 255} // default black
-				if s.border != nil {
-					bc = argbToRGBA(s.border.Color)
-				}
-				intPts := make([][2]int, len(pts))
-				for i, p := range pts {
-					intPts[i] = [2]int{int(p.x), int(p.y)}
-				}
-				if s.headEnd != nil && s.headEnd.Type != ArrowNone && s.headEnd.Type != "" {
-					tr.drawArrowOnPath(intPts[0][0], intPts[0][1], intPts, bc, pw, s.headEnd)
-				}
-				if s.tailEnd != nil && s.tailEnd.Type != ArrowNone && s.tailEnd.Type != "" {
-					last := intPts[len(intPts)-1]
-					tr.drawArrowOnPath(last[0], last[1], intPts, bc, pw, s.tailEnd)
-				}
 			}
 		}
 
@@ -883,13 +2286,16 @@ func (r *renderer) renderRichText(s *RichTextShape) {
 				// For vertical text, draw into a rotated buffer with swapped dimensions.
 				vtw, vth := drawTH, tw // text area: width=drawTH, height=tw (before rotation)
 				if vtw > 0 && vth > 0 {
-					tmp := image.NewRGBA(image.Rect(0, 0, vtw, vth))
-					tmpR := &renderer{img: tmp, scaleX: tr.scaleX, scaleY: tr.scaleY, fontCache: tr.fontCache, dpi: tr.dpi, fontScale: tr.fontScale}
-					tmpR.drawParagraphs(s.paragraphs, 0, 0, vtw, vth, s.textAnchor, wordWrap)
+					tmp := getRGBA(image.Rect(0, 0, vtw, vth))
+					tmpR := tr.withImage(tmp)
+					tmpR.vertText = s.textDirection
+					tmpR.svgText = tr.svgText.transformed(float64(tx)+float64(tw)/2, float64(ty)+float64(drawTH)/2, float64(vtw)/2, float64(vth)/2, vertRotation, false, false)
+					tmpR.drawParagraphs(s.paragraphs, 0, 0, vtw, vth, s.textAnchor, s.anchorCtr, wordWrap)
 					rotateAndComposite(tr.img, tmp, tx, ty, tw, drawTH, vertRotation)
+					putRGBA(tmp)
 				}
 			} else {
-				tr.drawParagraphs(s.paragraphs, tx, ty, tw, drawTH, s.textAnchor, wordWrap)
+				tr.drawParagraphs(s.paragraphs, tx, ty, tw, drawTH, s.textAnchor, s.anchorCtr, wordWrap)
 			}
 		}
 	}
@@ -900,7 +2306,7 @@ func (r *renderer) renderRichText(s *RichTextShape) {
 	if (flipH || flipV) && len(s.paragraphs) > 0 {
 		// Phase 1: render geometry only (with flip)
 		skipText = true
-		r.renderRotatedExpanded(x, y, w, h, bufH, rotation, flipH, flipV, drawContent)
+		r.renderRotatedPadded(x, y, w, h, padX, bufH, rotation, flipH, flipV, drawContent)
 		// Phase 2: render text only (rotation only, no flip)
 		skipText = false
 		textOnly := func(tr *renderer) {
@@ -924,22 +2330,25 @@ func (r *renderer) renderRichText(s *RichTextShape) {
 			if vertRotation != 0 {
 				vtw, vth := drawTH, tw
 				if vtw > 0 && vth > 0 {
-					tmp := image.NewRGBA(image.Rect(0, 0, vtw, vth))
-					tmpR := &renderer{img: tmp, scaleX: tr.scaleX, scaleY: tr.scaleY, fontCache: tr.fontCache, dpi: tr.dpi, fontScale: tr.fontScale}
-					tmpR.drawParagraphs(s.paragraphs, 0, 0, vtw, vth, s.textAnchor, wordWrap)
+					tmp := getRGBA(image.Rect(0, 0, vtw, vth))
+					tmpR := tr.withImage(tmp)
+					tmpR.vertText = s.textDirection
+					tmpR.svgText = tr.svgText.transformed(float64(tx)+float64(tw)/2, float64(ty)+float64(drawTH)/2, float64(vtw)/2, float64(vth)/2, vertRotation, false, false)
+					tmpR.drawParagraphs(s.paragraphs, 0, 0, vtw, vth, s.textAnchor, s.anchorCtr, wordWrap)
 					rotateAndComposite(tr.img, tmp, tx, ty, tw, drawTH, vertRotation)
+					putRGBA(tmp)
 				}
 			} else {
-				tr.drawParagraphs(s.paragraphs, tx, ty, tw, drawTH, s.textAnchor, wordWrap)
+				tr.drawParagraphs(s.paragraphs, tx, ty, tw, drawTH, s.textAnchor, s.anchorCtr, wordWrap)
 			}
 		}
 		if rotation != 0 {
-			r.renderRotatedExpanded(x, y, w, h, bufH, rotation, false, false, textOnly)
+			r.renderRotatedPadded(x, y, w, h, padX, bufH, rotation, false, false, textOnly)
 		} else {
 			textOnly(r)
 		}
 	} else if rotation != 0 {
-		r.renderRotatedExpanded(x, y, w, h, bufH, rotation, false, false, drawContent)
+		r.renderRotatedPadded(x, y, w, h, padX, bufH, rotation, false, false, drawContent)
 	} else {
 		drawContent(r)
 	}
@@ -958,6 +2367,14 @@ func (r *renderer) renderDrawing(s *DrawingShape) {
 		}
 	}
 	if len(imgData) == 0 {
+		if s.externalURL != "" {
+			// Linked picture that is not in the package: draw a frame with a
+			// cross where the image would be.
+			gray := color.RGBA{R: 200, G: 200, B: 200, A: 255}
+			r.drawRect(image.Rect(x, y, x+w, y+h), gray, 1)
+			r.drawLineAA(x, y, x+w-1, y+h-1, gray, 1)
+			r.drawLineAA(x+w-1, y, x, y+h-1, gray, 1)
+		}
 		return
 	}
 
@@ -974,15 +2391,18 @@ func (r *renderer) renderDrawing(s *DrawingShape) {
 		return
 	}
 
-	// Apply srcRect crop if set (values are in 1/1000 of a percent)
-	if s.cropLeft > 0 || s.cropTop > 0 || s.cropRight > 0 || s.cropBottom > 0 {
+	// Apply srcRect crop if set (values are in 1/1000 of a percent). A
+	// negative crop extends the source past the picture's edge, leaving a
+	// transparent margin between the picture and its frame.
+	if s.cropLeft != 0 || s.cropTop != 0 || s.cropRight != 0 || s.cropBottom != 0 {
 		bounds := srcImg.Bounds()
 		imgW := bounds.Dx()
 		imgH := bounds.Dy()
-		cx0 := int(float64(imgW) * float64(s.cropLeft) / 100000.0)
-		cy0 := int(float64(imgH) * float64(s.cropTop) / 100000.0)
-		cx1 := imgW - int(float64(imgW)*float64(s.cropRight)/100000.0)
-		cy1 := imgH - int(float64(imgH)*float64(s.cropBottom)/100000.0)
+		cx0 := int(math.Round(float64(imgW) * float64(s.cropLeft) / 100000.0))
+		cy0 := int(math.Round(float64(imgH) * float64(s.cropTop) / 100000.0))
+		cx1 := imgW - int(math.Round(float64(imgW)*float64(s.cropRight)/100000.0))
+		cy1 := imgH - int(math.Round(float64(imgH)*float64(s.cropBottom)/100000.0))
+		// A crop that leaves nothing of the picture is ignored.
 		if cx1 > cx0 && cy1 > cy0 {
 			cropped := image.NewRGBA(image.Rect(0, 0, cx1-cx0, cy1-cy0))
 			draw.Draw(cropped, cropped.Bounds(), srcImg, image.Pt(bounds.Min.X+cx0, bounds.Min.Y+cy0), draw.Src)
@@ -990,43 +2410,367 @@ func (r *renderer) renderDrawing(s *DrawingShape) {
 		}
 	}
 
+	// clrChange matches exact source pixels, so apply it before resampling
+	// blends the matched color into its neighbours.
+	if cc := s.clrChange; cc != nil {
+		changed := image.NewRGBA(image.Rect(0, 0, srcImg.Bounds().Dx(), srcImg.Bounds().Dy()))
+		draw.Draw(changed, changed.Bounds(), srcImg, srcImg.Bounds().Min, draw.Src)
+		applyColorChange(changed, r.rgba(cc.From), r.rgba(cc.To), cc.UseAlpha)
+		srcImg = changed
+	}
+
+	// Pixelated pictures keep hard edges and equal modules: they snap to a
+	// whole scale more readily and are enlarged by repeating pixels.
+	filter := r.imageFilter
+	srcW, srcH := srcImg.Bounds().Dx(), srcImg.Bounds().Dy()
+	pixelated := r.pixelatedPicture(s, srcImg)
+	snap := r.imageScaleSnap
+	if pixelated {
+		snap = math.Max(snap, pixelatedScaleSnap)
+	}
+	if sw, sh, ok := snapImageScale(srcW, srcH, w, h, snap); ok {
+		x += (w - sw) / 2
+		y += (h - sh) / 2
+		w, h = sw, sh
+		filter = ImageFilterAreaAverage
+		if w >= srcW {
+			filter = ImageFilterNearest
+		}
+	} else if pixelated && w >= srcW && h >= srcH {
+		filter = ImageFilterNearest
+	}
+
 	rotation := s.GetRotation()
 	flipH := s.GetFlipHorizontal()
 	flipV := s.GetFlipVertical()
+	frame := r.pictureFrame(s, w, h)
+	mask := r.frameMask(w, h, frame)
+	// The reflection is drawn below the picture as it appears, so a
+	// vertical flip is applied to the picture itself rather than to the
+	// buffer holding both.
+	flipReflected := s.reflection != nil && flipV
+	if flipReflected {
+		flipV = false
+	}
 
 	drawImg := func(tr *renderer) {
 		ox, oy := x, y
 		if tr != r {
 			ox, oy = 0, 0
 		}
-		scaledImg := scaleImageBilinear(srcImg, w, h)
+		rect := image.Rect(ox, oy, ox+w, oy+h)
+		tr.drawPictureShadow(frame, rect)
+		scaledImg := scaleImageFiltered(srcImg, w, h, filter)
+		if frame.gray {
+			applyGrayscale(scaledImg)
+		}
+		// Recolor first, then fade: both work on the premultiplied pixels, so the
+		// image's own alpha channel is preserved by the duotone and multiplied by
+		// alphaModFix, as PowerPoint stacks them.
+		if len(s.duotone) == 2 {
+			applyDuotone(scaledImg, r.rgba(s.duotone[0]), r.rgba(s.duotone[1]))
+		}
 		// Apply alphaModFix opacity if set (value is in 1/1000 of a percent, e.g. 5000 = 5%)
-		if s.alpha > 0 && s.alpha < 100000 {
-			alphaScale := float64(s.alpha) / 100000.0
-			bounds := scaledImg.Bounds()
-			for py := bounds.Min.Y; py < bounds.Max.Y; py++ {
-				for px := bounds.Min.X; px < bounds.Max.X; px++ {
-					c := scaledImg.RGBAAt(px, py)
-					// Scale all channels (premultiplied alpha format)
-					c.R = uint8(float64(c.R) * alphaScale)
-					c.G = uint8(float64(c.G) * alphaScale)
-					c.B = uint8(float64(c.B) * alphaScale)
-					c.A = uint8(float64(c.A) * alphaScale)
-					scaledImg.SetRGBA(px, py, c)
-				}
+		if op := s.opacity(); op < 1 {
+			// Scale all channels (premultiplied alpha format)
+			scale := uint32(math.Round(op * 65536))
+			for i, v := range scaledImg.Pix {
+				scaledImg.Pix[i] = uint8((uint32(v)*scale + 32768) >> 16)
 			}
 		}
-		draw.Draw(tr.img, image.Rect(ox, oy, ox+w, oy+h), scaledImg, image.Point{}, draw.Over)
+		if mask != nil {
+			applyFrameMask(scaledImg, mask)
+		}
+		if flipReflected {
+			flipRGBAVertical(scaledImg)
+		}
+		draw.Draw(tr.img, rect, scaledImg, image.Point{}, draw.Over)
+		tr.drawPictureBorder(frame, rect)
+		if s.reflection != nil {
+			tr.drawReflection(scaledImg, rect, s.reflection)
+		}
 	}
 
 	if rotation != 0 || flipH || flipV {
-		r.renderRotated(x, y, w, h, rotation, flipH, flipV, drawImg)
+		gap, _, shown := r.reflectionSize(s.reflection, h)
+		r.renderRotatedExpanded(x, y, w, h, h+gap+shown, rotation, flipH, flipV, drawImg)
 	} else {
 		drawImg(r)
 	}
 }
 
+// pixelatedPicture reports whether picture s, decoded as img, is drawn with
+// hard pixel edges: it is marked so, or it is two-tone and
+// RenderOptions.SharpBitonalImages is set.
+func (r *renderer) pixelatedPicture(s *DrawingShape, img image.Image) bool {
+	return s.pixelated || (r.sharpBitonal && isBitonalImage(img))
+}
+
+// reflectionSize returns the gap between a picture h pixels high and its
+// reflection, the height of the mirrored picture and how much of it is
+// shown, which ends at EndPosition.
+func (r *renderer) reflectionSize(ref *Reflection, h int) (gap, full, shown int) {
+	if ref == nil || ref.ScaleY == 0 {
+		return 0, 0, 0
+	}
+	gap = int(math.Round(float64(ref.Distance) * r.scaleY))
+	full = int(math.Round(float64(h) * math.Abs(float64(ref.ScaleY)) / 100000))
+	shown = minInt(int(math.Ceil(float64(full)*float64(ref.EndPosition)/100000)), full)
+	return gap, full, shown
+}
+
+// drawReflection draws the reflection of pic, a picture drawn at rect,
+// below it: scaled (and mirrored for a negative ScaleY) vertically, faded
+// from StartAlpha to EndAlpha along its height and blurred.
+func (r *renderer) drawReflection(pic *image.RGBA, rect image.Rectangle, ref *Reflection) {
+	w, h := rect.Dx(), rect.Dy()
+	gap, full, shown := r.reflectionSize(ref, h)
+	if w <= 0 || h <= 0 || shown <= 0 {
+		return
+	}
+	alphaAt := func(pos float64) float64 {
+		st, end := float64(ref.StartPosition), float64(ref.EndPosition)
+		switch {
+		case pos <= st:
+			return float64(ref.StartAlpha) / 100000
+		case pos >= end:
+			return float64(ref.EndAlpha) / 100000
+		}
+		t := (pos - st) / (end - st)
+		return (float64(ref.StartAlpha) + t*float64(ref.EndAlpha-ref.StartAlpha)) / 100000
+	}
+	refl := image.NewRGBA(image.Rect(0, 0, w, shown))
+	for j := 0; j < shown; j++ {
+		a := math.Max(0, math.Min(1, alphaAt((float64(j)+0.5)/float64(full)*100000)))
+		if a == 0 {
+			continue
+		}
+		sy := minInt(j*h/full, h-1)
+		if ref.ScaleY < 0 {
+			sy = h - 1 - sy
+		}
+		src := pic.Pix[sy*pic.Stride : sy*pic.Stride+w*4]
+		dst := refl.Pix[j*refl.Stride : j*refl.Stride+w*4]
+		for i, v := range src {
+			dst[i] = uint8(float64(v)*a + 0.5)
+		}
+	}
+	if blur := int(math.Round(float64(ref.BlurRadius) * r.scaleX)); blur > 0 {
+		boxBlurRGBA(refl, blur)
+	}
+	top := rect.Max.Y + gap
+	draw.Draw(r.img, image.Rect(rect.Min.X, top, rect.Max.X, top+shown), refl, image.Point{}, draw.Over)
+}
+
+// flipRGBAHorizontal mirrors img left to right in place.
+func flipRGBAHorizontal(img *image.RGBA) {
+	b := img.Bounds()
+	for y := 0; y < b.Dy(); y++ {
+		row := img.Pix[y*img.Stride : y*img.Stride+b.Dx()*4]
+		for x0, x1 := 0, len(row)-4; x0 < x1; x0, x1 = x0+4, x1-4 {
+			for c := 0; c < 4; c++ {
+				row[x0+c], row[x1+c] = row[x1+c], row[x0+c]
+			}
+		}
+	}
+}
+
+// flipRGBAVertical mirrors img top to bottom in place.
+func flipRGBAVertical(img *image.RGBA) {
+	b := img.Bounds()
+	n := b.Dx() * 4
+	for y0, y1 := 0, b.Dy()-1; y0 < y1; y0, y1 = y0+1, y1-1 {
+		row0 := img.Pix[y0*img.Stride : y0*img.Stride+n]
+		row1 := img.Pix[y1*img.Stride : y1*img.Stride+n]
+		for i := range row0 {
+			row0[i], row1[i] = row1[i], row0[i]
+		}
+	}
+}
+
+// boxBlurRGBA blurs img in place with two passes of a box filter of the
+// given radius in each direction, which approximates a Gaussian blur.
+func boxBlurRGBA(img *image.RGBA, radius int) {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	line := make([]int, 4*maxInt(w, h))
+	// pass blurs n pixels step bytes apart starting at off.
+	pass := func(off, step, n int) {
+		for i := 0; i < n; i++ {
+			for c := 0; c < 4; c++ {
+				line[i*4+c] = int(img.Pix[off+i*step+c])
+			}
+		}
+		for c := 0; c < 4; c++ {
+			sum := 0
+			for i := -radius; i <= radius; i++ {
+				sum += line[minInt(maxInt(i, 0), n-1)*4+c]
+			}
+			for i := 0; i < n; i++ {
+				img.Pix[off+i*step+c] = uint8(sum / (2*radius + 1))
+				sum += line[minInt(i+radius+1, n-1)*4+c] - line[maxInt(i-radius, 0)*4+c]
+			}
+		}
+	}
+	for k := 0; k < 2; k++ {
+		for y := 0; y < h; y++ {
+			pass(y*img.Stride, 4, w)
+		}
+		for x := 0; x < w; x++ {
+			pass(x*4, img.Stride, h)
+		}
+	}
+}
+
+// autoShapeTextArea returns the text area of s drawn in a w×h pixel box,
+// relative to the box. Default insets give way when the text overflows.
+func (r *renderer) autoShapeTextArea(s *AutoShape, w, h int) image.Rectangle {
+	// Compute text area with insets
+	lIns, rIns, tIns, bIns := int64(91440), int64(91440), int64(45720), int64(45720)
+	if s.insetsSet {
+		lIns, rIns, tIns, bIns = s.insetLeft, s.insetRight, s.insetTop, s.insetBottom
+	}
+	pxL := r.emuToPixelX(lIns)
+	pxR := r.emuToPixelX(rIns)
+	pxT := r.emuToPixelY(tIns)
+	pxB := r.emuToPixelY(bIns)
+
+	// Clamp default insets when they consume too much of the shape dimensions.
+	if !s.insetsSet {
+		maxInsetH := int(float64(h) * 0.35)
+		maxInsetW := int(float64(w) * 0.35)
+		if pxT+pxB > maxInsetH {
+			scale := float64(maxInsetH) / float64(pxT+pxB)
+			pxT = int(float64(pxT) * scale)
+			pxB = int(float64(pxB) * scale)
+		}
+		if pxL+pxR > maxInsetW {
+			scale := float64(maxInsetW) / float64(pxL+pxR)
+			pxL = int(float64(pxL) * scale)
+			pxR = int(float64(pxR) * scale)
+		}
+	}
+
+	tx, ty, tw, th := pxL, pxT, w-pxL-pxR, h-pxT-pxB
+
+	// For ellipses, further constrain text to the inscribed rectangle
+	// The inscribed rect of an ellipse insets by factor (1 - 1/√2) ≈ 0.2929
+	if s.shapeType == AutoShapeEllipse {
+		insetX := int(float64(w) * 0.1464) // half of 0.2929
+		insetY := int(float64(h) * 0.1464)
+		etx := insetX
+		ety := insetY
+		etw := w - 2*insetX
+		eth := h - 2*insetY
+		// Use the tighter of explicit insets vs ellipse inscribed rect
+		if etx > tx {
+			tx = etx
+		}
+		if ety > ty {
+			ty = ety
+		}
+		if etx+etw < pxL+tw {
+			tw = etx + etw - tx
+		}
+		if ety+eth < pxT+th {
+			th = ety + eth - ty
+		}
+	}
+
+	if tw < 1 {
+		tw = w
+	}
+	if th < 1 {
+		th = h
+	}
+
+	// When default insets are used and text overflows, reduce insets
+	// to make room. This handles font metric differences between systems.
+	if !s.insetsSet {
+		textH := r.measureParagraphsHeight(s.paragraphs, tw, th, s.textAnchor, true)
+		if textH > th && th > 0 && (pxT+pxB) > 0 {
+			needed := textH - th
+			avail := pxT + pxB
+			if needed >= avail {
+				pxT = 0
+				pxB = 0
+			} else {
+				sc := float64(avail-needed) / float64(avail)
+				pxT = int(float64(pxT) * sc)
+				pxB = int(float64(pxB) * sc)
+			}
+			tx = pxL
+			ty = pxT
+			th = h - pxT - pxB
+			if th < 1 {
+				th = h
+			}
+		}
+	}
+	return image.Rect(tx, ty, tx+tw, ty+th)
+}
+
+// autoShapeFontScale returns the font scale the text of s is drawn at,
+// shrunk from r.fontScale when the text overflows the shape. Like
+// richTextFontScale it measures at autofitPxPerEMU.
+func (r *renderer) autoShapeFontScale(s *AutoShape) float64 {
+	m := r.atAutofitScale()
+	h := m.emuToPixelY(s.height)
+	area := m.autoShapeTextArea(s, m.emuToPixelX(s.width), h)
+	tw, th := area.Dx(), area.Dy()
+
+	// Auto-shrink when text overflows the full shape height —
+	// CJK font metrics in Go are often larger than PowerPoint's.
+	// Use a conservative floor to avoid making text too small.
+	if (s.fontScale == 0 || s.fontScale == 100000) {
+		atextH := m.measureParagraphsHeight(s.paragraphs, tw, th, s.textAnchor, true)
+		if atextH > h && h > 0 && atextH > th && th > 0 {
+			lo, hi := 0.65, 1.0
+			for i := 0; i < 10; i++ {
+				mid := (lo + hi) / 2
+				m.fontScale = mid
+				mh := m.measureParagraphsHeight(s.paragraphs, tw, th, s.textAnchor, true)
+				if mh > th {
+					hi = mid
+				} else {
+					lo = mid
+				}
+			}
+			m.fontScale = lo
+		}
+	}
+
+	// Horizontal overflow: shrink font when wrapped lines still
+	// exceed the text area width due to font metric differences.
+	// Apply the same 3% tolerance used by wrapRunLine.
+	if tw > 0 && (s.fontScale == 0 || s.fontScale == 100000) {
+		hTol := tw * 103 / 100
+		maxLW := m.measureMaxLineWidth(s.paragraphs, tw, true)
+		if maxLW > hTol {
+			lo, hi := 0.5, m.fontScale
+			if hi <= 0 {
+				hi = 1.0
+			}
+			for i := 0; i < 12; i++ {
+				mid := (lo + hi) / 2
+				m.fontScale = mid
+				mw := m.measureMaxLineWidth(s.paragraphs, tw, true)
+				if mw > hTol {
+					hi = mid
+				} else {
+					lo = mid
+				}
+			}
+			m.fontScale = lo
+		}
+	}
+	return m.fontScale
+}
+
 func (r *renderer) renderAutoShape(s *AutoShape) {
+	if s.text == "" && len(s.paragraphs) == 0 && r.renderSubPixelShape(&s.BaseShape) {
+		return
+	}
 	x := r.emuToPixelX(s.offsetX)
 	y := r.emuToPixelY(s.offsetY)
 	w := r.emuToPixelX(s.width)
@@ -1041,14 +2785,16 @@ func (r *renderer) renderAutoShape(s *AutoShape) {
 		r.fontScale = float64(s.fontScale) / 100000.0
 	}
 	defer func() { r.fontScale = prevFontScale }()
+	// The text area is laid out at the shape's own font scale, before any
+	// shrinking.
+	var textArea image.Rectangle
+	if len(s.paragraphs) > 0 {
+		textArea = r.autoShapeTextArea(s, w, h)
+		r.fontScale = r.autoShapeFontScale(s)
+	}
 
 	// Vertical text direction
-	vertRotation := 0
-	if s.textDirection == "vert" || s.textDirection == "eaVert" || s.textDirection == "wordArtVert" {
-		vertRotation = 270
-	} else if s.textDirection == "vert270" {
-		vertRotation = 90
-	}
+	vertRotation := verticalTextRotation(s.textDirection)
 
 	drawContent := func(tr *renderer) {
 		ox, oy := x, y
@@ -1056,27 +2802,7 @@ func (r *renderer) renderAutoShape(s *AutoShape) {
 			ox, oy = 0, 0
 		}
 		rect := image.Rect(ox, oy, ox+w, oy+h)
-		if s.shadow != nil && s.shadow.Visible {
-			switch s.shapeType {
-			case AutoShapeRoundedRect, AutoShapeCallout1:
-				sRadius := minInt(w, h) * 16667 / 100000
-				if s.adjustValues != nil {
-					if adj, ok := s.adjustValues["adj"]; ok {
-						sRadius = minInt(w, h) * adj / 200000
-					}
-					if adj, ok := s.adjustValues["adj3"]; ok && s.shapeType == AutoShapeCallout1 {
-						sRadius = int(math.Min(float64(w), float64(h)) * float64(adj) / 100000.0)
-					}
-				}
-				tr.renderShadowRounded(s.shadow, rect, sRadius)
-			case AutoShapeRectangle, "":
-				tr.renderShadow(s.shadow, rect)
-			default:
-				// For non-rectangular shapes (arrows, triangles, ellipses, etc.),
-				// skip the rectangular shadow — it would fill the entire
-				// bounding box and look like a gray background.
-			}
-		}
+		tr.renderAutoShapeShadow(s, rect)
 		tr.renderAutoShapeFill(s, ox, oy, w, h)
 		tr.renderAutoShapeBorder(s, ox, oy, w, h)
 		// Arc shapes are stroke-only; if no explicit border was set, draw
@@ -1087,145 +2813,22 @@ func (r *renderer) renderAutoShape(s *AutoShape) {
 			tr.renderArcBorder(s, ox, oy, w, h, defC, defPw)
 		}
 		if len(s.paragraphs) > 0 {
-			// Compute text area with insets
-			lIns, rIns, tIns, bIns := int64(91440), int64(91440), int64(45720), int64(45720)
-			if s.insetsSet {
-				lIns, rIns, tIns, bIns = s.insetLeft, s.insetRight, s.insetTop, s.insetBottom
-			}
-			pxL := r.emuToPixelX(lIns)
-			pxR := r.emuToPixelX(rIns)
-			pxT := r.emuToPixelY(tIns)
-			pxB := r.emuToPixelY(bIns)
-
-			// Clamp default insets when they consume too much of the shape dimensions.
-			if !s.insetsSet {
-				maxInsetH := int(float64(h) * 0.35)
-				maxInsetW := int(float64(w) * 0.35)
-				if pxT+pxB > maxInsetH {
-					scale := float64(maxInsetH) / float64(pxT+pxB)
-					pxT = int(float64(pxT) * scale)
-					pxB = int(float64(pxB) * scale)
-				}
-				if pxL+pxR > maxInsetW {
-					scale := float64(maxInsetW) / float64(pxL+pxR)
-					pxL = int(float64(pxL) * scale)
-					pxR = int(float64(pxR) * scale)
-				}
-			}
-
-			tx, ty, tw, th := ox+pxL, oy+pxT, w-pxL-pxR, h-pxT-pxB
-
-			// For ellipses, further constrain text to the inscribed rectangle
-			// The inscribed rect of an ellipse insets by factor (1 - 1/√2) ≈ 0.2929
-			if s.shapeType == AutoShapeEllipse {
-				insetX := int(float64(w) * 0.1464) // half of 0.2929
-				insetY := int(float64(h) * 0.1464)
-				etx := ox + insetX
-				ety := oy + insetY
-				etw := w - 2*insetX
-				eth := h - 2*insetY
-				// Use the tighter of explicit insets vs ellipse inscribed rect
-				if etx > tx {
-					tx = etx
-				}
-				if ety > ty {
-					ty = ety
-				}
-				if etx+etw < ox+pxL+tw {
-					tw = etx + etw - tx
-				}
-				if ety+eth < oy+pxT+th {
-					th = ety + eth - ty
-				}
-			}
-
-			if tw < 1 {
-				tw = w
-			}
-			if th < 1 {
-				th = h
-			}
-
-			// When default insets are used and text overflows, reduce insets
-			// to make room. This handles font metric differences between systems.
-			if !s.insetsSet {
-				textH := r.measureParagraphsHeight(s.paragraphs, tw, th, s.textAnchor, true)
-				if textH > th && th > 0 && (pxT+pxB) > 0 {
-					needed := textH - th
-					avail := pxT + pxB
-					if needed >= avail {
-						pxT = 0
-						pxB = 0
-					} else {
-						sc := float64(avail-needed) / float64(avail)
-						pxT = int(float64(pxT) * sc)
-						pxB = int(float64(pxB) * sc)
-					}
-					tx = ox + pxL
-					ty = oy + pxT
-					th = h - pxT - pxB
-					if th < 1 {
-						th = h
-					}
-				}
-			}
-
-			// Auto-shrink when text overflows the full shape height —
-			// CJK font metrics in Go are often larger than PowerPoint's.
-			// Use a conservative floor to avoid making text too small.
-			if (s.fontScale == 0 || s.fontScale == 100000) {
-				atextH := r.measureParagraphsHeight(s.paragraphs, tw, th, s.textAnchor, true)
-				if atextH > h && h > 0 && atextH > th && th > 0 {
-					lo, hi := 0.65, 1.0
-					for i := 0; i < 10; i++ {
-						mid := (lo + hi) / 2
-						r.fontScale = mid
-						mh := r.measureParagraphsHeight(s.paragraphs, tw, th, s.textAnchor, true)
-						if mh > th {
-							hi = mid
-						} else {
-							lo = mid
-						}
-					}
-					r.fontScale = lo
-				}
-			}
-
-			// Horizontal overflow: shrink font when wrapped lines still
-			// exceed the text area width due to font metric differences.
-			// Apply the same 3% tolerance used by wrapRunLine.
-			if tw > 0 && (s.fontScale == 0 || s.fontScale == 100000) {
-				hTol := tw * 103 / 100
-				maxLW := r.measureMaxLineWidth(s.paragraphs, tw, true)
-				if maxLW > hTol {
-					lo, hi := 0.5, r.fontScale
-					if hi <= 0 {
-						hi = 1.0
-					}
-					for i := 0; i < 12; i++ {
-						mid := (lo + hi) / 2
-						r.fontScale = mid
-						mw := r.measureMaxLineWidth(s.paragraphs, tw, true)
-						if mw > hTol {
-							hi = mid
-						} else {
-							lo = mid
-						}
-					}
-					r.fontScale = lo
-				}
-			}
+			area := textArea.Add(image.Pt(ox, oy))
+			tx, ty, tw, th := area.Min.X, area.Min.Y, area.Dx(), area.Dy()
 
 			if vertRotation != 0 {
 				vtw, vth := th, tw
 				if vtw > 0 && vth > 0 {
-					tmp := image.NewRGBA(image.Rect(0, 0, vtw, vth))
-					tmpR := &renderer{img: tmp, scaleX: tr.scaleX, scaleY: tr.scaleY, fontCache: tr.fontCache, dpi: tr.dpi, fontScale: tr.fontScale}
-					tmpR.drawParagraphs(s.paragraphs, 0, 0, vtw, vth, s.textAnchor, true)
+					tmp := getRGBA(image.Rect(0, 0, vtw, vth))
+					tmpR := tr.withImage(tmp)
+					tmpR.vertText = s.textDirection
+					tmpR.svgText = tr.svgText.transformed(float64(tx)+float64(tw)/2, float64(ty)+float64(th)/2, float64(vtw)/2, float64(vth)/2, vertRotation, false, false)
+					tmpR.drawParagraphs(s.paragraphs, 0, 0, vtw, vth, s.textAnchor, s.anchorCtr, true)
 					rotateAndComposite(tr.img, tmp, tx, ty, tw, th, vertRotation)
+					putRGBA(tmp)
 				}
 			} else {
-				tr.drawParagraphs(s.paragraphs, tx, ty, tw, th, s.textAnchor, true)
+				tr.drawParagraphs(s.paragraphs, tx, ty, tw, th, s.textAnchor, s.anchorCtr, true)
 			}
 		} else if s.text != "" {
 			tr.drawStringCentered(s.text, tr.getFace(NewFont()), color.RGBA{A: 255}, rect)
@@ -1242,8 +2845,8 @@ func (r *renderer) renderAutoShape(s *AutoShape) {
 
 	if needsRtTriSwap {
 		drawSwapped := func(tr *renderer) {
-			if s.fill != nil && s.fill.Type != FillNone {
-				fc := argbToRGBA(s.fill.Color)
+			if fill := tr.resolveFill(s.fill); fill != nil && fill.Type != FillNone {
+				fc := r.rgba(fill.Color)
 				fc = tr.scaleAlpha(fc)
 				// Draw mirror-image triangle that, after correct clockwise
 				// rotation, produces the expected right-triangle orientation.
@@ -1299,138 +2902,78 @@ func (r *renderer) renderAutoShape(s *AutoShape) {
 			if tr != r {
 				ox, oy = 0, 0
 			}
-			lIns, rIns, tIns, bIns := int64(91440), int64(91440), int64(45720), int64(45720)
-			if s.insetsSet {
-				lIns, rIns, tIns, bIns = s.insetLeft, s.insetRight, s.insetTop, s.insetBottom
-			}
-			pxL := r.emuToPixelX(lIns)
-			pxR := r.emuToPixelX(rIns)
-			pxT := r.emuToPixelY(tIns)
-			pxB := r.emuToPixelY(bIns)
-			if !s.insetsSet {
-				maxInsetH := int(float64(h) * 0.35)
-				maxInsetW := int(float64(w) * 0.35)
-				if pxT+pxB > maxInsetH {
-					scale := float64(maxInsetH) / float64(pxT+pxB)
-					pxT = int(float64(pxT) * scale)
-					pxB = int(float64(pxB) * scale)
-				}
-				if pxL+pxR > maxInsetW {
-					scale := float64(maxInsetW) / float64(pxL+pxR)
-					pxL = int(float64(pxL) * scale)
-					pxR = int(float64(pxR) * scale)
-				}
-			}
-			tx, ty, tw, th := ox+pxL, oy+pxT, w-pxL-pxR, h-pxT-pxB
-			if s.shapeType == AutoShapeEllipse {
-				insetX := int(float64(w) * 0.1464)
-				insetY := int(float64(h) * 0.1464)
-				etx := ox + insetX
-				ety := oy + insetY
-				etw := w - 2*insetX
-				eth := h - 2*insetY
-				if etx > tx { tx = etx }
-				if ety > ty { ty = ety }
-				if etx+etw < ox+pxL+tw { tw = etx + etw - tx }
-				if ety+eth < oy+pxT+th { th = ety + eth - ty }
-			}
-			if tw < 1 { tw = w }
-			if th < 1 { th = h }
-			if !s.insetsSet {
-				textH := r.measureParagraphsHeight(s.paragraphs, tw, th, s.textAnchor, true)
-				if textH > th && th > 0 && (pxT+pxB) > 0 {
-					needed := textH - th
-					avail := pxT + pxB
-					if needed >= avail {
-						pxT = 0
-						pxB = 0
-					} else {
-						sc := float64(avail-needed) / float64(avail)
-						pxT = int(float64(pxT) * sc)
-						pxB = int(float64(pxB) * sc)
-					}
-					tx = ox + pxL
-					ty = oy + pxT
-					th = h - pxT - pxB
-					if th < 1 { th = h }
-				}
-			}
-			// Auto-shrink when text overflows
-			if s.fontScale == 0 || s.fontScale == 100000 {
-				atextH := r.measureParagraphsHeight(s.paragraphs, tw, th, s.textAnchor, true)
-				if atextH > h && h > 0 && atextH > th && th > 0 {
-					lo, hi := 0.65, 1.0
-					for i := 0; i < 10; i++ {
-						mid := (lo + hi) / 2
-						r.fontScale = mid
-						mh := r.measureParagraphsHeight(s.paragraphs, tw, th, s.textAnchor, true)
-						if mh > th {
-							hi = mid
-						} else {
-							lo = mid
-						}
-					}
-					r.fontScale = lo
-				}
-				// Horizontal overflow — apply 3% tolerance matching wrapRunLine
-				hTol := tw * 103 / 100
-				maxLW := r.measureMaxLineWidth(s.paragraphs, tw, true)
-				if maxLW > hTol && tw > 0 {
-					lo, hi := 0.5, r.fontScale
-					if hi <= 0 {
-						hi = 1.0
-					}
-					for i := 0; i < 12; i++ {
-						mid := (lo + hi) / 2
-						r.fontScale = mid
-						mw := r.measureMaxLineWidth(s.paragraphs, tw, true)
-						if mw > hTol {
-							hi = mid
-						} else {
-							lo = mid
-						}
-					}
-					r.fontScale = lo
-				}
-			}
+			area := textArea.Add(image.Pt(ox, oy))
+			tx, ty, tw, th := area.Min.X, area.Min.Y, area.Dx(), area.Dy()
+
 			if vertRotation != 0 {
 				vtw, vth := th, tw
 				if vtw > 0 && vth > 0 {
-					tmp := image.NewRGBA(image.Rect(0, 0, vtw, vth))
-					tmpR := &renderer{img: tmp, scaleX: tr.scaleX, scaleY: tr.scaleY, fontCache: tr.fontCache, dpi: tr.dpi, fontScale: tr.fontScale}
-					tmpR.drawParagraphs(s.paragraphs, 0, 0, vtw, vth, s.textAnchor, true)
+					tmp := getRGBA(image.Rect(0, 0, vtw, vth))
+					tmpR := tr.withImage(tmp)
+					tmpR.vertText = s.textDirection
+					tmpR.svgText = tr.svgText.transformed(float64(tx)+float64(tw)/2, float64(ty)+float64(th)/2, float64(vtw)/2, float64(vth)/2, vertRotation, false, false)
+					tmpR.drawParagraphs(s.paragraphs, 0, 0, vtw, vth, s.textAnchor, s.anchorCtr, true)
 					rotateAndComposite(tr.img, tmp, tx, ty, tw, th, vertRotation)
+					putRGBA(tmp)
 				}
 			} else {
-				tr.drawParagraphs(s.paragraphs, tx, ty, tw, th, s.textAnchor, true)
+				tr.drawParagraphs(s.paragraphs, tx, ty, tw, th, s.textAnchor, s.anchorCtr, true)
+			}
+		}
+		if rotation != 0 {
+			r.renderRotated(x, y, w, h, rotation, false, false, drawTextOnly)
+		} else {
+			drawTextOnly(r)
+		}
+	} else if rotation != 0 || flipH || flipV {
+		r.renderRotated(x, y, w, h, rotation, flipH, flipV, drawContent)
+	} else {
+		drawContent(r)
+	}
+}
+
+// renderAutoShapeShadow draws the shadow of a rectangular or rounded shape.
+func (r *renderer) renderAutoShapeShadow(s *AutoShape, rect image.Rectangle) {
+	if s.shadow == nil || !s.shadow.Visible {
+		return
+	}
+	w, h := rect.Dx(), rect.Dy()
+	switch s.shapeType {
+	case AutoShapeRoundedRect, AutoShapeCallout1:
+		sRadius := minInt(w, h) * 16667 / 100000
+		if s.adjustValues != nil {
+			if adj, ok := s.adjustValues["adj"]; ok {
+				sRadius = minInt(w, h) * adj / 200000
+			}
+			if adj, ok := s.adjustValues["adj3"]; ok && s.shapeType == AutoShapeCallout1 {
+				sRadius = int(math.Min(float64(w), float64(h)) * float64(adj) / 100000.0)
 			}
 		}
-		if rotation != 0 {
-			r.renderRotated(x, y, w, h, rotation, false, false, drawTextOnly)
-		} else {
-			drawTextOnly(r)
-		}
-	} else if rotation != 0 || flipH || flipV {
-		r.renderRotated(x, y, w, h, rotation, flipH, flipV, drawContent)
-	} else {
-		drawContent(r)
+		r.renderShadowRounded(s.shadow, rect, sRadius)
+	case AutoShapeRectangle, "":
+		r.renderShadow(s.shadow, rect)
+	default:
+		// For non-rectangular shapes (arrows, triangles, ellipses, etc.),
+		// skip the rectangular shadow — it would fill the entire
+		// bounding box and look like a gray background.
 	}
 }
 
 func (r *renderer) renderAutoShapeFill(s *AutoShape, x, y, w, h int) {
-	if s.fill == nil || s.fill.Type == FillNone {
+	fill := r.resolveFill(s.fill)
+	if fill == nil || fill.Type == FillNone {
 		return
 	}
-	fc := argbToRGBA(s.fill.Color)
+	fc := r.rgba(fill.Color)
 	fc = r.scaleAlpha(fc)
 	rect := image.Rect(x, y, x+w, y+h)
 
 	switch s.shapeType {
 	case AutoShapeEllipse:
-		if s.fill.Type == FillSolid {
+		if fill.Type == FillSolid {
 			r.fillEllipseAA(x, y, w, h, fc)
 		} else {
-			r.fillGradientLinear(rect, s.fill)
+			r.fillGradientLinear(rect, fill)
 		}
 	case AutoShapeRoundedRect:
 		radius := minInt(w, h) * 16667 / 100000
@@ -1439,47 +2982,13 @@ func (r *renderer) renderAutoShapeFill(s *AutoShape, x, y, w, h int) {
 				radius = minInt(w, h) * adj / 200000
 			}
 		}
-		if s.fill.Type == FillSolid {
+		if fill.Type == FillSolid {
 			r.fillRoundedRect(x, y, w, h, radius, fc)
 		} else {
-			r.fillGradientLinear(rect, s.fill)
-		}
-	case AutoShapeTriangle:
-		r.fillTriangle(x, y, w, h, fc)
-	case AutoShapeDiamond:
-		r.fillDiamond(x, y, w, h, fc)
-	case AutoShapeHexagon:
-		r.fillHexagon(x, y, w, h, fc)
-	case AutoShapeFlowchartPreparation:
-		r.fillFlowChartPreparation(x, y, w, h, fc)
-	case AutoShapePentagon:
-		r.fillPentagon(x, y, w, h, fc)
-	case AutoShapeArrowRight:
-		r.fillArrowRight(x, y, w, h, fc)
-	case AutoShapeArrowLeft:
-		r.fillArrowLeft(x, y, w, h, fc)
-	case AutoShapeArrowUp:
-		r.fillArrowUp(x, y, w, h, fc)
-	case AutoShapeArrowDown:
-		r.fillArrowDown(x, y, w, h, fc)
-	case AutoShapeStar5:
-		r.fillStar(x, y, w, h, 5, fc)
-	case AutoShapeStar4:
-		r.fillStar(x, y, w, h, 4, fc)
-	case AutoShapeHeart:
-		r.fillHeart(x, y, w, h, fc)
-	case AutoShapePlus:
-		r.fillPlus(x, y, w, h, fc)
-	case AutoShapeChevron:
-		r.fillChevron(x, y, w, h, fc)
-	case AutoShapeParallelogram:
-		r.fillParallelogram(x, y, w, h, fc)
-	case AutoShapeLeftRightArrow:
-		r.fillLeftRightArrow(x, y, w, h, fc)
+			r.fillGradientLinear(rect, fill)
+		}
 	case AutoShapeRtTriangle:
 		r.fillRtTriangle(x, y, w, h, fc)
-	case AutoShapeHomePlate:
-		r.fillHomePlate(x, y, w, h, fc)
 	case AutoShapeCallout1:
 		r.fillWedgeRoundRectCallout(x, y, w, h, fc, s.adjustValues)
 	case AutoShapeSnip2SameRect:
@@ -1489,10 +2998,21 @@ func (r *renderer) renderAutoShapeFill(s *AutoShape, x, y, w, h int) {
 	case AutoShapeBentArrow:
 		r.fillBentArrow(x, y, w, h, fc, s.adjustValues)
 	case AutoShapeArc:
-		// Arc preset geometry has no fill by default (it's just a stroke).
-		// Skip fill for arc shapes.
+		// The arc's fill path closes through the ellipse centre, so a
+		// filled arc paints the pie slice under the stroke.
+		pts, cx, cy := arcPoints(s, x, y, w, h)
+		pie := append(pts, fpoint{cx, cy})
+		if fill.Type == FillGradientLinear || fill.Type == FillGradientPath {
+			r.fillPolygonGradient(pie, fill)
+		} else {
+			r.fillPolygon(pie, fc)
+		}
+	case AutoShapeRectangle, "":
+		r.renderFill(fill, rect)
 	default:
-		r.renderFill(s.fill, rect)
+		if !r.fillPresetGeometry(s.shapeType, s.adjustValues, fill, x, y, w, h) {
+			r.renderFill(fill, rect)
+		}
 	}
 }
 
@@ -1500,7 +3020,7 @@ func (r *renderer) renderAutoShapeBorder(s *AutoShape, x, y, w, h int) {
 	if s.border == nil || s.border.Style == BorderNone {
 		return
 	}
-	bc := argbToRGBA(s.border.Color)
+	bc := r.rgba(s.border.Color)
 	pw := maxInt(int(float64(maxInt(s.border.Width, 1))*12700.0*r.scaleX), 1)
 
 	switch s.shapeType {
@@ -1513,34 +3033,7 @@ func (r *renderer) renderAutoShapeBorder(s *AutoShape, x, y, w, h int) {
 				radius = minInt(w, h) * adj / 200000
 			}
 		}
-		r.drawRoundedRect(x, y, w, h, radius, bc, pw)
-	case AutoShapeTriangle:
-		r.drawTriangle(x, y, w, h, bc, pw)
-	case AutoShapeDiamond:
-		r.drawDiamond(x, y, w, h, bc, pw)
-	case AutoShapeFlowchartPreparation:
-		pts := flowChartPreparationPoints(x, y, w, h)
-		r.drawPolygon(pts, bc, pw)
-	case AutoShapeChevron:
-		notch := w / 4
-		pts := []fpoint{
-			{float64(x), float64(y)},
-			{float64(x + w - notch), float64(y)},
-			{float64(x + w), float64(y + h/2)},
-			{float64(x + w - notch), float64(y + h)},
-			{float64(x), float64(y + h)},
-			{float64(x + notch), float64(y + h/2)},
-		}
-		r.drawPolygon(pts, bc, pw)
-	case AutoShapeParallelogram:
-		offset := w / 4
-		pts := []fpoint{
-			{float64(x + offset), float64(y)},
-			{float64(x + w), float64(y)},
-			{float64(x + w - offset), float64(y + h)},
-			{float64(x), float64(y + h)},
-		}
-		r.drawPolygon(pts, bc, pw)
+		r.drawRoundedRectBorder(x, y, w, h, radius, bc, pw, s.border.Style, s.border.DashPattern)
 	case AutoShapeBentArrow:
 		// Draw border following the bentArrow shape outline
 		adj1v, adj2v, adj3v, adj4v := 25000, 25000, 25000, 43750
@@ -1610,30 +3103,66 @@ func (r *renderer) renderAutoShapeBorder(s *AutoShape, x, y, w, h int) {
 			bpts = append(bpts, fpoint{icx + innerR*math.Cos(a), icy - innerR*math.Sin(a)})
 		}
 		bpts = append(bpts, fpoint{fx + shaftW, fy + fh})
-		r.drawPolygon(bpts, bc, pw)
+		r.drawPolygon(bpts, bc, pw, s.border)
 	case AutoShapeRtTriangle:
 		pts := []fpoint{
 			{float64(x), float64(y + h)},
 			{float64(x), float64(y)},
 			{float64(x + w), float64(y + h)},
 		}
-		r.drawPolygon(pts, bc, pw)
+		r.drawPolygon(pts, bc, pw, s.border)
 	case AutoShapeSnip2SameRect:
 		pts := r.snip2SameRectPoints(x, y, w, h, s.adjustValues)
-		r.drawPolygon(pts, bc, pw)
+		r.drawPolygon(pts, bc, pw, s.border)
 	case AutoShapeCallout1:
 		r.drawWedgeRoundRectCalloutBorder(x, y, w, h, bc, pw, s.adjustValues)
 	case AutoShapeArc:
 		r.renderArcBorder(s, x, y, w, h, bc, pw)
+	case AutoShapeRectangle, "":
+		r.drawRectBorder(image.Rect(x, y, x+w, y+h), bc, pw, s.border.Style, s.border.DashPattern, s.border.Join)
 	default:
-		r.drawRectBorder(image.Rect(x, y, x+w, y+h), bc, pw, s.border.Style)
+		if !r.strokePresetGeometry(s.shapeType, s.adjustValues, s.border, bc, pw, x, y, w, h) {
+			r.drawRectBorder(image.Rect(x, y, x+w, y+h), bc, pw, s.border.Style, s.border.DashPattern, s.border.Join)
+		}
 	}
 }
 
 // renderArcBorder draws an arc shape's stroke and arrowheads.
-// OOXML arc preset: adj1 = start angle, adj2 = end angle (in 60000ths of a degree).
-// Default: adj1=16200000 (270°), adj2=0 (0°) — a quarter-circle arc from bottom to right.
 func (r *renderer) renderArcBorder(s *AutoShape, x, y, w, h int, bc color.RGBA, pw int) {
+	pts, _, _ := arcPoints(s, x, y, w, h)
+
+	// Draw the arc stroke
+	var dashes []float64
+	if s.border != nil {
+		dashes = dashArray(s.border.Style, s.border.DashPattern, pw)
+	}
+	if dashes != nil {
+		r.drawDashedPolylineAA(pts, bc, pw, dashes)
+	} else {
+		for i := 1; i < len(pts); i++ {
+			r.drawLineAA(int(pts[i-1].x), int(pts[i-1].y), int(pts[i].x), int(pts[i].y), bc, pw)
+		}
+	}
+
+	// Draw arrowheads
+	intPts := make([][2]int, len(pts))
+	for i, p := range pts {
+		intPts[i] = [2]int{int(p.x), int(p.y)}
+	}
+	if s.headEnd != nil && s.headEnd.Type != ArrowNone && s.headEnd.Type != "" {
+		r.drawArrowOnPath(intPts[0][0], intPts[0][1], intPts, bc, pw, s.headEnd)
+	}
+	if s.tailEnd != nil && s.tailEnd.Type != ArrowNone && s.tailEnd.Type != "" {
+		last := intPts[len(intPts)-1]
+		r.drawArrowOnPath(last[0], last[1], intPts, bc, pw, s.tailEnd)
+	}
+}
+
+// arcPoints returns the outline of an arc shape and the centre of its
+// ellipse. OOXML arc preset: adj1 = start angle, adj2 = end angle (in
+// 60000ths of a degree). Default: adj1=16200000 (270°), adj2=0 (0°) — a
+// quarter-circle arc from top to right.
+func arcPoints(s *AutoShape, x, y, w, h int) ([]fpoint, float64, float64) {
 	// Get adjustment values (angles in 60000ths of a degree)
 	stAng := 16200000 // default start: 270°
 	endAng := 0       // default end: 0°
@@ -1668,32 +3197,7 @@ func (r *renderer) renderArcBorder(s *AutoShape, x, y, w, h int, bc color.RGBA,
 		a := stRad + sweep*t
 		pts[i] = fpoint{cx + rx*math.Cos(a), cy + ry*math.Sin(a)}
 	}
-
-	// Draw the arc stroke
-	ls := BorderSolid
-	if s.border != nil {
-		ls = s.border.Style
-	}
-	if ls == BorderDash || ls == BorderDot {
-		r.drawDashedPolylineAA(pts, bc, pw, ls)
-	} else {
-		for i := 1; i < len(pts); i++ {
-			r.drawLineAA(int(pts[i-1].x), int(pts[i-1].y), int(pts[i].x), int(pts[i].y), bc, pw)
-		}
-	}
-
-	// Draw arrowheads
-	intPts := make([][2]int, len(pts))
-	for i, p := range pts {
-		intPts[i] = [2]int{int(p.x), int(p.y)}
-	}
-	if s.headEnd != nil && s.headEnd.Type != ArrowNone && s.headEnd.Type != "" {
-		r.drawArrowOnPath(intPts[0][0], intPts[0][1], intPts, bc, pw, s.headEnd)
-	}
-	if s.tailEnd != nil && s.tailEnd.Type != ArrowNone && s.tailEnd.Type != "" {
-		last := intPts[len(intPts)-1]
-		r.drawArrowOnPath(last[0], last[1], intPts, bc, pw, s.tailEnd)
-	}
+	return pts, cx, cy
 }
 
 func (r *renderer) renderLine(s *LineShape) {
@@ -1709,6 +3213,33 @@ func (r *renderer) renderLine(s *LineShape) {
 	r.renderLineAt(s, ox, oy)
 }
 
+// lineStroke returns the stroke width in pixels and the colour of a line.
+// Lines thinner than one pixel are drawn one pixel wide with their alpha
+// reduced in proportion to the width they actually cover.
+func (r *renderer) lineStroke(s *LineShape) (int, color.RGBA) {
+	c := r.rgba(s.lineColor)
+	wpx := float64(s.GetLineWidthEMU()) * r.scaleX
+	if wpx >= 1 {
+		return int(wpx), c
+	}
+	if wpx > 0 {
+		c.A = uint8(math.Round(float64(c.A) * wpx))
+	}
+	return 1, c
+}
+
+// fillClosedLinePath fills a connector whose custom path is closed and
+// returns the points to stroke, which for a closed path end at its start.
+func (r *renderer) fillClosedLinePath(s *LineShape, pts []fpoint) []fpoint {
+	if !s.customPath.closed() {
+		return pts
+	}
+	if fill := r.resolveFill(s.fill); fill != nil && fill.Type != FillNone && len(pts) >= 3 {
+		r.fillPolygon(pts, r.scaleAlpha(r.rgba(fill.Color)))
+	}
+	return append(pts[:len(pts):len(pts)], pts[0])
+}
+
 // renderLineRotated handles connectors with rotation by transforming path points.
 func (r *renderer) renderLineRotated(s *LineShape) {
 	// Use float64 EMU coordinates throughout to avoid precision loss.
@@ -1742,14 +3273,14 @@ func (r *renderer) renderLineRotated(s *LineShape) {
 				pts[i].y = dx*sinA + dy*cosA + cyPx
 			}
 
-			pw := maxInt(int(float64(s.GetLineWidthEMU())*r.scaleX), 1)
-			c := argbToRGBA(s.lineColor)
-			ls := s.lineStyle
-			if ls == BorderDash || ls == BorderDot {
-				r.drawDashedPolylineAA(pts, c, pw, ls)
-			} else {
-				for i := 1; i < len(pts); i++ {
-					r.drawLineAA(int(pts[i-1].x), int(pts[i-1].y), int(pts[i].x), int(pts[i].y), c, pw)
+			outline := r.fillClosedLinePath(s, pts)
+			pw, c := r.lineStroke(s)
+			dashes := dashArray(s.lineStyle, s.dashPattern, pw)
+			if dashes != nil {
+				r.drawDashedPolylineAA(outline, c, pw, dashes)
+			} else if !r.strokeJoinedPolyline(outline, s.customPath.closed(), c, pw, s.lineJoin, s.miterLimit) {
+				for i := 1; i < len(outline); i++ {
+					r.drawLineAA(int(outline[i-1].x), int(outline[i-1].y), int(outline[i].x), int(outline[i].y), c, pw)
 				}
 			}
 			intPts := make([][2]int, len(pts))
@@ -1843,9 +3374,8 @@ func (r *renderer) renderLineRotated(s *LineShape) {
 		px2 := int(math.Round(rex * r.scaleX))
 		py2 := int(math.Round(rey * r.scaleY))
 
-		pw := maxInt(int(float64(s.GetLineWidthEMU())*r.scaleX), 1)
-		c := argbToRGBA(s.lineColor)
-		r.renderCurvedConnector(s.connectorType, px1, py1, px2, py2, s.adjustValues, c, pw, s.lineStyle, s.headEnd, s.tailEnd)
+		pw, c := r.lineStroke(s)
+		r.renderCurvedConnector(s.connectorType, px1, py1, px2, py2, s.adjustValues, c, pw, dashArray(s.lineStyle, s.dashPattern, pw), s.headEnd, s.tailEnd)
 		return
 
 	default:
@@ -1886,22 +3416,18 @@ func (r *renderer) renderLineRotated(s *LineShape) {
 		}
 	}
 
-	pw := maxInt(int(float64(s.GetLineWidthEMU())*r.scaleX), 1)
-	c := argbToRGBA(s.lineColor)
-	ls := s.lineStyle
+	pw, c := r.lineStroke(s)
+	dashes := dashArray(s.lineStyle, s.dashPattern, pw)
 
 	drawSeg := func(ax, ay, bx, by int) {
-		if ls == BorderDash || ls == BorderDot {
-			r.drawDashedLineAA(ax, ay, bx, by, c, pw, ls)
+		if dashes != nil {
+			r.drawDashedLineAA(ax, ay, bx, by, c, pw, dashes)
 		} else {
 			r.drawLineAA(ax, ay, bx, by, c, pw)
 		}
 	}
 
-	for i := 0; i+1 < len(transformed); i++ {
-		drawSeg(transformed[i][0], transformed[i][1],
-			transformed[i+1][0], transformed[i+1][1])
-	}
+	r.strokeConnectorPath(s, transformed, c, pw, dashes, drawSeg)
 
 	if s.headEnd != nil && s.headEnd.Type != ArrowNone && s.headEnd.Type != "" {
 		r.drawArrowOnPath(transformed[0][0], transformed[0][1], transformed, c, pw, s.headEnd)
@@ -1934,19 +3460,19 @@ func (r *renderer) renderLineAt(s *LineShape, ox, oy int) {
 		y1, y2 = y2, y1
 	}
 	// lineWidth in EMU, convert to pixels
-	pw := maxInt(int(float64(s.GetLineWidthEMU())*r.scaleX), 1)
-	c := argbToRGBA(s.lineColor)
-	ls := s.lineStyle
+	pw, c := r.lineStroke(s)
+	dashes := dashArray(s.lineStyle, s.dashPattern, pw)
 
 	// Custom geometry path (freeform curved arrows, etc.)
 	if s.customPath != nil && len(s.customPath.Commands) > 0 {
 		pts := r.customPathToPixelPoints(s.customPath, ox, oy, w, h)
 		if len(pts) >= 2 {
-			if ls == BorderDash || ls == BorderDot {
-				r.drawDashedPolylineAA(pts, c, pw, ls)
-			} else {
-				for i := 1; i < len(pts); i++ {
-					r.drawLineAA(int(pts[i-1].x), int(pts[i-1].y), int(pts[i].x), int(pts[i].y), c, pw)
+			outline := r.fillClosedLinePath(s, pts)
+			if dashes != nil {
+				r.drawDashedPolylineAA(outline, c, pw, dashes)
+			} else if !r.strokeJoinedPolyline(outline, s.customPath.closed(), c, pw, s.lineJoin, s.miterLimit) {
+				for i := 1; i < len(outline); i++ {
+					r.drawLineAA(int(outline[i-1].x), int(outline[i-1].y), int(outline[i].x), int(outline[i].y), c, pw)
 				}
 			}
 			intPts := make([][2]int, len(pts))
@@ -1966,8 +3492,8 @@ func (r *renderer) renderLineAt(s *LineShape, ox, oy int) {
 
 	// drawSeg draws a line segment respecting the connector's dash style.
 	drawSeg := func(ax, ay, bx, by int) {
-		if ls == BorderDash || ls == BorderDot {
-			r.drawDashedLineAA(ax, ay, bx, by, c, pw, ls)
+		if dashes != nil {
+			r.drawDashedLineAA(ax, ay, bx, by, c, pw, dashes)
 		} else {
 			r.drawLineAA(ax, ay, bx, by, c, pw)
 		}
@@ -1981,10 +3507,8 @@ func (r *renderer) renderLineAt(s *LineShape, ox, oy int) {
 			adjPct = v
 		}
 		midX := x1 + int(float64(x2-x1)*float64(adjPct)/100000.0)
-		drawSeg(x1, y1, midX, y1)
-		drawSeg(midX, y1, midX, y2)
-		drawSeg(midX, y2, x2, y2)
 		pathPts := [][2]int{{x1, y1}, {midX, y1}, {midX, y2}, {x2, y2}}
+		r.strokeConnectorPath(s, pathPts, c, pw, dashes, drawSeg)
 		if s.headEnd != nil && s.headEnd.Type != ArrowNone && s.headEnd.Type != "" {
 			r.drawArrowOnPath(x1, y1, pathPts, c, pw, s.headEnd)
 		}
@@ -1993,9 +3517,8 @@ func (r *renderer) renderLineAt(s *LineShape, ox, oy int) {
 		}
 
 	case s.connectorType == "bentConnector2":
-		drawSeg(x1, y1, x2, y1)
-		drawSeg(x2, y1, x2, y2)
 		pathPts := [][2]int{{x1, y1}, {x2, y1}, {x2, y2}}
+		r.strokeConnectorPath(s, pathPts, c, pw, dashes, drawSeg)
 		if s.headEnd != nil && s.headEnd.Type != ArrowNone && s.headEnd.Type != "" {
 			r.drawArrowOnPath(x1, y1, pathPts, c, pw, s.headEnd)
 		}
@@ -2014,11 +3537,8 @@ func (r *renderer) renderLineAt(s *LineShape, ox, oy int) {
 		}
 		midX := x1 + int(float64(x2-x1)*float64(adjPct1)/100000.0)
 		midY := y1 + int(float64(y2-y1)*float64(adjPct2)/100000.0)
-		drawSeg(x1, y1, midX, y1)
-		drawSeg(midX, y1, midX, midY)
-		drawSeg(midX, midY, x2, midY)
-		drawSeg(x2, midY, x2, y2)
 		pathPts := [][2]int{{x1, y1}, {midX, y1}, {midX, midY}, {x2, midY}, {x2, y2}}
+		r.strokeConnectorPath(s, pathPts, c, pw, dashes, drawSeg)
 		if s.headEnd != nil && s.headEnd.Type != ArrowNone && s.headEnd.Type != "" {
 			r.drawArrowOnPath(x1, y1, pathPts, c, pw, s.headEnd)
 		}
@@ -2042,12 +3562,8 @@ func (r *renderer) renderLineAt(s *LineShape, ox, oy int) {
 		midX1 := x1 + int(float64(x2-x1)*float64(adjPct1)/100000.0)
 		midY := y1 + int(float64(y2-y1)*float64(adjPct2)/100000.0)
 		midX2 := x1 + int(float64(x2-x1)*float64(adjPct3)/100000.0)
-		drawSeg(x1, y1, midX1, y1)
-		drawSeg(midX1, y1, midX1, midY)
-		drawSeg(midX1, midY, midX2, midY)
-		drawSeg(midX2, midY, midX2, y2)
-		drawSeg(midX2, y2, x2, y2)
 		pathPts := [][2]int{{x1, y1}, {midX1, y1}, {midX1, midY}, {midX2, midY}, {midX2, y2}, {x2, y2}}
+		r.strokeConnectorPath(s, pathPts, c, pw, dashes, drawSeg)
 		if s.headEnd != nil && s.headEnd.Type != ArrowNone && s.headEnd.Type != "" {
 			r.drawArrowOnPath(x1, y1, pathPts, c, pw, s.headEnd)
 		}
@@ -2056,7 +3572,7 @@ func (r *renderer) renderLineAt(s *LineShape, ox, oy int) {
 		}
 
 	case strings.HasPrefix(s.connectorType, "curvedConnector"):
-		r.renderCurvedConnector(s.connectorType, x1, y1, x2, y2, s.adjustValues, c, pw, ls, s.headEnd, s.tailEnd)
+		r.renderCurvedConnector(s.connectorType, x1, y1, x2, y2, s.adjustValues, c, pw, dashes, s.headEnd, s.tailEnd)
 
 	default:
 		// Straight line connector (line, straightConnector1, etc.)
@@ -2072,14 +3588,31 @@ func (r *renderer) renderLineAt(s *LineShape, ox, oy int) {
 	}
 }
 
+// strokeConnectorPath strokes the polyline of a connector with drawSeg, or
+// as one line with its bends joined when the line is solid and sets a join.
+func (r *renderer) strokeConnectorPath(s *LineShape, pts [][2]int, c color.RGBA, pw int, dashes []float64, drawSeg func(ax, ay, bx, by int)) {
+	if dashes == nil && s.lineJoin != "" {
+		fpts := make([]fpoint, len(pts))
+		for i, p := range pts {
+			fpts[i] = fpoint{float64(p[0]), float64(p[1])}
+		}
+		if r.strokeJoinedPolyline(fpts, false, c, pw, s.lineJoin, s.miterLimit) {
+			return
+		}
+	}
+	for i := 0; i+1 < len(pts); i++ {
+		drawSeg(pts[i][0], pts[i][1], pts[i+1][0], pts[i+1][1])
+	}
+}
+
 // renderCurvedConnector draws a curved connector using cubic Bezier curves.
 // OOXML curved connectors (curvedConnector2..5) follow the same waypoint
 // logic as bent connectors but replace the right-angle segments with smooth
 // S-curves through the waypoints.
-func (r *renderer) renderCurvedConnector(connType string, x1, y1, x2, y2 int, adj map[string]int, c color.RGBA, pw int, ls BorderStyle, headEnd, tailEnd *LineEnd) {
+func (r *renderer) renderCurvedConnector(connType string, x1, y1, x2, y2 int, adj map[string]int, c color.RGBA, pw int, dashes []float64, headEnd, tailEnd *LineEnd) {
 	drawBezier := func(bx0, by0, bx1, by1, bx2, by2, bx3, by3 float64) {
-		if ls == BorderDash || ls == BorderDot {
-			r.drawDashedCubicBezierAA(bx0, by0, bx1, by1, bx2, by2, bx3, by3, c, pw, ls)
+		if dashes != nil {
+			r.drawDashedCubicBezierAA(bx0, by0, bx1, by1, bx2, by2, bx3, by3, c, pw, dashes)
 		} else {
 			r.drawCubicBezierAA(bx0, by0, bx1, by1, bx2, by2, bx3, by3, c, pw)
 		}
@@ -2383,8 +3916,15 @@ func (r *renderer) renderTable(s *TableShape) {
 		}
 	}
 
-	pad := 3
-
+	// Fill every cell before stroking borders, so that a cell's fill does
+	// not cover the shared edge drawn by the cell above or to its left.
+	type cellBox struct {
+		cell           *TableCell
+		rect           image.Rectangle
+		row, col       int
+		endRow, endCol int
+	}
+	var boxes []cellBox
 	for row := 0; row < s.numRows; row++ {
 		if row >= len(s.rows) {
 			break
@@ -2410,18 +3950,137 @@ func (r *renderer) renderTable(s *TableShape) {
 			if endRow > s.numRows {
 				endRow = s.numRows
 			}
-			cellW := colX[endCol] - cx
-			cellH := rowY[endRow] - cy
-			cellRect := image.Rect(cx, cy, cx+cellW, cy+cellH)
-			r.renderFill(cell.fill, cellRect)
-			if cell.border != nil {
-				r.renderCellBorders(cell.border, cellRect)
-			} else {
-				r.drawRect(cellRect, color.RGBA{A: 255}, 1)
+			cellRect := image.Rect(cx, cy, colX[endCol], rowY[endRow])
+			fill := cell.fill
+			if !cell.fillSet && (fill == nil || fill.Type == FillNone) {
+				fill = s.styleCellFill(row, col)
 			}
-			r.drawParagraphs(cell.paragraphs, cx+pad, cy+pad, cellW-2*pad, cellH-2*pad, TextAnchorNone, true)
+			r.renderFill(fill, cellRect)
+			boxes = append(boxes, cellBox{cell, cellRect, row, col, endRow, endCol})
+		}
+	}
+	for _, b := range boxes {
+		if b.cell.border != nil || s.borders != nil {
+			r.renderCellBorders(s.effectiveCellBorders(b.cell, b.row, b.col, b.endRow, b.endCol), b.rect)
+		} else {
+			r.drawRect(b.rect, color.RGBA{A: 255}, 1)
+		}
+	}
+	for _, b := range boxes {
+		c := b.cell
+		text := image.Rect(
+			b.rect.Min.X+r.emuToPixelX(c.marginLeft), b.rect.Min.Y+r.emuToPixelY(c.marginTop),
+			b.rect.Max.X-r.emuToPixelX(c.marginRight), b.rect.Max.Y-r.emuToPixelY(c.marginBottom))
+		r.drawParagraphs(c.paragraphs, text.Min.X, text.Min.Y, text.Dx(), text.Dy(), c.anchor, false, true)
+	}
+}
+
+// styleCellFill returns the table style's fill for the cell at (row, col).
+// Parts are layered as PowerPoint does, later ones winning: the whole table,
+// banded columns and rows, the last and first columns, the last and first
+// rows, and the corner cells. The table look selects which parts apply.
+func (t *TableShape) styleCellFill(row, col int) *Fill {
+	if t.style == nil {
+		return nil
+	}
+	look := t.look
+	firstRow := look.FirstRow && row == 0
+	lastRow := look.LastRow && row == t.numRows-1
+	firstCol := look.FirstCol && col == 0
+	lastCol := look.LastCol && col == t.numCols-1
+
+	parts := []string{"wholeTbl"}
+	if look.BandCol && !firstCol && !lastCol {
+		band := col
+		if look.FirstCol {
+			band--
+		}
+		parts = append(parts, [2]string{"band1V", "band2V"}[band%2])
+	}
+	if look.BandRow && !firstRow && !lastRow {
+		band := row
+		if look.FirstRow {
+			band--
+		}
+		parts = append(parts, [2]string{"band1H", "band2H"}[band%2])
+	}
+	if lastCol {
+		parts = append(parts, "lastCol")
+	}
+	if firstCol {
+		parts = append(parts, "firstCol")
+	}
+	if lastRow {
+		parts = append(parts, "lastRow")
+	}
+	if firstRow {
+		parts = append(parts, "firstRow")
+	}
+	switch {
+	case firstRow && firstCol:
+		parts = append(parts, "nwCell")
+	case firstRow && lastCol:
+		parts = append(parts, "neCell")
+	case lastRow && firstCol:
+		parts = append(parts, "swCell")
+	case lastRow && lastCol:
+		parts = append(parts, "seCell")
+	}
+
+	var fill *Fill
+	for _, part := range parts {
+		if f, ok := t.style.fills[part]; ok {
+			fill = f
+		}
+	}
+	return fill
+}
+
+// effectiveCellBorders layers a cell's own borders over the table-level
+// borders. Outer edges take the table's outline; inside edges are taken only
+// by the cell below or to the right, so shared edges are not drawn twice.
+func (t *TableShape) effectiveCellBorders(cell *TableCell, row, col, endRow, endCol int) *CellBorders {
+	eff := &CellBorders{}
+	if cell.border != nil {
+		*eff = *cell.border
+	}
+	tb := t.borders
+	if tb == nil {
+		return eff
+	}
+	// A side without a line inherits unless the file gave it as no line.
+	unset := func(c *TableCell, b *Border, side string) bool {
+		return b == nil || b.Style == BorderNone && !strings.Contains(c.bordersSet, side)
+	}
+	// neighbour returns the cell at (r, c) if it exists and has borders.
+	neighbour := func(r, c int) *TableCell {
+		if r < 0 || r >= len(t.rows) || c < 0 || c >= len(t.rows[r]) || t.rows[r][c] == nil || t.rows[r][c].border == nil {
+			return nil
 		}
+		return t.rows[r][c]
 	}
+	if unset(cell, eff.Top, "T") {
+		if row == 0 {
+			eff.Top = tb.Top
+		} else if above := neighbour(row-1, col); above == nil || unset(above, above.border.Bottom, "B") {
+			// The cell above's own bottom edge wins over the table style.
+			eff.Top = tb.InsideH
+		}
+	}
+	if unset(cell, eff.Left, "L") {
+		if col == 0 {
+			eff.Left = tb.Left
+		} else if left := neighbour(row, col-1); left == nil || unset(left, left.border.Right, "R") {
+			eff.Left = tb.InsideV
+		}
+	}
+	if unset(cell, eff.Bottom, "B") && endRow >= t.numRows {
+		eff.Bottom = tb.Bottom
+	}
+	if unset(cell, eff.Right, "R") && endCol >= t.numCols {
+		eff.Right = tb.Right
+	}
+	return eff
 }
 
 func (r *renderer) renderCellBorders(cb *CellBorders, rect image.Rectangle) {
@@ -2430,7 +4089,11 @@ func (r *renderer) renderCellBorders(cb *CellBorders, rect image.Rectangle) {
 			return
 		}
 		pw := maxInt(int(float64(b.Width)*12700.0*r.scaleX), 1)
-		r.drawLineThick(x1, y1, x2, y2, argbToRGBA(b.Color), pw)
+		if dashes := dashArray(b.Style, b.DashPattern, pw); dashes != nil {
+			r.drawDashedLineAA(x1, y1, x2, y2, r.rgba(b.Color), pw, dashes)
+			return
+		}
+		r.drawLineThick(x1, y1, x2, y2, r.rgba(b.Color), pw)
 	}
 	drawBorder(cb.Top, rect.Min.X, rect.Min.Y, rect.Max.X, rect.Min.Y)
 	drawBorder(cb.Bottom, rect.Min.X, rect.Max.Y-1, rect.Max.X, rect.Max.Y-1)
@@ -2446,13 +4109,17 @@ func (r *renderer) renderFill(fill *Fill, rect image.Rectangle) {
 	}
 	switch fill.Type {
 	case FillSolid:
-		fc := argbToRGBA(fill.Color)
+		fc := r.rgba(fill.Color)
 		fc = r.scaleAlpha(fc)
 		r.fillRectBlend(rect, fc)
 	case FillGradientLinear:
 		r.fillGradientLinear(rect, fill)
 	case FillGradientPath:
 		r.fillGradientPath(rect, fill)
+	case FillPattern:
+		r.fillPattern(rect, fill)
+	case FillPicture:
+		r.fillPicture(rect, fill)
 	}
 }
 
@@ -2466,7 +4133,7 @@ func (r *renderer) renderCustomPathFill(cp *CustomGeomPath, fill *Fill, ox, oy,
 	if len(pts) < 3 {
 		return
 	}
-	fc := argbToRGBA(fill.Color)
+	fc := r.rgba(fill.Color)
 	fc = r.scaleAlpha(fc)
 	r.fillPolygon(pts, fc)
 }
@@ -2556,7 +4223,24 @@ func (r *renderer) customPathToPixelPoints(cp *CustomGeomPath, ox, oy, w, h int)
 	return pts
 }
 
+// resolveFill returns the fill to paint for f. FillBackground is replaced by
+// the slide background so the shape knocks out everything beneath it.
+func (r *renderer) resolveFill(f *Fill) *Fill {
+	if f == nil || f.Type != FillBackground {
+		return f
+	}
+	if r.knockoutFill != nil {
+		return r.knockoutFill
+	}
+	if r.background != nil && r.background.Type != FillNone && r.background.Type != FillBackground {
+		return r.background
+	}
+	return &Fill{Type: FillSolid, Color: ColorWhite}
+}
+
 // scaleAlpha applies the overlayOpacityScale to semi-transparent colors.
+// Opaque colors stay opaque and fully transparent colors stay transparent:
+// alpha 0 always means nothing is drawn, never that content is erased.
 func (r *renderer) scaleAlpha(c color.RGBA) color.RGBA {
 	scale := r.overlayOpacityScale
 	if scale <= 0 || scale >= 1.0 {
@@ -2569,8 +4253,7 @@ func (r *renderer) scaleAlpha(c color.RGBA) color.RGBA {
 }
 
 func (r *renderer) fillGradientLinear(rect image.Rectangle, fill *Fill) {
-	startC := argbToRGBA(fill.Color)
-	endC := argbToRGBA(fill.EndColor)
+	ramp := r.gradientRamp(r.rgba(fill.Color), r.rgba(fill.EndColor))
 	w := rect.Dx()
 	h := rect.Dy()
 	if w <= 0 || h <= 0 {
@@ -2607,19 +4290,14 @@ func (r *renderer) fillGradientLinear(rect image.Rectangle, fill *Fill) {
 			} else if t > 1 {
 				t = 1
 			}
-			it := 1 - t
-			pix[off] = uint8(float64(startC.R)*it + float64(endC.R)*t)
-			pix[off+1] = uint8(float64(startC.G)*it + float64(endC.G)*t)
-			pix[off+2] = uint8(float64(startC.B)*it + float64(endC.B)*t)
-			pix[off+3] = uint8(float64(startC.A)*it + float64(endC.A)*t)
+			blendPixelAt(pix, off, ramp(t))
 			off += 4
 		}
 	}
 }
 
 func (r *renderer) fillGradientPath(rect image.Rectangle, fill *Fill) {
-	startC := argbToRGBA(fill.Color)
-	endC := argbToRGBA(fill.EndColor)
+	ramp := r.gradientRamp(r.rgba(fill.Color), r.rgba(fill.EndColor))
 	w := rect.Dx()
 	h := rect.Dy()
 	if w <= 0 || h <= 0 {
@@ -2650,11 +4328,7 @@ func (r *renderer) fillGradientPath(rect image.Rectangle, fill *Fill) {
 			if t > 1 {
 				t = 1
 			}
-			it := 1 - t
-			pix[off] = uint8(float64(startC.R)*it + float64(endC.R)*t)
-			pix[off+1] = uint8(float64(startC.G)*it + float64(endC.G)*t)
-			pix[off+2] = uint8(float64(startC.B)*it + float64(endC.B)*t)
-			pix[off+3] = uint8(float64(startC.A)*it + float64(endC.A)*t)
+			blendPixelAt(pix, off, ramp(t))
 			off += 4
 		}
 	}
@@ -2680,7 +4354,7 @@ func (r *renderer) renderShadow(shadow *Shadow, rect image.Rectangle) {
 	dist := float64(shadow.Distance) * r.scaleX
 	dx := int(dist * math.Cos(rad))
 	dy := int(dist * math.Sin(rad))
-	shadowColor := argbToRGBA(shadow.Color)
+	shadowColor := r.rgba(shadow.Color)
 	shadowColor.A = uint8(float64(shadow.Alpha) * 255 / 100)
 	shadowRect := rect.Add(image.Pt(dx, dy))
 
@@ -2725,7 +4399,7 @@ func (r *renderer) renderShadowRounded(shadow *Shadow, rect image.Rectangle, rad
 	dist := float64(shadow.Distance) * r.scaleX
 	dx := int(dist * math.Cos(rad))
 	dy := int(dist * math.Sin(rad))
-	shadowColor := argbToRGBA(shadow.Color)
+	shadowColor := r.rgba(shadow.Color)
 	shadowColor.A = uint8(float64(shadow.Alpha) * 255 / 100)
 	shadowRect := rect.Add(image.Pt(dx, dy))
 
@@ -2744,8 +4418,9 @@ func (r *renderer) renderShadowRounded(shadow *Shadow, rect image.Rectangle, rad
 	if tmpW <= 0 || tmpH <= 0 {
 		return
 	}
-	tmp := image.NewRGBA(image.Rect(0, 0, tmpW, tmpH))
-	tmpR := &renderer{img: tmp, scaleX: r.scaleX, scaleY: r.scaleY}
+	tmp := getRGBA(image.Rect(0, 0, tmpW, tmpH))
+	defer putRGBA(tmp)
+	tmpR := r.withImage(tmp)
 
 	for i := steps; i >= 0; i-- {
 		t := float64(i) / float64(steps)
@@ -2795,38 +4470,70 @@ func (r *renderer) drawRect(rect image.Rectangle, c color.RGBA, width int) {
 	}
 }
 
-func (r *renderer) drawRectBorder(rect image.Rectangle, c color.RGBA, width int, style BorderStyle) {
-	if style == BorderSolid || style == BorderNone {
+func (r *renderer) drawRectBorder(rect image.Rectangle, c color.RGBA, width int, style BorderStyle, pattern []float64, join LineJoin) {
+	if len(pattern) == 0 && (style == BorderSolid || style == BorderNone) {
+		if (join == LineJoinRound || join == LineJoinBevel) && width > 2 {
+			r.strokeRectJoined(rect, c, width, join)
+			return
+		}
 		r.drawRect(rect, c, width)
 		return
 	}
-	dashLen, gapLen := 6, 4
+	if len(pattern) > 0 || (style != BorderDash && style != BorderDot) {
+		// Custom dashes and the presets other than dash and dot are sized in
+		// line widths, so stroke them along the centre line rather than
+		// ring by ring, keeping thick dashes square.
+		h := float64(width) / 2
+		x0, y0 := float64(rect.Min.X)+h, float64(rect.Min.Y)+h
+		x1, y1 := float64(rect.Max.X)-h, float64(rect.Max.Y)-h
+		pts := []fpoint{{x0, y0}, {x1, y0}, {x1, y1}, {x0, y1}, {x0, y0}}
+		r.drawDashedPolylineAA(pts, c, width, dashArray(style, pattern, width))
+		return
+	}
+	dashes := []int{6, 4}
 	if style == BorderDot {
-		dashLen, gapLen = 2, 2
+		dashes = []int{2, 2}
 	}
 	for i := 0; i < width; i++ {
-		r.drawDashedHLine(rect.Min.X, rect.Max.X, rect.Min.Y+i, c, dashLen, gapLen)
-		r.drawDashedHLine(rect.Min.X, rect.Max.X, rect.Max.Y-1-i, c, dashLen, gapLen)
-		r.drawDashedVLine(rect.Min.X+i, rect.Min.Y, rect.Max.Y, c, dashLen, gapLen)
-		r.drawDashedVLine(rect.Max.X-1-i, rect.Min.Y, rect.Max.Y, c, dashLen, gapLen)
-	}
-}
-
-func (r *renderer) drawDashedHLine(x1, x2, y int, c color.RGBA, dashLen, gapLen int) {
-	period := dashLen + gapLen
-	for x := x1; x < x2; x++ {
-		if (x-x1)%period < dashLen {
-			r.blendPixel(x, y, c)
+		ring := image.Rect(rect.Min.X+i, rect.Min.Y+i, rect.Max.X-i, rect.Max.Y-i)
+		if ring.Dx() <= 0 || ring.Dy() <= 0 {
+			break
 		}
+		r.drawDashedRectRing(ring, c, dashes)
 	}
 }
 
-func (r *renderer) drawDashedVLine(x, y1, y2 int, c color.RGBA, dashLen, gapLen int) {
-	period := dashLen + gapLen
-	for y := y1; y < y2; y++ {
-		if (y-y1)%period < dashLen {
+// drawDashedRectRing strokes the one-pixel perimeter of rect clockwise from the
+// top-left corner, carrying the dash phase across corners so that dashes do not
+// bunch up where two edges meet. dashes holds alternating on/off lengths.
+func (r *renderer) drawDashedRectRing(rect image.Rectangle, c color.RGBA, dashes []int) {
+	x0, y0 := rect.Min.X, rect.Min.Y
+	x1, y1 := rect.Max.X-1, rect.Max.Y-1
+	phase, remain := 0, dashes[0]
+	plot := func(x, y int) {
+		if phase%2 == 0 {
 			r.blendPixel(x, y, c)
 		}
+		if remain--; remain <= 0 {
+			phase = (phase + 1) % len(dashes)
+			remain = dashes[phase]
+		}
+	}
+	for x := x0; x < x1; x++ {
+		plot(x, y0)
+	}
+	for y := y0; y < y1; y++ {
+		plot(x1, y)
+	}
+	if y1 == y0 || x1 == x0 {
+		plot(x1, y1)
+		return
+	}
+	for x := x1; x > x0; x-- {
+		plot(x, y1)
+	}
+	for y := y1; y > y0; y-- {
+		plot(x0, y)
 	}
 }
 
@@ -2902,75 +4609,93 @@ func (r *renderer) drawLineAA(x1, y1, x2, y2 int, c color.RGBA, width int) {
 	}
 }
 
-// drawDashedLineAA draws a dashed or dotted anti-aliased line.
-func (r *renderer) drawDashedLineAA(x1, y1, x2, y2 int, c color.RGBA, width int, style BorderStyle) {
-	if style == BorderSolid || style == BorderNone {
-		r.drawLineAA(x1, y1, x2, y2, c, width)
-		return
+// dashArray returns the on/off lengths in pixels for a stroke of the given
+// style and pixel width, or nil for a solid stroke. A non-empty custom pattern
+// (in multiples of the line width, as read from <a:custDash>) takes precedence
+// over the preset style.
+func dashArray(style BorderStyle, pattern []float64, width int) []float64 {
+	if len(pattern) > 0 {
+		w := float64(maxInt(width, 1))
+		dashes := make([]float64, 0, len(pattern)+1)
+		for _, v := range pattern {
+			dashes = append(dashes, math.Max(v*w, 1))
+		}
+		if len(dashes)%2 == 1 {
+			// An odd pattern repeats with dash and gap roles swapped, as in SVG.
+			dashes = append(dashes, dashes...)
+		}
+		return dashes
 	}
-	dx := float64(x2 - x1)
-	dy := float64(y2 - y1)
-	length := math.Sqrt(dx*dx + dy*dy)
-	if length < 1 {
-		r.blendPixel(x1, y1, c)
-		return
+	if pattern, ok := presetDashes[style]; ok {
+		// The presets are drawn in units a quarter of the dash style's
+		// dash, so that their dashes match it in length.
+		unit := 3.0
+		if width > 1 {
+			unit = 1.2 * float64(width)
+		}
+		dashes := make([]float64, len(pattern))
+		for i, v := range pattern {
+			dashes[i] = v * unit
+		}
+		return dashes
+	}
+	if style != BorderDash && style != BorderDot {
+		return nil
 	}
-	dashLen := 12.0
-	gapLen := 6.0
+	dashLen, gapLen := 12.0, 6.0
 	if style == BorderDot {
-		dashLen = 3.0
-		gapLen = 3.0
+		dashLen, gapLen = 3.0, 3.0
 	}
 	// Scale dash/gap by line width for visual consistency
 	if width > 1 {
 		dashLen *= float64(width) * 0.4
 		gapLen *= float64(width) * 0.4
 	}
-	ux := dx / length
-	uy := dy / length
-	pos := 0.0
-	drawing := true
-	segStart := 0.0
-	for pos < length {
-		segLen := dashLen
-		if !drawing {
-			segLen = gapLen
-		}
-		segEnd := pos + segLen
-		if segEnd > length {
-			segEnd = length
-		}
-		if drawing {
-			sx := x1 + int(ux*segStart)
-			sy := y1 + int(uy*segStart)
-			ex := x1 + int(ux*segEnd)
-			ey := y1 + int(uy*segEnd)
-			r.drawLineAA(sx, sy, ex, ey, c, width)
-		}
-		pos = segEnd
-		segStart = segEnd
-		drawing = !drawing
+	return []float64{dashLen, gapLen}
+}
+
+// presetDashes holds the alternating dash and gap lengths of the
+// <a:prstDash> presets other than dash and dot, in line widths.
+var presetDashes = map[BorderStyle][]float64{
+	BorderLgDash:        {8, 3},
+	BorderDashDot:       {4, 3, 1, 3},
+	BorderLgDashDot:     {8, 3, 1, 3},
+	BorderLgDashDotDot:  {8, 3, 1, 3, 1, 3},
+	BorderSysDash:       {3, 1},
+	BorderSysDot:        {1, 1},
+	BorderSysDashDot:    {3, 1, 1, 1},
+	BorderSysDashDotDot: {3, 1, 1, 1, 1, 1},
+}
+
+// drawDashedLineAA draws a dashed anti-aliased line using the on/off lengths
+// from dashArray.
+func (r *renderer) drawDashedLineAA(x1, y1, x2, y2 int, c color.RGBA, width int, dashes []float64) {
+	if len(dashes) == 0 {
+		r.drawLineAA(x1, y1, x2, y2, c, width)
+		return
 	}
+	if x1 == x2 && y1 == y2 {
+		r.blendPixel(x1, y1, c)
+		return
+	}
+	r.drawDashedPolylineAA([]fpoint{{float64(x1), float64(y1)}, {float64(x2), float64(y2)}}, c, width, dashes)
 }
 
-// drawDashedPolylineAA draws a dashed/dotted polyline with continuous dash pattern
+// drawDashedPolylineAA draws a dashed polyline with continuous dash pattern
 // across all segments, so the dash state carries over from one segment to the next.
-func (r *renderer) drawDashedPolylineAA(pts []fpoint, c color.RGBA, width int, style BorderStyle) {
+// dashes holds alternating on/off lengths in pixels.
+func (r *renderer) drawDashedPolylineAA(pts []fpoint, c color.RGBA, width int, dashes []float64) {
 	if len(pts) < 2 {
 		return
 	}
-	dashLen := 12.0
-	gapLen := 6.0
-	if style == BorderDot {
-		dashLen = 3.0
-		gapLen = 3.0
-	}
-	if width > 1 {
-		dashLen *= float64(width) * 0.4
-		gapLen *= float64(width) * 0.4
+	if len(dashes) == 0 {
+		for i := 1; i < len(pts); i++ {
+			r.drawLineAA(int(pts[i-1].x), int(pts[i-1].y), int(pts[i].x), int(pts[i].y), c, width)
+		}
+		return
 	}
-	drawing := true
-	remain := dashLen // remaining length in current dash/gap phase
+	phase := 0
+	remain := dashes[0] // remaining length in current dash/gap phase
 
 	for i := 1; i < len(pts); i++ {
 		sx, sy := pts[i-1].x, pts[i-1].y
@@ -2989,7 +4714,7 @@ func (r *renderer) drawDashedPolylineAA(pts []fpoint, c color.RGBA, width int, s
 			if pos+step > segLen {
 				step = segLen - pos
 			}
-			if drawing {
+			if phase%2 == 0 {
 				ax := int(sx + ux*pos)
 				ay := int(sy + uy*pos)
 				bx := int(sx + ux*(pos+step))
@@ -2999,12 +4724,8 @@ func (r *renderer) drawDashedPolylineAA(pts []fpoint, c color.RGBA, width int, s
 			pos += step
 			remain -= step
 			if remain <= 0 {
-				drawing = !drawing
-				if drawing {
-					remain = dashLen
-				} else {
-					remain = gapLen
-				}
+				phase = (phase + 1) % len(dashes)
+				remain = dashes[phase]
 			}
 		}
 	}
@@ -3021,18 +4742,17 @@ func (r *renderer) drawCubicBezierAA(x0, y0, x1, y1, x2, y2, x3, y3 float64, c c
 	}
 }
 
-// drawDashedCubicBezierAA draws a dashed cubic Bezier curve.
-func (r *renderer) drawDashedCubicBezierAA(x0, y0, x1, y1, x2, y2, x3, y3 float64, c color.RGBA, width int, style BorderStyle) {
-	if style == BorderSolid || style == BorderNone {
+// drawDashedCubicBezierAA draws a dashed cubic Bezier curve, keeping the dash
+// phase continuous along the flattened curve.
+func (r *renderer) drawDashedCubicBezierAA(x0, y0, x1, y1, x2, y2, x3, y3 float64, c color.RGBA, width int, dashes []float64) {
+	if len(dashes) == 0 {
 		r.drawCubicBezierAA(x0, y0, x1, y1, x2, y2, x3, y3, c, width)
 		return
 	}
 	pts := r.flattenCubicBezier(x0, y0, x1, y1, x2, y2, x3, y3, 0)
 	pts = append([]fpoint{{x0, y0}}, pts...)
 	pts = append(pts, fpoint{x3, y3})
-	for i := 1; i < len(pts); i++ {
-		r.drawDashedLineAA(int(pts[i-1].x), int(pts[i-1].y), int(pts[i].x), int(pts[i].y), c, width, style)
-	}
+	r.drawDashedPolylineAA(pts, c, width, dashes)
 }
 
 // flattenCubicBezier recursively subdivides a cubic Bezier into line segments.
@@ -3284,7 +5004,14 @@ func (r *renderer) fillRoundedRect(x, y, w, h, radius int, c color.RGBA) {
 	}
 }
 
+// drawRoundedRect strokes the outline of a rounded rectangle. Thin outlines
+// are drawn edge by edge; thicker ones as a single ring, since separately
+// stroked edges and arcs leave seams where they meet.
 func (r *renderer) drawRoundedRect(x, y, w, h, radius int, c color.RGBA, lineWidth int) {
+	if lineWidth > 2 {
+		r.strokeRoundedRectRing(x, y, w, h, radius, c, lineWidth)
+		return
+	}
 	r.drawLineThick(x+radius, y, x+w-radius, y, c, lineWidth)
 	r.drawLineThick(x+radius, y+h-1, x+w-radius, y+h-1, c, lineWidth)
 	r.drawLineThick(x, y+radius, x, y+h-radius, c, lineWidth)
@@ -3295,6 +5022,86 @@ func (r *renderer) drawRoundedRect(x, y, w, h, radius int, c color.RGBA, lineWid
 	r.drawArc(x+w-radius*2, y+h-radius*2, radius*2, radius*2, c, 0, 0.5*math.Pi, lineWidth)
 }
 
+// strokeRoundedRectRing fills the band of lineWidth pixels centred on the
+// outline of a rounded rectangle, with anti-aliased edges. The outline runs
+// through the centres of the outermost pixels of the x, y, w, h box, as the
+// edge-by-edge stroke does.
+func (r *renderer) strokeRoundedRectRing(x, y, w, h, radius int, c color.RGBA, lineWidth int) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+	x0, y0 := float64(x)+0.5, float64(y)+0.5
+	x1, y1 := float64(x+w)-0.5, float64(y+h)-0.5
+	rad := math.Max(0, math.Min(float64(radius), math.Min(x1-x0, y1-y0)/2))
+	half := float64(lineWidth) / 2
+	cx, cy := (x0+x1)/2, (y0+y1)/2
+	bx, by := (x1-x0)/2-rad, (y1-y0)/2-rad
+
+	ext := int(math.Ceil(half)) + 1
+	box := image.Rect(x-ext, y-ext, x+w+ext, y+h+ext).Intersect(r.img.Bounds())
+	// Pixels this far inside the box are clear of the ring and skipped.
+	inset := ext + int(math.Ceil(rad))
+	skipX0, skipX1 := x+inset, x+w-inset
+	for py := box.Min.Y; py < box.Max.Y; py++ {
+		inner := py >= y+inset && py < y+h-inset
+		qy := math.Abs(float64(py)+0.5-cy) - by
+		for px := box.Min.X; px < box.Max.X; px++ {
+			if inner && px == skipX0 && skipX0 < skipX1 {
+				px = skipX1
+				if px >= box.Max.X {
+					break
+				}
+			}
+			// Signed distance from the pixel centre to the outline.
+			qx := math.Abs(float64(px)+0.5-cx) - bx
+			d := math.Hypot(math.Max(qx, 0), math.Max(qy, 0)) + math.Min(math.Max(qx, qy), 0) - rad
+			cov := half - math.Abs(d) + 0.5
+			if cov <= 0 {
+				continue
+			}
+			if cov > 1 {
+				cov = 1
+			}
+			r.blendPixel(px, py, color.RGBA{R: c.R, G: c.G, B: c.B, A: uint8(float64(c.A)*cov + 0.5)})
+		}
+	}
+}
+
+// roundedRectPoints returns the closed outline of a rounded rectangle, traced
+// clockwise from the end of the top-left corner arc.
+func roundedRectPoints(x, y, w, h, radius int) []fpoint {
+	fx, fy := float64(x), float64(y)
+	fw, fh := float64(w-1), float64(h-1)
+	rad := math.Min(float64(radius), math.Min(fw, fh)/2)
+	if rad < 0 {
+		rad = 0
+	}
+	steps := maxInt(int(rad*math.Pi/4), 4)
+	pts := make([]fpoint, 0, 4*(steps+1)+1)
+	corner := func(cx, cy, start float64) {
+		for i := 0; i <= steps; i++ {
+			a := start + float64(i)/float64(steps)*math.Pi/2
+			pts = append(pts, fpoint{cx + rad*math.Cos(a), cy + rad*math.Sin(a)})
+		}
+	}
+	corner(fx+fw-rad, fy+rad, 1.5*math.Pi)
+	corner(fx+fw-rad, fy+fh-rad, 0)
+	corner(fx+rad, fy+fh-rad, 0.5*math.Pi)
+	corner(fx+rad, fy+rad, math.Pi)
+	return append(pts, pts[0])
+}
+
+// drawRoundedRectBorder strokes a rounded rectangle, using a single continuous
+// dash pattern around the whole perimeter for dashed and dotted styles.
+func (r *renderer) drawRoundedRectBorder(x, y, w, h, radius int, c color.RGBA, lineWidth int, style BorderStyle, pattern []float64) {
+	dashes := dashArray(style, pattern, lineWidth)
+	if dashes == nil {
+		r.drawRoundedRect(x, y, w, h, radius, c, lineWidth)
+		return
+	}
+	r.drawDashedPolylineAA(roundedRectPoints(x, y, w, h, radius), c, lineWidth, dashes)
+}
+
 func (r *renderer) drawArc(cx, cy, w, h int, c color.RGBA, startAngle, endAngle float64, lineWidth int) {
 	rx := float64(w) / 2
 	ry := float64(h) / 2
@@ -3323,26 +5130,61 @@ type fpoint struct{ x, y float64 }
 
 // fillPolygon fills a polygon using scanline algorithm with sort.Float64s.
 func (r *renderer) fillPolygon(pts []fpoint, c color.RGBA) {
-	if len(pts) < 3 {
+	r.fillRings([][]fpoint{pts}, c)
+}
+
+// fillRings fills polygons together by the even-odd rule, so a ring inside
+// another one cuts a hole in it.
+func (r *renderer) fillRings(rings [][]fpoint, c color.RGBA) {
+	minY, maxY, n, ok := ringsSpan(rings)
+	if !ok {
 		return
 	}
-	minY, maxY := pts[0].y, pts[0].y
-	for _, p := range pts[1:] {
-		if p.y < minY {
-			minY = p.y
-		}
-		if p.y > maxY {
-			maxY = p.y
-		}
-	}
-
-	n := len(pts)
 	// Pre-allocate intersection buffer
 	intersections := make([]float64, 0, n)
 
 	for y := int(minY); y <= int(maxY); y++ {
 		fy := float64(y) + 0.5
-		intersections = intersections[:0]
+		intersections = ringIntersections(intersections[:0], rings, fy)
+		for i := 0; i+1 < len(intersections); i += 2 {
+			x1 := int(math.Ceil(intersections[i]))
+			x2 := int(math.Floor(intersections[i+1]))
+			if x1 <= x2 {
+				if c.A == 255 {
+					r.fillRectFast(image.Rect(x1, y, x2+1, y+1), c)
+				} else {
+					r.fillRectBlend(image.Rect(x1, y, x2+1, y+1), c)
+				}
+			}
+		}
+	}
+}
+
+// ringsSpan returns the vertical extent and total edge count of rings,
+// or false when they enclose no area.
+func ringsSpan(rings [][]fpoint) (minY, maxY float64, n int, ok bool) {
+	minY, maxY = math.Inf(1), math.Inf(-1)
+	for _, ring := range rings {
+		if len(ring) < 3 {
+			continue
+		}
+		for _, p := range ring {
+			minY = math.Min(minY, p.y)
+			maxY = math.Max(maxY, p.y)
+		}
+		n += len(ring)
+	}
+	return minY, maxY, n, n > 0
+}
+
+// ringIntersections appends the sorted x coordinates where the scanline
+// at fy crosses the edges of rings.
+func ringIntersections(dst []float64, rings [][]fpoint, fy float64) []float64 {
+	for _, pts := range rings {
+		n := len(pts)
+		if n < 3 {
+			continue
+		}
 		for i := 0; i < n; i++ {
 			j := (i + 1) % n
 			py1, py2 := pts[i].y, pts[j].y
@@ -3353,48 +5195,37 @@ func (r *renderer) fillPolygon(pts []fpoint, c color.RGBA) {
 				continue
 			}
 			dy := pts[j].y - pts[i].y
-			if dy == 0 {
-				continue
-			}
-			t := (fy - pts[i].y) / dy
-			intersections = append(intersections, pts[i].x+t*(pts[j].x-pts[i].x))
-		}
-		sort.Float64s(intersections)
-		for i := 0; i+1 < len(intersections); i += 2 {
-			x1 := int(math.Ceil(intersections[i]))
-			x2 := int(math.Floor(intersections[i+1]))
-			if x1 <= x2 {
-				if c.A == 255 {
-					r.fillRectFast(image.Rect(x1, y, x2+1, y+1), c)
-				} else {
-					r.fillRectBlend(image.Rect(x1, y, x2+1, y+1), c)
-				}
+			if dy == 0 {
+				continue
 			}
+			t := (fy - pts[i].y) / dy
+			dst = append(dst, pts[i].x+t*(pts[j].x-pts[i].x))
 		}
 	}
+	sort.Float64s(dst)
+	return dst
 }
 
 func (r *renderer) fillPolygonGradient(pts []fpoint, fill *Fill) {
-	if len(pts) < 3 || fill == nil {
+	r.fillRingsGradient([][]fpoint{pts}, fill)
+}
+
+// fillRingsGradient is fillRings with a linear gradient across the
+// bounding box of rings.
+func (r *renderer) fillRingsGradient(rings [][]fpoint, fill *Fill) {
+	minY, maxY, n, ok := ringsSpan(rings)
+	if !ok || fill == nil {
 		return
 	}
-	startC := argbToRGBA(fill.Color)
-	endC := argbToRGBA(fill.EndColor)
+	startC := r.rgba(fill.Color)
+	endC := r.rgba(fill.EndColor)
 
 	// Compute bounding box
-	minX, minY, maxX, maxY := pts[0].x, pts[0].y, pts[0].x, pts[0].y
-	for _, p := range pts[1:] {
-		if p.x < minX {
-			minX = p.x
-		}
-		if p.y < minY {
-			minY = p.y
-		}
-		if p.x > maxX {
-			maxX = p.x
-		}
-		if p.y > maxY {
-			maxY = p.y
+	minX, maxX := math.Inf(1), math.Inf(-1)
+	for _, ring := range rings {
+		for _, p := range ring {
+			minX = math.Min(minX, p.x)
+			maxX = math.Max(maxX, p.x)
 		}
 	}
 	bw := maxX - minX
@@ -3414,7 +5245,6 @@ func (r *renderer) fillPolygonGradient(pts []fpoint, fill *Fill) {
 	}
 	invMaxProj := 1.0 / (2 * maxProj)
 
-	n := len(pts)
 	intersections := make([]float64, 0, n)
 	bounds := r.img.Bounds()
 	pix := r.img.Pix
@@ -3425,24 +5255,7 @@ func (r *renderer) fillPolygonGradient(pts []fpoint, fill *Fill) {
 			continue
 		}
 		fy := float64(y) + 0.5
-		intersections = intersections[:0]
-		for i := 0; i < n; i++ {
-			j := (i + 1) % n
-			py1, py2 := pts[i].y, pts[j].y
-			if py1 > py2 {
-				py1, py2 = py2, py1
-			}
-			if fy < py1 || fy >= py2 {
-				continue
-			}
-			dy := pts[j].y - pts[i].y
-			if dy == 0 {
-				continue
-			}
-			t := (fy - pts[i].y) / dy
-			intersections = append(intersections, pts[i].x+t*(pts[j].x-pts[i].x))
-		}
-		sort.Float64s(intersections)
+		intersections = ringIntersections(intersections[:0], rings, fy)
 
 		dyf := float64(y) - minY - cy
 		rowBase := dyf*sinA + maxProj
@@ -3479,7 +5292,11 @@ func (r *renderer) fillPolygonGradient(pts []fpoint, fill *Fill) {
 	}
 }
 
-func (r *renderer) drawPolygon(pts []fpoint, c color.RGBA, width int) {
+// drawPolygon strokes a closed polygon, joining its corners as b asks.
+func (r *renderer) drawPolygon(pts []fpoint, c color.RGBA, width int, b *Border) {
+	if b != nil && r.strokeJoinedPolyline(pts, true, c, width, b.Join, b.MiterLimit) {
+		return
+	}
 	n := len(pts)
 	for i := 0; i < n; i++ {
 		j := (i + 1) % n
@@ -3487,222 +5304,6 @@ func (r *renderer) drawPolygon(pts []fpoint, c color.RGBA, width int) {
 	}
 }
 
-func (r *renderer) fillTriangle(x, y, w, h int, c color.RGBA) {
-	r.fillPolygon([]fpoint{
-		{float64(x) + float64(w)/2, float64(y)},
-		{float64(x + w), float64(y + h)},
-		{float64(x), float64(y + h)},
-	}, c)
-}
-
-func (r *renderer) drawTriangle(x, y, w, h int, c color.RGBA, width int) {
-	r.drawPolygon([]fpoint{
-		{float64(x) + float64(w)/2, float64(y)},
-		{float64(x + w), float64(y + h)},
-		{float64(x), float64(y + h)},
-	}, c, width)
-}
-
-func (r *renderer) fillDiamond(x, y, w, h int, c color.RGBA) {
-	cx, cy := float64(x)+float64(w)/2, float64(y)+float64(h)/2
-	r.fillPolygon([]fpoint{{cx, float64(y)}, {float64(x + w), cy}, {cx, float64(y + h)}, {float64(x), cy}}, c)
-}
-
-func (r *renderer) drawDiamond(x, y, w, h int, c color.RGBA, width int) {
-	cx, cy := float64(x)+float64(w)/2, float64(y)+float64(h)/2
-	r.drawPolygon([]fpoint{{cx, float64(y)}, {float64(x + w), cy}, {cx, float64(y + h)}, {float64(x), cy}}, c, width)
-}
-
-func (r *renderer) fillRegularPolygon(x, y, w, h, sides int, startAngle float64, c color.RGBA) {
-	pts := regularPolygonPoints(x, y, w, h, sides, startAngle)
-	r.fillPolygon(pts, c)
-}
-
-func regularPolygonPoints(x, y, w, h, sides int, startAngle float64) []fpoint {
-	cx := float64(x) + float64(w)/2
-	cy := float64(y) + float64(h)/2
-	rx := float64(w) / 2
-	ry := float64(h) / 2
-	pts := make([]fpoint, sides)
-	for i := 0; i < sides; i++ {
-		angle := startAngle + float64(i)*2*math.Pi/float64(sides)
-		pts[i] = fpoint{cx + rx*math.Cos(angle), cy + ry*math.Sin(angle)}
-	}
-	return pts
-}
-
-func (r *renderer) fillPentagon(x, y, w, h int, c color.RGBA) {
-	r.fillRegularPolygon(x, y, w, h, 5, -math.Pi/2, c)
-}
-
-func (r *renderer) fillHexagon(x, y, w, h int, c color.RGBA) {
-	r.fillRegularPolygon(x, y, w, h, 6, 0, c)
-}
-
-// flowChartPreparationPoints returns the 6 vertices for the OOXML flowChartPreparation shape.
-// Unlike a regular hexagon inscribed in an ellipse, this shape fills the entire bounding box:
-// left/right points at mid-height, top/bottom edges span full width minus an inset.
-func flowChartPreparationPoints(x, y, w, h int) []fpoint {
-	inset := float64(w) / 5.0
-	fx, fy, fw, fh := float64(x), float64(y), float64(w), float64(h)
-	return []fpoint{
-		{fx, fy + fh/2},          // left point
-		{fx + inset, fy},          // top-left
-		{fx + fw - inset, fy},     // top-right
-		{fx + fw, fy + fh/2},     // right point
-		{fx + fw - inset, fy + fh}, // bottom-right
-		{fx + inset, fy + fh},     // bottom-left
-	}
-}
-
-func (r *renderer) fillFlowChartPreparation(x, y, w, h int, c color.RGBA) {
-	pts := flowChartPreparationPoints(x, y, w, h)
-	r.fillPolygon(pts, c)
-}
-
-
-func (r *renderer) fillStar(x, y, w, h, points int, c color.RGBA) {
-	cx := float64(x) + float64(w)/2
-	cy := float64(y) + float64(h)/2
-	outerRx, outerRy := float64(w)/2, float64(h)/2
-	innerRx, innerRy := outerRx*0.4, outerRy*0.4
-	n := points * 2
-	pts := make([]fpoint, n)
-	for i := 0; i < n; i++ {
-		angle := -math.Pi/2 + float64(i)*2*math.Pi/float64(n)
-		rx, ry := outerRx, outerRy
-		if i%2 == 1 {
-			rx, ry = innerRx, innerRy
-		}
-		pts[i] = fpoint{cx + rx*math.Cos(angle), cy + ry*math.Sin(angle)}
-	}
-	r.fillPolygon(pts, c)
-}
-
-func (r *renderer) fillArrowRight(x, y, w, h int, c color.RGBA) {
-	shaftH := float64(h) * 0.4
-	headW := float64(w) * 0.35
-	shaftW := float64(w) - headW
-	top := float64(y) + (float64(h)-shaftH)/2
-	bot := top + shaftH
-	r.fillPolygon([]fpoint{
-		{float64(x), top}, {float64(x) + shaftW, top}, {float64(x) + shaftW, float64(y)},
-		{float64(x + w), float64(y) + float64(h)/2},
-		{float64(x) + shaftW, float64(y + h)}, {float64(x) + shaftW, bot}, {float64(x), bot},
-	}, c)
-}
-
-func (r *renderer) fillArrowLeft(x, y, w, h int, c color.RGBA) {
-	shaftH := float64(h) * 0.4
-	headW := float64(w) * 0.35
-	top := float64(y) + (float64(h)-shaftH)/2
-	bot := top + shaftH
-	r.fillPolygon([]fpoint{
-		{float64(x + w), top}, {float64(x) + headW, top}, {float64(x) + headW, float64(y)},
-		{float64(x), float64(y) + float64(h)/2},
-		{float64(x) + headW, float64(y + h)}, {float64(x) + headW, bot}, {float64(x + w), bot},
-	}, c)
-}
-
-func (r *renderer) fillArrowUp(x, y, w, h int, c color.RGBA) {
-	shaftW := float64(w) * 0.4
-	headH := float64(h) * 0.35
-	left := float64(x) + (float64(w)-shaftW)/2
-	right := left + shaftW
-	r.fillPolygon([]fpoint{
-		{float64(x) + float64(w)/2, float64(y)},
-		{float64(x + w), float64(y) + headH}, {right, float64(y) + headH},
-		{right, float64(y + h)}, {left, float64(y + h)},
-		{left, float64(y) + headH}, {float64(x), float64(y) + headH},
-	}, c)
-}
-
-func (r *renderer) fillArrowDown(x, y, w, h int, c color.RGBA) {
-	shaftW := float64(w) * 0.4
-	headH := float64(h) * 0.35
-	shaftTop := float64(h) - headH
-	left := float64(x) + (float64(w)-shaftW)/2
-	right := left + shaftW
-	r.fillPolygon([]fpoint{
-		{left, float64(y)}, {right, float64(y)},
-		{right, float64(y) + shaftTop}, {float64(x + w), float64(y) + shaftTop},
-		{float64(x) + float64(w)/2, float64(y + h)},
-		{float64(x), float64(y) + shaftTop}, {left, float64(y) + shaftTop},
-	}, c)
-}
-
-func (r *renderer) fillHeart(x, y, w, h int, c color.RGBA) {
-	cx := float64(x) + float64(w)/2
-	topY := float64(y) + float64(h)*0.3
-	halfW := float64(w) / 2
-	hScale := float64(h) * 0.7
-
-	for py := y; py < y+h; py++ {
-		ny := 1 - (float64(py)-topY)/hScale
-		ny2 := ny * ny
-		ny3 := ny2 * ny
-		for px := x; px < x+w; px++ {
-			nx := (float64(px) - cx) / halfW
-			nx2 := nx * nx
-			val := (nx2 + ny2 - 1)
-			val = val * val * val
-			val -= nx2 * ny3
-			if val <= 0 {
-				r.blendPixel(px, py, c)
-			}
-		}
-	}
-}
-
-func (r *renderer) fillPlus(x, y, w, h int, c color.RGBA) {
-	armW := w / 3
-	armH := h / 3
-	r.fillRectBlend(image.Rect(x, y+armH, x+w, y+h-armH), c)
-	r.fillRectBlend(image.Rect(x+armW, y, x+w-armW, y+h), c)
-}
-
-func (r *renderer) fillChevron(x, y, w, h int, c color.RGBA) {
-	notch := w / 4
-	pts := []fpoint{
-		{float64(x), float64(y)},
-		{float64(x + w - notch), float64(y)},
-		{float64(x + w), float64(y + h/2)},
-		{float64(x + w - notch), float64(y + h)},
-		{float64(x), float64(y + h)},
-		{float64(x + notch), float64(y + h/2)},
-	}
-	r.fillPolygon(pts, c)
-}
-
-func (r *renderer) fillParallelogram(x, y, w, h int, c color.RGBA) {
-	offset := w / 4
-	pts := []fpoint{
-		{float64(x + offset), float64(y)},
-		{float64(x + w), float64(y)},
-		{float64(x + w - offset), float64(y + h)},
-		{float64(x), float64(y + h)},
-	}
-	r.fillPolygon(pts, c)
-}
-
-func (r *renderer) fillLeftRightArrow(x, y, w, h int, c color.RGBA) {
-	headW := w / 4
-	bodyH := h / 3
-	pts := []fpoint{
-		{float64(x), float64(y + h/2)},
-		{float64(x + headW), float64(y)},
-		{float64(x + headW), float64(y + bodyH)},
-		{float64(x + w - headW), float64(y + bodyH)},
-		{float64(x + w - headW), float64(y)},
-		{float64(x + w), float64(y + h/2)},
-		{float64(x + w - headW), float64(y + h)},
-		{float64(x + w - headW), float64(y + h - bodyH)},
-		{float64(x + headW), float64(y + h - bodyH)},
-		{float64(x + headW), float64(y + h)},
-	}
-	r.fillPolygon(pts, c)
-}
-
 func (r *renderer) fillRtTriangle(x, y, w, h int, c color.RGBA) {
 	pts := []fpoint{
 		{float64(x), float64(y + h)},
@@ -3712,17 +5313,6 @@ func (r *renderer) fillRtTriangle(x, y, w, h int, c color.RGBA) {
 	r.fillPolygon(pts, c)
 }
 
-func (r *renderer) fillHomePlate(x, y, w, h int, c color.RGBA) {
-	notch := w / 5
-	pts := []fpoint{
-		{float64(x), float64(y)},
-		{float64(x + w - notch), float64(y)},
-		{float64(x + w), float64(y + h/2)},
-		{float64(x + w - notch), float64(y + h)},
-		{float64(x), float64(y + h)},
-	}
-	r.fillPolygon(pts, c)
-}
 // fillWedgeRoundRectCallout draws a rounded-rectangle callout shape.
 // OOXML wedgeRoundRectCallout has three adjust values:
 //   adj1: X offset of callout tip from center (1/100000 of width, default -20833)
@@ -4230,13 +5820,13 @@ func (r *renderer) getFace(f *Font) font.Face {
 	// 1pt = 12700 EMU; scaleX converts EMU to pixels.
 	sizePixels := sizePt * 12700.0 * r.scaleX
 
-	face := r.fontCache.GetFace(f.Name, sizePixels, f.Bold, f.Italic)
+	face := r.renderFace(f.Name, sizePixels, f.Bold, f.Italic)
 	if face != nil {
 		return face
 	}
 	// Try East Asian font name if specified
 	if f.NameEA != "" {
-		face = r.fontCache.GetFace(f.NameEA, sizePixels, f.Bold, f.Italic)
+		face = r.renderFace(f.NameEA, sizePixels, f.Bold, f.Italic)
 		if face != nil {
 			return face
 		}
@@ -4249,7 +5839,7 @@ func (r *renderer) getFace(f *Font) font.Face {
 		"Noto Sans CJK SC", "Noto Sans SC", "WenQuanYi Micro Hei",
 		"Arial", "Helvetica", "DejaVu Sans",
 	} {
-		face = r.fontCache.GetFace(fallback, sizePixels, f.Bold, f.Italic)
+		face = r.renderFace(fallback, sizePixels, f.Bold, f.Italic)
 		if face != nil {
 			return face
 		}
@@ -4257,6 +5847,84 @@ func (r *renderer) getFace(f *Font) font.Face {
 	return basicfont.Face7x13
 }
 
+// renderFace returns the face used to draw text, with the hinting and glyph
+// tuning selected in the render options, or nil if the font is not found.
+func (r *renderer) renderFace(name string, sizePixels float64, bold, italic bool) font.Face {
+	hinting := font.HintingFull
+	if r.textHinting == TextHintingVertical {
+		hinting = font.HintingVertical
+	}
+	face := r.fontCache.getFace(name, sizePixels, bold, italic, hinting)
+	if face == nil || r.textTuning == nil {
+		return face
+	}
+	return tunedFace{Face: face, tuning: r.textTuning}
+}
+
+// glyphTuning adjusts glyph coverage masks before they are composited.
+type glyphTuning struct {
+	lut      [256]uint8 // coverage remapping for the gamma
+	embolden float64    // fraction of each pixel's coverage spread to its right neighbour
+}
+
+// newGlyphTuning returns the tuning for the given gamma and stem darkening,
+// or nil if neither changes the glyphs.
+func newGlyphTuning(gamma, stem float64) *glyphTuning {
+	if gamma <= 0 {
+		gamma = 1
+	}
+	stem = math.Max(0, math.Min(stem, 1))
+	if gamma == 1 && stem == 0 {
+		return nil
+	}
+	t := &glyphTuning{embolden: stem}
+	for i := range t.lut {
+		t.lut[i] = uint8(255*math.Pow(float64(i)/255, 1/gamma) + 0.5)
+	}
+	return t
+}
+
+// tunedFace wraps a face so that its glyph masks pass through a glyphTuning.
+// Advances and metrics are those of the wrapped face.
+type tunedFace struct {
+	font.Face
+	tuning *glyphTuning
+}
+
+func (f tunedFace) Glyph(dot fixed.Point26_6, ch rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	dr, mask, maskp, advance, ok := f.Face.Glyph(dot, ch)
+	if !ok || dr.Empty() {
+		return dr, mask, maskp, advance, ok
+	}
+	at := func(x, y int) float64 {
+		if m, ok := mask.(*image.Alpha); ok {
+			return float64(m.AlphaAt(maskp.X+x, maskp.Y+y).A)
+		}
+		_, _, _, a := mask.At(maskp.X+x, maskp.Y+y).RGBA()
+		return float64(a >> 8)
+	}
+	w, h := dr.Dx(), dr.Dy()
+	extra := 0
+	if f.tuning.embolden > 0 {
+		extra = 1
+	}
+	out := image.NewAlpha(image.Rect(0, 0, w+extra, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w+extra; x++ {
+			var a float64
+			if x < w {
+				a = at(x, y)
+			}
+			if x > 0 && extra > 0 {
+				a = math.Max(a, f.tuning.embolden*at(x-1, y))
+			}
+			out.Pix[y*out.Stride+x] = f.tuning.lut[uint8(a+0.5)]
+		}
+	}
+	dr.Max.X += extra
+	return dr, out, image.Point{}, advance, true
+}
+
 // getCJKFace returns a font face suitable for CJK characters.
 // It tries NameEA first, then common CJK fonts.
 func (r *renderer) getCJKFace(f *Font) font.Face {
@@ -4275,7 +5943,7 @@ func (r *renderer) getCJKFace(f *Font) font.Face {
 
 	// Try East Asian font name first
 	if f.NameEA != "" {
-		face := r.fontCache.GetFace(f.NameEA, sizePixels, f.Bold, f.Italic)
+		face := r.renderFace(f.NameEA, sizePixels, f.Bold, f.Italic)
 		if face != nil {
 			return face
 		}
@@ -4287,7 +5955,7 @@ func (r *renderer) getCJKFace(f *Font) font.Face {
 		"Malgun Gothic", "Gulim",
 		"Noto Sans CJK SC", "Noto Sans SC", "WenQuanYi Micro Hei",
 	} {
-		face := r.fontCache.GetFace(name, sizePixels, f.Bold, f.Italic)
+		face := r.renderFace(name, sizePixels, f.Bold, f.Italic)
 		if face != nil {
 			return face
 		}
@@ -4397,35 +6065,13 @@ func (r *renderer) buildParaTextRuns(elements []ParagraphElement) []textRun {
 			if f == nil {
 				f = NewFont()
 			}
-			if containsCJK(e.text) && r.fontCache != nil {
-				sizePt := float64(f.Size)
-				if sizePt <= 0 {
-					sizePt = 10
-				}
-				if r.fontScale > 0 && r.fontScale != 1.0 {
-					sizePt *= r.fontScale
-				}
-				scaledPt := sizePt * 12700.0 * r.scaleX
-				latinFace := r.fontCache.GetFace(f.Name, scaledPt, f.Bold, f.Italic)
-				if latinFace == nil {
-					latinFace = r.getFace(f)
-				}
-				cjkFace := r.getCJKFace(f)
-				latinMeasure := r.getMeasureFace(f)
-				cjkMeasure := r.getCJKMeasureFace(f)
-				subRuns := r.splitRunByCJK(e.text, f, latinFace, cjkFace, latinMeasure, cjkMeasure)
-				runs = append(runs, subRuns...)
+			var fr []textRun
+			if f.NameSym != "" && containsSymbolRange(e.text) {
+				fr = r.splitRunBySymbol(e.text, f)
 			} else {
-				face := r.getFace(f)
-				mf := r.getMeasureFace(f)
-				runs = append(runs, textRun{
-					text:        e.text,
-					font:        f,
-					face:        face,
-					measureFace: mf,
-					width:       measureStringWithKern(face, e.text).Ceil(),
-				})
+				fr = r.textRunsFor(e.text, f)
 			}
+			runs = append(runs, r.applyCharSpacing(fr, f)...)
 		case *BreakElement:
 			runs = append(runs, textRun{text: "\n"})
 		}
@@ -4433,6 +6079,105 @@ func (r *renderer) buildParaTextRuns(elements []ParagraphElement) []textRun {
 	return runs
 }
 
+// textRunsFor measures text set in font f, splitting it into CJK and
+// non-CJK runs when the text contains CJK characters.
+func (r *renderer) textRunsFor(text string, f *Font) []textRun {
+	if containsCJK(text) && r.fontCache != nil {
+		sizePt := float64(f.Size)
+		if sizePt <= 0 {
+			sizePt = 10
+		}
+		if r.fontScale > 0 && r.fontScale != 1.0 {
+			sizePt *= r.fontScale
+		}
+		scaledPt := sizePt * 12700.0 * r.scaleX
+		latinFace := r.fontCache.GetFace(f.Name, scaledPt, f.Bold, f.Italic)
+		if latinFace == nil {
+			latinFace = r.getFace(f)
+		}
+		cjkFace := r.getCJKFace(f)
+		latinMeasure := r.getMeasureFace(f)
+		cjkMeasure := r.getCJKMeasureFace(f)
+		return r.splitRunByCJK(text, f, latinFace, cjkFace, latinMeasure, cjkMeasure)
+	}
+	face := r.getFace(f)
+	return []textRun{{
+		text:        text,
+		font:        f,
+		face:        face,
+		measureFace: r.getMeasureFace(f),
+		width:       measureStringWithKern(face, text).Ceil(),
+	}}
+}
+
+// splitRunBySymbol splits a run with an <a:sym> font so that characters in
+// the symbol range (U+F000–U+F0FF, as inserted from Wingdings and similar
+// fonts) are drawn with the symbol font through its PUA cmap, the same way
+// as symbol bullets. When the symbol font is not installed, those characters
+// are mapped to Unicode equivalents in the run's own font.
+func (r *renderer) splitRunBySymbol(text string, f *Font) []textRun {
+	symAvailable := r.fontCache != nil && r.fontCache.GetFace(f.NameSym, 12, false, false) != nil
+	symFont := *f
+	symFont.Name = f.NameSym
+	symFont.NameEA = ""
+
+	var runs []textRun
+	var buf strings.Builder
+	inSym := false
+	flush := func() {
+		if buf.Len() == 0 {
+			return
+		}
+		seg := buf.String()
+		buf.Reset()
+		if !inSym {
+			runs = append(runs, r.textRunsFor(seg, f)...)
+			return
+		}
+		if !symAvailable {
+			var mapped strings.Builder
+			for _, ch := range seg {
+				mapped.WriteString(mapSymbolChar(f.NameSym, string(ch-0xF000)))
+			}
+			runs = append(runs, r.textRunsFor(mapped.String(), f)...)
+			return
+		}
+		face := r.getFace(&symFont)
+		runs = append(runs, textRun{
+			text:        seg,
+			font:        &symFont,
+			face:        face,
+			measureFace: r.getMeasureFace(&symFont),
+			width:       measureStringWithKern(face, seg).Ceil(),
+		})
+	}
+	for _, ch := range text {
+		if sym := isSymbolRange(ch); sym != inSym {
+			flush()
+			inSym = sym
+		}
+		buf.WriteRune(ch)
+	}
+	flush()
+	return runs
+}
+
+// isSymbolRange reports whether ch lies in the Private Use Area block that
+// symbol fonts map their glyphs to.
+func isSymbolRange(ch rune) bool {
+	return ch >= 0xF000 && ch <= 0xF0FF
+}
+
+// containsSymbolRange reports whether text contains symbol-range characters.
+func containsSymbolRange(text string) bool {
+	for _, ch := range text {
+		if isSymbolRange(ch) {
+			return true
+		}
+	}
+	return false
+}
+
 // splitRunByCJK splits a text run into sub-runs where CJK and non-CJK
 // segments use different font faces. This ensures CJK characters are
 // rendered with a CJK-capable font even when the primary font is Latin-only.
@@ -4532,6 +6277,8 @@ type textLine struct {
 	ascent     int
 	descent    int
 	lineHeight int
+	// Unrounded ascent and line height, for TextLineSnapNone.
+	exactAscent, exactHeight float64
 }
 
 // buildTextLine measures a slice of textRuns and returns a textLine.
@@ -4539,6 +6286,7 @@ func (r *renderer) buildTextLine(runs []textRun) textLine {
 	var tl textLine
 	tl.runs = runs
 	maxHeight := 0 // track font's recommended line-to-line height (includes line gap)
+	var exactDescent, exactMaxHeight float64
 	hasCJK := false
 	for _, run := range runs {
 		tl.width += run.width
@@ -4564,6 +6312,9 @@ func (r *renderer) buildTextLine(runs []textRun) textLine {
 		if h := metrics.Height.Ceil(); h > maxHeight {
 			maxHeight = h
 		}
+		tl.exactAscent = math.Max(tl.exactAscent, float64(metrics.Ascent)/64)
+		exactDescent = math.Max(exactDescent, float64(metrics.Descent)/64)
+		exactMaxHeight = math.Max(exactMaxHeight, float64(metrics.Height)/64)
 	}
 	// Use the font's recommended height (ascent + descent + line gap) so that
 	// default single spacing matches PowerPoint's behaviour. When the font
@@ -4582,12 +6333,63 @@ func (r *renderer) buildTextLine(runs []textRun) textLine {
 			tl.lineHeight = adSum
 		}
 	}
+	tl.exactHeight = math.Max(exactMaxHeight, tl.exactAscent+exactDescent)
+	if hasCJK {
+		tl.exactHeight = tl.exactAscent + exactDescent
+	}
 	if tl.lineHeight < 1 {
 		tl.lineHeight = 14
 	}
 	return tl
 }
 
+// lineAdvance returns the distance from the top of a line to the top of
+// the next one, before paragraph spacing, for a paragraph's line spacing
+// (negative for spcPct in thousandths of a percent, positive for spcPts in
+// hundredths of a point, 0 for single). With TextLineSnapPixel the result
+// is a whole number of pixels.
+func (r *renderer) lineAdvance(tl textLine, lineSpacing int) float64 {
+	if r.lineSnap == TextLineSnapNone {
+		h := tl.exactHeight
+		if h <= 0 {
+			h = float64(tl.lineHeight)
+		}
+		if lineSpacing < 0 {
+			return h * float64(-lineSpacing) / 100000.0
+		} else if lineSpacing > 0 {
+			return float64(lineSpacing) * 127.0 * r.scaleY
+		}
+		return h
+	}
+	lh := tl.lineHeight
+	if lineSpacing < 0 {
+		// spcPct: negative value, percentage * 1000 (e.g. -150000 = 150%)
+		lh = int(float64(lh) * float64(-lineSpacing) / 100000.0)
+	} else if lineSpacing > 0 {
+		// spcPts: hundredths of a point (e.g. 1200 = 12pt)
+		lh = r.hundredthPtToPixelY(lineSpacing)
+	}
+	return float64(lh)
+}
+
+// paragraphSpace returns the pixel height of a paragraph's space before or
+// after: hundredths of a point, or for negative values thousandths of a
+// percent of the single-spaced height of the adjacent line tl.
+func (r *renderer) paragraphSpace(v int, tl textLine) int {
+	if v >= 0 {
+		return r.hundredthPtToPixelY(v)
+	}
+	return int(math.Round(r.lineAdvance(tl, 0) * float64(-v) / 100000.0))
+}
+
+// lineBaseline returns the baseline of a line whose top is at top.
+func (r *renderer) lineBaseline(tl textLine, top float64) int {
+	if r.lineSnap == TextLineSnapNone && tl.exactHeight > 0 {
+		return int(math.Round(top + tl.exactAscent))
+	}
+	return int(math.Round(top)) + tl.ascent
+}
+
 // measureParagraphsHeight estimates the total pixel height needed to render
 // the given paragraphs within the specified width, replicating the same line
 // building and spacing logic used by drawParagraphs.
@@ -4596,7 +6398,7 @@ func (r *renderer) measureParagraphsHeight(paragraphs []*Paragraph, w, h int, an
 		return 0
 	}
 	type lineInfo struct {
-		lineHeight  int
+		line        textLine
 		spaceBefore int
 		spaceAfter  int
 		lineSpacing int
@@ -4641,34 +6443,28 @@ func (r *renderer) measureParagraphsHeight(paragraphs []*Paragraph, w, h int, an
 		}
 		for i, line := range lines {
 			li := lineInfo{
-				lineHeight:  line.lineHeight,
+				line:        line,
 				lineSpacing: para.lineSpacing,
 			}
 			if i == 0 {
-				li.spaceBefore = r.hundredthPtToPixelY(para.spaceBefore)
+				li.spaceBefore = r.paragraphSpace(para.spaceBefore, line)
 			}
 			if i == len(lines)-1 {
-				li.spaceAfter = r.hundredthPtToPixelY(para.spaceAfter)
+				li.spaceAfter = r.paragraphSpace(para.spaceAfter, line)
 			}
 			allLines = append(allLines, li)
 		}
 	}
 
-	totalH := 0
+	totalH := 0.0
 	for i, li := range allLines {
 		if i > 0 {
-			totalH += li.spaceBefore
-		}
-		lh := li.lineHeight
-		if li.lineSpacing < 0 {
-			lh = int(float64(lh) * float64(-li.lineSpacing) / 100000.0)
-		} else if li.lineSpacing > 0 {
-			lh = r.hundredthPtToPixelY(li.lineSpacing)
+			totalH += float64(li.spaceBefore)
 		}
-		totalH += lh
-		totalH += li.spaceAfter
+		totalH += r.lineAdvance(li.line, li.lineSpacing)
+		totalH += float64(li.spaceAfter)
 	}
-	return totalH
+	return int(math.Round(totalH))
 }
 
 // measureMaxLineWidth returns the maximum line width across all paragraphs
@@ -4721,8 +6517,10 @@ func (r *renderer) measureMaxLineWidth(paragraphs []*Paragraph, w int, wordWrap
 }
 
 
-// drawParagraphs renders paragraphs within the given bounding box.
-func (r *renderer) drawParagraphs(paragraphs []*Paragraph, x, y, w, h int, anchor TextAnchorType, wordWrap bool) {
+// drawParagraphs renders paragraphs within the given bounding box. With
+// anchorCtr the text block, as wide as its longest line, is centered in the
+// box and lines are aligned within the block.
+func (r *renderer) drawParagraphs(paragraphs []*Paragraph, x, y, w, h int, anchor TextAnchorType, anchorCtr, wordWrap bool) {
 	if len(paragraphs) == 0 {
 		return
 	}
@@ -4802,33 +6600,25 @@ func (r *renderer) drawParagraphs(paragraphs []*Paragraph, x, y, w, h int, ancho
 				isLast:      i == len(lines)-1,
 			}
 			if i == 0 {
-				// spaceBefore is in hundredths of a point from spcPts
-				li.spaceBefore = r.hundredthPtToPixelY(para.spaceBefore)
+				li.spaceBefore = r.paragraphSpace(para.spaceBefore, line)
 			}
 			if i == len(lines)-1 {
-				li.spaceAfter = r.hundredthPtToPixelY(para.spaceAfter)
+				li.spaceAfter = r.paragraphSpace(para.spaceAfter, line)
 			}
 			allLines = append(allLines, li)
 		}
 	}
 
 	// Calculate total height
-	totalH := 0
+	totalHF := 0.0
 	for i, li := range allLines {
 		if i > 0 {
-			totalH += li.spaceBefore
+			totalHF += float64(li.spaceBefore)
 		}
-		lh := li.line.lineHeight
-		if li.lineSpacing < 0 {
-			// spcPct: negative value, percentage * 1000 (e.g. -150000 = 150%)
-			lh = int(float64(lh) * float64(-li.lineSpacing) / 100000.0)
-		} else if li.lineSpacing > 0 {
-			// spcPts: hundredths of a point (e.g. 1200 = 12pt)
-			lh = r.hundredthPtToPixelY(li.lineSpacing)
-		}
-		totalH += lh
-		totalH += li.spaceAfter
+		totalHF += r.lineAdvance(li.line, li.lineSpacing)
+		totalHF += float64(li.spaceAfter)
 	}
+	totalH := int(math.Round(totalHF))
 
 	// Vertical anchor offset
 	startY := y
@@ -4843,18 +6633,31 @@ func (r *renderer) drawParagraphs(paragraphs []*Paragraph, x, y, w, h int, ancho
 		// timeline annotation boxes.
 	}
 
-	curY := startY
+	if anchorCtr {
+		blockW := 0
+		for _, li := range allLines {
+			lw := li.line.width
+			if a := paragraphs[li.paraIdx].alignment; a != nil {
+				lw += r.emuToPixelX(a.MarginLeft) + r.emuToPixelX(a.MarginRight)
+				if li.isFirst {
+					lw += r.emuToPixelX(a.Indent)
+				}
+			}
+			blockW = maxInt(blockW, lw)
+		}
+		x += (w - blockW) / 2
+		w = blockW
+	}
+
+	curYF := float64(startY)
 	for i, li := range allLines {
 		if i > 0 {
-			curY += li.spaceBefore
+			curYF += float64(li.spaceBefore)
 		}
 
-		lh := li.line.lineHeight
-		if li.lineSpacing < 0 {
-			lh = int(float64(lh) * float64(-li.lineSpacing) / 100000.0)
-		} else if li.lineSpacing > 0 {
-			lh = r.hundredthPtToPixelY(li.lineSpacing)
-		}
+		advance := r.lineAdvance(li.line, li.lineSpacing)
+		curY := int(math.Round(curYF))
+		lh := int(math.Round(curYF+advance)) - curY
 
 		// Horizontal alignment
 		lineX := x
@@ -4876,7 +6679,13 @@ func (r *renderer) drawParagraphs(paragraphs []*Paragraph, x, y, w, h int, ancho
 			}
 		}
 
-		baseline := curY + li.line.ascent
+		baseline := r.lineBaseline(li.line, curYF)
+		if r.debug != nil {
+			r.debug.lines = append(r.debug.lines, debugLine{
+				box:      image.Rect(lineX, curY, lineX+li.line.width, curY+lh),
+				baseline: baseline,
+			})
+		}
 
 		// Draw each run
 		drawX := lineX
@@ -4888,8 +6697,12 @@ func (r *renderer) drawParagraphs(paragraphs []*Paragraph, x, y, w, h int, ancho
 				continue
 			}
 			fc := color.RGBA{A: 255}
-			if run.font != nil {
-				fc = argbToRGBA(run.font.Color)
+			if run.font != nil && !r.textOnly {
+				fc = r.rgba(run.font.Color)
+				if run.font.NoFill {
+					// Hollow glyphs: only the outline, if any, is drawn.
+					fc.A = 0
+				}
 			}
 
 			runBaseline := baseline
@@ -4901,69 +6714,403 @@ func (r *renderer) drawParagraphs(paragraphs []*Paragraph, x, y, w, h int, ancho
 				}
 			}
 
-			d := &font.Drawer{
-				Dst:  r.img,
-				Src:  image.NewUniform(fc),
-				Face: run.face,
-				Dot:  fixed.P(drawX, runBaseline),
+			if r.svgText != nil {
+				r.svgText.add(r, &run, drawX, runBaseline, fc)
+				drawX += run.width
+				continue
 			}
-			d.DrawString(run.text)
 
-			// Synthetic bold: if bold was requested but the font face is the
-			// regular weight (no bold variant found), re-draw with a 1px
-			// horizontal offset to embolden the glyphs.
-			if run.font != nil && run.font.Bold {
-				d2 := &font.Drawer{
+			if run.font != nil && run.font.Gradient != nil && !run.font.NoFill && !r.textOnly {
+				r.drawGradientRun(&run, drawX, runBaseline, li.line.ascent, li.line.descent)
+			} else if fc.A > 0 {
+				d := &font.Drawer{
 					Dst:  r.img,
 					Src:  image.NewUniform(fc),
 					Face: run.face,
-					Dot:  fixed.P(drawX+1, runBaseline),
+					Dot:  fixed.P(drawX, runBaseline),
+				}
+				r.drawRunText(d, &run)
+
+				// Synthetic bold: if bold was requested but the font face is the
+				// regular weight (no bold variant found), re-draw with a 1px
+				// horizontal offset to embolden the glyphs.
+				if run.font != nil && run.font.Bold {
+					d2 := &font.Drawer{
+						Dst:  r.img,
+						Src:  image.NewUniform(fc),
+						Face: run.face,
+						Dot:  fixed.P(drawX+1, runBaseline),
+					}
+					r.drawRunText(d2, &run)
+				}
+			}
+			if run.font != nil && run.font.OutlineColor != nil && !r.textOnly {
+				r.drawRunOutline(&run, drawX, runBaseline, li.line.ascent, li.line.descent)
+			}
+
+			// Underline
+			if run.font != nil && run.font.Underline != UnderlineNone && run.font.Underline != "" {
+				uc := fc
+				if run.font.UnderlineColor != nil && !r.textOnly {
+					uc = r.rgba(*run.font.UnderlineColor)
+				}
+				pos, thick := r.underlineMetrics(run.font)
+				r.drawUnderline(drawX, drawX+run.width, float64(runBaseline)+pos, thick, uc, run.font.Underline)
+			}
+
+			// Strikethrough
+			if run.font != nil && run.font.Strikethrough {
+				sy := runBaseline - li.line.ascent/3
+				if run.font.strikeType() == StrikeDouble {
+					// Two lines centred on the single strike position, kept
+					// apart by at least one pixel of the text behind them.
+					_, thick := r.underlineMetrics(run.font)
+					gap := maxInt(int(math.Round(thick)), 1)
+					r.drawLine(drawX, sy-gap, drawX+run.width, sy-gap, fc)
+					r.drawLine(drawX, sy+gap, drawX+run.width, sy+gap, fc)
+				} else {
+					r.drawLine(drawX, sy, drawX+run.width, sy, fc)
+				}
+			}
+
+			drawX += run.width
+		}
+
+		curYF += advance
+		curYF += float64(li.spaceAfter)
+	}
+}
+
+// underlineMetrics returns the distance of the top of the underline below
+// the baseline and its thickness in pixels for f. The values come from the
+// font's post table so that they scale with the font size; a uLn width
+// overrides the thickness.
+func (r *renderer) underlineMetrics(f *Font) (pos, thickness float64) {
+	sizePt := float64(f.Size)
+	if sizePt <= 0 {
+		sizePt = 10
+	}
+	if r.fontScale > 0 && r.fontScale != 1.0 {
+		sizePt *= r.fontScale
+	}
+	sizePixels := sizePt * 12700.0 * r.scaleX
+
+	// Typical values for fonts without a post table.
+	pos, thickness = 0.1, 0.05
+	if r.fontCache != nil {
+		for _, name := range []string{f.Name, f.NameEA} {
+			if name == "" {
+				continue
+			}
+			if p, t, ok := r.fontCache.underlineMetrics(name, f.Bold, f.Italic); ok {
+				pos, thickness = p, t
+				break
+			}
+		}
+	}
+	pos *= sizePixels
+	thickness *= sizePixels
+	if f.UnderlineWidth > 0 {
+		thickness = float64(f.UnderlineWidth) * r.scaleX
+	}
+	return pos, thickness
+}
+
+// drawUnderline draws an underline of the given style from x1 to x2, with
+// its top edge at top and the given thickness in pixels. The line is snapped
+// to whole pixels so that it stays crisp.
+func (r *renderer) drawUnderline(x1, x2 int, top, thickness float64, c color.RGBA, style UnderlineType) {
+	t := int(math.Round(thickness))
+	if t < 1 {
+		t = 1
+	}
+	if strings.HasSuffix(string(style), "Heavy") || style == UnderlineHeavy {
+		t *= 2
+	}
+	y := int(math.Round(top))
+	band := func(a, b, y int) {
+		r.fillRectBlend(image.Rect(a, y, b, y+t), c)
+	}
+	// dashes draws alternating on/off segments, in multiples of t.
+	dashes := func(pattern ...int) {
+		for x, i := x1, 0; x < x2; i++ {
+			n := pattern[i%len(pattern)] * t
+			if i%2 == 0 {
+				band(x, min(x+n, x2), y)
+			}
+			x += n
+		}
+	}
+	// wave draws a sine wave whose crests are t pixels thick.
+	wave := func(y int) {
+		amp := math.Max(1.5, float64(t))
+		period := 4 * amp
+		for px := x1; px < x2; px++ {
+			wy := float64(y) + amp*(0.5+0.5*math.Sin(float64(px-x1)*2*math.Pi/period))
+			iy := int(math.Floor(wy))
+			frac := wy - float64(iy)
+			r.blendPixelF(px, iy, c, 1-frac)
+			for k := 1; k < t; k++ {
+				r.blendPixel(px, iy+k, c)
+			}
+			r.blendPixelF(px, iy+t, c, frac)
+		}
+	}
+
+	switch style {
+	case UnderlineDouble:
+		band(x1, x2, y)
+		band(x1, x2, y+2*t)
+	case "dotted", "dottedHeavy":
+		dashes(1, 1)
+	case UnderlineDash, "dashHeavy":
+		dashes(4, 2)
+	case "dashLong", "dashLongHeavy":
+		dashes(8, 3)
+	case "dotDash", "dotDashHeavy":
+		dashes(1, 2, 4, 2)
+	case "dotDotDash", "dotDotDashHeavy":
+		dashes(1, 2, 1, 2, 4, 2)
+	case UnderlineWavy, "wavyHeavy":
+		wave(y)
+	case "wavyDbl":
+		wave(y)
+		wave(y + 3*t)
+	default:
+		band(x1, x2, y)
+	}
+}
+
+// verticalTextRotation returns the angle by which text laid out
+// horizontally is rotated for a bodyPr vert mode: vert, eaVert and
+// wordArtVert lines run top to bottom, vert270 lines bottom to top.
+func verticalTextRotation(dir string) int {
+	switch dir {
+	case "vert", "eaVert", "wordArtVert":
+		return 90
+	case "vert270":
+		return 270
+	}
+	return 0
+}
+
+// drawRunText draws the text of run with d. Vertical text is laid out
+// horizontally and then rotated 90° clockwise, which turns every glyph on
+// its side; in eaVert text, glyphs that stand upright in vertical writing
+// are therefore drawn rotated the other way, and in wordArtVert text all
+// glyphs are. With kumimoji, runs of one or two digits are set upright
+// side by side (tate-chu-yoko); longer numbers lie on their side.
+func (r *renderer) drawRunText(d *font.Drawer, run *textRun) {
+	if r.vertText != "eaVert" && r.vertText != "wordArtVert" {
+		d.DrawString(run.text)
+		return
+	}
+	kumimoji := run.font != nil && run.font.Kumimoji
+	var seg strings.Builder
+	flush := func() {
+		if seg.Len() > 0 {
+			d.DrawString(seg.String())
+			seg.Reset()
+		}
+	}
+	runes := []rune(run.text)
+	for i := 0; i < len(runes); {
+		ch := runes[i]
+		if r.vertText == "eaVert" && ch >= '0' && ch <= '9' {
+			j := i + 1
+			for j < len(runes) && runes[j] >= '0' && runes[j] <= '9' {
+				j++
+			}
+			if kumimoji && j-i <= 2 {
+				flush()
+				drawUprightText(d, string(runes[i:j]))
+			} else {
+				seg.WriteString(string(runes[i:j]))
+			}
+			i = j
+			continue
+		}
+		if r.vertText == "eaVert" && !uprightInVertical(ch) {
+			seg.WriteRune(ch)
+		} else {
+			flush()
+			drawUprightText(d, string(ch))
+		}
+		i++
+	}
+	flush()
+}
+
+// drawGradientRun draws a run whose font has a gradient fill. The glyphs
+// are rendered into an alpha mask through which the gradient, spanning the
+// run's box, is composited.
+func (r *renderer) drawGradientRun(run *textRun, x, baseline, ascent, descent int) {
+	pad := ascent/2 + 1 // room for overhanging and italic glyphs
+	box := image.Rect(x-pad, baseline-ascent-pad, x+run.width+pad, baseline+descent+pad).Intersect(r.img.Bounds())
+	if box.Empty() {
+		return
+	}
+	mask := image.NewAlpha(box)
+	d := &font.Drawer{Dst: mask, Src: image.Opaque, Face: run.face, Dot: fixed.P(x, baseline)}
+	r.drawRunText(d, run)
+	if run.font.Bold {
+		d.Dot = fixed.P(x+1, baseline)
+		r.drawRunText(d, run)
+	}
+	g := run.font.Gradient
+	grad := newLinearGradientImage(image.Rect(x, baseline-ascent, x+run.width, baseline+descent), g.Rotation, r.gradientRamp(r.rgba(g.Color), r.rgba(g.EndColor)))
+	draw.DrawMask(r.img, box, grad, box.Min, mask, box.Min, draw.Over)
+}
+
+// drawRunOutline strokes the outlines of a run's glyphs in the font's
+// outline color. The glyphs are rendered into an alpha mask, and the band
+// reaching half the outline width either side of its edges is painted, so
+// the outline is centred on the glyph contours.
+func (r *renderer) drawRunOutline(run *textRun, x, baseline, ascent, descent int) {
+	width := run.font.OutlineWidth
+	if width <= 0 {
+		width = 9525 // 0.75 pt
+	}
+	half := math.Max(float64(width)*r.scaleX/2, 0.5)
+	reach := int(math.Ceil(half)) + 1
+	pad := ascent/2 + 1 + reach
+	box := image.Rect(x-pad, baseline-ascent-pad, x+run.width+pad, baseline+descent+pad).Intersect(r.img.Bounds())
+	if box.Empty() {
+		return
+	}
+	mask := image.NewAlpha(box)
+	d := &font.Drawer{Dst: mask, Src: image.Opaque, Face: run.face, Dot: fixed.P(x, baseline)}
+	r.drawRunText(d, run)
+	if run.font.Bold {
+		d.Dot = fixed.P(x+1, baseline)
+		r.drawRunText(d, run)
+	}
+
+	// Moving a straight edge by h pixels changes the coverage of the pixels
+	// it crosses by h, so the coverage of the glyphs grown and shrunk by
+	// half the width is estimated from each neighbour's coverage plus or
+	// minus what is left of half after the distance to it; empty pixels
+	// cannot grow the glyphs nor full ones shrink them. The outline is the
+	// difference.
+	type tap struct {
+		dx, dy int
+		d      float64
+	}
+	var disc []tap
+	for dy := -reach; dy <= reach; dy++ {
+		for dx := -reach; dx <= reach; dx++ {
+			if d := math.Hypot(float64(dx), float64(dy)); d < half+1 {
+				disc = append(disc, tap{dx, dy, d})
+			}
+		}
+	}
+	band := image.NewAlpha(box)
+	for py := box.Min.Y; py < box.Max.Y; py++ {
+		for px := box.Min.X; px < box.Max.X; px++ {
+			grown, shrunk := 0.0, 1.0
+			for _, t := range disc {
+				v := 0.0
+				if p := image.Pt(px+t.dx, py+t.dy); p.In(box) {
+					v = float64(mask.Pix[mask.PixOffset(p.X, p.Y)]) / 255
+				}
+				if v > 0 {
+					grown = math.Max(grown, v+half-t.d)
+				}
+				if v < 1 {
+					shrunk = math.Min(shrunk, v-half+t.d)
 				}
-				d2.DrawString(run.text)
-			}
-
-			// Underline
-			if run.font != nil && run.font.Underline != UnderlineNone {
-				uy := runBaseline + 2
-				r.drawUnderline(drawX, drawX+run.width, uy, fc, run.font.Underline)
 			}
-
-			// Strikethrough
-			if run.font != nil && run.font.Strikethrough {
-				sy := runBaseline - li.line.ascent/3
-				r.drawLine(drawX, sy, drawX+run.width, sy, fc)
+			if a := math.Min(grown, 1) - math.Max(shrunk, 0); a > 0 {
+				band.Pix[band.PixOffset(px, py)] = uint8(math.Round(a * 255))
 			}
-
-			drawX += run.width
 		}
-
-		curY += lh
-		curY += li.spaceAfter
 	}
+	c := r.rgba(*run.font.OutlineColor)
+	draw.DrawMask(r.img, box, image.NewUniform(c), image.Point{}, band, box.Min, draw.Over)
 }
 
-// drawUnderline draws an underline of the given style.
-func (r *renderer) drawUnderline(x1, x2, y int, c color.RGBA, style UnderlineType) {
-	switch style {
-	case UnderlineSingle:
-		r.drawLine(x1, y, x2, y, c)
-	case UnderlineDouble:
-		r.drawLine(x1, y-1, x2, y-1, c)
-		r.drawLine(x1, y+1, x2, y+1, c)
-	case UnderlineHeavy:
-		r.drawLine(x1, y-1, x2, y-1, c)
-		r.drawLine(x1, y, x2, y, c)
-		r.drawLine(x1, y+1, x2, y+1, c)
-	case UnderlineDash:
-		r.drawDashedHLine(x1, x2, y, c, 6, 3)
-	case UnderlineWavy:
-		for px := x1; px < x2; px++ {
-			wy := y + int(math.Sin(float64(px-x1)*0.5)*2)
-			r.blendPixel(px, wy, c)
-		}
-	default:
-		r.drawLine(x1, y, x2, y, c)
+// linearGradientImage is an unbounded image whose colors follow a linear
+// gradient fill laid over rect, as fillGradientLinear paints it.
+type linearGradientImage struct {
+	rect           image.Rectangle
+	ramp           func(t float64) color.RGBA
+	cosA, sinA     float64
+	maxProj, scale float64
+}
+
+func newLinearGradientImage(rect image.Rectangle, rotation int, ramp func(t float64) color.RGBA) *linearGradientImage {
+	rad := float64(rotation) * math.Pi / 180.0
+	g := &linearGradientImage{
+		rect: rect,
+		ramp: ramp,
+		cosA: math.Cos(rad),
+		sinA: math.Sin(rad),
+	}
+	g.maxProj = math.Max(math.Abs(float64(rect.Dx())/2*g.cosA)+math.Abs(float64(rect.Dy())/2*g.sinA), 1)
+	g.scale = 1 / (2 * g.maxProj)
+	return g
+}
+
+func (g *linearGradientImage) ColorModel() color.Model { return color.NRGBAModel }
+
+func (g *linearGradientImage) Bounds() image.Rectangle {
+	return image.Rect(-1e9, -1e9, 1e9, 1e9)
+}
+
+func (g *linearGradientImage) At(x, y int) color.Color {
+	dx := float64(x-g.rect.Min.X) - float64(g.rect.Dx())/2
+	dy := float64(y-g.rect.Min.Y) - float64(g.rect.Dy())/2
+	t := math.Max(0, math.Min(1, (dx*g.cosA+dy*g.sinA+g.maxProj)*g.scale))
+	c := g.ramp(t)
+	return color.NRGBA{R: c.R, G: c.G, B: c.B, A: c.A}
+}
+
+// drawUprightText draws text rotated 90° counter-clockwise, centred in the
+// space it occupies on the line, and advances d.Dot past it.
+func drawUprightText(d *font.Drawer, text string) {
+	adv := font.MeasureString(d.Face, text)
+	m := d.Face.Metrics()
+	cellW, asc, desc := adv.Ceil(), m.Ascent.Ceil(), m.Descent.Ceil()
+	cellH := asc + desc
+	if cellW > 0 && cellH > 0 {
+		mask := image.NewAlpha(image.Rect(0, 0, cellW, cellH))
+		md := &font.Drawer{Dst: mask, Src: image.Opaque, Face: d.Face, Dot: fixed.P(0, asc)}
+		md.DrawString(text)
+		// Rotate the mask: the cell's top edge becomes its left edge.
+		rot := image.NewAlpha(image.Rect(0, 0, cellH, cellW))
+		for y := 0; y < cellH; y++ {
+			for x := 0; x < cellW; x++ {
+				rot.Pix[(cellW-1-x)*rot.Stride+y] = mask.Pix[y*mask.Stride+x]
+			}
+		}
+		cx := d.Dot.X.Round() + cellW/2
+		cy := d.Dot.Y.Round() - (asc-desc)/2
+		at := image.Pt(cx-cellH/2, cy-cellW/2)
+		draw.DrawMask(d.Dst, rot.Bounds().Add(at), d.Src, image.Point{}, rot, image.Point{}, draw.Over)
+	}
+	d.Dot.X += adv
+}
+
+// uprightInVertical reports whether ch stays upright in vertical East Asian
+// text, following the Vertical_Orientation property (UAX #50) in outline:
+// ideographs, kana, hangul, full-width forms and East Asian symbols are
+// upright; Latin text and brackets, which have rotated vertical forms, lie
+// on their side.
+func uprightInVertical(ch rune) bool {
+	switch {
+	case ch >= 0x3008 && ch <= 0x3011, ch >= 0x3014 && ch <= 0x301F, ch == 0x30FC,
+		ch == 0xFF08, ch == 0xFF09, ch == 0xFF0D, ch >= 0xFF1C && ch <= 0xFF1E,
+		ch == 0xFF3B, ch == 0xFF3D, ch == 0xFF3F, ch >= 0xFF5B && ch <= 0xFF60, ch == 0xFFE3:
+		// Brackets, dashes and the long vowel mark turn with the text.
+		return false
+	case isCJK(ch) && (ch < 0xFF61 || ch > 0xFFDC): // half-width kana and hangul lie on their side
+		return true
 	}
+	return ch >= 0x2460 && ch <= 0x24FF || // enclosed alphanumerics
+		ch >= 0x25A0 && ch <= 0x27BF || // geometric shapes, symbols, dingbats
+		ch >= 0x3100 && ch <= 0x33FF || // bopomofo, kanbun, enclosed CJK, CJK compatibility
+		ch >= 0xA960 && ch <= 0xA97F || ch >= 0xAC00 && ch <= 0xD7FF ||
+		ch >= 0x1F000 && ch <= 0x1FAFF // emoji and pictographs
 }
 
 // buildBulletRun creates a textRun for a bullet prefix.
@@ -5051,8 +7198,29 @@ func (r *renderer) buildBulletRun(b *Bullet, para *Paragraph) textRun {
 		text:  text,
 		font:  bulletFont,
 		face:  face,
-		width: w,
+		width: r.hangingBulletWidth(w, para.alignment),
+	}
+}
+
+// hangingBulletWidth widens a bullet of natural width w so that the text
+// after it starts where wrapped lines do. With a hanging indent (negative
+// indent) the bullet sits at marL+indent and the text at marL; a bullet too
+// wide for the hang pushes the text to the next default tab stop.
+func (r *renderer) hangingBulletWidth(w int, a *Alignment) int {
+	if a == nil || a.Indent >= 0 {
+		return w
+	}
+	startPx := r.emuToPixelX(a.MarginLeft) + r.emuToPixelX(a.Indent)
+	end := a.MarginLeft + a.Indent + int64(math.Ceil(float64(w)/r.scaleX))
+	stop := a.MarginLeft
+	if end > stop {
+		tab := a.DefTabSize
+		if tab <= 0 {
+			tab = defaultTabSize
+		}
+		stop = (end/tab + 1) * tab
 	}
+	return r.emuToPixelX(stop) - startPx
 }
 
 // isSymbolFont returns true if the font name is a symbol/dingbats font
@@ -5365,6 +7533,63 @@ func measureStringWithKern(face font.Face, s string) fixed.Int26_6 {
 	return advance
 }
 
+// applyCharSpacing applies f's kerning threshold and character spacing to
+// runs set in f by wrapping their faces, so that measuring and drawing
+// both see the adjusted advances.
+func (r *renderer) applyCharSpacing(runs []textRun, f *Font) []textRun {
+	sizePt := float64(f.Size)
+	if r.fontScale > 0 && r.fontScale != 1.0 {
+		sizePt *= r.fontScale
+	}
+	kern := f.Kern == nil || (*f.Kern > 0 && sizePt*100 >= float64(*f.Kern))
+	if kern && f.Spacing == 0 {
+		return runs
+	}
+	// spc is in hundredths of a point; one hundredth is 127 EMU.
+	spc := fixed.Int26_6(math.Round(float64(f.Spacing) * 127 * r.scaleX * 64))
+	for i := range runs {
+		if runs[i].face == nil {
+			continue
+		}
+		runs[i].face = &spacedFace{Face: runs[i].face, kern: kern, spacing: spc}
+		if runs[i].measureFace != nil {
+			runs[i].measureFace = &spacedFace{Face: runs[i].measureFace, kern: kern, spacing: spc}
+		}
+		runs[i].width = measureStringWithKern(runs[i].face, runs[i].text).Ceil()
+	}
+	return runs
+}
+
+// spacedFace adds fixed spacing after every glyph of a face and optionally
+// turns off its pair kerning.
+type spacedFace struct {
+	font.Face
+	kern    bool
+	spacing fixed.Int26_6
+}
+
+func (f *spacedFace) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	dr, mask, maskp, advance, ok := f.Face.Glyph(dot, r)
+	return dr, mask, maskp, advance + f.spacing, ok
+}
+
+func (f *spacedFace) GlyphBounds(r rune) (fixed.Rectangle26_6, fixed.Int26_6, bool) {
+	bounds, advance, ok := f.Face.GlyphBounds(r)
+	return bounds, advance + f.spacing, ok
+}
+
+func (f *spacedFace) GlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	advance, ok := f.Face.GlyphAdvance(r)
+	return advance + f.spacing, ok
+}
+
+func (f *spacedFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	if !f.kern {
+		return 0
+	}
+	return f.Face.Kern(r0, r1)
+}
+
 // wrapRunLine wraps text runs into multiple lines that fit within maxWidth.
 func (r *renderer) wrapRunLine(runs []textRun, maxWidth int) []textLine {
 	if len(runs) == 0 {
@@ -5680,7 +7905,23 @@ func getSeriesColor(s *ChartSeries, idx int, palette []color.RGBA) color.RGBA {
 	return palette[idx%len(palette)]
 }
 
+// seriesColor is getSeriesColor with the theme color overrides applied.
+func (r *renderer) seriesColor(s *ChartSeries, idx int, palette []color.RGBA) color.RGBA {
+	if s.FillColor.ARGB != "" && s.FillColor.ARGB != "00000000" {
+		return r.rgba(s.FillColor)
+	}
+	return palette[idx%len(palette)]
+}
+
 func (r *renderer) renderChart(s *ChartShape) {
+	// A chart read from a file is drawn from the picture PowerPoint cached
+	// of it, unless it has been changed since.
+	if p := s.currentPreview(); p != nil {
+		cp := *p
+		cp.BaseShape = s.BaseShape
+		r.renderDrawing(&cp)
+		return
+	}
 	x := r.emuToPixelX(s.offsetX)
 	y := r.emuToPixelY(s.offsetY)
 	w := r.emuToPixelX(s.width)
@@ -5691,12 +7932,22 @@ func (r *renderer) renderChart(s *ChartShape) {
 	r.drawRect(image.Rect(x, y, x+w, y+h), color.RGBA{R: 200, G: 200, B: 200, A: 255}, 1)
 
 	// Title
+	// A hidden title (autoTitleDeleted) takes no space, and neither does one
+	// that overlays the chart; the latter is drawn over the plot at the end.
 	titleH := 0
 	if s.title != nil && s.title.Visible && s.title.Text != "" {
 		face := r.getFace(s.title.Font)
-		fc := argbToRGBA(s.title.Font.Color)
-		titleH = face.Metrics().Height.Ceil() + 4
-		r.drawStringCentered(s.title.Text, face, fc, image.Rect(x, y, x+w, y+titleH))
+		fc := r.rgba(s.title.Font.Color)
+		th := face.Metrics().Height.Ceil() + 4
+		drawTitle := func() {
+			r.drawStringCentered(s.title.Text, face, fc, image.Rect(x, y, x+w, y+th))
+		}
+		if s.title.Overlay {
+			defer drawTitle()
+		} else {
+			titleH = th
+			drawTitle()
+		}
 	}
 
 	// Legend height
@@ -5713,22 +7964,30 @@ func (r *renderer) renderChart(s *ChartShape) {
 	if plotW < 10 {
 		plotW = 10
 	}
-	if plotH < 10 {
-		plotH = 10
-	}
 
 	ct := s.plotArea.GetType()
 	if ct == nil {
 		return
 	}
 
+	// Category labels take the space below the plot.
+	cats, edge := chartCategoryLabels(ct)
+	var catLayout categoryLabelLayout
+	if len(cats) > 0 {
+		catLayout = r.layoutCategoryLabels(s.plotArea.axisX, cats, categorySlot(len(cats), plotW, edge))
+		plotH -= catLayout.height
+	}
+	if plotH < 10 {
+		plotH = 10
+	}
+
 	switch c := ct.(type) {
 	case *BarChart:
-		r.renderBarChart(c, plotX, plotY, plotW, plotH)
+		r.renderBarChart(c, s.plotArea.axisX, s.plotArea.axisY, plotX, plotY, plotW, plotH)
 	case *Bar3DChart:
-		r.renderBarChart(&c.BarChart, plotX, plotY, plotW, plotH)
+		r.renderBarChart(&c.BarChart, s.plotArea.axisX, s.plotArea.axisY, plotX, plotY, plotW, plotH)
 	case *LineChart:
-		r.renderLineChart(c, plotX, plotY, plotW, plotH)
+		r.renderLineChart(c, s.plotArea.axisX, s.plotArea.axisY, plotX, plotY, plotW, plotH)
 	case *PieChart:
 		r.renderPieChart(c.Series, plotX, plotY, plotW, plotH)
 	case *Pie3DChart:
@@ -5736,12 +7995,15 @@ func (r *renderer) renderChart(s *ChartShape) {
 	case *DoughnutChart:
 		r.renderDoughnutChart(c, plotX, plotY, plotW, plotH)
 	case *AreaChart:
-		r.renderAreaChart(c, plotX, plotY, plotW, plotH)
+		r.renderAreaChart(c, s.plotArea.axisX, s.plotArea.axisY, plotX, plotY, plotW, plotH)
 	case *ScatterChart:
-		r.renderScatterChart(c, plotX, plotY, plotW, plotH)
+		r.renderScatterChart(c, s.plotArea.axisX, s.plotArea.axisY, plotX, plotY, plotW, plotH)
 	case *RadarChart:
 		r.renderRadarChart(c, plotX, plotY, plotW, plotH)
 	}
+	if len(cats) > 0 {
+		r.drawCategoryAxisLabels(s.plotArea.axisX, cats, catLayout, edge, plotX, plotY+plotH, plotW)
+	}
 
 	// Legend
 	if s.legend != nil && s.legend.Visible {
@@ -5749,7 +8011,7 @@ func (r *renderer) renderChart(s *ChartShape) {
 	}
 }
 
-func (r *renderer) renderBarChart(c *BarChart, px, py, pw, ph int) {
+func (r *renderer) renderBarChart(c *BarChart, catAx, ax *ChartAxis, px, py, pw, ph int) {
 	if len(c.Series) == 0 {
 		return
 	}
@@ -5785,35 +8047,53 @@ func (r *renderer) renderBarChart(c *BarChart, px, py, pw, ph int) {
 	}
 	valRange := maxVal - minVal
 
-	// Draw axes
-	axisColor := color.RGBA{R: 128, G: 128, B: 128, A: 255}
-	r.drawLine(px, py+ph, px+pw, py+ph, axisColor)
-	r.drawLine(px, py, px, py+ph, axisColor)
-
 	nCats := len(cats)
 	nSeries := len(c.Series)
+	catTicks := make([]int, nCats+1)
+	for i := range catTicks {
+		catTicks[i] = px + i*pw/maxInt(nCats, 1)
+	}
+	r.drawChartAxes(catAx, ax, catTicks, minVal, maxVal, px, py, pw, ph)
 	if nCats == 0 {
 		return
 	}
-	catW := pw / nCats
-	barW := catW / (nSeries + 1)
-	if barW < 1 {
-		barW = 1
-	}
+	// Lay out each category as PowerPoint does: the gap between clusters
+	// is gapWidth percent of a bar, and neighbouring bars in a cluster
+	// overlap by overlap percent of a bar (negative values separate them).
+	catW := float64(pw) / float64(nCats)
+	gap := math.Max(float64(c.GapWidthPercent)/100, 0)
+	overlap := math.Max(-1, math.Min(float64(c.OverlapPercent)/100, 1))
+	barW := catW / (gap + float64(nSeries) - float64(nSeries-1)*overlap)
 
 	for ci, cat := range cats {
 		for si, s := range c.Series {
 			v := s.Values[cat]
 			barH := int(float64(ph) * (v - minVal) / valRange)
-			bx := px + ci*catW + (si+1)*barW - barW/2
+			x0 := float64(px) + float64(ci)*catW + barW*gap/2 + float64(si)*barW*(1-overlap)
+			bx, bx1 := int(math.Round(x0)), int(math.Round(x0+barW))
+			if bx1 <= bx {
+				bx1 = bx + 1
+			}
 			by := py + ph - barH
-			sc := getSeriesColor(s, si, palette)
-			r.fillRectBlend(image.Rect(bx, by, bx+barW-1, py+ph), sc)
+			sc := r.seriesColor(s, si, palette)
+			r.fillRectBlend(image.Rect(bx, by, bx1, py+ph), sc)
+			if label := seriesLabelText(s, cat, v, 0); label != "" {
+				ly := by - r.chartLabelHeight(s.Font)/2 - 2
+				switch s.LabelPosition {
+				case LabelCenter:
+					ly = (by + py + ph) / 2
+				case LabelInsideEnd:
+					ly = by + r.chartLabelHeight(s.Font)/2 + 2
+				case LabelInsideBase:
+					ly = py + ph - r.chartLabelHeight(s.Font)/2 - 2
+				}
+				r.drawChartLabel(label, s.Font, (bx+bx1)/2, ly)
+			}
 		}
 	}
 }
 
-func (r *renderer) renderLineChart(c *LineChart, px, py, pw, ph int) {
+func (r *renderer) renderLineChart(c *LineChart, catAx, ax *ChartAxis, px, py, pw, ph int) {
 	if len(c.Series) == 0 {
 		return
 	}
@@ -5840,13 +8120,10 @@ func (r *renderer) renderLineChart(c *LineChart, px, py, pw, ph int) {
 	}
 	valRange := maxVal - minVal
 
-	// Draw axes
-	axisColor := color.RGBA{R: 128, G: 128, B: 128, A: 255}
-	r.drawLine(px, py+ph, px+pw, py+ph, axisColor)
-	r.drawLine(px, py, px, py+ph, axisColor)
+	r.drawChartAxes(catAx, ax, chartPointTicks(c.Series, px, pw), minVal, maxVal, px, py, pw, ph)
 
 	for si, s := range c.Series {
-		sc := getSeriesColor(s, si, palette)
+		sc := r.seriesColor(s, si, palette)
 		cats := s.Categories
 		nPts := len(cats)
 		if nPts == 0 {
@@ -5866,6 +8143,9 @@ func (r *renderer) renderLineChart(c *LineChart, px, py, pw, ph int) {
 			// Draw marker
 			r.fillEllipseAA(ptX-2, ptY-2, 5, 5, sc)
 			prevX, prevY = ptX, ptY
+			if label := seriesLabelText(s, cat, v, 0); label != "" {
+				r.drawChartLabel(label, s.Font, ptX, ptY-r.chartLabelHeight(s.Font)/2-4)
+			}
 		}
 	}
 }
@@ -5906,6 +8186,7 @@ func (r *renderer) renderPieChart(series []*ChartSeries, px, py, pw, ph int) {
 		endAngle := startAngle + sweep
 		sc := palette[i%len(palette)]
 		r.fillPieSlice(cx, cy, radius, startAngle, endAngle, sc)
+		r.drawSliceLabel(s, cat, v, total, cx, cy, float64(radius)*0.65, (startAngle+endAngle)/2)
 		startAngle = endAngle
 	}
 }
@@ -5986,6 +8267,7 @@ func (r *renderer) renderDoughnutChart(c *DoughnutChart, px, py, pw, ph int) {
 		endAngle := startAngle + sweep
 		sc := palette[i%len(palette)]
 		r.fillDoughnutSlice(cx, cy, innerR, outerR, startAngle, endAngle, sc)
+		r.drawSliceLabel(s, cat, v, total, cx, cy, float64(innerR+outerR)/2, (startAngle+endAngle)/2)
 		startAngle = endAngle
 	}
 }
@@ -6013,7 +8295,7 @@ func (r *renderer) fillDoughnutSlice(cx, cy, innerR, outerR int, startAngle, end
 	}
 }
 
-func (r *renderer) renderAreaChart(c *AreaChart, px, py, pw, ph int) {
+func (r *renderer) renderAreaChart(c *AreaChart, catAx, ax *ChartAxis, px, py, pw, ph int) {
 	if len(c.Series) == 0 {
 		return
 	}
@@ -6039,13 +8321,10 @@ func (r *renderer) renderAreaChart(c *AreaChart, px, py, pw, ph int) {
 	}
 	valRange := maxVal - minVal
 
-	// Axes
-	axisColor := color.RGBA{R: 128, G: 128, B: 128, A: 255}
-	r.drawLine(px, py+ph, px+pw, py+ph, axisColor)
-	r.drawLine(px, py, px, py+ph, axisColor)
+	r.drawChartAxes(catAx, ax, chartPointTicks(c.Series, px, pw), minVal, maxVal, px, py, pw, ph)
 
 	for si, s := range c.Series {
-		sc := getSeriesColor(s, si, palette)
+		sc := r.seriesColor(s, si, palette)
 		// Semi-transparent fill
 		fillC := color.RGBA{R: sc.R, G: sc.G, B: sc.B, A: 128}
 		cats := s.Categories
@@ -6073,10 +8352,15 @@ func (r *renderer) renderAreaChart(c *AreaChart, px, py, pw, ph int) {
 		for i := 0; i < nPts-1; i++ {
 			r.drawLineAA(int(pts[i].x), int(pts[i].y), int(pts[i+1].x), int(pts[i+1].y), sc, 2)
 		}
+		for i, cat := range cats {
+			if label := seriesLabelText(s, cat, s.Values[cat], 0); label != "" {
+				r.drawChartLabel(label, s.Font, int(pts[i].x), int(pts[i].y)-r.chartLabelHeight(s.Font)/2-4)
+			}
+		}
 	}
 }
 
-func (r *renderer) renderScatterChart(c *ScatterChart, px, py, pw, ph int) {
+func (r *renderer) renderScatterChart(c *ScatterChart, catAx, ax *ChartAxis, px, py, pw, ph int) {
 	if len(c.Series) == 0 {
 		return
 	}
@@ -6103,12 +8387,10 @@ func (r *renderer) renderScatterChart(c *ScatterChart, px, py, pw, ph int) {
 	}
 	valRange := maxVal - minVal
 
-	axisColor := color.RGBA{R: 128, G: 128, B: 128, A: 255}
-	r.drawLine(px, py+ph, px+pw, py+ph, axisColor)
-	r.drawLine(px, py, px, py+ph, axisColor)
+	r.drawChartAxes(catAx, ax, chartPointTicks(c.Series, px, pw), minVal, maxVal, px, py, pw, ph)
 
 	for si, s := range c.Series {
-		sc := getSeriesColor(s, si, palette)
+		sc := r.seriesColor(s, si, palette)
 		cats := s.Categories
 		nPts := len(cats)
 		if nPts == 0 {
@@ -6119,6 +8401,9 @@ func (r *renderer) renderScatterChart(c *ScatterChart, px, py, pw, ph int) {
 			ptX := px + (i * pw / maxInt(nPts-1, 1))
 			ptY := py + ph - int(float64(ph)*(v-minVal)/valRange)
 			r.fillEllipseAA(ptX-3, ptY-3, 7, 7, sc)
+			if label := seriesLabelText(s, cat, v, 0); label != "" {
+				r.drawChartLabel(label, s.Font, ptX, ptY-r.chartLabelHeight(s.Font)/2-5)
+			}
 		}
 	}
 }
@@ -6161,7 +8446,7 @@ func (r *renderer) renderRadarChart(c *RadarChart, px, py, pw, ph int) {
 
 	// Draw series
 	for si, s := range c.Series {
-		sc := getSeriesColor(s, si, palette)
+		sc := r.seriesColor(s, si, palette)
 		cats := s.Categories
 		nPts := len(cats)
 		if nPts == 0 {
@@ -6188,6 +8473,402 @@ func (r *renderer) renderRadarChart(c *RadarChart, px, py, pw, ph int) {
 	}
 }
 
+// drawValueAxisLabels draws the tick labels of a value axis spanning
+// minVal..maxVal along the left edge of the plot area, formatted with the
+// axis number format.
+// drawChartAxes draws the category and value axis lines of a plot area
+// with their tick marks and value labels. A deleted axis (c:delete, i.e.
+// Visible false) draws nothing. catTicks are the x positions of the
+// category axis major tick marks.
+func (r *renderer) drawChartAxes(catAx, valAx *ChartAxis, catTicks []int, minVal, maxVal float64, px, py, pw, ph int) {
+	axisColor := color.RGBA{R: 128, G: 128, B: 128, A: 255}
+	if catAx == nil || catAx.Visible {
+		r.drawLine(px, py+ph, px+pw, py+ph, axisColor)
+		if catAx != nil {
+			for _, x := range catTicks {
+				r.drawTickMark(x, py+ph, catAx.MajorTickMark, 4, false, axisColor)
+			}
+		}
+	}
+	if valAx == nil || valAx.Visible {
+		r.drawLine(px, py, px, py+ph, axisColor)
+		if valAx != nil {
+			if step, ok := valueAxisStep(valAx, minVal, maxVal); ok {
+				minor := step / 5
+				if valAx.MinorUnit != nil && *valAx.MinorUnit > 0 {
+					minor = *valAx.MinorUnit
+				}
+				if valAx.MinorTickMark != TickMarkNone && (maxVal-minVal)/minor <= 250 {
+					for v := math.Ceil(minVal/minor-1e-9) * minor; v <= maxVal+minor*1e-9; v += minor {
+						ty := py + ph - int(float64(ph)*(v-minVal)/(maxVal-minVal))
+						r.drawTickMark(px, ty, valAx.MinorTickMark, 2, true, axisColor)
+					}
+				}
+				for v := math.Ceil(minVal/step-1e-9) * step; v <= maxVal+step*1e-9; v += step {
+					ty := py + ph - int(float64(ph)*(v-minVal)/(maxVal-minVal))
+					r.drawTickMark(px, ty, valAx.MajorTickMark, 4, true, axisColor)
+				}
+			}
+		}
+	}
+	r.drawValueAxisLabels(valAx, minVal, maxVal, px, py, ph)
+}
+
+// drawTickMark draws a tick mark of length n across the axis line at
+// (x, y). vertical selects the value axis, whose outside is to the left;
+// the category axis's outside is below.
+func (r *renderer) drawTickMark(x, y int, mark string, n int, vertical bool, c color.RGBA) {
+	var lo, hi int
+	switch mark {
+	case TickMarkOutside:
+		lo, hi = 0, n
+	case TickMarkInside:
+		lo, hi = -n, 0
+	case TickMarkCross:
+		lo, hi = -n, n
+	default:
+		return
+	}
+	if vertical {
+		r.drawLine(x-hi, y, x-lo, y, c)
+	} else {
+		r.drawLine(x, y+lo, x, y+hi, c)
+	}
+}
+
+// chartPointTicks returns the x positions of the points of the first
+// series when they are spread edge to edge across the plot width, as the
+// line, area and scatter renderers place them.
+func chartPointTicks(series []*ChartSeries, px, pw int) []int {
+	if len(series) == 0 || series[0] == nil {
+		return nil
+	}
+	n := len(series[0].Categories)
+	ticks := make([]int, n)
+	for i := range ticks {
+		ticks[i] = px + i*pw/maxInt(n-1, 1)
+	}
+	return ticks
+}
+
+// valueAxisStep returns the major unit of a value axis spanning
+// [minVal, maxVal]; ok is false when the axis would have too many ticks.
+func valueAxisStep(ax *ChartAxis, minVal, maxVal float64) (step float64, ok bool) {
+	if maxVal <= minVal {
+		return 0, false
+	}
+	step = niceChartStep((maxVal - minVal) / 5)
+	if ax.MajorUnit != nil && *ax.MajorUnit > 0 {
+		step = *ax.MajorUnit
+	}
+	return step, (maxVal-minVal)/step <= 50
+}
+
+func (r *renderer) drawValueAxisLabels(ax *ChartAxis, minVal, maxVal float64, px, py, ph int) {
+	if ax == nil || !ax.Visible || ax.TickLabelPos == "none" {
+		return
+	}
+	step, ok := valueAxisStep(ax, minVal, maxVal)
+	if !ok {
+		return
+	}
+	pad := 4
+	if ax.MajorTickMark == TickMarkOutside || ax.MajorTickMark == TickMarkCross {
+		pad += 4
+	}
+	f := ax.Font
+	if f == nil {
+		f = NewFont()
+	}
+	fc := r.rgba(f.Color)
+	for v := math.Ceil(minVal/step-1e-9) * step; v <= maxVal+step*1e-9; v += step {
+		// Number formats may carry CJK literals, such as a currency unit.
+		t := r.layoutChartText(formatNumber(v, ax.NumberFormat), f)
+		ty := py + ph - int(float64(ph)*(v-minVal)/(maxVal-minVal))
+		t.draw(r.img, fc, px-pad-t.width, ty+(t.ascent-t.descent).Ceil()/2)
+	}
+}
+
+// chartCategoryLabels returns the category labels of an axis chart and
+// whether its points sit on the category edges (line, area and scatter
+// charts) rather than in the middle of each category (bar charts). Charts
+// without a category axis yield nil.
+func chartCategoryLabels(ct ChartType) (cats []string, edge bool) {
+	var series []*ChartSeries
+	switch c := ct.(type) {
+	case *BarChart:
+		series = c.Series
+	case *Bar3DChart:
+		series = c.Series
+	case *LineChart:
+		series, edge = c.Series, true
+	case *AreaChart:
+		series, edge = c.Series, true
+	case *ScatterChart:
+		series, edge = c.Series, true
+	}
+	if len(series) == 0 || series[0] == nil {
+		return nil, false
+	}
+	return series[0].Categories, edge
+}
+
+// categorySlot returns the distance in pixels between neighbouring
+// category labels.
+func categorySlot(n, pw int, edge bool) float64 {
+	if edge {
+		return float64(pw) / float64(maxInt(n-1, 1))
+	}
+	return float64(pw) / float64(maxInt(n, 1))
+}
+
+// categoryLabelX returns the x position of the label of category i.
+func categoryLabelX(i, n, px, pw int, edge bool) int {
+	if edge {
+		return px + i*pw/maxInt(n-1, 1)
+	}
+	return px + int((float64(i)+0.5)*float64(pw)/float64(n))
+}
+
+// categoryLabelLayout is how the category axis labels are placed.
+type categoryLabelLayout struct {
+	skip    int  // label every skip-th category
+	stagger bool // alternate the labels between two rows
+	rot     int  // rotation in degrees, clockwise
+	pad     int  // gap between the axis and the labels
+	height  int  // pixels taken below the axis, including pad
+}
+
+// layoutCategoryLabels fits the category labels into slot pixels each.
+// Rotated labels are skipped until their lines no longer overlap.
+// Horizontal labels that are too wide are staggered over two rows when
+// that is enough, and skipped otherwise. A TickLabelSkip on the axis
+// overrides the automatic choice.
+func (r *renderer) layoutCategoryLabels(ax *ChartAxis, cats []string, slot float64) categoryLabelLayout {
+	l := categoryLabelLayout{skip: 1}
+	if ax == nil || !ax.Visible || ax.TickLabelPos == "none" {
+		return l
+	}
+	l.pad = 2
+	if ax.MajorTickMark == TickMarkOutside || ax.MajorTickMark == TickMarkCross {
+		l.pad += 4
+	}
+	f := ax.Font
+	if f == nil {
+		f = NewFont()
+	}
+	lh := float64(r.chartLabelHeight(f))
+	maxW := 0.0
+	for _, c := range cats {
+		if tw := float64(r.layoutChartText(c, f).width); tw > maxW {
+			maxW = tw
+		}
+	}
+	const gap = 4 // minimum space between neighbouring labels
+	l.rot = maxInt(-90, minInt(ax.LabelRotation, 90))
+	var need float64
+	if l.rot != 0 {
+		rad := float64(l.rot) * math.Pi / 180
+		sin, cos := math.Abs(math.Sin(rad)), math.Abs(math.Cos(rad))
+		need = math.Min(maxW*cos+lh*sin, lh/sin) + gap
+		l.height = int(math.Ceil(maxW*sin + lh*cos))
+	} else {
+		need = maxW + gap
+		l.height = int(lh)
+	}
+	switch {
+	case ax.TickLabelSkip > 0:
+		l.skip = ax.TickLabelSkip
+	case need <= slot || slot <= 0:
+	case l.rot == 0 && need <= 2*slot:
+		l.stagger = true
+		l.height *= 2
+	default:
+		l.skip = int(math.Ceil(need / slot))
+	}
+	l.height += l.pad
+	return l
+}
+
+// drawCategoryAxisLabels draws the category labels below the axis at
+// axisY according to l.
+func (r *renderer) drawCategoryAxisLabels(ax *ChartAxis, cats []string, l categoryLabelLayout, edge bool, px, axisY, pw int) {
+	if ax == nil || !ax.Visible || ax.TickLabelPos == "none" {
+		return
+	}
+	f := ax.Font
+	if f == nil {
+		f = NewFont()
+	}
+	fc := r.rgba(f.Color)
+	lh := r.chartLabelHeight(f)
+	top := axisY + l.pad
+	for i := 0; i < len(cats); i += l.skip {
+		x := categoryLabelX(i, len(cats), px, pw, edge)
+		t := r.layoutChartText(cats[i], f)
+		if l.rot == 0 {
+			y := top
+			if l.stagger && (i/l.skip)%2 == 1 {
+				y += lh
+			}
+			t.drawCentered(r.img, fc, image.Rect(x, y, x, y+lh))
+			continue
+		}
+		r.drawRotatedCategoryLabel(t, fc, l.rot, x, top)
+	}
+}
+
+// drawRotatedCategoryLabel draws a label turned by rot degrees hanging
+// from (x, top): labels rising to the right end at x, labels falling to
+// the right start there.
+func (r *renderer) drawRotatedCategoryLabel(t chartText, c color.RGBA, rot, x, top int) {
+	tw := t.width
+	th := t.height()
+	if tw <= 0 || th <= 0 {
+		return
+	}
+	tmp := getRGBA(image.Rect(0, 0, tw, th))
+	defer putRGBA(tmp)
+	t.draw(tmp, c, 0, t.ascent.Ceil())
+
+	rad := float64(rot) * math.Pi / 180
+	sin, cos := math.Abs(math.Sin(rad)), math.Abs(math.Cos(rad))
+	bw := float64(tw)*cos + float64(th)*sin
+	bh := float64(tw)*sin + float64(th)*cos
+	cx := float64(x) - float64(tw)/2*cos
+	if rot > 0 {
+		cx = float64(x) + float64(tw)/2*cos
+	}
+	cy := float64(top) + bh/2
+	dx := int(math.Round(cx - bw/2))
+	dy := int(math.Round(cy - bh/2))
+	rotateAndComposite(r.img, tmp, dx, dy, int(math.Ceil(bw)), int(math.Ceil(bh)), rot)
+}
+
+// niceChartStep rounds a raw tick interval up to 1, 2 or 5 times a power of
+// ten.
+func niceChartStep(raw float64) float64 {
+	if raw <= 0 {
+		return 1
+	}
+	mag := math.Pow(10, math.Floor(math.Log10(raw)))
+	switch n := raw / mag; {
+	case n <= 1:
+		return mag
+	case n <= 2:
+		return 2 * mag
+	case n <= 5:
+		return 5 * mag
+	}
+	return 10 * mag
+}
+
+// seriesLabelText builds the data label for one point of s from the series
+// flags, formatting the value with the series number format. total is the
+// sum used for percentages; it is only meaningful for pie charts.
+func seriesLabelText(s *ChartSeries, cat string, v, total float64) string {
+	var parts []string
+	if s.ShowSeriesName && s.Title != "" {
+		parts = append(parts, s.Title)
+	}
+	if s.ShowCategoryName {
+		parts = append(parts, cat)
+	}
+	if s.ShowValue {
+		parts = append(parts, formatNumber(v, s.NumberFormat))
+	}
+	if s.ShowPercentage && total > 0 {
+		code := "0%"
+		if strings.Contains(s.NumberFormat, "%") {
+			code = s.NumberFormat
+		}
+		parts = append(parts, formatNumber(v/total, code))
+	}
+	sep := s.Separator
+	if sep == "" || sep == "," {
+		sep = ", "
+	}
+	return strings.Join(parts, sep)
+}
+
+// drawSliceLabel draws the data label of a pie or doughnut slice at dist
+// pixels from the center along angle.
+func (r *renderer) drawSliceLabel(s *ChartSeries, cat string, v, total float64, cx, cy int, dist, angle float64) {
+	label := seriesLabelText(s, cat, v, total)
+	if label == "" {
+		return
+	}
+	r.drawChartLabel(label, s.Font, cx+int(dist*math.Cos(angle)), cy+int(dist*math.Sin(angle)))
+}
+
+// chartLabelHeight returns the line height of chart label text in f.
+func (r *renderer) chartLabelHeight(f *Font) int {
+	if f == nil {
+		f = NewFont()
+	}
+	m := r.getFace(f).Metrics()
+	return (m.Ascent + m.Descent).Ceil()
+}
+
+// drawChartLabel draws text in f centered on (cx, cy).
+func (r *renderer) drawChartLabel(text string, f *Font, cx, cy int) {
+	if f == nil {
+		f = NewFont()
+	}
+	r.layoutChartText(text, f).drawCentered(r.img, r.rgba(f.Color), image.Rect(cx, cy, cx, cy))
+}
+
+// chartText is one line of chart text split into runs by script, the way
+// paragraph runs are built, so that CJK characters in labels are set in the
+// font's East Asian face (or a CJK fallback) rather than its Latin one.
+type chartText struct {
+	runs            []textRun
+	width           int
+	ascent, descent fixed.Int26_6
+}
+
+// layoutChartText splits and measures text set in f.
+func (r *renderer) layoutChartText(text string, f *Font) chartText {
+	if f == nil {
+		f = NewFont()
+	}
+	t := chartText{runs: r.buildParaTextRuns([]ParagraphElement{&TextRun{text: text, font: f}})}
+	for _, run := range t.runs {
+		t.width += run.width
+		m := run.face.Metrics()
+		if m.Ascent > t.ascent {
+			t.ascent = m.Ascent
+		}
+		if m.Descent > t.descent {
+			t.descent = m.Descent
+		}
+	}
+	return t
+}
+
+// height returns the height of the text's line box.
+func (t chartText) height() int { return (t.ascent + t.descent).Ceil() }
+
+// draw draws the text in c starting at x on the given baseline.
+func (t chartText) draw(dst draw.Image, c color.RGBA, x, baseline int) {
+	src := image.NewUniform(c)
+	for _, run := range t.runs {
+		d := &font.Drawer{Dst: dst, Src: src, Face: run.face, Dot: fixed.P(x, baseline)}
+		d.DrawString(run.text)
+		x += run.width
+	}
+}
+
+// drawCentered draws the text in c centered in rect.
+func (t chartText) drawCentered(dst draw.Image, c color.RGBA, rect image.Rectangle) {
+	if len(t.runs) == 0 {
+		return
+	}
+	x := rect.Min.X + (rect.Dx()-t.width)/2
+	y := rect.Min.Y + (rect.Dy()-t.height())/2 + t.ascent.Ceil()
+	t.draw(dst, c, x, y)
+}
+
 func (r *renderer) renderChartLegend(s *ChartShape, lx, ly, lw, lh int) {
 	ct := s.plotArea.GetType()
 	if ct == nil {
@@ -6203,17 +8884,17 @@ func (r *renderer) renderChartLegend(s *ChartShape, lx, ly, lw, lh int) {
 	case *BarChart:
 		for i, ser := range c.Series {
 			names = append(names, ser.Title)
-			colors = append(colors, getSeriesColor(ser, i, palette))
+			colors = append(colors, r.seriesColor(ser, i, palette))
 		}
 	case *Bar3DChart:
 		for i, ser := range c.Series {
 			names = append(names, ser.Title)
-			colors = append(colors, getSeriesColor(ser, i, palette))
+			colors = append(colors, r.seriesColor(ser, i, palette))
 		}
 	case *LineChart:
 		for i, ser := range c.Series {
 			names = append(names, ser.Title)
-			colors = append(colors, getSeriesColor(ser, i, palette))
+			colors = append(colors, r.seriesColor(ser, i, palette))
 		}
 	case *PieChart:
 		if len(c.Series) > 0 {
@@ -6239,17 +8920,17 @@ func (r *renderer) renderChartLegend(s *ChartShape, lx, ly, lw, lh int) {
 	case *AreaChart:
 		for i, ser := range c.Series {
 			names = append(names, ser.Title)
-			colors = append(colors, getSeriesColor(ser, i, palette))
+			colors = append(colors, r.seriesColor(ser, i, palette))
 		}
 	case *ScatterChart:
 		for i, ser := range c.Series {
 			names = append(names, ser.Title)
-			colors = append(colors, getSeriesColor(ser, i, palette))
+			colors = append(colors, r.seriesColor(ser, i, palette))
 		}
 	case *RadarChart:
 		for i, ser := range c.Series {
 			names = append(names, ser.Title)
-			colors = append(colors, getSeriesColor(ser, i, palette))
+			colors = append(colors, r.seriesColor(ser, i, palette))
 		}
 	}
 
@@ -6382,6 +9063,58 @@ func scaleImageBilinear(src image.Image, dstW, dstH int) *image.RGBA {
 	return dst
 }
 
+// clrChangeTolerance is the largest per-channel difference at which a pixel
+// still matches the clrChange source color, so that compression noise and
+// resampling around a flat background are replaced along with it.
+const clrChangeTolerance = 12
+
+// applyColorChange replaces the premultiplied pixels of img whose color is
+// within clrChangeTolerance of from with to. With useAlpha the pixel's alpha
+// is scaled by to's alpha; otherwise it is kept.
+func applyColorChange(img *image.RGBA, from, to color.RGBA, useAlpha bool) {
+	near := func(v, a uint8, want uint8) bool {
+		d := int(v)*255/int(a) - int(want)
+		return d >= -clrChangeTolerance && d <= clrChangeTolerance
+	}
+	for i := 0; i+3 < len(img.Pix); i += 4 {
+		a := img.Pix[i+3]
+		if a == 0 || !near(img.Pix[i], a, from.R) || !near(img.Pix[i+1], a, from.G) || !near(img.Pix[i+2], a, from.B) {
+			continue
+		}
+		af := float64(a) / 255
+		if useAlpha {
+			af *= float64(to.A) / 255
+		}
+		img.Pix[i] = uint8(float64(to.R)*af + 0.5)
+		img.Pix[i+1] = uint8(float64(to.G)*af + 0.5)
+		img.Pix[i+2] = uint8(float64(to.B)*af + 0.5)
+		img.Pix[i+3] = uint8(255*af + 0.5)
+	}
+}
+
+// applyDuotone maps the luminance of each premultiplied pixel in img onto the
+// gradient from dark to light, keeping the pixel's alpha. The alpha of the
+// duotone colors themselves further scales the result.
+func applyDuotone(img *image.RGBA, dark, light color.RGBA) {
+	for i := 0; i+3 < len(img.Pix); i += 4 {
+		a := img.Pix[i+3]
+		if a == 0 {
+			continue
+		}
+		// Luminance of the un-premultiplied color, 0..1.
+		lum := (0.299*float64(img.Pix[i]) + 0.587*float64(img.Pix[i+1]) + 0.114*float64(img.Pix[i+2])) / float64(a)
+		if lum > 1 {
+			lum = 1
+		}
+		c := lerpColor(dark, light, lum)
+		af := float64(a) / 255 * float64(c.A) / 255
+		img.Pix[i] = uint8(float64(c.R)*af + 0.5)
+		img.Pix[i+1] = uint8(float64(c.G)*af + 0.5)
+		img.Pix[i+2] = uint8(float64(c.B)*af + 0.5)
+		img.Pix[i+3] = uint8(255*af + 0.5)
+	}
+}
+
 // scaleImage scales an image using nearest-neighbor (fast fallback).
 func scaleImage(src image.Image, dstW, dstH int) *image.RGBA {
 	return scaleImageBilinear(src, dstW, dstH)
@@ -6485,12 +9218,30 @@ func decodeWMFDIB(data []byte, fc *FontCache) image.Image {
 		x, y    int
 		text    string
 		centerH bool // TA_CENTER
+		font    wmfFont
 	}
 
 	var dibs []dibRecord
 	var texts []textRecord
 	textAlignCenter := false
 
+	// Every object created by a record takes the lowest free slot in the
+	// object table; SelectObject and DeleteObject refer to these slots. Only
+	// fonts are kept, the other objects just occupy their slots.
+	var objects []bool
+	fonts := make(map[int]wmfFont)
+	var curFont wmfFont
+	newObject := func() int {
+		for i, used := range objects {
+			if !used {
+				objects[i] = true
+				return i
+			}
+		}
+		objects = append(objects, true)
+		return len(objects) - 1
+	}
+
 	pos := 18
 	for pos+6 < len(data) {
 		recSize := uint32(data[pos]) | uint32(data[pos+1])<<8 | uint32(data[pos+2])<<16 | uint32(data[pos+3])<<24
@@ -6553,6 +9304,32 @@ func decodeWMFDIB(data []byte, fc *FontCache) image.Image {
 				}
 			}
 
+		case 0x00F7, 0x01F9, 0x02FA, 0x02FC, 0x0142, 0x06FF: // Create palette, brush, pen, region
+			newObject()
+
+		case 0x02FB: // CreateFontIndirect
+			idx := newObject()
+			if recBytes >= 6+18 {
+				fonts[idx] = parseWMFLogFont(data[pos+6 : pos+recBytes])
+			}
+
+		case 0x012D: // SelectObject
+			if recBytes >= 8 {
+				idx := int(uint16(data[pos+6]) | uint16(data[pos+7])<<8)
+				if f, ok := fonts[idx]; ok {
+					curFont = f
+				}
+			}
+
+		case 0x01F0: // DeleteObject
+			if recBytes >= 8 {
+				idx := int(uint16(data[pos+6]) | uint16(data[pos+7])<<8)
+				if idx < len(objects) {
+					objects[idx] = false
+				}
+				delete(fonts, idx)
+			}
+
 		case 0x012E: // SetTextAlign
 			if recBytes >= 8 {
 				align := uint16(data[pos+6]) | uint16(data[pos+7])<<8
@@ -6573,7 +9350,7 @@ func decodeWMFDIB(data []byte, fc *FontCache) image.Image {
 				if p+strOff+count <= pos+recBytes && count > 0 {
 					raw := data[p+strOff : p+strOff+count]
 					text := decodeGBKToUTF8(raw)
-					texts = append(texts, textRecord{tx, ty, text, textAlignCenter})
+					texts = append(texts, textRecord{tx, ty, text, textAlignCenter, curFont})
 				}
 			}
 		}
@@ -6630,7 +9407,7 @@ func decodeWMFDIB(data []byte, fc *FontCache) image.Image {
 	for _, t := range texts {
 		tx := t.x * scale
 		ty := t.y * scale
-		drawWMFText(canvas, tx, ty, t.text, scale, t.centerH, fc)
+		drawWMFText(canvas, tx, ty, t.text, t.font, scale, t.centerH, fc)
 	}
 
 	return canvas
@@ -6645,16 +9422,54 @@ func decodeGBKToUTF8(data []byte) string {
 	return string(decoded)
 }
 
-// drawWMFText draws text onto the canvas at the given position.
-func drawWMFText(canvas *image.RGBA, x, y int, text string, scale int, centerH bool, fc *FontCache) {
+// wmfFont is the font selected by a WMF CreateFontIndirect record.
+type wmfFont struct {
+	height     int // em height in logical units; 0 selects the default size
+	bold       bool
+	italic     bool
+	escapement float64 // baseline angle in degrees, counterclockwise
+	face       string
+}
+
+// parseWMFLogFont parses the LOGFONT of a CreateFontIndirect record.
+func parseWMFLogFont(b []byte) wmfFont {
+	var f wmfFont
+	h := int(int16(uint16(b[0]) | uint16(b[1])<<8))
+	if h < 0 {
+		f.height = -h // character height
+	} else {
+		f.height = h * 85 / 100 // cell height includes internal leading
+	}
+	f.escapement = float64(int16(uint16(b[4])|uint16(b[5])<<8)) / 10
+	f.bold = int16(uint16(b[8])|uint16(b[9])<<8) >= 600
+	f.italic = b[10] != 0
+	if len(b) > 18 {
+		name := b[18:]
+		if i := bytes.IndexByte(name, 0); i >= 0 {
+			name = name[:i]
+		}
+		f.face = decodeGBKToUTF8(name)
+	}
+	return f
+}
+
+// drawWMFText draws text onto the canvas at the given position, in the
+// selected font's size, weight and escapement.
+func drawWMFText(canvas *image.RGBA, x, y int, text string, wf wmfFont, scale int, centerH bool, fc *FontCache) {
 	col := color.Black
 	// Try to use a proper font that supports Chinese characters
 	var face font.Face
 	if fc != nil {
-		// Try common Chinese fonts at a size proportional to the scale
 		fontSize := float64(10 * scale)
-		for _, name := range []string{"microsoft yahei", "微软雅黑", "simsun", "宋体", "simhei", "黑体"} {
-			if f := fc.GetFace(name, fontSize, false, false); f != nil {
+		if wf.height > 0 {
+			fontSize = float64(wf.height * scale)
+		}
+		names := []string{"microsoft yahei", "微软雅黑", "simsun", "宋体", "simhei", "黑体"}
+		if wf.face != "" {
+			names = append([]string{wf.face}, names...)
+		}
+		for _, name := range names {
+			if f := fc.GetFace(name, fontSize, wf.bold, wf.italic); f != nil {
 				face = f
 				break
 			}
@@ -6669,12 +9484,50 @@ func drawWMFText(canvas *image.RGBA, x, y int, text string, scale int, centerH b
 		Face: face,
 		Dot:  fixed.P(x, y+face.Metrics().Ascent.Ceil()),
 	}
+	textWidth := d.MeasureString(text)
 	if centerH {
 		// Measure text width and offset x to center
-		textWidth := d.MeasureString(text)
 		d.Dot.X = fixed.I(x) - textWidth/2
 	}
+	if wf.escapement == 0 {
+		d.DrawString(text)
+		return
+	}
+
+	// Draw upright into a buffer, then rotate it about the reference point.
+	m := face.Metrics()
+	bw, bh := textWidth.Ceil()+2, (m.Ascent + m.Descent).Ceil()+2
+	buf := image.NewRGBA(image.Rect(0, 0, bw, bh))
+	d.Dst = buf
+	d.Dot = fixed.P(1, 1+m.Ascent.Ceil())
 	d.DrawString(text)
+	refX := 1.0
+	if centerH {
+		refX += float64(textWidth) / 64 / 2
+	}
+	rad := wf.escapement * math.Pi / 180
+	cosA, sinA := math.Cos(rad), math.Sin(rad)
+	r := int(math.Hypot(float64(bw), float64(bh))) + 1
+	cb := canvas.Bounds()
+	for py := maxInt(y-r, cb.Min.Y); py < minInt(y+r, cb.Max.Y); py++ {
+		for px := maxInt(x-r, cb.Min.X); px < minInt(x+r, cb.Max.X); px++ {
+			dx, dy := float64(px-x)+0.5, float64(py-y)+0.5
+			u := dx*cosA - dy*sinA + refX
+			v := dx*sinA + dy*cosA + 1
+			iu, iv := int(math.Floor(u)), int(math.Floor(v))
+			if iu < 0 || iu >= bw || iv < 0 || iv >= bh {
+				continue
+			}
+			a := buf.Pix[buf.PixOffset(iu, iv)+3]
+			if a == 0 {
+				continue
+			}
+			o := canvas.PixOffset(px, py)
+			for c := 0; c < 3; c++ {
+				canvas.Pix[o+c] = uint8(uint32(canvas.Pix[o+c]) * uint32(255-a) / 255)
+			}
+		}
+	}
 }
 
 // parseDIB parses a BITMAPINFOHEADER + pixel data into an image.
@@ -0,0 +1,229 @@
+package renderworker
+
+import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrWorkerCrashed is returned by Supervisor.Render when the worker process
+// exited or broke the protocol while handling the job.
+var ErrWorkerCrashed = errors.New("render worker crashed")
+
+// ErrClosed is returned by Supervisor.Render after Close.
+var ErrClosed = errors.New("supervisor closed")
+
+// Supervisor runs jobs on a pool of worker processes. Workers are started
+// on demand, up to Workers at a time. A worker that crashes, exceeds the
+// job's context deadline or has served MaxJobs jobs is killed and replaced
+// by a fresh one on the next job.
+type Supervisor struct {
+	// Path and Args name the worker executable, which must call Serve on
+	// its stdin and stdout (e.g. cmd/gopptworker).
+	Path string
+	Args []string
+	// Env is the worker environment; nil inherits the supervisor's.
+	Env []string
+	// Workers is the maximum number of concurrent worker processes.
+	// 0 means 1.
+	Workers int
+	// MaxJobs recycles a worker after it has run this many jobs, bounding
+	// the memory a long-lived worker can accumulate. 0 means no limit.
+	MaxJobs int
+	// Stderr receives the workers' stderr; nil discards it.
+	Stderr io.Writer
+
+	mu     sync.Mutex
+	idle   []*worker
+	all    map[*worker]struct{}
+	slots  chan struct{}
+	closed bool
+	nextID atomic.Uint64
+}
+
+// NewSupervisor returns a Supervisor that runs workers with the given
+// executable and arguments.
+func NewSupervisor(path string, args ...string) *Supervisor {
+	return &Supervisor{Path: path, Args: args}
+}
+
+type worker struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	enc   *gob.Encoder
+	dec   *gob.Decoder
+	jobs  int
+}
+
+// Render runs job on a worker and returns its result. The job's ID is
+// assigned by the supervisor. A job that fails inside the worker is
+// reported through Result.Err with a nil error; the error is non-nil when
+// no result was received, e.g. ErrWorkerCrashed or the context's error.
+func (s *Supervisor) Render(ctx context.Context, job *Job) (*Result, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+
+	w, err := s.get()
+	if err != nil {
+		return nil, err
+	}
+	job.ID = s.nextID.Add(1)
+
+	type reply struct {
+		res *Result
+		err error
+	}
+	done := make(chan reply, 1)
+	go func() {
+		if err := w.enc.Encode(job); err != nil {
+			done <- reply{err: err}
+			return
+		}
+		var res Result
+		if err := w.dec.Decode(&res); err != nil {
+			done <- reply{err: err}
+			return
+		}
+		done <- reply{res: &res}
+	}()
+
+	select {
+	case <-ctx.Done():
+		s.discard(w)
+		<-done
+		return nil, ctx.Err()
+	case r := <-done:
+		if r.err != nil {
+			s.discard(w)
+			return nil, fmt.Errorf("%w: %v", ErrWorkerCrashed, r.err)
+		}
+		if r.res.ID != job.ID {
+			s.discard(w)
+			return nil, fmt.Errorf("%w: result for job %d, want %d", ErrWorkerCrashed, r.res.ID, job.ID)
+		}
+		w.jobs++
+		s.put(w)
+		return r.res, nil
+	}
+}
+
+// Close stops all workers. Jobs in flight fail with ErrWorkerCrashed.
+func (s *Supervisor) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	workers := make([]*worker, 0, len(s.all))
+	for w := range s.all {
+		workers = append(workers, w)
+	}
+	s.idle = nil
+	s.mu.Unlock()
+	for _, w := range workers {
+		s.discard(w)
+	}
+	return nil
+}
+
+// acquire waits for one of the Workers slots.
+func (s *Supervisor) acquire(ctx context.Context) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return ErrClosed
+	}
+	if s.slots == nil {
+		n := s.Workers
+		if n < 1 {
+			n = 1
+		}
+		s.slots = make(chan struct{}, n)
+		s.all = make(map[*worker]struct{})
+	}
+	slots := s.slots
+	s.mu.Unlock()
+	select {
+	case slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Supervisor) release() { <-s.slots }
+
+// get returns an idle worker or starts a new one.
+func (s *Supervisor) get() (*worker, error) {
+	s.mu.Lock()
+	if n := len(s.idle); n > 0 {
+		w := s.idle[n-1]
+		s.idle = s.idle[:n-1]
+		s.mu.Unlock()
+		return w, nil
+	}
+	s.mu.Unlock()
+
+	cmd := exec.Command(s.Path, s.Args...)
+	cmd.Env = s.Env
+	cmd.Stderr = s.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("start worker: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("start worker: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start worker: %w", err)
+	}
+	w := &worker{cmd: cmd, stdin: stdin, enc: gob.NewEncoder(stdin), dec: gob.NewDecoder(stdout)}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		w.kill()
+		return nil, ErrClosed
+	}
+	s.all[w] = struct{}{}
+	return w, nil
+}
+
+// put returns a healthy worker to the pool, or retires it once it has run
+// MaxJobs jobs.
+func (s *Supervisor) put(w *worker) {
+	s.mu.Lock()
+	if !s.closed && (s.MaxJobs <= 0 || w.jobs < s.MaxJobs) {
+		s.idle = append(s.idle, w)
+		s.mu.Unlock()
+		return
+	}
+	s.mu.Unlock()
+	s.discard(w)
+}
+
+// discard stops w and forgets it.
+func (s *Supervisor) discard(w *worker) {
+	s.mu.Lock()
+	_, ok := s.all[w]
+	delete(s.all, w)
+	s.mu.Unlock()
+	if ok {
+		w.kill()
+	}
+}
+
+// kill closes the worker's stdin, which ends Serve, and kills the process
+// in case it is stuck.
+func (w *worker) kill() {
+	w.stdin.Close()
+	if w.cmd.Process != nil {
+		w.cmd.Process.Kill()
+	}
+	w.cmd.Wait()
+}
@@ -0,0 +1,220 @@
+// Package renderworker renders presentations in separate worker processes,
+// so that a document that crashes or exhausts the renderer only takes down
+// its own process.
+//
+// A worker reads Jobs from stdin and writes one Result per Job to stdout,
+// both gob-encoded. Serve implements the worker side; cmd/gopptworker is a
+// ready-made worker binary. A Supervisor starts workers, hands them jobs and
+// replaces workers that crash, time out or have served their job quota.
+package renderworker
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"runtime/debug"
+	"strings"
+
+	gopresentation "github.com/VantageDataChat/GoPPT"
+)
+
+// Job is a render request: a PPTX file and the slides to render from it.
+type Job struct {
+	ID      uint64
+	PPTX    []byte
+	Slides  []int // 0-based slide indices; nil renders every slide
+	Options Options
+}
+
+// Options is the serializable subset of gopresentation.RenderOptions.
+type Options struct {
+	Width                       int
+	Format                      gopresentation.ImageFormat
+	JPEGQuality                 int
+	JPEGSubsampling             gopresentation.JPEGSubsampling
+	PNGPaletteSize              int
+	WebPLossless                bool
+	WebPQuality                 int
+	BackgroundColor             *color.RGBA
+	DPI                         float64
+	FontDirs                    []string
+	OverlayOpacityScale         float64
+	Bleed                       int64
+	Margin                      int64
+	CropMarks                   bool
+	TextOnly                    bool
+	ShowPlaceholderPrompts      bool
+	ShowUnsupportedPlaceholders bool
+	ColorMode                   gopresentation.ColorMode
+	TextHinting                 gopresentation.TextHinting
+	TextLineSnap                gopresentation.TextLineSnap
+	TextGamma                   float64
+	StemDarkening               float64
+	GradientInterpolation       gopresentation.GradientSpace
+	Supersample                 int
+	VideoRange                  bool
+	ImageFilter                 gopresentation.ImageFilter
+	ImageScaleSnap              float64
+	SharpBitonalImages          bool
+	ThemeColorOverrides         map[string]gopresentation.Color
+	DebugOverlay                bool
+	ShowGuides                  bool
+	ShowSafeAreas               bool
+}
+
+// NewOptions copies the serializable fields of opts. A nil opts yields the
+// default render options.
+func NewOptions(opts *gopresentation.RenderOptions) Options {
+	if opts == nil {
+		opts = gopresentation.DefaultRenderOptions()
+	}
+	return Options{
+		Width:                       opts.Width,
+		Format:                      opts.Format,
+		JPEGQuality:                 opts.JPEGQuality,
+		JPEGSubsampling:             opts.JPEGSubsampling,
+		PNGPaletteSize:              opts.PNGPaletteSize,
+		WebPLossless:                opts.WebPLossless,
+		WebPQuality:                 opts.WebPQuality,
+		BackgroundColor:             opts.BackgroundColor,
+		DPI:                         opts.DPI,
+		FontDirs:                    opts.FontDirs,
+		OverlayOpacityScale:         opts.OverlayOpacityScale,
+		Bleed:                       opts.Bleed,
+		Margin:                      opts.Margin,
+		CropMarks:                   opts.CropMarks,
+		TextOnly:                    opts.TextOnly,
+		ShowPlaceholderPrompts:      opts.ShowPlaceholderPrompts,
+		ShowUnsupportedPlaceholders: opts.ShowUnsupportedPlaceholders,
+		ColorMode:                   opts.ColorMode,
+		TextHinting:                 opts.TextHinting,
+		TextLineSnap:                opts.TextLineSnap,
+		TextGamma:                   opts.TextGamma,
+		StemDarkening:               opts.StemDarkening,
+		GradientInterpolation:       opts.GradientInterpolation,
+		Supersample:                 opts.Supersample,
+		VideoRange:                  opts.VideoRange,
+		ImageFilter:                 opts.ImageFilter,
+		ImageScaleSnap:              opts.ImageScaleSnap,
+		SharpBitonalImages:          opts.SharpBitonalImages,
+		ThemeColorOverrides:         opts.ThemeColorOverrides,
+		DebugOverlay:                opts.DebugOverlay,
+		ShowGuides:                  opts.ShowGuides,
+		ShowSafeAreas:               opts.ShowSafeAreas,
+	}
+}
+
+// RenderOptions returns the options as gopresentation.RenderOptions.
+func (o Options) RenderOptions() *gopresentation.RenderOptions {
+	return &gopresentation.RenderOptions{
+		Width:                       o.Width,
+		Format:                      o.Format,
+		JPEGQuality:                 o.JPEGQuality,
+		JPEGSubsampling:             o.JPEGSubsampling,
+		PNGPaletteSize:              o.PNGPaletteSize,
+		WebPLossless:                o.WebPLossless,
+		WebPQuality:                 o.WebPQuality,
+		BackgroundColor:             o.BackgroundColor,
+		DPI:                         o.DPI,
+		FontDirs:                    o.FontDirs,
+		OverlayOpacityScale:         o.OverlayOpacityScale,
+		Bleed:                       o.Bleed,
+		Margin:                      o.Margin,
+		CropMarks:                   o.CropMarks,
+		TextOnly:                    o.TextOnly,
+		ShowPlaceholderPrompts:      o.ShowPlaceholderPrompts,
+		ShowUnsupportedPlaceholders: o.ShowUnsupportedPlaceholders,
+		ColorMode:                   o.ColorMode,
+		TextHinting:                 o.TextHinting,
+		TextLineSnap:                o.TextLineSnap,
+		TextGamma:                   o.TextGamma,
+		StemDarkening:               o.StemDarkening,
+		GradientInterpolation:       o.GradientInterpolation,
+		Supersample:                 o.Supersample,
+		VideoRange:                  o.VideoRange,
+		ImageFilter:                 o.ImageFilter,
+		ImageScaleSnap:              o.ImageScaleSnap,
+		SharpBitonalImages:          o.SharpBitonalImages,
+		ThemeColorOverrides:         o.ThemeColorOverrides,
+		DebugOverlay:                o.DebugOverlay,
+		ShowGuides:                  o.ShowGuides,
+		ShowSafeAreas:               o.ShowSafeAreas,
+	}
+}
+
+// Result is the reply to the Job with the same ID.
+type Result struct {
+	ID     uint64
+	Slides []int    // 0-based indices of the rendered slides
+	Images [][]byte // encoded images, in the order of Slides
+	Err    string   // non-empty if the job failed
+}
+
+// Serve runs the worker side of the protocol: it decodes Jobs from r,
+// renders them and encodes a Result for each to w, until r reaches EOF.
+// Panics while rendering a job are reported in its Result.
+func Serve(r io.Reader, w io.Writer) error {
+	dec := gob.NewDecoder(r)
+	enc := gob.NewEncoder(w)
+	caches := make(map[string]*gopresentation.FontCache)
+	for {
+		var job Job
+		if err := dec.Decode(&job); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("decode job: %w", err)
+		}
+		opts := job.Options.RenderOptions()
+		key := strings.Join(opts.FontDirs, "\x00")
+		if caches[key] == nil {
+			caches[key] = gopresentation.NewFontCache(opts.FontDirs...)
+		}
+		opts.FontCache = caches[key]
+
+		res := render(&job, opts)
+		if err := enc.Encode(res); err != nil {
+			return fmt.Errorf("encode result: %w", err)
+		}
+	}
+}
+
+// render runs a single job.
+func render(job *Job, opts *gopresentation.RenderOptions) (res *Result) {
+	res = &Result{ID: job.ID}
+	defer func() {
+		if v := recover(); v != nil {
+			res = &Result{ID: job.ID, Err: fmt.Sprintf("panic: %v\n%s", v, debug.Stack())}
+		}
+	}()
+	pres, err := gopresentation.ReadFrom(bytes.NewReader(job.PPTX), int64(len(job.PPTX)))
+	if err != nil {
+		res.Err = err.Error()
+		return res
+	}
+	slides := job.Slides
+	if slides == nil {
+		slides = make([]int, pres.GetSlideCount())
+		for i := range slides {
+			slides[i] = i
+		}
+	}
+	for _, idx := range slides {
+		img, err := pres.SlideToImage(idx, opts)
+		if err != nil {
+			res.Err = fmt.Sprintf("slide %d: %v", idx+1, err)
+			return res
+		}
+		var buf bytes.Buffer
+		if err := gopresentation.EncodeImage(&buf, img, opts); err != nil {
+			res.Err = fmt.Sprintf("slide %d: %v", idx+1, err)
+			return res
+		}
+		res.Slides = append(res.Slides, idx)
+		res.Images = append(res.Images, buf.Bytes())
+	}
+	return res
+}
@@ -1,7 +1,9 @@
 package gopresentation
 
 import (
+	"bytes"
 	"fmt"
+	"image"
 	"os"
 	"strings"
 )
@@ -15,6 +17,8 @@ type Shape interface {
 	GetHeight() int64
 	GetName() string
 	GetRotation() int
+	// RawXML returns the shape's source XML when it was read from a file.
+	RawXML() []byte
 	// base returns the underlying BaseShape (unexported, internal use only).
 	base() *BaseShape
 }
@@ -46,6 +50,11 @@ type BaseShape struct {
 	border         *Border
 	shadow         *Shadow
 	hyperlink      *Hyperlink
+	locks          *ShapeLocks
+	textBox        bool
+	rawXML         []byte // source element when read from a file
+	id             int     // cNvPr id when read from a file
+	fade           float64 // render-time transparency, 0 (opaque) to 1 (hidden)
 }
 
 func (b *BaseShape) GetOffsetX() int64 { return b.offsetX }
@@ -56,6 +65,15 @@ func (b *BaseShape) GetName() string   { return b.name }
 func (b *BaseShape) GetRotation() int  { return b.rotation }
 func (b *BaseShape) base() *BaseShape  { return b }
 
+// GetID returns the shape id (cNvPr id) the shape had in the file it was
+// read from, or 0 for shapes created in code. Saving renumbers shapes.
+func (b *BaseShape) GetID() int { return b.id }
+
+// RawXML returns the shape's element (p:sp, p:pic, p:grpSp, ...) exactly as it
+// appeared in the slide part, or nil for shapes created in memory. It does not
+// reflect later changes made through the API.
+func (b *BaseShape) RawXML() []byte { return b.rawXML }
+
 func (b *BaseShape) SetOffsetX(x int64) *BaseShape { b.offsetX = x; return b }
 func (b *BaseShape) SetOffsetY(y int64) *BaseShape { b.offsetY = y; return b }
 func (b *BaseShape) SetWidth(w int64) *BaseShape   { b.width = w; return b }
@@ -128,6 +146,62 @@ func (b *BaseShape) SetShadow(s *Shadow) { b.shadow = s }
 func (b *BaseShape) GetHyperlink() *Hyperlink  { return b.hyperlink }
 func (b *BaseShape) SetHyperlink(h *Hyperlink) { b.hyperlink = h }
 
+// GetLocks returns the editing locks of the shape, or nil if it has none.
+func (b *BaseShape) GetLocks() *ShapeLocks  { return b.locks }
+func (b *BaseShape) SetLocks(l *ShapeLocks) { b.locks = l }
+
+// IsTextBox reports whether the shape is marked as a text box (txBox="1").
+func (b *BaseShape) IsTextBox() bool    { return b.textBox }
+func (b *BaseShape) SetTextBox(tb bool) { b.textBox = tb }
+
+// ShapeLocks holds the editing restrictions of a shape, as stored in the
+// <a:spLocks>, <a:picLocks>, <a:cxnSpLocks>, <a:grpSpLocks> and
+// <a:graphicFrameLocks> elements. Each flag forbids the named action in the
+// PowerPoint UI; none of them affect rendering.
+type ShapeLocks struct {
+	NoGrp              bool // may not be grouped
+	NoUngrp            bool // group may not be ungrouped
+	NoSelect           bool
+	NoRot              bool
+	NoChangeAspect     bool
+	NoMove             bool
+	NoResize           bool
+	NoEditPoints       bool
+	NoAdjustHandles    bool
+	NoChangeArrowheads bool
+	NoChangeShapeType  bool
+	NoTextEdit         bool
+	NoCrop             bool
+	NoDrilldown        bool
+}
+
+// attrs returns the lock flags paired with their XML attribute names, in
+// schema order.
+func (l *ShapeLocks) attrs() []struct {
+	name string
+	v    *bool
+} {
+	return []struct {
+		name string
+		v    *bool
+	}{
+		{"noGrp", &l.NoGrp},
+		{"noUngrp", &l.NoUngrp},
+		{"noDrilldown", &l.NoDrilldown},
+		{"noSelect", &l.NoSelect},
+		{"noRot", &l.NoRot},
+		{"noChangeAspect", &l.NoChangeAspect},
+		{"noMove", &l.NoMove},
+		{"noResize", &l.NoResize},
+		{"noEditPoints", &l.NoEditPoints},
+		{"noAdjustHandles", &l.NoAdjustHandles},
+		{"noChangeArrowheads", &l.NoChangeArrowheads},
+		{"noChangeShapeType", &l.NoChangeShapeType},
+		{"noTextEdit", &l.NoTextEdit},
+		{"noCrop", &l.NoCrop},
+	}
+}
+
 // CustomGeomPath represents a custom geometry path for freeform shapes.
 type CustomGeomPath struct {
 	Width    int64         // path coordinate space width
@@ -135,6 +209,12 @@ type CustomGeomPath struct {
 	Commands []PathCommand // path commands (moveTo, lineTo, close, etc.)
 }
 
+// closed reports whether the path ends with a close command.
+func (p *CustomGeomPath) closed() bool {
+	n := len(p.Commands)
+	return n > 0 && p.Commands[n-1].Type == "close"
+}
+
 // PathCommand represents a single path command.
 type PathCommand struct {
 	Type string // "moveTo", "lnTo", "close", "cubicBezTo", "quadBezTo", "arcTo"
@@ -159,6 +239,7 @@ type RichTextShape struct {
 	wordWrap        bool
 	verticalAlign   VerticalAlignment
 	textAnchor      TextAnchorType
+	anchorCtr       bool   // center the text block horizontally (bodyPr anchorCtr)
 	textDirection   string // "horz", "vert", "vert270", "eaVert", etc.
 	columns         int
 	columnSpacing   int64
@@ -171,6 +252,9 @@ type RichTextShape struct {
 	customPath  *CustomGeomPath // non-nil for freeform/custGeom shapes
 	headEnd     *LineEnd        // arrow at start of custom path (from <a:ln><a:headEnd>)
 	tailEnd     *LineEnd        // arrow at end of custom path (from <a:ln><a:tailEnd>)
+	// Preset geometry of the shape box (prstGeom); "" means a rectangle.
+	geometry     AutoShapeType
+	adjustValues map[string]int
 }
 
 // TextAnchorType represents the text anchoring type within a shape.
@@ -201,6 +285,7 @@ func NewRichTextShape() *RichTextShape {
 		wordWrap:   true,
 		columns:    1,
 	}
+	rt.textBox = true
 	return rt
 }
 
@@ -299,18 +384,41 @@ func (r *RichTextShape) GetTextAnchor() TextAnchorType {
 	return r.textAnchor
 }
 
+// SetAnchorCenter sets whether the text block is centered horizontally in the
+// shape, independently of paragraph alignment.
+func (r *RichTextShape) SetAnchorCenter(center bool) {
+	r.anchorCtr = center
+}
+
+// GetAnchorCenter reports whether the text block is centered horizontally.
+func (r *RichTextShape) GetAnchorCenter() bool {
+	return r.anchorCtr
+}
+
 // GetCustomPath returns the custom geometry path, if any.
 func (r *RichTextShape) GetCustomPath() *CustomGeomPath {
 	return r.customPath
 }
 
+// SetGeometry sets the preset geometry used for the shape's fill and border,
+// e.g. AutoShapeRoundedRect.
+func (r *RichTextShape) SetGeometry(t AutoShapeType) *RichTextShape {
+	r.geometry = t
+	return r
+}
+
+// GetGeometry returns the preset geometry; "" means a rectangle.
+func (r *RichTextShape) GetGeometry() AutoShapeType {
+	return r.geometry
+}
+
 // Paragraph represents a text paragraph.
 type Paragraph struct {
 	elements    []ParagraphElement
 	alignment   *Alignment
 	bullet      *Bullet
 	lineSpacing int // in points * 100
-	spaceBefore int
+	spaceBefore int // as lineSpacing: points * 100, or -percent * 1000 of a line
 	spaceAfter  int
 }
 
@@ -365,13 +473,15 @@ func (p *Paragraph) GetElements() []ParagraphElement {
 // GetSpaceBefore returns the space before the paragraph.
 func (p *Paragraph) GetSpaceBefore() int { return p.spaceBefore }
 
-// SetSpaceBefore sets the space before the paragraph.
+// SetSpaceBefore sets the space before the paragraph in hundredths of a
+// point (spcPts), or, when negative, in thousandths of a percent of the
+// paragraph's line height (spcPct; -50000 is half a line).
 func (p *Paragraph) SetSpaceBefore(v int) { p.spaceBefore = v }
 
 // GetSpaceAfter returns the space after the paragraph.
 func (p *Paragraph) GetSpaceAfter() int { return p.spaceAfter }
 
-// SetSpaceAfter sets the space after the paragraph.
+// SetSpaceAfter sets the space after the paragraph, as SetSpaceBefore.
 func (p *Paragraph) SetSpaceAfter(v int) { p.spaceAfter = v }
 
 // CreateTextRun creates a new text run.
@@ -429,13 +539,26 @@ type DrawingShape struct {
 	path               string // file path
 	data               []byte // raw image data
 	mimeType           string
+	externalURL        string // target of a linked (TargetMode="External") image
+	mediaPart          string // package part the image was read from, e.g. "ppt/media/image1.png"
+	mediaSize          int64  // size of mediaPart in bytes
 	resizeProportional bool
-	alpha              int // alphaModFix amount (0-100000); 0 means fully opaque (default)
+	alpha              int     // alphaModFix amount (0-100000); used when hasAlpha is set
+	hasAlpha           bool    // alpha was given, so that an amount of 0 hides the image
+	pixelated          bool    // render hint: enlarge by repeating pixels (SetPixelated)
+	duotone            []Color // <a:duotone> dark and light colors; nil when not recolored
+	clrChange          *ColorChange
 	// srcRect crop percentages in 1/1000 of a percent (e.g. 56333 = 56.333%)
 	cropLeft   int
 	cropTop    int
 	cropRight  int
 	cropBottom int
+
+	grayscale    bool           // <a:grayscl/> on the blip
+	geometry     string         // prstGeom of the outline; "" for a rectangle
+	adjustValues map[string]int // geometry adjust values, as for AutoShape
+	softEdge     int64          // <a:softEdge> radius in EMU; 0 for hard edges
+	reflection   *Reflection    // <a:reflection>; nil for none
 }
 
 func (d *DrawingShape) GetType() ShapeType { return ShapeTypeDrawing }
@@ -463,12 +586,35 @@ func (d *DrawingShape) SetImageData(data []byte, mimeType string) *DrawingShape
 	return d
 }
 
+// sizeToImage sets the size of a picture that has none to the pixel size
+// of its image at 96 DPI. Images that cannot be decoded are left unsized.
+func (d *DrawingShape) sizeToImage() {
+	if d.width != 0 || d.height != 0 {
+		return
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(d.data))
+	if err != nil {
+		return
+	}
+	d.width = int64(cfg.Width) * emuPerInch / 96
+	d.height = int64(cfg.Height) * emuPerInch / 96
+}
+
 // GetImageData returns the raw image data.
 func (d *DrawingShape) GetImageData() []byte { return d.data }
 
 // GetMimeType returns the image MIME type.
 func (d *DrawingShape) GetMimeType() string { return d.mimeType }
 
+// GetMediaPart returns the package part the image was read from, or "" for
+// pictures that were not read from a file.
+func (d *DrawingShape) GetMediaPart() string { return d.mediaPart }
+
+// GetMediaSize returns the size in bytes of the image part the picture was
+// read from. It is known even when ReaderOptions.SkipMedia left the image
+// unread.
+func (d *DrawingShape) GetMediaSize() int64 { return d.mediaSize }
+
 // maxImageFileSize is the maximum allowed size for an image file loaded from disk.
 const maxImageFileSize = 50 << 20 // 50 MB
 
@@ -550,6 +696,91 @@ func (d *DrawingShape) GetCropBottom() int { return d.cropBottom }
 // GetAlphaValue returns the alphaModFix amount (0-100000).
 func (d *DrawingShape) GetAlphaValue() int { return d.alpha }
 
+// SetAlphaValue sets the alphaModFix amount (0-100000). The image's own alpha
+// channel is multiplied by it.
+func (d *DrawingShape) SetAlphaValue(amt int) *DrawingShape {
+	d.alpha = amt
+	d.hasAlpha = true
+	return d
+}
+
+// opacity returns the fraction of the image's own alpha that is drawn.
+func (d *DrawingShape) opacity() float64 {
+	if !d.hasAlpha || d.alpha >= 100000 {
+		return 1
+	}
+	if d.alpha <= 0 {
+		return 0
+	}
+	return float64(d.alpha) / 100000
+}
+
+// IsPixelated reports whether the image is marked to be enlarged by
+// repeating its pixels.
+func (d *DrawingShape) IsPixelated() bool { return d.pixelated }
+
+// SetPixelated marks the image, such as a QR code, barcode or screenshot, to
+// be rendered with hard pixel edges: it is enlarged by repeating whole
+// pixels and snapped to a whole-number scale when that is close. The hint
+// only affects rendering and is not saved.
+func (d *DrawingShape) SetPixelated(pixelated bool) *DrawingShape {
+	d.pixelated = pixelated
+	return d
+}
+
+// GetExternalURL returns the target of a linked image, or "" if the image is
+// embedded. A linked image without an embedded copy has no data and renders as
+// a placeholder frame.
+func (d *DrawingShape) GetExternalURL() string { return d.externalURL }
+
+// SetExternalURL links the image to an external target instead of embedding it.
+func (d *DrawingShape) SetExternalURL(url string) *DrawingShape {
+	d.externalURL = url
+	return d
+}
+
+// GetDuotone returns the duotone recolor pair (dark, light), or nil.
+func (d *DrawingShape) GetDuotone() []Color { return d.duotone }
+
+// SetDuotone recolors the image so that black maps to dark and white to light,
+// with intermediate luminances interpolated between them.
+func (d *DrawingShape) SetDuotone(dark, light Color) *DrawingShape {
+	d.duotone = []Color{dark, light}
+	return d
+}
+
+// ColorChange describes an <a:clrChange> blip effect: pixels matching From
+// are replaced by To. With UseAlpha set the replacement takes To's alpha, which
+// is how PowerPoint's "Set Transparent Color" makes a background see-through.
+type ColorChange struct {
+	From     Color
+	To       Color
+	UseAlpha bool
+}
+
+// GetColorChange returns the clrChange effect, or nil.
+func (d *DrawingShape) GetColorChange() *ColorChange { return d.clrChange }
+
+// SetColorChange replaces pixels of color from with to, including to's alpha.
+func (d *DrawingShape) SetColorChange(from, to Color) *DrawingShape {
+	d.clrChange = &ColorChange{From: from, To: to, UseAlpha: true}
+	return d
+}
+
+// SetTransparentColor makes pixels of color c fully transparent.
+func (d *DrawingShape) SetTransparentColor(c Color) *DrawingShape {
+	return d.SetColorChange(c, NewColor("00"+colorRGB(c)))
+}
+
+// GetReflection returns the picture's reflection, or nil.
+func (d *DrawingShape) GetReflection() *Reflection { return d.reflection }
+
+// SetReflection draws a faded mirror image below the picture; nil removes it.
+func (d *DrawingShape) SetReflection(r *Reflection) *DrawingShape {
+	d.reflection = r
+	return d
+}
+
 // AutoShape represents a predefined shape (rectangle, ellipse, etc.).
 type AutoShape struct {
 	BaseShape
@@ -557,6 +788,7 @@ type AutoShape struct {
 	text          string
 	paragraphs    []*Paragraph
 	textAnchor    TextAnchorType
+	anchorCtr     bool // center the text block horizontally (bodyPr anchorCtr)
 	textDirection string
 	adjustValues  map[string]int // avLst adjustment values (e.g. "adj1" -> 10690)
 	fontScale     int            // normAutofit fontScale in thousandths of a percent (e.g. 62500 = 62.5%), 0 means 100%
@@ -694,6 +926,7 @@ func (a *AutoShape) GetAdjustValues() map[string]int {
 type LineShape struct {
 	BaseShape
 	lineStyle     BorderStyle
+	dashPattern   []float64 // custom dash in multiples of the line width; overrides lineStyle
 	lineWidth     int
 	lineWidthEMU  int             // raw line width in EMU for precision; 0 means use lineWidth*12700
 	lineColor     Color
@@ -702,6 +935,8 @@ type LineShape struct {
 	connectorType string          // prstGeom value: "line", "straightConnector1", "bentConnector3", etc.
 	adjustValues  map[string]int  // adjustment values for connector geometry
 	customPath    *CustomGeomPath // non-nil for custGeom connectors (freeform curved arrows)
+	lineJoin      LineJoin
+	miterLimit    int // as Border.MiterLimit
 }
 
 func (l *LineShape) GetType() ShapeType { return ShapeTypeLine }
@@ -724,6 +959,30 @@ func (l *LineShape) SetLineStyle(s BorderStyle) *LineShape {
 // GetLineStyle returns the line style.
 func (l *LineShape) GetLineStyle() BorderStyle { return l.lineStyle }
 
+// SetDashPattern sets a custom dash pattern of alternating dash and gap
+// lengths, in multiples of the line width.
+func (l *LineShape) SetDashPattern(pattern ...float64) *LineShape {
+	l.lineStyle = BorderDash
+	l.dashPattern = pattern
+	return l
+}
+
+// GetDashPattern returns the custom dash pattern, or nil if the line uses a
+// preset style.
+func (l *LineShape) GetDashPattern() []float64 { return l.dashPattern }
+
+// SetLineJoin sets how the line turns corners of bent connectors and
+// freeform paths. miterLimit applies to LineJoinMiter, as in
+// Border.MiterLimit.
+func (l *LineShape) SetLineJoin(join LineJoin, miterLimit int) *LineShape {
+	l.lineJoin = join
+	l.miterLimit = miterLimit
+	return l
+}
+
+// GetLineJoin returns the line join and miter limit.
+func (l *LineShape) GetLineJoin() (LineJoin, int) { return l.lineJoin, l.miterLimit }
+
 // SetLineWidth sets the line width.
 func (l *LineShape) SetLineWidth(w int) *LineShape {
 	l.lineWidth = w
@@ -783,6 +1042,38 @@ type TableShape struct {
 	numCols    int
 	colWidths  []int64 // individual column widths in EMU (from gridCol)
 	rowHeights []int64 // individual row heights in EMU (from tr)
+	borders    *TableBorders
+	look       TableLook
+	style      *tableStyle // table style from tableStyleId, if defined in the package
+}
+
+// TableLook holds the <a:tblPr> flags that select which conditional parts of
+// the table style apply: header and total rows, first and last columns, and
+// banded rows and columns.
+type TableLook struct {
+	FirstRow bool
+	LastRow  bool
+	FirstCol bool
+	LastCol  bool
+	BandRow  bool
+	BandCol  bool
+}
+
+// tableStyle is a table style read from ppt/tableStyles.xml.
+type tableStyle struct {
+	borders *TableBorders    // wholeTbl borders
+	fills   map[string]*Fill // cell fill by part: wholeTbl, band1H, firstRow, ...
+}
+
+// TableBorders represents table-level borders, typically from the table
+// style's wholeTbl definition. Cell borders override them side by side.
+type TableBorders struct {
+	Top     *Border
+	Bottom  *Border
+	Left    *Border
+	Right   *Border
+	InsideH *Border // between rows
+	InsideV *Border // between columns
 }
 
 func (t *TableShape) GetType() ShapeType { return ShapeTypeTable }
@@ -793,6 +1084,7 @@ func NewTableShape(rows, cols int) *TableShape {
 		numRows: rows,
 		numCols: cols,
 		rows:    make([][]*TableCell, rows),
+		look:    TableLook{FirstRow: true, BandRow: true},
 	}
 	for i := 0; i < rows; i++ {
 		table.rows[i] = make([]*TableCell, cols)
@@ -822,6 +1114,18 @@ func (t *TableShape) GetNumRows() int { return t.numRows }
 // GetNumCols returns the number of columns.
 func (t *TableShape) GetNumCols() int { return t.numCols }
 
+// GetBorders returns the table-level borders, or nil if none are set.
+func (t *TableShape) GetBorders() *TableBorders { return t.borders }
+
+// SetBorders sets the table-level borders.
+func (t *TableShape) SetBorders(b *TableBorders) { t.borders = b }
+
+// GetLook returns the table style options (<a:tblPr> flags).
+func (t *TableShape) GetLook() TableLook { return t.look }
+
+// SetLook sets the table style options (<a:tblPr> flags).
+func (t *TableShape) SetLook(l TableLook) { t.look = l }
+
 // SetHeight sets the height and returns for chaining.
 func (t *TableShape) SetHeight(h int64) *TableShape {
 	t.height = h
@@ -838,14 +1142,26 @@ func (t *TableShape) SetWidth(w int64) *TableShape {
 type TableCell struct {
 	paragraphs []*Paragraph
 	fill       *Fill
+	fillSet    bool // fill given in tcPr; otherwise the table style's fill applies
 	border     *CellBorders
+	bordersSet string // sides ("L", "R", "T", "B") given in tcPr; other sides without a line take the table style's border
 	colSpan    int
 	rowSpan    int
 	hMerge     bool // continuation of horizontal merge (skip rendering)
 	vMerge     bool // continuation of vertical merge (skip rendering)
+	// Inner margins in EMU (tcPr marL, marR, marT, marB).
+	marginLeft, marginRight, marginTop, marginBottom int64
+	anchor                                           TextAnchorType // tcPr anchor; TextAnchorNone is top
 }
 
-// CellBorders represents borders for a table cell.
+// Default table cell margins in EMU, as PowerPoint uses them.
+const (
+	defaultCellMarginLR = 91440 // 0.1"
+	defaultCellMarginTB = 45720 // 0.05"
+)
+
+// CellBorders represents borders for a table cell. A nil side, or one with
+// Style BorderNone that the file did not give, takes the table-level border.
 type CellBorders struct {
 	Top    *Border
 	Bottom *Border
@@ -864,8 +1180,12 @@ func NewTableCell() *TableCell {
 			Left:   NewBorder(),
 			Right:  NewBorder(),
 		},
-		colSpan: 1,
-		rowSpan: 1,
+		colSpan:      1,
+		rowSpan:      1,
+		marginLeft:   defaultCellMarginLR,
+		marginRight:  defaultCellMarginLR,
+		marginTop:    defaultCellMarginTB,
+		marginBottom: defaultCellMarginTB,
 	}
 }
 
@@ -903,3 +1223,20 @@ func (tc *TableCell) SetRowSpan(span int) { tc.rowSpan = span }
 
 // GetRowSpan returns the row span.
 func (tc *TableCell) GetRowSpan() int { return tc.rowSpan }
+
+// SetMargins sets the space between the cell edges and its text, in EMU.
+// Default: 91440 (0.1") left and right, 45720 (0.05") top and bottom.
+func (tc *TableCell) SetMargins(left, right, top, bottom int64) {
+	tc.marginLeft, tc.marginRight, tc.marginTop, tc.marginBottom = left, right, top, bottom
+}
+
+// GetMargins returns the cell margins in EMU.
+func (tc *TableCell) GetMargins() (left, right, top, bottom int64) {
+	return tc.marginLeft, tc.marginRight, tc.marginTop, tc.marginBottom
+}
+
+// SetAnchor sets the vertical alignment of the cell text.
+func (tc *TableCell) SetAnchor(anchor TextAnchorType) { tc.anchor = anchor }
+
+// GetAnchor returns the vertical alignment of the cell text.
+func (tc *TableCell) GetAnchor() TextAnchorType { return tc.anchor }
@@ -1,6 +1,13 @@
 package gopresentation
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // Transition represents a slide transition.
 type Transition struct {
@@ -32,6 +39,20 @@ const (
 	TransitionSpeedFast   TransitionSpeed = "fast"
 )
 
+// SlideTiming describes how a slide advances during a slide show, as stored on
+// its <p:transition> element.
+type SlideTiming struct {
+	// AdvanceOnClick reports whether a click advances the slide (advClick).
+	AdvanceOnClick bool
+	// AutoAdvance reports whether the slide advances on its own after
+	// AdvanceAfter (advTm), typically from rehearsed timings.
+	AutoAdvance  bool
+	AdvanceAfter time.Duration
+	// TransitionDuration is the length of the transition into the slide,
+	// or 0 if the slide has no transition.
+	TransitionDuration time.Duration
+}
+
 // Slide represents a single slide in a presentation.
 type Slide struct {
 	shapes     []Shape
@@ -42,6 +63,8 @@ type Slide struct {
 	comments   []*Comment
 	animations []*Animation
 	background *Fill
+	timing     SlideTiming
+	rawXML     []byte // slide part as read from the package
 }
 
 // newSlide creates a new empty slide.
@@ -51,9 +74,15 @@ func newSlide() *Slide {
 		visible:    true,
 		comments:   make([]*Comment, 0),
 		animations: make([]*Animation, 0),
+		timing:     SlideTiming{AdvanceOnClick: true},
 	}
 }
 
+// RawXML returns the slide part XML as read from the package, or nil for
+// slides created in memory. It does not reflect later changes made through the
+// API; use it to handle markup the library does not model.
+func (s *Slide) RawXML() []byte { return s.rawXML }
+
 // GetName returns the slide name.
 func (s *Slide) GetName() string {
 	return s.name
@@ -94,6 +123,36 @@ func (s *Slide) SetTransition(t *Transition) {
 	s.transition = t
 }
 
+// Timing returns the slide's advance timing. Video and GIF exporters can use
+// AdvanceAfter to pace frames by the deck's rehearsed timings; slides without
+// AutoAdvance wait for a click.
+func (s *Slide) Timing() SlideTiming {
+	t := s.timing
+	if tr := s.transition; tr != nil && tr.Type != TransitionNone {
+		ms := tr.Duration
+		if ms == 0 {
+			// Without an explicit duration the speed decides; OOXML defaults to fast.
+			switch tr.Speed {
+			case TransitionSpeedSlow:
+				ms = 1000
+			case TransitionSpeedMedium:
+				ms = 750
+			default:
+				ms = 500
+			}
+		}
+		t.TransitionDuration = time.Duration(ms) * time.Millisecond
+	}
+	return t
+}
+
+// SetTiming sets the slide's advance timing. TransitionDuration is ignored;
+// set the transition's Duration instead.
+func (s *Slide) SetTiming(t SlideTiming) {
+	t.TransitionDuration = 0
+	s.timing = t
+}
+
 // GetShapes returns all shapes on the slide.
 func (s *Slide) GetShapes() []Shape {
 	return s.shapes
@@ -129,27 +188,42 @@ func (s *Slide) CreateDrawingShape() *DrawingShape {
 
 // AddImage creates a drawing shape from a file path and adds it to the slide.
 // This is a convenience method matching unioffice's Slide.AddImage pattern.
+// The picture is sized to its pixel size at 96 DPI.
 func (s *Slide) AddImage(path string) (*DrawingShape, error) {
 	shape := NewDrawingShape()
 	if err := shape.SetImageFromFile(path); err != nil {
 		return nil, err
 	}
+	shape.sizeToImage()
 	s.shapes = append(s.shapes, shape)
 	return shape, nil
 }
 
 // AddImageData creates a drawing shape from raw image data and adds it to the slide.
+// The picture is sized to its pixel size at 96 DPI.
 func (s *Slide) AddImageData(data []byte, mimeType string) *DrawingShape {
 	shape := NewDrawingShape()
 	shape.SetImageData(data, mimeType)
+	shape.sizeToImage()
 	s.shapes = append(s.shapes, shape)
 	return shape
 }
 
-// AddTextBox creates a new rich text shape and adds it to the slide.
-// This is an alias for CreateRichTextShape matching unioffice naming.
+// Default sizes of the shapes made by the Add builders, so that a shape is
+// visible before the caller sizes it.
+const (
+	defaultTextBoxWidth  = 4 * emuPerInch
+	defaultTextBoxHeight = emuPerInch / 2
+	defaultColumnWidth   = emuPerInch
+	defaultRowHeight     = 370840 // about 0.41 inch, PowerPoint's row height for 18 pt text
+)
+
+// AddTextBox creates a new rich text shape, 4 by 0.5 inches, and adds it to
+// the slide. It matches unioffice's Slide.AddTextBox.
 func (s *Slide) AddTextBox() *RichTextShape {
-	return s.CreateRichTextShape()
+	shape := s.CreateRichTextShape()
+	shape.width, shape.height = defaultTextBoxWidth, defaultTextBoxHeight
+	return shape
 }
 
 // AddAutoShape creates a new auto shape and adds it to the slide.
@@ -158,10 +232,14 @@ func (s *Slide) AddAutoShape() *AutoShape {
 	return s.CreateAutoShape()
 }
 
-// AddTable creates a new table shape and adds it to the slide.
+// AddTable creates a new table shape with one-inch columns and rows of
+// PowerPoint's default height, about 0.41 inch, and adds it to the slide.
 // This matches unioffice's Slide.AddTable pattern.
 func (s *Slide) AddTable(rows, cols int) *TableShape {
-	return s.CreateTableShape(rows, cols)
+	shape := s.CreateTableShape(rows, cols)
+	shape.width = int64(max(cols, 0)) * defaultColumnWidth
+	shape.height = int64(max(rows, 0)) * defaultRowHeight
+	return shape
 }
 
 // CreateTableShape creates a new table shape and adds it to the slide.
@@ -324,6 +402,19 @@ func (s *Slide) ExtractText() string {
 	return joinNonEmpty(parts, "\n")
 }
 
+// GetTitle returns the text of the slide's title placeholder, with paragraphs
+// joined by spaces, or "" if the slide has no title.
+func (s *Slide) GetTitle() string {
+	for _, shape := range s.shapes {
+		ph, ok := shape.(*PlaceholderShape)
+		if !ok || (ph.phType != PlaceholderTitle && ph.phType != PlaceholderCtrTitle) {
+			continue
+		}
+		return strings.Join(extractParagraphsText(ph.paragraphs), " ")
+	}
+	return ""
+}
+
 // GetTextBoxes returns all RichTextShape (text box) shapes on the slide.
 func (s *Slide) GetTextBoxes() []*RichTextShape {
 	var boxes []*RichTextShape
@@ -345,3 +436,71 @@ func (s *Slide) RemoveShapeByPointer(target Shape) bool {
 	}
 	return false
 }
+
+// MoveShapeToFront moves the shape with the given name to the top of the
+// stacking order, in front of every other shape in its slide or group.
+// Shapes inside groups are searched too. Moving changes how the slide
+// renders and the order in which it is saved.
+func (s *Slide) MoveShapeToFront(name string) error {
+	shapes, i := s.findShape(func(sh Shape) bool { return sh.base().name == name })
+	if shapes == nil {
+		return fmt.Errorf("shape %q not found", name)
+	}
+	shape := (*shapes)[i]
+	copy((*shapes)[i:], (*shapes)[i+1:])
+	(*shapes)[len(*shapes)-1] = shape
+	return nil
+}
+
+// MoveShapeToBack moves the shape with the given name to the bottom of the
+// stacking order, behind every other shape in its slide or group.
+func (s *Slide) MoveShapeToBack(name string) error {
+	shapes, i := s.findShape(func(sh Shape) bool { return sh.base().name == name })
+	if shapes == nil {
+		return fmt.Errorf("shape %q not found", name)
+	}
+	shape := (*shapes)[i]
+	copy((*shapes)[1:i+1], (*shapes)[:i])
+	(*shapes)[0] = shape
+	return nil
+}
+
+// SetShapeOpacity fades the shape with the given name, or with the given
+// shape id (see BaseShape.GetID) when no shape has that name, when the
+// slide is rendered. alpha multiplies the opacity of everything the shape
+// draws, including its fill, outline, text, image and group children: 0
+// hides the shape and 1 restores it. The saved file is not affected.
+func (s *Slide) SetShapeOpacity(nameOrID string, alpha float64) error {
+	shapes, i := s.findShape(func(sh Shape) bool { return sh.base().name == nameOrID })
+	if shapes == nil {
+		if id, err := strconv.Atoi(nameOrID); err == nil && id > 0 {
+			shapes, i = s.findShape(func(sh Shape) bool { return sh.base().id == id })
+		}
+	}
+	if shapes == nil {
+		return fmt.Errorf("shape %q not found", nameOrID)
+	}
+	(*shapes)[i].base().fade = 1 - math.Max(0, math.Min(alpha, 1))
+	return nil
+}
+
+// findShape returns the shape list holding the first shape for which match
+// is true, searching groups depth-first, and the shape's index in it. The
+// list is nil when no shape matches.
+func (s *Slide) findShape(match func(Shape) bool) (*[]Shape, int) {
+	var find func(shapes *[]Shape) (*[]Shape, int)
+	find = func(shapes *[]Shape) (*[]Shape, int) {
+		for i, shape := range *shapes {
+			if match(shape) {
+				return shapes, i
+			}
+			if g, ok := shape.(*GroupShape); ok {
+				if list, j := find(&g.shapes); list != nil {
+					return list, j
+				}
+			}
+		}
+		return nil, -1
+	}
+	return find(&s.shapes)
+}
@@ -0,0 +1,158 @@
+package gopresentation
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// SlideHash returns a hex-encoded SHA-256 digest of everything that affects
+// how the slide renders: its shapes, text, images, background and the
+// content it inherits from its layout and master, plus the slide size and
+// the photo album settings.
+// Two slides with the same hash render identically with the same options.
+func (p *Presentation) SlideHash(index int) (string, error) {
+	if index < 0 || index >= len(p.slides) {
+		return "", errors.New("slide index out of range")
+	}
+	h := sha256.New()
+	hw := &valueHasher{h: h, seen: make(map[hashedPointer]int)}
+	hw.int(p.layout.CX)
+	hw.int(p.layout.CY)
+	hw.value(reflect.ValueOf(p.slides[index]))
+	if p.photoAlbum != nil {
+		hw.value(reflect.ValueOf(p.photoAlbum))
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// chartDigest returns a digest of what c shows, leaving out its frame, so
+// that a chart can tell whether it changed since it was read.
+func chartDigest(c *ChartShape) string {
+	h := sha256.New()
+	hw := &valueHasher{h: h, seen: make(map[hashedPointer]int)}
+	hw.value(reflect.ValueOf(c.title))
+	hw.value(reflect.ValueOf(c.plotArea))
+	hw.value(reflect.ValueOf(c.legend))
+	hw.value(reflect.ValueOf(c.view3D))
+	hw.value(reflect.ValueOf(c.displayBlankAs))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// valueHasher writes a canonical encoding of a value graph to a hash. It
+// reads unexported fields, so it sees the whole slide model, and it visits
+// each pointer once so shared values and cycles are handled.
+type valueHasher struct {
+	h    hash.Hash
+	buf  [8]byte
+	seen map[hashedPointer]int
+}
+
+type hashedPointer struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+func (w *valueHasher) int(v int64) {
+	binary.LittleEndian.PutUint64(w.buf[:], uint64(v))
+	w.h.Write(w.buf[:])
+}
+
+func (w *valueHasher) bytes(b []byte) {
+	w.int(int64(len(b)))
+	w.h.Write(b)
+}
+
+func (w *valueHasher) value(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		w.int(-1)
+	case reflect.Bool:
+		if v.Bool() {
+			w.int(1)
+		} else {
+			w.int(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		w.int(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		w.int(int64(v.Uint()))
+	case reflect.Float32, reflect.Float64:
+		w.int(int64(math.Float64bits(v.Float())))
+	case reflect.String:
+		w.bytes([]byte(v.String()))
+	case reflect.Slice:
+		if v.IsNil() {
+			w.int(-1)
+			return
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			w.bytes(v.Bytes())
+			return
+		}
+		fallthrough
+	case reflect.Array:
+		w.int(int64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			w.value(v.Index(i))
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			w.int(-1)
+			return
+		}
+		// Hash each entry on its own and sort the digests, so that the
+		// result does not depend on map iteration order.
+		entries := make([][]byte, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			eh := sha256.New()
+			ew := &valueHasher{h: eh, seen: make(map[hashedPointer]int)}
+			ew.value(iter.Key())
+			ew.value(iter.Value())
+			entries = append(entries, eh.Sum(nil))
+		}
+		sort.Slice(entries, func(i, j int) bool { return bytes.Compare(entries[i], entries[j]) < 0 })
+		w.int(int64(len(entries)))
+		for _, e := range entries {
+			w.h.Write(e)
+		}
+	case reflect.Pointer:
+		if v.IsNil() {
+			w.int(-1)
+			return
+		}
+		key := hashedPointer{v.Pointer(), v.Type()}
+		if n, ok := w.seen[key]; ok {
+			w.int(-2)
+			w.int(int64(n))
+			return
+		}
+		w.seen[key] = len(w.seen)
+		w.value(v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			w.int(-1)
+			return
+		}
+		w.bytes([]byte(v.Elem().Type().String()))
+		w.value(v.Elem())
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			w.value(v.Field(i))
+		}
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		// Not content; only record whether it is set.
+		if v.IsNil() {
+			w.int(0)
+		} else {
+			w.int(1)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package gopresentation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// SlideMapVersion is the version of the SlideMap JSON format. It changes
+// only when existing fields change meaning or are removed; new fields may
+// be added within a version.
+const SlideMapVersion = 1
+
+// SlideMap describes the interactive content of a rendered slide, so that
+// a web viewer can overlay links, tooltips and selectable text on the
+// image from SlideToImage without reading the PPTX. It is written as JSON
+// by WriteSlideMap:
+//
+//	{
+//	  "version": 1,
+//	  "slide": 1,
+//	  "slideSize": {"cx": 12192000, "cy": 6858000},
+//	  "image": {"width": 960, "height": 540, "originX": 0, "originY": 0},
+//	  "shapes": [
+//	    {
+//	      "name": "Title 1", "kind": "placeholder:title",
+//	      "bounds": {"x": 66, "y": 28, "width": 828, "height": 102},
+//	      "emu": {"x": 838200, "y": 365125, "cx": 10515600, "cy": 1325563},
+//	      "text": "Quarterly results",
+//	      "link": {"url": "https://example.com"},
+//	      "textLinks": [{"text": "results", "slide": 3}]
+//	    }
+//	  ]
+//	}
+//
+// Bounds are pixels of the rendered image, before rotation; Rotation gives
+// the clockwise turn about the center. Shapes are listed in z-order, back
+// to front, with groups flattened into their children.
+type SlideMap struct {
+	Version   int             `json:"version"`
+	Slide     int             `json:"slide"` // 1-based
+	SlideSize SlideMapSize    `json:"slideSize"`
+	Image     SlideMapImage   `json:"image"`
+	Shapes    []SlideMapShape `json:"shapes"`
+}
+
+// SlideMapSize is the slide size in EMU.
+type SlideMapSize struct {
+	CX int64 `json:"cx"`
+	CY int64 `json:"cy"`
+}
+
+// SlideMapImage is the size of the rendered image. OriginX and OriginY
+// are where the slide's top-left corner lies in the image when the render
+// options add a bleed or margin.
+type SlideMapImage struct {
+	Width   int `json:"width"`
+	Height  int `json:"height"`
+	OriginX int `json:"originX"`
+	OriginY int `json:"originY"`
+}
+
+// SlideMapRect is a rectangle in image pixels.
+type SlideMapRect struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// SlideMapEMURect is a rectangle in slide EMU.
+type SlideMapEMURect struct {
+	X  int64 `json:"x"`
+	Y  int64 `json:"y"`
+	CX int64 `json:"cx"`
+	CY int64 `json:"cy"`
+}
+
+// SlideMapLink is a hyperlink: an external URL or, for links within the
+// presentation, a 1-based slide number.
+type SlideMapLink struct {
+	Text    string `json:"text,omitempty"` // the linked run text, for text links
+	URL     string `json:"url,omitempty"`
+	Slide   int    `json:"slide,omitempty"`
+	Tooltip string `json:"tooltip,omitempty"`
+}
+
+// SlideMapShape is one shape of a SlideMap.
+type SlideMapShape struct {
+	Name      string          `json:"name,omitempty"`
+	Kind      string          `json:"kind"`
+	Alt       string          `json:"alt,omitempty"`
+	Bounds    SlideMapRect    `json:"bounds"`
+	EMU       SlideMapEMURect `json:"emu"`
+	Rotation  int             `json:"rotation,omitempty"`
+	Text      string          `json:"text,omitempty"` // paragraphs separated by "\n"
+	Link      *SlideMapLink   `json:"link,omitempty"` // click action of the whole shape
+	TextLinks []SlideMapLink  `json:"textLinks,omitempty"`
+}
+
+// SlideMap returns the interactive content of a slide with coordinates in
+// the image that SlideToImage renders with opts.
+func (p *Presentation) SlideMap(slideIndex int, opts *RenderOptions) (*SlideMap, error) {
+	if slideIndex < 0 || slideIndex >= len(p.slides) {
+		return nil, fmt.Errorf("slide index %d out of range (0-%d)", slideIndex, len(p.slides)-1)
+	}
+	if opts == nil {
+		opts = DefaultRenderOptions()
+	}
+	width := opts.Width
+	if width <= 0 {
+		width = 960
+	}
+	slideW := float64(p.layout.CX)
+	slideH := float64(p.layout.CY)
+	imgH := int(float64(width) * slideH / slideW)
+	scaleX := float64(width) / slideW
+	scaleY := float64(imgH) / slideH
+	off := maxInt(int(math.Round(float64(opts.Bleed)*scaleX)), 0) +
+		maxInt(int(math.Round(float64(opts.Margin)*scaleX)), 0)
+
+	m := &SlideMap{
+		Version:   SlideMapVersion,
+		Slide:     slideIndex + 1,
+		SlideSize: SlideMapSize{CX: p.layout.CX, CY: p.layout.CY},
+		Image:     SlideMapImage{Width: width + 2*off, Height: imgH + 2*off, OriginX: off, OriginY: off},
+		Shapes:    []SlideMapShape{},
+	}
+	var walk func(shapes []Shape)
+	walk = func(shapes []Shape) {
+		for _, shape := range shapes {
+			if g, ok := shape.(*GroupShape); ok {
+				walk(g.slideSpaceChildren())
+				continue
+			}
+			b := shape.base()
+			x0 := off + int(math.Round(float64(b.offsetX)*scaleX))
+			y0 := off + int(math.Round(float64(b.offsetY)*scaleY))
+			x1 := off + int(math.Round(float64(b.offsetX+b.width)*scaleX))
+			y1 := off + int(math.Round(float64(b.offsetY+b.height)*scaleY))
+			ms := SlideMapShape{
+				Name:     b.name,
+				Kind:     slideMapKind(shape),
+				Alt:      b.description,
+				Bounds:   SlideMapRect{X: x0, Y: y0, Width: x1 - x0, Height: y1 - y0},
+				EMU:      SlideMapEMURect{X: b.offsetX, Y: b.offsetY, CX: b.width, CY: b.height},
+				Rotation: b.rotation,
+				Link:     slideMapLink(b.hyperlink, ""),
+			}
+			var texts []string
+			addParagraphs := func(paragraphs []*Paragraph) {
+				texts = append(texts, extractParagraphsText(paragraphs)...)
+				for _, para := range paragraphs {
+					for _, elem := range para.elements {
+						if tr, ok := elem.(*TextRun); ok {
+							if l := slideMapLink(tr.hyperlink, tr.text); l != nil {
+								ms.TextLinks = append(ms.TextLinks, *l)
+							}
+						}
+					}
+				}
+			}
+			switch s := shape.(type) {
+			case *PlaceholderShape:
+				addParagraphs(s.paragraphs)
+			case *RichTextShape:
+				addParagraphs(s.paragraphs)
+			case *AutoShape:
+				if len(s.paragraphs) > 0 {
+					addParagraphs(s.paragraphs)
+				} else if s.text != "" {
+					texts = append(texts, s.text)
+				}
+			case *TableShape:
+				for _, row := range s.rows {
+					for _, cell := range row {
+						addParagraphs(cell.paragraphs)
+					}
+				}
+			}
+			ms.Text = strings.Join(texts, "\n")
+			m.Shapes = append(m.Shapes, ms)
+		}
+	}
+	walk(p.slides[slideIndex].shapes)
+	return m, nil
+}
+
+// WriteSlideMap writes the SlideMap of a slide to w as JSON.
+func (p *Presentation) WriteSlideMap(w io.Writer, slideIndex int, opts *RenderOptions) error {
+	m, err := p.SlideMap(slideIndex, opts)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(m); err != nil {
+		return fmt.Errorf("encode slide map: %w", err)
+	}
+	return nil
+}
+
+// slideMapKind names the kind of shape in a SlideMap.
+func slideMapKind(shape Shape) string {
+	if ph, ok := shape.(*PlaceholderShape); ok {
+		return "placeholder:" + string(ph.phType)
+	}
+	return debugShapeKind(shape)
+}
+
+// slideMapLink converts a hyperlink. Links without a target, or with a URL
+// scheme that NewHyperlink would reject, yield nil.
+func slideMapLink(h *Hyperlink, text string) *SlideMapLink {
+	if h == nil {
+		return nil
+	}
+	l := &SlideMapLink{Text: text, Tooltip: h.Tooltip}
+	if h.IsInternal {
+		l.Slide = h.SlideNumber
+	} else if isValidHyperlinkURL(h.URL) {
+		l.URL = h.URL
+	}
+	if l.URL == "" && l.Slide == 0 {
+		return nil
+	}
+	return l
+}
@@ -0,0 +1,433 @@
+package gopresentation
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// --- Binary snapshots of the presentation model ---
+//
+// A snapshot is the in-memory model written field by field, so that a deck
+// parsed once can be cached and loaded by other processes without parsing
+// its XML again. The header carries a fingerprint of the model's types: a
+// snapshot only loads into the build of the library that wrote it, and a
+// reader should fall back to the original file when UnmarshalBinary fails.
+
+// snapshotMagic starts every snapshot.
+const snapshotMagic = "GOPPTSNP"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// snapshotTypes lists the concrete types that are stored in interface
+// fields of the model, by the name written for them.
+var snapshotTypes = func() map[string]reflect.Type {
+	m := make(map[string]reflect.Type)
+	for _, v := range []any{
+		// Shape
+		&RichTextShape{}, &DrawingShape{}, &AutoShape{}, &LineShape{}, &TableShape{},
+		&ChartShape{}, &GroupShape{}, &PlaceholderShape{}, &UnsupportedShape{}, &InkShape{},
+		// ParagraphElement
+		&TextRun{}, &BreakElement{},
+		// ChartType
+		&BarChart{}, &Bar3DChart{}, &LineChart{}, &AreaChart{}, &PieChart{},
+		&Pie3DChart{}, &DoughnutChart{}, &ScatterChart{}, &RadarChart{},
+		// CustomProperty values
+		"", false, int(0), int32(0), int64(0), float32(0), float64(0), time.Time{},
+	} {
+		t := reflect.TypeOf(v)
+		m[t.String()] = t
+	}
+	return m
+}()
+
+var snapshotFingerprint = sync.OnceValue(func() uint64 {
+	h := fnv.New64a()
+	seen := make(map[reflect.Type]bool)
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		fmt.Fprintf(h, "%s/%d;", t, t.Kind())
+		if seen[t] {
+			return
+		}
+		seen[t] = true
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array:
+			walk(t.Elem())
+		case reflect.Map:
+			walk(t.Key())
+			walk(t.Elem())
+		case reflect.Struct:
+			if t == timeType {
+				return
+			}
+			for i := 0; i < t.NumField(); i++ {
+				fmt.Fprintf(h, "%s:", t.Field(i).Name)
+				walk(t.Field(i).Type)
+			}
+		}
+	}
+	walk(reflect.TypeOf(Presentation{}))
+	for _, name := range slices.Sorted(maps.Keys(snapshotTypes)) {
+		walk(snapshotTypes[name])
+	}
+	return h.Sum64()
+})
+
+// MarshalBinary writes the presentation model to a snapshot, which
+// UnmarshalBinary loads much faster than the presentation can be read from
+// its file. Snapshots are meant for caches: they can only be loaded by the
+// same version of this library.
+func (p *Presentation) MarshalBinary() ([]byte, error) {
+	e := &snapshotEncoder{ptrs: make(map[snapshotPtr]uint64)}
+	e.buf = append(e.buf, snapshotMagic...)
+	e.buf = binary.LittleEndian.AppendUint64(e.buf, snapshotFingerprint())
+	if err := e.value(reflect.ValueOf(p).Elem()); err != nil {
+		return nil, fmt.Errorf("snapshot: %w", err)
+	}
+	return e.buf, nil
+}
+
+// UnmarshalBinary replaces the presentation with the one in a snapshot
+// written by MarshalBinary.
+func (p *Presentation) UnmarshalBinary(data []byte) error {
+	n := len(snapshotMagic)
+	if len(data) < n+8 || string(data[:n]) != snapshotMagic {
+		return errors.New("snapshot: not a presentation snapshot")
+	}
+	if binary.LittleEndian.Uint64(data[n:]) != snapshotFingerprint() {
+		return errors.New("snapshot: written by a different version of the library")
+	}
+	d := &snapshotDecoder{buf: data[n+8:]}
+	var q Presentation
+	if err := d.value(reflect.ValueOf(&q).Elem()); err != nil {
+		return fmt.Errorf("snapshot: %w", err)
+	}
+	if len(d.buf) != 0 {
+		return errors.New("snapshot: trailing data")
+	}
+	*p = q
+	return nil
+}
+
+// settable returns v, a field that may be unexported, as a settable value.
+func settable(v reflect.Value) reflect.Value {
+	if v.CanSet() {
+		return v
+	}
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
+
+type snapshotEncoder struct {
+	buf  []byte
+	ptrs map[snapshotPtr]uint64 // pointers already written, by their index
+}
+
+// snapshotPtr identifies a pointer by its type as well as its address,
+// since an embedded struct shares its address with the one around it.
+type snapshotPtr struct {
+	t reflect.Type
+	p uintptr
+}
+
+func (e *snapshotEncoder) uint(v uint64) { e.buf = binary.AppendUvarint(e.buf, v) }
+
+func (e *snapshotEncoder) string(s string) {
+	e.uint(uint64(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+func (e *snapshotEncoder) value(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			e.buf = append(e.buf, 1)
+		} else {
+			e.buf = append(e.buf, 0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.buf = binary.AppendVarint(e.buf, v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		e.uint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		e.buf = binary.LittleEndian.AppendUint64(e.buf, math.Float64bits(v.Float()))
+	case reflect.String:
+		e.string(v.String())
+	case reflect.Pointer:
+		// 0 is nil, a known index refers to a pointer written before, and
+		// the next index is followed by what it points to.
+		if v.IsNil() {
+			e.uint(0)
+			return nil
+		}
+		key := snapshotPtr{v.Type(), v.Pointer()}
+		if id, ok := e.ptrs[key]; ok {
+			e.uint(id)
+			return nil
+		}
+		id := uint64(len(e.ptrs) + 1)
+		e.ptrs[key] = id
+		e.uint(id)
+		return e.value(v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			e.string("")
+			return nil
+		}
+		c := v.Elem()
+		if snapshotTypes[c.Type().String()] != c.Type() {
+			return fmt.Errorf("cannot store a value of type %s", c.Type())
+		}
+		e.string(c.Type().String())
+		return e.value(c)
+	case reflect.Slice:
+		// The length is stored plus one, so that nil slices stay nil.
+		if v.IsNil() {
+			e.uint(0)
+			return nil
+		}
+		e.uint(uint64(v.Len()) + 1)
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			e.buf = append(e.buf, v.Bytes()...)
+			return nil
+		}
+		return e.elems(v)
+	case reflect.Array:
+		return e.elems(v)
+	case reflect.Map:
+		if v.IsNil() {
+			e.uint(0)
+			return nil
+		}
+		e.uint(uint64(v.Len()) + 1)
+		it := v.MapRange()
+		for it.Next() {
+			if err := e.value(it.Key()); err != nil {
+				return err
+			}
+			if err := e.value(it.Value()); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		if v.Type() == timeType {
+			if !v.CanInterface() {
+				v = settable(v)
+			}
+			b, err := v.Interface().(time.Time).MarshalBinary()
+			if err != nil {
+				return err
+			}
+			e.string(string(b))
+			return nil
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if err := e.value(v.Field(i)); err != nil {
+				return fmt.Errorf("%s.%s: %w", v.Type(), v.Type().Field(i).Name, err)
+			}
+		}
+	default:
+		return fmt.Errorf("cannot store a %s", v.Kind())
+	}
+	return nil
+}
+
+func (e *snapshotEncoder) elems(v reflect.Value) error {
+	for i := 0; i < v.Len(); i++ {
+		if err := e.value(v.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type snapshotDecoder struct {
+	buf  []byte
+	ptrs []reflect.Value // pointers read so far; index i is id i+1
+}
+
+var errSnapshotTruncated = errors.New("truncated data")
+
+func (d *snapshotDecoder) uint() (uint64, error) {
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		return 0, errSnapshotTruncated
+	}
+	d.buf = d.buf[n:]
+	return v, nil
+}
+
+// bytes returns the next n bytes.
+func (d *snapshotDecoder) bytes(n uint64) ([]byte, error) {
+	if n > uint64(len(d.buf)) {
+		return nil, errSnapshotTruncated
+	}
+	b := d.buf[:n:n]
+	d.buf = d.buf[n:]
+	return b, nil
+}
+
+func (d *snapshotDecoder) string() (string, error) {
+	n, err := d.uint()
+	if err != nil {
+		return "", err
+	}
+	b, err := d.bytes(n)
+	return string(b), err
+}
+
+// value reads into v, which must be settable.
+func (d *snapshotDecoder) value(v reflect.Value) error {
+	v = settable(v)
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := d.bytes(1)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b[0] != 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x, n := binary.Varint(d.buf)
+		if n <= 0 {
+			return errSnapshotTruncated
+		}
+		d.buf = d.buf[n:]
+		v.SetInt(x)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		x, err := d.uint()
+		if err != nil {
+			return err
+		}
+		v.SetUint(x)
+	case reflect.Float32, reflect.Float64:
+		b, err := d.bytes(8)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(b)))
+	case reflect.String:
+		s, err := d.string()
+		if err != nil {
+			return err
+		}
+		v.SetString(s)
+	case reflect.Pointer:
+		id, err := d.uint()
+		if err != nil {
+			return err
+		}
+		switch {
+		case id == 0:
+			v.SetZero()
+		case id <= uint64(len(d.ptrs)):
+			p := d.ptrs[id-1]
+			if p.Type() != v.Type() {
+				return fmt.Errorf("pointer %d is a %s, not a %s", id, p.Type(), v.Type())
+			}
+			v.Set(p)
+		case id == uint64(len(d.ptrs))+1:
+			// Register the pointer before reading its target, which may
+			// point back to it.
+			p := reflect.New(v.Type().Elem())
+			d.ptrs = append(d.ptrs, p)
+			v.Set(p)
+			return d.value(p.Elem())
+		default:
+			return fmt.Errorf("bad pointer index %d", id)
+		}
+	case reflect.Interface:
+		name, err := d.string()
+		if err != nil || name == "" {
+			v.SetZero()
+			return err
+		}
+		t, ok := snapshotTypes[name]
+		if !ok || !t.Implements(v.Type()) {
+			return fmt.Errorf("unexpected type %q in a %s", name, v.Type())
+		}
+		c := reflect.New(t).Elem()
+		if err := d.value(c); err != nil {
+			return err
+		}
+		v.Set(c)
+	case reflect.Slice:
+		n, err := d.uint()
+		if err != nil || n == 0 {
+			v.SetZero()
+			return err
+		}
+		n--
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b, err := d.bytes(n)
+			if err != nil {
+				return err
+			}
+			v.SetBytes(append([]byte(nil), b...))
+			return nil
+		}
+		// Every element takes at least a byte.
+		if n > uint64(len(d.buf)) {
+			return errSnapshotTruncated
+		}
+		v.Set(reflect.MakeSlice(v.Type(), int(n), int(n)))
+		return d.elems(v)
+	case reflect.Array:
+		return d.elems(v)
+	case reflect.Map:
+		n, err := d.uint()
+		if err != nil || n == 0 {
+			v.SetZero()
+			return err
+		}
+		n--
+		if n > uint64(len(d.buf)) {
+			return errSnapshotTruncated
+		}
+		m := reflect.MakeMapWithSize(v.Type(), int(n))
+		for i := uint64(0); i < n; i++ {
+			k := reflect.New(v.Type().Key()).Elem()
+			if err := d.value(k); err != nil {
+				return err
+			}
+			e := reflect.New(v.Type().Elem()).Elem()
+			if err := d.value(e); err != nil {
+				return err
+			}
+			m.SetMapIndex(k, e)
+		}
+		v.Set(m)
+	case reflect.Struct:
+		if v.Type() == timeType {
+			s, err := d.string()
+			if err != nil {
+				return err
+			}
+			return v.Addr().Interface().(*time.Time).UnmarshalBinary([]byte(s))
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if err := d.value(v.Field(i)); err != nil {
+				return fmt.Errorf("%s.%s: %w", v.Type(), v.Type().Field(i).Name, err)
+			}
+		}
+	default:
+		return fmt.Errorf("cannot load a %s", v.Kind())
+	}
+	return nil
+}
+
+func (d *snapshotDecoder) elems(v reflect.Value) error {
+	for i := 0; i < v.Len(); i++ {
+		if err := d.value(v.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
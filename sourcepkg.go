@@ -0,0 +1,519 @@
+package gopresentation
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"reflect"
+	"strings"
+)
+
+// sourcePackage is the package a presentation was read from. When the
+// presentation is saved again, parts of the package whose content the model
+// has not changed are copied byte for byte, so that everything the model
+// does not cover, such as animations, SmartArt, custom XML or embedded
+// objects, survives the round trip.
+type sourcePackage struct {
+	names   []string // part names in package order
+	parts   map[string][]byte
+	methods map[string]uint16 // zip compression method of each part
+	slides  []sourceSlide     // in presentation order
+	layout  DocumentLayout
+	// props and presProps hash the document and presentation properties
+	// as read, and settings those of the other presentation.xml settings.
+	props     string
+	presProps string
+	settings  string
+}
+
+// sourceSlide records a slide as read, to tell later whether it changed.
+type sourceSlide struct {
+	slide    *Slide
+	part     string // e.g. "ppt/slides/slide3.xml"
+	hash     string // SlideHash as read
+	notes    string
+	comments string // hash of the comments as read
+}
+
+// hashOf returns a digest of the value graph reachable from v.
+func hashOf(v any) string {
+	h := sha256.New()
+	hw := &valueHasher{h: h, seen: make(map[hashedPointer]int)}
+	hw.value(reflect.ValueOf(v))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// presentationSettings returns a digest of the settings of p that are
+// written to ppt/presentation.xml besides its slides and slide size.
+func presentationSettings(p *Presentation) string {
+	return hashOf([]any{p.notesSize, p.embeddedFonts, p.photoAlbum})
+}
+
+// keepSource records the package zr that pres was read from, with its
+// slides in slideParts.
+func keepSource(zr *zip.Reader, pres *Presentation, slideParts []string) error {
+	src := &sourcePackage{
+		parts:     make(map[string][]byte, len(zr.File)),
+		methods:   make(map[string]uint16, len(zr.File)),
+		layout:    *pres.layout,
+		props:     hashOf(pres.properties),
+		presProps: hashOf(pres.presentationProperties),
+		settings:  presentationSettings(pres),
+	}
+	for _, f := range zr.File {
+		if strings.HasSuffix(f.Name, "/") {
+			continue
+		}
+		if f.UncompressedSize64 > maxZipEntrySize {
+			return fmt.Errorf("file %s exceeds maximum allowed size (%d bytes)", f.Name, maxZipEntrySize)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open %s in zip: %w", f.Name, err)
+		}
+		data, err := io.ReadAll(io.LimitReader(rc, int64(maxZipEntrySize)+1))
+		rc.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read %s from zip: %w", f.Name, err)
+		}
+		if _, dup := src.parts[f.Name]; !dup {
+			src.names = append(src.names, f.Name)
+		}
+		src.parts[f.Name] = data
+		src.methods[f.Name] = f.Method
+	}
+	for i, slide := range pres.slides {
+		hash, err := pres.SlideHash(i)
+		if err != nil {
+			return err
+		}
+		src.slides = append(src.slides, sourceSlide{
+			slide:    slide,
+			part:     slideParts[i],
+			hash:     hash,
+			notes:    slide.notes,
+			comments: hashOf(slide.comments),
+		})
+	}
+	pres.source = src
+	return nil
+}
+
+// canKeepSource reports whether the presentation can be saved by updating
+// its source package: the slides must be the ones read, in the same order,
+// on a slide of the same size, with their comments unchanged and notes
+// added only to slides that had a notes page, and the other
+// presentation.xml settings must be unchanged.
+func (w *PPTXWriter) canKeepSource() bool {
+	p := w.presentation
+	src := p.source
+	if src == nil || len(p.slides) != len(src.slides) || p.layout == nil ||
+		p.layout.CX != src.layout.CX || p.layout.CY != src.layout.CY ||
+		presentationSettings(p) != src.settings {
+		return false
+	}
+	for i, ss := range src.slides {
+		slide := p.slides[i]
+		if slide != ss.slide || hashOf(slide.comments) != ss.comments {
+			return false
+		}
+		if slide.notes != ss.notes && slide.notes != "" && src.relTarget(ss.part, relTypeNotesSlide) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// relsPartName returns the relationships part of part.
+func relsPartName(part string) string {
+	dir, name := path.Split(part)
+	return dir + "_rels/" + name + ".rels"
+}
+
+// rels parses the relationships of part; nil if it has none.
+func (src *sourcePackage) rels(part string) []xmlRelationship {
+	data, ok := src.parts[relsPartName(part)]
+	if !ok {
+		return nil
+	}
+	var rels xmlRelationships
+	if xml.Unmarshal(data, &rels) != nil {
+		return nil
+	}
+	return rels.Relationships
+}
+
+// relTarget returns the package path of the first relationship of part
+// with type relType, or "".
+func (src *sourcePackage) relTarget(part, relType string) string {
+	for _, rel := range src.rels(part) {
+		if rel.Type == relType && rel.TargetMode != "External" {
+			return resolveRelativePath(path.Dir(part), rel.Target)
+		}
+	}
+	return ""
+}
+
+// relativeTarget returns the relationship target of part target as seen
+// from part from.
+func relativeTarget(from, target string) string {
+	fromDir := strings.Split(path.Dir(from), "/")
+	to := strings.Split(target, "/")
+	i := 0
+	for i < len(fromDir) && i < len(to)-1 && fromDir[i] == to[i] {
+		i++
+	}
+	return strings.Repeat("../", len(fromDir)-i) + strings.Join(to[i:], "/")
+}
+
+// sourceUpdate collects the parts that replace or are added to a source
+// package.
+type sourceUpdate struct {
+	src      *sourcePackage
+	parts    map[string][]byte
+	added    []string // new part names in the order added
+	types    xmlContentTypes
+	typesSet bool
+	byHash   map[string]string // digest of source media content → part name
+}
+
+// set replaces or adds a part.
+func (u *sourceUpdate) set(name string, data []byte) {
+	if _, ok := u.src.parts[name]; !ok {
+		if _, ok := u.parts[name]; !ok {
+			u.added = append(u.added, name)
+		}
+	}
+	u.parts[name] = data
+}
+
+// exists reports whether a part is in the package being written.
+func (u *sourceUpdate) exists(name string) bool {
+	_, inSrc := u.src.parts[name]
+	_, inNew := u.parts[name]
+	return inSrc || inNew
+}
+
+// freeName returns a part name of the form prefix + n + suffix that is not
+// taken yet.
+func (u *sourceUpdate) freeName(prefix, suffix string) string {
+	for n := 1; ; n++ {
+		if name := fmt.Sprintf("%s%d%s", prefix, n, suffix); !u.exists(name) {
+			return name
+		}
+	}
+}
+
+// addMedia stores a media part and returns its name, reusing a source part
+// with the same content.
+func (u *sourceUpdate) addMedia(data []byte, ext, contentType string) string {
+	if u.byHash == nil {
+		u.byHash = make(map[string]string)
+		for _, name := range u.src.names {
+			if strings.HasPrefix(name, "ppt/media/") {
+				sum := sha256.Sum256(u.src.parts[name])
+				if _, ok := u.byHash[string(sum[:])]; !ok {
+					u.byHash[string(sum[:])] = name
+				}
+			}
+		}
+	}
+	sum := sha256.Sum256(data)
+	if name, ok := u.byHash[string(sum[:])]; ok {
+		return name
+	}
+	name := u.freeName("ppt/media/image", "."+ext)
+	u.set(name, data)
+	u.byHash[string(sum[:])] = name
+	u.addDefault(ext, contentType)
+	return name
+}
+
+// addDefault makes sure the package declares a content type for ext.
+func (u *sourceUpdate) addDefault(ext, contentType string) {
+	for _, d := range u.types.Defaults {
+		if strings.EqualFold(d.Extension, ext) {
+			return
+		}
+	}
+	u.types.Defaults = append(u.types.Defaults, xmlDefault{Extension: ext, ContentType: contentType})
+	u.typesSet = true
+}
+
+// addOverride declares the content type of a new part.
+func (u *sourceUpdate) addOverride(name, contentType string) {
+	u.types.Overrides = append(u.types.Overrides, xmlOverride{PartName: "/" + name, ContentType: contentType})
+	u.typesSet = true
+}
+
+// writeKeepingSource writes the source package with the changes made to
+// the presentation since it was read. Slides that changed are written
+// again from the model, keeping their layout and their other
+// relationships; their pictures and charts become new parts unless a
+// source part has the same content.
+func (w *PPTXWriter) writeKeepingSource(writer io.Writer) error {
+	p := w.presentation
+	src := p.source
+	u := &sourceUpdate{src: src, parts: make(map[string][]byte)}
+	if err := xml.Unmarshal(src.parts["[Content_Types].xml"], &u.types); err != nil {
+		return fmt.Errorf("failed to parse content types: %w", err)
+	}
+	u.types.Xmlns = nsContentTypes
+
+	for i, ss := range src.slides {
+		hash, err := p.SlideHash(i)
+		if err != nil {
+			return err
+		}
+		if hash == ss.hash && p.slides[i].notes == ss.notes {
+			continue
+		}
+		if err := w.updateSlide(u, i, ss, hash != ss.hash); err != nil {
+			return fmt.Errorf("slide %d: %w", i+1, err)
+		}
+	}
+
+	// Regenerated property parts replace the source ones where present.
+	var staged []func(*zip.Writer) error
+	if hashOf(p.properties) != src.props {
+		staged = append(staged, w.writeCoreProperties, w.writeAppProperties)
+	}
+	if hashOf(p.presentationProperties) != src.presProps {
+		staged = append(staged, w.writePresProps, w.writeViewProps)
+	}
+	if len(staged) > 0 {
+		parts, err := stageParts(func(zw *zip.Writer) error {
+			for _, fn := range staged {
+				if err := fn(zw); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for name, data := range parts {
+			if _, ok := src.parts[name]; ok {
+				u.parts[name] = data
+			}
+		}
+	}
+
+	if u.typesSet {
+		var buf bytes.Buffer
+		buf.WriteString(xml.Header)
+		enc := xml.NewEncoder(&buf)
+		enc.Indent("", "  ")
+		if err := enc.Encode(u.types); err != nil {
+			return fmt.Errorf("failed to encode content types: %w", err)
+		}
+		u.parts["[Content_Types].xml"] = buf.Bytes()
+	}
+
+	zw := zip.NewWriter(writer)
+	for _, name := range append(append([]string(nil), src.names...), u.added...) {
+		data, ok := u.parts[name]
+		if !ok {
+			data = src.parts[name]
+		}
+		method, ok := src.methods[name]
+		if !ok {
+			method = zip.Deflate
+		}
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: method})
+		if err != nil {
+			return fmt.Errorf("failed to create %s in zip: %w", name, err)
+		}
+		if _, err := fw.Write(data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// stageParts runs fn against an in-memory package and returns the parts
+// it wrote, so that the regular writers can produce single parts.
+func stageParts(fn func(zw *zip.Writer) error) (map[string][]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	if err := fn(zw); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		return nil, err
+	}
+	parts := make(map[string][]byte, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		parts[f.Name] = data
+	}
+	return parts, nil
+}
+
+// updateSlide writes slide i of the presentation over its source part, or
+// with reshape false only its notes page.
+func (w *PPTXWriter) updateSlide(u *sourceUpdate, i int, ss sourceSlide, reshape bool) error {
+	slide := w.presentation.slides[i]
+	num := i + 1
+	staged, err := stageParts(func(zw *zip.Writer) error {
+		if reshape {
+			hlinkRelMap := w.buildHyperlinkRelMap(slide)
+			if err := w.writeSlide(zw, slide, num, hlinkRelMap); err != nil {
+				return err
+			}
+			if err := w.writeSlideRels(zw, slide, num, hlinkRelMap); err != nil {
+				return err
+			}
+			for _, shape := range slide.shapes {
+				if cs, ok := shape.(*ChartShape); ok {
+					if err := w.writeChartPart(zw, cs, w.getChartIndex(cs)); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		if slide.notes != ss.notes {
+			return w.writeNotesSlide(zw, slide, num)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if slide.notes != ss.notes {
+		if notesPart := u.src.relTarget(ss.part, relTypeNotesSlide); notesPart != "" {
+			u.set(notesPart, staged[fmt.Sprintf("ppt/notesSlides/notesSlide%d.xml", num)])
+		}
+	}
+	if !reshape {
+		return nil
+	}
+	u.set(ss.part, staged[fmt.Sprintf("ppt/slides/slide%d.xml", num)])
+
+	media := make(map[string]*DrawingShape)
+	for _, ds := range collectDrawingShapes(slide.shapes) {
+		media[fmt.Sprintf("ppt/media/image%d.%s", w.getImageIndex(slide, ds), w.getImageExtension(ds))] = ds
+	}
+
+	var stagedRels xmlRelationships
+	if err := xml.Unmarshal(staged[fmt.Sprintf("ppt/slides/_rels/slide%d.xml.rels", num)], &stagedRels); err != nil {
+		return fmt.Errorf("failed to parse relationships: %w", err)
+	}
+	rels := xmlRelationships{Xmlns: nsRelationships}
+	used := make(map[string]bool)
+	for _, rel := range stagedRels.Relationships {
+		if rel.TargetMode == "External" {
+			rels.Relationships = append(rels.Relationships, rel)
+			used[rel.ID] = true
+			continue
+		}
+		stagedTarget := resolveRelativePath("ppt/slides", rel.Target)
+		switch rel.Type {
+		case relTypeSlideLayout:
+			if layout := u.src.relTarget(ss.part, relTypeSlideLayout); layout != "" {
+				rel.Target = relativeTarget(ss.part, layout)
+			}
+		case relTypeImage:
+			ds := media[stagedTarget]
+			if ds == nil {
+				continue
+			}
+			data, err := w.mediaData(ds)
+			if err != nil {
+				return err
+			}
+			rel.Target = relativeTarget(ss.part, u.addMedia(data, w.getImageExtension(ds), w.getImageContentType(ds)))
+		case relTypeChart:
+			data, ok := staged[stagedTarget]
+			if !ok {
+				continue
+			}
+			name := u.freeName("ppt/charts/chart", ".xml")
+			u.set(name, data)
+			u.addOverride(name, ctChart)
+			rel.Target = relativeTarget(ss.part, name)
+		case relTypeNotesSlide, relTypeComment:
+			// Taken from the source relationships below.
+			continue
+		}
+		rels.Relationships = append(rels.Relationships, rel)
+		used[rel.ID] = true
+	}
+	// The source relationships not written again stay, renamed where their
+	// ID is taken, if the new slide still refers to them or, like the notes
+	// page and comments, they are attached to the slide without a
+	// reference. Pictures, charts and links of removed shapes go.
+	refs := relReferences(staged[fmt.Sprintf("ppt/slides/slide%d.xml", num)])
+	next := len(rels.Relationships) + 1
+	targets := make(map[[2]string]bool)
+	for _, rel := range rels.Relationships {
+		targets[[2]string{rel.Type, rel.Target}] = true
+	}
+	for _, rel := range u.src.rels(ss.part) {
+		if rel.Type == relTypeSlideLayout || targets[[2]string{rel.Type, rel.Target}] {
+			continue
+		}
+		if rel.Type != relTypeNotesSlide && rel.Type != relTypeComment && !refs[rel.ID] {
+			continue
+		}
+		if used[rel.ID] {
+			for used[fmt.Sprintf("rId%d", next)] {
+				next++
+			}
+			rel.ID = fmt.Sprintf("rId%d", next)
+		}
+		used[rel.ID] = true
+		rels.Relationships = append(rels.Relationships, rel)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(rels); err != nil {
+		return fmt.Errorf("failed to encode relationships: %w", err)
+	}
+	u.set(relsPartName(ss.part), buf.Bytes())
+	return nil
+}
+
+// relReferences returns the relationship IDs that the part data refers to
+// in attributes of the relationships namespace, such as r:id and r:embed.
+func relReferences(data []byte) map[string]bool {
+	refs := make(map[string]bool)
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return refs
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		for _, attr := range se.Attr {
+			if attr.Name.Space == nsOfficeDocRels {
+				refs[attr.Value] = true
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package gopresentation
+
+import "strings"
+
+// StructRole is the role of a StructElement, named after the standard PDF
+// structure types so that a tagged export can use it directly.
+type StructRole string
+
+const (
+	StructHeading1  StructRole = "H1"
+	StructHeading2  StructRole = "H2"
+	StructParagraph StructRole = "P"
+	StructFigure    StructRole = "Figure"
+	StructTable     StructRole = "Table"
+)
+
+// StructElement is one entry of a slide's logical structure.
+type StructElement struct {
+	Role StructRole
+	// Text is the text of a heading or paragraph.
+	Text string
+	// Alt is the alternative text of a figure; "" if the author gave none.
+	Alt string
+	// Cells holds the text of a table, row by row.
+	Cells [][]string
+	// Shape is the shape the element comes from.
+	Shape Shape
+}
+
+// Structure returns the slide's content in reading order as accessibility
+// structure: the title and subtitle as headings, then the other shapes in
+// z-order, with text split into paragraphs, pictures, charts and other
+// objects as figures carrying their alt text, and tables with their cells.
+// Footers, dates, slide numbers, lines and shapes without text or alt text
+// are decoration and are left out.
+func (s *Slide) Structure() []StructElement {
+	var headings, body []StructElement
+	var walk func(shapes []Shape)
+	walk = func(shapes []Shape) {
+		for _, shape := range shapes {
+			switch sh := shape.(type) {
+			case *PlaceholderShape:
+				text := strings.Join(extractParagraphsText(sh.paragraphs), " ")
+				switch sh.phType {
+				case PlaceholderTitle, PlaceholderCtrTitle:
+					if text != "" {
+						headings = append(headings, StructElement{Role: StructHeading1, Text: text, Shape: sh})
+					}
+				case PlaceholderSubTitle:
+					if text != "" {
+						headings = append(headings, StructElement{Role: StructHeading2, Text: text, Shape: sh})
+					}
+				case PlaceholderDate, PlaceholderFooter, PlaceholderSlideNum:
+				default:
+					body = appendParagraphs(body, sh.paragraphs, sh)
+				}
+			case *RichTextShape:
+				body = appendParagraphs(body, sh.paragraphs, sh)
+			case *AutoShape:
+				if len(sh.paragraphs) > 0 {
+					body = appendParagraphs(body, sh.paragraphs, sh)
+				} else if sh.text != "" {
+					body = append(body, StructElement{Role: StructParagraph, Text: sh.text, Shape: sh})
+				} else if sh.description != "" {
+					body = append(body, StructElement{Role: StructFigure, Alt: sh.description, Shape: sh})
+				}
+			case *DrawingShape, *UnsupportedShape, *InkShape:
+				body = append(body, StructElement{Role: StructFigure, Alt: shape.base().description, Shape: sh})
+			case *ChartShape:
+				alt := sh.description
+				if alt == "" {
+					alt = sh.Title()
+				}
+				body = append(body, StructElement{Role: StructFigure, Alt: alt, Shape: sh})
+			case *TableShape:
+				cells := make([][]string, len(sh.rows))
+				for i, row := range sh.rows {
+					cells[i] = make([]string, len(row))
+					for j, cell := range row {
+						cells[i][j] = strings.Join(extractParagraphsText(cell.paragraphs), "\n")
+					}
+				}
+				body = append(body, StructElement{Role: StructTable, Cells: cells, Shape: sh})
+			case *GroupShape:
+				if sh.description != "" {
+					// A described group is read as one figure.
+					body = append(body, StructElement{Role: StructFigure, Alt: sh.description, Shape: sh})
+				} else {
+					walk(sh.shapes)
+				}
+			}
+		}
+	}
+	walk(s.shapes)
+	return append(headings, body...)
+}
+
+// appendParagraphs appends a paragraph element for each non-empty paragraph.
+func appendParagraphs(elems []StructElement, paragraphs []*Paragraph, shape Shape) []StructElement {
+	for _, text := range extractParagraphsText(paragraphs) {
+		elems = append(elems, StructElement{Role: StructParagraph, Text: text, Shape: shape})
+	}
+	return elems
+}
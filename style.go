@@ -1,12 +1,16 @@
 package gopresentation
 
 import (
+	"slices"
+	"sort"
 	"strings"
 )
 
 // Color represents an ARGB color.
 type Color struct {
 	ARGB string // 8-character hex string, e.g., "FF000000" for black
+
+	scheme *schemeColorRef // theme color it was read from, if any
 }
 
 // Predefined colors.
@@ -98,14 +102,40 @@ func hexVal(c byte) int {
 type Font struct {
 	Name          string
 	NameEA        string // East Asian font name (from <a:ea> element)
+	NameCS        string // complex script font name (from <a:cs> element)
+	NameSym       string // symbol font name (from <a:sym> element)
 	Size          int    // in points
 	Bold          bool
 	Italic        bool
 	Underline     UnderlineType
-	Strikethrough bool
+	Strikethrough bool       // the text is struck through; see Strike for the style
+	Strike        StrikeType // strike style; "" with Strikethrough set means single
 	Color         Color
 	Superscript   bool
 	Subscript     bool
+	Kumimoji      bool // digits stay upright in vertical East Asian text
+
+	UnderlineColor *Color // underline color from uFill or uLn; nil uses Color
+	UnderlineWidth int64  // underline thickness in EMU from uLn; 0 uses the font's metrics
+
+	// Kern is the minimum size, in hundredths of a point, at which pair
+	// kerning applies (rPr kern). nil kerns at every size; 0 disables
+	// kerning, as PowerPoint writes when "Kerning for fonts" is off.
+	Kern *int
+	// Spacing is extra space after each character in hundredths of a
+	// point (rPr spc); negative values condense the text.
+	Spacing int
+	// Gradient, if set, fills the glyphs with a linear gradient (rPr
+	// gradFill). Color holds its first stop for plain-color fallbacks.
+	Gradient *Fill
+	// NoFill leaves the glyphs unfilled (rPr noFill), so that only their
+	// outline, if any, is drawn.
+	NoFill bool
+	// OutlineColor is the color of the glyph outline (rPr ln); nil draws
+	// none.
+	OutlineColor *Color
+	// OutlineWidth is the outline thickness in EMU; 0 uses 0.75 pt.
+	OutlineWidth int64
 }
 
 // UnderlineType represents the underline style.
@@ -120,6 +150,26 @@ const (
 	UnderlineWavy   UnderlineType = "wavy"
 )
 
+// StrikeType represents the strikethrough style (rPr strike).
+type StrikeType string
+
+const (
+	StrikeNone   StrikeType = "noStrike"
+	StrikeSingle StrikeType = "sngStrike"
+	StrikeDouble StrikeType = "dblStrike"
+)
+
+// strikeType returns the effective strikethrough style.
+func (f *Font) strikeType() StrikeType {
+	if !f.Strikethrough {
+		return StrikeNone
+	}
+	if f.Strike == StrikeDouble {
+		return StrikeDouble
+	}
+	return StrikeSingle
+}
+
 // NewFont creates a new Font with defaults.
 func NewFont() *Font {
 	return &Font{
@@ -174,9 +224,55 @@ func (f *Font) SetUnderline(u UnderlineType) *Font {
 	return f
 }
 
+// SetUnderlineColor sets an underline color that differs from the text color.
+func (f *Font) SetUnderlineColor(c Color) *Font {
+	f.UnderlineColor = &c
+	return f
+}
+
+// SetOutline draws the glyph outlines in c, width EMU thick. With filled
+// false the glyphs are hollow.
+func (f *Font) SetOutline(c Color, width int64, filled bool) *Font {
+	f.OutlineColor = &c
+	f.OutlineWidth = width
+	f.NoFill = !filled
+	return f
+}
+
+// SetKerning sets the minimum size, in hundredths of a point, at which pair
+// kerning applies; 0 disables kerning.
+func (f *Font) SetKerning(minSize int) *Font {
+	f.Kern = &minSize
+	return f
+}
+
+// SetSpacing sets the character spacing in hundredths of a point.
+func (f *Font) SetSpacing(spc int) *Font {
+	f.Spacing = spc
+	return f
+}
+
+// SetGradient fills the text with a linear gradient from start to end at
+// the given angle in degrees.
+func (f *Font) SetGradient(start, end Color, angle int) *Font {
+	f.Gradient = NewFill().SetGradientLinear(start, end, angle)
+	f.Color = start
+	return f
+}
+
 // SetStrikethrough sets the strikethrough property.
 func (f *Font) SetStrikethrough(s bool) *Font {
 	f.Strikethrough = s
+	if !s {
+		f.Strike = ""
+	}
+	return f
+}
+
+// SetStrike sets the strikethrough style, e.g. StrikeDouble.
+func (f *Font) SetStrike(t StrikeType) *Font {
+	f.Strike = t
+	f.Strikethrough = t == StrikeSingle || t == StrikeDouble
 	return f
 }
 
@@ -190,8 +286,12 @@ type Alignment struct {
 	MarginBottom int64
 	Indent     int64
 	Level      int
+	DefTabSize int64 // default tab stop interval in EMU (defTabSz); 0 means one inch
 }
 
+// defaultTabSize is the default tab stop interval when defTabSz is not set.
+const defaultTabSize = 914400
+
 // HorizontalAlignment represents horizontal text alignment.
 type HorizontalAlignment string
 
@@ -236,8 +336,26 @@ func (a *Alignment) SetVertical(v VerticalAlignment) *Alignment {
 type Fill struct {
 	Type      FillType
 	Color     Color
-	EndColor  Color // for gradient fills
+	EndColor  Color // for gradient fills; the background of pattern fills
 	Rotation  int   // gradient rotation in degrees
+	Pattern   string // preset pattern of FillPattern, e.g. "pct50" or "dnDiag"
+	Picture   []byte // encoded image of FillPicture, stretched over the area
+	Tile      *PictureTile // repeats Picture instead of stretching it; nil stretches
+}
+
+// PictureTile repeats a picture fill at a fixed size (a:tile). Offsets are
+// in EMU and scales in 1/1000 of a percent of the picture's own size at 96
+// dpi.
+type PictureTile struct {
+	OffsetX int64
+	OffsetY int64
+	ScaleX  int
+	ScaleY  int
+	// Flip mirrors every other tile: "x", "y", "xy" or "" for none.
+	Flip string
+	// Align is the corner or edge of the area the tiling starts from, e.g.
+	// "tl" or "ctr"; "" is the top left.
+	Align string
 }
 
 // FillType represents the type of fill.
@@ -248,6 +366,13 @@ const (
 	FillSolid
 	FillGradientLinear
 	FillGradientPath
+	// FillBackground paints the shape with the slide background (p:sp useBgFill),
+	// hiding any shapes beneath it.
+	FillBackground
+	// FillPattern repeats a preset two-color pattern (a:pattFill).
+	FillPattern
+	// FillPicture stretches or tiles an image over the area (a:blipFill).
+	FillPicture
 )
 
 // NewFill creates a new Fill with no fill.
@@ -261,6 +386,33 @@ func (f *Fill) SetNoFill() *Fill {
 	return f
 }
 
+// SetPattern sets a preset pattern fill with foreground and background
+// colors.
+func (f *Fill) SetPattern(prst string, fg, bg Color) *Fill {
+	f.Type = FillPattern
+	f.Pattern = prst
+	f.Color = fg
+	f.EndColor = bg
+	return f
+}
+
+// SetPicture sets a picture fill from encoded image data, stretched over
+// the area. Picture fills are rendered but not written when saving.
+func (f *Fill) SetPicture(data []byte) *Fill {
+	f.Type = FillPicture
+	f.Picture = data
+	f.Tile = nil
+	return f
+}
+
+// SetPictureTile sets a picture fill that repeats the image at its own
+// size, as a:tile with 100% scales does.
+func (f *Fill) SetPictureTile(data []byte) *Fill {
+	f.SetPicture(data)
+	f.Tile = &PictureTile{ScaleX: 100000, ScaleY: 100000}
+	return f
+}
+
 // SetSolid sets a solid fill.
 func (f *Fill) SetSolid(color Color) *Fill {
 	f.Type = FillSolid
@@ -282,7 +434,27 @@ type Border struct {
 	Style BorderStyle
 	Width int // in points (1 pt = 12700 EMU)
 	Color Color
-}
+	// DashPattern holds a custom dash (<a:custDash>) as alternating dash and
+	// gap lengths in multiples of the line width. It overrides the dash
+	// implied by Style when non-empty.
+	DashPattern []float64
+	// Join is how the line turns corners (<a:round>, <a:bevel> or
+	// <a:miter>); "" leaves it to the renderer.
+	Join LineJoin
+	// MiterLimit caps the length of mitered corners, in 1/1000 percent of
+	// the line width (<a:miter lim>); 0 means 800000. Longer corners are
+	// beveled.
+	MiterLimit int
+}
+
+// LineJoin is the shape of a line's corners.
+type LineJoin string
+
+const (
+	LineJoinRound LineJoin = "round"
+	LineJoinBevel LineJoin = "bevel"
+	LineJoinMiter LineJoin = "miter"
+)
 
 // BorderStyle represents the border line style.
 type BorderStyle string
@@ -292,6 +464,16 @@ const (
 	BorderSolid BorderStyle = "solid"
 	BorderDash  BorderStyle = "dash"
 	BorderDot   BorderStyle = "dot"
+	// The other <a:prstDash> presets, named as in the file. Their dashes
+	// and gaps are sized in multiples of the line width.
+	BorderLgDash        BorderStyle = "lgDash"
+	BorderDashDot       BorderStyle = "dashDot"
+	BorderLgDashDot     BorderStyle = "lgDashDot"
+	BorderLgDashDotDot  BorderStyle = "lgDashDotDot"
+	BorderSysDash       BorderStyle = "sysDash"
+	BorderSysDot        BorderStyle = "sysDot"
+	BorderSysDashDot    BorderStyle = "sysDashDot"
+	BorderSysDashDotDot BorderStyle = "sysDashDotDot"
 )
 
 // ArrowType represents the type of arrow head/tail on a line.
@@ -334,6 +516,14 @@ func (b *Border) SetSolidFill(c Color) *Border {
 	return b
 }
 
+// SetDashPattern sets a custom dash pattern of alternating dash and gap
+// lengths, in multiples of the line width.
+func (b *Border) SetDashPattern(pattern ...float64) *Border {
+	b.Style = BorderDash
+	b.DashPattern = pattern
+	return b
+}
+
 // SetWidth sets the border width in EMU.
 func (b *Border) SetWidth(w int) *Border {
 	b.Width = w
@@ -388,12 +578,37 @@ func (s *Shadow) SetDistance(d int) *Shadow {
 	return s
 }
 
+// Reflection is a mirrored copy of a picture drawn below it that fades out
+// (<a:reflection>). Positions and alphas are in 1/1000 of a percent.
+type Reflection struct {
+	BlurRadius    int64 // in EMU
+	Distance      int64 // gap between the picture and the reflection, in EMU
+	StartAlpha    int   // opacity at StartPosition
+	StartPosition int   // where the fade starts, as part of the picture's height
+	EndAlpha      int   // opacity at EndPosition and beyond
+	EndPosition   int   // where the fade and the reflection end; 35000 shows 35% of the picture
+	ScaleY        int   // vertical scale; negative mirrors the picture
+}
+
+// NewReflection creates a reflection like PowerPoint's "Tight reflection":
+// half transparent, fading out over the first 35% of the picture.
+func NewReflection() *Reflection {
+	return &Reflection{
+		BlurRadius:  6350,
+		StartAlpha:  52000,
+		EndAlpha:    300,
+		EndPosition: 35000,
+		ScaleY:      -100000,
+	}
+}
+
 // Hyperlink represents a hyperlink.
 type Hyperlink struct {
 	URL     string
 	Tooltip string
 	IsInternal bool
 	SlideNumber int
+	slidePart   string // target slide of a link read from a file
 }
 
 // allowedHyperlinkSchemes defines the URL schemes permitted in hyperlinks.
@@ -536,6 +751,69 @@ func clamp8(v float64) uint8 {
 	return uint8(v + 0.5)
 }
 
+// schemeColorRef records the theme color a Color was read from
+// (<a:schemeClr>) and the modifiers applied to it since, so that the
+// renderer can derive the same color from an overridden theme color.
+type schemeColorRef struct {
+	name string
+	mods []colorMod
+}
+
+// colorMod is one color modifier: op is "lumMod", "lumOff", "tint" or
+// "shade", and amount is the argument of the matching apply function.
+type colorMod struct {
+	op     string
+	amount float64
+}
+
+// schemeColor returns the color argb of the theme color name.
+func schemeColor(name, argb string) Color {
+	c := NewColor(argb)
+	c.scheme = &schemeColorRef{name: name}
+	return c
+}
+
+// modifyColor applies the modifier op to c and, for a theme color, records
+// it. The reference is copied, as copies of c made before share it.
+func modifyColor(c *Color, op string, amount float64) {
+	m := colorMod{op: op, amount: amount}
+	m.apply(c)
+	if c.scheme != nil {
+		c.scheme = &schemeColorRef{name: c.scheme.name, mods: append(slices.Clip(c.scheme.mods), m)}
+	}
+}
+
+func (m colorMod) apply(c *Color) {
+	switch m.op {
+	case "lumMod":
+		applyLumMod(c, m.amount)
+	case "lumOff":
+		applyLumOff(c, m.amount)
+	case "tint":
+		applyTint(c, m.amount)
+	case "shade":
+		applyShade(c, m.amount)
+	}
+}
+
+// withScheme returns c as if read from the theme colors in scheme: a color
+// read from one of them is derived again from its replacement, keeping its
+// own alpha. Other colors are returned unchanged.
+func (c Color) withScheme(scheme map[string]Color) Color {
+	if c.scheme == nil {
+		return c
+	}
+	base, ok := scheme[c.scheme.name]
+	if !ok {
+		return c
+	}
+	for _, m := range c.scheme.mods {
+		m.apply(&base)
+	}
+	base.ARGB = c.ARGB[:2] + base.ARGB[2:]
+	return base
+}
+
 // applyLumMod multiplies the luminance by factor (e.g. 0.75 = 75%).
 func applyLumMod(c *Color, factor float64) {
 	r, g, b := c.GetRed(), c.GetGreen(), c.GetBlue()
@@ -584,6 +862,37 @@ func applyShade(c *Color, amount float64) {
 	c.ARGB = c.ARGB[:2] + colorHex(nr) + colorHex(ng) + colorHex(nb)
 }
 
+// dominantStopColor returns the gradient stop color that covers the largest
+// share of the gradient, each stop owning the range up to the midpoints with
+// its neighbours. positions are in thousandths of a percent (0-100000).
+func dominantStopColor(colors []Color, positions []int) Color {
+	idx := make([]int, len(colors))
+	for i := range idx {
+		idx[i] = i
+	}
+	pos := func(i int) int {
+		if i < len(positions) {
+			return positions[i]
+		}
+		return 0
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return pos(idx[a]) < pos(idx[b]) })
+	best, bestSpan := idx[0], -1
+	for k, i := range idx {
+		lo, hi := 0, 100000
+		if k > 0 {
+			lo = (pos(idx[k-1]) + pos(i)) / 2
+		}
+		if k < len(idx)-1 {
+			hi = (pos(i) + pos(idx[k+1])) / 2
+		}
+		if hi-lo > bestSpan {
+			best, bestSpan = i, hi-lo
+		}
+	}
+	return colors[best]
+}
+
 func colorHex(v uint8) string {
 	const hex = "0123456789ABCDEF"
 	return string([]byte{hex[v>>4], hex[v&0x0f]})
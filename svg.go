@@ -0,0 +1,722 @@
+package gopresentation
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"strings"
+
+	"golang.org/x/image/font"
+)
+
+// svgTextRun is a run of text laid out by the renderer, written to SVG as a
+// <text> element.
+type svgTextRun struct {
+	text      string
+	font      *Font
+	size      float64 // font size in pixels
+	x, y      int     // start of the run on its baseline
+	width     int
+	color     color.RGBA
+	outline   color.RGBA
+	stroke    float64          // outline width in pixels; 0 for none
+	transform string           // SVG transform from the run's buffer to the slide
+	matrix    DisplayTransform // the same transform as a matrix, for display lists
+}
+
+// svgTextSink collects the text runs drawParagraphs lays out instead of
+// drawing them, for SVG output and display lists. transform and matrix map
+// the coordinates of the buffer being drawn into to the slide; a sink
+// without runs discards the text.
+type svgTextSink struct {
+	transform string
+	matrix    DisplayTransform
+	runs      *[]svgTextRun
+}
+
+// transformed returns a sink for a buffer composited onto this one by
+// rotating and flipping it about (scx, scy) and placing that point at
+// (dcx, dcy), as renderRotated and rotateAndComposite do.
+func (s *svgTextSink) transformed(dcx, dcy, scx, scy float64, rotation int, flipH, flipV bool) *svgTextSink {
+	if s == nil {
+		return nil
+	}
+	t := svgTransformList(dcx, dcy, scx, scy, rotation, flipH, flipV)
+	if s.transform != "" {
+		t = s.transform + " " + t
+	}
+	m := s.matrix.Multiply(rotateFlipTransform(dcx, dcy, scx, scy, rotation, flipH, flipV))
+	return &svgTextSink{transform: t, matrix: m, runs: s.runs}
+}
+
+// add records a run drawn by r with its baseline starting at (x, baseline).
+func (s *svgTextSink) add(r *renderer, run *textRun, x, baseline int, c color.RGBA) {
+	if s.runs == nil {
+		return
+	}
+	f := run.font
+	if f == nil {
+		f = NewFont()
+	}
+	sizePt := float64(f.Size)
+	if sizePt <= 0 {
+		sizePt = 10
+	}
+	if r.fontScale > 0 && r.fontScale != 1.0 {
+		sizePt *= r.fontScale
+	}
+	// Trailing spaces, and the space a bullet hangs in, take up width that
+	// textLength would otherwise spread over the visible text.
+	text, width := strings.TrimRight(run.text, " \t"), run.width
+	if text != run.text && run.face != nil {
+		trailing := font.MeasureString(run.mface(), run.text[len(text):]).Round()
+		width = minInt(width-trailing, font.MeasureString(run.mface(), text).Round())
+	}
+	tr := svgTextRun{
+		text:      text,
+		font:      f,
+		size:      sizePt * 12700.0 * r.scaleX,
+		x:         x,
+		y:         baseline,
+		width:     width,
+		color:     c,
+		transform: s.transform,
+		matrix:    s.matrix,
+	}
+	if f.OutlineColor != nil && !r.textOnly {
+		tr.outline = r.rgba(*f.OutlineColor)
+		tr.stroke = float64(f.OutlineWidth) * r.scaleX
+		if f.OutlineWidth <= 0 {
+			tr.stroke = 9525 * r.scaleX
+		}
+	}
+	*s.runs = append(*s.runs, tr)
+}
+
+// svgTransformList returns the SVG transform that rotates and flips a box
+// about (scx, scy) and moves that point to (dcx, dcy).
+func svgTransformList(dcx, dcy, scx, scy float64, rotation int, flipH, flipV bool) string {
+	t := fmt.Sprintf("translate(%s %s)", pdfNum(dcx), pdfNum(dcy))
+	if rotation != 0 {
+		t += fmt.Sprintf(" rotate(%d)", rotation)
+	}
+	if flipH || flipV {
+		fx, fy := 1, 1
+		if flipH {
+			fx = -1
+		}
+		if flipV {
+			fy = -1
+		}
+		t += fmt.Sprintf(" scale(%d %d)", fx, fy)
+	}
+	return t + fmt.Sprintf(" translate(%s %s)", pdfNum(-scx), pdfNum(-scy))
+}
+
+// SlideToSVG renders a slide as an SVG document of opts.Width pixels. Shapes
+// with plain geometry, pictures and straight lines are written as vector
+// elements, text as <text> elements naming the run's fonts, and pictures
+// embedded as base64 data. Shapes SVG cannot express, such as charts,
+// shadows and recolored pictures, are embedded as images rendered at twice
+// the output resolution. Of the options, the width, fonts, background
+// color, text-only mode and gradient interpolation apply.
+func (p *Presentation) SlideToSVG(slideIndex int, opts *RenderOptions) ([]byte, error) {
+	if slideIndex < 0 || slideIndex >= len(p.slides) {
+		return nil, fmt.Errorf("slide index %d out of range (0-%d)", slideIndex, len(p.slides)-1)
+	}
+	if opts == nil {
+		opts = DefaultRenderOptions()
+	}
+	width := opts.Width
+	if width <= 0 {
+		width = 960
+	}
+	slide := p.slides[slideIndex]
+	slideW := float64(p.layout.CX)
+	slideH := float64(p.layout.CY)
+	height := int(float64(width) * slideH / slideW)
+
+	// Text is laid out and fallback shapes are drawn by the raster
+	// renderer, on a scratch image at the output size.
+	scratch := image.NewRGBA(image.Rect(0, 0, width, height))
+	w := &svgWriter{r: p.newRenderer(slideIndex, opts, scratch, float64(width)/slideW, float64(height)/slideH)}
+
+	fmt.Fprintf(&w.buf, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n"+
+		"<svg xmlns=\"http://www.w3.org/2000/svg\" xmlns:xlink=\"http://www.w3.org/1999/xlink\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n",
+		width, height, width, height)
+	w.r.fillSlideBackground(slide, opts, scratch.Bounds())
+	if bg := w.r.background; bg.Type == FillSolid {
+		fmt.Fprintf(&w.buf, "<rect width=\"%d\" height=\"%d\"%s/>\n", width, height, svgPaint("fill", w.r.rgba(bg.Color)))
+	} else if err := w.image(scratch, 0, 0, float64(width), float64(height)); err != nil {
+		return nil, err
+	}
+	for _, shape := range slide.shapes {
+		if err := w.shape(shape); err != nil {
+			return nil, err
+		}
+	}
+	w.buf.WriteString("</svg>\n")
+	return w.buf.Bytes(), nil
+}
+
+// SaveSlideAsSVG renders a slide with SlideToSVG and writes it to path.
+func (p *Presentation) SaveSlideAsSVG(slideIndex int, path string, opts *RenderOptions) error {
+	data, err := p.SlideToSVG(slideIndex, opts)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// svgWriter writes the shapes of a slide as SVG elements.
+type svgWriter struct {
+	buf bytes.Buffer
+	r   *renderer // renderer at the output scale, drawing into a scratch image
+	ids int       // gradients defined so far
+}
+
+// svgRasterScale is how many times the output resolution shapes without a
+// vector form are rendered at.
+const svgRasterScale = 2
+
+// shape writes one shape, and the children of a group.
+func (w *svgWriter) shape(shape Shape) error {
+	if shape == nil {
+		return nil
+	}
+	bs := shape.base()
+	if bs.fade >= 1 {
+		return nil
+	}
+	var closing []string
+	if hl := bs.hyperlink; hl != nil && !hl.IsInternal && isValidHyperlinkURL(hl.URL) {
+		fmt.Fprintf(&w.buf, "<a xlink:href=\"%s\">\n", svgEscape(hl.URL))
+		closing = append(closing, "</a>\n")
+	}
+	if bs.fade > 0 {
+		fmt.Fprintf(&w.buf, "<g opacity=\"%s\">\n", pdfNum(1-bs.fade))
+		closing = append(closing, "</g>\n")
+	}
+
+	var err error
+	if g, ok := shape.(*GroupShape); ok {
+		err = w.group(g)
+	} else {
+		if !w.r.textOnly && !w.vector(shape) {
+			err = w.raster(shape)
+		}
+		if err == nil {
+			w.text(shape)
+		}
+	}
+	for i := len(closing) - 1; i >= 0; i-- {
+		w.buf.WriteString(closing[i])
+	}
+	return err
+}
+
+// group writes the children of g, rotated and flipped with the group.
+func (w *svgWriter) group(g *GroupShape) error {
+	rotation := g.GetRotation()
+	flipH, flipV := g.GetFlipHorizontal(), g.GetFlipVertical()
+	transformed := rotation != 0 || flipH || flipV
+	if transformed {
+		fmt.Fprintf(&w.buf, "<g%s>\n", w.transform(&g.BaseShape))
+	}
+	for _, child := range g.slideSpaceChildren() {
+		if err := w.shape(child); err != nil {
+			return err
+		}
+	}
+	if transformed {
+		w.buf.WriteString("</g>\n")
+	}
+	return nil
+}
+
+// shapeBox returns the position and size of a shape in output pixels, for
+// SVG and display list output.
+func (r *renderer) shapeBox(bs *BaseShape) (x, y, wd, ht float64) {
+	return float64(bs.offsetX) * r.scaleX, float64(bs.offsetY) * r.scaleY,
+		float64(bs.width) * r.scaleX, float64(bs.height) * r.scaleY
+}
+
+// gradientLine returns the ends of a linear gradient at angle rotation in
+// the given box: through its centre, spanning the box's projection on that
+// line, as fillGradientLinear draws it.
+func gradientLine(x, y, wd, ht float64, rotation int) (x1, y1, x2, y2 float64) {
+	rad := float64(rotation) * math.Pi / 180
+	cosA, sinA := math.Cos(rad), math.Sin(rad)
+	cx, cy := x+wd/2, y+ht/2
+	proj := math.Max(math.Abs(wd/2*cosA)+math.Abs(ht/2*sinA), 1)
+	return cx - proj*cosA, cy - proj*sinA, cx + proj*cosA, cy + proj*sinA
+}
+
+// lineEnds returns the ends of a straight line in output pixels, before
+// its rotation.
+func (r *renderer) lineEnds(s *LineShape) (x1, y1, x2, y2 float64) {
+	x, y, wd, ht := r.shapeBox(&s.BaseShape)
+	x1, y1, x2, y2 = x, y, x+wd, y+ht
+	if s.flipHorizontal {
+		x1, x2 = x2, x1
+	}
+	if s.flipVertical {
+		y1, y2 = y2, y1
+	}
+	return x1, y1, x2, y2
+}
+
+// plainPictureData returns the bytes of a picture that vector output can
+// show as an image with only its crops and opacity, or nil for pictures
+// with effects, frames, outward crops or other geometry, which are
+// rasterized.
+func (r *renderer) plainPictureData(s *DrawingShape) []byte {
+	if s.clrChange != nil || len(s.duotone) > 0 || s.reflection != nil {
+		return nil
+	}
+	if s.cropLeft < 0 || s.cropTop < 0 || s.cropRight < 0 || s.cropBottom < 0 {
+		return nil
+	}
+	frame := r.pictureFrame(s, 1, 1)
+	if (frame.border != nil && frame.border.Style != BorderNone) || (frame.shadow != nil && frame.shadow.Visible) ||
+		frame.softEdge > 0 || frame.gray || (s.geometry != "" && s.geometry != "rect") {
+		return nil
+	}
+	data := s.data
+	if len(data) == 0 && s.path != "" {
+		data, _ = os.ReadFile(s.path)
+	}
+	return data
+}
+
+// pictureCrop returns the part of an iw by ih picture that s shows, its
+// crops being fractions of the picture, and whether that is less than the
+// whole. A crop that leaves nothing is ignored, as the renderer does.
+func pictureCrop(s *DrawingShape, iw, ih float64) (x, y, wd, ht float64, cropped bool) {
+	x, y = iw*float64(s.cropLeft)/100000, ih*float64(s.cropTop)/100000
+	wd, ht = iw-x-iw*float64(s.cropRight)/100000, ih-y-ih*float64(s.cropBottom)/100000
+	if (x == 0 && y == 0 && wd == iw && ht == ih) || wd <= 0 || ht <= 0 {
+		return 0, 0, iw, ih, false
+	}
+	return x, y, wd, ht, true
+}
+
+// transform returns the transform attribute that rotates and flips a shape
+// about its centre, or "" if it is drawn as it is.
+func (w *svgWriter) transform(bs *BaseShape) string {
+	if bs.rotation == 0 && !bs.flipHorizontal && !bs.flipVertical {
+		return ""
+	}
+	x, y, wd, ht := w.r.shapeBox(bs)
+	cx, cy := x+wd/2, y+ht/2
+	return fmt.Sprintf(" transform=\"%s\"", svgTransformList(cx, cy, cx, cy, bs.rotation, bs.flipHorizontal, bs.flipVertical))
+}
+
+// vector writes the geometry of shape as SVG elements and reports whether
+// it could. Text is written separately.
+func (w *svgWriter) vector(shape Shape) bool {
+	switch s := shape.(type) {
+	case *AutoShape:
+		if (s.shadow != nil && s.shadow.Visible) || (s.text != "" && len(s.paragraphs) == 0) {
+			return false
+		}
+		if s.shapeType == AutoShapeRtTriangle && (s.rotation == 90 || s.rotation == 270) {
+			return false
+		}
+		return w.geometry(&s.BaseShape, s.shapeType, s.adjustValues)
+	case *RichTextShape:
+		return w.textShapeGeometry(s)
+	case *PlaceholderShape:
+		return w.textShapeGeometry(&s.RichTextShape)
+	case *DrawingShape:
+		return w.picture(s)
+	case *LineShape:
+		return w.line(s)
+	}
+	return false
+}
+
+// textShapeGeometry writes the box of a text shape: a preset geometry, a
+// freeform path or a rectangle.
+func (w *svgWriter) textShapeGeometry(s *RichTextShape) bool {
+	if (s.shadow != nil && s.shadow.Visible) || svgHasArrow(s.headEnd) || svgHasArrow(s.tailEnd) {
+		return false
+	}
+	if g := s.geometryShape(); g != nil {
+		return w.geometry(&s.BaseShape, g.shapeType, g.adjustValues)
+	}
+	if s.customPath == nil {
+		return w.geometry(&s.BaseShape, AutoShapeRectangle, nil)
+	}
+	x, y, wd, ht := w.r.shapeBox(&s.BaseShape)
+	path := customDisplayPath(s.customPath, x, y, wd, ht)
+	if len(path) == 0 {
+		return true
+	}
+	return w.element(&s.BaseShape, fmt.Sprintf("<path d=\"%s\" fill-rule=\"evenodd\"", svgDisplayPathData(path)))
+}
+
+// geometry writes a shape with preset geometry kind, if SVG output
+// supports it.
+func (w *svgWriter) geometry(bs *BaseShape, kind AutoShapeType, adj map[string]int) bool {
+	x, y, wd, ht := w.r.shapeBox(bs)
+	elem, ok := svgPresetElement(kind, adj, x, y, wd, ht)
+	if !ok {
+		return false
+	}
+	return w.element(bs, elem)
+}
+
+// element writes elem, an unterminated SVG element outlining bs, with the
+// shape's fill, outline and transform. It reports false for fills that
+// have no SVG form.
+func (w *svgWriter) element(bs *BaseShape, elem string) bool {
+	fill := w.r.resolveFill(bs.fill)
+	paint := " fill=\"none\""
+	if fill != nil && fill.Type != FillNone {
+		var ok bool
+		if paint, ok = w.fill(fill, bs); !ok {
+			return false
+		}
+	}
+	stroke := svgStroke(bs.border, w.r)
+	if paint == " fill=\"none\"" && stroke == "" {
+		return true
+	}
+	fmt.Fprintf(&w.buf, "%s%s%s%s/>\n", elem, paint, stroke, w.transform(bs))
+	return true
+}
+
+// fill returns the fill attributes for a solid or linear gradient fill of
+// bs, defining the gradient first. Other fills are rasterized.
+func (w *svgWriter) fill(fill *Fill, bs *BaseShape) (string, bool) {
+	switch fill.Type {
+	case FillSolid:
+		return svgPaint("fill", w.r.scaleAlpha(w.r.rgba(fill.Color))), true
+	case FillGradientLinear:
+		interp := ""
+		switch w.r.gradientSpace {
+		case GradientSpaceSRGB:
+		case GradientSpaceLinear:
+			interp = " color-interpolation=\"linearRGB\""
+		default:
+			return "", false
+		}
+		x, y, wd, ht := w.r.shapeBox(bs)
+		x1, y1, x2, y2 := gradientLine(x, y, wd, ht, fill.Rotation)
+		w.ids++
+		id := fmt.Sprintf("grad%d", w.ids)
+		from, to := w.r.rgba(fill.Color), w.r.rgba(fill.EndColor)
+		fmt.Fprintf(&w.buf, "<defs><linearGradient id=\"%s\" gradientUnits=\"userSpaceOnUse\" x1=\"%s\" y1=\"%s\" x2=\"%s\" y2=\"%s\"%s>"+
+			"<stop offset=\"0\"%s/><stop offset=\"1\"%s/></linearGradient></defs>\n",
+			id, pdfNum(x1), pdfNum(y1), pdfNum(x2), pdfNum(y2), interp,
+			svgPaint("stop-color", from), svgPaint("stop-color", to))
+		return fmt.Sprintf(" fill=\"url(#%s)\"", id), true
+	}
+	return "", false
+}
+
+// picture writes a picture as an embedded image. Pictures with effects,
+// frames or formats a browser may not show are rasterized.
+func (w *svgWriter) picture(s *DrawingShape) bool {
+	data := w.r.plainPictureData(s)
+	if len(data) == 0 {
+		return false
+	}
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width <= 0 || cfg.Height <= 0 {
+		return false
+	}
+	switch format {
+	case "png", "jpeg", "gif":
+	default:
+		return false
+	}
+	href := "data:image/" + format + ";base64," + base64.StdEncoding.EncodeToString(data)
+
+	x, y, wd, ht := w.r.shapeBox(&s.BaseShape)
+	attrs := w.transform(&s.BaseShape)
+	if op := s.opacity(); op < 1 {
+		attrs += fmt.Sprintf(" opacity=\"%s\"", pdfNum(op))
+	}
+	pixelated := s.pixelated
+	if !pixelated && w.r.sharpBitonal {
+		if img, _, err := image.Decode(bytes.NewReader(data)); err == nil {
+			pixelated = w.r.pixelatedPicture(s, img)
+		}
+	}
+	if pixelated {
+		attrs += " style=\"image-rendering:pixelated\""
+	}
+	vx, vy, vw, vh, cropped := pictureCrop(s, float64(cfg.Width), float64(cfg.Height))
+	if !cropped {
+		fmt.Fprintf(&w.buf, "<image x=\"%s\" y=\"%s\" width=\"%s\" height=\"%s\" preserveAspectRatio=\"none\"%s xlink:href=\"%s\"/>\n",
+			pdfNum(x), pdfNum(y), pdfNum(wd), pdfNum(ht), attrs, href)
+		return true
+	}
+	fmt.Fprintf(&w.buf, "<g%s><svg x=\"%s\" y=\"%s\" width=\"%s\" height=\"%s\" viewBox=\"%s %s %s %s\" preserveAspectRatio=\"none\">"+
+		"<image width=\"%d\" height=\"%d\" xlink:href=\"%s\"/></svg></g>\n",
+		attrs, pdfNum(x), pdfNum(y), pdfNum(wd), pdfNum(ht), pdfNum(vx), pdfNum(vy), pdfNum(vw), pdfNum(vh),
+		cfg.Width, cfg.Height, href)
+	return true
+}
+
+// line writes a straight line without arrowheads.
+func (w *svgWriter) line(s *LineShape) bool {
+	switch s.connectorType {
+	case "", "line", "straightConnector1":
+	default:
+		return false
+	}
+	if s.customPath != nil || svgHasArrow(s.headEnd) || svgHasArrow(s.tailEnd) {
+		return false
+	}
+	x1, y1, x2, y2 := w.r.lineEnds(s)
+	transform := ""
+	if s.rotation != 0 {
+		cx, cy := (x1+x2)/2, (y1+y2)/2
+		transform = fmt.Sprintf(" transform=\"%s\"", svgTransformList(cx, cy, cx, cy, s.rotation, false, false))
+	}
+	pw := float64(s.GetLineWidthEMU()) * w.r.scaleX
+	if pw <= 0 {
+		pw = 1
+	}
+	fmt.Fprintf(&w.buf, "<line x1=\"%s\" y1=\"%s\" x2=\"%s\" y2=\"%s\"%s stroke-width=\"%s\"%s%s/>\n",
+		pdfNum(x1), pdfNum(y1), pdfNum(x2), pdfNum(y2), svgPaint("stroke", w.r.rgba(s.lineColor)), pdfNum(pw),
+		svgDashes(dashArray(s.lineStyle, s.dashPattern, int(math.Max(pw, 1)))), transform)
+	return true
+}
+
+// raster writes shape as an image rendered at svgRasterScale times the
+// output resolution, without its text.
+func (w *svgWriter) raster(shape Shape) error {
+	img, x, y, wd, ht := rasterizeShape(w.r, shape)
+	if img == nil {
+		return nil
+	}
+	return w.image(img, x, y, wd, ht)
+}
+
+// rasterizeShape renders shape without its text at svgRasterScale times the
+// resolution of base, and returns the drawn part and the box it covers in
+// base's pixels. It returns a nil image if nothing was drawn.
+func rasterizeShape(base *renderer, shape Shape) (img image.Image, x, y, wd, ht float64) {
+	bs := shape.base()
+	if bs.width <= 0 && bs.height <= 0 {
+		return nil, 0, 0, 0, 0
+	}
+	r := base.withImage(nil)
+	r.scaleX, r.scaleY = base.scaleX*svgRasterScale, base.scaleY*svgRasterScale
+	r.svgText = &svgTextSink{}
+	pw := int(math.Ceil(float64(bs.width) * r.scaleX))
+	ph := int(math.Ceil(float64(bs.height) * r.scaleY))
+	// Leave room for rotation, thick strokes, shadows and overflowing text,
+	// as RenderShape does.
+	margin := int(math.Ceil(math.Hypot(float64(pw), float64(ph))/2)) + int(math.Ceil(48*9525*r.scaleX))
+	r.img = image.NewRGBA(image.Rect(0, 0, pw+2*margin, ph+2*margin))
+
+	// The shape is faded by the group it is written in.
+	moved := translatedShape(shape, int64(float64(margin)/r.scaleX)-bs.offsetX, int64(float64(margin)/r.scaleY)-bs.offsetY)
+	if moved != shape {
+		moved.base().fade = 0
+	}
+	r.renderShape(moved)
+	crop := opaqueBounds(r.img)
+	if crop.Empty() {
+		return nil, 0, 0, 0, 0
+	}
+	mb := moved.base()
+	x = float64(bs.offsetX-mb.offsetX)*base.scaleX + float64(crop.Min.X)/svgRasterScale
+	y = float64(bs.offsetY-mb.offsetY)*base.scaleY + float64(crop.Min.Y)/svgRasterScale
+	return r.img.SubImage(crop), x, y, float64(crop.Dx()) / svgRasterScale, float64(crop.Dy()) / svgRasterScale
+}
+
+// image writes img as an embedded PNG covering the given box.
+func (w *svgWriter) image(img image.Image, x, y, wd, ht float64) error {
+	var data bytes.Buffer
+	if err := png.Encode(&data, img); err != nil {
+		return fmt.Errorf("encoding SVG image: %w", err)
+	}
+	fmt.Fprintf(&w.buf, "<image x=\"%s\" y=\"%s\" width=\"%s\" height=\"%s\" preserveAspectRatio=\"none\" xlink:href=\"data:image/png;base64,%s\"/>\n",
+		pdfNum(x), pdfNum(y), pdfNum(wd), pdfNum(ht), base64.StdEncoding.EncodeToString(data.Bytes()))
+	return nil
+}
+
+// text lays out the text of shape with the renderer and writes each run as
+// a <text> element.
+func (w *svgWriter) text(shape Shape) {
+	switch shape.(type) {
+	case *AutoShape, *RichTextShape, *PlaceholderShape, *TableShape:
+	default:
+		return
+	}
+	var runs []svgTextRun
+	w.r.svgText = &svgTextSink{runs: &runs}
+	w.r.renderShape(shape)
+	w.r.svgText = nil
+
+	group := ""
+	for _, run := range runs {
+		f := run.font
+		var decoration []string
+		if f.Underline != "" && f.Underline != UnderlineNone {
+			decoration = append(decoration, "underline")
+		}
+		if f.Strikethrough {
+			decoration = append(decoration, "line-through")
+		}
+		if strings.TrimSpace(run.text) == "" && len(decoration) == 0 {
+			continue
+		}
+		if run.transform != group {
+			if group != "" {
+				w.buf.WriteString("</g>\n")
+			}
+			if run.transform != "" {
+				fmt.Fprintf(&w.buf, "<g transform=\"%s\">\n", run.transform)
+			}
+			group = run.transform
+		}
+		var families []string
+		for _, name := range []string{f.Name, f.NameEA} {
+			if name != "" && (len(families) == 0 || families[0] != "'"+name+"'") {
+				families = append(families, "'"+name+"'")
+			}
+		}
+		families = append(families, "sans-serif")
+		fmt.Fprintf(&w.buf, "<text x=\"%d\" y=\"%d\" font-family=\"%s\" font-size=\"%s\"%s",
+			run.x, run.y, svgEscape(strings.Join(families, ", ")), pdfNum(run.size), svgPaint("fill", run.color))
+		if run.stroke > 0 {
+			fmt.Fprintf(&w.buf, "%s stroke-width=\"%s\"", svgPaint("stroke", run.outline), pdfNum(run.stroke))
+		}
+		if f.Bold {
+			w.buf.WriteString(" font-weight=\"bold\"")
+		}
+		if f.Italic {
+			w.buf.WriteString(" font-style=\"italic\"")
+		}
+		if len(decoration) > 0 {
+			fmt.Fprintf(&w.buf, " text-decoration=\"%s\"", strings.Join(decoration, " "))
+		}
+		if run.width > 0 {
+			fmt.Fprintf(&w.buf, " textLength=\"%d\"", run.width)
+		}
+		w.buf.WriteString(" xml:space=\"preserve\">")
+		xml.EscapeText(&w.buf, []byte(run.text))
+		w.buf.WriteString("</text>\n")
+	}
+	if group != "" {
+		w.buf.WriteString("</g>\n")
+	}
+}
+
+// svgPresetElement returns an unterminated SVG element outlining preset
+// geometry kind in the given box, or false if the preset is not supported.
+func svgPresetElement(kind AutoShapeType, adj map[string]int, x, y, w, h float64) (string, bool) {
+	switch kind {
+	case "", AutoShapeRectangle:
+		return fmt.Sprintf("<rect x=\"%s\" y=\"%s\" width=\"%s\" height=\"%s\"", pdfNum(x), pdfNum(y), pdfNum(w), pdfNum(h)), true
+	case AutoShapeRoundedRect:
+		radius := math.Min(w, h) * 16667 / 100000
+		if v, ok := adj["adj"]; ok {
+			radius = math.Min(w, h) * float64(v) / 200000
+		}
+		return fmt.Sprintf("<rect x=\"%s\" y=\"%s\" width=\"%s\" height=\"%s\" rx=\"%s\"",
+			pdfNum(x), pdfNum(y), pdfNum(w), pdfNum(h), pdfNum(radius)), true
+	case AutoShapeEllipse:
+		return fmt.Sprintf("<ellipse cx=\"%s\" cy=\"%s\" rx=\"%s\" ry=\"%s\"", pdfNum(x+w/2), pdfNum(y+h/2), pdfNum(w/2), pdfNum(h/2)), true
+	}
+	path, ok := presetOutline(kind, adj, x, y, w, h)
+	if !ok {
+		return "", false
+	}
+	return "<path d=\"" + svgDisplayPathData(path) + "\" fill-rule=\"evenodd\"", true
+}
+
+// svgDisplayPathData returns the SVG path data of path.
+func svgDisplayPathData(path DisplayPath) string {
+	var sb strings.Builder
+	for _, seg := range path {
+		switch seg.Verb {
+		case DisplayMoveTo:
+			sb.WriteString("M")
+		case DisplayLineTo:
+			sb.WriteString("L")
+		case DisplayCubicTo:
+			sb.WriteString("C")
+		case DisplayClose:
+			sb.WriteString("Z ")
+		}
+		for _, p := range seg.Points {
+			fmt.Fprintf(&sb, "%s %s ", pdfNum(p.X), pdfNum(p.Y))
+		}
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// svgStroke returns the stroke attributes of an outline, or "" for none.
+func svgStroke(b *Border, r *renderer) string {
+	if b == nil || b.Style == BorderNone {
+		return ""
+	}
+	pw := math.Max(float64(maxInt(b.Width, 1))*12700*r.scaleX, 1)
+	s := svgPaint("stroke", r.rgba(b.Color)) + fmt.Sprintf(" stroke-width=\"%s\"", pdfNum(pw))
+	s += svgDashes(dashArray(b.Style, b.DashPattern, int(pw)))
+	switch b.Join {
+	case LineJoinRound, LineJoinBevel:
+		s += fmt.Sprintf(" stroke-linejoin=\"%s\"", b.Join)
+	case LineJoinMiter:
+		s += " stroke-linejoin=\"miter\""
+		if b.MiterLimit > 0 {
+			s += fmt.Sprintf(" stroke-miterlimit=\"%s\"", pdfNum(float64(b.MiterLimit)/100000))
+		}
+	}
+	return s
+}
+
+// svgDashes returns the stroke-dasharray attribute for dashes, or "" for a
+// solid stroke.
+func svgDashes(dashes []float64) string {
+	if len(dashes) == 0 {
+		return ""
+	}
+	parts := make([]string, len(dashes))
+	for i, d := range dashes {
+		parts[i] = pdfNum(d)
+	}
+	return fmt.Sprintf(" stroke-dasharray=\"%s\"", strings.Join(parts, " "))
+}
+
+// svgPaint returns the attribute painting with c, e.g. fill, with its
+// opacity when c is translucent.
+func svgPaint(attr string, c color.RGBA) string {
+	opacity := attr + "-opacity"
+	if attr == "stop-color" {
+		opacity = "stop-opacity"
+	} else if c.A == 0 {
+		return fmt.Sprintf(" %s=\"none\"", attr)
+	}
+	s := fmt.Sprintf(" %s=\"#%02x%02x%02x\"", attr, c.R, c.G, c.B)
+	if c.A < 255 {
+		s += fmt.Sprintf(" %s=\"%s\"", opacity, pdfNum(float64(c.A)/255))
+	}
+	return s
+}
+
+// svgHasArrow reports whether a line end draws an arrowhead.
+func svgHasArrow(le *LineEnd) bool {
+	return le != nil && le.Type != ArrowNone && le.Type != ""
+}
+
+// svgEscape escapes s for an attribute value in double quotes.
+func svgEscape(s string) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(s))
+	return strings.ReplaceAll(sb.String(), "&#39;", "'")
+}
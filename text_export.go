@@ -0,0 +1,325 @@
+package gopresentation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// readingRowEMU is the height of the bands shapes are grouped in for
+// reading order: shapes whose tops are this close are read left to right.
+const readingRowEMU = 91440 // 0.1 inch
+
+// readingLine is a paragraph of text extracted for reading.
+type readingLine struct {
+	text   string
+	level  int    // indent level of the paragraph, from 0
+	marker string // "•" for a bullet, "3." for a numbered item, "" for none
+}
+
+// readingBlock is one shape's content extracted for reading.
+type readingBlock struct {
+	heading int // 1 for the title, 2 for the subtitle, 0 for other content
+	lines   []readingLine
+	cells   [][]string // table rows
+	alt     string     // alternative text of a figure
+	media   string     // image part of a figure, if known
+}
+
+// readingOrder returns shapes sorted from top left to bottom right, with
+// title and subtitle placeholders first.
+func readingOrder(shapes []Shape) []Shape {
+	sorted := make([]Shape, 0, len(shapes))
+	for _, shape := range shapes {
+		if shape != nil {
+			sorted = append(sorted, shape)
+		}
+	}
+	rank := func(shape Shape) int {
+		if ph, ok := shape.(*PlaceholderShape); ok {
+			switch ph.phType {
+			case PlaceholderTitle, PlaceholderCtrTitle:
+				return 0
+			case PlaceholderSubTitle:
+				return 1
+			}
+		}
+		return 2
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if ra, rb := rank(a), rank(b); ra != rb {
+			return ra < rb
+		}
+		if ya, yb := a.GetOffsetY()/readingRowEMU, b.GetOffsetY()/readingRowEMU; ya != yb {
+			return ya < yb
+		}
+		return a.GetOffsetX() < b.GetOffsetX()
+	})
+	return sorted
+}
+
+// textBlocks returns the content of a slide in reading order. Footers,
+// dates and slide numbers are left out.
+func (s *Slide) textBlocks() []readingBlock {
+	var blocks []readingBlock
+	var walk func(shapes []Shape)
+	walk = func(shapes []Shape) {
+		for _, shape := range readingOrder(shapes) {
+			switch sh := shape.(type) {
+			case *PlaceholderShape:
+				switch sh.phType {
+				case PlaceholderTitle, PlaceholderCtrTitle, PlaceholderSubTitle:
+					text := strings.Join(extractParagraphsText(sh.paragraphs), " ")
+					if text == "" {
+						continue
+					}
+					heading := 1
+					if sh.phType == PlaceholderSubTitle {
+						heading = 2
+					}
+					blocks = append(blocks, readingBlock{heading: heading, lines: []readingLine{{text: text}}})
+				case PlaceholderDate, PlaceholderFooter, PlaceholderSlideNum:
+				default:
+					blocks = appendTextBlock(blocks, sh.paragraphs)
+				}
+			case *RichTextShape:
+				blocks = appendTextBlock(blocks, sh.paragraphs)
+			case *AutoShape:
+				if len(sh.paragraphs) > 0 {
+					blocks = appendTextBlock(blocks, sh.paragraphs)
+				} else if sh.text != "" {
+					blocks = append(blocks, readingBlock{lines: []readingLine{{text: sh.text}}})
+				}
+			case *TableShape:
+				cells := make([][]string, len(sh.rows))
+				empty := true
+				for i, row := range sh.rows {
+					cells[i] = make([]string, len(row))
+					for j, cell := range row {
+						if cell != nil {
+							cells[i][j] = strings.Join(extractParagraphsText(cell.paragraphs), "\n")
+							empty = empty && cells[i][j] == ""
+						}
+					}
+				}
+				if !empty {
+					blocks = append(blocks, readingBlock{cells: cells})
+				}
+			case *DrawingShape:
+				if sh.description != "" {
+					blocks = append(blocks, readingBlock{alt: sh.description, media: sh.mediaPart})
+				}
+			case *ChartShape, *UnsupportedShape:
+				if alt := shape.base().description; alt != "" {
+					blocks = append(blocks, readingBlock{alt: alt})
+				}
+			case *GroupShape:
+				walk(sh.shapes)
+			}
+		}
+	}
+	walk(s.shapes)
+	return blocks
+}
+
+// appendTextBlock appends a block of the non-empty paragraphs, with their
+// bullets. Consecutive numbered paragraphs of a level count up.
+func appendTextBlock(blocks []readingBlock, paragraphs []*Paragraph) []readingBlock {
+	var lines []readingLine
+	numbers := make(map[int]int) // next number by level
+	for _, para := range paragraphs {
+		if para == nil {
+			continue
+		}
+		var sb strings.Builder
+		for _, elem := range para.elements {
+			switch e := elem.(type) {
+			case *TextRun:
+				sb.WriteString(e.text)
+			case *BreakElement:
+				sb.WriteString("\n")
+			}
+		}
+		text := strings.TrimSpace(sb.String())
+		if text == "" {
+			continue
+		}
+		line := readingLine{text: text}
+		if para.alignment != nil {
+			line.level = max(para.alignment.Level, 0)
+		}
+		for level := range numbers {
+			if level > line.level {
+				delete(numbers, level)
+			}
+		}
+		if b := para.bullet; b != nil {
+			switch b.Type {
+			case BulletTypeChar:
+				line.marker = "•"
+			case BulletTypeNumeric, BulletTypeAutoNum:
+				n, ok := numbers[line.level]
+				if !ok {
+					n = max(b.StartAt, 1)
+				}
+				line.marker = fmt.Sprintf("%d.", n)
+				numbers[line.level] = n + 1
+			}
+		}
+		if line.marker == "" || !strings.HasSuffix(line.marker, ".") {
+			delete(numbers, line.level)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return blocks
+	}
+	return append(blocks, readingBlock{lines: lines})
+}
+
+// ExtractSlideText returns the text of one slide in reading order, from top
+// left to bottom right after the title: one line per paragraph, bullets
+// indented by level, and table rows with their cells separated by tabs.
+// Unlike Slide.ExtractText it does not follow the z-order of the shapes.
+func (p *Presentation) ExtractSlideText(slideIndex int) (string, error) {
+	slide, err := p.GetSlide(slideIndex)
+	if err != nil {
+		return "", err
+	}
+	var out []string
+	for _, block := range slide.textBlocks() {
+		for _, line := range block.lines {
+			text := line.text
+			if line.marker != "" {
+				text = line.marker + " " + text
+			}
+			out = append(out, strings.Repeat("  ", line.level)+text)
+		}
+		for _, row := range block.cells {
+			out = append(out, strings.ReplaceAll(strings.Join(row, "\t"), "\n", " "))
+		}
+	}
+	return strings.Join(out, "\n"), nil
+}
+
+// ToMarkdown converts the presentation to Markdown for indexing or language
+// models: a heading per slide with its title, the content in reading order
+// with nested bullet and numbered lists, tables as pipe tables, pictures
+// with alt text as images, and the speaker notes as a quote.
+func (p *Presentation) ToMarkdown() string {
+	var sb strings.Builder
+	if p.properties != nil && p.properties.Title != "" {
+		fmt.Fprintf(&sb, "# %s\n\n", markdownEscape(p.properties.Title))
+	}
+	for i, slide := range p.slides {
+		blocks := slide.textBlocks()
+		title := fmt.Sprintf("Slide %d", i+1)
+		if len(blocks) > 0 && blocks[0].heading == 1 {
+			title += ": " + markdownInline(blocks[0].lines[0].text)
+			blocks = blocks[1:]
+		}
+		fmt.Fprintf(&sb, "## %s\n\n", title)
+		for _, block := range blocks {
+			writeMarkdownBlock(&sb, block)
+		}
+		if notes := strings.TrimSpace(slide.notes); notes != "" {
+			for _, line := range strings.Split(notes, "\n") {
+				sb.WriteString(strings.TrimRight("> "+markdownInline(line), " ") + "\n")
+			}
+			sb.WriteString("\n")
+		}
+	}
+	return strings.TrimRight(sb.String(), "\n") + "\n"
+}
+
+// writeMarkdownBlock writes one block followed by a blank line.
+func writeMarkdownBlock(sb *strings.Builder, block readingBlock) {
+	switch {
+	case block.heading > 0:
+		fmt.Fprintf(sb, "### %s\n\n", markdownInline(block.lines[0].text))
+	case len(block.cells) > 0:
+		cols := 0
+		for _, row := range block.cells {
+			cols = max(cols, len(row))
+		}
+		for i, row := range block.cells {
+			sb.WriteString("|")
+			for j := 0; j < cols; j++ {
+				cell := ""
+				if j < len(row) {
+					cell = strings.ReplaceAll(markdownInline(row[j]), "|", `\|`)
+				}
+				sb.WriteString(" " + cell + " |")
+			}
+			sb.WriteString("\n")
+			if i == 0 {
+				sb.WriteString("|" + strings.Repeat(" --- |", cols) + "\n")
+			}
+		}
+		sb.WriteString("\n")
+	case block.alt != "":
+		fmt.Fprintf(sb, "![%s](%s)\n\n", markdownInline(block.alt), block.media)
+	default:
+		// Each deeper paragraph level nests one list level, so skipped
+		// levels never indent an item into a code block.
+		var levels []int // paragraph levels of the open lists
+		for i, line := range block.lines {
+			if line.marker == "" {
+				if i > 0 {
+					sb.WriteString("\n")
+				}
+				sb.WriteString(markdownEscape(line.text) + "\n")
+				levels = levels[:0]
+				continue
+			}
+			if len(levels) == 0 && i > 0 {
+				sb.WriteString("\n")
+			}
+			for len(levels) > 0 && levels[len(levels)-1] > line.level {
+				levels = levels[:len(levels)-1]
+			}
+			if len(levels) == 0 || levels[len(levels)-1] < line.level {
+				levels = append(levels, line.level)
+			}
+			marker := "-"
+			if line.marker != "•" {
+				marker = line.marker
+			}
+			sb.WriteString(strings.Repeat("    ", len(levels)-1) + marker + " " + markdownInline(line.text) + "\n")
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// markdownInline escapes the characters that format text inline and turns
+// line breaks into <br>.
+func markdownInline(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch r {
+		case '\\', '*', '_', '`', '[', ']', '<', '>':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case '\n':
+			sb.WriteString("<br>")
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// markdownEscape escapes text for a paragraph of its own, including the
+// markers a line may not start with.
+func markdownEscape(s string) string {
+	s = markdownInline(s)
+	switch {
+	case strings.HasPrefix(s, "#"), strings.HasPrefix(s, "-"), strings.HasPrefix(s, "+"), strings.HasPrefix(s, "="):
+		return `\` + s
+	}
+	if i := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' }); i > 0 && (s[i] == '.' || s[i] == ')') {
+		return s[:i] + `\` + s[i:]
+	}
+	return s
+}
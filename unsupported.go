@@ -0,0 +1,46 @@
+package gopresentation
+
+// UnsupportedShape stands in for a graphic frame whose content this package
+// cannot read, such as a SmartArt diagram, a 3D model, an embedded OLE
+// object or an ActiveX control. It keeps the frame's position, name and alt
+// text so that renderers can mark where the object sits. Unsupported shapes
+// are not written back when the presentation is saved.
+type UnsupportedShape struct {
+	BaseShape
+	kind string
+	uri  string
+}
+
+// ShapeTypeUnsupported is the shape type for unsupported graphic frames.
+const ShapeTypeUnsupported ShapeType = 12
+
+func (u *UnsupportedShape) GetType() ShapeType { return ShapeTypeUnsupported }
+
+// GetKind returns a short description of the object, e.g. "SmartArt" or
+// "OLE object (Excel.Sheet.12)".
+func (u *UnsupportedShape) GetKind() string { return u.kind }
+
+// GetURI returns the graphicData URI that identifies the object's type.
+func (u *UnsupportedShape) GetURI() string { return u.uri }
+
+// graphicDataKind names the object type identified by a graphicData URI.
+func graphicDataKind(uri string) string {
+	switch uri {
+	case "http://schemas.openxmlformats.org/drawingml/2006/chart":
+		return "Chart"
+	case "http://schemas.openxmlformats.org/drawingml/2006/diagram":
+		return "SmartArt"
+	case "http://schemas.openxmlformats.org/presentationml/2006/ole":
+		return "OLE object"
+	case "http://schemas.openxmlformats.org/presentationml/2006/control":
+		return "ActiveX control"
+	case "http://schemas.microsoft.com/office/drawing/2017/model3d":
+		return "3D model"
+	case "http://schemas.microsoft.com/office/drawing/2010/slicer",
+		"http://schemas.microsoft.com/office/drawing/2010/timeslicer":
+		return "Slicer"
+	case "http://schemas.microsoft.com/office/drawing/2014/chartex":
+		return "Chart"
+	}
+	return "Unsupported object"
+}
@@ -1,148 +1,266 @@
 package gopresentation
 
 import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
 	"strings"
 )
 
-// Validate checks the presentation for structural issues and returns an error
-// describing all problems found, or nil if the presentation is valid.
+// ValidationIssueKind classifies a problem found by Validate.
+type ValidationIssueKind int
+
+const (
+	// IssueModel is an inconsistency in the presentation model, such as a
+	// negative size or a table without rows.
+	IssueModel ValidationIssueKind = iota
+	// IssueMissingPart is a relationship whose target is not in the
+	// package the presentation was read from.
+	IssueMissingPart
+	// IssueDanglingRelationship is an r:id, r:embed or similar reference
+	// that names no relationship of its part.
+	IssueDanglingRelationship
+	// IssueInvalidGeometry is an unknown preset geometry, a malformed
+	// position or size, or an unreadable adjust value.
+	IssueInvalidGeometry
+	// IssueMalformedPart is a part whose XML cannot be parsed.
+	IssueMalformedPart
+)
+
+// String returns a short name for the kind.
+func (k ValidationIssueKind) String() string {
+	switch k {
+	case IssueModel:
+		return "model"
+	case IssueMissingPart:
+		return "missing part"
+	case IssueDanglingRelationship:
+		return "dangling relationship"
+	case IssueInvalidGeometry:
+		return "invalid geometry"
+	case IssueMalformedPart:
+		return "malformed part"
+	}
+	return fmt.Sprintf("ValidationIssueKind(%d)", int(k))
+}
+
+// ValidationIssue is one problem found by Validate.
+type ValidationIssue struct {
+	Kind ValidationIssueKind
+	// Part is the package part the issue was found in, for issues found
+	// while reading; "" for model issues.
+	Part string
+	// Slide is the 1-based number of the slide concerned, or 0.
+	Slide int
+	// Location narrows the issue down within the slide or part, e.g.
+	// "shape 2" or `shape "Title 1"`; "" when it concerns the whole.
+	Location string
+	Message  string
+}
+
+// String formats the issue as in the error returned by Validate.
+func (i ValidationIssue) String() string {
+	var parts []string
+	if i.Slide > 0 {
+		parts = append(parts, fmt.Sprintf("slide %d", i.Slide))
+	} else if i.Part != "" {
+		parts = append(parts, i.Part)
+	}
+	if i.Location != "" {
+		parts = append(parts, i.Location)
+	}
+	return strings.Join(append(parts, i.Message), ": ")
+}
+
+// packageIssue is an issue found in the package while reading, with the
+// slide it concerns, so that Validate numbers it as the slide is now.
+type packageIssue struct {
+	ValidationIssue
+	slide *Slide
+}
+
+// ValidationError is the error returned by Validate. Use errors.As to get
+// the report.
+type ValidationError struct {
+	Issues []ValidationIssue
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		lines[i] = issue.String()
+	}
+	return fmt.Sprintf("validation failed:\n  %s", strings.Join(lines, "\n  "))
+}
+
+// Validate checks the presentation for structural issues and returns a
+// *ValidationError describing all problems found, or nil if the
+// presentation is valid. For a presentation read with
+// ReaderOptions.CheckPackage, the report starts with the problems of the
+// package: missing parts, dangling relationship IDs and invalid geometry,
+// which explain most decks that render oddly. Those of slides removed
+// since are left out.
 // This is analogous to unioffice's Validate() method.
 func (p *Presentation) Validate() error {
-	var errs []string
+	var issues []ValidationIssue
+	for _, pi := range p.packageIssues {
+		issue := pi.ValidationIssue
+		if pi.slide != nil {
+			n := slices.Index(p.slides, pi.slide)
+			if n < 0 {
+				continue
+			}
+			issue.Slide = n + 1
+		}
+		issues = append(issues, issue)
+	}
+	model := func(msg string) {
+		issues = append(issues, ValidationIssue{Kind: IssueModel, Message: msg})
+	}
 
 	if p.properties == nil {
-		errs = append(errs, "document properties are nil")
+		model("document properties are nil")
 	}
 	if p.presentationProperties == nil {
-		errs = append(errs, "presentation properties are nil")
+		model("presentation properties are nil")
 	}
 	if p.layout == nil {
-		errs = append(errs, "document layout is nil")
+		model("document layout is nil")
 	} else {
 		if p.layout.CX <= 0 {
-			errs = append(errs, "layout width (CX) must be positive")
+			model("layout width (CX) must be positive")
 		}
 		if p.layout.CY <= 0 {
-			errs = append(errs, "layout height (CY) must be positive")
+			model("layout height (CY) must be positive")
 		}
 	}
 	if len(p.slides) == 0 {
-		errs = append(errs, "presentation must have at least one slide")
+		model("presentation must have at least one slide")
 	}
 
 	for i, slide := range p.slides {
-		prefix := fmt.Sprintf("slide %d", i+1)
-		if slideErrs := validateSlide(slide); len(slideErrs) > 0 {
-			for _, e := range slideErrs {
-				errs = append(errs, prefix+": "+e)
-			}
+		for _, issue := range validateSlide(slide) {
+			issue.Slide = i + 1
+			issues = append(issues, issue)
 		}
 	}
 
-	if len(errs) == 0 {
+	if len(issues) == 0 {
 		return nil
 	}
-	return fmt.Errorf("validation failed:\n  %s", strings.Join(errs, "\n  "))
+	return &ValidationError{Issues: issues}
 }
 
-func validateSlide(s *Slide) []string {
-	var errs []string
+func validateSlide(s *Slide) []ValidationIssue {
+	var issues []ValidationIssue
 	for j, shape := range s.shapes {
 		prefix := fmt.Sprintf("shape %d", j+1)
+		var errs []string
 		if shape == nil {
-			errs = append(errs, prefix+": shape is nil")
+			issues = append(issues, ValidationIssue{Kind: IssueModel, Location: prefix, Message: "shape is nil"})
 			continue
 		}
 		if shape.GetWidth() < 0 {
-			errs = append(errs, prefix+": width is negative")
+			errs = append(errs, "width is negative")
 		}
 		if shape.GetHeight() < 0 {
-			errs = append(errs, prefix+": height is negative")
+			errs = append(errs, "height is negative")
 		}
 
 		switch sh := shape.(type) {
 		case *DrawingShape:
-			if sh.data == nil && sh.path == "" {
-				errs = append(errs, prefix+": drawing shape has no image data or path")
+			if sh.data == nil && sh.path == "" && sh.mediaPart == "" {
+				errs = append(errs, "drawing shape has no image data or path")
 			}
 			if sh.mimeType != "" && !isValidImageMime(sh.mimeType) {
-				errs = append(errs, prefix+": unsupported image MIME type: "+sh.mimeType)
+				errs = append(errs, "unsupported image MIME type: "+sh.mimeType)
 			}
 		case *TableShape:
 			if sh.numRows <= 0 || sh.numCols <= 0 {
-				errs = append(errs, prefix+": table must have at least 1 row and 1 column")
+				errs = append(errs, "table must have at least 1 row and 1 column")
 			}
 			if sh.numRows > 0 && sh.numCols > 0 && len(sh.rows) != sh.numRows {
-				errs = append(errs, prefix+": table row count mismatch")
+				errs = append(errs, "table row count mismatch")
 			}
 		case *ChartShape:
 			if sh.plotArea.chartType == nil {
-				errs = append(errs, prefix+": chart shape has no chart type set")
+				errs = append(errs, "chart shape has no chart type set")
 			}
 		case *RichTextShape:
 			if len(sh.paragraphs) == 0 {
-				errs = append(errs, prefix+": rich text shape has no paragraphs")
+				errs = append(errs, "rich text shape has no paragraphs")
 			}
 			if sh.columns < 1 {
-				errs = append(errs, prefix+": text columns must be >= 1")
+				errs = append(errs, "text columns must be >= 1")
 			}
-			errs = append(errs, validateParagraphs(sh.paragraphs, prefix)...)
+			errs = append(errs, validateParagraphs(sh.paragraphs)...)
 		case *PlaceholderShape:
 			if len(sh.paragraphs) == 0 {
-				errs = append(errs, prefix+": placeholder shape has no paragraphs")
+				errs = append(errs, "placeholder shape has no paragraphs")
 			}
 			if sh.phType == "" {
-				errs = append(errs, prefix+": placeholder type is empty")
+				errs = append(errs, "placeholder type is empty")
 			}
 		case *LineShape:
 			if !isValidARGB(sh.lineColor.ARGB) {
-				errs = append(errs, prefix+": line color is invalid ARGB")
+				errs = append(errs, "line color is invalid ARGB")
 			}
 		case *GroupShape:
 			for k, gs := range sh.shapes {
 				if gs == nil {
-					errs = append(errs, fmt.Sprintf("%s: group child %d is nil", prefix, k+1))
+					errs = append(errs, fmt.Sprintf("group child %d is nil", k+1))
 				}
 			}
 		}
+		for _, e := range errs {
+			issues = append(issues, ValidationIssue{Kind: IssueModel, Location: prefix, Message: e})
+		}
 	}
 
 	for j, c := range s.comments {
+		comment := func(msg string) {
+			issues = append(issues, ValidationIssue{Kind: IssueModel, Location: fmt.Sprintf("comment %d", j+1), Message: msg})
+		}
 		if c == nil {
-			errs = append(errs, fmt.Sprintf("comment %d: is nil", j+1))
+			comment("is nil")
 			continue
 		}
 		if c.Author == nil {
-			errs = append(errs, fmt.Sprintf("comment %d: missing author", j+1))
+			comment("missing author")
 		}
 		if c.Text == "" {
-			errs = append(errs, fmt.Sprintf("comment %d: empty text", j+1))
+			comment("empty text")
 		}
 	}
 
-	return errs
+	return issues
 }
 
 // validateParagraphs checks paragraph elements for common issues.
-func validateParagraphs(paragraphs []*Paragraph, prefix string) []string {
+func validateParagraphs(paragraphs []*Paragraph) []string {
 	var errs []string
 	for i, para := range paragraphs {
 		if para == nil {
-			errs = append(errs, fmt.Sprintf("%s: paragraph %d is nil", prefix, i+1))
+			errs = append(errs, fmt.Sprintf("paragraph %d is nil", i+1))
 			continue
 		}
 		if para.alignment == nil {
-			errs = append(errs, fmt.Sprintf("%s: paragraph %d has nil alignment", prefix, i+1))
+			errs = append(errs, fmt.Sprintf("paragraph %d has nil alignment", i+1))
 		}
 		for k, elem := range para.elements {
 			if elem == nil {
-				errs = append(errs, fmt.Sprintf("%s: paragraph %d element %d is nil", prefix, i+1, k+1))
+				errs = append(errs, fmt.Sprintf("paragraph %d element %d is nil", i+1, k+1))
 				continue
 			}
 			if tr, ok := elem.(*TextRun); ok {
 				if tr.font == nil {
-					errs = append(errs, fmt.Sprintf("%s: paragraph %d text run %d has nil font", prefix, i+1, k+1))
+					errs = append(errs, fmt.Sprintf("paragraph %d text run %d has nil font", i+1, k+1))
 				}
 			}
 		}
@@ -158,3 +276,210 @@ func isValidImageMime(mime string) bool {
 	}
 	return false
 }
+
+// presetGeometries are the values of ST_ShapeType, the preset geometries a
+// prstGeom may name.
+var presetGeometries = func() map[string]bool {
+	m := make(map[string]bool)
+	for _, name := range strings.Fields(`
+		line lineInv triangle rtTriangle rect diamond parallelogram trapezoid
+		nonIsoscelesTrapezoid pentagon hexagon heptagon octagon decagon
+		dodecagon star4 star5 star6 star7 star8 star10 star12 star16 star24
+		star32 roundRect round1Rect round2SameRect round2DiagRect
+		snipRoundRect snip1Rect snip2SameRect snip2DiagRect plaque ellipse
+		teardrop homePlate chevron pieWedge pie blockArc donut noSmoking
+		rightArrow leftArrow upArrow downArrow stripedRightArrow
+		notchedRightArrow bentUpArrow leftRightArrow upDownArrow leftUpArrow
+		leftRightUpArrow quadArrow leftArrowCallout rightArrowCallout
+		upArrowCallout downArrowCallout leftRightArrowCallout
+		upDownArrowCallout quadArrowCallout bentArrow uturnArrow
+		circularArrow leftCircularArrow leftRightCircularArrow
+		curvedRightArrow curvedLeftArrow curvedUpArrow curvedDownArrow
+		swooshArrow cube can lightningBolt heart sun moon smileyFace
+		irregularSeal1 irregularSeal2 foldedCorner bevel frame halfFrame
+		corner diagStripe chord arc leftBracket rightBracket leftBrace
+		rightBrace bracketPair bracePair straightConnector1 bentConnector2
+		bentConnector3 bentConnector4 bentConnector5 curvedConnector2
+		curvedConnector3 curvedConnector4 curvedConnector5 callout1 callout2
+		callout3 accentCallout1 accentCallout2 accentCallout3 borderCallout1
+		borderCallout2 borderCallout3 accentBorderCallout1
+		accentBorderCallout2 accentBorderCallout3 wedgeRectCallout
+		wedgeRoundRectCallout wedgeEllipseCallout cloudCallout cloud ribbon
+		ribbon2 ellipseRibbon ellipseRibbon2 leftRightRibbon verticalScroll
+		horizontalScroll wave doubleWave plus flowChartProcess
+		flowChartDecision flowChartInputOutput flowChartPredefinedProcess
+		flowChartInternalStorage flowChartDocument flowChartMultidocument
+		flowChartTerminator flowChartPreparation flowChartManualInput
+		flowChartManualOperation flowChartConnector flowChartPunchedCard
+		flowChartPunchedTape flowChartSummingJunction flowChartOr
+		flowChartCollate flowChartSort flowChartExtract flowChartMerge
+		flowChartOfflineStorage flowChartOnlineStorage flowChartMagneticTape
+		flowChartMagneticDisk flowChartMagneticDrum flowChartDisplay
+		flowChartDelay flowChartAlternateProcess flowChartOffpageConnector
+		actionButtonBlank actionButtonHome actionButtonHelp
+		actionButtonInformation actionButtonForwardNext
+		actionButtonBackPrevious actionButtonEnd actionButtonBeginning
+		actionButtonReturn actionButtonDocument actionButtonSound
+		actionButtonMovie gear6 gear9 funnel mathPlus mathMinus mathMultiply
+		mathDivide mathEqual mathNotEqual cornerTabs squareTabs plaqueTabs
+		chartX chartStar chartPlus`) {
+		m[name] = true
+	}
+	return m
+}()
+
+// relationshipNamespaces are the namespaces of attributes that hold
+// relationship IDs, in transitional and strict documents.
+var relationshipNamespaces = map[string]bool{
+	nsOfficeDocRels: true,
+	"http://purl.oclc.org/ooxml/officeDocument/relationships": true,
+}
+
+// checkPackage looks for the structural problems of a package that the
+// reader works around: relationships to missing parts, references to
+// relationships that do not exist and invalid geometry in slides, layouts,
+// masters and notes. slides were read from slideParts.
+func checkPackage(zr *zip.Reader, slideParts []string, slides []*Slide) []packageIssue {
+	files := zipIndex(zr)
+	slideOf := make(map[string]*Slide, len(slideParts))
+	for i, part := range slideParts {
+		slideOf[part] = slides[i]
+	}
+	var issues []packageIssue
+	report := func(kind ValidationIssueKind, part, location, msg string) {
+		issues = append(issues, packageIssue{
+			ValidationIssue: ValidationIssue{Kind: kind, Part: part, Location: location, Message: msg},
+			slide:           slideOf[part],
+		})
+	}
+
+	// Relationships of every part, by part name.
+	relIDs := make(map[string]map[string]bool)
+	for _, f := range zr.File {
+		dir, name := path.Split(f.Name)
+		if !strings.HasSuffix(dir, "_rels/") || !strings.HasSuffix(name, ".rels") {
+			continue
+		}
+		source := path.Join(strings.TrimSuffix(dir, "_rels/"), strings.TrimSuffix(name, ".rels"))
+		data, err := readZipFile(f)
+		if err != nil {
+			continue
+		}
+		var rels xmlRelsForRead
+		if err := xml.Unmarshal(data, &rels); err != nil {
+			report(IssueMalformedPart, source, "", "relationships cannot be parsed: "+err.Error())
+			continue
+		}
+		ids := make(map[string]bool, len(rels.Relationships))
+		for _, rel := range rels.Relationships {
+			ids[rel.ID] = true
+			if rel.isExternal() || rel.Target == "" {
+				continue
+			}
+			target := strings.TrimPrefix(rel.Target, "/")
+			if !strings.HasPrefix(rel.Target, "/") {
+				target = path.Join(path.Dir(source), rel.Target)
+			}
+			if files[target] == nil {
+				if unescaped, err := url.PathUnescape(target); err != nil || files[unescaped] == nil {
+					report(IssueMissingPart, source, "", fmt.Sprintf("relationship %s targets missing part %s", rel.ID, target))
+				}
+			}
+		}
+		relIDs[source] = ids
+	}
+
+	for _, f := range zr.File {
+		dir := path.Dir(f.Name)
+		switch dir {
+		case "ppt/slides", "ppt/slideLayouts", "ppt/slideMasters", "ppt/notesSlides":
+		default:
+			continue
+		}
+		if path.Ext(f.Name) != ".xml" {
+			continue
+		}
+		data, err := readZipFile(f)
+		if err != nil {
+			continue
+		}
+		checkPartXML(data, relIDs[f.Name], func(kind ValidationIssueKind, location, msg string) {
+			report(kind, f.Name, location, msg)
+		})
+	}
+	return issues
+}
+
+// checkPartXML reports the dangling relationship IDs and invalid geometry
+// of one slide-like part with relationship IDs ids.
+func checkPartXML(data []byte, ids map[string]bool, report func(kind ValidationIssueKind, location, msg string)) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	location := ""
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if err != io.EOF {
+				report(IssueMalformedPart, location, "XML cannot be parsed: "+err.Error())
+			}
+			return
+		}
+		t, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		for _, attr := range t.Attr {
+			if relationshipNamespaces[attr.Name.Space] && attr.Value != "" && !ids[attr.Value] {
+				report(IssueDanglingRelationship, location, fmt.Sprintf("%s r:%s=%q names no relationship", t.Name.Local, attr.Name.Local, attr.Value))
+			}
+		}
+		switch t.Name.Local {
+		case "cNvPr":
+			location = ""
+			for _, attr := range t.Attr {
+				if attr.Name.Local == "name" {
+					location = fmt.Sprintf("shape %q", attr.Value)
+				}
+			}
+		case "prstGeom":
+			for _, attr := range t.Attr {
+				if attr.Name.Local == "prst" && !presetGeometries[attr.Value] {
+					report(IssueInvalidGeometry, location, fmt.Sprintf("unknown preset geometry %q", attr.Value))
+				}
+			}
+		case "off", "chOff", "ext", "chExt":
+			if t.Name.Space != nsDrawingML && t.Name.Space != nsPresentationML {
+				continue
+			}
+			for _, attr := range t.Attr {
+				switch attr.Name.Local {
+				case "x", "y", "cx", "cy":
+				default:
+					continue
+				}
+				v, err := strconv.ParseInt(attr.Value, 10, 64)
+				if err != nil {
+					report(IssueInvalidGeometry, location, fmt.Sprintf("%s %s=%q is not a coordinate", t.Name.Local, attr.Name.Local, attr.Value))
+				} else if v < 0 && (attr.Name.Local == "cx" || attr.Name.Local == "cy") {
+					report(IssueInvalidGeometry, location, fmt.Sprintf("%s %s=%d is negative", t.Name.Local, attr.Name.Local, v))
+				}
+			}
+		case "gd":
+			// Adjust values of preset geometries are constants; custom
+			// geometry guides may use any formula.
+			for _, attr := range t.Attr {
+				if attr.Name.Local != "fmla" {
+					continue
+				}
+				if f := strings.Fields(attr.Value); len(f) == 0 || f[0] == "val" && (len(f) != 2 || !isInteger(f[1])) {
+					report(IssueInvalidGeometry, location, fmt.Sprintf("guide formula %q cannot be read", attr.Value))
+				}
+			}
+		}
+	}
+}
+
+// isInteger reports whether s is a decimal integer.
+func isInteger(s string) bool {
+	_, err := strconv.ParseInt(s, 10, 64)
+	return err == nil
+}
@@ -71,6 +71,9 @@ func (w *PPTXWriter) WriteTo(writer io.Writer) error {
 	if w.presentation == nil {
 		return fmt.Errorf("presentation is nil")
 	}
+	if w.presentation.source != nil && w.canKeepSource() {
+		return w.writeKeepingSource(writer)
+	}
 
 	zw := zip.NewWriter(writer)
 
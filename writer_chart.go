@@ -90,9 +90,9 @@ func (w *PPTXWriter) writeChartPart(zw *zip.Writer, chart *ChartShape, chartIdx
         </a:p>
       </c:rich>
     </c:tx>
-    <c:overlay val="0"/>
+    <c:overlay val="%s"/>
   </c:title>
-`, chart.title.Font.Size*100, boolToXML(chart.title.Font.Bold), xmlEscape(chart.title.Text))
+`, chart.title.Font.Size*100, boolToXML(chart.title.Font.Bold), xmlEscape(chart.title.Text), boolToXML(chart.title.Overlay))
 	} else if !chart.title.Visible {
 		titleXML = `  <c:autoTitleDeleted val="1"/>
 `
@@ -148,6 +148,15 @@ func isPieType(ct ChartType) bool {
 	return false
 }
 
+// tickMarkXML returns the ST_TickMark value for v, defaulting to none.
+func tickMarkXML(v string) string {
+	switch v {
+	case TickMarkInside, TickMarkOutside, TickMarkCross:
+		return v
+	}
+	return TickMarkNone
+}
+
 func (w *PPTXWriter) writeAxesXML(chart *ChartShape) string {
 	axX := chart.plotArea.axisX
 	axY := chart.plotArea.axisY
@@ -159,8 +168,15 @@ func (w *PPTXWriter) writeAxesXML(chart *ChartShape) string {
         <c:axPos val="b"/>
         <c:crossAx val="2"/>
         <c:crosses val="%s"/>
+        <c:majorTickMark val="%s"/>
+        <c:minorTickMark val="%s"/>
         <c:tickLblPos val="%s"/>
-`, w.axisOrientation(axX), boolToXML(!axX.Visible), axX.CrossesAt, axX.TickLabelPos)
+`, w.axisOrientation(axX), boolToXML(!axX.Visible), axX.CrossesAt, tickMarkXML(axX.MajorTickMark), tickMarkXML(axX.MinorTickMark), axX.TickLabelPos)
+
+	if axX.LabelRotation != 0 {
+		catAxisXML += fmt.Sprintf(`        <c:txPr><a:bodyPr rot="%d" vert="horz"/><a:lstStyle/><a:p><a:pPr><a:defRPr/></a:pPr><a:endParaRPr lang="en-US"/></a:p></c:txPr>
+`, axX.LabelRotation*60000)
+	}
 
 	if axX.Title != "" {
 		catAxisXML += fmt.Sprintf(`        <c:title><c:tx><c:rich><a:bodyPr/><a:lstStyle/><a:p><a:r><a:rPr lang="en-US"/><a:t>%s</a:t></a:r></a:p></c:rich></c:tx></c:title>
@@ -169,6 +185,10 @@ func (w *PPTXWriter) writeAxesXML(chart *ChartShape) string {
 	if axX.MajorGridlines != nil {
 		catAxisXML += w.writeGridlinesXML("c:majorGridlines", axX.MajorGridlines)
 	}
+	if axX.TickLabelSkip > 0 {
+		catAxisXML += fmt.Sprintf(`        <c:tickLblSkip val="%d"/>
+`, axX.TickLabelSkip)
+	}
 	catAxisXML += "      </c:catAx>\n"
 
 	valAxisXML := fmt.Sprintf(`      <c:valAx>
@@ -191,8 +211,10 @@ func (w *PPTXWriter) writeAxesXML(chart *ChartShape) string {
         <c:axPos val="l"/>
         <c:crossAx val="1"/>
         <c:crosses val="%s"/>
+        <c:majorTickMark val="%s"/>
+        <c:minorTickMark val="%s"/>
         <c:tickLblPos val="%s"/>
-`, boolToXML(!axY.Visible), axY.CrossesAt, axY.TickLabelPos)
+`, boolToXML(!axY.Visible), axY.CrossesAt, tickMarkXML(axY.MajorTickMark), tickMarkXML(axY.MinorTickMark), axY.TickLabelPos)
 
 	if axY.MajorUnit != nil {
 		valAxisXML += fmt.Sprintf(`        <c:majorUnit val="%g"/>
@@ -205,6 +227,10 @@ func (w *PPTXWriter) writeAxesXML(chart *ChartShape) string {
 	if axY.Title != "" {
 		valAxisXML += fmt.Sprintf(`        <c:title><c:tx><c:rich><a:bodyPr/><a:lstStyle/><a:p><a:r><a:rPr lang="en-US"/><a:t>%s</a:t></a:r></a:p></c:rich></c:tx></c:title>
 `, xmlEscape(axY.Title))
+	}
+	if axY.NumberFormat != "" {
+		valAxisXML += fmt.Sprintf(`        <c:numFmt formatCode="%s" sourceLinked="0"/>
+`, xmlEscape(axY.NumberFormat))
 	}
 	if axY.MajorGridlines != nil {
 		valAxisXML += w.writeGridlinesXML("c:majorGridlines", axY.MajorGridlines)
@@ -253,6 +279,9 @@ func (w *PPTXWriter) writeSeriesXML(series []*ChartSeries, categories []string,
 		// Data labels
 		if s.ShowValue || s.ShowCategoryName || s.ShowPercentage || s.ShowSeriesName {
 			sb.WriteString("          <c:dLbls>\n")
+			if s.NumberFormat != "" {
+				sb.WriteString(fmt.Sprintf("            <c:numFmt formatCode=\"%s\" sourceLinked=\"0\"/>\n", xmlEscape(s.NumberFormat)))
+			}
 			if s.ShowValue {
 				sb.WriteString("            <c:showVal val=\"1\"/>\n")
 			}
@@ -3,12 +3,14 @@ package gopresentation
 import (
 	"archive/zip"
 	"fmt"
+	"strings"
 )
 
 // --- Presentation Part ---
 
 func (w *PPTXWriter) writePresentation(zw *zip.Writer) error {
 	layout := w.presentation.layout
+	notesCX, notesCY := w.presentation.notesPageSize()
 
 	slideList := ""
 	relIdx := 2 // rId1 is slideMaster
@@ -18,6 +20,24 @@ func (w *PPTXWriter) writePresentation(zw *zip.Writer) error {
 		relIdx++
 	}
 
+	albumXML := ""
+	if a := w.presentation.photoAlbum; a != nil {
+		albumXML = "  <p:photoAlbum"
+		if a.BlackAndWhite {
+			albumXML += ` bw="1"`
+		}
+		if a.ShowCaptions {
+			albumXML += ` showCaptions="1"`
+		}
+		if a.Layout != "" {
+			albumXML += fmt.Sprintf(` layout="%s"`, xmlEscape(string(a.Layout)))
+		}
+		if a.Frame != "" {
+			albumXML += fmt.Sprintf(` frame="%s"`, xmlEscape(string(a.Frame)))
+		}
+		albumXML += "/>\n"
+	}
+
 	content := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
 <p:presentation xmlns:a="%s" xmlns:r="%s" xmlns:p="%s">
   <p:sldMasterIdLst>
@@ -27,12 +47,13 @@ func (w *PPTXWriter) writePresentation(zw *zip.Writer) error {
 %s  </p:sldIdLst>
   <p:sldSz cx="%d" cy="%d" type="%s"/>
   <p:notesSz cx="%d" cy="%d"/>
-  <p:defaultTextStyle/>
+%s  <p:defaultTextStyle/>
 </p:presentation>`,
 		nsDrawingML, nsOfficeDocRels, nsPresentationML,
 		slideList,
 		layout.CX, layout.CY, layout.Name,
-		layout.CY, layout.CX, // notes are rotated
+		notesCX, notesCY,
+		albumXML,
 	)
 	return writeRawXMLToZip(zw, "ppt/presentation.xml", content)
 }
@@ -79,6 +100,19 @@ func (w *PPTXWriter) writeViewProps(zw *zip.Writer) error {
 		lastView = "sldSorterView"
 	}
 
+	var guides strings.Builder
+	if len(pp.guides) > 0 {
+		guides.WriteString("\n      <p:guideLst>")
+		for _, g := range pp.guides {
+			orient := "horz"
+			if g.Vertical {
+				orient = "vert"
+			}
+			fmt.Fprintf(&guides, `\n        <p:guide orient="%s" pos="%d"/>`, orient, g.Position*8/12700)
+		}
+		guides.WriteString("\n      </p:guideLst>")
+	}
+
 	content := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
 <p:viewPr xmlns:a="%s" xmlns:r="%s" xmlns:p="%s" lastView="%s">
   <p:normalViewPr>
@@ -93,11 +127,11 @@ func (w *PPTXWriter) writeViewProps(zw *zip.Writer) error {
           <a:sy n="%d" d="100"/>
         </p:scale>
         <p:origin x="0" y="0"/>
-      </p:cViewPr>
+      </p:cViewPr>%s
     </p:cSldViewPr>
   </p:slideViewPr>
 </p:viewPr>`, nsDrawingML, nsOfficeDocRels, nsPresentationML, lastView,
-		int(pp.zoom*100), int(pp.zoom*100))
+		int(pp.zoom*100), int(pp.zoom*100), guides.String())
 	return writeRawXMLToZip(zw, "ppt/viewProps.xml", content)
 }
 
@@ -3,6 +3,7 @@ package gopresentation
 import (
 	"archive/zip"
 	"fmt"
+	"math"
 	"os"
 	"strings"
 )
@@ -31,7 +32,7 @@ func (w *PPTXWriter) buildHyperlinkRelMap(slide *Slide) map[*TextRun]string {
 func countShapeRels(shape Shape) int {
 	switch s := shape.(type) {
 	case *DrawingShape:
-		if s.data != nil || s.path != "" {
+		if s.data != nil || s.path != "" || s.externalURL != "" {
 			return 1
 		}
 	case *ChartShape:
@@ -106,9 +107,9 @@ func (w *PPTXWriter) writeSlide(zw *zip.Writer, slide *Slide, slideNum int, hlin
 
 	// Background XML
 	bgXML := ""
-	if slide.background != nil && slide.background.Type != FillNone {
-		bgXML = "    <p:bg>\n      <p:bgPr>\n"
-		bgXML += w.writeFillXML(slide.background)
+	// p:bgPr needs a fill, and picture fills are not written.
+	if fillXML := w.writeFillXML(slide.background); fillXML != "" {
+		bgXML = "    <p:bg>\n      <p:bgPr>\n" + fillXML
 		bgXML += "        <a:effectLst/>\n      </p:bgPr>\n    </p:bg>\n"
 	}
 
@@ -134,11 +135,64 @@ func (w *PPTXWriter) writeSlide(zw *zip.Writer, slide *Slide, slideNum int, hlin
   <p:clrMapOvr>
     <a:masterClrMapping/>
   </p:clrMapOvr>
-</p:sld>`, nsDrawingML, nsOfficeDocRels, nsPresentationML, bgXML, result)
+%s</p:sld>`, nsDrawingML, nsOfficeDocRels, nsPresentationML, bgXML, result, writeTransitionXML(slide))
 
 	return writeRawXMLToZip(zw, fmt.Sprintf("ppt/slides/slide%d.xml", slideNum), content)
 }
 
+// writeTransitionXML returns the <p:transition> element for the slide's
+// transition and advance timing, or "" when both are at their defaults.
+func writeTransitionXML(slide *Slide) string {
+	tr := slide.transition
+	timing := slide.timing
+	if (tr == nil || tr.Type == TransitionNone) && timing.AdvanceOnClick && !timing.AutoAdvance {
+		return ""
+	}
+	var attrs strings.Builder
+	if tr != nil && tr.Speed != "" {
+		fmt.Fprintf(&attrs, ` spd="%s"`, tr.Speed)
+	}
+	if !timing.AdvanceOnClick {
+		attrs.WriteString(` advClick="0"`)
+	}
+	if timing.AutoAdvance {
+		fmt.Fprintf(&attrs, ` advTm="%d"`, timing.AdvanceAfter.Milliseconds())
+	}
+	effect := ""
+	if tr != nil {
+		switch tr.Type {
+		case TransitionFade:
+			effect = "<p:fade/>"
+		case TransitionPush:
+			effect = "<p:push/>"
+		case TransitionWipe:
+			effect = "<p:wipe/>"
+		case TransitionSplit:
+			effect = "<p:split/>"
+		case TransitionCover:
+			effect = "<p:cover/>"
+		case TransitionUncover:
+			effect = "<p:pull/>"
+		case TransitionDissolve:
+			effect = "<p:dissolve/>"
+		}
+	}
+	element := func(attrs string) string {
+		if effect == "" {
+			return fmt.Sprintf("<p:transition%s/>", attrs)
+		}
+		return fmt.Sprintf("<p:transition%s>%s</p:transition>", attrs, effect)
+	}
+	if tr == nil || tr.Duration <= 0 {
+		return "  " + element(attrs.String()) + "\n"
+	}
+	// The duration is a PowerPoint 2010 attribute, so it goes in a p14
+	// Choice with the plain transition as the fallback, as PowerPoint
+	// writes it.
+	return fmt.Sprintf(`  <mc:AlternateContent xmlns:mc="%s"><mc:Choice xmlns:p14="%s" Requires="p14">%s</mc:Choice><mc:Fallback>%s</mc:Fallback></mc:AlternateContent>
+`, nsMarkupCompat, nsP14, element(fmt.Sprintf(`%s p14:dur="%d"`, attrs.String(), tr.Duration)), element(attrs.String()))
+}
+
 func (w *PPTXWriter) writeSlideRels(zw *zip.Writer, slide *Slide, slideNum int, hlinkRelMap map[*TextRun]string) error {
 	var rels strings.Builder
 	fmt.Fprintf(&rels, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
@@ -156,6 +210,11 @@ func (w *PPTXWriter) writeSlideRels(zw *zip.Writer, slide *Slide, slideNum int,
   <Relationship Id="rId%d" Type="%s" Target="../media/image%d.%s"/>`,
 					relIdx, relTypeImage, imgIdx, ext)
 				relIdx++
+			} else if s.externalURL != "" {
+				fmt.Fprintf(&rels, `
+  <Relationship Id="rId%d" Type="%s" Target="%s" TargetMode="External"/>`,
+					relIdx, relTypeImage, xmlEscape(s.externalURL))
+				relIdx++
 			}
 		case *ChartShape:
 			chartIdx := w.getChartIndex(s)
@@ -215,6 +274,9 @@ func (w *PPTXWriter) getImageIndex(slide *Slide, target *DrawingShape) int {
 			if ds == target {
 				return idx
 			}
+			if ds.data == nil && ds.path == "" {
+				continue // linked or empty pictures have no media part
+			}
 			idx++
 		}
 	}
@@ -228,7 +290,7 @@ func collectDrawingShapes(shapes []Shape) []*DrawingShape {
 	for _, shape := range shapes {
 		switch s := shape.(type) {
 		case *DrawingShape:
-			if s.data != nil || s.path != "" {
+			if s.data != nil || s.path != "" || s.mediaPart != "" {
 				result = append(result, s)
 			}
 		case *GroupShape:
@@ -255,6 +317,14 @@ func xfrmAttrs(b *BaseShape) string {
 	return sb.String()
 }
 
+// richTextGeometry returns the prstGeom preset of a text shape.
+func richTextGeometry(s *RichTextShape) AutoShapeType {
+	if s.geometry == "" {
+		return AutoShapeRectangle
+	}
+	return s.geometry
+}
+
 func (w *PPTXWriter) writeRichTextShapeXML(s *RichTextShape, shapeID *int) string {
 	id := *shapeID
 	*shapeID++
@@ -282,7 +352,7 @@ func (w *PPTXWriter) writeRichTextShapeXML(s *RichTextShape, shapeID *int) strin
 	return fmt.Sprintf(`      <p:sp>
         <p:nvSpPr>
           <p:cNvPr id="%d" name="%s"%s/>
-          <p:cNvSpPr txBox="1"/>
+          %s
           <p:nvPr/>
         </p:nvSpPr>
         <p:spPr>
@@ -290,7 +360,7 @@ func (w *PPTXWriter) writeRichTextShapeXML(s *RichTextShape, shapeID *int) strin
             <a:off x="%d" y="%d"/>
             <a:ext cx="%d" cy="%d"/>
           </a:xfrm>
-          <a:prstGeom prst="rect">
+          <a:prstGeom prst="%s">
             <a:avLst/>
           </a:prstGeom>
 %s%s        </p:spPr>
@@ -299,10 +369,10 @@ func (w *PPTXWriter) writeRichTextShapeXML(s *RichTextShape, shapeID *int) strin
           <a:lstStyle/>
 %s        </p:txBody>
       </p:sp>
-`, id, xmlEscape(name), descrAttr, xfAttrs,
+`, id, xmlEscape(name), descrAttr, nvShapePropsXML("cNvSpPr", "spLocks", &s.BaseShape, nil), xfAttrs,
 		s.offsetX, s.offsetY, s.width, s.height,
-		fillXML, borderXML,
-		boolToWrap(s.wordWrap), s.columns, textAnchorAttr(s.textAnchor),
+		richTextGeometry(s), fillXML, borderXML,
+		boolToWrap(s.wordWrap), s.columns, textAnchorAttr(s.textAnchor)+anchorCtrAttr(s.anchorCtr),
 		normAutofitXML(s.fontScale),
 		paragraphsXML.String())
 }
@@ -322,6 +392,14 @@ func textAnchorAttr(anchor TextAnchorType) string {
 	return fmt.Sprintf(` anchor="%s"`, string(anchor))
 }
 
+// anchorCtrAttr returns the anchorCtr attribute string for <a:bodyPr>.
+func anchorCtrAttr(center bool) string {
+	if !center {
+		return ""
+	}
+	return ` anchorCtr="1"`
+}
+
 // normAutofitXML returns the <a:normAutofit> child element for <a:bodyPr> if fontScale is set.
 func normAutofitXML(fontScale int) string {
 	if fontScale > 0 && fontScale != 100000 {
@@ -341,6 +419,18 @@ func (w *PPTXWriter) writeParagraphXML(para *Paragraph) string {
 	if align.Level > 0 {
 		algn += fmt.Sprintf(` lvl="%d"`, align.Level)
 	}
+	if align.MarginLeft != 0 {
+		algn += fmt.Sprintf(` marL="%d"`, align.MarginLeft)
+	}
+	if align.MarginRight != 0 {
+		algn += fmt.Sprintf(` marR="%d"`, align.MarginRight)
+	}
+	if align.Indent != 0 {
+		algn += fmt.Sprintf(` indent="%d"`, align.Indent)
+	}
+	if align.DefTabSize > 0 {
+		algn += fmt.Sprintf(` defTabSz="%d"`, align.DefTabSize)
+	}
 
 	var elementsXML strings.Builder
 	for _, elem := range para.elements {
@@ -361,11 +451,17 @@ func (w *PPTXWriter) writeParagraphXML(para *Paragraph) string {
 		spacing = fmt.Sprintf(`
             <a:lnSpc><a:spcPts val="%d"/></a:lnSpc>`, para.lineSpacing)
 	}
-	if para.spaceBefore > 0 {
+	if para.spaceBefore < 0 {
+		spacing += fmt.Sprintf(`
+            <a:spcBef><a:spcPct val="%d"/></a:spcBef>`, -para.spaceBefore)
+	} else if para.spaceBefore > 0 {
 		spacing += fmt.Sprintf(`
             <a:spcBef><a:spcPts val="%d"/></a:spcBef>`, para.spaceBefore)
 	}
-	if para.spaceAfter > 0 {
+	if para.spaceAfter < 0 {
+		spacing += fmt.Sprintf(`
+            <a:spcAft><a:spcPct val="%d"/></a:spcAft>`, -para.spaceAfter)
+	} else if para.spaceAfter > 0 {
 		spacing += fmt.Sprintf(`
             <a:spcAft><a:spcPts val="%d"/></a:spcAft>`, para.spaceAfter)
 	}
@@ -396,16 +492,50 @@ func (w *PPTXWriter) writeTextRunXML(tr *TextRun) string {
 	if font.Underline != UnderlineNone && font.Underline != "" {
 		attrs += fmt.Sprintf(` u="%s"`, font.Underline)
 	}
-	if font.Strikethrough {
-		attrs += ` strike="sngStrike"`
+	if st := font.strikeType(); st != StrikeNone {
+		attrs += fmt.Sprintf(` strike="%s"`, st)
+	}
+	if font.Kumimoji {
+		attrs += ` kumimoji="1"`
+	}
+	if font.Kern != nil {
+		attrs += fmt.Sprintf(` kern="%d"`, *font.Kern)
+	}
+	if font.Spacing != 0 {
+		attrs += fmt.Sprintf(` spc="%d"`, font.Spacing)
 	}
 
 	solidFill := ""
-	if font.Color.ARGB != "" {
+	if font.OutlineColor != nil {
+		width := ""
+		if font.OutlineWidth > 0 {
+			width = fmt.Sprintf(` w="%d"`, font.OutlineWidth)
+		}
 		solidFill = fmt.Sprintf(`
+              <a:ln%s><a:solidFill>%s</a:solidFill></a:ln>`, width, srgbClrXML(*font.OutlineColor))
+	}
+	if font.NoFill {
+		solidFill += `
+              <a:noFill/>`
+	} else if g := font.Gradient; g != nil && g.Type == FillGradientLinear {
+		solidFill += fmt.Sprintf(`
+              <a:gradFill><a:gsLst><a:gs pos="0">%s</a:gs><a:gs pos="100000">%s</a:gs></a:gsLst><a:lin ang="%d" scaled="1"/></a:gradFill>`,
+			srgbClrXML(g.Color), srgbClrXML(g.EndColor), g.Rotation*60000)
+	} else if font.Color.ARGB != "" {
+		solidFill += fmt.Sprintf(`
               <a:solidFill><a:srgbClr val="%s"/></a:solidFill>`, colorRGB(font.Color))
 	}
 
+	underline := ""
+	if font.UnderlineWidth > 0 {
+		underline += fmt.Sprintf(`
+              <a:uLn w="%d"/>`, font.UnderlineWidth)
+	}
+	if font.UnderlineColor != nil {
+		underline += fmt.Sprintf(`
+              <a:uFill><a:solidFill>%s</a:solidFill></a:uFill>`, srgbClrXML(*font.UnderlineColor))
+	}
+
 	latin := ""
 	if font.Name != "" {
 		latin = fmt.Sprintf(`
@@ -418,6 +548,17 @@ func (w *PPTXWriter) writeTextRunXML(tr *TextRun) string {
               <a:ea typeface="%s"/>`, xmlEscape(font.NameEA))
 	}
 
+	cs := ""
+	if font.NameCS != "" {
+		cs = fmt.Sprintf(`
+              <a:cs typeface="%s"/>`, xmlEscape(font.NameCS))
+	}
+	sym := ""
+	if font.NameSym != "" {
+		sym = fmt.Sprintf(`
+              <a:sym typeface="%s"/>`, xmlEscape(font.NameSym))
+	}
+
 	hlinkStart := ""
 	hlinkEnd := ""
 	if tr.hyperlink != nil && !tr.hyperlink.IsInternal {
@@ -426,11 +567,11 @@ func (w *PPTXWriter) writeTextRunXML(tr *TextRun) string {
 	}
 
 	return fmt.Sprintf(`            <a:r>
-              <a:rPr%s>%s%s%s%s%s
+              <a:rPr%s>%s%s%s%s%s%s%s%s
               </a:rPr>
               <a:t>%s</a:t>
             </a:r>
-`, attrs, solidFill, latin, ea, hlinkStart, hlinkEnd, xmlEscape(tr.text))
+`, attrs, solidFill, underline, latin, ea, cs, sym, hlinkStart, hlinkEnd, xmlEscape(tr.text))
 }
 
 // --- Drawing Shape XML ---
@@ -449,33 +590,88 @@ func (w *PPTXWriter) writeDrawingShapeXML(s *DrawingShape, shapeID *int, slideNu
 	currentSlide := w.presentation.slides[slideNum-1]
 	relIdx := countRelIdxBefore(currentSlide.shapes, s)
 
-	shadowXML := ""
+	effectXML := ""
 	if s.shadow != nil && s.shadow.Visible {
-		shadowXML = fmt.Sprintf(`
-          <a:effectLst>
+		effectXML = fmt.Sprintf(`
             <a:outerShdw blurRad="%d" dist="%d" dir="%d" algn="bl" rotWithShape="0">
               <a:srgbClr val="%s">
                 <a:alpha val="%d"/>
               </a:srgbClr>
-            </a:outerShdw>
-          </a:effectLst>`,
+            </a:outerShdw>`,
 			s.shadow.BlurRadius*12700,
 			s.shadow.Distance*12700,
 			s.shadow.Direction*60000,
 			colorRGB(s.shadow.Color),
 			s.shadow.Alpha*1000)
 	}
+	if ref := s.reflection; ref != nil {
+		effectXML += fmt.Sprintf(`
+            <a:reflection blurRad="%d" stA="%d" stPos="%d" endA="%d" endPos="%d" dist="%d" dir="5400000" sy="%d" algn="bl"/>`,
+			ref.BlurRadius, ref.StartAlpha, ref.StartPosition, ref.EndAlpha, ref.EndPosition, ref.Distance, ref.ScaleY)
+	}
+	if s.softEdge > 0 {
+		effectXML += fmt.Sprintf(`
+            <a:softEdge rad="%d"/>`, s.softEdge)
+	}
+	shadowXML := ""
+	if effectXML != "" {
+		shadowXML = `
+          <a:effectLst>` + effectXML + `
+          </a:effectLst>`
+	}
+	lnXML := ""
+	if s.border != nil && s.border.Style == BorderNone {
+		lnXML = "\n          <a:ln><a:noFill/></a:ln>"
+	} else if b := w.writeBorderXML(s.border); b != "" {
+		lnXML = "\n" + strings.TrimSuffix(b, "\n")
+	}
+	geometry := s.geometry
+	if geometry == "" {
+		geometry = "rect"
+	}
+
+	// A linked picture without image data references its external rel.
+	blipAttr := "r:embed"
+	if s.data == nil && s.path == "" && s.externalURL != "" {
+		blipAttr = "r:link"
+	}
+
+	// Blip effects: the clrChange and duotone recolors apply before the
+	// alphaModFix fade.
+	blipXML := "/>"
+	if s.clrChange != nil || len(s.duotone) == 2 || s.grayscale || s.opacity() < 1 {
+		var sb strings.Builder
+		sb.WriteString(">")
+		if cc := s.clrChange; cc != nil {
+			useA := ""
+			if !cc.UseAlpha {
+				useA = ` useA="0"`
+			}
+			fmt.Fprintf(&sb, `<a:clrChange%s><a:clrFrom>%s</a:clrFrom><a:clrTo>%s</a:clrTo></a:clrChange>`,
+				useA, srgbClrXML(cc.From), srgbClrXML(cc.To))
+		}
+		if len(s.duotone) == 2 {
+			fmt.Fprintf(&sb, `<a:duotone><a:srgbClr val="%s"/><a:srgbClr val="%s"/></a:duotone>`,
+				colorRGB(s.duotone[0]), colorRGB(s.duotone[1]))
+		}
+		if s.grayscale {
+			sb.WriteString("<a:grayscl/>")
+		}
+		if s.opacity() < 1 {
+			fmt.Fprintf(&sb, `<a:alphaModFix amt="%d"/>`, maxInt(s.alpha, 0))
+		}
+		sb.WriteString("</a:blip>")
+		blipXML = sb.String()
+	}
 
 	return fmt.Sprintf(`      <p:pic>
         <p:nvPicPr>
           <p:cNvPr id="%d" name="%s" descr="%s"/>
-          <p:cNvPicPr>
-            <a:picLocks noChangeAspect="1"/>
-          </p:cNvPicPr>
+          %s
           <p:nvPr/>
         </p:nvPicPr>
         <p:blipFill>
-          <a:blip r:embed="rId%d"/>
+          <a:blip %s="rId%d"%s
           <a:stretch>
             <a:fillRect/>
           </a:stretch>
@@ -485,16 +681,16 @@ func (w *PPTXWriter) writeDrawingShapeXML(s *DrawingShape, shapeID *int, slideNu
             <a:off x="%d" y="%d"/>
             <a:ext cx="%d" cy="%d"/>
           </a:xfrm>
-          <a:prstGeom prst="rect">
+          <a:prstGeom prst="%s">
             <a:avLst/>
-          </a:prstGeom>%s
+          </a:prstGeom>%s%s
         </p:spPr>
       </p:pic>
-`, id, xmlEscape(name), xmlEscape(s.description),
-		relIdx,
+`, id, xmlEscape(name), xmlEscape(s.description), nvShapePropsXML("cNvPicPr", "picLocks", &s.BaseShape, &ShapeLocks{NoChangeAspect: true}),
+		blipAttr, relIdx, blipXML,
 		xfrmAttrs(&s.BaseShape),
 		s.offsetX, s.offsetY, s.width, s.height,
-		shadowXML)
+		xmlEscape(geometry), lnXML, shadowXML)
 }
 
 // --- Auto Shape XML ---
@@ -510,6 +706,10 @@ func (w *PPTXWriter) writeAutoShapeXML(s *AutoShape, shapeID *int) string {
 
 	fillXML := w.writeFillXML(s.GetFill())
 	borderXML := w.writeBorderXML(s.GetBorder())
+	spAttrs := ""
+	if s.fill != nil && s.fill.Type == FillBackground {
+		spAttrs = ` useBgFill="1"`
+	}
 
 	textXML := ""
 	if s.text != "" {
@@ -531,10 +731,10 @@ func (w *PPTXWriter) writeAutoShapeXML(s *AutoShape, shapeID *int) string {
 		descrAttr = fmt.Sprintf(` descr="%s"`, xmlEscape(s.description))
 	}
 
-	return fmt.Sprintf(`      <p:sp>
+	return fmt.Sprintf(`      <p:sp%s>
         <p:nvSpPr>
           <p:cNvPr id="%d" name="%s"%s/>
-          <p:cNvSpPr/>
+          %s
           <p:nvPr/>
         </p:nvSpPr>
         <p:spPr>
@@ -547,7 +747,7 @@ func (w *PPTXWriter) writeAutoShapeXML(s *AutoShape, shapeID *int) string {
           </a:prstGeom>
 %s%s        </p:spPr>%s
       </p:sp>
-`, id, xmlEscape(name), descrAttr,
+`, spAttrs, id, xmlEscape(name), descrAttr, nvShapePropsXML("cNvSpPr", "spLocks", &s.BaseShape, nil),
 		xfrmAttrs(&s.BaseShape),
 		s.offsetX, s.offsetY, s.width, s.height,
 		s.shapeType,
@@ -583,17 +783,20 @@ func (w *PPTXWriter) writeLineShapeXML(s *LineShape, shapeID *int) string {
 
 	// Build dash style XML
 	var dashXML string
-	switch s.lineStyle {
-	case BorderDash:
-		dashXML = "\n            <a:prstDash val=\"dash\"/>"
-	case BorderDot:
-		dashXML = "\n            <a:prstDash val=\"dot\"/>"
+	if prst := prstDashXML(s.lineStyle); prst != "" {
+		dashXML = "\n            " + prst
+	}
+	if len(s.dashPattern) > 0 {
+		dashXML = "\n            " + custDashXML(s.dashPattern)
+	}
+	if join := lineJoinXML(s.lineJoin, s.miterLimit); join != "" {
+		dashXML += "\n            " + join
 	}
 
 	return fmt.Sprintf(`      <p:cxnSp>
         <p:nvCxnSpPr>
           <p:cNvPr id="%d" name="%s"/>
-          <p:cNvCxnSpPr/>
+          %s
           <p:nvPr/>
         </p:nvCxnSpPr>
         <p:spPr>
@@ -611,7 +814,7 @@ func (w *PPTXWriter) writeLineShapeXML(s *LineShape, shapeID *int) string {
           </a:ln>
         </p:spPr>
       </p:cxnSp>
-`, id, xmlEscape(name),
+`, id, xmlEscape(name), nvShapePropsXML("cNvCxnSpPr", "cxnSpLocks", &s.BaseShape, nil),
 		xfrmAttrs(&s.BaseShape),
 		s.offsetX, s.offsetY, s.width, s.height,
 		prstGeom,
@@ -654,9 +857,8 @@ func (w *PPTXWriter) writeTableShapeXML(s *TableShape, shapeID *int) string {
 		for j := 0; j < s.numCols; j++ {
 			cell := s.rows[i][j]
 			cellFill := ""
-			if cell.fill != nil && cell.fill.Type == FillSolid {
-				cellFill = fmt.Sprintf(`
-                  <a:solidFill><a:srgbClr val="%s"/></a:solidFill>`, colorRGB(cell.fill.Color))
+			if fillXML := w.writeFillXML(cell.fill); fillXML != "" {
+				cellFill = "\n" + strings.TrimRight(fillXML, "\n")
 			}
 
 			var cellText strings.Builder
@@ -679,10 +881,10 @@ func (w *PPTXWriter) writeTableShapeXML(s *TableShape, shapeID *int) string {
                   <a:bodyPr/>
                   <a:lstStyle/>
 %s                </a:txBody>
-                <a:tcPr>%s
+                <a:tcPr%s>%s
                 </a:tcPr>
               </a:tc>
-`, cellText.String(), cellFill))
+`, cellText.String(), cellPrAttrs(cell), cellFill))
 		}
 		rowsXML.WriteString("            </a:tr>\n")
 	}
@@ -690,9 +892,7 @@ func (w *PPTXWriter) writeTableShapeXML(s *TableShape, shapeID *int) string {
 	return fmt.Sprintf(`      <p:graphicFrame>
         <p:nvGraphicFramePr>
           <p:cNvPr id="%d" name="%s"/>
-          <p:cNvGraphicFramePr>
-            <a:graphicFrameLocks noGrp="1"/>
-          </p:cNvGraphicFramePr>
+          %s
           <p:nvPr/>
         </p:nvGraphicFramePr>
         <p:xfrm>
@@ -702,16 +902,33 @@ func (w *PPTXWriter) writeTableShapeXML(s *TableShape, shapeID *int) string {
         <a:graphic>
           <a:graphicData uri="http://schemas.openxmlformats.org/drawingml/2006/table">
             <a:tbl>
-              <a:tblPr firstRow="1" bandRow="1"/>
+              <a:tblPr%s/>
               <a:tblGrid>
 %s              </a:tblGrid>
 %s            </a:tbl>
           </a:graphicData>
         </a:graphic>
       </p:graphicFrame>
-`, id, xmlEscape(name),
+`, id, xmlEscape(name), nvShapePropsXML("cNvGraphicFramePr", "graphicFrameLocks", &s.BaseShape, &ShapeLocks{NoGrp: true}),
 		s.offsetX, s.offsetY, s.width, s.height,
-		gridCols.String(), rowsXML.String())
+		tableLookAttrs(s.look), gridCols.String(), rowsXML.String())
+}
+
+// tableLookAttrs returns the flag attributes for <a:tblPr>.
+func tableLookAttrs(l TableLook) string {
+	var sb strings.Builder
+	for _, f := range []struct {
+		name string
+		on   bool
+	}{
+		{"firstRow", l.FirstRow}, {"firstCol", l.FirstCol}, {"lastRow", l.LastRow},
+		{"lastCol", l.LastCol}, {"bandRow", l.BandRow}, {"bandCol", l.BandCol},
+	} {
+		if f.on {
+			fmt.Fprintf(&sb, ` %s="1"`, f.name)
+		}
+	}
+	return sb.String()
 }
 
 // --- Fill and Border helpers ---
@@ -732,22 +949,89 @@ func (w *PPTXWriter) writeFillXML(f *Fill) string {
             <a:lin ang="%d" scaled="1"/>
           </a:gradFill>
 `, colorRGB(f.Color), colorRGB(f.EndColor), f.Rotation*60000)
+	case FillPattern:
+		return fmt.Sprintf("          <a:pattFill prst=\"%s\"><a:fgClr><a:srgbClr val=\"%s\"/></a:fgClr><a:bgClr><a:srgbClr val=\"%s\"/></a:bgClr></a:pattFill>\n",
+			f.Pattern, colorRGB(f.Color), colorRGB(f.EndColor))
 	default:
+		// Picture fills need an image part and are not written.
+		return ""
+	}
+}
+
+// nvShapePropsXML builds a shape's <p:cNv*Pr> element carrying its txBox flag
+// and editing locks. def is written when the shape has no locks of its own.
+func nvShapePropsXML(elem, locksTag string, b *BaseShape, def *ShapeLocks) string {
+	attrs := ""
+	if b.textBox && elem == "cNvSpPr" {
+		attrs = ` txBox="1"`
+	}
+	l := b.locks
+	if l == nil {
+		l = def
+	}
+	var locks strings.Builder
+	if l != nil {
+		for _, a := range l.attrs() {
+			if *a.v {
+				fmt.Fprintf(&locks, ` %s="1"`, a.name)
+			}
+		}
+	}
+	if locks.Len() == 0 {
+		return fmt.Sprintf("<p:%s%s/>", elem, attrs)
+	}
+	return fmt.Sprintf("<p:%s%s>\n            <a:%s%s/>\n          </p:%s>", elem, attrs, locksTag, locks.String(), elem)
+}
+
+// prstDashXML encodes a dashed line style as an <a:prstDash> element, or
+// returns "" for solid lines.
+func prstDashXML(style BorderStyle) string {
+	switch style {
+	case "", BorderNone, BorderSolid:
 		return ""
 	}
+	return fmt.Sprintf("<a:prstDash val=\"%s\"/>", style)
+}
+
+// custDashXML encodes a dash pattern (in multiples of the line width) as an
+// <a:custDash> element. An odd-length pattern is repeated to pair up gaps.
+func custDashXML(pattern []float64) string {
+	if len(pattern)%2 == 1 {
+		pattern = append(append([]float64{}, pattern...), pattern...)
+	}
+	var sb strings.Builder
+	sb.WriteString("<a:custDash>")
+	for i := 0; i < len(pattern); i += 2 {
+		fmt.Fprintf(&sb, "<a:ds d=\"%d\" sp=\"%d\"/>",
+			int(math.Round(pattern[i]*100000)), int(math.Round(pattern[i+1]*100000)))
+	}
+	sb.WriteString("</a:custDash>")
+	return sb.String()
+}
+
+// lineJoinXML encodes a line join, or returns "" if none is set.
+func lineJoinXML(join LineJoin, miterLimit int) string {
+	switch join {
+	case LineJoinRound, LineJoinBevel:
+		return "<a:" + string(join) + "/>"
+	case LineJoinMiter:
+		if miterLimit > 0 {
+			return fmt.Sprintf("<a:miter lim=\"%d\"/>", miterLimit)
+		}
+		return "<a:miter/>"
+	}
+	return ""
 }
 
 func (w *PPTXWriter) writeBorderXML(b *Border) string {
 	if b == nil || b.Style == BorderNone {
 		return ""
 	}
-	var dashXML string
-	switch b.Style {
-	case BorderDash:
-		dashXML = "<a:prstDash val=\"dash\"/>"
-	case BorderDot:
-		dashXML = "<a:prstDash val=\"dot\"/>"
+	dashXML := prstDashXML(b.Style)
+	if len(b.DashPattern) > 0 {
+		dashXML = custDashXML(b.DashPattern)
 	}
+	dashXML += lineJoinXML(b.Join, b.MiterLimit)
 	if dashXML != "" {
 		return fmt.Sprintf("          <a:ln w=\"%d\"><a:solidFill><a:srgbClr val=\"%s\"/></a:solidFill>%s</a:ln>\n",
 			b.Width, colorRGB(b.Color), dashXML)
@@ -762,43 +1046,47 @@ func (w *PPTXWriter) writeMedia(zw *zip.Writer) error {
 	imgIdx := 1
 	for _, slide := range w.presentation.slides {
 		for _, ds := range collectDrawingShapes(slide.shapes) {
-			if ds.data != nil {
-				ext := w.getImageExtension(ds)
-				fw, err := zw.Create(fmt.Sprintf("ppt/media/image%d.%s", imgIdx, ext))
-				if err != nil {
-					return err
-				}
-				if _, err := fw.Write(ds.data); err != nil {
-					return err
-				}
-				imgIdx++
-			} else if ds.path != "" {
-				info, err := os.Stat(ds.path)
-				if err != nil {
-					return fmt.Errorf("failed to stat image %s: %w", ds.path, err)
-				}
-				if info.Size() > maxImageFileSize {
-					return fmt.Errorf("image file %s too large: %d bytes (max %d)", ds.path, info.Size(), maxImageFileSize)
-				}
-				data, err := os.ReadFile(ds.path)
-				if err != nil {
-					return fmt.Errorf("failed to read image %s: %w", ds.path, err)
-				}
-				ext := w.getImageExtension(ds)
-				fw, err := zw.Create(fmt.Sprintf("ppt/media/image%d.%s", imgIdx, ext))
-				if err != nil {
-					return err
-				}
-				if _, err := fw.Write(data); err != nil {
-					return err
-				}
-				imgIdx++
+			data, err := w.mediaData(ds)
+			if err != nil {
+				return err
 			}
+			ext := w.getImageExtension(ds)
+			fw, err := zw.Create(fmt.Sprintf("ppt/media/image%d.%s", imgIdx, ext))
+			if err != nil {
+				return err
+			}
+			if _, err := fw.Write(data); err != nil {
+				return err
+			}
+			imgIdx++
 		}
 	}
 	return nil
 }
 
+// mediaData returns the image of a picture, reading it from its file if
+// it was added by path.
+func (w *PPTXWriter) mediaData(ds *DrawingShape) ([]byte, error) {
+	if ds.data != nil {
+		return ds.data, nil
+	}
+	if ds.path == "" && ds.mediaPart != "" {
+		return nil, fmt.Errorf("image %s was not loaded (ReaderOptions.SkipMedia)", ds.mediaPart)
+	}
+	info, err := os.Stat(ds.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat image %s: %w", ds.path, err)
+	}
+	if info.Size() > maxImageFileSize {
+		return nil, fmt.Errorf("image file %s too large: %d bytes (max %d)", ds.path, info.Size(), maxImageFileSize)
+	}
+	data, err := os.ReadFile(ds.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image %s: %w", ds.path, err)
+	}
+	return data, nil
+}
+
 func (w *PPTXWriter) getChartIndex(target *ChartShape) int {
 	idx := 1
 	for _, slide := range w.presentation.slides {
@@ -831,9 +1119,7 @@ func (w *PPTXWriter) writeChartShapeXML(s *ChartShape, shapeID *int, slideNum in
 	return fmt.Sprintf(`      <p:graphicFrame>
         <p:nvGraphicFramePr>
           <p:cNvPr id="%d" name="%s"/>
-          <p:cNvGraphicFramePr>
-            <a:graphicFrameLocks noGrp="1"/>
-          </p:cNvGraphicFramePr>
+          %s
           <p:nvPr/>
         </p:nvGraphicFramePr>
         <p:xfrm>
@@ -846,7 +1132,7 @@ func (w *PPTXWriter) writeChartShapeXML(s *ChartShape, shapeID *int, slideNum in
           </a:graphicData>
         </a:graphic>
       </p:graphicFrame>
-`, id, xmlEscape(name),
+`, id, xmlEscape(name), nvShapePropsXML("cNvGraphicFramePr", "graphicFrameLocks", &s.BaseShape, &ShapeLocks{NoGrp: true}),
 		s.offsetX, s.offsetY, s.width, s.height,
 		relIdx)
 }
@@ -883,7 +1169,7 @@ func (w *PPTXWriter) writeGroupShapeXML(g *GroupShape, shapeID *int, slideNum in
 	return fmt.Sprintf(`      <p:grpSp>
         <p:nvGrpSpPr>
           <p:cNvPr id="%d" name="%s"/>
-          <p:cNvGrpSpPr/>
+          %s
           <p:nvPr/>
         </p:nvGrpSpPr>
         <p:grpSpPr>
@@ -895,7 +1181,7 @@ func (w *PPTXWriter) writeGroupShapeXML(g *GroupShape, shapeID *int, slideNum in
           </a:xfrm>
         </p:grpSpPr>
 %s      </p:grpSp>
-`, id, xmlEscape(name),
+`, id, xmlEscape(name), nvShapePropsXML("cNvGrpSpPr", "grpSpLocks", &g.BaseShape, nil),
 		xfrmAttrs(&g.BaseShape),
 		g.offsetX, g.offsetY, g.width, g.height,
 		g.offsetX, g.offsetY, g.width, g.height,
@@ -918,12 +1204,22 @@ func (w *PPTXWriter) writePlaceholderShapeXML(s *PlaceholderShape, shapeID *int)
 		paragraphsXML.WriteString(w.writeParagraphXML(para))
 	}
 
+	// Geometry, fill and border are written only when set, so that the
+	// placeholder otherwise inherits them from the layout.
+	var spPrXML strings.Builder
+	if s.geometry != "" {
+		fmt.Fprintf(&spPrXML, `          <a:prstGeom prst="%s">
+            <a:avLst/>
+          </a:prstGeom>
+`, s.geometry)
+	}
+	spPrXML.WriteString(w.writeFillXML(s.fill))
+	spPrXML.WriteString(w.writeBorderXML(s.border))
+
 	return fmt.Sprintf(`      <p:sp>
         <p:nvSpPr>
           <p:cNvPr id="%d" name="%s"/>
-          <p:cNvSpPr>
-            <a:spLocks noGrp="1"/>
-          </p:cNvSpPr>
+          %s
           <p:nvPr>
             <p:ph type="%s" idx="%d"/>
           </p:nvPr>
@@ -933,17 +1229,17 @@ func (w *PPTXWriter) writePlaceholderShapeXML(s *PlaceholderShape, shapeID *int)
             <a:off x="%d" y="%d"/>
             <a:ext cx="%d" cy="%d"/>
           </a:xfrm>
-        </p:spPr>
+%s        </p:spPr>
         <p:txBody>
           <a:bodyPr/>
           <a:lstStyle/>
 %s        </p:txBody>
       </p:sp>
-`, id, xmlEscape(name),
+`, id, xmlEscape(name), nvShapePropsXML("cNvSpPr", "spLocks", &s.BaseShape, &ShapeLocks{NoGrp: true}),
 		s.phType, s.phIdx,
 		xfrmAttrs(&s.BaseShape),
 		s.offsetX, s.offsetY, s.width, s.height,
-		paragraphsXML.String())
+		spPrXML.String(), paragraphsXML.String())
 }
 
 // --- Notes Slide ---
@@ -1037,3 +1333,26 @@ func (w *PPTXWriter) writeBulletXML(b *Bullet) string {
 
 	return sb.String()
 }
+
+// cellPrAttrs returns the tcPr attributes for the margins and anchor of a
+// cell that differ from the defaults.
+func cellPrAttrs(cell *TableCell) string {
+	var b strings.Builder
+	for _, m := range []struct {
+		name    string
+		v, dflt int64
+	}{
+		{"marL", cell.marginLeft, defaultCellMarginLR},
+		{"marR", cell.marginRight, defaultCellMarginLR},
+		{"marT", cell.marginTop, defaultCellMarginTB},
+		{"marB", cell.marginBottom, defaultCellMarginTB},
+	} {
+		if m.v != m.dflt {
+			fmt.Fprintf(&b, ` %s="%d"`, m.name, m.v)
+		}
+	}
+	if cell.anchor != TextAnchorNone {
+		fmt.Fprintf(&b, ` anchor="%s"`, cell.anchor)
+	}
+	return b.String()
+}
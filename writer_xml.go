@@ -21,6 +21,7 @@ const (
 	nsCoreProperties   = "http://schemas.openxmlformats.org/package/2006/metadata/core-properties"
 	nsExtProperties    = "http://schemas.openxmlformats.org/officeDocument/2006/extended-properties"
 	nsXSI              = "http://www.w3.org/2001/XMLSchema-instance"
+	nsMarkupCompat     = "http://schemas.openxmlformats.org/markup-compatibility/2006"
 
 	relTypeSlide       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide"
 	relTypeSlideMaster = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/slideMaster"
@@ -39,6 +40,7 @@ const (
 	relTypeCommentAuth = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/commentAuthors"
 	relTypeNotesSlide  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/notesSlide"
 	relTypeNotesMaster = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/notesMaster"
+	relTypeHandoutMaster = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/handoutMaster"
 
 	ctPresentation     = "application/vnd.openxmlformats-officedocument.presentationml.presentation.main+xml"
 	ctSlide            = "application/vnd.openxmlformats-officedocument.presentationml.slide+xml"
@@ -351,6 +353,21 @@ func (w *PPTXWriter) writeAppProperties(zw *zip.Writer) error {
 
 func (w *PPTXWriter) writeCoreProperties(zw *zip.Writer) error {
 	props := w.presentation.properties
+	// Status, language and the dates are left out when unset, as in files read
+	// without them.
+	var optional strings.Builder
+	if props.Status != "" {
+		fmt.Fprintf(&optional, "  <cp:contentStatus>%s</cp:contentStatus>\n", xmlEscape(props.Status))
+	}
+	if props.Language != "" {
+		fmt.Fprintf(&optional, "  <dc:language>%s</dc:language>\n", xmlEscape(props.Language))
+	}
+	if !props.Created.IsZero() {
+		fmt.Fprintf(&optional, "  <dcterms:created xsi:type=\"dcterms:W3CDTF\">%s</dcterms:created>\n", props.Created.UTC().Format("2006-01-02T15:04:05Z"))
+	}
+	if !props.Modified.IsZero() {
+		fmt.Fprintf(&optional, "  <dcterms:modified xsi:type=\"dcterms:W3CDTF\">%s</dcterms:modified>\n", props.Modified.UTC().Format("2006-01-02T15:04:05Z"))
+	}
 	content := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
 <cp:coreProperties xmlns:cp="%s" xmlns:dc="%s" xmlns:dcterms="%s" xmlns:xsi="%s">
   <dc:creator>%s</dc:creator>
@@ -361,9 +378,7 @@ func (w *PPTXWriter) writeCoreProperties(zw *zip.Writer) error {
   <cp:keywords>%s</cp:keywords>
   <cp:category>%s</cp:category>
   <cp:revision>%s</cp:revision>
-  <dcterms:created xsi:type="dcterms:W3CDTF">%s</dcterms:created>
-  <dcterms:modified xsi:type="dcterms:W3CDTF">%s</dcterms:modified>
-</cp:coreProperties>`,
+%s</cp:coreProperties>`,
 		nsCoreProperties, nsDC, nsDCTerms, nsXSI,
 		xmlEscape(props.Creator),
 		xmlEscape(props.LastModifiedBy),
@@ -373,8 +388,7 @@ func (w *PPTXWriter) writeCoreProperties(zw *zip.Writer) error {
 		xmlEscape(props.Keywords),
 		xmlEscape(props.Category),
 		xmlEscape(props.Revision),
-		props.Created.UTC().Format("2006-01-02T15:04:05Z"),
-		props.Modified.UTC().Format("2006-01-02T15:04:05Z"),
+		optional.String(),
 	)
 	return writeRawXMLToZip(zw, "docProps/core.xml", content)
 }
@@ -400,3 +414,12 @@ func colorRGB(c Color) string {
 	}
 	return "000000"
 }
+
+// srgbClrXML returns an <a:srgbClr> element for c, with an <a:alpha> child
+// when c is not fully opaque.
+func srgbClrXML(c Color) string {
+	if a := c.GetAlpha(); len(c.ARGB) == 8 && a < 255 {
+		return fmt.Sprintf(`<a:srgbClr val="%s"><a:alpha val="%d"/></a:srgbClr>`, colorRGB(c), int(a)*100000/255)
+	}
+	return fmt.Sprintf(`<a:srgbClr val="%s"/>`, colorRGB(c))
+}